@@ -16,11 +16,54 @@ from .jsonl import JsonlAnalyzer
 from .gdscript import GDScriptAnalyzer
 from .jupyter_analyzer import JupyterAnalyzer
 from .javascript import JavaScriptAnalyzer
-from .typescript import TypeScriptAnalyzer
+from .typescript import TypeScriptAnalyzer, TSXAnalyzer
 from .bash import BashAnalyzer
 from .nginx import NginxAnalyzer
 from .toml import TomlAnalyzer
 from .dockerfile import DockerfileAnalyzer
+from .java import JavaAnalyzer
+from .kotlin import KotlinAnalyzer
+from .c_cpp import CAnalyzer, CppAnalyzer
+from .csharp import CSharpAnalyzer
+from .ruby import RubyAnalyzer
+from .php import PHPAnalyzer
+from .swift import SwiftAnalyzer
+from .scala import ScalaAnalyzer
+from .sql import SQLAnalyzer
+from .protobuf import ProtobufAnalyzer
+from .terraform import TerraformAnalyzer
+from .makefile import MakefileAnalyzer
+from .cmake import CMakeAnalyzer
+from .html import HTMLAnalyzer
+from .css import CSSAnalyzer, SCSSAnalyzer
+from .vue_svelte import VueAnalyzer, SvelteAnalyzer
+from .elixir import ElixirAnalyzer
+from .haskell import HaskellAnalyzer
+from .lua import LuaAnalyzer
+from .zig import ZigAnalyzer
+from .dart import DartAnalyzer
+from .objc import ObjCAnalyzer
+from .lisp import LispAnalyzer
+from .ml import OCamlAnalyzer, FSharpAnalyzer
+from .gradle import GradleAnalyzer
+from .solidity import SolidityAnalyzer
+from .erlang import ErlangAnalyzer
+from .perl import PerlAnalyzer
+from .r import RAnalyzer
+from .julia import JuliaAnalyzer
+from .powershell import PowerShellAnalyzer
+from .fortran import FortranAnalyzer
+from .hdl import VerilogAnalyzer, VHDLAnalyzer
+from .bazel import BazelAnalyzer
+from .thrift import ThriftAnalyzer
+from .avro import AvroSchemaAnalyzer, AvroIDLAnalyzer
+from .latex import LaTeXAnalyzer
+from .assembly import AssemblyAnalyzer
+from .xml import XmlAnalyzer
+from .csv_tsv import CsvAnalyzer
+from .ini import IniAnalyzer
+from .nim import NimAnalyzer
+from .crystal import CrystalAnalyzer
 
 __all__ = [
     'PythonAnalyzer',
@@ -34,8 +77,58 @@ __all__ = [
     'JupyterAnalyzer',
     'JavaScriptAnalyzer',
     'TypeScriptAnalyzer',
+    'TSXAnalyzer',
     'BashAnalyzer',
     'NginxAnalyzer',
     'TomlAnalyzer',
     'DockerfileAnalyzer',
+    'JavaAnalyzer',
+    'KotlinAnalyzer',
+    'CAnalyzer',
+    'CppAnalyzer',
+    'CSharpAnalyzer',
+    'RubyAnalyzer',
+    'PHPAnalyzer',
+    'SwiftAnalyzer',
+    'ScalaAnalyzer',
+    'SQLAnalyzer',
+    'ProtobufAnalyzer',
+    'TerraformAnalyzer',
+    'MakefileAnalyzer',
+    'CMakeAnalyzer',
+    'HTMLAnalyzer',
+    'CSSAnalyzer',
+    'SCSSAnalyzer',
+    'VueAnalyzer',
+    'SvelteAnalyzer',
+    'ElixirAnalyzer',
+    'HaskellAnalyzer',
+    'LuaAnalyzer',
+    'ZigAnalyzer',
+    'DartAnalyzer',
+    'ObjCAnalyzer',
+    'LispAnalyzer',
+    'OCamlAnalyzer',
+    'FSharpAnalyzer',
+    'GradleAnalyzer',
+    'SolidityAnalyzer',
+    'ErlangAnalyzer',
+    'PerlAnalyzer',
+    'RAnalyzer',
+    'JuliaAnalyzer',
+    'PowerShellAnalyzer',
+    'FortranAnalyzer',
+    'VerilogAnalyzer',
+    'VHDLAnalyzer',
+    'BazelAnalyzer',
+    'ThriftAnalyzer',
+    'AvroSchemaAnalyzer',
+    'AvroIDLAnalyzer',
+    'LaTeXAnalyzer',
+    'AssemblyAnalyzer',
+    'XmlAnalyzer',
+    'CsvAnalyzer',
+    'IniAnalyzer',
+    'NimAnalyzer',
+    'CrystalAnalyzer',
 ]
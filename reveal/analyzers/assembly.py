@@ -0,0 +1,251 @@
+"""Assembly file analyzer (GAS, NASM and MASM syntax)."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+@register('.s', '.asm', '.S', '.nasm', '.inc', name='Assembly', icon='')
+class AssemblyAnalyzer(FileAnalyzer):
+    """Assembly file analyzer.
+
+    Extracts:
+    - Sections (.text/.data/.section, NASM `section`, MASM `.code`) with
+      their extent
+    - Global and external symbols (.globl/.global/.extern, NASM
+      global/extern, MASM PUBLIC/EXTERN/EXTRN)
+    - Labels; each spans until the next label, local labels (.L*, @@,
+      numeric) are folded into their parent. MASM PROC/ENDP procedures
+      are labels too. Labels typed with `.type x, @function` are
+      marked as functions
+    - Macros (.macro/.endm, %macro/%endmacro, MASM MACRO/ENDM) with
+      their parameters
+    - Constants (.equ/.set, `x equ ...`, %define, #define) and includes
+
+    Works with GAS (AT&T or Intel), NASM and MASM conventions; `;`, `//`
+    and `/* */` comments are ignored, as are `#`/`@` comments at the
+    start of a line.
+    """
+
+    SECTION = re.compile(
+        r'^\s*(?:\.section\s+(?P<named>[\w.$]+)|(?P<short>\.(?:text|data|bss|rodata))\b'
+        r'|(?:section|segment)\s+(?P<nasm>[\w.$]+)|(?P<masm>\.(?:code|data\??|const|stack))\b'
+        r'|(?P<masm_segment>\w+)\s+SEGMENT\b)',
+        re.IGNORECASE
+    )
+    GLOBAL = re.compile(r'^\s*(?:\.globa?l|global|public)\s+(?P<names>[\w.$@?,:\s]+)', re.IGNORECASE)
+    EXTERN = re.compile(r'^\s*(?:\.extern|extern|extrn)\s+(?P<names>[\w.$@?,:\s]+)', re.IGNORECASE)
+    TYPE = re.compile(r'^\s*\.type\s+(?P<name>[\w.$]+)\s*,\s*[@%#]?(?P<type>function|object)', re.IGNORECASE)
+    LABEL = re.compile(r'^(?P<name>[A-Za-z_.$@?][\w.$@?]*|\d+)\s*:(?!:|=)')
+    PROC = re.compile(r'^\s*(?P<name>[A-Za-z_$@?][\w$@?]*)\s+PROC\b(?P<rest>.*)$', re.IGNORECASE)
+    ENDP = re.compile(r'^\s*(?P<name>[A-Za-z_$@?][\w$@?]*)\s+ENDP\b', re.IGNORECASE)
+    MACRO = re.compile(
+        r'^\s*(?:\.macro\s+(?P<gas>[\w.$]+)(?P<gas_params>.*)|%i?macro\s+(?P<nasm>[\w.$]+)(?P<nasm_params>.*)'
+        r'|(?P<masm>[A-Za-z_$@?][\w$@?]*)\s+MACRO\b(?P<masm_params>.*))$',
+        re.IGNORECASE
+    )
+    MACRO_END = re.compile(r'^\s*(?:\.endm\b|\.endmacro\b|%endmacro\b|ENDM\b)', re.IGNORECASE)
+    CONSTANT = re.compile(
+        r'^\s*(?:\.(?:equ|set|equiv)\s+(?P<gas>[\w.$]+)\s*,\s*(?P<gas_value>.+)'
+        r'|(?P<nasm>[A-Za-z_.$@?][\w.$@?]*):?\s+(?:equ|=)\s+(?P<nasm_value>.+)'
+        r'|[%#]define\s+(?P<define>\w+)(?:\s+(?P<define_value>.+))?)$',
+        re.IGNORECASE
+    )
+    INCLUDE = re.compile(r'''^\s*(?P<content>(?:\.include|%include|#include|include)\s+["'<]?[^"'>\s]+["'>]?)''',
+                         re.IGNORECASE)
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract sections, symbols, labels, macros and constants."""
+        structure = {
+            'includes': [],
+            'sections': [],
+            'globals': [],
+            'externs': [],
+            'constants': [],
+            'macros': [],
+            'labels': [],
+        }
+        symbol_types = {}
+        macro = None
+        code = self._code_lines()
+
+        for i, line in enumerate(code, 1):
+            if not line.strip():
+                continue
+
+            if macro is not None:
+                if self.MACRO_END.match(line):
+                    macro['line_end'] = i
+                    macro = None
+                continue
+
+            match = self.MACRO.match(line)
+            if match:
+                name = match.group('gas') or match.group('nasm') or match.group('masm')
+                params = (match.group('gas_params') or match.group('nasm_params')
+                          or match.group('masm_params') or '').strip()
+                macro = {'line': i, 'name': name}
+                if params:
+                    macro['params'] = [p.strip() for p in re.split(r'[,\s]+', params) if p.strip()]
+                    macro['signature'] = f"({', '.join(macro['params'])})"
+                structure['macros'].append(macro)
+                continue
+
+            match = self.INCLUDE.match(line)
+            if match:
+                structure['includes'].append({'line': i, 'content': ' '.join(match.group('content').split())})
+                continue
+
+            match = self.SECTION.match(line)
+            if match:
+                name = next(g for g in match.groups() if g)
+                structure['sections'].append({'line': i, 'name': name})
+                continue
+
+            for pattern, category in ((self.GLOBAL, 'globals'), (self.EXTERN, 'externs')):
+                match = pattern.match(line)
+                if match:
+                    for name in re.split(r'[,\s]+', match.group('names').strip()):
+                        name = name.split(':', 1)[0]
+                        if name:
+                            structure[category].append({'line': i, 'name': name})
+                    break
+            else:
+                match = self.TYPE.match(line)
+                if match:
+                    symbol_types[match.group('name')] = match.group('type').lower()
+                    continue
+
+                match = self.CONSTANT.match(line)
+                if match:
+                    name = match.group('gas') or match.group('nasm') or match.group('define')
+                    value = match.group('gas_value') or match.group('nasm_value') or match.group('define_value')
+                    entry = {'line': i, 'name': name}
+                    if value:
+                        entry['value'] = value.strip()
+                        entry['signature'] = f" = {entry['value']}"
+                    structure['constants'].append(entry)
+                    continue
+
+                match = self.PROC.match(line)
+                if match:
+                    structure['labels'].append({'line': i, 'name': match.group('name'), 'kind': 'proc'})
+                    continue
+
+                match = self.ENDP.match(line)
+                if match:
+                    proc = next((l for l in reversed(structure['labels'])
+                                 if l['name'] == match.group('name') and l.get('kind') == 'proc'), None)
+                    if proc:
+                        proc['line_end'] = i
+                    continue
+
+                match = self.LABEL.match(line)
+                if match and not self._is_local(match.group('name')):
+                    structure['labels'].append({'line': i, 'name': match.group('name')})
+
+        self._set_extents(structure, code, symbol_types)
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _set_extents(self, structure: Dict[str, List[Dict[str, Any]]], code: List[str],
+                     symbol_types: Dict[str, str]):
+        """Sections run until the next section, labels until the next label or section."""
+        global_names = {g['name'] for g in structure['globals']}
+
+        section_lines = [s['line'] for s in structure['sections']]
+        for category, boundaries in (('sections', section_lines),
+                                     ('labels', section_lines + [l['line'] for l in structure['labels']])):
+            for item in structure[category]:
+                if 'line_end' in item:
+                    continue
+                following = min((b for b in boundaries if b > item['line']), default=len(code) + 1) - 1
+                line_end = self._last_code_line(code, item['line'], following)
+                if line_end > item['line']:
+                    item['line_end'] = line_end
+
+        for label in structure['labels']:
+            if label['name'] in global_names:
+                label['visibility'] = 'global'
+            kind = symbol_types.get(label['name'], label.get('kind'))
+            if kind:
+                label['kind'] = kind
+                label['signature'] = f"  ({kind})"
+            if 'line_end' in label:
+                label['line_count'] = label['line_end'] - label['line'] + 1
+
+    @staticmethod
+    def _is_local(name: str) -> bool:
+        """.L1, .Lfunc_end0, @@, 1: - assembler-local labels."""
+        return name.isdigit() or name.startswith(('.L', '@@', 'L$')) or name == '@@'
+
+    @staticmethod
+    def _last_code_line(code: List[str], start: int, end: int) -> int:
+        """Last non-blank line in [start, end] (1-indexed)."""
+        for i in range(end, start, -1):
+            if code[i - 1].strip():
+                return i
+        return start
+
+    def _code_lines(self) -> List[str]:
+        """Lines with comments removed (strings kept)."""
+        code = []
+        in_comment = False
+
+        for line in self.lines:
+            if in_comment:
+                if '*/' not in line:
+                    code.append('')
+                    continue
+                line = line.split('*/', 1)[1]
+                in_comment = False
+            line = re.sub(r'/\*.*?\*/', ' ', line)
+            if '/*' in line:
+                line, in_comment = line.split('/*', 1)[0], True
+
+            stripped = line.lstrip()
+            if stripped.startswith(('#', '@', '!')) and not re.match(
+                    r'#\s*(?:include|define|if|else|endif|undef)\b', stripped):
+                code.append('')
+                continue
+            code.append(self._strip_comment(line))
+
+        return code
+
+    @staticmethod
+    def _strip_comment(line: str) -> str:
+        """Remove ; and // comments outside string literals."""
+        quote = None
+        for i, char in enumerate(line):
+            if quote:
+                if char == quote:
+                    quote = None
+            elif char in '"\'':
+                quote = char
+            elif char == ';' or line.startswith('//', i):
+                return line[:i].rstrip()
+        return line.rstrip()
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a label (with its body), macro or section."""
+        structure = self.get_structure()
+
+        for category in ('labels', 'macros', 'sections'):
+            for item in structure.get(category, []):
+                if item['name'] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': name,
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
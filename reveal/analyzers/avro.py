@@ -0,0 +1,289 @@
+"""Apache Avro schema (.avsc) and IDL (.avdl) analyzers."""
+
+import json
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+from ..regex_analyzer import RegexAnalyzer
+
+
+# Fields shown inline before eliding
+MAX_INLINE_FIELDS = 8
+
+
+def _inline(items) -> str:
+    """Format ' { a: int, b: string, ... }' for display."""
+    items = list(items)
+    if not items:
+        return ' {}'
+    shown = ', '.join(items[:MAX_INLINE_FIELDS])
+    if len(items) > MAX_INLINE_FIELDS:
+        shown += f", ... +{len(items) - MAX_INLINE_FIELDS}"
+    return f" {{ {shown} }}"
+
+
+@register('.avsc', name='Avro Schema', icon='')
+class AvroSchemaAnalyzer(FileAnalyzer):
+    """Avro JSON schema analyzer.
+
+    Extracts every named type - records (and errors), enums and fixed -
+    including ones nested in field types, unions, arrays and maps.
+    Records list their fields with types; each type records its
+    (possibly inherited) namespace.
+    """
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract records, enums and fixed types."""
+        structure = {'records': [], 'enums': [], 'fixed': []}
+        try:
+            schema = json.loads(self.content)
+        except json.JSONDecodeError:
+            return {}
+
+        self._spans = self._object_spans()
+        self._cursor = 0
+        self._walk(schema, None, structure)
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _walk(self, schema: Any, namespace: Optional[str], structure: Dict[str, List[Dict[str, Any]]]):
+        """Visit named types depth-first, in document order."""
+        if isinstance(schema, list):
+            for item in schema:
+                self._walk(item, namespace, structure)
+            return
+        if not isinstance(schema, dict):
+            return
+
+        kind = schema.get('type')
+        if isinstance(kind, (dict, list)):
+            self._walk(kind, namespace, structure)
+            return
+        if kind == 'array':
+            self._walk(schema.get('items'), namespace, structure)
+            return
+        if kind == 'map':
+            self._walk(schema.get('values'), namespace, structure)
+            return
+        if kind not in ('record', 'error', 'enum', 'fixed') or not isinstance(schema.get('name'), str):
+            return
+
+        name = schema['name']
+        namespace = schema.get('namespace', namespace) if '.' not in name else name.rsplit('.', 1)[0]
+        entry = {'name': name.rsplit('.', 1)[-1]}
+        entry.update(self._locate(name))
+        if namespace:
+            entry['namespace'] = namespace
+        if schema.get('doc'):
+            entry['doc'] = schema['doc']
+
+        if kind in ('record', 'error'):
+            fields = [f for f in schema.get('fields', []) if isinstance(f, dict)]
+            entry['fields'] = [{'name': f.get('name'), 'type': self._type_name(f.get('type'))} for f in fields]
+            signature = _inline(f"{f['name']}: {f['type']}" for f in entry['fields'])
+            if kind == 'error':
+                entry['kind'] = 'error'
+                signature += '  (error)'
+            entry['signature'] = signature
+            structure['records'].append(entry)
+            for field in fields:
+                self._walk(field.get('type'), namespace, structure)
+        elif kind == 'enum':
+            entry['symbols'] = list(schema.get('symbols', []))
+            entry['signature'] = _inline(entry['symbols'])
+            structure['enums'].append(entry)
+        else:
+            entry['size'] = schema.get('size')
+            entry['signature'] = f" ({entry['size']} bytes)"
+            structure['fixed'].append(entry)
+
+    def _type_name(self, schema: Any) -> str:
+        """Short type description: long, array<string>, null|User."""
+        if isinstance(schema, str):
+            return schema.rsplit('.', 1)[-1]
+        if isinstance(schema, list):
+            return '|'.join(self._type_name(s) for s in schema)
+        if isinstance(schema, dict):
+            kind = schema.get('type')
+            if kind == 'array':
+                return f"array<{self._type_name(schema.get('items'))}>"
+            if kind == 'map':
+                return f"map<{self._type_name(schema.get('values'))}>"
+            if schema.get('name'):
+                return str(schema['name']).rsplit('.', 1)[-1]
+            if schema.get('logicalType'):
+                return f"{schema['logicalType']}"
+            return self._type_name(kind)
+        return '?'
+
+    def _locate(self, name: str) -> Dict[str, int]:
+        """line/line_end of the object declaring `"name": name` (after the previous one)."""
+        pattern = re.compile(r'"name"\s*:\s*"' + re.escape(name) + '"')
+        match = pattern.search(self.content, self._cursor) or pattern.search(self.content)
+        if not match:
+            return {'line': 1}
+        self._cursor = match.end()
+        enclosing = [s for s in self._spans if s[0] < match.start() < s[1]]
+        start, end = max(enclosing) if enclosing else (match.start(), match.end())
+        location = {'line': self.content.count('\n', 0, start) + 1}
+        line_end = self.content.count('\n', 0, end) + 1
+        if line_end > location['line']:
+            location['line_end'] = line_end
+        return location
+
+    def _object_spans(self) -> List[Tuple[int, int]]:
+        """(start, end) offsets of every JSON object in the file."""
+        spans = []
+        stack = []
+        in_string = False
+        escaped = False
+        for i, char in enumerate(self.content):
+            if in_string:
+                if escaped:
+                    escaped = False
+                elif char == '\\':
+                    escaped = True
+                elif char == '"':
+                    in_string = False
+            elif char == '"':
+                in_string = True
+            elif char == '{':
+                stack.append(i)
+            elif char == '}' and stack:
+                spans.append((stack.pop(), i))
+        return spans
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a named type's JSON by (short or qualified) name."""
+        for items in self.get_structure().values():
+            for item in items:
+                qualified = f"{item['namespace']}.{item['name']}" if item.get('namespace') else item['name']
+                if name in (item['name'], qualified):
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item['name'],
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
+
+
+@register('.avdl', name='Avro IDL', icon='')
+class AvroIDLAnalyzer(RegexAnalyzer):
+    """Avro IDL analyzer.
+
+    Extracts:
+    - Imports (idl, protocol and schema files)
+    - Protocols
+    - Records and errors with their fields, enums with symbols, fixed types
+    - Protocol messages (named Protocol.message) with parameters, return
+      type, throws and oneway
+    """
+
+    comment_prefixes = ('//', '/*', '*')
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>import\s+(?:idl|protocol|schema)\s+"[^"]+")'),
+        'protocols': re.compile(r'^\s*(?:@\w+\(.*?\)\s*)*protocol\s+(?P<name>\w+)'),
+        'records': re.compile(r'^\s*(?:@\w+\(.*?\)\s*)*(?P<kind>record|error)\s+(?P<name>\w+)'),
+        'enums': re.compile(r'^\s*(?:@\w+\(.*?\)\s*)*enum\s+(?P<name>\w+)'),
+        'fixed': re.compile(r'^\s*(?:@\w+\(.*?\)\s*)*fixed\s+(?P<name>\w+)\s*\(\s*(?P<size>\d+)\s*\)'),
+        'messages': re.compile(
+            r'^\s*(?:@\w+\(.*?\)\s*)*(?P<returns>union\s*\{[^}]*\}|[\w.]+(?:<.*?>)?\??)\s+`?(?P<name>\w+)`?\s*\('
+            r'(?P<params>[^)]*)\)\s*(?P<rest>[^;]*);'
+        ),
+    }
+
+    FIELD = re.compile(
+        r'^\s*(?:@\w+\(.*?\)\s*)*(?P<type>union\s*\{[^}]*\}|[\w.]+(?:<.*?>)?\??)\s+(?:@\w+\(.*?\)\s*)*'
+        r'`?(?P<name>\w+)`?\s*(?:=[^;]*)?;'
+    )
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; messages only come from protocol bodies."""
+        self._depths = self._line_depths()
+        return super().get_structure(head=head, tail=tail, range=range, **kwargs)
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Attach fields, symbols and message signatures."""
+        if category == 'messages':
+            return self._message_entry(match, line_no)
+
+        entry = super()._make_entry(category, match, line_no)
+        line_end = entry.get('line_end', line_no)
+
+        if category == 'records':
+            entry['fields'] = []
+            for i in range(line_no, line_end - 1):
+                field = self.FIELD.match(self.lines[i])
+                if field and self._depths[i] == self._depths[line_no - 1] + 1:
+                    entry['fields'].append({'name': field.group('name'), 'type': self._type_name(field.group('type'))})
+            signature = _inline(f"{f['name']}: {f['type']}" for f in entry['fields'])
+            if entry['kind'] == 'error':
+                signature += '  (error)'
+            else:
+                entry.pop('kind')
+            entry['signature'] = signature
+        elif category == 'enums':
+            body = ' '.join(self.lines[line_no - 1:line_end])
+            symbols = re.search(r'\{([^}]*)\}', body)
+            entry['symbols'] = re.findall(r'\w+', symbols.group(1)) if symbols else []
+            entry['signature'] = _inline(entry['symbols'])
+        elif category == 'fixed':
+            entry['signature'] = f" ({entry['size']} bytes)"
+
+        return entry
+
+    def _message_entry(self, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """`Ret name(T a, U b) throws E;` / `void ping() oneway;` inside a protocol."""
+        protocol = self._enclosing_protocol(line_no)
+        if protocol is None:
+            return None
+
+        params = []
+        for param in re.split(r',(?![^{<]*[}>])', match.group('params')):
+            words = param.split('=', 1)[0].split()
+            if len(words) >= 2:
+                params.append(f"{self._type_name(' '.join(words[:-1]))} {words[-1]}")
+        returns = self._type_name(match.group('returns'))
+        entry = {'line': line_no, 'name': f"{protocol}.{match.group('name')}", 'protocol': protocol, 'returns': returns}
+        signature = f"({', '.join(params)}) -> {returns}"
+
+        rest = match.group('rest')
+        throws = re.search(r'\bthrows\s+([\w.,\s]+)', rest)
+        if throws:
+            entry['throws'] = [t.strip() for t in throws.group(1).split(',') if t.strip()]
+            signature += f" throws {', '.join(entry['throws'])}"
+        if re.search(r'\boneway\b', rest):
+            entry['oneway'] = True
+            signature += '  (oneway)'
+        entry['signature'] = signature
+        return entry
+
+    def _enclosing_protocol(self, line_no: int) -> Optional[str]:
+        """Name of the protocol whose body directly contains line_no."""
+        if self._depths[line_no - 1] != 1:
+            return None
+        for i in range(line_no - 2, -1, -1):
+            if self._depths[i] == 0:
+                opener = self.patterns['protocols'].match(self.lines[i])
+                return opener.group('name') if opener else None
+        return None
+
+    @staticmethod
+    def _type_name(text: str) -> str:
+        """'union { null, string }' -> 'null|string'."""
+        union = re.match(r'^union\s*\{([^}]*)\}$', text.strip())
+        if union:
+            return '|'.join(t.strip() for t in union.group(1).split(','))
+        return ' '.join(text.split())
@@ -1,20 +1,22 @@
 """Bash/Shell script analyzer - tree-sitter based."""
 
-from typing import Optional
+from typing import Dict, List, Any, Optional
 from ..base import register
 from ..treesitter import TreeSitterAnalyzer
 
 
 @register('.sh', name='Shell Script', icon='')
 @register('.bash', name='Bash Script', icon='')
+@register('.zsh', '.ksh', name='Shell Script', icon='')
 class BashAnalyzer(TreeSitterAnalyzer):
     """Bash/Shell script analyzer.
 
     Full shell script support via tree-sitter!
     Extracts:
     - Function definitions
-    - Variable assignments
-    - Command invocations
+    - Sourced files (source / .)
+    - Exported environment variables
+    - Top-level command sections (grouped under `# comment` headings)
 
     Cross-platform compatible:
     - Analyzes bash scripts on any OS (Windows/Linux/macOS)
@@ -26,6 +28,146 @@ class BashAnalyzer(TreeSitterAnalyzer):
     """
     language = 'bash'
 
+    # Top-level statements that don't count as commands in a section
+    NON_COMMAND_TYPES = ('comment', 'function_definition', 'variable_assignment',
+                         'declaration_command')
+
+    # Compound statements, shown by their keyword
+    COMPOUND_TYPES = {
+        'if_statement': 'if', 'for_statement': 'for', 'c_style_for_statement': 'for',
+        'while_statement': 'while', 'case_statement': 'case', 'subshell': '( )',
+        'compound_statement': '{ }',
+    }
+
+    # Commands listed per section before eliding
+    MAX_SECTION_COMMANDS = 5
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract sourced files (`source lib.sh` / `. lib.sh`)."""
+        imports = []
+
+        for node in self._find_nodes_by_type('command'):
+            if self._command_name(node) in ('source', '.'):
+                imports.append({
+                    'line': node.start_point[0] + 1,
+                    'content': self._get_node_text(node),
+                })
+
+        return imports
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract exported variables and top-level command sections."""
+        return {
+            'exports': self._extract_exports(),
+            'sections': self._extract_sections(),
+        }
+
+    def _extract_exports(self) -> List[Dict[str, Any]]:
+        """Extract `export NAME[=value]` declarations."""
+        exports = []
+
+        for node in self._find_nodes_by_type('declaration_command'):
+            if not self._get_node_text(node).startswith('export'):
+                continue
+
+            for child in node.children:
+                if child.type == 'variable_assignment':
+                    name_node = child.child_by_field_name('name')
+                    value_node = child.child_by_field_name('value')
+                    entry = {
+                        'line': node.start_point[0] + 1,
+                        'name': self._get_node_text(name_node) if name_node else self._get_node_text(child),
+                    }
+                    if value_node is not None:
+                        entry['signature'] = f"={self._get_node_text(value_node)}"
+                    exports.append(entry)
+                elif child.type in ('variable_name', 'word'):
+                    exports.append({
+                        'line': node.start_point[0] + 1,
+                        'name': self._get_node_text(child),
+                    })
+
+        return exports
+
+    def _extract_sections(self) -> List[Dict[str, Any]]:
+        """Group top-level commands under the comment heading preceding them.
+
+        A heading is a top-level comment that starts a comment block.
+        Commands before the first heading form a '(script start)' section.
+        Sections holding only functions or assignments are omitted.
+        """
+        if not self.tree:
+            return []
+
+        sections = []
+        current = {'line': 1, 'name': '(script start)', 'commands': []}
+        previous_comment_line = None
+
+        for node in self.tree.root_node.children:
+            line = node.start_point[0] + 1
+
+            if node.type == 'comment':
+                text = self._get_node_text(node)
+                is_heading = not text.startswith('#!') and previous_comment_line != line - 1
+                previous_comment_line = node.end_point[0] + 1
+                if is_heading:
+                    sections.append(current)
+                    current = {'line': line, 'name': self._heading_text(text), 'commands': []}
+                continue
+
+            if node.type in self.NON_COMMAND_TYPES:
+                continue
+
+            name = self._statement_name(node)
+            if name:
+                current['commands'].append(name)
+                current['line_end'] = node.end_point[0] + 1
+
+        sections.append(current)
+
+        result = []
+        for section in sections:
+            commands = section.pop('commands')
+            if not commands:
+                continue
+
+            unique = list(dict.fromkeys(commands))
+            shown = ', '.join(unique[:self.MAX_SECTION_COMMANDS])
+            if len(unique) > self.MAX_SECTION_COMMANDS:
+                shown += ', ...'
+            plural = 's' if len(commands) != 1 else ''
+            section['signature'] = f" ({len(commands)} command{plural}: {shown})"
+            result.append(section)
+
+        return result
+
+    @staticmethod
+    def _heading_text(comment: str) -> str:
+        """Strip comment markers and banner decoration: '# === Build ===' -> 'Build'."""
+        return comment.lstrip('#').strip().strip('=-*#').strip() or comment
+
+    def _statement_name(self, node) -> Optional[str]:
+        """Short name for a top-level statement (command name or keyword)."""
+        if node.type == 'command':
+            return self._command_name(node)
+        if node.type in self.COMPOUND_TYPES:
+            return self.COMPOUND_TYPES[node.type]
+
+        # pipeline / list / redirected_statement / negated_command: first command
+        for child in node.children:
+            if child.is_named:
+                name = self._statement_name(child)
+                if name:
+                    return name
+        return None
+
+    def _command_name(self, node) -> Optional[str]:
+        """Get the command word of a `command` node."""
+        name_node = node.child_by_field_name('name')
+        if name_node is None:
+            return None
+        return self._get_node_text(name_node)
+
     def _get_function_name(self, node) -> Optional[str]:
         """Extract function name from bash function_definition node.
 
@@ -0,0 +1,210 @@
+"""Bazel and Buck build file analyzer (Starlark)."""
+
+import ast
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+@register('BUILD', 'BUILD.bazel', 'BUCK', 'TARGETS', 'WORKSPACE', 'WORKSPACE.bazel', 'MODULE.bazel', '.bzl',
+          name='Bazel', icon='')
+class BazelAnalyzer(FileAnalyzer):
+    """Bazel / Buck build file analyzer.
+
+    Extracts:
+    - load() statements
+    - Targets: every top-level rule or macro call with a name, showing
+      the rule type, srcs (globs summarized) and deps
+    - In .bzl files: macros (def) and rule()/macro definitions
+
+    Starlark is parsed with Python's ast module, so strings, comments and
+    multi-line calls need no special handling.
+    """
+
+    # Attributes shown for every target (others are kept out of the listing)
+    SOURCE_ATTRIBUTES = ('srcs', 'hdrs')
+    DEPENDENCY_ATTRIBUTES = ('deps', 'exports', 'runtime_deps', 'exported_deps')
+    RULE_DEFINITIONS = ('rule', 'macro', 'repository_rule', 'aspect', 'module_extension')
+    # Inline source entries before eliding
+    MAX_INLINE_SOURCES = 3
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract loads, targets, macros and rule definitions."""
+        structure = {
+            'loads': [],
+            'targets': [],
+            'macros': [],
+            'rules': [],
+        }
+
+        tree = self._parse()
+        for node in tree.body if tree else []:
+            if isinstance(node, ast.Expr) and isinstance(node.value, ast.Call):
+                call = node.value
+                rule = self._call_name(call.func)
+                if rule == 'load':
+                    structure['loads'].append(self._load_entry(call))
+                    continue
+                target = self._target_entry(call, rule)
+                if target:
+                    structure['targets'].append(target)
+
+            elif isinstance(node, ast.FunctionDef):
+                structure['macros'].append({
+                    'line': node.lineno,
+                    'line_end': node.end_lineno,
+                    'name': node.name,
+                    'signature': f"({', '.join(self._params(node.args))})",
+                    'line_count': node.end_lineno - node.lineno + 1,
+                })
+
+            elif isinstance(node, ast.Assign) and isinstance(node.value, ast.Call):
+                kind = self._call_name(node.value.func)
+                names = [t.id for t in node.targets if isinstance(t, ast.Name)]
+                if kind in self.RULE_DEFINITIONS and names:
+                    entry = {'line': node.lineno, 'name': names[0], 'kind': kind}
+                    if node.end_lineno > node.lineno:
+                        entry['line_end'] = node.end_lineno
+                    implementation = self._keyword(node.value, 'implementation')
+                    if isinstance(implementation, ast.Name):
+                        entry['implementation'] = implementation.id
+                        entry['signature'] = f" ({kind}, implementation={implementation.id})"
+                    else:
+                        entry['signature'] = f" ({kind})"
+                    structure['rules'].append(entry)
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _parse(self) -> Optional[ast.Module]:
+        try:
+            return ast.parse(self.content)
+        except SyntaxError:
+            return None
+
+    def _load_entry(self, call: ast.Call) -> Dict[str, Any]:
+        """load("//pkg:defs.bzl", "a", b = "c")."""
+        args = [self._describe(a) for a in call.args]
+        symbols = args[1:] + [k.arg for k in call.keywords if k.arg]
+        source = ast.get_source_segment(self.content, call) or 'load()'
+        entry = {'line': call.lineno, 'content': ' '.join(source.split()).replace('( ', '(').replace(', )', ')')}
+        if args:
+            entry['module'] = args[0]
+            entry['symbols'] = symbols
+        return entry
+
+    def _target_entry(self, call: ast.Call, rule: str) -> Optional[Dict[str, Any]]:
+        """A rule/macro invocation with name = "..."."""
+        name = self._keyword(call, 'name')
+        if not isinstance(name, ast.Constant) or not isinstance(name.value, str):
+            return None
+
+        entry = {'line': call.lineno, 'name': name.value, 'rule': rule}
+        if call.end_lineno > call.lineno:
+            entry['line_end'] = call.end_lineno
+
+        sources = []
+        for attribute in self.SOURCE_ATTRIBUTES:
+            value = self._keyword(call, attribute)
+            if value is not None:
+                sources.extend(self._items(value))
+        deps = []
+        for attribute in self.DEPENDENCY_ATTRIBUTES:
+            value = self._keyword(call, attribute)
+            if value is not None:
+                deps.extend(self._items(value))
+        visibility = self._keyword(call, 'visibility')
+
+        signature = f" ({rule})"
+        if sources:
+            entry['srcs'] = sources
+            shown = sources[:self.MAX_INLINE_SOURCES]
+            more = len(sources) - len(shown)
+            signature += f" srcs: {', '.join(shown)}" + (f", +{more} more" if more else '')
+        if deps:
+            entry['deps'] = deps
+            signature += f" -> {', '.join(deps)}"
+        if visibility is not None:
+            entry['visibility'] = self._items(visibility)
+        entry['signature'] = signature
+        return entry
+
+    def _items(self, node: ast.AST) -> List[str]:
+        """Entries of a list attribute; globs and selects are summarized."""
+        if isinstance(node, (ast.List, ast.Tuple)):
+            return [self._describe(e) for e in node.elts]
+        if isinstance(node, ast.BinOp) and isinstance(node.op, ast.Add):
+            return self._items(node.left) + self._items(node.right)
+        return [self._describe(node)]
+
+    def _describe(self, node: ast.AST) -> str:
+        """Short text for an attribute value: "a.cc", glob(src/**/*.py), select(...)."""
+        if isinstance(node, ast.Constant):
+            return str(node.value)
+        if isinstance(node, ast.Name):
+            return node.id
+        if isinstance(node, ast.Call):
+            function = self._call_name(node.func)
+            if function == 'glob':
+                include = node.args[0] if node.args else self._keyword(node, 'include')
+                patterns = self._items(include) if include is not None else []
+                exclude = self._keyword(node, 'exclude')
+                text = f"glob({', '.join(patterns)}"
+                if exclude is not None:
+                    text += f", exclude: {', '.join(self._items(exclude))}"
+                return text + ')'
+            return f"{function}(...)"
+        return ast.unparse(node) if hasattr(ast, 'unparse') else '...'
+
+    @staticmethod
+    def _keyword(call: ast.Call, name: str) -> Optional[ast.AST]:
+        return next((k.value for k in call.keywords if k.arg == name), None)
+
+    @staticmethod
+    def _call_name(func: ast.AST) -> str:
+        """cc_library, native.cc_library, ..."""
+        if isinstance(func, ast.Attribute):
+            return f"{BazelAnalyzer._call_name(func.value)}.{func.attr}"
+        if isinstance(func, ast.Name):
+            return func.id
+        return '?'
+
+    @staticmethod
+    def _params(args: ast.arguments) -> List[str]:
+        params = [a.arg for a in args.posonlyargs + args.args]
+        if args.vararg:
+            params.append(f"*{args.vararg.arg}")
+        params.extend(a.arg for a in args.kwonlyargs)
+        if args.kwarg:
+            params.append(f"**{args.kwarg.arg}")
+        return params
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Target overview for directory trees: 'targets: lib, test'."""
+        names = [t['name'] for t in self.get_structure().get('targets', [])]
+        if not names:
+            return None
+        return 'targets: ' + ', '.join(names[:4]) + (', ...' if len(names) > 4 else '')
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a target (by name or :name label), macro or rule definition."""
+        structure = self.get_structure()
+        name = name.lstrip(':')
+
+        for category in ('targets', 'macros', 'rules'):
+            for item in structure.get(category, []):
+                if item['name'] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': name,
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
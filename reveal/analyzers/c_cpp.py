@@ -0,0 +1,247 @@
+"""C and C++ file analyzers - tree-sitter based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.c', '.h', name='C', icon='')
+class CAnalyzer(TreeSitterAnalyzer):
+    """C file analyzer.
+
+    Extracts:
+    - #include directives and macros
+    - Function definitions and declarations (prototypes)
+    - Structs, unions, enums and typedefs
+
+    Headers are reported as "C Header" in directory trees. A .h file
+    that uses C++ syntax (class, namespace, template) is parsed with
+    the C++ grammar; one declaring @interface/@protocol is analyzed as
+    Objective-C.
+    """
+    language = 'c'
+
+    HEADER_EXTENSIONS = {'.h', '.hh', '.hpp', '.hxx', '.h++'}
+
+    # Declarator wrappers between a declaration and its identifier
+    DECLARATOR_WRAPPERS = (
+        'pointer_declarator', 'reference_declarator', 'array_declarator',
+        'parenthesized_declarator', 'function_declarator', 'init_declarator',
+    )
+
+    CPP_HEADER_PATTERN = re.compile(r'^\s*(class|namespace|template)\b', re.MULTILINE)
+
+    def __init__(self, path: str):
+        super().__init__(path)
+        # C++-flavoured .h files are parsed with the C++ grammar
+        if self.language == 'c' and self.is_header and self.CPP_HEADER_PATTERN.search(self.content):
+            self.language = 'cpp'
+        if self.is_header:
+            self.type_name = f"{type(self).type_name} Header"
+            if self._objc_analyzer() is not None:
+                self.type_name = "Objective-C Header"
+
+    @property
+    def is_header(self) -> bool:
+        """True for header files (.h, .hpp, ...), False for implementation files."""
+        return self.path.suffix.lower() in self.HEADER_EXTENSIONS
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Objective-C headers are handed to the Objective-C analyzer."""
+        objc = self._objc_analyzer()
+        if objc is not None:
+            return objc.get_structure(head, tail, range, **kwargs)
+        return super().get_structure(head, tail, range, **kwargs)
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract from Objective-C headers via the Objective-C analyzer."""
+        objc = self._objc_analyzer()
+        if objc is not None:
+            return objc.extract_element(element_type, name)
+        return super().extract_element(element_type, name)
+
+    def _objc_analyzer(self):
+        """Objective-C analyzer for .h files declaring @interface/@protocol."""
+        from .objc import ObjCAnalyzer
+
+        if self.is_header and ObjCAnalyzer.OBJC_HEADER_PATTERN.search(self.content):
+            return ObjCAnalyzer(str(self.path))
+        return None
+
+    def get_metadata(self) -> Dict[str, Any]:
+        """Add header/source distinction to metadata."""
+        meta = super().get_metadata()
+        meta['kind'] = 'header' if self.is_header else 'source'
+        return meta
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract #include directives."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node).strip(),
+        } for node in self._find_nodes_by_type('preproc_include')]
+
+    def _extract_structs(self) -> List[Dict[str, Any]]:
+        """Extract struct definitions (forward references are skipped)."""
+        return self._extract_bodied(['struct_specifier'])
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract class definitions (C++ only; C headers parsed as C++ included)."""
+        return self._extract_bodied(['class_specifier'])
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract declarations, unions, enums, typedefs and macros."""
+        return {
+            'declarations': self._extract_declarations(),
+            'unions': self._extract_bodied(['union_specifier']),
+            'enums': self._extract_bodied(['enum_specifier']),
+            'typedefs': self._extract_typedefs(),
+            'macros': self._extract_definitions(['preproc_def', 'preproc_function_def']),
+        }
+
+    def _extract_bodied(self, node_types: List[str]) -> List[Dict[str, Any]]:
+        """Extract named specifiers that have a body (definitions, not references)."""
+        items = []
+
+        for node_type in node_types:
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if name and node.child_by_field_name('body') is not None:
+                    items.append({
+                        'line': node.start_point[0] + 1,
+                        'line_end': node.end_point[0] + 1,
+                        'name': name,
+                    })
+
+        return sorted(items, key=lambda i: i['line'])
+
+    def _extract_declarations(self) -> List[Dict[str, Any]]:
+        """Extract function declarations (prototypes and member declarations)."""
+        declarations = []
+
+        for node_type in ('declaration', 'field_declaration'):
+            for node in self._find_nodes_by_type(node_type):
+                function = self._find_function_declarator(node.child_by_field_name('declarator'))
+                if function is None:
+                    continue
+
+                name = self._declarator_name(function)
+                if name:
+                    declarations.append({
+                        'line': node.start_point[0] + 1,
+                        'name': name,
+                        'signature': self._format_signature(node, function),
+                    })
+
+        return sorted(declarations, key=lambda d: d['line'])
+
+    def _extract_typedefs(self) -> List[Dict[str, Any]]:
+        """Extract typedef names."""
+        typedefs = []
+
+        for node in self._find_nodes_by_type('type_definition'):
+            name = self._declarator_name(node.child_by_field_name('declarator'))
+            if name:
+                typedefs.append({
+                    'line': node.start_point[0] + 1,
+                    'name': name,
+                })
+
+        return typedefs
+
+    def _inner_declarator(self, node):
+        """Step one level into a declarator wrapper."""
+        inner = node.child_by_field_name('declarator')
+        if inner is None and node.named_children:
+            # reference_declarator has no field name: `&` <declarator>
+            inner = node.named_children[-1]
+        return inner
+
+    def _find_function_declarator(self, node):
+        """Find the function_declarator inside (possibly pointer-wrapped) declarator."""
+        while node is not None:
+            if node.type == 'function_declarator':
+                return node
+            if node.type not in self.DECLARATOR_WRAPPERS:
+                return None
+            node = self._inner_declarator(node)
+        return None
+
+    def _declarator_name(self, node) -> Optional[str]:
+        """Unwrap declarators down to the declared identifier."""
+        while node is not None and node.type in self.DECLARATOR_WRAPPERS:
+            node = self._inner_declarator(node)
+
+        if node is None:
+            return None
+        return self._get_node_text(node)
+
+    def _get_function_name(self, node) -> Optional[str]:
+        """Function names live in the declarator: int *foo(void) -> foo."""
+        return self._declarator_name(node.child_by_field_name('declarator'))
+
+    def _get_node_name(self, node) -> Optional[str]:
+        """Resolve names for definitions, including function_definition."""
+        if node.type == 'function_definition':
+            return self._get_function_name(node)
+        return super()._get_node_name(node)
+
+    def _get_signature(self, node) -> str:
+        """Get function definition signature: parameters and return type."""
+        function = self._find_function_declarator(node.child_by_field_name('declarator'))
+        if function is None:
+            return super()._get_signature(node)
+        return self._format_signature(node, function)
+
+    def _format_signature(self, node, function) -> str:
+        """Format '(params) -> return_type' from a declaration and its declarator."""
+        params = function.child_by_field_name('parameters')
+        signature = self._get_node_text(params) if params is not None else '()'
+
+        return_type = node.child_by_field_name('type')
+        if return_type is not None:
+            # Pointer returns: the '*' belongs to the declarator (int *foo())
+            declarator = node.child_by_field_name('declarator')
+            pointer = '*' if declarator is not None and declarator.type == 'pointer_declarator' else ''
+            signature += f" -> {self._get_node_text(return_type)}{pointer}"
+
+        return signature
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Map element types to C/C++ node types."""
+        return {
+            'function': ['function_definition'],
+            'class': ['class_specifier'],
+            'struct': ['struct_specifier', 'union_specifier', 'enum_specifier'],
+            'namespace': ['namespace_definition'],
+        }
+
+    def _find_named_node(self, node_types: List[str], name: str):
+        """Only match definitions with bodies (skip `struct foo *p;` references)."""
+        for node_type in node_types:
+            for node in self._find_nodes_by_type(node_type):
+                if self._get_node_name(node) != name:
+                    continue
+                if node_type != 'function_definition' and node.child_by_field_name('body') is None:
+                    continue
+                return node
+        return None
+
+
+@register('.cpp', '.cc', '.cxx', '.c++', '.hpp', '.hh', '.hxx', '.h++', name='C++', icon='')
+class CppAnalyzer(CAnalyzer):
+    """C++ file analyzer.
+
+    Everything CAnalyzer extracts, plus classes and namespaces.
+    Out-of-class method definitions are named with their qualifier
+    (e.g., 'Server::start').
+    """
+    language = 'cpp'
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Add namespaces to the C categories."""
+        result = {'namespaces': self._extract_bodied(['namespace_definition'])}
+        result.update(super()._extract_language_specific())
+        return result
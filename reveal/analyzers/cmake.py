@@ -0,0 +1,250 @@
+"""CMake (CMakeLists.txt / .cmake) analyzer."""
+
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+
+
+@register('CMakeLists.txt', '.cmake', name='CMake', icon='')
+class CMakeAnalyzer(FileAnalyzer):
+    """CMake analyzer.
+
+    Extracts:
+    - project() declaration
+    - Targets (add_executable/add_library/add_custom_target) with their
+      linked libraries from target_link_libraries()
+    - Subdirectories (add_subdirectory)
+    - Packages (find_package) with version, REQUIRED and components
+    - Options, included modules, functions and macros
+    """
+
+    COMMAND = re.compile(r'^\s*(?P<command>[A-Za-z_]\w*)\s*\(')
+
+    TARGET_COMMANDS = {
+        'add_executable': 'executable',
+        'add_library': 'library',
+        'add_custom_target': 'custom',
+    }
+    LIBRARY_TYPES = ('STATIC', 'SHARED', 'MODULE', 'OBJECT', 'INTERFACE', 'IMPORTED', 'ALIAS')
+    FIND_PACKAGE_KEYWORDS = ('REQUIRED', 'QUIET', 'CONFIG', 'MODULE', 'NO_MODULE',
+                             'COMPONENTS', 'OPTIONAL_COMPONENTS', 'EXACT')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract project, targets, subdirectories, packages and options."""
+        structure = {
+            'project': [],
+            'targets': [],
+            'subdirectories': [],
+            'packages': [],
+            'options': [],
+            'includes': [],
+            'functions': [],
+        }
+        targets = {}
+
+        for command, args, line, line_end in self._commands():
+            name = command.lower()
+
+            if name == 'project' and args:
+                entry = {'line': line, 'name': args[0]}
+                version = self._keyword_value(args, 'VERSION')
+                if version:
+                    entry['signature'] = f" {version}"
+                structure['project'].append(entry)
+
+            elif name in self.TARGET_COMMANDS and args:
+                entry = {'line': line, 'name': args[0], 'kind': self.TARGET_COMMANDS[name]}
+                if line_end > line:
+                    entry['line_end'] = line_end
+                library_type = next((a for a in args[1:2] if a in self.LIBRARY_TYPES), None)
+                if library_type:
+                    entry['kind'] = f"{library_type.lower()} library"
+                entry['signature'] = f" ({entry['kind']})"
+                targets[entry['name']] = entry
+                structure['targets'].append(entry)
+
+            elif name == 'target_link_libraries' and args and args[0] in targets:
+                entry = targets[args[0]]
+                links = [a for a in args[1:] if a not in ('PUBLIC', 'PRIVATE', 'INTERFACE')]
+                entry.setdefault('links', []).extend(links)
+                entry['signature'] = f" ({entry['kind']}) -> {', '.join(entry['links'])}"
+
+            elif name == 'add_subdirectory' and args:
+                structure['subdirectories'].append({'line': line, 'name': args[0]})
+
+            elif name == 'find_package' and args:
+                entry = {'line': line, 'name': args[0]}
+                details = []
+                if len(args) > 1 and args[1] not in self.FIND_PACKAGE_KEYWORDS:
+                    entry['version'] = args[1]
+                    details.append(args[1])
+                if 'REQUIRED' in args:
+                    entry['required'] = True
+                    details.append('REQUIRED')
+                components = self._keyword_list(args, ('COMPONENTS', 'REQUIRED'))
+                if components:
+                    entry['components'] = components
+                    details.append(f"components: {' '.join(components)}")
+                if details:
+                    entry['signature'] = f" ({', '.join(details)})"
+                structure['packages'].append(entry)
+
+            elif name == 'option' and args:
+                entry = {'line': line, 'name': args[0]}
+                if len(args) > 2:
+                    entry['signature'] = f" = {args[2]}"
+                structure['options'].append(entry)
+
+            elif name == 'include' and args:
+                structure['includes'].append({'line': line, 'content': f"include({args[0]})"})
+
+            elif name in ('function', 'macro') and args:
+                end = self._block_end(line, 'end' + name)
+                structure['functions'].append({
+                    'line': line,
+                    'line_end': end,
+                    'name': args[0],
+                    'signature': f"({' '.join(args[1:])})",
+                    'kind': name,
+                })
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _commands(self) -> List[Tuple[str, List[str], int, int]]:
+        """Parse command invocations: (command, args, line, line_end)."""
+        commands = []
+        i = 0
+
+        while i < len(self.lines):
+            match = self.COMMAND.match(self.lines[i])
+            if not match:
+                i += 1
+                continue
+
+            args, end = self._parse_arguments(i, match.end())
+            commands.append((match.group('command'), args, i + 1, end + 1))
+            i = end + 1
+
+        return commands
+
+    def _parse_arguments(self, line_index: int, column: int) -> Tuple[List[str], int]:
+        """Split a command's arguments, which may span lines; returns (args, last line index)."""
+        args = []
+        current = ''
+        depth = 1
+        quote = False
+        i = line_index
+        text = self.lines[i][column:]
+
+        while True:
+            j = 0
+            while j < len(text):
+                char = text[j]
+                if quote:
+                    if char == '\\' and j + 1 < len(text):
+                        current += text[j:j + 2]
+                        j += 2
+                        continue
+                    if char == '"':
+                        quote = False
+                    else:
+                        current += char
+                elif char == '"':
+                    quote = True
+                elif char == '#':
+                    break
+                elif char == '(':
+                    depth += 1
+                    current += char
+                elif char == ')':
+                    depth -= 1
+                    if depth == 0:
+                        if current:
+                            args.append(current)
+                        return args, i
+                    current += char
+                elif char.isspace():
+                    if current:
+                        args.append(current)
+                        current = ''
+                else:
+                    current += char
+                j += 1
+
+            if quote:
+                current += '\n'
+            elif current:
+                args.append(current)
+                current = ''
+            i += 1
+            if i >= len(self.lines):
+                return args, len(self.lines) - 1
+            text = self.lines[i]
+
+    def _block_end(self, line: int, end_command: str) -> int:
+        """Line of the matching endfunction()/endmacro()."""
+        for i in range(line, len(self.lines)):
+            match = self.COMMAND.match(self.lines[i])
+            if match and match.group('command').lower() == end_command:
+                return i + 1
+        return line
+
+    @staticmethod
+    def _keyword_value(args: List[str], keyword: str) -> Optional[str]:
+        """Value following a keyword argument (e.g. VERSION 1.2)."""
+        if keyword in args:
+            index = args.index(keyword)
+            if index + 1 < len(args):
+                return args[index + 1]
+        return None
+
+    def _keyword_list(self, args: List[str], keywords: Tuple[str, ...]) -> List[str]:
+        """Arguments following the first of keywords, up to the next keyword."""
+        for keyword in keywords:
+            if keyword in args:
+                values = []
+                for arg in args[args.index(keyword) + 1:]:
+                    if arg in self.FIND_PACKAGE_KEYWORDS:
+                        if values:
+                            break
+                        continue
+                    values.append(arg)
+                return values
+        return []
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Build overview for directory trees: 'targets: app, core; packages: Boost'."""
+        structure = self.get_structure()
+        parts = []
+
+        for category in ('targets', 'packages', 'subdirectories'):
+            names = [item['name'] for item in structure.get(category, [])]
+            if names:
+                shown = ', '.join(names[:4]) + (', ...' if len(names) > 4 else '')
+                parts.append(f"{category}: {shown}")
+
+        return '; '.join(parts) or None
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a target or function definition."""
+        structure = self.get_structure()
+
+        for category in ('targets', 'functions'):
+            for item in structure.get(category, []):
+                if item['name'] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': name,
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
@@ -0,0 +1,209 @@
+"""Crystal file analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_MODIFIERS = r'(?:(?P<visibility>private|protected)\s+)?(?P<abstract>abstract\s+)?'
+_TYPE_NAME = r'(?P<name>[A-Z][\w:]*)(?P<generics>\([^)]*\))?'
+
+
+@register('.cr', name='Crystal', icon='')
+class CrystalAnalyzer(RegexAnalyzer):
+    """Crystal file analyzer.
+
+    Extracts:
+    - require statements
+    - Modules, classes (abstract ones marked, with superclass), structs,
+      enums (with members), lib bindings and aliases; nested types are
+      named Outer::Inner
+    - Methods named Type#name, class methods (def self.x) Type.name, and
+      top-level functions - with parameters, return type and visibility
+    - Macros
+
+    Strings and comments are ignored when matching `end`, so one-line
+    definitions (`def x; end`) and `do ... end` blocks nest correctly.
+    """
+
+    block_style = 'end'
+    comment_prefixes = ('#',)
+    function_categories = ('functions', 'methods', 'class_methods', 'macros')
+
+    # Enum members shown inline before eliding
+    MAX_INLINE_MEMBERS = 6
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>require\s+"[^"]+")'),
+        'modules': re.compile(r'^\s*' + _MODIFIERS + r'module\s+' + _TYPE_NAME),
+        'classes': re.compile(r'^\s*' + _MODIFIERS + r'class\s+' + _TYPE_NAME + r'(?:\s*<\s*(?P<superclass>[\w:()]+))?'),
+        'structs': re.compile(r'^\s*' + _MODIFIERS + r'struct\s+' + _TYPE_NAME + r'(?:\s*<\s*(?P<superclass>[\w:()]+))?'),
+        'enums': re.compile(r'^\s*' + _MODIFIERS + r'enum\s+' + _TYPE_NAME + r'(?:\s*:\s*(?P<base>\w+))?'),
+        'libs': re.compile(r'^\s*lib\s+(?P<name>[A-Z]\w*)'),
+        'aliases': re.compile(r'^\s*' + _MODIFIERS + r'alias\s+(?P<name>[A-Z][\w:]*)\s*=\s*(?P<target>.+?)\s*$'),
+        'macros': re.compile(r'^\s*' + _MODIFIERS + r'macro\s+(?P<name>[\w?!=]+)(?P<params>.*)$'),
+        'functions': re.compile(
+            r'^\s*' + _MODIFIERS + r'def\s+(?P<receiver>self\.)?(?P<name>[\w?!=]+|[+\-*/%<>=!~^&|\[\]]+)(?P<params>.*)$'
+        ),
+    }
+
+    # Keywords opening a block when they start a statement
+    OPENER = re.compile(
+        r'^\s*(?:(?:private|protected|abstract)\s+)*'
+        r'(class|module|struct|def|macro|enum|lib|annotation|union|if|unless|while|until|case|begin|select)\b'
+    )
+    # `x = if ...`, `y = case ...` open a block mid-line
+    ASSIGNED_OPENER = re.compile(r'(?:=|\(|,)\s*(if|unless|case|begin)\b')
+    DO = re.compile(r'\bdo\b\s*(?:\|[^|]*\|)?\s*(?:;|$)')
+    END = re.compile(r'(?<![.\w])end\b(?![?!:])')
+    ABSTRACT_DEF = re.compile(r'^\s*(?:(?:private|protected)\s+)?abstract\s+def\b')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; qualify nested types and attach methods to their type."""
+        source_lines = self.lines
+        self.lines = [self._code(line) for line in source_lines]
+        try:
+            structure = super().get_structure(**kwargs)
+        finally:
+            self.lines = source_lines
+
+        containers = sorted((t for c in ('modules', 'classes', 'structs', 'enums', 'libs')
+                             for t in structure.get(c, [])), key=lambda t: t['line'])
+        for container in containers:
+            parents = [c['name'] for c in containers if self._encloses(c, container)]
+            if parents:
+                container['name'] = f"{parents[-1]}::{container['name']}"
+
+        functions = []
+        methods = []
+        class_methods = []
+        for function in structure.pop('functions', []):
+            if any(self._encloses(f, function) for f in structure.get('macros', [])):
+                continue
+            owners = [c['name'] for c in containers if self._encloses(c, function)]
+            receiver = function.pop('receiver', None)
+            if not owners:
+                functions.append(function)
+            elif receiver:
+                function['name'] = f"{owners[-1]}.{function['name']}"
+                class_methods.append(function)
+            else:
+                function['name'] = f"{owners[-1]}#{function['name']}"
+                methods.append(function)
+        structure.update({'functions': functions, 'methods': methods, 'class_methods': class_methods})
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    @staticmethod
+    def _encloses(outer: Dict[str, Any], inner: Dict[str, Any]) -> bool:
+        return outer is not inner and outer['line'] < inner['line'] <= outer.get('line_end', outer['line'])
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Shape entries: signatures, visibility, superclass and enum members."""
+        entry = super()._make_entry(category, match, line_no)
+        if category == 'imports':
+            return entry
+
+        if entry.pop('abstract', None):
+            entry['abstract'] = True
+        signature = entry.pop('generics', '')
+
+        if category in ('functions', 'macros'):
+            signature += self._signature(entry.pop('params', ''), line_no)
+            if entry.get('abstract'):
+                signature += '  (abstract)'
+                entry.pop('line_end', None)
+                entry.pop('line_count', None)
+        elif category in ('classes', 'structs'):
+            if entry.get('superclass'):
+                signature += f" < {entry['superclass']}"
+            if entry.get('abstract'):
+                signature += '  (abstract)'
+        elif category == 'enums':
+            if entry.get('base'):
+                signature += f" : {entry['base']}"
+            entry['members'] = self._enum_members(line_no, entry.get('line_end', line_no))
+            shown = entry['members'][:self.MAX_INLINE_MEMBERS]
+            more = len(entry['members']) - len(shown)
+            signature += f" {{ {', '.join(shown)}{f', ... +{more}' if more else ''} }}"
+        elif category == 'aliases':
+            signature += f" = {entry['target']}"
+
+        entry['signature'] = signature
+        return entry
+
+    def _signature(self, params: str, line_no: int) -> str:
+        """'(a : Int32, b = 2) -> String' from the text after the name."""
+        text = params.strip()
+        args = ''
+        if text.startswith('('):
+            # Parameter list may continue on following lines
+            for line in self.lines[line_no:line_no + 10]:
+                if text.count('(') <= text.count(')'):
+                    break
+                text += ' ' + line.strip()
+            depth = 0
+            for i, char in enumerate(text):
+                if char == '(':
+                    depth += 1
+                elif char == ')':
+                    depth -= 1
+                    if depth == 0:
+                        args, text = ' '.join(text[1:i].split()), text[i + 1:]
+                        break
+
+        signature = f"({args})"
+        returns = re.match(r'\s*:\s*(.+?)\s*(?:forall\b.*)?(?:;.*)?$', text)
+        if returns:
+            signature += f" -> {returns.group(1)}"
+        return signature
+
+    def _enum_members(self, start: int, end: int) -> List[str]:
+        """Constant names declared directly in an enum body."""
+        members = []
+        for line in self.lines[start:end - 1]:
+            match = re.match(r'^\s*([A-Z]\w*)\s*(?:=.*)?$', line)
+            if match:
+                members.append(match.group(1))
+        return members
+
+    def _find_keyword_end(self, line_no: int) -> int:
+        """Count block openers (statement keywords, `do`) against `end`."""
+        depth = 0
+
+        for i in range(line_no - 1, len(self.lines)):
+            line = self.lines[i]
+            for statement in line.split(';'):
+                opener = self.OPENER.match(statement)
+                if opener and not self.ABSTRACT_DEF.match(statement):
+                    depth += 1
+                elif self.ASSIGNED_OPENER.search(statement):
+                    depth += 1
+                if self.DO.search(statement):
+                    depth += 1
+                for _ in self.END.finditer(statement):
+                    depth -= 1
+                    if depth <= 0:
+                        return i + 1
+            if i == line_no - 1 and depth <= 0:
+                # Abstract defs and other single-line entries
+                return line_no
+
+        return line_no
+
+    @staticmethod
+    def _code(line: str) -> str:
+        """Line without string/char literals and comments."""
+        if re.match(r'^\s*require\s+"', line):
+            return line
+        line = re.sub(r'"(?:\\.|[^"\\])*"', '""', line)
+        line = re.sub(r"'(?:\\.|[^'\\])'", "''", line)
+        return line.split('#', 1)[0]
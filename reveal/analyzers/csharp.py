@@ -0,0 +1,289 @@
+"""C# file analyzer - tree-sitter based."""
+
+from pathlib import Path
+from typing import Dict, List, Any, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+# Partial types of .cs files by absolute path, with the (mtime, size) they were read at
+_PARTIAL_TYPES: Dict[str, Tuple[Tuple[int, int], Dict[str, List[Dict[str, Any]]]]] = {}
+
+
+@register('.cs', name='C#', icon='')
+class CSharpAnalyzer(TreeSitterAnalyzer):
+    """C# file analyzer.
+
+    Extracts:
+    - using directives and namespaces (block and file-scoped)
+    - Classes, structs, records, interfaces and enums
+    - Methods, constructors and properties with visibility
+
+    Partial types are flagged: the other files in the same directory
+    declaring the same partial type are listed under 'parts' (e.g.,
+    Form1.cs + Form1.Designer.cs), and the members of every part are
+    rolled up under 'members', each with the file it is declared in.
+    """
+    language = 'c_sharp'
+
+    # Default visibility in C# is private for members and nested types,
+    # public for interface members and internal for top-level types
+    VISIBILITY_KEYWORDS = ('public', 'protected', 'internal', 'private')
+    TOP_LEVEL_CONTAINERS = ('compilation_unit', 'namespace_declaration', 'file_scoped_namespace_declaration')
+
+    MEMBER_NODES = {
+        'method_declaration': 'method',
+        'constructor_declaration': 'constructor',
+        'property_declaration': 'property',
+        'field_declaration': 'field',
+        'event_declaration': 'event',
+        'event_field_declaration': 'event',
+    }
+
+    TYPE_NODES = {
+        'classes': ['class_declaration'],
+        'structs': ['struct_declaration'],
+        'records': ['record_declaration', 'record_struct_declaration'],
+        'interfaces': ['interface_declaration'],
+        'enums': ['enum_declaration'],
+    }
+
+    def __init__(self, path: str):
+        super().__init__(path)
+        self._sibling_partials = None
+
+    def cache_inputs(self) -> List[Path]:
+        """The other .cs files of the directory, when this file may declare partial types."""
+        if 'partial' not in self.content:
+            return []
+        return [sibling for sibling in sorted(self.path.parent.glob('*.cs')) if sibling != self.path]
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract using directives."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node).rstrip(';'),
+        } for node in self._find_nodes_by_type('using_directive')]
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract methods and constructors."""
+        functions = []
+
+        for node_type in ('method_declaration', 'constructor_declaration'):
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if not name:
+                    continue
+
+                entry = self._function_entry(node, name)
+                entry.update(self._declaration_info(node))
+                functions.append(entry)
+
+        return sorted(functions, key=lambda f: f['line'])
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract classes (partial classes include their other parts)."""
+        return self._extract_types(self.TYPE_NODES['classes'])
+
+    def _extract_structs(self) -> List[Dict[str, Any]]:
+        """Extract struct declarations."""
+        return self._extract_types(self.TYPE_NODES['structs'])
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract namespaces, records, interfaces, enums and properties."""
+        return {
+            'namespaces': self._extract_definitions([
+                'namespace_declaration', 'file_scoped_namespace_declaration',
+            ]),
+            'records': self._extract_types(self.TYPE_NODES['records']),
+            'interfaces': self._extract_types(self.TYPE_NODES['interfaces']),
+            'enums': self._extract_types(self.TYPE_NODES['enums']),
+            'properties': self._extract_properties(),
+        }
+
+    def _extract_types(self, node_types: List[str]) -> List[Dict[str, Any]]:
+        """Extract type declarations with visibility and partial parts."""
+        types = []
+
+        for node_type in node_types:
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if not name:
+                    continue
+
+                entry = {
+                    'line': node.start_point[0] + 1,
+                    'line_end': node.end_point[0] + 1,
+                    'name': name,
+                }
+                entry.update(self._declaration_info(node))
+
+                if 'partial' in entry.get('modifiers', []):
+                    others = self._sibling_partial_types().get(name, [])
+                    if others:
+                        entry['parts'] = [other['file'] for other in others]
+                        entry['members'] = self._type_members(node, self.path.name) + [
+                            member for other in others for member in other['members']]
+
+                types.append(entry)
+
+        return sorted(types, key=lambda t: t['line'])
+
+    def _extract_properties(self) -> List[Dict[str, Any]]:
+        """Extract property declarations with type and accessors."""
+        properties = []
+
+        for node in self._find_nodes_by_type('property_declaration'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            prop_type = node.child_by_field_name('type')
+            accessors = node.child_by_field_name('accessors')
+
+            entry = {
+                'line': node.start_point[0] + 1,
+                'name': name,
+                'signature': f": {self._get_node_text(prop_type)}" if prop_type is not None else '',
+            }
+            if accessors is not None:
+                entry['accessors'] = [
+                    self._get_node_text(child).split('{')[0].split('=>')[0].strip().rstrip(';')
+                    for child in accessors.children
+                    if child.type == 'accessor_declaration'
+                ]
+            entry.update(self._declaration_info(node))
+            properties.append(entry)
+
+        return properties
+
+    def _declaration_info(self, node) -> Dict[str, Any]:
+        """Visibility, other modifiers and attributes of a declaration."""
+        modifiers = [self._get_node_text(child) for child in node.children
+                     if child.type == 'modifier']
+        attributes = [self._get_node_text(child) for child in node.children
+                      if child.type == 'attribute_list']
+
+        visibility = ' '.join(m for m in modifiers if m in self.VISIBILITY_KEYWORDS)
+        if not visibility:
+            parent = node.parent
+            container = parent.parent if parent is not None and parent.type == 'declaration_list' else parent
+            if container is None or container.type in self.TOP_LEVEL_CONTAINERS:
+                visibility = 'internal'
+            elif container.type == 'interface_declaration':
+                visibility = 'public'
+            else:
+                visibility = 'private'
+
+        info = {'visibility': visibility}
+        other = [m for m in modifiers if m not in self.VISIBILITY_KEYWORDS]
+        if other:
+            info['modifiers'] = other
+        if attributes:
+            info['attributes'] = attributes
+        return info
+
+    def _sibling_partial_types(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Partial types declared in the other .cs files of the directory.
+
+        Maps type name to [{'file': ..., 'members': [...]}]. Each sibling is
+        parsed once while unchanged (see _partial_types), so analyzing a
+        whole directory doesn't re-parse every file for every other one.
+        """
+        if self._sibling_partials is not None:
+            return self._sibling_partials
+
+        self._sibling_partials = {}
+        for sibling in sorted(self.path.parent.glob('*.cs')):
+            if sibling == self.path:
+                continue
+            for name, parts in _partial_types(sibling).items():
+                self._sibling_partials.setdefault(name, []).extend(parts)
+
+        return self._sibling_partials
+
+    def _own_partial_types(self) -> Dict[str, List[Dict[str, Any]]]:
+        """This file's partial types: name -> [{'file': ..., 'members': [...]}]."""
+        types: Dict[str, List[Dict[str, Any]]] = {}
+        for node_types in self.TYPE_NODES.values():
+            for node_type in node_types:
+                for node in self._find_nodes_by_type(node_type):
+                    name = self._get_node_name(node)
+                    if name and 'partial' in self._declaration_info(node).get('modifiers', []):
+                        types.setdefault(name, []).append({
+                            'file': self.path.name,
+                            'members': self._type_members(node, self.path.name),
+                        })
+        return types
+
+    def _type_members(self, node, file_name: str) -> List[Dict[str, Any]]:
+        """Methods, constructors, properties, fields and events declared directly in a type."""
+        body = node.child_by_field_name('body')
+        if body is None:
+            return []
+
+        members = []
+        for child in body.children:
+            kind = self.MEMBER_NODES.get(child.type)
+            if not kind:
+                continue
+            if child.type in ('field_declaration', 'event_field_declaration'):
+                names = [self._get_node_name(declarator)
+                         for declarator in self._find_descendants(child, 'variable_declarator')]
+            else:
+                names = [self._get_node_name(child)]
+            for name in names:
+                if name:
+                    members.append({
+                        'name': name,
+                        'kind': kind,
+                        'file': file_name,
+                        'line': child.start_point[0] + 1,
+                        'visibility': self._declaration_info(child)['visibility'],
+                    })
+
+        return members
+
+    def _get_signature(self, node) -> str:
+        """Get method signature: parameters and return type."""
+        params = node.child_by_field_name('parameters')
+        signature = self._get_node_text(params) if params is not None else '()'
+
+        return_type = node.child_by_field_name('type') or node.child_by_field_name('returns')
+        if return_type is not None:
+            signature += f" -> {self._get_node_text(return_type)}"
+
+        return signature
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Make C# types and properties extractable by name.
+
+        Constructors come last so `reveal Foo.cs Foo` shows the class
+        rather than its constructor.
+        """
+        type_map = super()._get_element_type_map()
+        type_map['record'] = self.TYPE_NODES['records']
+        type_map['interface'] = self.TYPE_NODES['interfaces']
+        type_map['enum'] = self.TYPE_NODES['enums']
+        type_map['property'] = ['property_declaration']
+        type_map['namespace'] = ['namespace_declaration', 'file_scoped_namespace_declaration']
+        type_map['constructor'] = ['constructor_declaration']
+        return type_map
+
+
+def _partial_types(path: Path) -> Dict[str, List[Dict[str, Any]]]:
+    """Partial types a .cs file declares, parsed once per version of the file (none for unreadable files)."""
+    try:
+        stat = path.stat()
+    except OSError:
+        return {}
+    key, state = str(path.resolve()), (stat.st_mtime_ns, stat.st_size)
+    cached = _PARTIAL_TYPES.get(key)
+    if cached is None or cached[0] != state:
+        try:
+            text = path.read_text(encoding='utf-8', errors='replace')
+        except OSError:
+            return {}
+        types = CSharpAnalyzer(str(path))._own_partial_types() if 'partial' in text else {}
+        cached = _PARTIAL_TYPES[key] = (state, types)
+    return cached[1]
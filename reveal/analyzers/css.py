@@ -0,0 +1,243 @@
+"""CSS and SCSS stylesheet analyzers."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+@register('.css', name='CSS', icon='')
+class CSSAnalyzer(FileAnalyzer):
+    """CSS stylesheet analyzer.
+
+    Extracts:
+    - @import rules
+    - Custom properties (--name: value)
+    - Selectors (rules inside @media are listed with their query)
+    - Media queries, keyframes and other at-rules
+    """
+
+    # SCSS adds // comments, $variables, mixins, functions and nesting
+    scss = False
+
+    VARIABLE = re.compile(r'^(?P<name>--[\w-]+|\$[\w-]+)\s*:\s*(?P<value>.+?)(?:\s*!default)?$', re.S)
+    IMPORT = re.compile(r'^@(?:import|use|forward)\b')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract imports, variables, selectors, mixins and media queries."""
+        structure = {
+            'imports': [],
+            'variables': [],
+            'mixins': [],
+            'functions': [],
+            'selectors': [],
+            'media_queries': [],
+            'keyframes': [],
+            'at_rules': [],
+        }
+
+        for kind, text, line, line_end, parents in self._statements():
+            if kind == 'declaration':
+                self._add_declaration(structure, text, line, parents)
+            else:
+                self._add_block(structure, text, line, line_end, parents)
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _add_declaration(self, structure, text: str, line: int, parents: List[str]) -> None:
+        """Record imports and variables; property declarations are skipped."""
+        if self.IMPORT.match(text):
+            structure['imports'].append({'line': line, 'content': text})
+            return
+
+        match = self.VARIABLE.match(text)
+        if not match:
+            return
+        # SCSS $variables are listed at top level only; custom properties anywhere
+        if match.group('name').startswith('$') and (not self.scss or parents):
+            return
+
+        value = ' '.join(match.group('value').split())
+        if len(value) > 60:
+            value = value[:57] + '...'
+        entry = {'line': line, 'name': match.group('name'), 'signature': f": {value}"}
+        selector = self._selector_context(parents)
+        if selector and selector != ':root':
+            entry['scope'] = selector
+        structure['variables'].append(entry)
+
+    def _add_block(self, structure, text: str, line: int, line_end: int, parents: List[str]) -> None:
+        """Record a rule or at-rule block."""
+        entry = {'line': line}
+        if line_end > line:
+            entry['line_end'] = line_end
+
+        at_rule = re.match(r'^@([\w-]+)\s*(.*)$', text, re.S)
+        if at_rule:
+            keyword = at_rule.group(1).lower()
+            params = ' '.join(at_rule.group(2).split())
+
+            if keyword == 'media':
+                entry['name'] = f"@media {params}"
+                structure['media_queries'].append(entry)
+            elif keyword in ('mixin', 'function') and self.scss:
+                signature = re.match(r'^([\w-]+)\s*(\(.*\))?', params)
+                entry['name'] = signature.group(1) if signature else params
+                if signature and signature.group(2):
+                    entry['signature'] = signature.group(2)
+                structure['mixins' if keyword == 'mixin' else 'functions'].append(entry)
+            elif keyword.endswith('keyframes'):
+                entry['name'] = params
+                structure['keyframes'].append(entry)
+            elif keyword not in ('include', 'each', 'for', 'if', 'else', 'while'):
+                entry['name'] = f"@{keyword} {params}".strip()
+                structure['at_rules'].append(entry)
+            return
+
+        # Declarations nested inside mixins/functions/control flow aren't selectors
+        if any(p.startswith(('@mixin', '@function', '@keyframes', '@-')) for p in parents):
+            return
+        if re.match(r'^(from|to|\d+(\.\d+)?%)(\s*,\s*(from|to|\d+(\.\d+)?%))*$', text):
+            return
+
+        entry['name'] = self._resolve_selector(text, parents)
+        media = [p for p in parents if p.startswith('@media')]
+        if media:
+            entry['signature'] = f"  ({' '.join(media[-1].split())})"
+        structure['selectors'].append(entry)
+
+    def _resolve_selector(self, selector: str, parents: List[str]) -> str:
+        """Expand SCSS nesting: '&:hover' under '.btn' -> '.btn:hover'."""
+        selector = ' '.join(selector.split())
+        parent = self._selector_context(parents)
+        if not parent:
+            return selector
+        if '&' in selector:
+            return selector.replace('&', parent)
+        return f"{parent} {selector}"
+
+    def _selector_context(self, parents: List[str]) -> Optional[str]:
+        """Fully resolved selector of the innermost enclosing rule."""
+        resolved = None
+
+        for parent in parents:
+            if parent.startswith('@'):
+                continue
+            parent = ' '.join(parent.split())
+            if resolved is None:
+                resolved = parent
+            elif '&' in parent:
+                resolved = parent.replace('&', resolved)
+            else:
+                resolved = f"{resolved} {parent}"
+
+        return resolved
+
+    def _statements(self):
+        """Yield (kind, text, line, line_end, parents) for blocks and declarations.
+
+        kind is 'block' (text is the selector/at-rule prelude) or
+        'declaration'. parents are the preludes of enclosing blocks.
+        """
+        source = self._strip_comments(self.content)
+        stack = []      # (prelude, line, index in results)
+        results = []
+        buffer = ''
+        buffer_line = None
+        line = 1
+        quote = None
+
+        for char in source:
+            if char == '\n':
+                line += 1
+
+            if quote:
+                buffer += char
+                if char == quote:
+                    quote = None
+                continue
+
+            if char in ('"', "'"):
+                quote = char
+            elif char == '{' and buffer.rstrip().endswith('#'):
+                # SCSS interpolation #{...} - keep as text
+                buffer += char
+                stack.append(('#{', line, None))
+                continue
+            elif char == '{':
+                prelude = buffer.strip()
+                start = buffer_line or line
+                results.append(['block', prelude, start, line, [p for p, _, _ in stack if p != '#{']])
+                stack.append((prelude, start, len(results) - 1))
+                buffer, buffer_line = '', None
+                continue
+            elif char == '}':
+                if stack and stack[-1][0] == '#{':
+                    stack.pop()
+                    buffer += char
+                    continue
+                if buffer.strip():
+                    results.append(['declaration', buffer.strip(), buffer_line or line, line,
+                                    [p for p, _, _ in stack if p != '#{']])
+                if stack:
+                    _, _, index = stack.pop()
+                    results[index][3] = line
+                buffer, buffer_line = '', None
+                continue
+            elif char == ';':
+                if buffer.strip():
+                    results.append(['declaration', buffer.strip(), buffer_line or line, line,
+                                    [p for p, _, _ in stack if p != '#{']])
+                buffer, buffer_line = '', None
+                continue
+
+            if buffer_line is None and not char.isspace():
+                buffer_line = line
+            buffer += char
+
+        for kind, text, start, end, parents in results:
+            yield kind, text, start, end, parents
+
+    def _strip_comments(self, source: str) -> str:
+        """Blank out comments, keeping newlines so line numbers stay valid."""
+        def blank(match):
+            return re.sub(r'[^\n]', ' ', match.group(0))
+
+        pattern = r'/\*.*?\*/'
+        if self.scss:
+            # // comments, but not inside url(http://...)
+            pattern += r'|(?<![:\w])//[^\n]*'
+        return re.sub(pattern, blank, source, flags=re.S)
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a rule, mixin or media query block by name."""
+        structure = self.get_structure()
+
+        for items in structure.values():
+            for item in items:
+                if item.get('name') == name and 'line_end' in item:
+                    return {
+                        'name': name,
+                        'line_start': item['line'],
+                        'line_end': item['line_end'],
+                        'source': '\n'.join(self.lines[item['line'] - 1:item['line_end']]),
+                    }
+
+        return super().extract_element(element_type, name)
+
+
+@register('.scss', name='SCSS', icon='')
+class SCSSAnalyzer(CSSAnalyzer):
+    """SCSS stylesheet analyzer.
+
+    Adds $variables, @mixin/@function definitions, @use/@forward imports,
+    and resolves nested selectors ('&:hover' under '.btn' -> '.btn:hover').
+    """
+
+    scss = True
@@ -0,0 +1,142 @@
+"""CSV and TSV file analyzer."""
+
+import csv
+import io
+import re
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+@register('.csv', '.tsv', name='CSV', icon='')
+class CsvAnalyzer(FileAnalyzer):
+    """CSV/TSV schema preview.
+
+    Shows the table's shape without loading it elsewhere:
+    - Delimiter (sniffed from the first lines; `,` `\\t` `;` `|`), row
+      count and whether the first row is a header
+    - Columns with types inferred from a sample of rows (integer, float,
+      bool, date, datetime, string); a trailing `?` marks columns with
+      empty cells in the sample
+    """
+
+    DELIMITERS = ',\t;|'
+    # Characters given to the delimiter sniffer
+    SNIFF_SIZE = 64 * 1024
+    # Data rows examined when inferring column types
+    SAMPLE_ROWS = 100
+
+    # Most specific first; a column takes the first type all its sampled values match
+    TYPES = (
+        ('integer', re.compile(r'^[+-]?\d+$')),
+        ('float', re.compile(r'^[+-]?(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?$')),
+        ('bool', re.compile(r'^(?:true|false|yes|no)$', re.IGNORECASE)),
+        ('date', re.compile(r'^\d{4}-\d{2}-\d{2}$')),
+        ('datetime', re.compile(r'^\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2})?$')),
+    )
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract the table summary and column schema."""
+        table = self._table()
+        if not table:
+            return {}
+
+        columns = []
+        for index, name in enumerate(table['columns']):
+            column_type = table['types'][index]
+            columns.append({
+                'line': 1,
+                'name': name,
+                'type': column_type,
+                'signature': f": {column_type}",
+            })
+
+        if head or tail or range:
+            columns = self._apply_semantic_slice(columns, head, tail, range)
+
+        delimiter = '\\t' if table['delimiter'] == '\t' else table['delimiter']
+        summary = (f"{table['rows']} rows × {len(table['columns'])} columns, delimiter '{delimiter}'"
+                   f"{'' if table['header'] else ', no header'}")
+        return {
+            'table': [{
+                'line': 1,
+                'content': summary,
+                'rows': table['rows'],
+                'delimiter': table['delimiter'],
+                'header': table['header'],
+            }],
+            'columns': columns,
+        }
+
+    def _table(self) -> Optional[Dict[str, Any]]:
+        """Delimiter, header, column names, inferred types and row count."""
+        if not self.content.strip():
+            return None
+
+        delimiter = self._delimiter()
+        reader = csv.reader(io.StringIO(self.content), delimiter=delimiter)
+        first = next(reader, None)
+        if first is None:
+            return None
+
+        sample = []
+        rows = 0
+        for row in reader:
+            if not any(cell.strip() for cell in row):
+                continue
+            if len(sample) < self.SAMPLE_ROWS:
+                sample.append(row)
+            rows += 1
+
+        width = max([len(first)] + [len(row) for row in sample])
+        types = [self._column_type([row[i] if i < len(row) else '' for row in sample]) for i in range(width)]
+        header = self._has_header(first, types)
+        if header:
+            columns = [name.strip() or f"column{i + 1}" for i, name in enumerate(first)]
+            columns += [f"column{i + 1}" for i in range(len(columns), width)]
+        else:
+            columns = [f"column{i + 1}" for i in range(width)]
+            types = [self._column_type([row[i] if i < len(row) else '' for row in [first] + sample])
+                     for i in range(width)]
+            rows += 1
+
+        return {'delimiter': delimiter, 'header': header, 'columns': columns, 'types': types, 'rows': rows}
+
+    def _delimiter(self) -> str:
+        """Sniff the delimiter; fall back to the extension's convention."""
+        default = '\t' if self.path.suffix.lower() == '.tsv' else ','
+        sample = self.content[:self.SNIFF_SIZE]
+        if '\n' in sample:
+            # Drop a possibly truncated last line
+            sample = sample[:sample.rindex('\n')]
+        try:
+            return csv.Sniffer().sniff(sample, delimiters=self.DELIMITERS).delimiter
+        except csv.Error:
+            return default
+
+    def _column_type(self, values: List[str]) -> str:
+        """First type matching every non-empty value; '?' suffix when some are empty."""
+        present = [v.strip() for v in values if v.strip()]
+        optional = '?' if len(present) < len(values) else ''
+        if not present:
+            return 'empty'
+        for name, pattern in self.TYPES:
+            if all(pattern.match(v) for v in present):
+                return name + optional
+        return 'string' + optional
+
+    def _has_header(self, first: List[str], types: List[str]) -> bool:
+        """A first row whose cells all fit their typed columns is data, not a header."""
+        patterns = dict(self.TYPES)
+        typed = [(cell.strip(), patterns[column_type.rstrip('?')])
+                 for cell, column_type in zip(first, types) if column_type.rstrip('?') in patterns]
+        if not typed:
+            return True
+        return not all(not cell or pattern.match(cell) for cell, pattern in typed)
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Table shape for directory trees: '5 columns, 1200 rows'."""
+        table = self._table()
+        if not table:
+            return None
+        return f"{len(table['columns'])} columns, {table['rows']} rows"
@@ -0,0 +1,159 @@
+"""Dart / Flutter file analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_TYPE = r'[\w$.]+(?:<[\w$<>?, .]*>)?\??'
+
+
+@register('.dart', name='Dart', icon='')
+class DartAnalyzer(RegexAnalyzer):
+    """Dart file analyzer with Flutter widget detection.
+
+    Extracts:
+    - import / export / part directives
+    - Classes, mixins, extensions and enums
+    - Widgets: classes extending StatelessWidget, StatefulWidget (and
+      their State<T> classes) or other *Widget base classes
+    - Top-level functions and class members (methods, getters/setters,
+      constructors) as Class.member; `_names` are library-private
+    """
+
+    # Base classes that make a class a widget, and the kind shown
+    WIDGET_BASES = {
+        'StatelessWidget': 'stateless',
+        'StatefulWidget': 'stateful',
+        'ConsumerWidget': 'consumer',
+        'ConsumerStatefulWidget': 'stateful',
+        'HookWidget': 'hook',
+        'HookConsumerWidget': 'hook',
+        'InheritedWidget': 'inherited',
+    }
+
+    KEYWORDS = {'if', 'for', 'while', 'switch', 'catch', 'return', 'else', 'do', 'try',
+                'assert', 'super', 'this', 'new', 'await', 'throw', 'on'}
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>(?:import|export|part(?:\s+of)?)\s+[\'"].*?[\'"].*?);?\s*$'),
+        'classes': re.compile(
+            r'^\s*(?P<modifiers>(?:(?:abstract|base|final|interface|sealed|mixin)\s+)*)'
+            r'(?P<kind>class|mixin|enum|extension(?:\s+type)?)\s+(?P<name>\w+)?(?:<[^{]*?>)?'
+            r'(?P<signature>\s*(?:on|extends|with|implements)\b[^{]*)?'
+        ),
+        'functions': re.compile(
+            r'^\s*(?:@\w+(?:\([^)]*\))?\s+)*(?P<modifiers>(?:(?:static|external|factory|const|late|final)\s+)*)'
+            r'(?:(?P<returns>' + _TYPE + r')\s+)?(?:(?P<accessor>get|set|operator)\s+)?'
+            r'(?P<name>[\w$]+(?:\.[\w$]+)?|[<>=+\-*/%~\[\]]+)\s*(?P<params>\([^;]*?\))?'
+            r'\s*(?:async\*?|sync\*)?\s*(?P<body>\{|=>|:|;)'
+        ),
+    }
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; split widgets from classes, members from functions."""
+        self._depths = self._line_depths()
+        structure = super().get_structure(**kwargs)
+
+        classes = structure.pop('classes', [])
+        structure['widgets'] = [c for c in classes if 'widget' in c]
+        structure['classes'] = [c for c in classes if 'widget' not in c]
+
+        functions = structure.pop('functions', [])
+        for function in functions:
+            owner = next((c for c in classes
+                          if c['line'] < function['line'] <= c.get('line_end', c['line'])), None)
+            if owner:
+                function['class'] = owner['name']
+                if function['name'] == owner['name'] or function['name'].startswith(owner['name'] + '.'):
+                    function.setdefault('kind', 'constructor')
+                else:
+                    function['name'] = f"{owner['name']}.{function['name']}"
+        # Depth-1 matches outside classes are statements in function bodies
+        structure['functions'] = [f for f in functions
+                                  if 'class' not in f and self._depths[f['line'] - 1] == 0]
+        structure['methods'] = [f for f in functions if 'class' in f]
+
+        ordered = {}
+        for category in ('imports', 'widgets', 'classes', 'methods', 'functions'):
+            ordered[category] = structure.get(category, [])
+
+        if head or tail or range:
+            for category in ordered:
+                ordered[category] = self._apply_semantic_slice(
+                    ordered[category], head, tail, range
+                )
+
+        return {k: v for k, v in ordered.items() if v}
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Detect widgets; keep only top-level functions and direct class members."""
+        if category == 'imports':
+            return super()._make_entry(category, match, line_no)
+
+        depth = self._depths[line_no - 1]
+
+        if category == 'classes':
+            if depth != 0 or not match.group('name'):
+                return None
+            entry = super()._make_entry(category, match, line_no)
+            kind = entry.pop('kind')
+            modifiers = entry.pop('modifiers', '').split()
+            if kind != 'class' or modifiers:
+                entry['kind'] = ' '.join(modifiers + [kind])
+            heritage = entry.get('signature', '').strip()
+            if heritage:
+                entry['signature'] = f" {' '.join(heritage.split())}"
+            self._detect_widget(entry, heritage)
+            entry['visibility'] = self._visibility(entry['name'])
+            return entry
+
+        # functions
+        name = match.group('name')
+        if depth > 1 or name in self.KEYWORDS:
+            return None
+        if match.group('params') is None and not match.group('accessor'):
+            return None
+        if match.group('body') in (':', ';') and not (match.group('params') and depth == 1):
+            # Initializer lists and abstract/redirecting members only exist in classes
+            return None
+
+        entry = super()._make_entry(category, match, line_no)
+        entry.pop('body', None)
+        accessor = entry.pop('accessor', None)
+        returns = entry.pop('returns', None)
+        modifiers = entry.pop('modifiers', '').split()
+        params = entry.pop('params', '' if accessor == 'get' else '()')
+
+        entry['signature'] = params + (f" -> {returns}" if returns else '')
+        if accessor in ('get', 'set'):
+            entry['kind'] = f"{accessor}ter"
+        elif 'factory' in modifiers:
+            entry['kind'] = 'factory'
+        if modifiers:
+            entry['modifiers'] = modifiers
+        entry['visibility'] = self._visibility(entry['name'])
+        return entry
+
+    def _detect_widget(self, entry: Dict[str, Any], heritage: str) -> None:
+        """Mark Flutter widgets and State classes."""
+        extends = re.search(r'\bextends\s+([\w$.]+)(?:<\s*([\w$]+)\s*>)?', heritage)
+        if not extends:
+            return
+
+        base, type_arg = extends.group(1), extends.group(2)
+        if base in self.WIDGET_BASES:
+            entry['widget'] = self.WIDGET_BASES[base]
+        elif base in ('State', 'ConsumerState') and type_arg:
+            entry['widget'] = 'state'
+            entry['state_of'] = type_arg
+        elif base.endswith('Widget'):
+            entry['widget'] = 'widget'
+
+    @staticmethod
+    def _visibility(name: str) -> str:
+        """Dart privacy is by name: a leading underscore is library-private."""
+        member = name.split('.')[-1]
+        return 'private' if member.startswith('_') else 'public'
@@ -1,15 +1,20 @@
 """Dockerfile analyzer."""
 
+import json
 import re
 from typing import Dict, List, Any, Optional
 from ..base import FileAnalyzer, register
 
 
-@register('Dockerfile', name='Dockerfile', icon='')
+@register('Dockerfile', 'Containerfile', '.dockerfile', name='Dockerfile', icon='')
 class DockerfileAnalyzer(FileAnalyzer):
     """Dockerfile analyzer.
 
     Extracts Docker directives (FROM, RUN, COPY, ENV, EXPOSE, etc.).
+
+    Multi-stage builds are summarized under 'stages': each stage's base
+    image, exposed ports, COPY/ADD sources (including --from stages)
+    and its ENTRYPOINT/CMD.
     """
 
     def get_structure(self) -> Dict[str, List[Dict[str, Any]]]:
@@ -64,6 +69,9 @@ class DockerfileAnalyzer(FileAnalyzer):
 
         # Build result
         result = {}
+        stages = self._build_stages(from_images, copies, exposes, entrypoints, cmds)
+        if stages:
+            result['stages'] = stages
         if from_images:
             result['from'] = from_images
         if runs:
@@ -119,6 +127,7 @@ class DockerfileAnalyzer(FileAnalyzer):
             copies.append({
                 'line': line_num,
                 'content': args_str,
+                **self._parse_copy(args_str),
             })
 
         elif directive == 'ENV':
@@ -165,6 +174,92 @@ class DockerfileAnalyzer(FileAnalyzer):
                 'content': args_str,
             })
 
+    def _parse_copy(self, args_str: str) -> Dict[str, Any]:
+        """Split COPY/ADD arguments into sources, destination and --from stage."""
+        parsed = {}
+        parts = self._split_args(args_str)
+
+        while parts and parts[0].startswith('--'):
+            flag = parts.pop(0)
+            if flag.startswith('--from='):
+                parsed['from_stage'] = flag.split('=', 1)[1]
+
+        if len(parts) >= 2:
+            parsed['sources'] = parts[:-1]
+            parsed['dest'] = parts[-1]
+        return parsed
+
+    @staticmethod
+    def _split_args(args_str: str) -> List[str]:
+        """Split instruction arguments in shell form or JSON (exec) form."""
+        if args_str.startswith('['):
+            try:
+                parts = json.loads(args_str)
+                if isinstance(parts, list):
+                    return [str(p) for p in parts]
+            except ValueError:
+                pass
+        return args_str.split()
+
+    def _build_stages(self, from_images, copies, exposes, entrypoints, cmds) -> List[Dict[str, Any]]:
+        """Summarize each build stage (FROM ... up to the next FROM)."""
+        stages = []
+
+        for index, entry in enumerate(from_images):
+            match = re.match(r'^(?:--\S+\s+)*(\S+)(?:\s+AS\s+(\S+))?', entry['name'], re.IGNORECASE)
+            if not match:
+                continue
+
+            start = entry['line']
+            end = from_images[index + 1]['line'] - 1 if index + 1 < len(from_images) else len(self.lines)
+            in_stage = lambda items: [i for i in items if start <= i['line'] <= end]
+
+            stage = {
+                'line': start,
+                'line_end': end,
+                'name': match.group(2) or f"stage {index}",
+                'base': match.group(1),
+            }
+
+            ports = [port for e in in_stage(exposes) for port in e['content'].split()]
+            sources = [source for c in in_stage(copies) for source in c.get('sources', [])]
+            from_stages = [c['from_stage'] for c in in_stage(copies) if 'from_stage' in c]
+            entrypoint = in_stage(entrypoints)
+            cmd = in_stage(cmds)
+
+            details = [f"FROM {stage['base']}"]
+            if ports:
+                stage['ports'] = ports
+                details.append(f"expose {' '.join(ports)}")
+            if sources:
+                stage['copy_sources'] = sources
+            if from_stages:
+                stage['copies_from'] = list(dict.fromkeys(from_stages))
+                details.append(f"copies from {', '.join(stage['copies_from'])}")
+            if entrypoint:
+                stage['entrypoint'] = entrypoint[-1]['content']
+                details.append(f"ENTRYPOINT {stage['entrypoint']}")
+            if cmd:
+                stage['cmd'] = cmd[-1]['content']
+                details.append(f"CMD {stage['cmd']}")
+
+            stage['signature'] = f" ({'; '.join(details)})"
+            stages.append(stage)
+
+        return stages
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Base images and exposed ports: 'node:18 -> nginx:alpine, expose 80'."""
+        stages = self.get_structure().get('stages', [])
+        if not stages:
+            return None
+
+        summary = ' -> '.join(stage['base'] for stage in stages)
+        ports = stages[-1].get('ports')
+        if ports:
+            summary += f", expose {' '.join(ports)}"
+        return summary
+
     def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
         """Extract a specific directive or stage.
 
@@ -175,5 +270,15 @@ class DockerfileAnalyzer(FileAnalyzer):
         Returns:
             Dict with directive content
         """
+        # Build stages by name (e.g., 'builder')
+        for stage in self.get_structure().get('stages', []):
+            if stage['name'] == name:
+                return {
+                    'name': name,
+                    'line_start': stage['line'],
+                    'line_end': stage['line_end'],
+                    'source': '\n'.join(self.lines[stage['line'] - 1:stage['line_end']]),
+                }
+
         # Fall back to grep-based search
         return super().extract_element(element_type, name)
@@ -0,0 +1,283 @@
+"""Elixir file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+# Callbacks recognized by name when the module `use`s the behaviour
+BEHAVIOUR_CALLBACKS = {
+    'GenServer': {
+        'init/1', 'handle_call/3', 'handle_cast/2', 'handle_info/2',
+        'handle_continue/2', 'terminate/2', 'code_change/3', 'format_status/1', 'format_status/2',
+    },
+    'Supervisor': {'init/1'},
+    'Agent': set(),
+    'Application': {'start/2', 'stop/1', 'prep_stop/1', 'config_change/3'},
+}
+
+
+@register('.ex', '.exs', name='Elixir', icon='')
+class ElixirAnalyzer(TreeSitterAnalyzer):
+    """Elixir file analyzer.
+
+    Extracts:
+    - Modules (nested modules are named Outer.Inner)
+    - use / import / alias / require directives
+    - def/defp functions as name/arity (multi-clause functions are
+      merged; default arguments noted), public or private
+    - defmacro/defmacrop macros
+    - Behaviour callbacks: functions marked @impl, or known GenServer
+      (Supervisor, Application) callbacks in modules that `use` them
+
+    Everything in Elixir is a call: definitions are `call` nodes whose
+    target is defmodule, def, defp, ... and module attributes are `@`
+    unary operators.
+    """
+    language = 'elixir'
+
+    DIRECTIVES = ('use', 'import', 'alias', 'require')
+    # Attributes that may sit between @impl and its def
+    DEF_ATTRIBUTES = ('doc', 'spec', 'since', 'deprecated')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract modules, directives, merged function clauses and callbacks."""
+        if not self.tree:
+            return {}
+
+        functions = self._merge_clauses(self._definitions(('def', 'defp')))
+        structure = {
+            'modules': self._extract_modules(),
+            'imports': self._extract_imports(),
+            'macros': self._merge_clauses(self._definitions(('defmacro', 'defmacrop'))),
+            'functions': [f for f in functions if not f.get('behaviour')],
+            'callbacks': [f for f in functions if f.get('behaviour')],
+        }
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_modules(self) -> List[Dict[str, Any]]:
+        """Extract defmodule blocks, nested ones named Outer.Inner."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'line_end': node.end_point[0] + 1,
+            'name': self._module_name(node),
+        } for node in self._calls(('defmodule',))]
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract use / import / alias / require directives naming a module."""
+        imports = []
+
+        for node in self._calls(self.DIRECTIVES):
+            arguments = self._arguments(node)
+            if not arguments or arguments[0].type != 'alias':
+                continue
+            imports.append({
+                'line': node.start_point[0] + 1,
+                'content': self._get_node_text(node).split('\n')[0].strip(),
+            })
+
+        return imports
+
+    def _definitions(self, kinds: Tuple[str, ...]) -> List[Dict[str, Any]]:
+        """Entries for def-like calls of the given kinds, named name/arity."""
+        entries = []
+
+        for node in self._calls(kinds):
+            arguments = self._arguments(node)
+            if not arguments:
+                continue
+            head = arguments[0]
+            # def name(args) when guard
+            if head.type == 'binary_operator' and self._operator(head) == 'when':
+                head = head.child_by_field_name('left')
+            if head is None or head.type not in ('call', 'identifier'):
+                continue
+
+            if head.type == 'call':
+                target = head.child_by_field_name('target')
+                name = self._get_node_text(target) if target is not None else ''
+                params = [self._get_node_text(param) for param in self._arguments(head)]
+            else:
+                name = self._get_node_text(head)
+                params = []
+            if not name:
+                continue
+
+            kind = self._get_node_text(node.child_by_field_name('target'))
+            entry = {
+                'line': node.start_point[0] + 1,
+                'name': f"{name}/{len(params)}",
+                'signature': f" ({', '.join(params)})" if params else '',
+                'arity': len(params),
+            }
+            defaults = sum(1 for p in params if '\\\\' in p)
+            if defaults:
+                entry['min_arity'] = len(params) - defaults
+            entry['visibility'] = 'private' if kind.endswith('p') else 'public'
+
+            line_end = node.end_point[0] + 1
+            if line_end > entry['line']:
+                entry['line_end'] = line_end
+                entry['line_count'] = line_end - entry['line'] + 1
+
+            entry['impl'] = self._impl_of(node)
+            module = self._enclosing_module(node)
+            if module is not None:
+                entry['module'] = self._module_name(module)
+                entry['_behaviours'] = self._used_behaviours(module)
+            entries.append(entry)
+
+        return entries
+
+    def _merge_clauses(self, functions: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        """Merge consecutive clauses of the same name/arity into one entry."""
+        merged = []
+        by_key = {}
+
+        for function in functions:
+            key = (function.get('module'), function['name'])
+            if key in by_key:
+                first = by_key[key]
+                first['clauses'] = first.get('clauses', 1) + 1
+                end = function.get('line_end', function['line'])
+                first['line_end'] = max(first.get('line_end', first['line']), end)
+                first['line_count'] = first['line_end'] - first['line'] + 1
+                first['impl'] = first['impl'] or function['impl']
+                continue
+
+            by_key[key] = function
+            merged.append(function)
+
+        for function in merged:
+            behaviour = self._behaviour(function, function.pop('_behaviours', []))
+            if behaviour:
+                function['behaviour'] = behaviour
+            del function['impl']
+
+        return merged
+
+    @staticmethod
+    def _behaviour(function: Dict[str, Any], used: List[str]) -> Optional[str]:
+        """Behaviour a function implements, if it's a callback."""
+        for behaviour in used:
+            if function['name'] in BEHAVIOUR_CALLBACKS.get(behaviour, ()):
+                return behaviour
+        if function['impl']:
+            return function['impl'] if function['impl'] is not True else (used[0] if used else 'behaviour')
+        return None
+
+    def _used_behaviours(self, module) -> List[str]:
+        """Behaviours `use`d (or @behaviour-declared) directly in a module."""
+        behaviours = []
+
+        for child in self._body(module):
+            if self._call_name(child) == 'use':
+                arguments = self._arguments(child)
+            else:
+                attribute = self._attribute(child)
+                if attribute is None or self._call_name(attribute) != 'behaviour':
+                    continue
+                arguments = self._arguments(attribute)
+            if arguments and arguments[0].type == 'alias':
+                behaviours.append(self._get_node_text(arguments[0]))
+
+        return behaviours
+
+    def _impl_of(self, node):
+        """@impl above a def: True, or the behaviour name for `@impl Mod`."""
+        siblings = [child for child in node.parent.children if child.is_named] if node.parent is not None else []
+        index = next((i for i, child in enumerate(siblings) if child.start_byte == node.start_byte), 0)
+
+        for previous in reversed(siblings[:index]):
+            if previous.type == 'comment':
+                continue
+            attribute = self._attribute(previous)
+            name = self._call_name(attribute) if attribute is not None else None
+            if name == 'impl':
+                arguments = self._arguments(attribute)
+                value = self._get_node_text(arguments[0]) if arguments else 'true'
+                return True if value == 'true' else value
+            if name in self.DEF_ATTRIBUTES:
+                continue
+            return False
+        return False
+
+    def _calls(self, names: Tuple[str, ...]) -> List:
+        """Call nodes whose target is one of names, in file order."""
+        return [node for node in self._find_nodes_by_type('call') if self._call_name(node) in names]
+
+    def _call_name(self, node) -> Optional[str]:
+        """The identifier a call node calls (def, use, ...)."""
+        if node is None or node.type != 'call':
+            return None
+        target = node.child_by_field_name('target')
+        if target is None or target.type != 'identifier':
+            return None
+        return self._get_node_text(target)
+
+    def _arguments(self, call) -> List:
+        """Named argument nodes of a call (keyword lists like `do: x` excluded)."""
+        for child in call.children:
+            if child.type == 'arguments':
+                return [arg for arg in child.children if arg.is_named and arg.type != 'keywords']
+        return []
+
+    def _body(self, call) -> List:
+        """Named expressions in a call's do ... end block."""
+        for child in call.children:
+            if child.type == 'do_block':
+                return [expr for expr in child.children if expr.is_named]
+        return []
+
+    def _attribute(self, node):
+        """The call inside a module attribute (`@impl true` -> impl(true)), else None."""
+        if node.type != 'unary_operator' or self._operator(node) != '@':
+            return None
+        operand = node.child_by_field_name('operand')
+        return operand if operand is not None and operand.type == 'call' else None
+
+    def _operator(self, node) -> str:
+        operator = node.child_by_field_name('operator')
+        return self._get_node_text(operator) if operator is not None else ''
+
+    def _enclosing_module(self, node):
+        """Innermost defmodule call containing a node."""
+        parent = node.parent
+        while parent is not None:
+            if self._call_name(parent) == 'defmodule':
+                return parent
+            parent = parent.parent
+        return None
+
+    def _module_name(self, module) -> str:
+        """Full name of a defmodule call, prefixed by the modules around it."""
+        arguments = self._arguments(module)
+        name = self._get_node_text(arguments[0]) if arguments else '?'
+        outer = self._enclosing_module(module)
+        return f"{self._module_name(outer)}.{name}" if outer is not None else name
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract by name/arity, or by bare name (first arity)."""
+        structure = self.get_structure()
+
+        for items in structure.values():
+            for item in items:
+                item_name = item.get('name', '')
+                if item_name == name or item_name.split('/')[0] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item_name,
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
@@ -0,0 +1,238 @@
+"""Erlang file analyzer - tree-sitter based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+# Known OTP behaviour callbacks (name/arity)
+BEHAVIOUR_CALLBACKS = {
+    'gen_server': {
+        'init/1', 'handle_call/3', 'handle_cast/2', 'handle_info/2',
+        'handle_continue/2', 'terminate/2', 'code_change/3', 'format_status/1', 'format_status/2',
+    },
+    'gen_statem': {
+        'init/1', 'callback_mode/0', 'handle_event/4', 'terminate/3', 'code_change/4', 'format_status/1',
+    },
+    'gen_event': {
+        'init/1', 'handle_event/2', 'handle_call/2', 'handle_info/2', 'terminate/2', 'code_change/3',
+    },
+    'supervisor': {'init/1'},
+    'supervisor_bridge': {'init/1', 'terminate/2'},
+    'application': {'start/2', 'stop/1', 'prep_stop/1', 'config_change/3', 'start_phase/3'},
+}
+
+
+@register('.erl', '.hrl', '.escript', name='Erlang', icon='')
+class ErlangAnalyzer(TreeSitterAnalyzer):
+    """Erlang file analyzer.
+
+    Extracts:
+    - -module and -behaviour declarations
+    - Exported functions (each name/arity from -export lists)
+    - -import, -include and -include_lib directives
+    - Records (with fields), types and macros
+    - Functions as name/arity with clause count, exported or private,
+      and their -spec when present
+    - OTP callbacks: functions a declared behaviour (gen_server,
+      supervisor, application, ...) expects, listed separately
+
+    Forms, clauses and argument lists come from the tree; attribute
+    details are read from the form's text, comments left out.
+    """
+    language = 'erlang'
+
+    ATTRIBUTE = re.compile(r'^-\s*(?P<name>[a-z_]+)\s*(?P<paren>\()?(?P<body>.*)$', re.DOTALL)
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract module attributes and functions."""
+        if not self.tree:
+            return {}
+
+        structure = {
+            'module': [],
+            'behaviours': [],
+            'exports': [],
+            'imports': [],
+            'records': [],
+            'types': [],
+            'macros': [],
+        }
+        specs = {}
+        functions = []
+        export_all = False
+
+        for node in self.tree.root_node.named_children:
+            if node.type == 'fun_decl':
+                entry = self._function_entry(node)
+                if entry:
+                    functions.append(entry)
+                continue
+
+            attribute = self.ATTRIBUTE.match(self._code_text(node))
+            if not attribute:
+                continue
+            body = attribute.group('body').strip().rstrip('.').strip()
+            if attribute.group('paren') and body.endswith(')'):
+                body = body[:-1].strip()
+            if attribute.group('name') == 'compile' and 'export_all' in body:
+                export_all = True
+            self._add_attribute(structure, specs, attribute.group('name'), body, node)
+
+        exported = {e['name'] for e in structure['exports']}
+        behaviours = [b['name'] for b in structure['behaviours']]
+
+        for function in functions:
+            function['visibility'] = 'public' if export_all or function['name'] in exported else 'private'
+            if function['name'] in specs:
+                function['spec'] = specs[function['name']]
+                function['signature'] = f" :: {specs[function['name']]}"
+            else:
+                function['signature'] = f" ({function.pop('params')})"
+            function.pop('params', None)
+            behaviour = next((b for b in behaviours if function['name'] in BEHAVIOUR_CALLBACKS.get(b, ())), None)
+            if behaviour:
+                function['behaviour'] = behaviour
+
+        structure['callbacks'] = [f for f in functions if f.get('behaviour')]
+        structure['functions'] = [f for f in functions if not f.get('behaviour')]
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _add_attribute(self, structure: Dict[str, List[Dict[str, Any]]], specs: Dict[str, str],
+                       name: str, body: str, node) -> None:
+        """Route a -attribute(...) form to its category."""
+        line = node.start_point[0] + 1
+        line_end = node.end_point[0] + 1
+
+        if name == 'module':
+            structure['module'].append({'line': line, 'name': body})
+
+        elif name in ('behaviour', 'behavior'):
+            structure['behaviours'].append({'line': line, 'name': body})
+
+        elif name == 'export':
+            for function in self._children(node, 'fa'):
+                structure['exports'].append({'line': function.start_point[0] + 1,
+                                             'name': self._function_ref(function)})
+
+        elif name == 'import':
+            module = body.partition(',')[0].strip()
+            names = [self._function_ref(function) for function in self._children(node, 'fa')]
+            structure['imports'].append({'line': line, 'content': f"{module}: {', '.join(names)}"})
+
+        elif name in ('include', 'include_lib'):
+            structure['imports'].append({'line': line, 'content': f"-{name}({body})"})
+
+        elif name == 'record':
+            record = body.partition(',')[0]
+            field_names = [self._get_node_name(field) for field in self._children(node, 'record_field')]
+            field_names = [field for field in field_names if field]
+            entry = {'line': line, 'name': record.strip(), 'fields': field_names,
+                     'signature': f" {{{', '.join(field_names)}}}"}
+            if line_end > line:
+                entry['line_end'] = line_end
+            structure['records'].append(entry)
+
+        elif name in ('type', 'opaque'):
+            match = re.match(r'([a-z][\w@]*)\s*\(([^)]*)\)\s*::\s*(.+)', body, re.DOTALL)
+            if match:
+                definition = ' '.join(match.group(3).split())
+                if len(definition) > 60:
+                    definition = definition[:57] + '...'
+                entry = {'line': line, 'name': f"{match.group(1)}({match.group(2).strip()})",
+                         'signature': f" :: {definition}"}
+                if name == 'opaque':
+                    entry['signature'] = '  (opaque)'
+                structure['types'].append(entry)
+
+        elif name == 'define':
+            match = re.match(r"([\w@']+)", body)
+            if match:
+                structure['macros'].append({'line': line, 'name': f"?{match.group(1)}"})
+
+        elif name == 'spec':
+            match = re.match(r"([a-z][\w@]*)\s*(\(.*)", body, re.DOTALL)
+            args = self._find_descendants(node, 'expr_args')
+            if match and args:
+                specs[f"{match.group(1)}/{self._arity(args[0])}"] = ' '.join(match.group(2).split())
+
+    def _function_entry(self, node) -> Optional[Dict[str, Any]]:
+        """name/arity entry for a function declaration, with its clause count."""
+        clauses = self._children(node, 'function_clause')
+        if not clauses:
+            return None
+        name = self._get_node_name(clauses[0])
+        args = next((child for child in clauses[0].children if child.type == 'expr_args'), None)
+        if not name or args is None:
+            return None
+
+        start = node.start_point[0] + 1
+        end = node.end_point[0] + 1
+        entry = {
+            'line': start,
+            'name': f"{name}/{self._arity(args)}",
+            'params': ' '.join(self._code_text(args)[1:-1].split()),
+        }
+        if len(clauses) > 1:
+            entry['clauses'] = len(clauses)
+        if end > start:
+            entry['line_end'] = end
+            entry['line_count'] = end - start + 1
+        return entry
+
+    def _get_node_name(self, node) -> Optional[str]:
+        """Name of a function clause or record field: its leading atom."""
+        name = node.child_by_field_name('name')
+        if name is None:
+            name = next((child for child in node.named_children if child.type == 'atom'), None)
+        return self._get_node_text(name) if name is not None else None
+
+    @staticmethod
+    def _children(node, node_type: str) -> List:
+        """Direct children of a given type (a record's fields, not those of records in its defaults)."""
+        return [child for child in node.named_children if child.type == node_type]
+
+    @staticmethod
+    def _arity(args) -> int:
+        """Number of arguments in an argument list node."""
+        return sum(1 for child in args.named_children if child.type != 'comment')
+
+    def _function_ref(self, node) -> str:
+        """name/arity of an export or import list entry."""
+        return re.sub(r'\s+', '', self._code_text(node))
+
+    def _code_text(self, node) -> str:
+        """The node's text with the comments inside it left out."""
+        text = self.content.encode('utf-8')
+        parts, position = [], node.start_byte
+        for comment in self._find_descendants(node, 'comment'):
+            parts.append(text[position:comment.start_byte])
+            position = comment.end_byte
+        parts.append(text[position:node.end_byte])
+        return b''.join(parts).decode('utf-8', errors='replace').strip()
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a function by name/arity, or by bare name (all arities' first match)."""
+        structure = self.get_structure()
+
+        for category in ('functions', 'callbacks', 'records', 'types'):
+            for item in structure.get(category, []):
+                if item['name'] == name or item['name'].split('/')[0] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item['name'],
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
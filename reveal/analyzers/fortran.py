@@ -0,0 +1,269 @@
+"""Fortran file analyzer (fixed and free form) - tree-sitter based."""
+
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+_PREFIX = (
+    r'(?:(?:pure|impure|elemental|recursive|non_recursive|module)\s+'
+    r'|(?P<type>(?:integer|real|double\s+precision|complex|logical|character|type|class)'
+    r'\s*(?:\([^)]*\)|\*\s*\d+)?)\s+)*'
+)
+
+
+@register('.f90', '.f95', '.f03', '.f08', '.f', '.for', '.f77', '.ftn', name='Fortran', icon='')
+class FortranAnalyzer(TreeSitterAnalyzer):
+    """Fortran file analyzer.
+
+    Extracts:
+    - Programs, modules and submodules
+    - use statements (with only: lists)
+    - Subroutines and functions with their arguments, result variable and
+      return type; procedures contained in a module or another procedure
+      note their parent
+    - Derived types (with extends) and named/abstract interfaces
+
+    Free form (.f90 and later) is parsed with the Fortran grammar and
+    fixed form (.f, .for, .f77) with its fixed-form variant. The tree gives
+    units, their nesting and extent; the opening statement (comments
+    dropped, continuation lines joined) is matched for the details.
+    Keywords are case-insensitive.
+    """
+    language = 'fortran'
+
+    FIXED_FORM_EXTENSIONS = ('.f', '.for', '.f77', '.ftn')
+
+    # Program units, procedures, types and interfaces: nodes opened by a statement and closed by `end`
+    UNIT_NODES = ('program', 'module', 'submodule', 'subroutine', 'function', 'derived_type_definition',
+                  'interface', 'block_data')
+
+    PROGRAM = re.compile(r'^program\s+(?P<name>\w+)', re.IGNORECASE)
+    MODULE = re.compile(r'^module\s+(?!procedure\b)(?P<name>\w+)\s*$', re.IGNORECASE)
+    SUBMODULE = re.compile(r'^submodule\s*\(\s*(?P<parent>[\w:]+)\s*\)\s*(?P<name>\w+)', re.IGNORECASE)
+    SUBROUTINE = re.compile(_PREFIX + r'subroutine\s+(?P<name>\w+)\s*(?:\((?P<args>[^)]*)\))?', re.IGNORECASE)
+    FUNCTION = re.compile(
+        _PREFIX + r'function\s+(?P<name>\w+)\s*\((?P<args>[^)]*)\)\s*(?:result\s*\(\s*(?P<result>\w+)\s*\))?',
+        re.IGNORECASE
+    )
+    TYPE = re.compile(
+        r'^type\s*(?:,\s*(?P<attributes>[^:]+?)\s*::\s*|::\s*|\s+(?!is\b))(?P<name>\w+)\s*(?:\([^)]*\))?\s*$',
+        re.IGNORECASE
+    )
+    INTERFACE = re.compile(r'^(?P<abstract>abstract\s+)?interface\b\s*(?P<name>.*)$', re.IGNORECASE)
+    BLOCK_DATA = re.compile(r'^block\s*data\b\s*(?P<name>\w*)', re.IGNORECASE)
+    USE = re.compile(
+        r'^use\s*(?:,\s*(?:non_)?intrinsic\s*)?(?:::)?\s*(?P<name>\w+)\s*(?P<rest>,.*)?$', re.IGNORECASE
+    )
+    PROCEDURE = re.compile(r'^(?:module\s+)?procedure\b\s*(?:::)?\s*(.+)$', re.IGNORECASE)
+
+    def __init__(self, path: str):
+        super().__init__(path)
+        if self.path.suffix.lower() in self.FIXED_FORM_EXTENSIONS:
+            self.language = 'fixed_form_fortran'
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract program units, procedures, types, interfaces and use statements."""
+        if not self.tree:
+            return {}
+
+        structure = {
+            'programs': [],
+            'modules': [],
+            'uses': [],
+            'types': [],
+            'interfaces': [],
+            'subroutines': [],
+            'functions': [],
+        }
+        self._collect(self.tree.root_node, structure, None)
+
+        for interface in structure['interfaces']:
+            if interface.get('procedures'):
+                interface['signature'] = f" ({', '.join(interface['procedures'])})"
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _collect(self, node, structure: Dict[str, List[Dict[str, Any]]], parent: Optional[Dict[str, Any]]) -> None:
+        """Add the units and use statements below node; parent is the innermost enclosing unit."""
+        for child in node.named_children:
+            if child.type == 'use_statement':
+                use = self.USE.match(self._statement_text(child))
+                if use:
+                    structure['uses'].append(self._use_entry(use, child.start_point[0] + 1, parent))
+                continue
+            if child.type not in self.UNIT_NODES:
+                self._collect(child, structure, parent)
+                continue
+
+            category, entry = self._unit_entry(child)
+            if category is None:
+                self._collect(child, structure, parent)
+                continue
+            if category == 'interfaces':
+                self._interface_procedures(child, entry)
+                # Unnamed interface blocks only declare external procedures
+                if entry['name']:
+                    structure[category].append(entry)
+                continue
+
+            if parent is not None:
+                entry['parent'] = parent['name']
+            structure[category].append(entry)
+            self._collect(child, structure, entry)
+
+    def _unit_entry(self, node) -> Tuple[Optional[str], Optional[Dict[str, Any]]]:
+        """Category and entry of a unit node, from its opening statement, with its extent."""
+        statement = next((child for child in node.named_children if child.type.endswith('_statement')), None)
+        if statement is None:
+            return None, None
+        category, entry = self._unit(self._statement_text(statement), node.start_point[0] + 1, node)
+        if entry is not None:
+            entry['line_end'] = self._line_end(node)
+            if entry['kind'] in ('subroutine', 'function'):
+                entry['line_count'] = entry['line_end'] - entry['line'] + 1
+        return category, entry
+
+    def _interface_procedures(self, node, interface: Dict[str, Any]) -> None:
+        """Procedures an interface block declares or names (module procedure a, b)."""
+        procedures = []
+        for child in node.named_children:
+            if child.type in ('subroutine', 'function'):
+                _, entry = self._unit_entry(child)
+                if entry is not None:
+                    procedures.append(entry['name'])
+            elif child.type.endswith('procedure_statement'):
+                procedure = self.PROCEDURE.match(self._statement_text(child))
+                if procedure:
+                    procedures.extend(name.strip() for name in procedure.group(1).split(',') if name.strip())
+        if procedures:
+            interface['procedures'] = procedures
+
+    def _unit(self, text: str, line: int, node) -> Tuple[Optional[str], Optional[Dict[str, Any]]]:
+        """Classify a statement that opens a program unit, procedure, type or interface."""
+        match = self.PROGRAM.match(text)
+        if match:
+            return 'programs', {'line': line, 'name': match.group('name'), 'kind': 'program'}
+
+        match = self.MODULE.match(text)
+        if match:
+            return 'modules', {'line': line, 'name': match.group('name'), 'kind': 'module'}
+
+        match = self.SUBMODULE.match(text)
+        if match:
+            return 'modules', {'line': line, 'name': match.group('name'), 'kind': 'submodule',
+                               'signature': f" ({match.group('parent')})"}
+
+        match = self.FUNCTION.match(text)
+        if match:
+            entry = {'line': line, 'name': match.group('name'), 'kind': 'function',
+                     'signature': f"({self._args(match.group('args'))})"}
+            if match.group('result'):
+                entry['result'] = match.group('result')
+            return_type = match.group('type') or self._declared_type(match.group('result') or match.group('name'), node)
+            if return_type:
+                entry['returns'] = ' '.join(return_type.split())
+                entry['signature'] += f" -> {entry['returns']}"
+            return 'functions', entry
+
+        match = self.SUBROUTINE.match(text)
+        if match:
+            return 'subroutines', {'line': line, 'name': match.group('name'), 'kind': 'subroutine',
+                                   'signature': f"({self._args(match.group('args') or '')})"}
+
+        match = self.TYPE.match(text)
+        if match:
+            entry = {'line': line, 'name': match.group('name'), 'kind': 'type'}
+            extends = re.search(r'extends\s*\(\s*(\w+)\s*\)', match.group('attributes') or '', re.IGNORECASE)
+            if extends:
+                entry['extends'] = extends.group(1)
+                entry['signature'] = f" extends {extends.group(1)}"
+            return 'types', entry
+
+        match = self.INTERFACE.match(text)
+        if match:
+            name = match.group('name').strip()
+            entry = {'line': line, 'name': name, 'kind': 'interface'}
+            if match.group('abstract'):
+                entry['abstract'] = True
+                entry['name'] = name or '(abstract)'
+            return 'interfaces', entry
+
+        match = self.BLOCK_DATA.match(text)
+        if match:
+            return 'programs', {'line': line, 'name': match.group('name') or '(block data)', 'kind': 'block data'}
+
+        return None, None
+
+    def _use_entry(self, match, line: int, scope: Optional[Dict[str, Any]]) -> Dict[str, Any]:
+        """use module[, only: a, b]."""
+        entry = {'line': line, 'name': match.group('name')}
+        rest = (match.group('rest') or '').lstrip(',').strip()
+        if rest:
+            entry['signature'] = f", {' '.join(rest.split())}"
+        if scope is not None:
+            entry['scope'] = scope['name']
+        return entry
+
+    @staticmethod
+    def _args(args: str) -> str:
+        return ', '.join(a.strip() for a in args.split(',') if a.strip())
+
+    def _declared_type(self, variable: str, node) -> Optional[str]:
+        """Type of a function result declared in the function body (e.g. `real :: area`)."""
+        pattern = re.compile(
+            r'^\s*(?P<type>(?:integer|real|double\s+precision|complex|logical|character|type|class)'
+            r'\s*(?:\([^)]*\)|\*\s*\d+)?)\s*(?:,[^:]*)?(?:::)?\s*(?:[\w\s,]*,\s*)?' + re.escape(variable) + r'\b',
+            re.IGNORECASE
+        )
+        for child in node.named_children:
+            if child.type == 'variable_declaration':
+                match = pattern.match(self._statement_text(child))
+                if match:
+                    return match.group('type')
+        return None
+
+    def _statement_text(self, node) -> str:
+        """A statement's text on one line: comments dropped and continuation lines joined."""
+        text = self.content.encode('utf-8')
+        parts, position = [], node.start_byte
+        for comment in self._find_descendants(node, 'comment'):
+            parts.append(text[position:comment.start_byte])
+            position = comment.end_byte
+        parts.append(text[position:node.end_byte])
+        lines = b''.join(parts).decode('utf-8', errors='replace').split('\n')
+        if self.language == 'fixed_form_fortran':
+            # Continuation lines mark column 6; statements end at column 72
+            return ' '.join([lines[0]] + [line[6:72] for line in lines[1:]]).strip()
+        return re.sub(r'\s*&\s*(?:&\s*)?', ' ', ' '.join(lines)).strip()
+
+    @staticmethod
+    def _line_end(node) -> int:
+        """Last line of a node (one whose extent runs to the start of the next line ends on the line before)."""
+        row, column = node.end_point
+        return row if column == 0 and row > node.start_point[0] else row + 1
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a procedure, module or type by (case-insensitive) name."""
+        structure = self.get_structure()
+
+        for category in ('subroutines', 'functions', 'modules', 'programs', 'types', 'interfaces'):
+            for item in structure.get(category, []):
+                if item['name'].lower() == name.lower():
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item['name'],
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
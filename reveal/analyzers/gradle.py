@@ -0,0 +1,317 @@
+"""Gradle build script analyzer (Groovy and Kotlin DSL)."""
+
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+
+
+@register('.gradle', 'build.gradle.kts', 'settings.gradle.kts', name='Gradle', icon='')
+class GradleAnalyzer(FileAnalyzer):
+    """Gradle build script analyzer.
+
+    Extracts:
+    - Plugins (plugins { } block and `apply plugin:`) with versions
+    - Dependencies grouped by configuration (implementation, api,
+      testImplementation, ...), including project(':x') dependencies
+    - Tasks (task x, tasks.register/create) with their type
+    - Repositories
+    - Subprojects included from settings.gradle, and the root project name
+
+    Works for both Groovy (build.gradle) and Kotlin DSL (build.gradle.kts).
+    """
+
+    PLUGIN = re.compile(
+        r'^(?:id\s*\(?\s*["\'](?P<id>[^"\']+)["\']\s*\)?|kotlin\s*\(\s*"(?P<kotlin>[^"]+)"\s*\)'
+        r'|alias\s*\(\s*(?P<alias>[\w.]+)\s*\)|`(?P<builtin>[\w-]+)`|(?P<bare>[a-z][\w-]*))'
+        r'(?:\s+version\s*\(?\s*["\'](?P<version>[^"\']+)["\']\s*\)?)?(?:\s+apply\s*\(?\s*(?P<apply>\w+)\s*\)?)?\s*$'
+    )
+    APPLY_PLUGIN = re.compile(r'^apply\s*\(?\s*plugin\s*[:=]\s*["\'](?P<id>[^"\']+)["\']')
+    DEPENDENCY = re.compile(r'^(?P<config>[a-z]\w*)(?P<args>(?:\s|\().*)$')
+    MAP_NOTATION = re.compile(r'(group|name|version)\s*[:=]\s*["\']([^"\']+)["\']')
+    TASK = re.compile(
+        r'^(?:task(?:\s+|\s*\(\s*)["\']?(?P<name>[\w-]+)["\']?\s*(?:,?\s*\(?\s*type\s*:\s*(?P<type>[\w.]+)\s*\)?)?'
+        r'|tasks\s*\.\s*(?:register|create)\s*(?:<(?P<kt_type>[\w.]+)>)?\s*\(\s*["\'](?P<reg_name>[\w-]+)["\']'
+        r'(?:\s*,\s*(?P<reg_type>[\w.]+)(?:::class(?:\.java)?)?)?)'
+    )
+    INCLUDE = re.compile(r'^include\s*\(?\s*(?P<args>.+?)\s*\)?\s*$')
+    ROOT_NAME = re.compile(r'^rootProject\s*\.\s*name\s*=\s*["\'](?P<name>[^"\']+)["\']')
+
+    # Blocks whose contents are configuration, not dependency declarations
+    NON_DEPENDENCY_CALLS = ('exclude', 'because', 'version', 'constraints', 'components', 'modules')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract plugins, dependencies by configuration, tasks and subprojects."""
+        structure = {
+            'project': [],
+            'plugins': [],
+            'repositories': [],
+        }
+        dependencies = {}
+        tasks = []
+        subprojects = []
+
+        for line_no, text, stack, opens_block in self._segments():
+            inner = stack[-1] if stack else None
+            scope = self._scope(stack)
+
+            root = self.ROOT_NAME.match(text)
+            if root and not stack:
+                structure['project'].append({'line': line_no, 'name': root.group('name')})
+                continue
+
+            if inner == 'plugins':
+                plugin = self._plugin_entry(text, line_no)
+                if plugin:
+                    structure['plugins'].append(plugin)
+                continue
+
+            apply = self.APPLY_PLUGIN.match(text)
+            if apply:
+                structure['plugins'].append({'line': line_no, 'name': apply.group('id'), 'signature': '  (apply)'})
+                continue
+
+            if inner == 'repositories':
+                structure['repositories'].append({'line': line_no, 'name': re.sub(r'\s*\(\s*\)$', '', text)})
+                continue
+
+            url = re.match(r'^url\s*[=(]?\s*(?:uri\s*\(\s*)?["\']([^"\']+)["\']', text)
+            if url and len(stack) > 1 and stack[-2] == 'repositories' and structure['repositories']:
+                structure['repositories'][-1]['signature'] = f" {url.group(1)}"
+                continue
+
+            if inner == 'dependencies':
+                dependency = self._dependency_entry(text, line_no)
+                if dependency:
+                    if scope:
+                        dependency['scope'] = scope
+                        dependency['signature'] = f"  ({scope})"
+                    dependencies.setdefault(dependency['configuration'], []).append(dependency)
+                continue
+
+            task = self.TASK.match(text)
+            if task:
+                entry = {
+                    'line': line_no,
+                    'name': task.group('name') or task.group('reg_name'),
+                }
+                task_type = task.group('type') or task.group('kt_type') or task.group('reg_type')
+                if task_type:
+                    entry['type'] = task_type
+                    entry['signature'] = f" ({task_type})"
+                if opens_block:
+                    line_end = self._block_end(line_no)
+                    if line_end > line_no:
+                        entry['line_end'] = line_end
+                tasks.append(entry)
+                continue
+
+            include = self.INCLUDE.match(text)
+            if include and not stack:
+                for name in re.findall(r'["\']([^"\']+)["\']', include.group('args')):
+                    subprojects.append({'line': line_no, 'name': name})
+
+        structure['subprojects'] = subprojects
+        for configuration, entries in dependencies.items():
+            structure[f"{self._snake(configuration)}_dependencies"] = entries
+        structure['tasks'] = tasks
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _segments(self):
+        """Yield (line, text, block_stack, opens_block) for each statement.
+
+        Statements are split at braces and semicolons, so one-liners such as
+        `plugins { id 'java' }` are seen with the right enclosing block.
+        """
+        stack = []
+        in_comment = False
+
+        for line_no, line in enumerate(self.lines, 1):
+            code, in_comment = self._strip_comments(line, in_comment)
+            masked = self._mask_strings(code)
+            start = 0
+
+            for i, char in enumerate(masked + ';'):
+                if char not in '{};':
+                    continue
+                text = code[start:i].strip()
+                start = i + 1
+                if char == '{':
+                    if text:
+                        yield line_no, text, list(stack), True
+                    stack.append(self._block_name(text))
+                elif text:
+                    yield line_no, text, list(stack), False
+                if char == '}' and stack:
+                    stack.pop()
+
+    @staticmethod
+    def _strip_comments(line: str, in_comment: bool) -> Tuple[str, bool]:
+        """Remove // and /* */ comments (outside strings)."""
+        result = []
+        quote = None
+        i = 0
+
+        while i < len(line):
+            char = line[i]
+            if in_comment:
+                if line.startswith('*/', i):
+                    in_comment = False
+                    i += 2
+                    continue
+                i += 1
+                continue
+            if quote:
+                result.append(char)
+                if char == '\\' and i + 1 < len(line):
+                    result.append(line[i + 1])
+                    i += 1
+                elif char == quote:
+                    quote = None
+            elif char in '"\'':
+                quote = char
+                result.append(char)
+            elif line.startswith('//', i):
+                break
+            elif line.startswith('/*', i):
+                in_comment = True
+                i += 2
+                continue
+            else:
+                result.append(char)
+            i += 1
+
+        return ''.join(result), in_comment
+
+    @staticmethod
+    def _mask_strings(code: str) -> str:
+        """Replace string contents with spaces, keeping offsets."""
+        return re.sub(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'', lambda m: ' ' * len(m.group(0)), code)
+
+    @staticmethod
+    def _block_name(text: str) -> str:
+        """Name of a block from the text before its '{' (e.g. 'dependencies')."""
+        match = re.match(r'^([\w.]+)', text)
+        return match.group(1) if match else text
+
+    @staticmethod
+    def _scope(stack: List[str]) -> Optional[str]:
+        """Enclosing project scope: subprojects, allprojects, buildscript or project(':x')."""
+        for name in stack:
+            if name in ('subprojects', 'allprojects', 'buildscript'):
+                return name
+            if name == 'project':
+                return 'project'
+        return None
+
+    def _plugin_entry(self, text: str, line_no: int) -> Optional[Dict[str, Any]]:
+        """Parse one line of a plugins { } block."""
+        match = self.PLUGIN.match(text)
+        if not match:
+            return None
+
+        if match.group('kotlin'):
+            name = f"kotlin({match.group('kotlin')})"
+        else:
+            name = match.group('id') or match.group('alias') or match.group('builtin') or match.group('bare')
+        entry = {'line': line_no, 'name': name}
+
+        details = []
+        if match.group('version'):
+            entry['version'] = match.group('version')
+            details.append(match.group('version'))
+        if match.group('apply') == 'false':
+            entry['applied'] = False
+            details.append('apply false')
+        if details:
+            entry['signature'] = f" {' '.join(details)}"
+        return entry
+
+    def _dependency_entry(self, text: str, line_no: int) -> Optional[Dict[str, Any]]:
+        """Parse `configuration 'group:name:version'` and its variants."""
+        match = self.DEPENDENCY.match(text)
+        if not match or match.group('config') in self.NON_DEPENDENCY_CALLS:
+            return None
+
+        args = match.group('args').strip()
+        if args.startswith('(') and args.endswith(')'):
+            args = args[1:-1].strip()
+        project = re.search(r'project\s*\(\s*(?:path\s*[:=]\s*)?["\']([^"\']+)["\']', args)
+        coordinates = re.match(r'^(?:(platform|enforcedPlatform)\s*\(\s*)?["\']([^"\']+)["\']', args)
+        notation = dict(self.MAP_NOTATION.findall(args))
+
+        if project:
+            name = f"project({project.group(1)})"
+        elif coordinates:
+            name = coordinates.group(2)
+            if coordinates.group(1):
+                name = f"{coordinates.group(1)}({name})"
+        elif 'name' in notation:
+            name = ':'.join(notation[key] for key in ('group', 'name', 'version') if key in notation)
+        elif re.match(r'^(libs|files|fileTree|gradleApi|localGroovy|kotlin)\b', args):
+            # Version catalog aliases (libs.foo.bar) and file/built-in dependencies
+            name = args
+        else:
+            return None
+
+        return {'line': line_no, 'name': name, 'configuration': match.group('config')}
+
+    def _block_end(self, line_no: int) -> int:
+        """Line where a block opened on line_no closes."""
+        depth = 0
+        in_comment = False
+
+        for i in range(line_no - 1, len(self.lines)):
+            code, in_comment = self._strip_comments(self.lines[i], in_comment)
+            masked = self._mask_strings(code)
+            for char in masked:
+                if char == '{':
+                    depth += 1
+                elif char == '}':
+                    depth -= 1
+                    if depth == 0:
+                        return i + 1
+
+        return line_no
+
+    @staticmethod
+    def _snake(name: str) -> str:
+        """testImplementation -> test_implementation."""
+        return re.sub(r'(?<=[a-z0-9])([A-Z])', r'_\1', name).lower()
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Plugin and dependency overview for directory trees."""
+        structure = self.get_structure()
+        parts = []
+
+        plugins = [p['name'] for p in structure.get('plugins', [])]
+        if plugins:
+            parts.append('plugins: ' + ', '.join(plugins[:4]) + (', ...' if len(plugins) > 4 else ''))
+        dependency_count = sum(len(v) for k, v in structure.items() if k.endswith('_dependencies'))
+        if dependency_count:
+            parts.append(f"{dependency_count} dependencies")
+        if structure.get('subprojects'):
+            parts.append(f"{len(structure['subprojects'])} subprojects")
+
+        return '; '.join(parts) or None
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a task definition."""
+        for task in self.get_structure().get('tasks', []):
+            if task['name'] == name:
+                line_end = task.get('line_end', task['line'])
+                return {
+                    'name': name,
+                    'line_start': task['line'],
+                    'line_end': line_end,
+                    'source': '\n'.join(self.lines[task['line'] - 1:line_end]),
+                }
+
+        return super().extract_element(element_type, name)
@@ -0,0 +1,263 @@
+"""Haskell file analyzer - tree-sitter based."""
+
+import re
+from typing import Dict, List, Any, Iterator
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.hs', '.lhs', name='Haskell', icon='')
+class HaskellAnalyzer(TreeSitterAnalyzer):
+    """Haskell file analyzer.
+
+    Extracts:
+    - Module declaration and its export list
+    - Imports (qualified/as/hiding kept as written)
+    - data / newtype / type declarations (with constructors)
+    - Type classes (with their method signatures) and instances
+    - Top-level function type signatures; each spans its equations
+
+    The parse tree gives each top-level declaration and where it ends;
+    details are read from the declaration's own text. Node types of both
+    the original and the rewritten tree-sitter-haskell grammar are
+    recognized.
+    """
+    language = 'haskell'
+
+    # Nodes grouping top-level declarations (rewritten grammar)
+    WRAPPERS = ('header', 'imports', 'declarations')
+    DATA_NODES = ('adt', 'data_type', 'newtype', 'type_alias', 'type_synomym', 'type_family', 'data_family')
+    EQUATION_NODES = ('function', 'bind')
+
+    TYPE_HEAD = re.compile(r'^(?P<kind>data|newtype|type(?:\s+family)?)\s+(?:instance\s+)?(?:\([^)]*\)\s*=>\s*)?'
+                           r'(?P<name>[A-Z][\w\']*)(?P<params>(?:\s+[a-z_][\w\']*)*)')
+    CLASS_HEAD = re.compile(r'^class\s+(?:\(?(?P<context>[^=]*?)\)?\s*=>\s*)?(?P<name>[A-Z][\w\']*)'
+                            r'(?P<signature>[^{]*?)\s*(?:where\b.*)?$')
+    INSTANCE_HEAD = re.compile(r'^instance\s+(?:\(?[^=]*?\)?\s*=>\s*)?(?P<name>[A-Z][\w\'.]*(?:\s+[^w][^\n]*?)?)'
+                               r'\s*(?:where\b.*)?$')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure, listing the module and its exports first."""
+        structure = super().get_structure(head, tail, range, **kwargs)
+        leading = {k: structure.pop(k) for k in ('module', 'exports') if k in structure}
+        return {**leading, **structure}
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract imports as written."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._flat_text(node),
+        } for node in self._top_level() if node.type == 'import']
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract top-level type signatures, each spanning the equations after it."""
+        declarations = list(self._top_level())
+        functions = []
+
+        for i, node in enumerate(declarations):
+            if node.type != 'signature':
+                continue
+            text = self._flat_text(node)
+            name, _, signature = text.partition('::')
+            name = name.strip()
+
+            line = node.start_point[0] + 1
+            line_end = node.end_point[0] + 1
+            for following in declarations[i + 1:]:
+                if following.type not in self.EQUATION_NODES or not self._defines(following, name):
+                    break
+                line_end = following.end_point[0] + 1
+
+            entry = {'line': line, 'name': name, 'signature': f" :: {signature.strip()}"}
+            if line_end > line:
+                entry['line_end'] = line_end
+                entry['line_count'] = line_end - line + 1
+            functions.append(entry)
+
+        return functions
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract type classes with their superclasses and method signatures."""
+        classes = []
+
+        for node in self._top_level():
+            if node.type != 'class':
+                continue
+            match = self.CLASS_HEAD.match(self._head_text(node))
+            if not match:
+                continue
+
+            signature = match.group('signature').strip()
+            entry = {
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': match.group('name'),
+                'signature': f" {signature}" if signature else '',
+            }
+            if match.group('context'):
+                entry['superclasses'] = match.group('context')
+            methods = []
+            for method in self._descendants(node, 'signature'):
+                names = self._flat_text(method).split('::', 1)[0]
+                methods.extend(name.strip() for name in names.split(','))
+            if methods:
+                entry['methods'] = methods
+                entry['signature'] += f" where {', '.join(methods)}"
+            classes.append(entry)
+
+        return classes
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract the module header, exports, types and instances."""
+        module, exports = self._module_header()
+        return {
+            'module': module,
+            'exports': exports,
+            'types': self._extract_types(),
+            'instances': self._extract_instances(),
+        }
+
+    def _module_header(self):
+        """The module entry and one entry per exported name."""
+        name_node = None
+        exports_node = None
+        for node in self._top_level():
+            if node.type == 'module' and name_node is None:
+                name_node = node
+            elif node.type == 'exports' and exports_node is None:
+                exports_node = node
+        if name_node is None:
+            return [], []
+
+        entry = {'line': name_node.start_point[0] + 1, 'name': self._get_node_text(name_node)}
+        exports = []
+        if exports_node is not None:
+            exports = [{'line': item.start_point[0] + 1, 'name': self._flat_text(item)}
+                       for item in exports_node.children if item.is_named and item.type != 'comment']
+            names = [export['name'] for export in exports]
+            entry['exports'] = names
+            entry['signature'] = f" ({len(names)} export{'s' if len(names) != 1 else ''})"
+        return [entry], exports
+
+    def _extract_types(self) -> List[Dict[str, Any]]:
+        """Extract data / newtype / type declarations with constructors."""
+        types = []
+
+        for node in self._top_level():
+            if node.type not in self.DATA_NODES:
+                continue
+            text = self._flat_text(node)
+            match = self.TYPE_HEAD.match(text)
+            if not match:
+                continue
+
+            kind = ' '.join(match.group('kind').split())
+            params = match.group('params').split()
+            entry = {
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': match.group('name'),
+                'kind': kind,
+            }
+            constructors = self._constructors(text) if kind in ('data', 'newtype') else []
+            signature = ''.join(f" {p}" for p in params)
+            if constructors:
+                entry['constructors'] = constructors
+                signature += f" = {' | '.join(constructors)}"
+            elif kind.startswith('type') and '=' in text:
+                signature += f" = {text.split('=', 1)[1].strip()}"
+            entry['signature'] = f"{signature}  ({kind})" if signature else f"  ({kind})"
+            if entry['line_end'] == entry['line']:
+                del entry['line_end']
+            types.append(entry)
+
+        return types
+
+    def _extract_instances(self) -> List[Dict[str, Any]]:
+        """Extract instance declarations, named by class and type."""
+        instances = []
+
+        for node in self._top_level():
+            if node.type != 'instance':
+                continue
+            match = self.INSTANCE_HEAD.match(self._head_text(node))
+            if match:
+                instances.append({
+                    'line': node.start_point[0] + 1,
+                    'line_end': node.end_point[0] + 1,
+                    'name': ' '.join(match.group('name').split()),
+                })
+
+        return instances
+
+    def _top_level(self) -> Iterator:
+        """Top-level declarations in file order, through the grammar's grouping nodes."""
+        def walk(node):
+            for child in node.children:
+                if not child.is_named or child.type == 'comment':
+                    continue
+                if child.type in self.WRAPPERS:
+                    yield from walk(child)
+                else:
+                    yield child
+
+        if self.tree:
+            yield from walk(self.tree.root_node)
+
+    def _defines(self, node, name: str) -> bool:
+        """Whether an equation defines name (`area (Circle r) = ...`, `x <+> y = ...`)."""
+        name_node = node.child_by_field_name('name')
+        if name_node is not None:
+            return self._get_node_text(name_node).strip('()') == name.strip('()')
+        words = self._flat_text(node).split()
+        return bool(words) and (words[0] == name or len(words) > 1 and words[1] == name.strip('()'))
+
+    def _descendants(self, node, node_type: str) -> List:
+        found = []
+        for child in node.children:
+            if child.type == node_type:
+                found.append(child)
+            else:
+                found.extend(self._descendants(child, node_type))
+        return found
+
+    def _head_text(self, node) -> str:
+        """A class or instance declaration up to its `where`."""
+        text = self._flat_text(node)
+        match = re.search(r'\bwhere\b', text)
+        return text[:match.end()] if match else text
+
+    def _flat_text(self, node) -> str:
+        """A node's text on one line, without -- comments."""
+        lines = [line.split('--', 1)[0] for line in self._get_node_text(node).split('\n')]
+        return ' '.join(' '.join(lines).split())
+
+    @staticmethod
+    def _constructors(body: str) -> List[str]:
+        """Constructor names of a data/newtype declaration."""
+        if '=' not in body:
+            return []
+        body = body.split('=', 1)[1].split(' deriving ')[0]
+
+        constructors = []
+        depth = 0
+        current = ''
+        for char in body:
+            if char in '({[':
+                depth += 1
+            elif char in ')}]':
+                depth -= 1
+            if char == '|' and depth == 0:
+                constructors.append(current)
+                current = ''
+                continue
+            current += char
+        constructors.append(current)
+
+        names = []
+        for constructor in constructors:
+            match = re.match(r'\s*(?:forall[^.]*\.\s*)?(?:[^=]*=>\s*)?([A-Z][\w\']*|\(:[^)]*\))', constructor)
+            if match:
+                names.append(match.group(1))
+        return names
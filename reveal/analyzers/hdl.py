@@ -0,0 +1,595 @@
+"""Verilog/SystemVerilog and VHDL analyzers."""
+
+import bisect
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+
+
+class _HDLAnalyzer(FileAnalyzer):
+    """Shared statement splitting for hardware description languages.
+
+    Source is split into statements at top-level `;` and at the block
+    keywords returned by _cuts(), with comments removed. Offsets are kept
+    so every statement (and every port or parameter inside one) maps back
+    to its line.
+    """
+
+    def _statements(self) -> List[Tuple[int, int, str]]:
+        """(start offset, end offset, text) for each statement."""
+        code = self._without_comments()
+        masked = self._mask_strings(code)
+        self._line_starts = [0] + [i + 1 for i, char in enumerate(code) if char == '\n']
+        cuts = self._cuts(masked)
+
+        statements = []
+        depth = 0
+        start = 0
+        for i, char in enumerate(masked):
+            if char in '([{':
+                depth += 1
+            elif char in ')]}':
+                depth = max(depth - 1, 0)
+            elif char == ';' and depth == 0:
+                statements.append((start, i))
+                start = i + 1
+                continue
+            if depth == 0 and i + 1 in cuts:
+                statements.append((start, i + 1))
+                start = i + 1
+        statements.append((start, len(code)))
+
+        result = []
+        for begin, end in statements:
+            text = code[begin:end]
+            stripped = text.lstrip()
+            if not stripped.strip():
+                continue
+            offset = begin + len(text) - len(stripped)
+            result.append((offset, end, ' '.join(stripped.split())))
+        self._code = code
+        return result
+
+    def _line_at(self, offset: int) -> int:
+        return bisect.bisect_right(self._line_starts, offset)
+
+    def _end_line(self, end: int) -> int:
+        """Line of the last non-blank character before end."""
+        text = self._code[:end].rstrip()
+        return self._line_at(max(len(text) - 1, 0))
+
+    def _cuts(self, masked: str) -> set:
+        """Offsets (besides `;`) where a statement ends."""
+        return set()
+
+    @staticmethod
+    def _split_top(text: str, separator: str) -> List[Tuple[str, int]]:
+        """Split on a separator outside brackets; keep each part's offset."""
+        parts = []
+        depth = 0
+        start = 0
+        for i, char in enumerate(text):
+            if char in '([{':
+                depth += 1
+            elif char in ')]}':
+                depth -= 1
+            elif char == separator and depth == 0:
+                parts.append((text[start:i], start))
+                start = i + 1
+        parts.append((text[start:], start))
+        return [(part.strip(), offset + len(part) - len(part.lstrip())) for part, offset in parts if part.strip()]
+
+    @staticmethod
+    def _balanced(text: str, start: int) -> int:
+        """Index just past the bracket closing the one at text[start]."""
+        depth = 0
+        for i in range(start, len(text)):
+            if text[i] in '([{':
+                depth += 1
+            elif text[i] in ')]}':
+                depth -= 1
+                if depth == 0:
+                    return i + 1
+        return len(text)
+
+    def _find_word(self, word: str, start: int) -> int:
+        """Offset of the next whole-word occurrence of word at or after start."""
+        match = re.compile(r'\b' + re.escape(word) + r'\b').search(self._code, start)
+        return match.start() if match else start
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a design unit or instance by name."""
+        structure = self.get_structure()
+
+        for category in self.EXTRACTABLE:
+            for item in structure.get(category, []):
+                if self._same_name(item['name'], name) or self._same_name(item['name'].split('.')[-1], name):
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item['name'],
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
+
+    @staticmethod
+    def _same_name(a: str, b: str) -> bool:
+        return a == b
+
+
+@register('.v', '.sv', '.vh', '.svh', name='Verilog', icon='')
+class VerilogAnalyzer(_HDLAnalyzer):
+    """Verilog / SystemVerilog file analyzer.
+
+    Extracts:
+    - `include directives and package imports
+    - Modules (and SystemVerilog interfaces, packages and programs)
+    - Parameters and localparams, from the #( ) header or the body
+    - Ports with direction, type and width (ANSI headers and
+      old-style declarations in the body)
+    - Module instantiations (instance name and module type); gate
+      primitives are skipped
+    """
+
+    EXTRACTABLE = ('modules', 'instances')
+
+    UNIT = re.compile(
+        r'^(?P<kind>module|macromodule|interface|program|package)\s+(?:(?:automatic|static)\s+)?(?P<name>\w+)'
+    )
+    UNIT_END = re.compile(r'^(?:endmodule|endinterface|endprogram|endpackage)\b')
+    BLOCK_KEYWORD = re.compile(
+        r'\b(?:begin|end|generate|endgenerate|endmodule|endinterface|endprogram|endpackage|endcase|'
+        r'endfunction|endtask|endclass|endspecify|fork|join|join_any|join_none)\b(?:\s*:\s*\w+)?'
+    )
+    PARAMETER = re.compile(r'^(?P<keyword>parameter|localparam)\b\s*(?P<rest>.*)$')
+    PORT = re.compile(
+        r'^(?P<direction>input|output|inout|ref)\b\s*(?P<type>.*?)\s*(?P<name>\w+)\s*(?P<unpacked>(?:\[[^\]]*\]\s*)*)'
+        r'(?:=.*)?$'
+    )
+    INSTANCE = re.compile(
+        r'^(?P<type>[A-Za-z_]\w*)\b\s*(?:#\s*(?:\(.*?\)|\d+)\s*)?(?P<name>[A-Za-z_]\w*)\s*(?:\[[^\]]*\]\s*)?\(',
+        re.DOTALL
+    )
+
+    KEYWORDS = {
+        'module', 'macromodule', 'interface', 'program', 'package', 'function', 'task', 'class',
+        'assign', 'always', 'always_ff', 'always_comb', 'always_latch', 'initial', 'final', 'if', 'else',
+        'for', 'foreach', 'while', 'repeat', 'forever', 'case', 'casex', 'casez', 'wait', 'return',
+        'assert', 'assume', 'cover', 'property', 'sequence', 'covergroup', 'constraint', 'typedef',
+        'wire', 'reg', 'logic', 'bit', 'byte', 'int', 'integer', 'real', 'genvar', 'input', 'output',
+        'inout', 'parameter', 'localparam', 'import', 'export', 'modport', 'clocking', 'default',
+        'disable', 'force', 'release', 'deassign', 'virtual', 'extern', 'static', 'automatic',
+        # Gate primitives
+        'and', 'or', 'nand', 'nor', 'xor', 'xnor', 'not', 'buf', 'bufif0', 'bufif1', 'notif0', 'notif1',
+        'pullup', 'pulldown', 'tran', 'rtran', 'nmos', 'pmos', 'cmos',
+    }
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract design units, parameters, ports and instantiations."""
+        structure = {
+            'imports': self._includes(),
+            'modules': [],
+            'parameters': [],
+            'ports': [],
+            'instances': [],
+        }
+        module = None
+        ports = {}
+
+        for offset, end, text in self._statements():
+            text = re.sub(r'^\(\*.*?\*\)\s*', '', text)
+            line = self._line_at(offset)
+
+            unit = self.UNIT.match(text)
+            if unit:
+                module = {'line': line, 'name': unit.group('name')}
+                if unit.group('kind') not in ('module', 'macromodule'):
+                    module['kind'] = unit.group('kind')
+                structure['modules'].append(module)
+                ports = {}
+                self._header(module, text, offset, structure, ports)
+                continue
+
+            if self.UNIT_END.match(text):
+                if module is not None:
+                    module['line_end'] = self._end_line(end)
+                    module = None
+                continue
+
+            if text.startswith('import ') and (module is None or module.get('kind') == 'package'):
+                structure['imports'].append({'line': line, 'content': text})
+                continue
+
+            if module is None:
+                continue
+
+            parameter = self.PARAMETER.match(text)
+            if parameter:
+                self._parameters(module, text, offset, structure)
+                continue
+
+            if self.PORT.match(text.split(',')[0]):
+                self._ports(module, text, offset, structure, ports)
+                continue
+
+            instance = self.INSTANCE.match(text)
+            if instance and instance.group('type') not in self.KEYWORDS and instance.group('name') not in self.KEYWORDS:
+                entry = {
+                    'line': line,
+                    'name': f"{module['name']}.{instance.group('name')}",
+                    'module': module['name'],
+                    'type': instance.group('type'),
+                    'signature': f": {instance.group('type')}",
+                }
+                line_end = self._end_line(end)
+                if line_end > line:
+                    entry['line_end'] = line_end
+                structure['instances'].append(entry)
+
+        for module in structure['modules']:
+            names = [p['name'].split('.', 1)[1] for p in structure['ports'] if p['module'] == module['name']]
+            module['signature'] = f"({', '.join(names)})"
+            if module.get('kind'):
+                module['signature'] += f"  ({module['kind']})"
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _header(self, module: Dict[str, Any], text: str, offset: int,
+                structure: Dict[str, List[Dict[str, Any]]], ports: Dict[str, Dict[str, Any]]):
+        """Parameters and ports declared in `module name #(...) (...)`."""
+        rest = text[self.UNIT.match(text).end():]
+        cursor = self._find_word(module['name'], offset)
+
+        params = re.match(r'\s*#\s*\(', rest)
+        if params:
+            close = self._balanced(rest, params.end() - 1)
+            inner = rest[params.end():close - 1]
+            cursor = self._parameters(module, inner, cursor, structure, header=True)
+            rest = rest[close:]
+
+        port_list = re.match(r'\s*\(', rest)
+        if port_list:
+            close = self._balanced(rest, port_list.end() - 1)
+            self._ports(module, rest[port_list.end():close - 1], cursor, structure, ports)
+
+    def _parameters(self, module: Dict[str, Any], text: str, offset: int,
+                    structure: Dict[str, List[Dict[str, Any]]], header: bool = False) -> int:
+        """`parameter W = 8, D = 4` (in a #( ) list, parameters may omit the keyword).
+
+        Returns the source offset just past the last parameter name.
+        """
+        local = False
+        for part, _ in self._split_top(text, ','):
+            keyword = self.PARAMETER.match(part)
+            if keyword:
+                local = keyword.group('keyword') == 'localparam'
+                part = keyword.group('rest')
+            elif not header and not re.match(r'^\w+\s*(?:\[[^\]]*\]\s*)*=', part):
+                continue
+            left, _, value = part.partition('=')
+            names = re.findall(r'\w+', re.sub(r'\[[^\]]*\]', '', left))
+            if not names:
+                continue
+            name = names[-1]
+            offset = self._find_word(name, offset)
+            entry = {
+                'line': self._line_at(offset),
+                'name': f"{module['name']}.{name}",
+                'module': module['name'],
+            }
+            signature = ''
+            declared_type = ' '.join(left.split()[:-1]) if len(left.split()) > 1 else ''
+            if declared_type and declared_type not in ('parameter', 'localparam'):
+                entry['type'] = declared_type
+                signature = f": {declared_type}"
+            if value.strip():
+                entry['value'] = ' '.join(value.split())
+                signature += f" = {entry['value']}"
+            if local:
+                entry['local'] = True
+                signature += '  (local)'
+            if signature:
+                entry['signature'] = signature
+            structure['parameters'].append(entry)
+        return offset
+
+    def _ports(self, module: Dict[str, Any], text: str, offset: int,
+               structure: Dict[str, List[Dict[str, Any]]], ports: Dict[str, Dict[str, Any]]):
+        """ANSI port lists and `input [7:0] a, b` declarations; names inherit the previous direction."""
+        direction = None
+        port_type = ''
+        for part, _ in self._split_top(text, ','):
+            match = self.PORT.match(part)
+            if match:
+                direction = match.group('direction')
+                port_type = ' '.join(match.group('type').split())
+                name = match.group('name')
+            else:
+                bare = re.match(r'^(?P<type>.*?)\s*(?P<name>\w+)\s*(?:\[[^\]]*\]\s*)*$', part)
+                if not bare:
+                    continue
+                name = bare.group('name')
+                if bare.group('type') and direction is None:
+                    # SystemVerilog interface port: bus_if.master m
+                    port_type = ' '.join(bare.group('type').split())
+
+            offset = self._find_word(name, offset)
+            if name in ports:
+                entry = ports[name]
+            else:
+                entry = {
+                    'line': self._line_at(offset),
+                    'name': f"{module['name']}.{name}",
+                    'module': module['name'],
+                }
+                ports[name] = entry
+                structure['ports'].append(entry)
+
+            if direction:
+                entry['direction'] = direction
+            if port_type:
+                entry['type'] = port_type
+            signature = ' '.join(filter(None, [entry.get('direction'), entry.get('type')]))
+            if signature:
+                entry['signature'] = f": {signature}"
+
+    def _includes(self) -> List[Dict[str, Any]]:
+        """`include "file.vh" directives."""
+        includes = []
+        for i, line in enumerate(self.lines, 1):
+            match = re.match(r'^\s*`include\s+["<]([^">]+)[">]', line)
+            if match:
+                includes.append({'line': i, 'content': f'`include "{match.group(1)}"'})
+        return includes
+
+    def _cuts(self, masked: str) -> set:
+        """Statements also end around begin/end style keywords (which take no `;`)."""
+        cuts = set()
+        for match in self.BLOCK_KEYWORD.finditer(masked):
+            cuts.add(match.start())
+            cuts.add(match.end())
+        return cuts
+
+    def _without_comments(self) -> str:
+        """Source with comments and compiler directives blanked (offsets preserved)."""
+        result = []
+        text = self.content
+        i = 0
+        while i < len(text):
+            if text.startswith('//', i):
+                end = text.find('\n', i)
+                end = len(text) if end < 0 else end
+                result.append(' ' * (end - i))
+                i = end
+            elif text.startswith('/*', i):
+                end = text.find('*/', i + 2)
+                end = len(text) if end < 0 else end + 2
+                result.append(re.sub(r'[^\n]', ' ', text[i:end]))
+                i = end
+            elif text[i] == '"':
+                end = i + 1
+                while end < len(text) and text[end] not in '"\n':
+                    end += 2 if text[end] == '\\' else 1
+                result.append(text[i:end + 1])
+                i = end + 1
+            elif text[i] == '`' and (i == 0 or text[i - 1] == '\n' or not text[:i].split('\n')[-1].strip()):
+                # `include / `define / `timescale ... to the end of the line
+                end = text.find('\n', i)
+                end = len(text) if end < 0 else end
+                result.append(' ' * (end - i))
+                i = end
+            else:
+                result.append(text[i])
+                i += 1
+        return ''.join(result)[:len(text)]
+
+    @staticmethod
+    def _mask_strings(code: str) -> str:
+        return re.sub(r'"(?:\\.|[^"\\\n])*"', lambda m: '"' + ' ' * (len(m.group(0)) - 2) + '"', code)
+
+
+@register('.vhd', '.vhdl', name='VHDL', icon='')
+class VHDLAnalyzer(_HDLAnalyzer):
+    """VHDL file analyzer.
+
+    Extracts:
+    - library / use clauses
+    - Entities with their generics and ports (mode and type)
+    - Architectures (and the entity they implement), packages and
+      component declarations
+    - Instantiations: `u1 : entity work.alu port map (...)` and
+      component instantiations
+
+    Keywords are case-insensitive.
+    """
+
+    EXTRACTABLE = ('entities', 'architectures', 'packages', 'components', 'instances')
+
+    ENTITY = re.compile(r'^entity\s+(?P<name>\w+)\s+is$', re.IGNORECASE)
+    ARCHITECTURE = re.compile(r'^architecture\s+(?P<name>\w+)\s+of\s+(?P<entity>\w+)\s+is$', re.IGNORECASE)
+    PACKAGE = re.compile(r'^package\s+(?P<body>body\s+)?(?P<name>\w+)\s+is$', re.IGNORECASE)
+    COMPONENT = re.compile(r'^component\s+(?P<name>\w+)(?:\s+is)?$', re.IGNORECASE)
+    SUBPROGRAM = re.compile(r'^(?:(?:pure|impure)\s+)?(?:function|procedure)\b.*\bis$', re.IGNORECASE)
+    PROCESS = re.compile(r'^(?:\w+\s*:\s*)?(?:postponed\s+)?(?:process|block)\b', re.IGNORECASE)
+    END = re.compile(r'^end\b\s*(?P<rest>.*)$', re.IGNORECASE)
+    INTERFACE_LIST = re.compile(r'^(?P<kind>generic|port)\s*\((?P<items>.*)\)$', re.IGNORECASE | re.DOTALL)
+    INTERFACE_ITEM = re.compile(
+        r'^(?:(?:signal|constant|variable)\s+)?(?P<names>\w+(?:\s*,\s*\w+)*)\s*:\s*'
+        r'(?:(?P<mode>in|out|inout|buffer|linkage)\s+)?(?P<type>.+?)(?:\s*:=\s*(?P<default>.+))?$',
+        re.IGNORECASE | re.DOTALL
+    )
+    INSTANCE = re.compile(
+        r'^(?P<label>\w+)\s*:\s*(?:entity\s+(?P<entity>[\w.]+)(?:\s*\(\s*(?P<arch>\w+)\s*\))?'
+        r'|(?:component\s+)?(?P<component>\w+))\s+(?:generic|port)\s+map\b',
+        re.IGNORECASE
+    )
+    NON_UNIT_END = re.compile(r'^(?:if|loop|case|generate|record|units|protected)\b', re.IGNORECASE)
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract design units, generics, ports and instantiations."""
+        structure = {
+            'imports': [],
+            'entities': [],
+            'generics': [],
+            'ports': [],
+            'architectures': [],
+            'packages': [],
+            'components': [],
+            'instances': [],
+        }
+        stack: List[Optional[Dict[str, Any]]] = []
+
+        for offset, end, text in self._statements():
+            line = self._line_at(offset)
+            owner = next((e for e in reversed(stack) if e is not None), None)
+
+            end_match = self.END.match(text)
+            if end_match:
+                if not self.NON_UNIT_END.match(end_match.group('rest')) and stack:
+                    entry = stack.pop()
+                    if entry is not None:
+                        entry['line_end'] = self._end_line(end)
+                continue
+
+            if re.match(r'^(?:library|use|context)\s', text, re.IGNORECASE) and not stack:
+                structure['imports'].append({'line': line, 'content': text})
+                continue
+
+            match = self.ENTITY.match(text)
+            if match:
+                entry = {'line': line, 'name': match.group('name')}
+                structure['entities'].append(entry)
+                stack.append(entry)
+                continue
+
+            match = self.ARCHITECTURE.match(text)
+            if match:
+                entry = {'line': line, 'name': match.group('name'),
+                         'entity': match.group('entity'), 'signature': f" of {match.group('entity')}"}
+                structure['architectures'].append(entry)
+                stack.append(entry)
+                continue
+
+            match = self.PACKAGE.match(text)
+            if match:
+                entry = {'line': line, 'name': match.group('name')}
+                if match.group('body'):
+                    entry['kind'] = 'body'
+                    entry['signature'] = '  (body)'
+                structure['packages'].append(entry)
+                stack.append(entry)
+                continue
+
+            match = self.COMPONENT.match(text)
+            if match:
+                entry = {'line': line, 'name': match.group('name')}
+                structure['components'].append(entry)
+                stack.append(entry)
+                continue
+
+            if self.SUBPROGRAM.match(text) or self.PROCESS.match(text):
+                stack.append(None)
+                continue
+
+            match = self.INTERFACE_LIST.match(text)
+            if match and owner is not None and owner in structure['entities'] + structure['components']:
+                self._interface_list(owner, match, offset, structure, listed=owner in structure['entities'])
+                continue
+
+            match = self.INSTANCE.match(text)
+            if match and owner is not None:
+                unit = match.group('entity') or match.group('component')
+                if match.group('arch'):
+                    unit += f"({match.group('arch')})"
+                scope = owner.get('entity', owner['name'])
+                entry = {
+                    'line': line,
+                    'name': f"{scope}.{match.group('label')}",
+                    'unit': unit,
+                    'signature': f": {unit}",
+                }
+                line_end = self._end_line(end)
+                if line_end > line:
+                    entry['line_end'] = line_end
+                structure['instances'].append(entry)
+
+        for entity in structure['entities']:
+            names = [p['name'].split('.', 1)[1] for p in structure['ports'] if p['entity'] == entity['name']]
+            entity['signature'] = f"({', '.join(names)})"
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _interface_list(self, owner: Dict[str, Any], match, offset: int,
+                        structure: Dict[str, List[Dict[str, Any]]], listed: bool):
+        """generic (...) / port (...) clause; entity clauses become generics/ports entries."""
+        kind = match.group('kind').lower()
+        names = []
+        position = offset
+        for item, _ in self._split_top(match.group('items'), ';'):
+            declared = self.INTERFACE_ITEM.match(item)
+            if not declared:
+                continue
+            for name in (n.strip() for n in declared.group('names').split(',')):
+                names.append(name)
+                if not listed:
+                    continue
+                position = self._find_word(name, position)
+                entry = {
+                    'line': self._line_at(position),
+                    'name': f"{owner['name']}.{name}",
+                    'entity': owner['name'],
+                    'type': ' '.join(declared.group('type').split()),
+                }
+                signature = entry['type']
+                if declared.group('mode'):
+                    entry['mode'] = declared.group('mode').lower()
+                    signature = f"{entry['mode']} {signature}"
+                if declared.group('default'):
+                    entry['default'] = ' '.join(declared.group('default').split())
+                    signature += f" := {entry['default']}"
+                entry['signature'] = f": {signature}"
+                structure['generics' if kind == 'generic' else 'ports'].append(entry)
+        if not listed and kind == 'port':
+            owner['signature'] = f"({', '.join(names)})"
+
+    def _cuts(self, masked: str) -> set:
+        """Statements also end after `is`/`begin`/`generate`/... and before a generic/port clause."""
+        cuts = set()
+        for match in re.finditer(r'\b(?:is|begin|generate|then|else|loop)\b', masked, re.IGNORECASE):
+            cuts.add(match.end())
+        for match in re.finditer(r'\b(?:generic|port)\s*\(', masked, re.IGNORECASE):
+            cuts.add(match.start())
+        return cuts
+
+    def _without_comments(self) -> str:
+        """Source with -- comments blanked (offsets preserved)."""
+        lines = []
+        for line in self.content.split('\n'):
+            code = re.sub(r'"[^"\n]*"', lambda m: 'x' * len(m.group(0)), line)
+            index = code.find('--')
+            lines.append(line[:index] + ' ' * (len(line) - index) if index >= 0 else line)
+        return '\n'.join(lines)
+
+    @staticmethod
+    def _mask_strings(code: str) -> str:
+        return re.sub(r'"[^"\n]*"', lambda m: '"' + ' ' * (len(m.group(0)) - 2) + '"', code)
+
+    @staticmethod
+    def _same_name(a: str, b: str) -> bool:
+        return a.lower() == b.lower()
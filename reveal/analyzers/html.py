@@ -0,0 +1,158 @@
+"""HTML document analyzer."""
+
+from html.parser import HTMLParser
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+# Elements without a closing tag
+VOID_ELEMENTS = {
+    'area', 'base', 'br', 'col', 'embed', 'hr', 'img', 'input', 'link',
+    'meta', 'param', 'source', 'track', 'wbr',
+}
+
+
+class _OutlineParser(HTMLParser):
+    """Collect headings, script/style includes and id'd elements with line ranges."""
+
+    def __init__(self):
+        super().__init__(convert_charrefs=True)
+        self.title = None
+        self.headings = []
+        self.scripts = []
+        self.styles = []
+        self.sections = []
+        self._stack = []       # (tag, entry or None)
+        self._text = None      # (tag, entry, [chunks]) while inside title/heading
+        self._inline = None    # (category, entry) while inside inline script/style
+
+    def handle_starttag(self, tag, attrs):
+        line = self.getpos()[0]
+        attrs = {k: v or '' for k, v in attrs}
+        entry = None
+
+        if tag == 'script':
+            if attrs.get('src'):
+                entry = {'line': line, 'name': attrs['src']}
+                if attrs.get('type') == 'module':
+                    entry['signature'] = ' (module)'
+                self.scripts.append(entry)
+            else:
+                entry = {'line': line, 'name': 'inline script'}
+                self.scripts.append(entry)
+                self._inline = ('script', entry)
+        elif tag == 'style':
+            entry = {'line': line, 'name': 'inline style'}
+            self.styles.append(entry)
+            self._inline = ('style', entry)
+        elif tag == 'link' and 'stylesheet' in attrs.get('rel', '').lower().split():
+            link = {'line': line, 'name': attrs.get('href', '')}
+            if attrs.get('media'):
+                link['signature'] = f" (media={attrs['media']})"
+            self.styles.append(link)
+        elif tag == 'title':
+            self._text = (tag, None, [])
+        elif tag in ('h1', 'h2', 'h3', 'h4', 'h5', 'h6'):
+            heading = {'line': line, 'level': int(tag[1]), 'name': ''}
+            self.headings.append(heading)
+            self._text = (tag, heading, [])
+
+        if attrs.get('id'):
+            section = {'line': line, 'name': f"#{attrs['id']}", 'signature': f" <{tag}>"}
+            self.sections.append(section)
+            entry = section
+
+        if tag not in VOID_ELEMENTS:
+            self._stack.append((tag, entry))
+
+    def handle_startendtag(self, tag, attrs):
+        self.handle_starttag(tag, attrs)
+        if tag not in VOID_ELEMENTS and self._stack and self._stack[-1][0] == tag:
+            self._stack.pop()
+
+    def handle_endtag(self, tag):
+        line = self.getpos()[0]
+
+        if self._text and self._text[0] == tag:
+            text = ' '.join(''.join(self._text[2]).split())
+            if self._text[1] is None:
+                self.title = {'line': line, 'content': text}
+            else:
+                self._text[1]['name'] = text
+            self._text = None
+
+        if self._inline and self._inline[0] == tag:
+            entry = self._inline[1]
+            if line > entry['line']:
+                entry['line_end'] = line
+                entry['signature'] = f" ({line - entry['line'] + 1} lines)"
+            self._inline = None
+
+        # Close up to the matching open tag (tolerates unclosed elements)
+        for index in range(len(self._stack) - 1, -1, -1):
+            if self._stack[index][0] == tag:
+                for _, entry in self._stack[index:]:
+                    if entry is not None and line > entry['line']:
+                        entry.setdefault('line_end', line)
+                del self._stack[index:]
+                break
+
+    def handle_data(self, data):
+        if self._text:
+            self._text[2].append(data)
+
+
+@register('.html', '.htm', '.xhtml', name='HTML', icon='')
+class HTMLAnalyzer(FileAnalyzer):
+    """HTML document analyzer.
+
+    Extracts:
+    - Document title
+    - Outline by headings (h1-h6, indented by level)
+    - Script includes (src) and inline scripts
+    - Stylesheet links and inline styles
+    - Elements with an id (sections, forms, app mount points), with line ranges
+    """
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract title, headings, scripts, styles and id'd sections."""
+        parser = _OutlineParser()
+        try:
+            parser.feed(self.content)
+            parser.close()
+        except Exception:
+            # Malformed markup - keep whatever was parsed
+            pass
+
+        structure = {
+            'title': [parser.title] if parser.title else [],
+            'headings': parser.headings,
+            'scripts': parser.scripts,
+            'styles': parser.styles,
+            'sections': parser.sections,
+        }
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract an element by id (with or without '#')."""
+        target = name if name.startswith('#') else f"#{name}"
+
+        for section in self.get_structure().get('sections', []):
+            if section['name'] == target:
+                line_end = section.get('line_end', section['line'])
+                return {
+                    'name': section['name'],
+                    'line_start': section['line'],
+                    'line_end': line_end,
+                    'source': '\n'.join(self.lines[section['line'] - 1:line_end]),
+                }
+
+        return super().extract_element(element_type, name)
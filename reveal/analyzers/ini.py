@@ -0,0 +1,63 @@
+"""INI-style config file analyzer (.ini, .cfg, .properties, git/editor configs)."""
+
+import re
+from typing import Dict, List, Any, Tuple
+from ..base import register
+from .toml import TomlAnalyzer
+
+
+@register('.ini', '.cfg', '.properties', '.editorconfig', '.gitconfig', '.gitmodules', '.coveragerc',
+          '.pylintrc', 'pylintrc', '.flake8', name='INI', icon='')
+class IniAnalyzer(TomlAnalyzer):
+    """INI-style config analyzer.
+
+    Same view as TOML: the section hierarchy (dotted names such as
+    setup.cfg's [options.extras_require] nest under their parent) with
+    key names, and keys before the first section with their values.
+    Accepts `key = value` and `key: value`, keys without values, `;`,
+    `#` and `!` comments, and indented continuation lines (setup.cfg's
+    install_requires lists), which are joined for display.
+    """
+
+    SECTION = re.compile(r'^\[\s*(?P<name>[^\]]+?)\s*\]\s*(?:[;#].*)?$')
+    KEY = re.compile(r'^(?P<name>[^=:\s\[][^=:]*?)\s*(?:[=:]\s*(?P<value>.*))?$')
+
+    def _parse(self) -> Tuple[List[Dict[str, Any]], List[Dict[str, Any]]]:
+        """Sections (with their keys) and keys before the first section, raw values."""
+        sections = []
+        keys = []
+        entry = None
+
+        for i, line in enumerate(self.lines, 1):
+            stripped = line.strip()
+
+            # Skip empty lines and comments
+            if not stripped or stripped[0] in '#;!':
+                continue
+
+            # Continuation of the previous key's value
+            if line[0] in ' \t' and entry is not None:
+                entry['continuation'].append(stripped)
+                entry['line_end'] = i
+                continue
+
+            section_match = self.SECTION.match(stripped)
+            if section_match:
+                sections.append({'line': i, 'name': section_match.group('name'), 'keys': []})
+                entry = None
+                continue
+
+            key_match = self.KEY.match(stripped)
+            if key_match:
+                entry = {'line': i, 'name': key_match.group('name'), 'value': (key_match.group('value') or '').strip(),
+                         'continuation': []}
+                (sections[-1]['keys'] if sections else keys).append(entry)
+
+        for item in keys + [k for s in sections for k in s['keys']]:
+            continuation = item.pop('continuation')
+            if continuation:
+                item['value'] = ', '.join(([item['value']] if item['value'] else []) + continuation)
+
+        self._set_levels(sections)
+        self._set_section_ends(sections)
+        return sections, keys
@@ -0,0 +1,154 @@
+"""Java file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.java', name='Java', icon='')
+class JavaAnalyzer(TreeSitterAnalyzer):
+    """Java file analyzer.
+
+    Extracts:
+    - Package declaration and imports
+    - Classes, interfaces, enums, records and annotation types
+    - Methods and constructors with signatures, grouped by visibility
+      (public, protected, package-private, private)
+    - Annotations on classes and methods (e.g., @Override)
+    """
+    language = 'java'
+
+    # Display order for visibility groups
+    VISIBILITY_ORDER = ('public', 'protected', 'package', 'private')
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract methods and constructors grouped by visibility."""
+        functions = []
+
+        for node_type in ('method_declaration', 'constructor_declaration'):
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if not name:
+                    continue
+
+                entry = self._function_entry(node, name)
+                entry.update(self._declaration_info(node))
+                functions.append(entry)
+
+        return sorted(functions, key=lambda f: (
+            self.VISIBILITY_ORDER.index(f['visibility']), f['line']
+        ))
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract classes with visibility and annotations."""
+        return self._extract_types(['class_declaration'])
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract package, interfaces, enums, records and annotation types."""
+        return {
+            'package': self._extract_package(),
+            'interfaces': self._extract_types(['interface_declaration']),
+            'enums': self._extract_types(['enum_declaration']),
+            'records': self._extract_types(['record_declaration']),
+            'annotations': self._extract_types(['annotation_type_declaration']),
+        }
+
+    def _extract_package(self) -> List[Dict[str, Any]]:
+        """Extract the package declaration."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node).rstrip(';'),
+        } for node in self._find_nodes_by_type('package_declaration')]
+
+    def _extract_types(self, node_types: List[str]) -> List[Dict[str, Any]]:
+        """Extract type declarations with visibility and annotations."""
+        types = []
+
+        for node_type in node_types:
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if not name:
+                    continue
+
+                entry = {
+                    'line': node.start_point[0] + 1,
+                    'line_end': node.end_point[0] + 1,
+                    'name': name,
+                }
+                entry.update(self._declaration_info(node))
+                types.append(entry)
+
+        return sorted(types, key=lambda t: t['line'])
+
+    def _declaration_info(self, node) -> Dict[str, Any]:
+        """Visibility, modifiers and annotations of a declaration."""
+        modifiers, annotations = self._get_modifiers(node)
+
+        visibility = 'package'
+        for keyword in ('public', 'protected', 'private'):
+            if keyword in modifiers:
+                visibility = keyword
+                break
+
+        # Interface members are implicitly public
+        if visibility == 'package' and self._in_interface(node):
+            visibility = 'public'
+
+        info = {'visibility': visibility}
+        other = [m for m in modifiers if m != visibility]
+        if other:
+            info['modifiers'] = other
+        if annotations:
+            info['annotations'] = annotations
+        return info
+
+    def _get_modifiers(self, node) -> Tuple[List[str], List[str]]:
+        """Split a declaration's `modifiers` node into keywords and annotations."""
+        keywords = []
+        annotations = []
+
+        for child in node.children:
+            if child.type != 'modifiers':
+                continue
+            for modifier in child.children:
+                text = self._get_node_text(modifier)
+                if modifier.type in ('marker_annotation', 'annotation'):
+                    annotations.append(text)
+                else:
+                    keywords.append(text)
+
+        return keywords, annotations
+
+    @staticmethod
+    def _in_interface(node) -> bool:
+        """Check whether a declaration is a member of an interface."""
+        parent = node.parent
+        return parent is not None and parent.type == 'interface_body'
+
+    def _get_signature(self, node) -> str:
+        """Get method signature: parameters, return type and throws clause."""
+        params = node.child_by_field_name('parameters')
+        signature = self._get_node_text(params) if params is not None else '()'
+
+        return_type = node.child_by_field_name('type')
+        if return_type is not None:
+            signature += f" -> {self._get_node_text(return_type)}"
+
+        for child in node.children:
+            if child.type == 'throws':
+                signature += f" {self._get_node_text(child)}"
+
+        return signature
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Make interfaces, enums, records and constructors extractable by name.
+
+        Constructors come last so `reveal Foo.java Foo` shows the class
+        rather than its constructor.
+        """
+        type_map = super()._get_element_type_map()
+        type_map['interface'] = ['interface_declaration']
+        type_map['enum'] = ['enum_declaration']
+        type_map['record'] = ['record_declaration']
+        type_map['constructor'] = ['constructor_declaration']
+        return type_map
@@ -0,0 +1,238 @@
+"""Julia file analyzer - tree-sitter based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+_FUNCTION_NAME = r'(?P<name>(?:[A-Z]\w*\.)*(?:[A-Za-z_][\w!]*|\([^)]+\)|[+\-*/^<>=!%&|]+))'
+_TYPE_PARAMS = r'(?P<type_params>\{[^}]*\})?'
+
+
+@register('.jl', name='Julia', icon='')
+class JuliaAnalyzer(TreeSitterAnalyzer):
+    """Julia file analyzer.
+
+    Extracts:
+    - Modules (nested modules named Outer.Inner) and their exports
+    - using / import statements
+    - Structs (mutable, parametric, with supertype) and abstract types
+    - Functions - long form and one-line `f(x) = ...` - one entry per
+      method, so multiple-dispatch signatures are listed side by side
+    - Macros
+
+    The parse tree gives each definition, its nesting and where it
+    ends, so docstrings and `end` inside indexing (a[end]) need no
+    special handling; names and signatures are read from the first line.
+    """
+    language = 'julia'
+
+    MODULE_NODES = ('module_definition', 'baremodule_definition')
+    # Node types per category; both the original and the rewritten grammar's names
+    CATEGORY_NODES = {
+        'modules': MODULE_NODES,
+        'imports': ('using_statement', 'import_statement'),
+        'exports': ('export_statement', 'public_statement'),
+        'abstract_types': ('abstract_definition',),
+        'structs': ('struct_definition',),
+        'macros': ('macro_definition',),
+        'functions': ('function_definition',),
+    }
+    # `f(x) = ...` is its own node type in the original grammar, an assignment in the rewritten one
+    SHORT_FUNCTION_NODES = ('short_function_definition', 'assignment', 'assignment_expression')
+    # Definitions nested only in these are at definition level (struct inner constructors included)
+    DEFINITION_SCOPES = MODULE_NODES + ('source_file', 'struct_definition', 'block', 'macrocall_expression',
+                                        'macro_argument_list')
+
+    # Patterns read each definition's first line
+    patterns = {
+        'modules': re.compile(r'^\s*(?:bare)?module\s+(?P<name>\w+)'),
+        'imports': re.compile(r'^\s*(?P<content>(?:using|import)\s+.+?)\s*$'),
+        'exports': re.compile(r'^\s*(?:export|public)\s+(?P<content>.+?)\s*$'),
+        'abstract_types': re.compile(
+            r'^\s*abstract\s+type\s+(?P<name>\w+)' + _TYPE_PARAMS + r'(?:\s*<:\s*(?P<supertype>[\w.{}, ]+?))?\s+end'
+        ),
+        'structs': re.compile(
+            r'^\s*(?P<mutable>mutable\s+)?struct\s+(?P<name>\w+)' + _TYPE_PARAMS +
+            r'(?:\s*<:\s*(?P<supertype>[\w.{}, ]+?))?\s*(?:#.*)?$'
+        ),
+        'macros': re.compile(r'^\s*macro\s+(?P<name>\w+)\s*(?P<signature>\(.*\))'),
+        'functions': re.compile(
+            r'^\s*(?:@\w+\s+)*function\s+' + _FUNCTION_NAME + _TYPE_PARAMS + r'\s*(?P<params>\(.*)?$'
+        ),
+    }
+    SHORT_FUNCTION = re.compile(
+        r'^\s*(?:@\w+\s+)*' + _FUNCTION_NAME + _TYPE_PARAMS + r'(?P<params>\(.*?\))'
+        r'(?:\s*::\s*[\w.{}]+)?(?:\s+where\s+.+?)?\s*=(?![=>])'
+    )
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; qualify nested modules and count dispatch methods."""
+        if not self.tree:
+            return {}
+
+        structure = {}
+        for category, node_types in self.CATEGORY_NODES.items():
+            nodes = sorted((n for t in node_types for n in self._find_nodes_by_type(t)), key=lambda n: n.start_byte)
+            if category == 'functions':
+                nodes = sorted(nodes + self._short_functions(), key=lambda n: n.start_byte)
+                nodes = [n for n in nodes if self._is_definition_level(n)]
+            entries = (self._node_entry(category, node) for node in nodes)
+            structure[category] = [entry for entry in entries if entry is not None]
+
+        functions = structure['functions']
+        counts = {}
+        for function in functions:
+            counts[function['name']] = counts.get(function['name'], 0) + 1
+        for function in functions:
+            if counts[function['name']] > 1:
+                function['dispatch'] = counts[function['name']]
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _node_entry(self, category: str, node) -> Optional[Dict[str, Any]]:
+        """Entry for a definition node, read from its first line."""
+        line_no = node.start_point[0] + 1
+        line = self.lines[line_no - 1][node.start_point[1]:]
+        if node.type in self.SHORT_FUNCTION_NODES:
+            match = self.SHORT_FUNCTION.match(line)
+            if not match:
+                return None
+            entry = self._short_function_entry(match, line_no)
+            self._set_module(entry, node)
+            return entry
+
+        match = self.patterns[category].match(line)
+        if not match:
+            return None
+        groups = {k: v for k, v in match.groupdict().items() if v is not None}
+        entry = {'line': line_no}
+        if 'name' in groups:
+            entry['name'] = groups.pop('name')
+        else:
+            entry['content'] = groups.pop('content')
+        line_end = node.end_point[0] + 1
+        if line_end > line_no and category not in ('imports', 'exports', 'abstract_types'):
+            entry['line_end'] = line_end
+
+        if category == 'modules':
+            entry['name'] = '.'.join(self._enclosing_modules(node) + [entry['name']])
+
+        elif category in ('structs', 'abstract_types'):
+            signature = groups.get('type_params', '')
+            if groups.get('supertype'):
+                entry['supertype'] = groups['supertype']
+                signature += f" <: {groups['supertype']}"
+            if groups.get('mutable'):
+                entry['mutable'] = True
+                signature += '  (mutable)'
+            if category == 'structs':
+                entry['fields'] = self._fields(line_no, entry.get('line_end', line_no))
+            entry['signature'] = signature
+
+        elif category == 'macros':
+            entry['signature'] = groups['signature']
+
+        elif category == 'functions':
+            entry['signature'] = groups.get('type_params', '') + self._signature(groups.get('params', ''), line_no)
+
+        if category in ('functions', 'macros') and 'line_end' in entry:
+            entry['line_count'] = entry['line_end'] - line_no + 1
+        if category == 'functions':
+            self._set_module(entry, node)
+        return entry
+
+    def _short_function_entry(self, match, line_no: int) -> Dict[str, Any]:
+        """`f(x::Int) = ...` one-line method definition."""
+        header = self.lines[line_no - 1].split('=', 1)[0] if '==' not in self.lines[line_no - 1] else ''
+        entry = {
+            'line': line_no,
+            'name': match.group('name'),
+            'signature': (match.group('type_params') or '') + self._signature(match.group('params'), line_no),
+            'form': 'short',
+        }
+        where = re.search(r'\bwhere\s+(.+?)\s*$', header)
+        if where and 'where' not in entry['signature']:
+            entry['signature'] += f" where {where.group(1)}"
+        return entry
+
+    def _signature(self, params: str, line_no: int) -> str:
+        """'(x::Int, y) -> Ret where T' from the text after the name."""
+        if not params:
+            return '()'
+        text = params
+        if params.count('(') > params.count(')'):
+            # Parameter list continues on following lines
+            text = ' '.join([params] + [line.strip() for line in self.lines[line_no:line_no + 10]])
+
+        depth = 0
+        for i, char in enumerate(text):
+            if char == '(':
+                depth += 1
+            elif char == ')':
+                depth -= 1
+                if depth == 0:
+                    args = ' '.join(text[1:i].split())
+                    rest = text[i + 1:]
+                    break
+        else:
+            return ' '.join(text.split())
+
+        signature = f"({args})"
+        returns = re.match(r'\s*::\s*([\w.{}, ]+?)(?=\s+where\b|\s*=|\s*$)', rest)
+        if returns:
+            signature += f" -> {returns.group(1)}"
+        where = re.search(r'\bwhere\s+(\{[^}]*\}|[\w<:{} ]+?)(?=\s*=|\s*$)', rest)
+        if where:
+            signature += f" where {where.group(1).strip()}"
+        return signature
+
+    def _fields(self, start: int, end: int) -> List[str]:
+        """Field names of a struct body."""
+        fields = []
+        for line in self.lines[start:end - 1]:
+            match = re.match(r'^\s*(?:const\s+)?(\w+)\s*(?:::.*)?$', line.split('#', 1)[0])
+            if match and match.group(1) not in ('end', 'function'):
+                fields.append(match.group(1))
+        return fields
+
+    def _short_functions(self) -> List:
+        """Nodes of one-line `f(x) = ...` method definitions."""
+        nodes = [n for t in self.SHORT_FUNCTION_NODES for n in self._find_nodes_by_type(t)]
+        return [n for n in nodes
+                if self.SHORT_FUNCTION.match(self.lines[n.start_point[0]][n.start_point[1]:])]
+
+    def _is_definition_level(self, node) -> bool:
+        """Definitions at file or module level (or struct inner constructors), not local closures."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type not in self.DEFINITION_SCOPES:
+                return False
+            parent = parent.parent
+        return True
+
+    def _enclosing_modules(self, node) -> List[str]:
+        """Names of the modules around a node, outermost first."""
+        names = []
+        parent = node.parent
+        while parent is not None:
+            if parent.type in self.MODULE_NODES:
+                match = self.patterns['modules'].match(self._get_node_text(parent))
+                if match:
+                    names.insert(0, match.group('name'))
+            parent = parent.parent
+        return names
+
+    def _set_module(self, entry: Dict[str, Any], node) -> None:
+        """Record the innermost module an entry is defined in."""
+        modules = self._enclosing_modules(node)
+        if modules:
+            entry['module'] = '.'.join(modules)
@@ -0,0 +1,215 @@
+"""Kotlin file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.kt', '.kts', name='Kotlin', icon='')
+class KotlinAnalyzer(TreeSitterAnalyzer):
+    """Kotlin file analyzer.
+
+    Extracts:
+    - Package header and imports
+    - Classes, data classes, interfaces and enum classes
+    - Objects and companion objects
+    - Top-level functions, member functions and extension functions
+
+    Useful for Android and Kotlin/JVM projects.
+    """
+    language = 'kotlin'
+
+    # Node types that can appear as a function's receiver or return type
+    TYPE_NODES = ('user_type', 'nullable_type', 'function_type', 'parenthesized_type')
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract import headers."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node),
+        } for node in self._find_nodes_by_type('import_header')]
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract top-level (non-extension) functions."""
+        return [entry for entry, node in self._function_entries()
+                if 'receiver' not in entry and node.parent.type == 'source_file']
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract regular classes (data classes, interfaces and enums are separate)."""
+        return [c for c in self._class_entries() if c['kind'] == 'class']
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract Kotlin-specific declarations."""
+        classes = self._class_entries()
+        functions = self._function_entries()
+
+        return {
+            'package': [{
+                'line': node.start_point[0] + 1,
+                'content': self._get_node_text(node),
+            } for node in self._find_nodes_by_type('package_header')],
+            'data_classes': [c for c in classes if c['kind'] == 'data class'],
+            'interfaces': [c for c in classes if c['kind'] == 'interface'],
+            'enums': [c for c in classes if c['kind'] == 'enum class'],
+            'objects': self._extract_objects(),
+            'companion_objects': self._extract_companions(),
+            'methods': [entry for entry, node in functions
+                        if 'receiver' not in entry and node.parent.type != 'source_file'],
+            'extensions': [entry for entry, node in functions if 'receiver' in entry],
+        }
+
+    def _class_entries(self) -> List[Dict[str, Any]]:
+        """Extract all class declarations with their kind."""
+        classes = []
+
+        for node in self._find_nodes_by_type('class_declaration'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            keywords = [child.type for child in node.children if not child.is_named]
+            modifiers = self._get_modifiers(node)
+
+            if 'interface' in keywords:
+                kind = 'interface'
+            elif 'enum' in keywords or 'enum' in modifiers:
+                kind = 'enum class'
+            elif 'data' in modifiers:
+                kind = 'data class'
+            else:
+                kind = 'class'
+
+            classes.append({
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': name,
+                'kind': kind,
+                'visibility': self._get_visibility(modifiers),
+            })
+
+        return classes
+
+    def _extract_objects(self) -> List[Dict[str, Any]]:
+        """Extract object declarations (singletons)."""
+        objects = []
+
+        for node in self._find_nodes_by_type('object_declaration'):
+            name = self._get_node_name(node)
+            if name:
+                objects.append({
+                    'line': node.start_point[0] + 1,
+                    'line_end': node.end_point[0] + 1,
+                    'name': name,
+                    'visibility': self._get_visibility(self._get_modifiers(node)),
+                })
+
+        return objects
+
+    def _extract_companions(self) -> List[Dict[str, Any]]:
+        """Extract companion objects, named after their enclosing class."""
+        companions = []
+
+        for node in self._find_nodes_by_type('companion_object'):
+            name = self._get_node_name(node) or 'Companion'
+            owner = self._enclosing_class_name(node)
+
+            companions.append({
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': f"{owner}.{name}" if owner else name,
+            })
+
+        return companions
+
+    def _function_entries(self):
+        """Build (entry, node) pairs for every function declaration."""
+        entries = []
+
+        for node in self._find_nodes_by_type('function_declaration'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            entry = self._function_entry(node, name)
+            entry['visibility'] = self._get_visibility(self._get_modifiers(node))
+
+            receiver = self._get_receiver(node)
+            if receiver:
+                entry['receiver'] = receiver
+                entry['name'] = f"{receiver}.{name}"
+
+            entries.append((entry, node))
+
+        return entries
+
+    def _get_node_name(self, node) -> Optional[str]:
+        """Kotlin names are type_identifier / simple_identifier children."""
+        for child in node.children:
+            if child.type in ('type_identifier', 'simple_identifier'):
+                return self._get_node_text(child)
+        return None
+
+    def _get_receiver(self, node) -> Optional[str]:
+        """Get the receiver type of an extension function (fun String.shout())."""
+        for child in node.children:
+            if child.type == 'simple_identifier':
+                return None
+            if child.type in self.TYPE_NODES:
+                return self._get_node_text(child)
+        return None
+
+    def _get_signature(self, node) -> str:
+        """Get function signature: parameters and optional return type."""
+        params = '()'
+        return_type = ''
+        seen_params = False
+        expect_type = False
+
+        for child in node.children:
+            if child.type == 'function_value_parameters':
+                params = self._get_node_text(child)
+                seen_params = True
+            elif seen_params and child.type == ':':
+                expect_type = True
+            elif expect_type:
+                return_type = f" -> {self._get_node_text(child)}"
+                break
+
+        return params + return_type
+
+    def _get_modifiers(self, node) -> List[str]:
+        """Get modifier keywords (data, private, override, ...) of a declaration."""
+        modifiers = []
+
+        for child in node.children:
+            if child.type != 'modifiers':
+                continue
+            for modifier in child.children:
+                if modifier.type != 'annotation':
+                    modifiers.append(self._get_node_text(modifier))
+
+        return modifiers
+
+    @staticmethod
+    def _get_visibility(modifiers: List[str]) -> str:
+        """Kotlin declarations are public unless stated otherwise."""
+        for keyword in ('private', 'protected', 'internal', 'public'):
+            if keyword in modifiers:
+                return keyword
+        return 'public'
+
+    def _enclosing_class_name(self, node) -> Optional[str]:
+        """Find the name of the class or object enclosing a node."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type in ('class_declaration', 'object_declaration'):
+                return self._get_node_name(parent)
+            parent = parent.parent
+        return None
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Map element types to Kotlin node types."""
+        return {
+            'function': ['function_declaration'],
+            'class': ['class_declaration', 'object_declaration'],
+        }
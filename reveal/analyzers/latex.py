@@ -0,0 +1,259 @@
+"""LaTeX document analyzer."""
+
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+
+
+@register('.tex', '.ltx', '.sty', '.cls', name='LaTeX', icon='')
+class LaTeXAnalyzer(FileAnalyzer):
+    """LaTeX document analyzer.
+
+    Extracts:
+    - Packages (\\usepackage, \\RequirePackage) and the document class
+    - Included files (\\input, \\include, \\subfile) and bibliography
+      files (\\bibliography, \\addbibresource)
+    - The sectioning hierarchy (\\part down to \\subparagraph); each
+      heading spans its section, shown indented like Markdown headings
+    - Defined commands and environments (\\newcommand, \\def,
+      \\DeclareMathOperator, \\newenvironment, ...) with argument counts
+    - Cited keys (\\cite, \\citep, \\parencite, ...) with citation counts
+
+    Comments (% ...) and verbatim environments are ignored.
+    """
+
+    SECTION_LEVELS = {
+        'part': 1, 'chapter': 2, 'section': 3, 'subsection': 4,
+        'subsubsection': 5, 'paragraph': 6, 'subparagraph': 7,
+    }
+
+    COMMAND = re.compile(r'\\(?P<command>[A-Za-z]+)(?P<star>\*)?')
+    DEFINITIONS = ('newcommand', 'renewcommand', 'providecommand', 'DeclareRobustCommand',
+                   'DeclareMathOperator', 'newenvironment', 'renewenvironment', 'def', 'gdef', 'edef',
+                   'NewDocumentCommand', 'RenewDocumentCommand', 'NewDocumentEnvironment')
+    INCLUDES = ('input', 'include', 'subfile', 'InputIfFileExists')
+    BIBLIOGRAPHY = ('bibliography', 'addbibresource')
+    VERBATIM = re.compile(r'\\begin\{(verbatim|lstlisting|minted|comment|Verbatim)\*?\}')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract packages, includes, headings, definitions and citations."""
+        structure = {
+            'packages': [],
+            'includes': [],
+            'headings': [],
+            'commands': [],
+            'citations': [],
+        }
+        citations = {}
+        code = self._code_lines()
+
+        for i, line in enumerate(code, 1):
+            for match in self.COMMAND.finditer(line):
+                command = match.group('command')
+                rest_start = match.end()
+
+                if command in ('usepackage', 'RequirePackage', 'documentclass'):
+                    options, position = self._optional(code, i, rest_start)
+                    names, _ = self._argument(code, i, position)
+                    for name in (n.strip() for n in (names or '').split(',') if n.strip()):
+                        entry = {'line': i, 'name': name}
+                        if command == 'documentclass':
+                            entry['kind'] = 'class'
+                        if options:
+                            entry['options'] = options
+                            entry['signature'] = f" [{options}]"
+                        if command == 'documentclass':
+                            entry['signature'] = entry.get('signature', '') + '  (class)'
+                        structure['packages'].append(entry)
+
+                elif command in self.INCLUDES or command in self.BIBLIOGRAPHY:
+                    target, _ = self._argument(code, i, rest_start)
+                    for name in (n.strip() for n in (target or '').split(',') if n.strip()):
+                        entry = {'line': i, 'content': f"\\{command}{{{name}}}", 'target': name}
+                        if command in self.BIBLIOGRAPHY:
+                            entry['kind'] = 'bibliography'
+                        structure['includes'].append(entry)
+
+                elif command in self.SECTION_LEVELS:
+                    short, position = self._optional(code, i, rest_start)
+                    title, _ = self._argument(code, i, position)
+                    if title is None:
+                        continue
+                    entry = {
+                        'line': i,
+                        'level': self.SECTION_LEVELS[command],
+                        'name': ' '.join(title.split()),
+                        'kind': command,
+                    }
+                    if match.group('star'):
+                        entry['numbered'] = False
+                    structure['headings'].append(entry)
+
+                elif command in self.DEFINITIONS:
+                    entry = self._definition(code, i, command, rest_start)
+                    if entry:
+                        structure['commands'].append(entry)
+
+                elif re.match(r'^(?:no)?cite[a-z]*$|^(?:paren|foot|auto|text|full)cite[a-z]*$|^[Cc]ite[a-z]*$',
+                              command):
+                    _, position = self._optional(code, i, rest_start)
+                    _, position = self._optional(code, i, position)
+                    keys, _ = self._argument(code, i, position)
+                    for key in (k.strip() for k in (keys or '').split(',') if k.strip()):
+                        if key not in citations:
+                            citations[key] = {'line': i, 'name': key, 'count': 0}
+                            structure['citations'].append(citations[key])
+                        citations[key]['count'] += 1
+
+        self._set_heading_ends(structure['headings'])
+        for citation in structure['citations']:
+            if citation['count'] > 1:
+                citation['signature'] = f" ({citation['count']}x)"
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _definition(self, code: List[str], line: int, command: str, position: int) -> Optional[Dict[str, Any]]:
+        """\\newcommand{\\name}[2]{...}, \\def\\name#1{...}, \\newenvironment{name}[1]{...}{...}."""
+        text = code[line - 1][position:]
+        if command in ('def', 'gdef', 'edef'):
+            match = re.match(r'\s*\\([A-Za-z@]+)((?:#\d)*)', text)
+            if not match:
+                return None
+            entry = {'line': line, 'name': f"\\{match.group(1)}", 'kind': 'def'}
+            params = len(re.findall(r'#\d', match.group(2)))
+        else:
+            match = re.match(r'\s*\*?\s*\{?\s*(\\?[A-Za-z@*]+)\s*\}?', text)
+            if not match:
+                return None
+            name = match.group(1)
+            environment = 'environment' in command.lower()
+            entry = {'line': line, 'name': name if environment or name.startswith('\\') else f"\\{name}",
+                     'kind': 'environment' if environment else 'command'}
+            count = re.match(r'\s*\[(\d)\]', text[match.end():])
+            params = int(count.group(1)) if count else 0
+            if command == 'DeclareMathOperator':
+                entry['kind'] = 'operator'
+
+        line_end = self._definition_end(code, line, position)
+        if line_end > line:
+            entry['line_end'] = line_end
+        if params:
+            entry['params'] = params
+        entry['signature'] = f"[{params}]" if params else ''
+        if entry['kind'] != 'command':
+            entry['signature'] += f"  ({entry['kind']})"
+        return entry
+
+    def _definition_end(self, code: List[str], line: int, position: int) -> int:
+        """Last line of a definition: braces opened after position balanced."""
+        depth = 0
+        opened = False
+        for i in range(line - 1, len(code)):
+            text = code[i][position:] if i == line - 1 else code[i]
+            text = re.sub(r'\\[{}]', '', text)
+            for char in text:
+                if char == '{':
+                    depth += 1
+                    opened = True
+                elif char == '}':
+                    depth -= 1
+            if opened and depth <= 0:
+                following = code[i + 1].lstrip() if i + 1 < len(code) else ''
+                if not following.startswith('{'):
+                    return i + 1
+        return line
+
+    def _argument(self, code: List[str], line: int, position: int) -> Tuple[Optional[str], int]:
+        """Balanced {…} argument starting at position (may continue on later lines)."""
+        text = code[line - 1][position:]
+        stripped = text.lstrip()
+        if not stripped.startswith('{'):
+            return None, position
+        start = len(text) - len(stripped)
+        depth = 0
+        collected = ''
+        for offset, char in enumerate(stripped):
+            if char == '{':
+                depth += 1
+                if depth == 1:
+                    continue
+            elif char == '}':
+                depth -= 1
+                if depth == 0:
+                    return collected, position + start + offset + 1
+            collected += char
+        # Argument continues on the following lines (long titles)
+        for following in code[line:line + 5]:
+            for char in following:
+                if char == '{':
+                    depth += 1
+                elif char == '}':
+                    depth -= 1
+                    if depth == 0:
+                        return collected, len(code[line - 1])
+                collected += char
+            collected += ' '
+        return None, position
+
+    def _optional(self, code: List[str], line: int, position: int) -> Tuple[Optional[str], int]:
+        """[…] optional argument starting at position."""
+        text = code[line - 1][position:]
+        match = re.match(r'\s*\[([^\]]*)\]', text)
+        if not match:
+            return None, position
+        return match.group(1).strip(), position + match.end()
+
+    def _set_heading_ends(self, headings: List[Dict[str, Any]]):
+        """Each heading spans its section, up to the next heading of the same or higher level."""
+        end_document = next((i for i, line in enumerate(self.lines, 1) if '\\end{document}' in line),
+                            len(self.lines) + 1) - 1
+        for index, heading in enumerate(headings):
+            heading['line_end'] = max(end_document, heading['line'])
+            for following in headings[index + 1:]:
+                if following['level'] <= heading['level']:
+                    heading['line_end'] = following['line'] - 1
+                    break
+
+    def _code_lines(self) -> List[str]:
+        """Lines with % comments removed and verbatim environments blanked."""
+        code = []
+        verbatim = None
+
+        for line in self.lines:
+            if verbatim:
+                code.append('')
+                if f"\\end{{{verbatim}" in line:
+                    verbatim = None
+                continue
+            start = self.VERBATIM.search(line)
+            if start and f"\\end{{{start.group(1)}" not in line[start.end():]:
+                verbatim = start.group(1)
+                code.append(line[:start.start()])
+                continue
+            code.append(re.sub(r'(?<!\\)%.*', '', line))
+
+        return code
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a section (by title) or a command definition."""
+        structure = self.get_structure()
+
+        for category in ('headings', 'commands'):
+            for item in structure.get(category, []):
+                if item['name'] in (name, f"\\{name}"):
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item['name'],
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
@@ -0,0 +1,426 @@
+"""Lisp-family analyzer (Clojure, Scheme, Racket) - s-expression based."""
+
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+
+
+class Form(list):
+    """A parenthesized/bracketed form with its source line range."""
+
+    def __init__(self, opener: str, line: int):
+        super().__init__()
+        self.opener = opener
+        self.line = line
+        self.line_end = line
+
+
+class Atom(str):
+    """A symbol, keyword, number or string literal with its line."""
+
+    def __new__(cls, text: str, line: int):
+        atom = super().__new__(cls, text)
+        atom.line = line
+        return atom
+
+
+class Metadata:
+    """Clojure ^metadata attached to the following form."""
+
+    def __init__(self, value):
+        self.value = value
+
+
+CLOSERS = {'(': ')', '[': ']', '{': '}'}
+
+
+def read_forms(source: str) -> List[Any]:
+    """Read top-level forms. Tolerates unbalanced input (unclosed forms end at EOF)."""
+    top = []
+    stack = []
+    line = 1
+    i = 0
+    n = len(source)
+    discard = 0   # pending #_ discards (Clojure)
+
+    def add(item):
+        nonlocal discard
+        if discard:
+            discard -= 1
+            return
+        (stack[-1] if stack else top).append(item)
+
+    while i < n:
+        char = source[i]
+
+        if char == '\n':
+            line += 1
+            i += 1
+        elif char.isspace() or char == ',':
+            i += 1
+        elif char == ';':
+            while i < n and source[i] != '\n':
+                i += 1
+        elif source.startswith('#|', i):
+            end = source.find('|#', i + 2)
+            end = n if end < 0 else end + 2
+            line += source.count('\n', i, end)
+            i = end
+        elif source.startswith('#;', i) or source.startswith('#_', i):
+            discard += 1
+            i += 2
+        elif char == '"':
+            start, start_line = i, line
+            i += 1
+            while i < n and source[i] != '"':
+                if source[i] == '\\':
+                    i += 1
+                elif source[i] == '\n':
+                    line += 1
+                i += 1
+            i += 1
+            add(Atom(source[start:i], start_line))
+        elif char in '([{':
+            stack.append(Form(char, line))
+            i += 1
+        elif char in ')]}':
+            i += 1
+            if stack:
+                form = stack.pop()
+                form.line_end = line
+                add(form)
+        elif char == '#' and i + 1 < n and source[i + 1] in '({[':
+            # #( #{ #[ - anonymous fn / set / vector literal: read as a form
+            i += 1
+        elif char == '#' and source.startswith('#\\', i):
+            i += 3
+            while i < n and not source[i].isspace() and source[i] not in '()[]{}':
+                i += 1
+        elif char in "'`@^~" or (char == '#' and i + 1 < n and source[i + 1] in "'`,"):
+            # Reader prefixes; ^ marks metadata on the next form
+            if char == '^':
+                (stack[-1] if stack else top).append(Metadata(None))
+            i += 2 if char == '#' else 1
+            if char == '~' and i < n and source[i] == '@':
+                i += 1
+        else:
+            start = i
+            while i < n and not source[i].isspace() and source[i] not in '()[]{}";,':
+                i += 1
+            add(Atom(source[start:i], line))
+
+    while stack:
+        form = stack.pop()
+        form.line_end = line
+        (stack[-1] if stack else top).append(form)
+
+    return [_attach_metadata(form) for form in top if not isinstance(form, Metadata)]
+
+
+def _attach_metadata(form):
+    """Drop metadata markers inside forms, keeping the marked forms as `meta`."""
+    if not isinstance(form, Form):
+        return form
+
+    items = list(form)
+    del form[:]
+    form.meta = []
+    pending = False
+    for item in items:
+        if isinstance(item, Metadata):
+            pending = True
+            continue
+        if pending:
+            form.meta.append(item)
+            pending = False
+            continue
+        form.append(_attach_metadata(item))
+    return form
+
+
+def text_of(form) -> str:
+    """Render a form back to compact source text."""
+    if isinstance(form, Form):
+        return form.opener + ' '.join(text_of(f) for f in form) + CLOSERS[form.opener]
+    return str(form)
+
+
+@register('.clj', '.cljs', '.cljc', '.edn', '.scm', '.ss', '.sld', '.rkt', name='Lisp', icon='')
+class LispAnalyzer(FileAnalyzer):
+    """Lisp-family analyzer built on a small s-expression reader.
+
+    Clojure: ns (with :require/:import), defn/defn- (arities and private
+    flag), def, defmacro, defprotocol/defrecord/deftype/definterface,
+    defmulti/defmethod.
+
+    Scheme/Racket: #lang and module forms, require/provide, define
+    (function or value), define-syntax, define-record-type and struct.
+
+    Forms are matched structurally, so nested parentheses, strings and
+    comments never confuse the outline.
+    """
+
+    FUNCTION_FORMS = {'defn', 'defn-', 'define', 'define*', 'define/contract', 'define/public',
+                      'define/private', 'define/override', 'defun'}
+    MACRO_FORMS = {'defmacro', 'define-syntax', 'define-syntax-rule', 'define-macro', 'defsyntax'}
+    TYPE_FORMS = {'defprotocol', 'defrecord', 'deftype', 'definterface', 'define-record-type',
+                  'struct', 'define-struct', 'defstruct', 'define-class'}
+    VALUE_FORMS = {'def', 'defonce', 'define-values', 'defvar', 'defparameter', 'defconst'}
+    METHOD_FORMS = {'defmulti', 'defmethod'}
+    IMPORT_FORMS = {'require', 'import', 'use', 'provide', 'require-python'}
+    NAMESPACE_FORMS = {'ns', 'module', 'module+', 'module*', 'define-library', 'library'}
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract namespaces, imports, functions, macros, types and values."""
+        structure = {
+            'namespaces': [],
+            'imports': [],
+            'exports': [],
+            'types': [],
+            'functions': [],
+            'macros': [],
+            'methods': [],
+            'definitions': [],
+        }
+
+        lang = self._racket_lang()
+        if lang:
+            structure['namespaces'].append({'line': 1, 'content': lang})
+
+        for form in read_forms(self.content):
+            self._visit(form, structure, prefix='')
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _visit(self, form, structure: Dict[str, List[Dict[str, Any]]], prefix: str) -> None:
+        """Classify one top-level (or module-level) form."""
+        if not isinstance(form, Form) or form.opener != '(' or not form or not isinstance(form[0], Atom):
+            return
+
+        head = str(form[0])
+        entry = {'line': form.line}
+        if form.line_end > form.line:
+            entry['line_end'] = form.line_end
+
+        if head in self.NAMESPACE_FORMS:
+            name = self._name(form)
+            entry['name'] = name or head
+            structure['namespaces'].append(entry)
+            for item in form[2:]:
+                # (ns foo (:require ...)) clauses, or forms inside a (module ...)
+                if isinstance(item, Form) and item and str(item[0]).lstrip(':') in self.IMPORT_FORMS:
+                    self._add_imports(item, structure)
+                elif head != 'ns':
+                    self._visit(item, structure, prefix=prefix)
+            return
+
+        if head in self.IMPORT_FORMS:
+            self._add_imports(form, structure)
+            return
+
+        if head in ('begin', 'do', 'progn', 'when', 'eval-when-compile'):
+            for item in form[1:]:
+                self._visit(item, structure, prefix)
+            return
+
+        name_form = form[1] if len(form) > 1 else None
+
+        if head in self.FUNCTION_FORMS:
+            if isinstance(name_form, Form) and name_form.opener == '(':
+                # Scheme: (define (name args ...) body)
+                self._curried_name(name_form, entry)
+                structure['functions'].append(self._function(entry, head))
+            elif isinstance(name_form, Atom):
+                entry['name'] = str(name_form)
+                arities = self._arities(form[2:])
+                if arities is not None:
+                    entry['signature'] = f" {' '.join(arities)}"
+                    if len(arities) > 1:
+                        entry['arities'] = len(arities)
+                    structure['functions'].append(self._function(entry, head, form))
+                elif len(form) > 2 and self._is_lambda(form[2]):
+                    entry['signature'] = self._lambda_params(form[2])
+                    structure['functions'].append(self._function(entry, head, form))
+                else:
+                    structure['definitions'].append(entry)
+            return
+
+        if head in self.MACRO_FORMS:
+            if isinstance(name_form, Form) and name_form:
+                self._curried_name(name_form, entry)
+            elif name_form is not None:
+                entry['name'] = str(name_form)
+                arities = self._arities(form[2:])
+                if arities:
+                    entry['signature'] = f" {arities[0]}"
+            if 'name' in entry:
+                structure['macros'].append(entry)
+            return
+
+        if head in self.TYPE_FORMS and name_form is not None:
+            if isinstance(name_form, Form):
+                name_form = name_form[0] if name_form else ''
+            entry['name'] = str(name_form)
+            entry['kind'] = head
+            fields = form[2] if len(form) > 2 else None
+            if head in ('defrecord', 'deftype', 'struct', 'define-struct') and isinstance(fields, Form):
+                entry['signature'] = f" {text_of(fields)}"
+            elif head == 'defprotocol':
+                methods = [str(f[0]) for f in form[2:] if isinstance(f, Form) and f]
+                if methods:
+                    entry['methods'] = methods
+                    entry['signature'] = f" ({', '.join(methods)})"
+            structure['types'].append(entry)
+            return
+
+        if head in self.METHOD_FORMS and isinstance(name_form, Atom):
+            entry['name'] = str(name_form)
+            if head == 'defmethod' and len(form) > 2:
+                entry['signature'] = f" {text_of(form[2])}"
+            entry['kind'] = head
+            structure['methods'].append(entry)
+            return
+
+        if head in self.VALUE_FORMS and name_form is not None:
+            if isinstance(name_form, Form):
+                entry['name'] = text_of(name_form)
+            else:
+                entry['name'] = str(name_form)
+                if len(form) > 2 and self._is_lambda(form[-1]):
+                    entry['signature'] = self._lambda_params(form[-1])
+                    structure['functions'].append(self._function(entry, head, form))
+                    return
+            if self._is_private(form):
+                entry['visibility'] = 'private'
+            structure['definitions'].append(entry)
+
+    def _function(self, entry: Dict[str, Any], head: str, form: Optional[Form] = None) -> Dict[str, Any]:
+        """Add visibility and line count to a function entry."""
+        if head == 'defn-' or head == 'define/private' or (form is not None and self._is_private(form)):
+            entry['visibility'] = 'private'
+        if 'line_end' in entry:
+            entry['line_count'] = entry['line_end'] - entry['line'] + 1
+        return entry
+
+    @staticmethod
+    def _curried_name(name_form: Form, entry: Dict[str, Any]) -> None:
+        """(define ((f a) b) ...) and (define (f a b) ...) -> name f, signature (a b)."""
+        inner = name_form
+        while isinstance(inner, Form) and inner and isinstance(inner[0], Form):
+            inner = inner[0]
+        entry['name'] = str(inner[0]) if inner else '?'
+        entry['signature'] = '(' + ' '.join(text_of(p) for p in inner[1:]) + ')'
+
+    @staticmethod
+    def _is_lambda(form) -> bool:
+        """(fn [...] ...), (lambda (...) ...) and friends."""
+        return isinstance(form, Form) and form.opener == '(' and bool(form) and \
+            str(form[0]) in ('fn', 'lambda', 'λ', 'case-lambda', 'memoize', 'fn*')
+
+    @staticmethod
+    def _lambda_params(form: Form) -> str:
+        """Parameters of a lambda: ' [x]' (Clojure vector) or '(x)'."""
+        if len(form) < 2:
+            return '()'
+        params = form[1]
+        if isinstance(params, Form) and params.opener == '[':
+            return f" {text_of(params)}"
+        return text_of(params) if isinstance(params, Form) else f"({params})"
+
+    @staticmethod
+    def _arities(body: List[Any]) -> Optional[List[str]]:
+        """Clojure parameter vectors after an optional docstring/attr-map."""
+        items = [item for item in body if not (isinstance(item, Atom) and item.startswith('"'))]
+        items = [item for item in items if not (isinstance(item, Form) and item.opener == '{')]
+        if not items:
+            return None
+
+        first = items[0]
+        if isinstance(first, Form) and first.opener == '[':
+            return [text_of(first)]
+
+        arities = []
+        for item in items:
+            if isinstance(item, Form) and item.opener == '(' and item and \
+                    isinstance(item[0], Form) and item[0].opener == '[':
+                arities.append(text_of(item[0]))
+            else:
+                break
+        return arities or None
+
+    @staticmethod
+    def _is_private(form: Form) -> bool:
+        """^:private / ^{:private true} metadata on the definition."""
+        for meta in getattr(form, 'meta', []):
+            text = text_of(meta)
+            if ':private' in text and 'false' not in text:
+                return True
+        return False
+
+    @staticmethod
+    def _name(form: Form) -> Optional[str]:
+        """Name of a ns/module/library form."""
+        if len(form) > 1:
+            return text_of(form[1]) if isinstance(form[1], Form) else str(form[1])
+        return None
+
+    # Racket/R7RS require/provide wrappers: (only-in lib name ...)
+    IMPORT_WRAPPERS = {'only-in', 'except-in', 'prefix-in', 'rename-in', 'for-syntax', 'only',
+                       'except', 'prefix', 'rename', 'struct-out', 'contract-out', 'all-from-out'}
+
+    def _add_imports(self, form: Form, structure: Dict[str, List[Dict[str, Any]]]) -> None:
+        """One entry per required library (or provided name, listed as exports)."""
+        kind = str(form[0]).lstrip(':')
+        category = 'exports' if kind == 'provide' else 'imports'
+        structure.setdefault(category, [])
+
+        for item in form[1:]:
+            if isinstance(item, Atom) and item.startswith(':'):
+                continue  # (require ... :reload) flags
+            entry = {'line': getattr(item, 'line', form.line)}
+
+            if isinstance(item, Form) and item and isinstance(item[0], Atom) and \
+                    str(item[0]) in self.IMPORT_WRAPPERS and len(item) > 1:
+                # (only-in racket/string string-join) -> racket/string (only-in string-join)
+                entry['name'] = text_of(item[1]).strip('"')
+                details = ' '.join([str(item[0])] + [text_of(p) for p in item[2:]])
+                entry['signature'] = f" ({details})"
+            elif isinstance(item, Form) and item:
+                # [clojure.string :as str], (java.util Date)
+                entry['name'] = text_of(item[0]).strip('"')
+                if len(item) > 1:
+                    entry['signature'] = f" {' '.join(text_of(p) for p in item[1:])}"
+            else:
+                entry['name'] = text_of(item).strip('"')
+
+            if kind != ('provide' if category == 'exports' else 'require'):
+                entry['kind'] = kind
+            structure[category].append(entry)
+
+    def _racket_lang(self) -> Optional[str]:
+        """#lang line (Racket)."""
+        for line in self.lines[:5]:
+            if line.startswith('#lang'):
+                return line.strip()
+        return None
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a definition form by name."""
+        for items in self.get_structure().values():
+            for item in items:
+                if item.get('name') == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': name,
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
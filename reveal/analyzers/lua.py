@@ -0,0 +1,192 @@
+"""Lua file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Iterator, Optional, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.lua', name='Lua', icon='')
+class LuaAnalyzer(TreeSitterAnalyzer):
+    """Lua file analyzer.
+
+    Extracts:
+    - require() calls
+    - Local and global functions (function f() / local f = function())
+    - Table functions (M.f, M:f) and the tables they form modules of;
+      the table returned at the end of the file is the module's export
+    """
+    language = 'lua'
+
+    FUNCTION_NODES = ('function_declaration', 'function_definition')
+    TABLE_MEMBERS = ('dot_index_expression', 'method_index_expression')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure with imports and modules listed first."""
+        structure = super().get_structure(head, tail, range, **kwargs)
+        leading = {k: structure.pop(k) for k in ('imports', 'modules') if k in structure}
+        return {**leading, **structure}
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract require() calls, with the local they are bound to."""
+        imports = []
+
+        for call in self._find_nodes_by_type('function_call'):
+            name = call.child_by_field_name('name')
+            if name is None or self._get_node_text(name) != 'require':
+                continue
+            module = self._string_argument(call)
+            if module is None:
+                continue
+
+            entry = {'line': call.start_point[0] + 1, 'name': module}
+            alias = self._local_bound_to(call)
+            if alias:
+                entry['signature'] = f" as {alias}"
+            imports.append(entry)
+
+        return imports
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract top-level local and global functions (nested ones are not listed)."""
+        return [entry for entry, node, target in self._definitions()
+                if target.type == 'identifier' and not self._is_nested(node)]
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract table functions and the module tables they form."""
+        methods = [entry for entry, _, target in self._definitions() if target.type in self.TABLE_MEMBERS]
+        return {'modules': self._module_tables(methods), 'methods': methods}
+
+    def _definitions(self) -> Iterator[Tuple[Dict[str, Any], Any, Any]]:
+        """(entry, function node, name node) for every named function, in file order.
+
+        Covers `function f()`, `local function f()`, `function M.f()`,
+        `function M:f()` and `f = function()` / `M.f = function()`.
+        """
+        found = []
+
+        for node in self._find_nodes_by_type('function_declaration'):
+            target = node.child_by_field_name('name')
+            if target is not None:
+                is_local = any(child.type == 'local' for child in node.children)
+                found.append((node, target, is_local))
+
+        for assignment in self._find_nodes_by_type('assignment_statement'):
+            for target, value in self._assigned_pairs(assignment):
+                if value.type == 'function_definition':
+                    found.append((value, target, assignment.parent is not None
+                                  and assignment.parent.type == 'variable_declaration'))
+
+        for node, target, is_local in sorted(found, key=lambda item: item[0].start_byte):
+            if target.type not in ('identifier',) + self.TABLE_MEMBERS:
+                continue
+            name = self._get_node_text(target)
+            # `M.f = function` starts at the assignment, not at `function`
+            start = target.start_point[0] + 1
+            end = node.end_point[0] + 1
+            params = node.child_by_field_name('parameters')
+            entry = {
+                'line': start,
+                'name': name,
+                'signature': self._get_node_text(params) if params is not None else '()',
+            }
+            if end > start:
+                entry['line_end'] = end
+                entry['line_count'] = end - start + 1
+            if target.type == 'identifier':
+                entry['visibility'] = 'local' if is_local else 'global'
+            else:
+                table = target.child_by_field_name('table')
+                entry['table'] = self._get_node_text(table) if table is not None else name
+                if target.type == 'method_index_expression':
+                    entry['kind'] = 'method'
+            yield entry, node, target
+
+    def _assigned_pairs(self, assignment) -> List[Tuple[Any, Any]]:
+        """(variable, value) pairs of `a, b = x, y`."""
+        variables = []
+        values = []
+        for child in assignment.children:
+            if child.type == 'variable_list':
+                variables = [node for node in child.children if node.is_named]
+            elif child.type == 'expression_list':
+                values = [node for node in child.children if node.is_named]
+        return list(zip(variables, values))
+
+    def _module_tables(self, methods: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        """Tables that have functions assigned to them."""
+        members = {}
+        for method in methods:
+            members.setdefault(method['table'], []).append(method['name'][len(method['table']) + 1:])
+
+        returned = self._returned_name()
+        modules = []
+        for table, names in members.items():
+            first_use = min(m['line'] for m in methods if m['table'] == table)
+            entry = {'line': self._table_definition(table) or first_use, 'name': table, 'functions': names}
+            shown = ', '.join(names[:5]) + (', ...' if len(names) > 5 else '')
+            entry['signature'] = f" ({len(names)} function{'s' if len(names) != 1 else ''}: {shown})"
+            if table == returned:
+                entry['exported'] = True
+                entry['signature'] += '  (returned)'
+            modules.append(entry)
+
+        return sorted(modules, key=lambda m: m['line'])
+
+    def _returned_name(self) -> Optional[str]:
+        """Name of the table the file ends by returning (`return M`)."""
+        statements = [child for child in self.tree.root_node.children if child.is_named and child.type != 'comment']
+        if not statements or statements[-1].type != 'return_statement':
+            return None
+
+        values = [child for child in statements[-1].children if child.is_named]
+        if len(values) == 1 and values[0].type == 'expression_list':
+            values = [child for child in values[0].children if child.is_named]
+        if len(values) == 1 and values[0].type in ('identifier', 'dot_index_expression'):
+            return self._get_node_text(values[0])
+        return None
+
+    def _table_definition(self, table: str) -> Optional[int]:
+        """Line where a table is created (`local M = {}`, `M = setmetatable(...)`)."""
+        for assignment in self._find_nodes_by_type('assignment_statement'):
+            for target, value in self._assigned_pairs(assignment):
+                if self._get_node_text(target) == table and value.type in ('table_constructor', 'function_call'):
+                    return target.start_point[0] + 1
+        return None
+
+    def _string_argument(self, call) -> Optional[str]:
+        """The string a call is made with: require("x"), require "x"."""
+        arguments = call.child_by_field_name('arguments')
+        if arguments is None:
+            return None
+        if arguments.type != 'string':
+            strings = [child for child in arguments.children if child.type == 'string']
+            if not strings:
+                return None
+            arguments = strings[0]
+
+        content = arguments.child_by_field_name('content')
+        if content is not None:
+            return self._get_node_text(content)
+        return self._get_node_text(arguments).strip('\'"[]=')
+
+    def _local_bound_to(self, call) -> Optional[str]:
+        """The local a call's result is assigned to (`local json = require(...)`)."""
+        expressions = call.parent
+        assignment = expressions.parent if expressions is not None else None
+        if assignment is None or assignment.type != 'assignment_statement' \
+                or assignment.parent is None or assignment.parent.type != 'variable_declaration':
+            return None
+        for target, value in self._assigned_pairs(assignment):
+            if value.start_byte == call.start_byte:
+                return self._get_node_text(target)
+        return None
+
+    def _is_nested(self, node) -> bool:
+        """Whether a function sits inside another function's body."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type in self.FUNCTION_NODES:
+                return True
+            parent = parent.parent
+        return False
@@ -0,0 +1,177 @@
+"""Makefile analyzer."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+@register('Makefile', 'GNUmakefile', '.mk', '.make', name='Makefile', icon='')
+class MakefileAnalyzer(FileAnalyzer):
+    """Makefile analyzer.
+
+    Extracts:
+    - Targets with their prerequisites (the default goal is marked)
+    - Phony targets (declared in .PHONY), listed separately
+    - Variables (=, :=, ?=, +=, define ... endef)
+    - include directives
+    """
+
+    VARIABLE = re.compile(
+        r'^(?:(?:export|override)\s+)*(?P<name>[A-Za-z_][\w.-]*)\s*(?P<op>[:?+!]*=|::=)\s*(?P<value>.*)$'
+    )
+    RULE = re.compile(r'^(?P<targets>[^\s:=#][^:=#]*?)\s*(?P<sep>::?)(?!=)\s*(?P<prereqs>[^=]*?)\s*(?:;.*)?$')
+    INCLUDE = re.compile(r'^-?(?:include|sinclude)\s+(?P<content>.+)$')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract targets, phony targets, variables and includes."""
+        targets = []
+        variables = []
+        includes = []
+        phony = set()
+        in_define = False
+
+        for line_no, line in self._logical_lines():
+            if in_define:
+                if re.match(r'^\s*endef\b', line):
+                    in_define = False
+                continue
+
+            # Recipe lines and comments
+            if line.startswith('\t') or not line.strip() or line.lstrip().startswith('#'):
+                continue
+
+            define = re.match(r'^(?:(?:export|override)\s+)*define\s+(?P<name>[\w.-]+)', line)
+            if define:
+                variables.append({'line': line_no, 'name': define.group('name'), 'signature': ' (define)'})
+                in_define = True
+                continue
+
+            include = self.INCLUDE.match(line)
+            if include:
+                includes.append({'line': line_no, 'content': line.strip()})
+                continue
+
+            variable = self.VARIABLE.match(line)
+            if variable:
+                value = ' '.join(variable.group('value').split())
+                if len(value) > 60:
+                    value = value[:57] + '...'
+                variables.append({
+                    'line': line_no,
+                    'name': variable.group('name'),
+                    'signature': f" {variable.group('op')} {value}".rstrip(),
+                })
+                continue
+
+            rule = self.RULE.match(line)
+            if not rule:
+                continue
+
+            prereqs = rule.group('prereqs').split()
+            if rule.group('targets').strip() == '.PHONY':
+                phony.update(prereqs)
+                continue
+
+            for target in rule.group('targets').split():
+                if target.startswith('.'):
+                    # Special targets (.SUFFIXES, .DEFAULT_GOAL, ...)
+                    continue
+                entry = {'line': line_no, 'name': target}
+                if prereqs:
+                    entry['signature'] = f": {' '.join(prereqs)}"
+                    entry['prerequisites'] = prereqs
+                line_end = self._recipe_end(line_no)
+                if line_end > line_no:
+                    entry['line_end'] = line_end
+                targets.append(entry)
+
+        default_goal = self._default_goal(targets)
+        for target in targets:
+            if target['name'] == default_goal:
+                target['default'] = True
+                target['signature'] = target.get('signature', '') + '  (default)'
+
+        structure = {
+            'targets': [t for t in targets if t['name'] not in phony],
+            'phony_targets': [t for t in targets if t['name'] in phony],
+            'variables': variables,
+            'includes': includes,
+        }
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _logical_lines(self):
+        """Yield (line_no, line) with backslash continuations joined."""
+        buffer = ''
+        start = None
+
+        for i, line in enumerate(self.lines, 1):
+            if start is None:
+                start = i
+            if line.endswith('\\') and not line.startswith('\t'):
+                buffer += line[:-1] + ' '
+                continue
+            yield start, buffer + line
+            buffer = ''
+            start = None
+
+        if buffer:
+            yield start, buffer
+
+    def _recipe_end(self, line_no: int) -> int:
+        """Last recipe line (tab-indented) following a rule."""
+        last = line_no
+
+        for i in range(line_no, len(self.lines)):
+            line = self.lines[i]
+            if line.startswith('\t'):
+                last = i + 1
+            elif line.strip() and not line.lstrip().startswith('#'):
+                break
+
+        return last
+
+    def _default_goal(self, targets: List[Dict[str, Any]]) -> Optional[str]:
+        """.DEFAULT_GOAL if set, otherwise the first non-pattern target."""
+        for line in self.lines:
+            match = re.match(r'^\.DEFAULT_GOAL\s*:?=\s*(\S+)', line)
+            if match:
+                return match.group(1)
+
+        for target in targets:
+            if '%' not in target['name']:
+                return target['name']
+        return None
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Target overview for directory trees: 'targets: all, build, test'."""
+        structure = self.get_structure()
+        names = [t['name'] for t in sorted(structure.get('targets', []) + structure.get('phony_targets', []),
+                                           key=lambda t: t['line']) if '%' not in t['name']]
+        if not names:
+            return None
+        return 'targets: ' + ', '.join(names[:6]) + (', ...' if len(names) > 6 else '')
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a target and its recipe."""
+        structure = self.get_structure()
+
+        for category in ('targets', 'phony_targets', 'variables'):
+            for item in structure.get(category, []):
+                if item['name'] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': name,
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
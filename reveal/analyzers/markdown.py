@@ -11,8 +11,15 @@ class MarkdownAnalyzer(FileAnalyzer):
     """Markdown file analyzer.
 
     Extracts headings, links, images, code blocks, and other entities.
+
+    By default shows the heading hierarchy (each heading spans its section)
+    plus a summary of code block languages and link targets; --links and
+    --code list every link and code block instead.
     """
 
+    # [text](url)
+    LINK_PATTERN = r'\[([^\]]+)\]\(([^\)]+)\)'
+
     def get_structure(self, head: int = None, tail: int = None,
                      range: tuple = None,
                      extract_links: bool = False,
@@ -37,7 +44,8 @@ class MarkdownAnalyzer(FileAnalyzer):
             **kwargs: Additional parameters (unused)
 
         Returns:
-            Dict with headings and optionally links/code
+            Dict with headings plus code language/link target summaries,
+            or full links/code blocks when requested
 
         Note: Slicing applies to each category independently
         (e.g., --head 5 shows first 5 headings AND first 5 links)
@@ -47,16 +55,23 @@ class MarkdownAnalyzer(FileAnalyzer):
         # Always extract headings
         result['headings'] = self._extract_headings()
 
-        # Extract links if requested
-        if extract_links:
-            result['links'] = self._extract_links(link_type=link_type, domain=domain)
-
-        # Extract code blocks if requested
+        # Extract code blocks if requested, otherwise summarize languages
         if extract_code:
             result['code_blocks'] = self._extract_code_blocks(
                 language=language,
                 include_inline=inline_code
             )
+        elif not extract_links:
+            result['code_languages'] = self._summarize_code_languages()
+
+        # Extract links if requested, otherwise summarize link targets
+        if extract_links:
+            result['links'] = self._extract_links(link_type=link_type, domain=domain)
+        elif not extract_code:
+            result['link_targets'] = self._summarize_link_targets()
+
+        if not extract_links and not extract_code:
+            result = {k: v for k, v in result.items() if v or k == 'headings'}
 
         # Apply semantic slicing to each category
         if head or tail or range:
@@ -68,15 +83,27 @@ class MarkdownAnalyzer(FileAnalyzer):
         return result
 
     def _extract_headings(self) -> List[Dict[str, Any]]:
-        """Extract markdown headings."""
+        """Extract markdown headings.
+
+        Each heading spans its section (up to the next heading of the same
+        or higher level), so --outline shows the hierarchy.
+        """
         headings = []
+        in_fence = False
 
         for i, line in enumerate(self.lines, 1):
+            # Lines starting with # inside code blocks are not headings
+            if line.strip().startswith('```'):
+                in_fence = not in_fence
+                continue
+            if in_fence:
+                continue
+
             # Match heading syntax: # Heading, ## Heading, etc.
             match = re.match(r'^(#{1,6})\s+(.+)$', line)
             if match:
                 level = len(match.group(1))
-                title = match.group(2).strip()
+                title = match.group(2).strip().rstrip('#').strip()
 
                 headings.append({
                     'line': i,
@@ -84,8 +111,56 @@ class MarkdownAnalyzer(FileAnalyzer):
                     'name': title,
                 })
 
+        for index, heading in enumerate(headings):
+            heading['line_end'] = len(self.lines)
+            for following in headings[index + 1:]:
+                if following['level'] <= heading['level']:
+                    heading['line_end'] = following['line'] - 1
+                    break
+
         return headings
 
+    def _summarize_code_languages(self) -> List[Dict[str, Any]]:
+        """Summarize fenced code blocks by language (first occurrence line)."""
+        languages = {}
+
+        for block in self._extract_code_blocks():
+            lang = block['language']
+            if lang not in languages:
+                languages[lang] = {'line': block['line_start'], 'name': lang, 'blocks': 0, 'lines': 0}
+            languages[lang]['blocks'] += 1
+            languages[lang]['lines'] += block['line_count']
+
+        for summary in languages.values():
+            blocks = summary['blocks']
+            lines = summary['lines']
+            summary['signature'] = (f" ({blocks} block{'s' if blocks != 1 else ''}, "
+                                    f"{lines} line{'s' if lines != 1 else ''})")
+
+        return list(languages.values())
+
+    def _summarize_link_targets(self) -> List[Dict[str, Any]]:
+        """Summarize link targets: one entry per URL (first occurrence line)."""
+        targets = {}
+
+        for link in self._extract_links():
+            url = link['url']
+            if url not in targets:
+                targets[url] = {'line': link['line'], 'name': url, 'type': link['type'], 'count': 0}
+                if link.get('broken'):
+                    targets[url]['broken'] = True
+            targets[url]['count'] += 1
+
+        for target in targets.values():
+            details = [target['type']]
+            if target['count'] > 1:
+                details.append(f"{target['count']} links")
+            if target.get('broken'):
+                details.append('BROKEN')
+            target['signature'] = f" ({', '.join(details)})"
+
+        return list(targets.values())
+
     def _extract_links(self, link_type: Optional[str] = None,
                       domain: Optional[str] = None) -> List[Dict[str, Any]]:
         """Extract all links from markdown.
@@ -99,11 +174,8 @@ class MarkdownAnalyzer(FileAnalyzer):
         """
         links = []
 
-        # Match [text](url) pattern
-        link_pattern = r'\[([^\]]+)\]\(([^\)]+)\)'
-
         for i, line in enumerate(self.lines, 1):
-            for match in re.finditer(link_pattern, line):
+            for match in re.finditer(self.LINK_PATTERN, line):
                 text = match.group(1)
                 url = match.group(2)
 
@@ -174,20 +246,31 @@ class MarkdownAnalyzer(FileAnalyzer):
         Returns:
             True if link target doesn't exist
         """
-        # Resolve relative to markdown file's directory
-        base_dir = self.path.parent
-        target = base_dir / url
-
-        # Try both as-is and with common extensions
-        if target.exists():
-            return False
+        candidates = self._link_candidates(url)
+        return bool(candidates) and not any(target.exists() for target in candidates)
 
-        # Try with .md extension if not already present
-        if not target.suffix:
-            if (target.parent / f"{target.name}.md").exists():
-                return False
+    def _link_candidates(self, url: str) -> List[Path]:
+        """Files an internal link may point to: the path relative to this file, and with .md when it has no suffix.
 
-        return True
+        Same-document anchors (#section) have none.
+        """
+        url = url.split('#', 1)[0]
+        if not url:
+            return []
+        target = self.path.parent / url
+        if target.suffix:
+            return [target]
+        return [target, target.parent / f"{target.name}.md"]
+
+    def cache_inputs(self) -> List[Path]:
+        """Targets of the internal links, checked for broken links."""
+        inputs = []
+        for line in self.lines:
+            for match in re.finditer(self.LINK_PATTERN, line):
+                url = match.group(2)
+                if not url.startswith(('mailto:', 'http://', 'https://')):
+                    inputs.extend(self._link_candidates(url))
+        return inputs
 
     def _extract_code_blocks(self, language: Optional[str] = None,
                             include_inline: bool = False) -> List[Dict[str, Any]]:
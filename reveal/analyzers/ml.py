@@ -0,0 +1,419 @@
+"""OCaml analyzer (tree-sitter based) and F# analyzer (regex based).
+
+tree-sitter-languages bundles an OCaml grammar but none for F#, so F#
+keeps its line patterns.
+"""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+from ..treesitter import TreeSitterAnalyzer
+
+
+_IDENT = r"[a-z_][\w']*"
+_MODULE = r"[A-Z][\w']*"
+
+
+@register('.ml', '.mli', name='OCaml', icon='')
+class OCamlAnalyzer(TreeSitterAnalyzer):
+    """OCaml file analyzer.
+
+    Extracts:
+    - open / include statements
+    - Modules and module types (nested modules named Outer.Inner)
+    - Functors (modules with parameters), listed separately
+    - Type definitions (variants, records, aliases; `and` types included)
+    - Module-level let bindings (local `let ... in` is skipped) and
+      `val` declarations in signatures
+
+    .mli files are parsed with the grammar's interface variant.
+    """
+    language = 'ocaml'
+
+    # Nodes whose items are at module level
+    MODULE_BODIES = ('compilation_unit', 'structure', 'signature')
+
+    def __init__(self, path: str):
+        super().__init__(path)
+        if self.path.suffix.lower() == '.mli':
+            self.language = 'ocaml_interface'
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure with nested names qualified and functors split out."""
+        if not self.tree:
+            return {}
+
+        modules = self._extract_modules()
+        structure = {
+            'imports': self._extract_imports(),
+            'modules': [m for m in modules if not m.get('functor')],
+            'functors': [m for m in modules if m.get('functor')],
+            'module_types': self._extract_module_types(),
+            'types': self._extract_types(),
+            'values': self._extract_values(),
+            'functions': self._extract_functions(),
+        }
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract open / include statements."""
+        imports = [{
+            'line': node.start_point[0] + 1,
+            'content': self._flat_text(node),
+        } for node_type in ('open_module', 'include_module') for node in self._find_nodes_by_type(node_type)]
+        return sorted(imports, key=lambda entry: entry['line'])
+
+    def _extract_modules(self) -> List[Dict[str, Any]]:
+        """Extract module bindings; functors carry their parameters."""
+        modules = []
+
+        for binding in self._find_nodes_by_type('module_binding'):
+            name = binding.child_by_field_name('name')
+            if name is None:
+                continue
+            body = binding.child_by_field_name('body')
+            params = [child for child in binding.children if child.type == 'module_parameter']
+            if body is not None and body.type == 'functor':
+                params = [child for child in body.children if child.type == 'module_parameter']
+
+            entry = self._ranged_entry(binding, self._qualified(binding, self._get_node_text(name)))
+            signature = ' '.join(self._flat_text(param) for param in params)
+            if params:
+                entry['functor'] = True
+            module_type = binding.child_by_field_name('module_type')
+            if module_type is not None:
+                signature += f" : {self._flat_text(module_type)}"
+            if body is not None and body.type not in ('structure', 'functor'):
+                # module M = Other (alias / functor application)
+                signature += f" = {self._flat_text(body)}"
+            if signature:
+                entry['signature'] = f" {signature.strip()}"
+            modules.append(entry)
+
+        return modules
+
+    def _extract_module_types(self) -> List[Dict[str, Any]]:
+        """Extract `module type` definitions."""
+        module_types = []
+        for node in self._find_nodes_by_type('module_type_definition'):
+            name = node.child_by_field_name('name')
+            if name is not None:
+                module_types.append(self._ranged_entry(node, self._qualified(node, self._get_node_text(name))))
+        return module_types
+
+    def _extract_types(self) -> List[Dict[str, Any]]:
+        """Extract type bindings, classified as variant, record, alias or abstract."""
+        types = []
+
+        for binding in self._find_nodes_by_type('type_binding'):
+            name = binding.child_by_field_name('name')
+            if name is None:
+                continue
+            params = ' '.join(self._span_text(binding.start_byte, name.start_byte).split())
+            kind, detail = self._type_kind(binding)
+
+            entry = {'line': binding.start_point[0] + 1, 'name': self._get_node_text(name), 'kind': kind}
+            signature = f" {params}" if params else ''
+            if detail:
+                signature += f" = {detail}"
+            entry['signature'] = signature + f"  ({kind})"
+            self._set_module(entry, binding)
+            types.append(entry)
+
+        return types
+
+    def _extract_values(self) -> List[Dict[str, Any]]:
+        """Extract `val` and `external` declarations with their types."""
+        values = []
+
+        for node in sorted(self._find_nodes_by_type('value_specification') + self._find_nodes_by_type('external'),
+                           key=lambda n: n.start_byte):
+            name = node.child_by_field_name('name')
+            if name is None:
+                continue
+            type_text = ' '.join(self._span_text(name.end_byte, node.end_byte).split())
+            entry = {
+                'line': node.start_point[0] + 1,
+                'name': self._get_node_text(name),
+                'signature': f": {type_text.lstrip(':').strip()}",
+            }
+            self._set_module(entry, node)
+            values.append(entry)
+
+        return values
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract module-level let bindings; `let ... in` expressions are skipped."""
+        functions = []
+
+        for definition in self._find_nodes_by_type('value_definition'):
+            if definition.parent is None or definition.parent.type not in self.MODULE_BODIES:
+                continue
+            is_rec = any(child.type == 'rec' for child in definition.children)
+
+            for binding in definition.children:
+                if binding.type != 'let_binding':
+                    continue
+                pattern = binding.child_by_field_name('pattern')
+                if pattern is None:
+                    continue
+                entry = self._ranged_entry(binding, self._get_node_text(pattern))
+                params = ' '.join(self._flat_text(child) for child in binding.children if child.type == 'parameter')
+                if is_rec:
+                    entry['kind'] = 'rec'
+                if params:
+                    entry['signature'] = f" {params}"
+                if 'line_end' in entry:
+                    entry['line_count'] = entry['line_end'] - entry['line'] + 1
+                self._set_module(entry, binding)
+                functions.append(entry)
+
+        return functions
+
+    def _type_kind(self, binding):
+        """Classify a type binding and summarize it."""
+        for child in binding.children:
+            if child.type == 'variant_declaration':
+                constructors = [self._get_node_text(c.children[0]) for c in child.children
+                                if c.type == 'constructor_declaration' and c.children]
+                return 'variant', ' | '.join(constructors)
+            if child.type == 'record_declaration':
+                fields = []
+                for field in child.children:
+                    if field.type == 'field_declaration':
+                        names = [n for n in field.children if n.type == 'field_name']
+                        if names:
+                            fields.append(self._get_node_text(names[0]))
+                return 'record', '{ ' + '; '.join(fields) + ' }'
+
+        text = self._flat_text(binding)
+        if '=' not in text:
+            return 'abstract', ''
+        return 'alias', text.split('=', 1)[1].strip()
+
+    def _ranged_entry(self, node, name: str) -> Dict[str, Any]:
+        """Entry for a node, with line_end when it spans several lines."""
+        entry = {'line': node.start_point[0] + 1, 'name': name}
+        line_end = node.end_point[0] + 1
+        if line_end > entry['line']:
+            entry['line_end'] = line_end
+        return entry
+
+    def _enclosing_modules(self, node) -> List[str]:
+        """Names of the modules (and module types) around a node, outermost first."""
+        names = []
+        parent = node.parent
+        while parent is not None:
+            if parent.type in ('module_binding', 'module_type_definition'):
+                name = parent.child_by_field_name('name')
+                if name is not None:
+                    names.insert(0, self._get_node_text(name))
+            parent = parent.parent
+        return names
+
+    def _qualified(self, node, name: str) -> str:
+        return '.'.join(self._enclosing_modules(node) + [name])
+
+    def _set_module(self, entry: Dict[str, Any], node) -> None:
+        """Record the innermost module an entry is defined in."""
+        modules = self._enclosing_modules(node)
+        if modules:
+            entry['module'] = '.'.join(modules)
+
+    def _span_text(self, start_byte: int, end_byte: int) -> str:
+        """Source text between two byte offsets."""
+        return self.content.encode('utf-8')[start_byte:end_byte].decode('utf-8')
+
+    def _flat_text(self, node) -> str:
+        """A node's text on one line, without (* comments *)."""
+        text = re.sub(r'\(\*.*?\*\)', ' ', self._get_node_text(node), flags=re.DOTALL)
+        return ' '.join(text.split())
+
+
+@register('.fs', '.fsi', '.fsx', name='F#', icon='')
+class FSharpAnalyzer(RegexAnalyzer):
+    """F# file analyzer.
+
+    Same categories as OCaml, with F#'s layout rules: modules and types
+    are indentation-delimited, `namespace` and top-level `module X.Y`
+    declarations name the file's scope, and type members
+    (`member this.Foo`) are listed as methods.
+    """
+
+    block_style = 'indent'
+    comment_prefixes = ('//', '(*')
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>open\s+(?:type\s+)?' + _MODULE + r'[\w.]*)'),
+        'namespaces': re.compile(r'^\s*(?P<content>namespace\s+(?:rec\s+)?[\w.]+|module\s+(?:rec\s+)?[\w.]+\s*$)'),
+        'modules': re.compile(r'^\s*module\s+(?:(?:public|private|internal)\s+)?(?P<name>' + _MODULE + r')\s*='),
+        'types': re.compile(
+            r'^\s*(?:\[<[^>]*>\]\s*)?(?:type|and)\s+(?:(?:public|private|internal)\s+)?(?P<name>[A-Za-z_][\w\']*)'
+            r'(?P<params><[^>]*>)?\s*(?P<rest>(?:\([^)]*\))?.*)$'
+        ),
+        'values': re.compile(r'^\s*val\s+(?:mutable\s+)?(?P<name>' + _IDENT + r')\s*:\s*(?P<signature>.*)$'),
+        'methods': re.compile(
+            r'^\s*(?:(?:static|override|abstract|default)\s+)*member\s+(?:(?:val|private|internal)\s+)?'
+            r'(?:\w+\.)?(?P<name>[A-Za-z_][\w\']*)(?P<signature>[^=]*?)\s*(?:=|$)'
+        ),
+        'functions': re.compile(
+            r'^(?P<indent>\s*)let\s+(?:(?:inline|private|public|internal|mutable)\s+)*(?P<rec>rec\s+)?'
+            r'(?P<name>' + _IDENT + r'|\([^)]*\))(?P<params>[^=]*?)\s*=(?!=)'
+        ),
+    }
+
+    function_categories = ('functions', 'methods')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; qualify nested module names and note each entry's module."""
+        structure = super().get_structure(**kwargs)
+
+        modules = structure.get('modules', [])
+        for module in modules:
+            parent = self._enclosing(module['line'], modules, exclude=module)
+            if parent:
+                module['name'] = f"{parent['name']}.{module['name']}"
+        for category in ('functions', 'values', 'types'):
+            for entry in structure.get(category, []):
+                parent = self._enclosing(entry['line'], modules)
+                if parent:
+                    entry['module'] = parent['name']
+
+        ordered = {
+            'imports': structure.pop('imports', []),
+            'modules': structure.pop('modules', []),
+            **structure,
+        }
+
+        if head or tail or range:
+            for category in ordered:
+                ordered[category] = self._apply_semantic_slice(
+                    ordered[category], head, tail, range
+                )
+
+        return {k: v for k, v in ordered.items() if v}
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """F# types show their constructor/params; members get their owner."""
+        if category == 'namespaces':
+            return {'line': line_no, 'content': match.group('content').strip()}
+
+        if category == 'functions':
+            return self._let_entry(match, line_no)
+
+        entry = super()._make_entry(category, match, line_no)
+
+        if category == 'methods':
+            signature = ' '.join(entry.get('signature', '').split())
+            entry['signature'] = f" {signature}" if signature and not signature.startswith('(') else signature
+            owner = self._owner_type(line_no)
+            if owner:
+                entry['name'] = f"{owner}.{entry['name']}"
+            return entry
+
+        if category == 'types':
+            params = entry.pop('params', '')
+            rest = entry.pop('rest', '').strip()
+            kind, detail = self._type_kind(rest, line_no)
+            entry['kind'] = kind
+            signature = params
+            if detail:
+                signature += f" {detail}" if detail.startswith('(') else f" = {detail}"
+            entry['signature'] = signature + f"  ({kind})"
+            return entry
+
+        if category == 'values':
+            entry.pop('line_end', None)
+            entry['signature'] = f": {entry['signature'].strip()}"
+            return entry
+
+        return entry
+
+    def _let_entry(self, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Module-level let binding; lets inside function bodies are skipped."""
+        if not self._is_module_level(line_no, len(match.group('indent'))):
+            return None
+
+        entry = {'line': line_no, 'name': match.group('name')}
+        params = ' '.join(match.group('params').split())
+        if match.group('rec'):
+            entry['kind'] = 'rec'
+        if params:
+            entry['signature'] = f" {params}"
+
+        line_end = self._find_indent_end(line_no)
+        if line_end > line_no:
+            entry['line_end'] = line_end
+            entry['line_count'] = line_end - line_no + 1
+        return entry
+
+    def _owner_type(self, line_no: int) -> Optional[str]:
+        """Name of the type a member belongs to (nearest less-indented `type` line)."""
+        indent = len(self.lines[line_no - 1]) - len(self.lines[line_no - 1].lstrip())
+        for i in range(line_no - 2, -1, -1):
+            line = self.lines[i]
+            if not line.strip() or len(line) - len(line.lstrip()) >= indent:
+                continue
+            match = re.match(r'^\s*(?:\[<[^>]*>\]\s*)?(?:type|and)\s+(?:(?:public|private|internal)\s+)?(\w+)', line)
+            return match.group(1) if match else None
+        return None
+
+    def _type_kind(self, rest: str, line_no: int):
+        """Classify F# types: class (primary constructor), record, union, interface, alias."""
+        rest = rest.split('//', 1)[0].strip()
+        constructor = re.match(r'^(\([^)]*\))', rest)
+        if constructor:
+            return 'class', constructor.group(1)
+        if not rest.startswith('='):
+            return 'abstract', ''
+
+        body = rest[1:].strip()
+        following = []
+        for line in self.lines[line_no:line_no + 20]:
+            if not line.strip():
+                continue
+            if not line[0].isspace() or re.match(r'^\s*(type|and|let|module)\b', line):
+                break
+            following.append(line.strip())
+        full = ' '.join([body] + following).strip()
+
+        if full.startswith('{'):
+            fields = re.findall(r"(?:\{|;|\s)\s*(?:mutable\s+)?([A-Za-z_][\w']*)\s*:", full.split('}')[0])
+            return 'record', '{ ' + '; '.join(fields) + ' }'
+        if full.startswith('|') or re.match(r'^[A-Z][\w\']*\s+of\b', full):
+            return 'union', ' | '.join(re.findall(r"(?:^|\|)\s*([A-Z][\w']*)", full))
+        if re.match(r'^(interface\b|abstract\s+member\b)', full):
+            return 'interface', ''
+        if body:
+            return 'alias', body
+        return 'class', ''
+
+    def _is_module_level(self, line_no: int, indent: int) -> bool:
+        """A let directly inside a module (or top level), not in a function body."""
+        for i in range(line_no - 2, -1, -1):
+            line = self.lines[i]
+            if not line.strip() or self._is_comment(line):
+                continue
+            line_indent = len(line) - len(line.lstrip())
+            if line_indent < indent:
+                return bool(re.match(r'^\s*(module|namespace)\b', line))
+            if line_indent == indent:
+                continue
+        return True
+
+    @staticmethod
+    def _enclosing(line_no: int, containers: List[Dict[str, Any]], exclude=None) -> Optional[Dict[str, Any]]:
+        """Innermost module whose range contains a line."""
+        enclosing = [c for c in containers if c is not exclude
+                     and c['line'] < line_no <= c.get('line_end', c['line'])]
+        return enclosing[-1] if enclosing else None
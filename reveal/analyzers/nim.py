@@ -0,0 +1,235 @@
+"""Nim file analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_ROUTINE = (r'^\s*(?P<kind>{kinds})\s+(?P<name>\w+|`[^`]+`)(?P<exported>\*)?'
+            r'\s*(?P<generics>\[[^\]]*\])?(?P<params>.*)$')
+_TYPE_DEFINITION = re.compile(
+    r'^\s*(?P<name>\w+|`[^`]+`)(?P<exported>\*)?\s*(?P<generics>\[[^\]]*\])?\s*(?:\{\..*?\.\})?\s*=\s*(?P<definition>.*)$'
+)
+
+
+@register('.nim', '.nims', '.nimble', name='Nim', icon='')
+class NimAnalyzer(RegexAnalyzer):
+    """Nim file analyzer.
+
+    Extracts:
+    - import / include / from ... import statements and exports
+    - Types from `type` sections (object, ref object, enum, tuple,
+      distinct, concept, aliases) with inheritance and field/value names
+    - Procs, funcs and converters; methods; iterators; templates and
+      macros - each with generic parameters, parameters and return type
+    - Exported symbols (marked `*`) are public, the rest private
+
+    Comments (#, ## and #[ ]# blocks) and triple-quoted strings are
+    ignored; definitions end where indentation returns to their level.
+    """
+
+    block_style = 'indent'
+    comment_prefixes = ('#',)
+    function_categories = ('functions', 'methods', 'iterators', 'macros')
+
+    # Field/value names shown inline before eliding
+    MAX_INLINE_FIELDS = 6
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>(?:import|include|from\s+\S+\s+import)\s+.+?)\s*$'),
+        'exports': re.compile(r'^\s*(?P<content>export\s+.+?)\s*$'),
+        'functions': re.compile(_ROUTINE.format(kinds='proc|func|converter')),
+        'methods': re.compile(_ROUTINE.format(kinds='method')),
+        'iterators': re.compile(_ROUTINE.format(kinds='iterator')),
+        'macros': re.compile(_ROUTINE.format(kinds='template|macro')),
+    }
+
+    TYPE_SECTION = re.compile(r'^(?P<indent>\s*)type\b\s*(?P<rest>.*)$')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure from code with comments and long strings blanked.
+
+        Routines nested in another routine's body (local helpers) are left out.
+        """
+        source_lines = self.lines
+        self.lines = self._code_lines()
+        try:
+            structure = super().get_structure(**kwargs)
+        finally:
+            self.lines = source_lines
+
+        routines = [r for category in self.function_categories for r in structure.get(category, [])]
+        for category in self.function_categories:
+            if category in structure:
+                structure[category] = [r for r in structure[category] if not any(
+                    other['line'] < r['line'] <= other.get('line_end', other['line']) for other in routines)]
+
+        order = ('imports', 'exports', 'types')
+        structure = {k: structure[k] for k in sorted(structure, key=lambda c: order.index(c) if c in order else 3)}
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Shape routine entries: signature, kind and visibility."""
+        entry = super()._make_entry(category, match, line_no)
+        if category in ('imports', 'exports'):
+            return entry
+
+        entry['name'] = entry['name'].strip('`')
+        entry['visibility'] = 'public' if entry.pop('exported', None) else 'private'
+        kind = entry.pop('kind')
+        if kind not in ('proc', 'method', 'iterator'):
+            entry['kind'] = kind
+        signature = entry.pop('generics', '') + self._signature(entry.pop('params', ''), line_no)
+        if entry.get('kind'):
+            signature += f"  ({kind})"
+        entry['signature'] = signature
+        return entry
+
+    def _signature(self, params: str, line_no: int) -> str:
+        """'(a: int, b = 2) -> string' from the text after the name."""
+        text = params.strip()
+        args = ''
+        if text.startswith('('):
+            # Parameter list may continue on following lines
+            for line in self.lines[line_no:line_no + 10]:
+                if text.count('(') <= text.count(')'):
+                    break
+                text += ' ' + line.strip()
+            depth = 0
+            for i, char in enumerate(text):
+                if char == '(':
+                    depth += 1
+                elif char == ')':
+                    depth -= 1
+                    if depth == 0:
+                        args, text = ' '.join(text[1:i].split()), text[i + 1:]
+                        break
+
+        signature = f"({args})"
+        returns = re.match(r'\s*:\s*(.+?)\s*(?:\{\..*?\.\}\s*)?(?:=.*)?$', text)
+        if returns:
+            signature += f" -> {returns.group(1)}"
+        return signature
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Types declared in `type` sections (or one-line `type X = Y`)."""
+        types = []
+
+        for i, line in enumerate(self.lines, 1):
+            section = self.TYPE_SECTION.match(line)
+            if not section:
+                continue
+            if section.group('rest'):
+                match = _TYPE_DEFINITION.match(section.group('rest'))
+                if match:
+                    types.append(self._type_entry(match, i))
+                continue
+
+            indent = len(section.group('indent'))
+            member_indent = None
+            for j in range(i, len(self.lines)):
+                body = self.lines[j]
+                if not body.strip():
+                    continue
+                current = len(body) - len(body.lstrip())
+                if current <= indent:
+                    break
+                if member_indent is None:
+                    member_indent = current
+                if current == member_indent:
+                    match = _TYPE_DEFINITION.match(body)
+                    if match:
+                        types.append(self._type_entry(match, j + 1))
+
+        return {'types': types}
+
+    def _type_entry(self, match, line_no: int) -> Dict[str, Any]:
+        """`Name*[T] = ref object of Parent` with its fields or enum values."""
+        definition = match.group('definition').strip()
+        entry = {
+            'line': line_no,
+            'name': match.group('name').strip('`'),
+            'visibility': 'public' if match.group('exported') else 'private',
+        }
+        kind = re.match(r'(?:(?:ref|ptr)\s+)?(?:object|enum|tuple|concept|distinct)\b', definition)
+        entry['kind'] = kind.group() if kind else 'alias'
+        parent = re.search(r'\bobject\s+of\s+([\w.\[\]]+)', definition)
+        if parent:
+            entry['parent'] = parent.group(1)
+
+        line_end = self._find_indent_end(line_no)
+        if line_end > line_no:
+            entry['line_end'] = line_end
+        members = self._members(definition, line_no, line_end)
+        if entry['kind'] == 'enum':
+            definition = 'enum'
+
+        if members:
+            entry['fields' if entry['kind'] != 'enum' else 'values'] = members
+
+        signature = (match.group('generics') or '') + f" = {definition}"
+        if members:
+            shown = members[:self.MAX_INLINE_FIELDS]
+            more = len(members) - len(shown)
+            signature += f" {{ {', '.join(shown)}{f', ... +{more}' if more else ''} }}"
+        entry['signature'] = signature
+        return entry
+
+    def _members(self, definition: str, line_no: int, line_end: int) -> List[str]:
+        """Object field names or enum values declared in the type's body (or inline)."""
+        members = []
+        inline = re.match(r'enum\s+(.+)$', definition)
+        if inline:
+            members.extend(re.findall(r'(\w+)\s*(?:=\s*[^,]+)?', inline.group(1)))
+
+        body = [line for line in self.lines[line_no:line_end] if line.strip()]
+        if not body:
+            return members
+        indent = min(len(line) - len(line.lstrip()) for line in body)
+        for line in body:
+            if len(line) - len(line.lstrip()) != indent:
+                continue
+            stripped = line.strip()
+            if stripped.startswith(('case ', 'of ', 'else', 'when ')):
+                continue
+            for name in re.findall(r'(?:^|,)\s*(\w+)\*?\s*(?=[,:=]|$)', stripped.split(':', 1)[0] + ':'):
+                members.append(name)
+        return members
+
+    def _code_lines(self) -> List[str]:
+        """Lines with #[ ]# block comments and triple-quoted strings blanked."""
+        code = []
+        comment_depth = 0
+        in_string = False
+
+        for line in self.lines:
+            if in_string:
+                code.append('')
+                if '"""' in line:
+                    in_string = False
+                continue
+            if comment_depth:
+                comment_depth += line.count('#[') - line.count(']#')
+                code.append('')
+                continue
+            stripped = line.strip()
+            if stripped.startswith(('#[', '##[')) and line.count('#[') > line.count(']#'):
+                comment_depth = line.count('#[') - line.count(']#')
+                code.append('')
+                continue
+            if line.count('"""') == 1:
+                in_string = True
+                code.append(line.split('"""', 1)[0])
+                continue
+            code.append(line)
+
+        return code
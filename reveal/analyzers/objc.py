@@ -0,0 +1,268 @@
+"""Objective-C file analyzer - tree-sitter based."""
+
+import re
+from pathlib import Path
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.m', '.mm', name='Objective-C', icon='')
+class ObjCAnalyzer(TreeSitterAnalyzer):
+    """Objective-C file analyzer.
+
+    Extracts:
+    - #import / #include / @import
+    - @interface declarations (class extensions and categories included)
+      and @implementation blocks, paired with their counterpart in the
+      same file or the sibling .h/.m file
+    - @protocol declarations
+    - @property declarations (Class.name with attributes and type)
+    - Methods as -[Class selector:] / +[Class selector:]
+
+    Also used for .h headers containing @interface or @protocol.
+    """
+    language = 'objc'
+
+    OBJC_HEADER_PATTERN = re.compile(r'^\s*@(interface|protocol|implementation)\b', re.MULTILINE)
+
+    IMPORT_NODES = ('preproc_include', 'module_import')
+    # Container node -> category
+    CONTAINER_NODES = {
+        'class_interface': 'interfaces',
+        'category_interface': 'interfaces',
+        'class_implementation': 'implementations',
+        'category_implementation': 'implementations',
+        'protocol_declaration': 'protocols',
+    }
+    METHOD_NODES = ('method_declaration', 'method_definition')
+
+    # Headers are matched against a node's text flattened to one line
+    HEADERS = {
+        'interfaces': re.compile(
+            r'^@interface\s+(?P<name>\w+)(?:\s*<[^>]*>)?\s*(?:\(\s*(?P<category>\w*)\s*\))?'
+            r'(?P<signature>\s*:\s*\w+)?(?P<protocols>\s*<[^>]*>)?'
+        ),
+        'implementations': re.compile(r'^@implementation\s+(?P<name>\w+)\s*(?:\(\s*(?P<category>\w*)\s*\))?'),
+        'protocols': re.compile(r'^@protocol\s+(?P<name>\w+)(?P<signature>\s*<[^>]*>)?'),
+    }
+    PROPERTY = re.compile(
+        r'^@property\s*(?:\((?P<attributes>[^)]*)\))?\s*(?P<type>[^;]*?[\s*])(?P<name>\w+)\s*(?:\w+\([^)]*\)\s*)*;'
+    )
+    METHOD = re.compile(r'^(?P<scope>[-+])\s*\((?P<returns>[^)]*)\)\s*(?P<selector>\w+.*)$')
+
+    def cache_inputs(self) -> List[Path]:
+        """The header and implementation files with the same stem, searched for counterparts."""
+        return [self.path.with_suffix(suffix) for suffix in ('.h', '.m', '.mm') if suffix != self.path.suffix.lower()]
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure and pair interfaces with implementations."""
+        if not self.tree:
+            return {}
+
+        structure = {'imports': self._extract_imports(), **self._extract_containers(),
+                     'properties': self._extract_properties(), 'methods': self._extract_methods()}
+        self._pair_containers(structure)
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract #import / #include / @import lines."""
+        imports = []
+        for node_type in self.IMPORT_NODES:
+            for node in self._find_nodes_by_type(node_type):
+                imports.append({
+                    'line': node.start_point[0] + 1,
+                    'content': self._flat_text(node).rstrip(';'),
+                })
+        return sorted(imports, key=lambda entry: entry['line'])
+
+    def _extract_containers(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract @interface / @implementation / @protocol blocks."""
+        structure = {'interfaces': [], 'implementations': [], 'protocols': []}
+
+        for node in self._containers():
+            category = self.CONTAINER_NODES[node.type]
+            match = self.HEADERS[category].match(self._flat_text(node))
+            if not match:
+                continue
+            groups = {k: v for k, v in match.groupdict().items() if v is not None}
+
+            entry = {'line': node.start_point[0] + 1, 'name': groups['name']}
+            if 'category' in groups:
+                category_name = groups['category']
+                entry['name'] += f" ({category_name})"
+                entry['kind'] = 'category' if category_name else 'extension'
+            signature = groups.get('signature', '') + groups.get('protocols', '')
+            if signature.strip():
+                entry['signature'] = f" {' '.join(signature.split())}"
+            line_end = node.end_point[0] + 1
+            if line_end > entry['line']:
+                entry['line_end'] = line_end
+            structure[category].append(entry)
+
+        return structure
+
+    def _extract_properties(self) -> List[Dict[str, Any]]:
+        """Extract @property declarations, named Class.property."""
+        properties = []
+
+        for node in self._find_nodes_by_type('property_declaration'):
+            match = self.PROPERTY.match(self._flat_text(node))
+            if not match:
+                continue
+            owner = self._owner(node)
+            attributes = match.group('attributes')
+            prop_type = ' '.join(match.group('type').split())
+            entry = {
+                'line': node.start_point[0] + 1,
+                'name': f"{owner}.{match.group('name')}" if owner else match.group('name'),
+                'signature': f": {prop_type}" + (f" ({attributes.strip()})" if attributes else ''),
+            }
+            if attributes:
+                entry['attributes'] = [a.strip() for a in attributes.split(',')]
+            properties.append(entry)
+
+        return properties
+
+    def _extract_methods(self) -> List[Dict[str, Any]]:
+        """Extract method declarations and definitions as -[Class selector:]."""
+        methods = []
+
+        for node in sorted((n for t in self.METHOD_NODES for n in self._find_nodes_by_type(t)),
+                           key=lambda n: n.start_byte):
+            declaration = self._flat_text(node).split('{', 1)[0]
+            match = self.METHOD.match(declaration)
+            if not match:
+                continue
+            owner = self._owner(node)
+            selector = self._selector(declaration)
+            scope = match.group('scope')
+            entry = {
+                'line': node.start_point[0] + 1,
+                'name': f"{scope}[{owner} {selector}]" if owner else f"{scope}{selector}",
+                'signature': f" -> {' '.join(match.group('returns').split())}",
+                'selector': selector,
+                'kind': 'class method' if scope == '+' else 'instance method',
+            }
+            if node.type == 'method_declaration':
+                entry['declaration'] = True
+            else:
+                line_end = node.end_point[0] + 1
+                if line_end > entry['line']:
+                    entry['line_end'] = line_end
+                    entry['line_count'] = line_end - entry['line'] + 1
+            methods.append(entry)
+
+        return methods
+
+    def _containers(self) -> List:
+        """Container nodes in file order."""
+        nodes = [node for node_type in self.CONTAINER_NODES for node in self._find_nodes_by_type(node_type)]
+        return sorted(nodes, key=lambda node: node.start_byte)
+
+    def _owner(self, node) -> Optional[str]:
+        """Class (or protocol) name of the container around a node."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type in self.CONTAINER_NODES:
+                match = re.match(r'@\w+\s+(\w+)', self._flat_text(parent))
+                return match.group(1) if match else None
+            parent = parent.parent
+        return None
+
+    def _flat_text(self, node) -> str:
+        """A node's text on one line, without // comments."""
+        lines = [line.split('//', 1)[0] for line in self._get_node_text(node).split('\n')]
+        return ' '.join(' '.join(lines).split())
+
+    def _pair_containers(self, structure: Dict[str, List[Dict[str, Any]]]) -> None:
+        """Link each @interface to its @implementation (same file or sibling .h/.m)."""
+        interfaces = structure.get('interfaces', [])
+        implementations = structure.get('implementations', [])
+
+        def implemented_name(interface):
+            # A class extension `Foo ()` is implemented by `@implementation Foo`
+            return interface['name'].split(' (')[0] if interface.get('kind') == 'extension' else interface['name']
+
+        for interface in interfaces:
+            match = next((i for i in implementations if i['name'] == implemented_name(interface)), None)
+            if match:
+                interface['implementation'] = f"line {match['line']}"
+            elif self.path.suffix.lower() == '.h':
+                found = self._find_in_sibling(('.m', '.mm'), '@implementation', interface['name'])
+                if found:
+                    interface['implementation'] = found
+
+        for implementation in implementations:
+            match = next((i for i in interfaces if i['name'] == implementation['name']), None)
+            if match:
+                implementation['interface'] = f"line {match['line']}"
+            found = self._find_in_sibling(('.h',), '@interface', implementation['name'])
+            if found:
+                implementation['interface'] = found
+
+        for entry in interfaces + implementations:
+            counterpart = entry.get('implementation') or entry.get('interface')
+            if counterpart:
+                label = 'implementation' if 'implementation' in entry else 'interface'
+                entry['signature'] = entry.get('signature', '') + f"  ({label}: {counterpart})"
+
+    def _find_in_sibling(self, suffixes, keyword: str, name: str) -> Optional[str]:
+        """Find `keyword Name` in a sibling file with the same stem: 'Foo.h:12'."""
+        base = name.split(' (')[0]
+        category = name[len(base):].strip()
+        pattern = re.compile(rf'^\s*{keyword}\s+{re.escape(base)}\b\s*(\(\s*\w*\s*\))?')
+
+        for suffix in suffixes:
+            sibling = self.path.with_suffix(suffix)
+            if not sibling.exists():
+                continue
+            try:
+                lines = sibling.read_text(encoding='utf-8', errors='replace').splitlines()
+            except OSError:
+                continue
+            for i, line in enumerate(lines, 1):
+                match = pattern.match(line)
+                if not match:
+                    continue
+                found_category = re.sub(r'\s+', '', match.group(1) or '')
+                # A class extension `()` pairs with the main @implementation
+                if category == found_category or (keyword == '@interface' and not category):
+                    return f"{sibling.name}:{i}"
+        return None
+
+    @staticmethod
+    def _selector(declaration: str) -> str:
+        """Selector from a method header: '- (void)foo:(int)a bar:(id)b' -> 'foo:bar:'."""
+        header = re.sub(r'^\s*[-+]\s*\([^)]*\)\s*', '', declaration)
+        header = re.sub(r'\([^)]*\)', ' ', header)
+        keywords = re.findall(r'(\w+)\s*:', header)
+        if keywords:
+            return ''.join(f"{k}:" for k in keywords)
+        simple = re.match(r'\s*(\w+)', header)
+        return simple.group(1) if simple else header.strip()
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract by full name (-[Class sel:]) or by bare selector, preferring definitions."""
+        methods = self.get_structure().get('methods', [])
+        candidates = sorted((m for m in methods if m['selector'] == name),
+                            key=lambda m: m.get('declaration', False))
+        if candidates:
+            method = candidates[0]
+            line_end = method.get('line_end', method['line'])
+            return {
+                'name': method['name'],
+                'line_start': method['line'],
+                'line_end': line_end,
+                'source': '\n'.join(self.lines[method['line'] - 1:line_end]),
+            }
+
+        return super().extract_element(element_type, name)
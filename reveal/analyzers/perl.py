@@ -0,0 +1,204 @@
+"""Perl file analyzer - tree-sitter based."""
+
+import re
+from typing import Dict, List, Any, Iterator, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.pl', '.pm', '.t', '.cgi', name='Perl', icon='')
+class PerlAnalyzer(TreeSitterAnalyzer):
+    """Perl file analyzer.
+
+    Extracts:
+    - Packages (statement and block form) with their parent classes
+      (use parent/base, @ISA, Moose extends)
+    - use / require statements (lowercase pragmas listed separately)
+    - Subs, with the argument list unpacked from @_ when there is no
+      signature; leading underscore means private
+    - Moose/Moo attributes (has ...)
+
+    The parser keeps POD and everything after __END__/__DATA__ out of
+    the code. Statements are recognized by their text, so node types of
+    both the original and the rewritten tree-sitter-perl grammar work.
+    """
+    language = 'perl'
+
+    SUB_NODES = ('function_definition', 'subroutine_declaration_statement')
+
+    PRAGMA = re.compile(r'^(?P<content>(?:use|no)\s+(?:[a-z]\w*|v?\d[\d.]*)\b[^;]*)')
+    IMPORT = re.compile(r'^(?P<content>(?:use|require)\s+[A-Z][\w:]*[^;]*)')
+    PACKAGE = re.compile(r'^package\s+(?P<name>[\w:]+)')
+    SUB = re.compile(r'^sub\s+(?P<name>[\w:]+)\s*(?P<signature>\([^)]*\))?')
+    ATTRIBUTE = re.compile(r'''^has\s+\+?['"]?(?P<name>\w+)['"]?\s*=>\s*\((?P<options>.*)''')
+    PARENTS = re.compile(r'^(?:use\s+(?:parent|base)\b|(?:our\s+)?@ISA\s*=|extends\b)(.*)')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; assign subs to packages and find parent classes."""
+        if not self.tree:
+            return {}
+
+        packages = self._extract_packages()
+        pragmas, imports = self._extract_uses()
+        structure = {
+            'packages': packages,
+            'pragmas': pragmas,
+            'imports': imports,
+            'subs': self._extract_subs(),
+            'attributes': self._extract_attributes(),
+        }
+
+        for category in ('subs', 'attributes'):
+            for entry in structure[category]:
+                owner = [p for p in packages if p['line'] <= entry['line'] <= p['line_end']]
+                if owner:
+                    entry['package'] = owner[-1]['name']
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_packages(self) -> List[Dict[str, Any]]:
+        """Extract packages; statement-form ones run until the next package."""
+        statements = list(self._statements())
+        packages = []
+
+        for index, node in enumerate(statements):
+            match = self.PACKAGE.match(self._flat_text(node))
+            if not match:
+                continue
+            entry = {'line': node.start_point[0] + 1, 'name': match.group('name'), 'line_end': node.end_point[0] + 1}
+            if not any(child.type == 'block' for child in node.children):
+                for following in statements[index + 1:]:
+                    if self.PACKAGE.match(self._flat_text(following)):
+                        break
+                    entry['line_end'] = following.end_point[0] + 1
+            packages.append(entry)
+
+        for package in packages:
+            parents = self._parents(package)
+            if parents:
+                package['parents'] = parents
+                package['signature'] = f" (isa {', '.join(parents)})"
+
+        return packages
+
+    def _extract_uses(self):
+        """Extract use / no / require statements, split into pragmas and module imports."""
+        pragmas = []
+        imports = []
+
+        for node in self._statements():
+            text = self._flat_text(node)
+            for pattern, found in ((self.PRAGMA, pragmas), (self.IMPORT, imports)):
+                match = pattern.match(text)
+                if match:
+                    found.append({'line': node.start_point[0] + 1, 'content': match.group('content').rstrip()})
+                    break
+
+        return pragmas, imports
+
+    def _extract_subs(self) -> List[Dict[str, Any]]:
+        """Extract named subs with their signature or unpacked arguments."""
+        subs = []
+
+        for node in sorted((n for t in self.SUB_NODES for n in self._find_nodes_by_type(t)),
+                           key=lambda n: n.start_byte):
+            match = self.SUB.match(self._flat_text(node))
+            if not match:
+                continue
+            entry = {'line': node.start_point[0] + 1, 'name': match.group('name')}
+            signature = match.group('signature') or self._unpacked_args(node)
+            if signature:
+                entry['signature'] = signature
+            line_end = node.end_point[0] + 1
+            if line_end > entry['line']:
+                entry['line_end'] = line_end
+                entry['line_count'] = line_end - entry['line'] + 1
+            entry['visibility'] = 'private' if entry['name'].split('::')[-1].startswith('_') else 'public'
+            subs.append(entry)
+
+        return subs
+
+    def _extract_attributes(self) -> List[Dict[str, Any]]:
+        """Extract Moose/Moo `has` attributes with is/isa options."""
+        attributes = []
+
+        for node in self._statements():
+            match = self.ATTRIBUTE.match(self._flat_text(node))
+            if not match:
+                continue
+            entry = {'line': node.start_point[0] + 1, 'name': match.group('name')}
+            details = re.findall(r'''\b(is|isa)\s*=>\s*['"]?([\w:\[\]]+)''', match.group('options'))
+            if details:
+                entry['signature'] = f" ({', '.join(f'{k} => {v}' for k, v in details)})"
+            attributes.append(entry)
+
+        return attributes
+
+    def _statements(self) -> Iterator:
+        """File-level statements in order, including those inside package blocks.
+
+        POD, comments and the __END__/__DATA__ section are skipped.
+        """
+        def walk(node):
+            for child in node.children:
+                if not child.is_named or self._is_skipped(child):
+                    continue
+                yield child
+                if self.PACKAGE.match(self._flat_text(child)):
+                    for block in child.children:
+                        if block.type == 'block':
+                            yield from walk(block)
+
+        yield from walk(self.tree.root_node)
+
+    def _is_skipped(self, node) -> bool:
+        text = self._get_node_text(node).lstrip()
+        return node.type in ('comment', 'comments') or text.startswith(('=', '#', '__END__', '__DATA__'))
+
+    def _unpacked_args(self, sub) -> Optional[str]:
+        """Argument list from `my ($self, %args) = @_;` or `my $self = shift;`."""
+        names = []
+        body = sub.child_by_field_name('body')
+        if body is None:
+            return None
+
+        for statement in body.children:
+            if not statement.is_named or statement.type in ('comment', 'comments'):
+                continue
+            text = self._flat_text(statement)
+            unpack = re.match(r'^my\s*\(([^)]*)\)\s*=\s*@_\s*;?', text)
+            if unpack:
+                return f"({', '.join(n.strip() for n in unpack.group(1).split(','))})"
+            shift = re.match(r'^my\s+([$@%]\w+)\s*=\s*shift\b', text)
+            if not shift:
+                break
+            names.append(shift.group(1))
+
+        return f"({', '.join(names)})" if names else None
+
+    def _parents(self, package: Dict[str, Any]) -> List[str]:
+        """Parent classes declared inside a package."""
+        parents = []
+
+        for node in self._statements():
+            if not package['line'] <= node.start_point[0] + 1 <= package['line_end']:
+                continue
+            declared = self.PARENTS.match(self._flat_text(node))
+            if declared:
+                args = re.sub(r'-norequire\s*,?', '', declared.group(1))
+                words = re.findall(r'qw\s*[(\[{/]([^)\]}/]*)', args)
+                names = ' '.join(words).split() if words else re.findall(r'''['"]([\w:]+)['"]''', args)
+                parents.extend(names)
+
+        return parents
+
+    def _flat_text(self, node) -> str:
+        """A node's text on one line."""
+        return ' '.join(self._get_node_text(node).split())
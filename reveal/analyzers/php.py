@@ -0,0 +1,151 @@
+"""PHP file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.php', '.phtml', name='PHP', icon='')
+class PHPAnalyzer(TreeSitterAnalyzer):
+    """PHP file analyzer.
+
+    Extracts:
+    - Namespace declarations and `use` imports
+    - Classes, interfaces, traits and enums
+    - Functions and class methods (Class::method) with visibility
+    - Traits used by classes
+
+    Works for Laravel, Symfony and WordPress codebases.
+    """
+    language = 'php'
+
+    TYPE_NODES = ('class_declaration', 'interface_declaration',
+                  'trait_declaration', 'enum_declaration')
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract top-level `use` imports (not trait uses inside classes)."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node).rstrip(';'),
+        } for node in self._find_nodes_by_type('namespace_use_declaration')]
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract standalone functions."""
+        functions = []
+
+        for node in self._find_nodes_by_type('function_definition'):
+            name = self._get_node_name(node)
+            if name:
+                functions.append(self._function_entry(node, name))
+
+        return functions
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract classes."""
+        return self._extract_types('class_declaration')
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract namespaces, interfaces, traits, enums, methods and trait uses."""
+        return {
+            'namespaces': [{
+                'line': node.start_point[0] + 1,
+                'content': self._get_node_text(node).split('{')[0].strip().rstrip(';'),
+            } for node in self._find_nodes_by_type('namespace_definition')],
+            'interfaces': self._extract_types('interface_declaration'),
+            'traits': self._extract_types('trait_declaration'),
+            'enums': self._extract_types('enum_declaration'),
+            'methods': self._extract_methods(),
+            'trait_uses': self._extract_trait_uses(),
+        }
+
+    def _extract_types(self, node_type: str) -> List[Dict[str, Any]]:
+        """Extract type declarations with modifiers (abstract, final)."""
+        types = []
+
+        for node in self._find_nodes_by_type(node_type):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            entry = {
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': name,
+            }
+            modifiers = [self._get_node_text(child) for child in node.children
+                         if child.type in ('abstract_modifier', 'final_modifier', 'readonly_modifier')]
+            if modifiers:
+                entry['modifiers'] = modifiers
+            types.append(entry)
+
+        return types
+
+    def _extract_methods(self) -> List[Dict[str, Any]]:
+        """Extract methods named Class::method with visibility."""
+        methods = []
+
+        for node in self._find_nodes_by_type('method_declaration'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            owner = self._enclosing_type_name(node)
+            entry = self._function_entry(node, f"{owner}::{name}" if owner else name)
+
+            modifiers = []
+            entry['visibility'] = 'public'
+            for child in node.children:
+                if child.type == 'visibility_modifier':
+                    entry['visibility'] = self._get_node_text(child)
+                elif child.type in ('static_modifier', 'abstract_modifier', 'final_modifier'):
+                    modifiers.append(self._get_node_text(child))
+            if modifiers:
+                entry['modifiers'] = modifiers
+
+            methods.append(entry)
+
+        return methods
+
+    def _extract_trait_uses(self) -> List[Dict[str, Any]]:
+        """Extract `use SomeTrait;` statements inside class bodies."""
+        uses = []
+
+        for node in self._find_nodes_by_type('use_declaration'):
+            owner = self._enclosing_type_name(node)
+            for child in node.children:
+                if child.type in ('name', 'qualified_name'):
+                    uses.append({
+                        'line': node.start_point[0] + 1,
+                        'name': f"{owner} uses {self._get_node_text(child)}" if owner
+                                else self._get_node_text(child),
+                    })
+
+        return uses
+
+    def _enclosing_type_name(self, node) -> Optional[str]:
+        """Name of the class, interface, trait or enum enclosing a node."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type in self.TYPE_NODES:
+                return self._get_node_name(parent)
+            parent = parent.parent
+        return None
+
+    def _get_signature(self, node) -> str:
+        """Get function signature: parameters and optional return type."""
+        params = node.child_by_field_name('parameters')
+        signature = self._get_node_text(params) if params is not None else '()'
+
+        return_type = node.child_by_field_name('return_type')
+        if return_type is not None:
+            signature += f" -> {self._get_node_text(return_type).lstrip(':').strip()}"
+
+        return signature
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Make interfaces, traits and enums extractable by name."""
+        type_map = super()._get_element_type_map()
+        type_map['interface'] = ['interface_declaration']
+        type_map['trait'] = ['trait_declaration']
+        type_map['enum'] = ['enum_declaration']
+        return type_map
@@ -0,0 +1,250 @@
+"""PowerShell file analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+@register('.ps1', '.psm1', name='PowerShell', icon='')
+class PowerShellAnalyzer(RegexAnalyzer):
+    """PowerShell file analyzer.
+
+    Extracts:
+    - Import-Module, `using module/namespace`, #Requires and dot-sourced scripts
+    - The script's own param() block
+    - Functions and filters with their parameters (from `function f($a)` or
+      the param() block), [CmdletBinding()] and [OutputType()]
+    - Classes (PowerShell 5+) and enums
+    """
+
+    comment_prefixes = ('#',)
+
+    patterns = {
+        'imports': re.compile(
+            r'^\s*(?P<content>(?:Import-Module|ipmo)\s+.+?|using\s+(?:module|namespace|assembly)\s+.+?'
+            r'|#Requires\s+-Modules?\s+.+?|\.\s+["\']?[$\w.\\/:-]+\.ps1["\']?)\s*$',
+            re.IGNORECASE
+        ),
+        'classes': re.compile(r'^\s*class\s+(?P<name>\w+)(?:\s*:\s*(?P<base>[\w.,\s]+?))?\s*\{?\s*$', re.IGNORECASE),
+        'enums': re.compile(r'^\s*enum\s+(?P<name>\w+)', re.IGNORECASE),
+        'functions': re.compile(
+            r'^\s*(?P<kind>function|filter|workflow)\s+(?:(?:global|script|private):)?(?P<name>[\w-]+)'
+            r'\s*(?P<inline>\([^)]*\))?',
+            re.IGNORECASE
+        ),
+    }
+
+    PARAMETER = re.compile(r'(?P<attributes>(?:\[[^\]]*(?:\([^)]*\))?[^\]]*\]\s*)*)\$(?P<name>\w+)(?:\s*=\s*(?P<default>[^,]+))?')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure, plus the script-level param() block."""
+        source_lines = self.lines
+        self.lines = self._code_lines()
+        try:
+            structure = super().get_structure(**kwargs)
+            script_params = self._script_parameters()
+        finally:
+            self.lines = source_lines
+
+        classes = structure.get('classes', [])
+        functions = []
+        for function in structure.get('functions', []):
+            if any(c['line'] < function['line'] <= c.get('line_end', c['line']) for c in classes):
+                continue
+            functions.append(function)
+        structure['functions'] = functions
+
+        ordered = {'imports': structure.get('imports', []), 'parameters': script_params}
+        ordered.update({k: v for k, v in structure.items() if k != 'imports'})
+
+        if head or tail or range:
+            for category in ordered:
+                ordered[category] = self._apply_semantic_slice(
+                    ordered[category], head, tail, range
+                )
+
+        return {k: v for k, v in ordered.items() if v}
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Shape function and class entries."""
+        entry = super()._make_entry(category, match, line_no)
+
+        if category == 'functions':
+            kind = entry.pop('kind').lower()
+            if kind != 'function':
+                entry['kind'] = kind
+            inline = entry.pop('inline', None)
+            if inline:
+                params_text = inline[1:-1]
+            else:
+                params_text = self._param_block(line_no + 1, entry.get('line_end', line_no))[0]
+            parameters = self._parameters(params_text or '')
+            entry['parameters'] = [p['name'] for p in parameters]
+            mandatory = [p['name'] for p in parameters if p.get('mandatory')]
+            if mandatory:
+                entry['mandatory'] = mandatory
+
+            attributes = self._function_attributes(line_no, entry.get('line_end', line_no))
+            entry.update(attributes)
+            signature = f"({', '.join(self._format_parameter(p) for p in parameters)})"
+            if attributes.get('output_type'):
+                signature += f" -> {attributes['output_type']}"
+            if attributes.get('cmdlet_binding'):
+                signature += '  [CmdletBinding]'
+            entry['signature'] = signature
+            entry['visibility'] = 'public' if '-' in entry['name'] else 'private'
+
+        elif category == 'classes':
+            base = entry.pop('base', None)
+            if base:
+                entry['bases'] = [b.strip() for b in base.split(',')]
+                entry['signature'] = f" : {', '.join(entry['bases'])}"
+
+        return entry
+
+    def _is_comment(self, line: str) -> bool:
+        """#Requires lines are directives, not comments."""
+        return super()._is_comment(line) and not re.match(r'^\s*#Requires\b', line, re.IGNORECASE)
+
+    def _script_parameters(self) -> List[Dict[str, Any]]:
+        """Parameters of a param() block at the top of the script."""
+        for i, line in enumerate(self.lines, 1):
+            stripped = line.strip()
+            if not stripped or stripped.startswith(('[CmdletBinding', '#', '[OutputType', 'using ')):
+                continue
+            if re.match(r'^param\s*\(', stripped, re.IGNORECASE):
+                text, first, _ = self._param_block(i, len(self.lines))
+                entries = []
+                for parameter in self._parameters(text):
+                    entry = {'line': first + parameter['offset'], 'name': f"${parameter['name']}"}
+                    signature = f": {parameter['type']}" if parameter.get('type') else ''
+                    if parameter.get('default'):
+                        signature += f" = {parameter['default']}"
+                    if parameter.get('mandatory'):
+                        entry['mandatory'] = True
+                        signature += '  (mandatory)'
+                    if signature:
+                        entry['signature'] = signature
+                    entries.append(entry)
+                return entries
+            break
+        return []
+
+    def _param_block(self, start: int, end: int) -> Tuple[str, int, int]:
+        """Text inside the first param( ... ) between start and end (1-indexed lines)."""
+        for i in range(start - 1, min(end, len(self.lines))):
+            match = re.search(r'\bparam\s*\(', self.lines[i], re.IGNORECASE)
+            if not match:
+                continue
+            text = self.lines[i][match.end():]
+            depth = 1
+            collected = []
+            for j in range(i, len(self.lines)):
+                chunk = text if j == i else self.lines[j]
+                for k, char in enumerate(self._strip_strings(chunk)):
+                    if char == '(':
+                        depth += 1
+                    elif char == ')':
+                        depth -= 1
+                        if depth == 0:
+                            collected.append(chunk[:k])
+                            return '\n'.join(collected), i + 1, j + 1
+                collected.append(chunk)
+            break
+        return '', start, start
+
+    def _parameters(self, text: str) -> List[Dict[str, Any]]:
+        """Parse '[Parameter(Mandatory)][string]$Name = "x", [int]$Count' into parameters."""
+        parameters = []
+
+        for part, offset in self._split_parameters(text):
+            match = self.PARAMETER.search(part)
+            if not match:
+                continue
+            attributes = re.findall(r'\[([^\]]*(?:\([^)]*\))?[^\]]*)\]', match.group('attributes'))
+            parameter = {'name': match.group('name'), 'offset': offset + part[:match.start('name')].count('\n')}
+            types = [a for a in attributes if not re.match(r'^(Parameter|Validate|Alias|Allow)', a, re.IGNORECASE)]
+            if types:
+                parameter['type'] = types[-1]
+            if any(re.match(r'^Parameter\s*\(.*\bMandatory\b(?!\s*=\s*\$false)', a, re.IGNORECASE)
+                   for a in attributes):
+                parameter['mandatory'] = True
+            if match.group('default'):
+                parameter['default'] = match.group('default').strip()
+            parameters.append(parameter)
+
+        return parameters
+
+    def _split_parameters(self, text: str) -> List[Tuple[str, int]]:
+        """Split a parameter list on top-level commas; keep each part's starting line offset."""
+        parts = []
+        depth = 0
+        current = ''
+        offset = 0
+
+        for char in text:
+            if char in '([{':
+                depth += 1
+            elif char in ')]}':
+                depth -= 1
+            if char == ',' and depth == 0:
+                parts.append((current, offset))
+                offset += current.count('\n')
+                current = ''
+                continue
+            current += char
+
+        if current.strip():
+            parts.append((current, offset))
+        return parts
+
+    @staticmethod
+    def _format_parameter(parameter: Dict[str, Any]) -> str:
+        """'[string]$Name = "x"' style, with * for mandatory parameters."""
+        text = f"${parameter['name']}"
+        if parameter.get('type'):
+            text = f"[{parameter['type']}]{text}"
+        if parameter.get('default'):
+            text += f" = {parameter['default']}"
+        if parameter.get('mandatory'):
+            text += '*'
+        return text
+
+    def _function_attributes(self, start: int, end: int) -> Dict[str, Any]:
+        """[CmdletBinding()] and [OutputType()] at the top of a function body."""
+        attributes = {}
+        for line in self.lines[start - 1:min(end, start + 5)]:
+            if re.search(r'\[CmdletBinding\b', line, re.IGNORECASE):
+                attributes['cmdlet_binding'] = True
+            output = re.search(r'\[OutputType\(\s*\[?([\w.\[\]]+?)\]?\s*\)\]', line, re.IGNORECASE)
+            if output:
+                attributes['output_type'] = output.group(1)
+        return attributes
+
+    def _code_lines(self) -> List[str]:
+        """Lines with <# ... #> block comments blanked."""
+        code = []
+        in_comment = False
+
+        for line in self.lines:
+            if in_comment:
+                code.append('')
+                in_comment = '#>' not in line
+            elif line.lstrip().startswith('<#') and '#>' not in line:
+                code.append('')
+                in_comment = True
+            elif line.lstrip().startswith('<#'):
+                code.append('')
+            else:
+                code.append(line)
+
+        return code
+
+    @staticmethod
+    def _strip_strings(line: str) -> str:
+        """Remove strings and # comments so braces inside them don't count."""
+        line = re.sub(r'"(?:`.|[^"`])*"', '""', line)
+        line = re.sub(r"'(?:''|[^'])*'", "''", line)
+        return re.sub(r'(?<![<`])#.*', '', line)
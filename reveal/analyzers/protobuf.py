@@ -0,0 +1,125 @@
+"""Protocol Buffers (.proto) analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_FIELD = re.compile(
+    r'^\s*(?:(?P<label>optional|required|repeated)\s+)?'
+    r'(?P<type>map\s*<[^>]+>|\.?[\w.]+)\s+(?P<name>\w+)\s*=\s*(?P<number>\d+)'
+)
+_ENUM_VALUE = re.compile(r'^\s*(?P<name>\w+)\s*=\s*(?P<number>-?\w+)')
+
+
+@register('.proto', name='Protocol Buffers', icon='')
+class ProtobufAnalyzer(RegexAnalyzer):
+    """Protocol Buffers analyzer for gRPC API review.
+
+    Extracts:
+    - syntax/package, imports and options
+    - Messages with their fields and field numbers (nested messages are
+      named Outer.Inner; oneof fields belong to their message)
+    - Enums with values
+    - Services and RPC methods with request/response types and streaming
+    """
+
+    # Fields shown inline before eliding
+    MAX_INLINE_FIELDS = 8
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>import\s+(?:public\s+|weak\s+)?"[^"]+")'),
+        'package': re.compile(r'^\s*(?P<content>(?:package|syntax|edition)\b[^;]*)'),
+        'messages': re.compile(r'^\s*message\s+(?P<name>\w+)'),
+        'enums': re.compile(r'^\s*enum\s+(?P<name>\w+)'),
+        'services': re.compile(r'^\s*service\s+(?P<name>\w+)'),
+        'methods': re.compile(
+            r'^\s*rpc\s+(?P<name>\w+)\s*\(\s*(?P<request>(?:stream\s+)?[\w.]+)\s*\)'
+            r'\s*returns\s*\(\s*(?P<response>(?:stream\s+)?[\w.]+)\s*\)'
+        ),
+    }
+
+    BLOCK_OPENER = re.compile(r'^\s*(message|enum|service|oneof)\s+(\w+)')
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Qualify nested names and attach fields, values and RPC types."""
+        entry = super()._make_entry(category, match, line_no)
+        if 'name' not in entry:
+            return entry
+
+        parents = self._enclosing_blocks(line_no)
+
+        if category == 'methods':
+            request = entry.pop('request')
+            response = entry.pop('response')
+            entry['signature'] = f"({request}) -> {response}"
+            if parents:
+                entry['name'] = f"{parents[-1][1]}.{entry['name']}"
+            return entry
+
+        names = [name for kind, name in parents if kind in ('message', 'enum')]
+        entry['name'] = '.'.join(names + [entry['name']])
+
+        if category == 'messages':
+            fields = self._body_items(line_no, entry.get('line_end', line_no), _FIELD)
+            entry['fields'] = [{
+                'name': f['name'], 'number': int(f['number']), 'type': ' '.join(f['type'].split()),
+                **({'label': f['label']} if f.get('label') else {}),
+            } for f in fields]
+            entry['signature'] = self._inline(f"{f['name']}={f['number']}" for f in entry['fields'])
+        elif category == 'enums':
+            values = self._body_items(line_no, entry.get('line_end', line_no), _ENUM_VALUE)
+            entry['values'] = [{'name': v['name'], 'number': v['number']} for v in values
+                               if v['name'] not in ('option', 'reserved')]
+            entry['signature'] = self._inline(f"{v['name']}={v['number']}" for v in entry['values'])
+
+        return entry
+
+    def _inline(self, items) -> str:
+        """Format ' { a=1, b=2, ... }' for display."""
+        items = list(items)
+        if not items:
+            return ' {}'
+        shown = ', '.join(items[:self.MAX_INLINE_FIELDS])
+        if len(items) > self.MAX_INLINE_FIELDS:
+            shown += f", ... +{len(items) - self.MAX_INLINE_FIELDS}"
+        return f" {{ {shown} }}"
+
+    def _body_items(self, start: int, end: int, pattern) -> List[Dict[str, str]]:
+        """Match pattern against lines directly in a block (and its oneofs)."""
+        items = []
+        depth = 0
+        oneof_depths = set()
+
+        for i in range(start - 1, end):
+            line = self._strip_strings(self.lines[i])
+            direct = depth == 1 or depth in oneof_depths
+            if direct and not self.BLOCK_OPENER.match(line):
+                match = pattern.match(line)
+                if match:
+                    items.append({k: v for k, v in match.groupdict().items() if v is not None})
+
+            opener = self.BLOCK_OPENER.match(line)
+            if opener and opener.group(1) == 'oneof' and direct:
+                oneof_depths.add(depth + 1)
+            depth += line.count('{') - line.count('}')
+            oneof_depths = {d for d in oneof_depths if d <= depth}
+
+        return items
+
+    def _enclosing_blocks(self, line_no: int) -> List[tuple]:
+        """(kind, name) of the message/enum/service blocks enclosing a line, outermost first."""
+        blocks = []
+        depth = 0
+
+        for i in range(line_no - 2, -1, -1):
+            line = self._strip_strings(self.lines[i])
+            depth += line.count('}') - line.count('{')
+            if depth < 0:
+                opener = self.BLOCK_OPENER.match(line)
+                if opener:
+                    blocks.insert(0, (opener.group(1), opener.group(2)))
+                depth = 0
+
+        return blocks
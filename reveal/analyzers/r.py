@@ -0,0 +1,251 @@
+"""R file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.r', '.Rprofile', name='R', icon='')
+class RAnalyzer(TreeSitterAnalyzer):
+    """R file analyzer.
+
+    Extracts:
+    - library() / require() / requireNamespace() calls and source() files
+    - Function assignments (name <- function(...), name = function(...))
+    - S4 classes (setClass, with slots and contains), generics and methods
+    - R6 classes (R6Class, with inherit) and Reference classes (setRefClass);
+      their methods are listed as Class$method, public or private
+
+    Assignments and call arguments are read through both the original
+    and the rewritten tree-sitter-r node types.
+    """
+    language = 'r'
+
+    IMPORT_CALLS = ('library', 'require', 'requireNamespace', 'source')
+    CLASS_KINDS = {'R6Class': 'R6', 'setRefClass': 'RC', 'setClass': 'S4'}
+    # List arguments of R6Class / setRefClass holding member functions
+    MEMBER_LISTS = {'public': 'public', 'private': 'private', 'active': 'active', 'methods': 'public'}
+
+    ASSIGNMENT_NODES = ('left_assignment', 'super_assignment', 'equals_assignment')
+    ASSIGNMENT_OPERATORS = ('<-', '<<-', '=')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure, with R6/RC member functions listed as methods."""
+        if not self.tree:
+            return {}
+
+        classes, members = self._extract_classes()
+        structure = {
+            'imports': self._extract_imports(),
+            'classes': classes,
+            'generics': self._extract_generics(),
+            'methods': sorted(self._extract_s4_methods() + members, key=lambda m: m['line']),
+            'functions': self._extract_functions(),
+        }
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract library / require / requireNamespace / source calls."""
+        imports = []
+
+        for call, function in self._calls(self.IMPORT_CALLS):
+            arguments = self._arguments(call)
+            if not arguments:
+                continue
+            name = self._unquote(arguments[0][1])
+            imports.append({
+                'line': call.start_point[0] + 1,
+                'content': name if function == 'library' else f"{function}({name})",
+            })
+
+        return imports
+
+    def _extract_classes(self) -> Tuple[List[Dict[str, Any]], List[Dict[str, Any]]]:
+        """Extract S4, R6 and Reference classes, and the member functions of R6/RC ones."""
+        classes = []
+        members = []
+
+        for call, function in self._calls(tuple(self.CLASS_KINDS)):
+            entry = self._named_call_entry(call)
+            if entry is None:
+                continue
+            kind = self.CLASS_KINDS[function]
+            entry['kind'] = kind
+            arguments = self._arguments(call)
+
+            parent = next((value for name, value in arguments if name in ('inherit', 'contains')), None)
+            if parent is not None:
+                entry['parent'] = self._unquote(parent)
+                entry['signature'] = f"({entry['parent']})  ({kind})"
+            else:
+                entry['signature'] = f" ({kind})"
+
+            slots = self._slots(arguments)
+            if slots:
+                entry['slots'] = slots
+            classes.append(entry)
+
+            if kind in ('R6', 'RC'):
+                members.extend(self._members(entry['name'], arguments))
+
+        return classes, members
+
+    def _extract_generics(self) -> List[Dict[str, Any]]:
+        """Extract setGeneric calls."""
+        generics = []
+        for call, _ in self._calls(('setGeneric',)):
+            entry = self._named_call_entry(call)
+            if entry is not None:
+                generics.append(entry)
+        return generics
+
+    def _extract_s4_methods(self) -> List[Dict[str, Any]]:
+        """Extract setMethod calls with the class they specialize on."""
+        methods = []
+
+        for call, _ in self._calls(('setMethod',)):
+            entry = self._named_call_entry(call)
+            arguments = self._arguments(call)
+            if entry is None or len(arguments) < 2:
+                continue
+            signature = arguments[1][1]
+            if signature.type == 'call' and self._function_name(signature) == 'signature':
+                inner = self._arguments(signature)
+                if not inner:
+                    continue
+                signature = inner[0][1]
+            entry['class'] = self._unquote(signature)
+            entry['signature'] = f" ({entry['class']})"
+            if 'line_end' in entry:
+                entry['line_count'] = entry['line_end'] - entry['line'] + 1
+            methods.append(entry)
+
+        return methods
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract top-level function assignments (nested helpers are not listed)."""
+        functions = []
+
+        for node in self.tree.root_node.children:
+            assignment = self._assignment(node)
+            if assignment is None:
+                continue
+            target, value = assignment
+            if target.type == 'identifier' and value.type == 'function_definition':
+                functions.append(self._function_entry_for(self._get_node_text(target), node, value))
+
+        return functions
+
+    def _members(self, class_name: str, arguments) -> List[Dict[str, Any]]:
+        """Member functions in an R6/RC class's public/private/active/methods lists."""
+        members = []
+
+        for list_name, value in arguments:
+            if list_name not in self.MEMBER_LISTS or value.type != 'call':
+                continue
+            for name, member in self._arguments(value):
+                if name and member.type == 'function_definition':
+                    entry = self._function_entry_for(f"{class_name}${name}", member, member)
+                    entry['class'] = class_name
+                    entry['visibility'] = self.MEMBER_LISTS[list_name]
+                    members.append(entry)
+
+        return members
+
+    def _function_entry_for(self, name: str, node, definition) -> Dict[str, Any]:
+        """Entry for a function spanning node, with definition's parameter list."""
+        params = definition.child_by_field_name('parameters')
+        entry = {
+            'line': node.start_point[0] + 1,
+            'name': name.strip('`'),
+            'signature': ' '.join(self._get_node_text(params).split()) if params is not None else '()',
+        }
+        line_end = node.end_point[0] + 1
+        if line_end > entry['line']:
+            entry['line_end'] = line_end
+            entry['line_count'] = line_end - entry['line'] + 1
+        return entry
+
+    def _named_call_entry(self, call) -> Optional[Dict[str, Any]]:
+        """Entry for a call whose first argument is a name string: setClass("Person", ...)."""
+        arguments = self._arguments(call)
+        if not arguments or arguments[0][1].type != 'string':
+            return None
+        entry = {'line': call.start_point[0] + 1, 'name': self._unquote(arguments[0][1])}
+        line_end = call.end_point[0] + 1
+        if line_end > entry['line']:
+            entry['line_end'] = line_end
+        return entry
+
+    def _slots(self, arguments) -> List[str]:
+        """Slot/field names from slots = c(...), fields = list(...) or representation(...)."""
+        for name, value in arguments:
+            if value.type != 'call':
+                continue
+            function = self._function_name(value)
+            if name in ('slots', 'fields') or function == 'representation':
+                inner = self._arguments(value)
+                named = [arg_name for arg_name, _ in inner if arg_name]
+                return named or [self._unquote(arg) for _, arg in inner if arg.type == 'string']
+        return []
+
+    def _calls(self, names: Tuple[str, ...]) -> List[Tuple[Any, str]]:
+        """(call node, function name) for calls to any of names, in file order."""
+        found = []
+        for call in self._find_nodes_by_type('call'):
+            function = self._function_name(call)
+            if function in names:
+                found.append((call, function))
+        return found
+
+    def _function_name(self, call) -> str:
+        """Name a call calls, without its namespace (R6::R6Class -> R6Class)."""
+        function = call.child_by_field_name('function')
+        if function is None:
+            return ''
+        return self._get_node_text(function).split('::')[-1].strip('`')
+
+    def _arguments(self, call) -> List[Tuple[Optional[str], Any]]:
+        """(name or None, value node) for each argument of a call."""
+        arguments = call.child_by_field_name('arguments')
+        if arguments is None:
+            return []
+
+        found = []
+        for argument in arguments.children:
+            if not argument.is_named or argument.type == 'comment':
+                continue
+            if argument.type in ('argument', 'default_argument'):
+                name = argument.child_by_field_name('name')
+                value = argument.child_by_field_name('value')
+                if value is not None:
+                    found.append((self._get_node_text(name).strip('`') if name is not None else None, value))
+            else:
+                found.append((None, argument))
+        return found
+
+    def _assignment(self, node) -> Optional[Tuple[Any, Any]]:
+        """(target, value) of `x <- v`, `x <<- v` or `x = v`, else None."""
+        if node.type in self.ASSIGNMENT_NODES:
+            target, value = node.child_by_field_name('name'), node.child_by_field_name('value')
+        elif node.type == 'binary_operator':
+            operator = node.child_by_field_name('operator')
+            if operator is None or self._get_node_text(operator) not in self.ASSIGNMENT_OPERATORS:
+                return None
+            target, value = node.child_by_field_name('lhs'), node.child_by_field_name('rhs')
+        else:
+            return None
+        if target is None or value is None:
+            return None
+        return target, value
+
+    def _unquote(self, node) -> str:
+        return self._get_node_text(node).strip('\'"`')
@@ -0,0 +1,166 @@
+"""Ruby file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.rb', '.rake', '.gemspec', name='Ruby', icon='')
+class RubyAnalyzer(TreeSitterAnalyzer):
+    """Ruby file analyzer.
+
+    Extracts:
+    - require / require_relative imports
+    - Modules and classes
+    - Instance methods (Class#method) and class methods (Class.method,
+      from `def self.x` or `class << self`)
+    - attr_accessor / attr_reader / attr_writer declarations
+    - Rails associations (has_many, belongs_to, ...)
+    """
+    language = 'ruby'
+
+    # Call node types across tree-sitter-ruby versions
+    CALL_TYPES = ('call', 'method_call')
+
+    IMPORT_METHODS = ('require', 'require_relative', 'load')
+    ATTR_METHODS = ('attr_accessor', 'attr_reader', 'attr_writer')
+    ASSOCIATION_METHODS = ('has_many', 'has_one', 'belongs_to', 'has_and_belongs_to_many')
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract require / require_relative / load calls."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node),
+        } for node, _ in self._calls(self.IMPORT_METHODS)]
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract top-level methods (defined outside any class or module)."""
+        return [entry for entry, owner, kind in self._method_entries() if owner is None]
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract classes with their superclass."""
+        classes = []
+
+        for node in self._find_nodes_by_type('class'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            entry = {
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': name,
+            }
+            superclass = node.child_by_field_name('superclass')
+            if superclass is not None:
+                entry['signature'] = f" {self._get_node_text(superclass)}"
+            classes.append(entry)
+
+        return classes
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract modules, instance/class methods, attributes and associations."""
+        methods = self._method_entries()
+
+        return {
+            'modules': self._extract_definitions(['module']),
+            'methods': [entry for entry, owner, kind in methods
+                        if owner is not None and kind == 'instance'],
+            'class_methods': [entry for entry, owner, kind in methods
+                              if owner is not None and kind == 'class'],
+            'attributes': self._extract_macro_calls(self.ATTR_METHODS),
+            'associations': self._extract_macro_calls(self.ASSOCIATION_METHODS),
+        }
+
+    def _method_entries(self):
+        """Build (entry, owner, kind) triples for every method definition.
+
+        Instance methods are named Owner#name, class methods Owner.name.
+        """
+        entries = []
+
+        for node_type in ('method', 'singleton_method'):
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if not name:
+                    continue
+
+                owner = self._enclosing_owner(node)
+                is_class_method = node_type == 'singleton_method' or self._in_singleton_class(node)
+                kind = 'class' if is_class_method else 'instance'
+
+                entry = self._function_entry(node, name)
+                if owner is not None:
+                    entry['name'] = f"{owner}{'.' if is_class_method else '#'}{name}"
+                entries.append((entry, owner, kind))
+
+        return sorted(entries, key=lambda e: e[0]['line'])
+
+    def _extract_macro_calls(self, methods) -> List[Dict[str, Any]]:
+        """Extract class-body macro calls (attr_*, has_many, ...) with their symbol names."""
+        items = []
+
+        for node, method in self._calls(methods):
+            arguments = node.child_by_field_name('arguments')
+            if arguments is None:
+                continue
+
+            for arg in arguments.children:
+                if arg.type in ('simple_symbol', 'symbol'):
+                    items.append({
+                        'line': node.start_point[0] + 1,
+                        'name': self._get_node_text(arg).lstrip(':'),
+                        'kind': method,
+                    })
+
+        return items
+
+    def _calls(self, methods):
+        """Yield (node, method_name) for receiver-less calls to the given methods."""
+        for call_type in self.CALL_TYPES:
+            for node in self._find_nodes_by_type(call_type):
+                if node.child_by_field_name('receiver') is not None:
+                    continue
+                method = node.child_by_field_name('method')
+                if method is None:
+                    continue
+                method_name = self._get_node_text(method)
+                if method_name in methods:
+                    yield node, method_name
+
+    def _enclosing_owner(self, node) -> Optional[str]:
+        """Name of the nearest enclosing class or module."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type in ('class', 'module'):
+                return self._get_node_name(parent)
+            parent = parent.parent
+        return None
+
+    @staticmethod
+    def _in_singleton_class(node) -> bool:
+        """Check whether a method is defined inside `class << self`."""
+        parent = node.parent
+        while parent is not None:
+            if parent.type == 'singleton_class':
+                return True
+            if parent.type in ('class', 'module'):
+                return False
+            parent = parent.parent
+        return False
+
+    def _get_signature(self, node) -> str:
+        """Get method parameters (Ruby allows omitting the parentheses)."""
+        params = node.child_by_field_name('parameters')
+        if params is None:
+            return ''
+
+        text = self._get_node_text(params)
+        return text if text.startswith('(') else f"({text})"
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Map element types to Ruby node types."""
+        return {
+            'function': ['method', 'singleton_method'],
+            'class': ['class', 'module'],
+        }
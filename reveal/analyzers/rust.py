@@ -1,5 +1,6 @@
 """Rust file analyzer - tree-sitter based."""
 
+from typing import Dict, List, Any
 from ..base import register
 from ..treesitter import TreeSitterAnalyzer
 
@@ -8,6 +9,52 @@ from ..treesitter import TreeSitterAnalyzer
 class RustAnalyzer(TreeSitterAnalyzer):
     """Rust file analyzer.
 
-    Full Rust support in 3 lines!
+    Extracts:
+    - use declarations
+    - Functions (free functions and methods)
+    - Structs, enums and traits
+    - impl blocks (inherent and trait impls)
     """
     language = 'rust'
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract Rust enums, traits and impl blocks."""
+        return {
+            'enums': self._extract_definitions(['enum_item']),
+            'traits': self._extract_definitions(['trait_item']),
+            'impls': self._extract_impls(),
+        }
+
+    def _extract_impls(self) -> List[Dict[str, Any]]:
+        """Extract impl blocks.
+
+        Named after the implementing type, with the trait for trait impls:
+        - impl Calculator { ... }              -> 'Calculator'
+        - impl fmt::Display for CalcError {}   -> 'fmt::Display for CalcError'
+        """
+        impls = []
+
+        for node in self._find_nodes_by_type('impl_item'):
+            type_node = node.child_by_field_name('type')
+            if type_node is None:
+                continue
+
+            name = self._get_node_text(type_node)
+            trait_node = node.child_by_field_name('trait')
+            if trait_node is not None:
+                name = f"{self._get_node_text(trait_node)} for {name}"
+
+            impls.append({
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': name,
+            })
+
+        return impls
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Make enums and traits extractable by name."""
+        type_map = super()._get_element_type_map()
+        type_map['enum'] = ['enum_item']
+        type_map['trait'] = ['trait_item']
+        return type_map
@@ -0,0 +1,211 @@
+"""Scala file analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.scala', '.sc', name='Scala', icon='')
+class ScalaAnalyzer(TreeSitterAnalyzer):
+    """Scala file analyzer.
+
+    Extracts:
+    - Package clause and imports
+    - Classes, case classes, traits and objects (including case objects)
+    - defs and member/top-level vals
+    - Implicit definitions (implicit def/val/class, Scala 3 givens)
+
+    Useful for Spark and Akka codebases.
+    """
+    language = 'scala'
+
+    # Scopes whose vals are listed (not locals inside method bodies)
+    MEMBER_SCOPES = ('template_body', 'compilation_unit', 'package_clause')
+
+    def _extract_imports(self) -> List[Dict[str, Any]]:
+        """Extract import declarations."""
+        return [{
+            'line': node.start_point[0] + 1,
+            'content': self._get_node_text(node),
+        } for node in self._find_nodes_by_type('import_declaration')]
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract defs (abstract declarations included)."""
+        functions = []
+
+        for node_type in ('function_definition', 'function_declaration'):
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if not name:
+                    continue
+
+                entry = self._function_entry(node, name)
+                modifiers = self._get_modifiers(node)
+                if modifiers:
+                    entry['modifiers'] = modifiers
+                functions.append(entry)
+
+        return sorted(functions, key=lambda f: f['line'])
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract regular (non-case) classes."""
+        return [c for c in self._class_entries() if not c.get('case')]
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract package, case classes, traits, objects, vals and implicits."""
+        classes = self._class_entries()
+
+        return {
+            'package': [{
+                'line': node.start_point[0] + 1,
+                'content': self._get_node_text(node).split('{')[0].strip(),
+            } for node in self._find_nodes_by_type('package_clause')],
+            'case_classes': [c for c in classes if c.get('case')],
+            'traits': self._extract_definitions(['trait_definition']),
+            'objects': self._extract_objects(),
+            'vals': self._extract_vals(),
+            'implicits': self._extract_implicits(),
+        }
+
+    def _class_entries(self) -> List[Dict[str, Any]]:
+        """Extract all class definitions, flagging case classes."""
+        classes = []
+
+        for node in self._find_nodes_by_type('class_definition'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            entry = {
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': name,
+            }
+            if self._is_case(node):
+                entry['case'] = True
+            params = node.child_by_field_name('class_parameters')
+            if params is not None:
+                entry['signature'] = self._get_node_text(params)
+            classes.append(entry)
+
+        return classes
+
+    def _extract_objects(self) -> List[Dict[str, Any]]:
+        """Extract objects; case objects are marked as such."""
+        objects = []
+
+        for node in self._find_nodes_by_type('object_definition'):
+            name = self._get_node_name(node)
+            if not name:
+                continue
+
+            entry = {
+                'line': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'name': name,
+            }
+            if self._is_case(node):
+                entry['kind'] = 'case object'
+            objects.append(entry)
+
+        return objects
+
+    def _extract_vals(self) -> List[Dict[str, Any]]:
+        """Extract vals and vars declared in templates or at top level."""
+        vals = []
+
+        for node_type in ('val_definition', 'val_declaration', 'var_definition', 'var_declaration'):
+            for node in self._find_nodes_by_type(node_type):
+                if node.parent is None or node.parent.type not in self.MEMBER_SCOPES:
+                    continue
+
+                name = self._get_val_name(node)
+                if not name:
+                    continue
+
+                entry = {
+                    'line': node.start_point[0] + 1,
+                    'name': name,
+                    'kind': node_type.split('_')[0],
+                }
+                val_type = node.child_by_field_name('type')
+                if val_type is not None:
+                    entry['signature'] = f": {self._get_node_text(val_type)}"
+                vals.append(entry)
+
+        return sorted(vals, key=lambda v: v['line'])
+
+    def _extract_implicits(self) -> List[Dict[str, Any]]:
+        """Extract implicit defs, vals and classes, plus Scala 3 givens."""
+        implicits = []
+
+        node_kinds = {
+            'function_definition': 'def',
+            'val_definition': 'val',
+            'class_definition': 'class',
+            'object_definition': 'object',
+        }
+        for node_type, kind in node_kinds.items():
+            for node in self._find_nodes_by_type(node_type):
+                if 'implicit' not in self._get_modifiers(node):
+                    continue
+
+                name = self._get_val_name(node) if kind == 'val' else self._get_node_name(node)
+                if name:
+                    implicits.append({
+                        'line': node.start_point[0] + 1,
+                        'name': name,
+                        'kind': f"implicit {kind}",
+                    })
+
+        for node in self._find_nodes_by_type('given_definition'):
+            implicits.append({
+                'line': node.start_point[0] + 1,
+                'name': self._get_node_name(node) or self._get_node_text(node).split('\n')[0].rstrip(':= '),
+                'kind': 'given',
+            })
+
+        return sorted(implicits, key=lambda i: i['line'])
+
+    def _get_modifiers(self, node) -> List[str]:
+        """Get modifier keywords (implicit, override, private, ...) of a definition."""
+        modifiers = []
+
+        for child in node.children:
+            if child.type == 'modifiers':
+                modifiers.extend(self._get_node_text(m) for m in child.children)
+
+        return modifiers
+
+    @staticmethod
+    def _is_case(node) -> bool:
+        """Check for the `case` keyword on a class or object definition."""
+        return any(child.type == 'case' for child in node.children)
+
+    def _get_val_name(self, node) -> Optional[str]:
+        """vals name a pattern rather than an identifier (val (a, b) = ...)."""
+        pattern = node.child_by_field_name('pattern')
+        if pattern is not None:
+            return self._get_node_text(pattern)
+        return self._get_node_name(node)
+
+    def _get_signature(self, node) -> str:
+        """Get def signature: all parameter lists plus return type."""
+        params = ''.join(self._get_node_text(child) for child in node.children
+                         if child.type == 'parameters')
+        signature = params or '()'
+
+        return_type = node.child_by_field_name('return_type')
+        if return_type is not None:
+            signature += f" -> {self._get_node_text(return_type)}"
+
+        return signature
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Map element types to Scala node types."""
+        return {
+            'function': ['function_definition', 'function_declaration'],
+            'class': ['class_definition', 'object_definition', 'trait_definition'],
+            'trait': ['trait_definition'],
+            'object': ['object_definition'],
+        }
@@ -0,0 +1,239 @@
+"""Solidity file analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_PARAMS = r'\((?P<params>[^)]*)\)'
+
+
+@register('.sol', name='Solidity', icon='')
+class SolidityAnalyzer(RegexAnalyzer):
+    """Solidity file analyzer.
+
+    Extracts:
+    - pragma and import directives
+    - Contracts, abstract contracts, interfaces and libraries with their
+      inheritance (`is A, B`)
+    - State variables with type and visibility (constant/immutable marked)
+    - Functions, constructors, fallback/receive, named Contract.function,
+      with visibility, mutability (view/pure/payable) and modifiers
+    - Modifiers, events, custom errors, structs and enums
+    """
+
+    comment_prefixes = ('//', '/*', '*')
+
+    patterns = {
+        'pragmas': re.compile(r'^\s*(?P<content>pragma\s+[^;]+)'),
+        'imports': re.compile(r'^\s*import\s+(?P<content>[^;]+)'),
+        'contracts': re.compile(
+            r'^\s*(?P<abstract>abstract\s+)?(?P<kind>contract|interface|library)\s+(?P<name>\w+)'
+        ),
+        'modifiers': re.compile(r'^\s*modifier\s+(?P<name>\w+)\s*(?:' + _PARAMS + r')?'),
+        'events': re.compile(r'^\s*event\s+(?P<name>\w+)\s*' + _PARAMS),
+        'errors': re.compile(r'^\s*error\s+(?P<name>\w+)\s*' + _PARAMS),
+        'structs': re.compile(r'^\s*struct\s+(?P<name>\w+)'),
+        'enums': re.compile(r'^\s*enum\s+(?P<name>\w+)'),
+        'functions': re.compile(
+            r'^\s*(?:function\s+(?P<name>\w+)|(?P<special>constructor|fallback|receive))\s*\('
+        ),
+        'state_variables': re.compile(
+            r'^\s*(?P<type>mapping\s*\(.*\)|[A-Za-z_][\w.]*(?:\s*\[[^\]]*\])*)\s+'
+            r'(?P<attributes>(?:(?:public|private|internal|constant|immutable|override|transient)\s+)*)'
+            r'(?P<name>\w+)\s*(?:=[^;]*)?;'
+        ),
+    }
+
+    VISIBILITY = ('public', 'external', 'internal', 'private')
+    MUTABILITY = ('view', 'pure', 'payable')
+    NOT_TYPES = ('return', 'emit', 'delete', 'using', 'revert', 'require', 'assert', 'else')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; qualify members with their contract."""
+        self._depths = self._line_depths()
+        structure = super().get_structure(**kwargs)
+
+        contracts = structure.get('contracts', [])
+        for category, entries in structure.items():
+            if category in ('pragmas', 'imports', 'contracts'):
+                continue
+            for entry in entries:
+                owner = next((c for c in contracts
+                              if c['line'] < entry['line'] <= c.get('line_end', c['line'])), None)
+                if owner:
+                    entry['contract'] = owner['name']
+                    entry['name'] = f"{owner['name']}.{entry['name']}"
+                    if owner['kind'] == 'interface' and category == 'functions':
+                        entry.setdefault('visibility', 'external')
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return structure
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Shape contract, function and state variable entries."""
+        depth = self._depths[line_no - 1]
+
+        if category == 'state_variables':
+            if depth != 1 or match.group('type') in self.NOT_TYPES:
+                return None
+            return self._state_variable_entry(match, line_no)
+
+        if category == 'functions' and match.group('special'):
+            # constructor / fallback / receive have no name of their own
+            special = match.group('special')
+            entry = {'line': line_no, 'name': special, 'kind': special}
+            line_end = self._find_block_end(line_no)
+            if line_end > line_no:
+                entry['line_end'] = line_end
+                entry['line_count'] = line_end - line_no + 1
+            return self._function_entry(entry, line_no)
+
+        entry = super()._make_entry(category, match, line_no)
+
+        if category in ('pragmas', 'imports'):
+            entry['content'] = ('import ' if category == 'imports' else '') + entry['content'].strip()
+            return entry
+
+        if category == 'contracts':
+            kind = entry.pop('kind')
+            if entry.pop('abstract', None):
+                kind = f"abstract {kind}"
+            entry['kind'] = kind
+            header = self._header(line_no)
+            inherits = re.search(r'\bis\s+(.+?)\s*\{', header)
+            signature = ''
+            if inherits:
+                entry['inherits'] = [re.sub(r'\s*\(.*', '', base).strip()
+                                     for base in self._split_top_level(inherits.group(1))]
+                signature = f" is {', '.join(entry['inherits'])}"
+            entry['signature'] = signature + (f"  ({kind})" if kind != 'contract' else '')
+            return entry
+
+        if category in ('events', 'errors', 'modifiers'):
+            params = ' '.join(entry.pop('params', '').split())
+            if category != 'modifiers' or match.group('params') is not None:
+                entry['signature'] = f"({params})"
+            if category != 'modifiers':
+                entry.pop('line_end', None)
+            return entry
+
+        if category == 'functions':
+            return self._function_entry(entry, line_no)
+
+        return entry
+
+    def _function_entry(self, entry: Dict[str, Any], line_no: int) -> Dict[str, Any]:
+        """Parse a (possibly multi-line) function header."""
+        header = self._header(line_no)
+        start = header.index('(')
+        close = self._matching_paren(header, start)
+        params = ' '.join(header[start + 1:close].split())
+        rest = header[close + 1:]
+
+        returns = ''
+        returns_match = re.search(r'\breturns\s*\(', rest)
+        if returns_match:
+            open_paren = returns_match.end() - 1
+            returns = ' '.join(rest[open_paren:self._matching_paren(rest, open_paren) + 1].split())
+            rest = rest[:returns_match.start()]
+
+        words = re.findall(r'\w+(?:\([^)]*\))?', rest.split('{', 1)[0])
+        visibility = next((w for w in words if w in self.VISIBILITY), None)
+        mutability = next((w for w in words if w in self.MUTABILITY), None)
+        modifiers = [w for w in words if w not in self.VISIBILITY + self.MUTABILITY]
+
+        if visibility:
+            entry['visibility'] = visibility
+        if mutability:
+            entry['mutability'] = mutability
+        if modifiers:
+            entry['modifiers'] = modifiers
+
+        qualifiers = ([mutability] if mutability else []) + modifiers
+        entry['signature'] = f"({params})" + (f" -> {returns}" if returns else '') + \
+            (f"  {' '.join(qualifiers)}" if qualifiers else '')
+        return entry
+
+    def _state_variable_entry(self, match, line_no: int) -> Dict[str, Any]:
+        """State variable with type, visibility and constant/immutable marker."""
+        attributes = match.group('attributes').split()
+        var_type = ' '.join(match.group('type').split())
+        entry = {'line': line_no, 'name': match.group('name'), 'type': var_type}
+
+        visibility = next((a for a in attributes if a in self.VISIBILITY), 'internal')
+        entry['visibility'] = visibility
+        signature = f": {var_type}"
+        for marker in ('constant', 'immutable'):
+            if marker in attributes:
+                entry[marker] = True
+                signature += f"  ({marker})"
+        entry['signature'] = signature
+        return entry
+
+    def _find_block_end(self, line_no: int) -> int:
+        """Brace-match from the line where the body opens (headers often span lines)."""
+        for i in range(line_no - 1, min(len(self.lines), line_no + 20)):
+            code = self._strip_strings(self.lines[i])
+            if '{' in code:
+                return self._find_brace_end(i + 1)
+            if ';' in code:
+                break
+        return line_no
+
+    def _header(self, line_no: int) -> str:
+        """Declaration text from line_no up to its opening '{' or ';'."""
+        parts = []
+        for line in self.lines[line_no - 1:line_no + 20]:
+            code = line.split('//', 1)[0]
+            parts.append(code.strip())
+            if '{' in code or ';' in code:
+                break
+        return ' '.join(parts)
+
+    @staticmethod
+    def _matching_paren(text: str, start: int) -> int:
+        """Index of the ')' matching the '(' at start."""
+        depth = 0
+        for i in range(start, len(text)):
+            if text[i] == '(':
+                depth += 1
+            elif text[i] == ')':
+                depth -= 1
+                if depth == 0:
+                    return i
+        return len(text)
+
+    @staticmethod
+    def _split_top_level(text: str) -> List[str]:
+        """Split on commas not nested in parentheses."""
+        parts, depth, current = [], 0, ''
+        for char in text:
+            if char == '(':
+                depth += 1
+            elif char == ')':
+                depth -= 1
+            if char == ',' and depth == 0:
+                parts.append(current)
+                current = ''
+            else:
+                current += char
+        parts.append(current)
+        return [p for p in parts if p.strip()]
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract by qualified (Token.transfer) or bare (transfer) name."""
+        if '.' not in name:
+            for entries in self.get_structure().values():
+                for item in entries:
+                    if item.get('name', '').endswith(f".{name}"):
+                        return super().extract_element(element_type, item['name'])
+
+        return super().extract_element(element_type, name)
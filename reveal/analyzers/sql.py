@@ -0,0 +1,327 @@
+"""SQL file analyzer - schema and migration files."""
+
+import re
+from typing import Dict, List, Any, Optional, Tuple
+from ..base import FileAnalyzer, register
+
+
+# Identifier: plain, "quoted", `backticked` or [bracketed], optionally schema-qualified
+_IDENT = r'(?:[\w$]+|"[^"]+"|`[^`]+`|\[[^\]]+\])'
+_NAME = rf'(?P<name>{_IDENT}(?:\s*\.\s*{_IDENT})*)'
+
+_CREATE = r'^\s*CREATE\s+(?:OR\s+(?:REPLACE|ALTER)\s+)?'
+_IF_NOT_EXISTS = r'(?:IF\s+NOT\s+EXISTS\s+)?'
+
+# Column-definition keywords that end a column's type
+_COLUMN_KEYWORDS = {
+    'NOT', 'NULL', 'DEFAULT', 'PRIMARY', 'REFERENCES', 'UNIQUE', 'CHECK',
+    'CONSTRAINT', 'GENERATED', 'COLLATE', 'AUTO_INCREMENT', 'AUTOINCREMENT',
+    'IDENTITY', 'COMMENT', 'ON', 'AS',
+}
+
+# Table elements that are constraints, not columns
+_CONSTRAINT_KEYWORDS = ('CONSTRAINT', 'PRIMARY', 'FOREIGN', 'UNIQUE', 'CHECK',
+                        'KEY', 'INDEX', 'EXCLUDE', 'FULLTEXT', 'SPATIAL')
+
+
+@register('.sql', '.ddl', name='SQL', icon='')
+class SQLAnalyzer(FileAnalyzer):
+    """SQL analyzer for schema and migration files.
+
+    Extracts CREATE statements (and ALTER TABLE in migrations):
+    - tables with their column lists and constraints
+    - views, indexes, functions/procedures and triggers
+    - ALTER TABLE statements
+
+    Statements are split on ';' while respecting strings, comments and
+    dollar-quoted function bodies ($$ ... $$), so Postgres, MySQL and
+    SQLite dumps all work.
+    """
+
+    STATEMENT_PATTERNS = {
+        'tables': re.compile(
+            _CREATE + r'(?:(?:GLOBAL|LOCAL)\s+)?(?:TEMP(?:ORARY)?\s+|UNLOGGED\s+|VIRTUAL\s+)?'
+            r'TABLE\s+' + _IF_NOT_EXISTS + _NAME, re.IGNORECASE),
+        'views': re.compile(
+            _CREATE + r'(?:TEMP(?:ORARY)?\s+|RECURSIVE\s+)?(?:MATERIALIZED\s+)?VIEW\s+'
+            + _IF_NOT_EXISTS + _NAME, re.IGNORECASE),
+        'indexes': re.compile(
+            _CREATE + r'(?P<unique>UNIQUE\s+)?(?:CLUSTERED\s+|NONCLUSTERED\s+)?INDEX\s+'
+            r'(?:CONCURRENTLY\s+)?' + _IF_NOT_EXISTS + _NAME
+            + rf'\s+ON\s+(?:ONLY\s+)?(?P<table>{_IDENT}(?:\.{_IDENT})*)\s*(?:USING\s+\w+\s*)?',
+            re.IGNORECASE),
+        'functions': re.compile(
+            _CREATE + r'(?:DEFINER\s*=\s*\S+\s+)?(?P<kind>FUNCTION|PROCEDURE)\s+'
+            + _IF_NOT_EXISTS + _NAME + r'\s*(?P<args>\((?:[^()]|\([^()]*\))*\))?'
+            r'(?:\s*RETURNS\s+(?P<returns>(?:SETOF\s+)?TABLE\s*\([^)]*\)|[\w.\[\]]+(?:\s*\([^)]*\))?))?',
+            re.IGNORECASE),
+        'triggers': re.compile(
+            _CREATE + r'(?:CONSTRAINT\s+)?TRIGGER\s+' + _IF_NOT_EXISTS + _NAME
+            + rf'(?P<timing>[\s\S]*?)\s+ON\s+(?P<table>{_IDENT}(?:\.{_IDENT})*)',
+            re.IGNORECASE),
+        'alters': re.compile(
+            r'^\s*ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?' + _NAME + r'\s+(?P<action>[\s\S]+)',
+            re.IGNORECASE),
+    }
+
+    SINGULAR = {'indexes': 'index', 'functions': 'function', 'triggers': 'trigger', 'alters': 'alter'}
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract schema objects from CREATE / ALTER statements."""
+        structure = {category: [] for category in self.STATEMENT_PATTERNS}
+
+        for statement, line_start, line_end in self._split_statements():
+            for category, pattern in self.STATEMENT_PATTERNS.items():
+                match = pattern.match(statement)
+                if match:
+                    entry = {'line': line_start, 'name': self._unquote(match.group('name'))}
+                    entry.update(getattr(self, f'_parse_{category}')(match, statement))
+                    if line_end > line_start:
+                        entry['line_end'] = line_end
+                    structure[category].append(entry)
+                    break
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Schema overview for directory trees: 'tables: users, orders; 2 indexes'."""
+        structure = self.get_structure()
+        parts = []
+
+        for category in ('tables', 'views'):
+            names = [item['name'] for item in structure.get(category, [])]
+            if names:
+                shown = ', '.join(names[:4]) + (', ...' if len(names) > 4 else '')
+                parts.append(f"{category}: {shown}")
+
+        for category in ('indexes', 'functions', 'triggers', 'alters'):
+            count = len(structure.get(category, []))
+            if count:
+                parts.append(f"{count} {category if count != 1 else self.SINGULAR[category]}")
+
+        return '; '.join(parts) or None
+
+    def _parse_tables(self, match, statement: str) -> Dict[str, Any]:
+        """Column list and constraints of a CREATE TABLE."""
+        entry = {}
+        body = self._parenthesized(statement, match.end())
+
+        if body is None:
+            # CREATE TABLE ... AS SELECT / LIKE
+            entry['signature'] = ' ' + ' '.join(statement[match.end():].split())[:60]
+            return entry
+
+        columns = []
+        constraints = []
+        for element in self._split_top_level(body):
+            column = re.match(rf'\s*({_IDENT})(.*)', element, re.DOTALL)
+            if not column:
+                continue
+            if column.group(1).upper() in _CONSTRAINT_KEYWORDS:
+                constraints.append(' '.join(element.split()))
+                continue
+
+            column_type = []
+            for word in column.group(2).split():
+                if word.upper() in _COLUMN_KEYWORDS:
+                    break
+                column_type.append(word)
+            columns.append({'name': self._unquote(column.group(1)), 'type': ' '.join(column_type)})
+
+        entry['columns'] = columns
+        entry['signature'] = f" ({', '.join(c['name'] for c in columns)})"
+        if constraints:
+            entry['constraints'] = constraints
+        return entry
+
+    def _parse_views(self, match, statement: str) -> Dict[str, Any]:
+        """Views: note materialized views."""
+        if re.search(r'\bMATERIALIZED\b', statement[:match.end()], re.IGNORECASE):
+            return {'kind': 'materialized'}
+        return {}
+
+    def _parse_indexes(self, match, statement: str) -> Dict[str, Any]:
+        """Indexed table and columns."""
+        table = self._unquote(match.group('table'))
+        columns = self._parenthesized(statement, match.end())
+        columns = f"({' '.join(columns.split())})" if columns is not None else ''
+        entry = {'signature': f" ON {table} {columns}".rstrip(), 'table': table}
+        if match.group('unique'):
+            entry['unique'] = True
+        return entry
+
+    def _parse_functions(self, match, statement: str) -> Dict[str, Any]:
+        """Arguments and return type of a function or procedure."""
+        args = ' '.join((match.group('args') or '()').split())
+        signature = args
+        if match.group('returns'):
+            signature += f" -> {' '.join(match.group('returns').split())}"
+        return {'signature': signature, 'kind': match.group('kind').lower()}
+
+    def _parse_triggers(self, match, statement: str) -> Dict[str, Any]:
+        """Timing/event and table of a trigger."""
+        timing = ' '.join(match.group('timing').split())
+        table = self._unquote(match.group('table'))
+        return {'signature': f" {timing} ON {table}".replace('  ', ' '), 'table': table}
+
+    def _parse_alters(self, match, statement: str) -> Dict[str, Any]:
+        """First line of the ALTER TABLE action (ADD COLUMN ..., DROP ...)."""
+        action = ' '.join(match.group('action').split())
+        if len(action) > 80:
+            action = action[:77] + '...'
+        return {'signature': f" {action}"}
+
+    def _split_statements(self) -> List[Tuple[str, int, int]]:
+        """Split the file into (statement, line_start, line_end) triples.
+
+        Comments are dropped; strings, quoted identifiers and dollar-quoted
+        bodies are kept intact so ';' inside them doesn't split.
+        """
+        statements = []
+        text = self.content
+        current = []
+        line = 1
+        start_line = None
+        i = 0
+
+        def flush(end_line):
+            statement = ''.join(current).strip()
+            if statement:
+                statements.append((statement, start_line, end_line))
+
+        while i < len(text):
+            char = text[i]
+
+            # -- comments, and MySQL # comments at the start of a line
+            at_line_start = text[text.rfind('\n', 0, i) + 1:i].strip() == ''
+            if text.startswith('--', i) or (char == '#' and at_line_start):
+                end = text.find('\n', i)
+                i = len(text) if end == -1 else end
+                continue
+
+            if text.startswith('/*', i):
+                end = text.find('*/', i + 2)
+                end = len(text) if end == -1 else end + 2
+                line += text.count('\n', i, end)
+                current.append(' ')
+                i = end
+                continue
+
+            if char in '\'"`':
+                end = self._find_quote_end(text, i, char)
+                chunk = text[i:end]
+            elif char == '$':
+                tag = re.match(r'\$[A-Za-z_]*\$', text[i:])
+                if tag:
+                    close = text.find(tag.group(0), i + len(tag.group(0)))
+                    end = len(text) if close == -1 else close + len(tag.group(0))
+                    chunk = text[i:end]
+                else:
+                    end, chunk = i + 1, char
+            elif char == ';':
+                flush(line)
+                current = []
+                start_line = None
+                i += 1
+                continue
+            else:
+                end, chunk = i + 1, char
+
+            if start_line is None and not chunk.isspace():
+                start_line = line
+            current.append(chunk)
+            line += chunk.count('\n')
+            i = end
+
+        flush(line)
+        return statements
+
+    @staticmethod
+    def _find_quote_end(text: str, start: int, quote: str) -> int:
+        """Index just past the closing quote (doubled quotes are escapes)."""
+        i = start + 1
+        while i < len(text):
+            if text[i] == '\\' and quote == "'":
+                i += 2
+                continue
+            if text[i] == quote:
+                if text.startswith(quote * 2, i):
+                    i += 2
+                    continue
+                return i + 1
+            i += 1
+        return len(text)
+
+    @staticmethod
+    def _parenthesized(statement: str, start: int) -> Optional[str]:
+        """Content of the parenthesized group starting right after `start`."""
+        rest = statement[start:].lstrip()
+        if not rest.startswith('('):
+            return None
+
+        depth = 0
+        for i, char in enumerate(rest):
+            if char == '(':
+                depth += 1
+            elif char == ')':
+                depth -= 1
+                if depth == 0:
+                    return rest[1:i]
+        return rest[1:]
+
+    @staticmethod
+    def _split_top_level(body: str) -> List[str]:
+        """Split on commas outside parentheses and quotes."""
+        parts = []
+        depth = 0
+        quote = None
+        current = ''
+
+        for char in body:
+            if quote:
+                if char == quote:
+                    quote = None
+            elif char in '\'"`':
+                quote = char
+            elif char == '(':
+                depth += 1
+            elif char == ')':
+                depth -= 1
+            elif char == ',' and depth == 0:
+                parts.append(current.strip())
+                current = ''
+                continue
+            current += char
+
+        if current.strip():
+            parts.append(current.strip())
+        return parts
+
+    @staticmethod
+    def _unquote(name: str) -> str:
+        """Strip identifier quoting: "public"."users" -> public.users."""
+        parts = re.split(r'\s*\.\s*', name.strip())
+        return '.'.join(p.strip('"`[]') for p in parts)
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a CREATE statement (table, view, function, ...) by name."""
+        for category, items in self.get_structure().items():
+            for item in items:
+                if item['name'] == name or item['name'].split('.')[-1] == name:
+                    line_start = item['line']
+                    line_end = item.get('line_end', line_start)
+                    return {
+                        'name': item['name'],
+                        'line_start': line_start,
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[line_start - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
@@ -0,0 +1,120 @@
+"""Swift file analyzer - regex based (no tree-sitter grammar available)."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+# Attributes (@objc, @MainActor, ...) and modifiers preceding a declaration
+_PREFIX = (
+    r'^\s*(?:@\w+(?:\([^)]*\))?\s+)*'
+    r'(?P<modifiers>(?:(?:open|public|internal|fileprivate|private)(?:\(set\))?\s+'
+    r'|(?:final|static|class|override|mutating|nonmutating|convenience|required'
+    r'|indirect|lazy|dynamic|optional|nonisolated|distributed)\s+)*)'
+)
+
+# Inheritance / conformance clause: `: Base, Protocol`
+_INHERITS = r'(?P<signature>\s*:\s*[^{]+)?'
+
+
+@register('.swift', name='Swift', icon='')
+class SwiftAnalyzer(RegexAnalyzer):
+    """Swift file analyzer.
+
+    Extracts:
+    - Imports
+    - Classes, structs, protocols, actors and extensions
+    - Enums with their cases
+    - Functions, initializers and subscripts
+
+    Every declaration carries its access level (open, public, internal,
+    fileprivate, private); Swift's default is internal.
+    """
+
+    ACCESS_LEVELS = ('open', 'public', 'internal', 'fileprivate', 'private')
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?:@testable\s+)?import\s+(?:\w+\s+)?[\w.]+'),
+        'classes': re.compile(_PREFIX + r'(?:class|actor)\s+(?!func\b|var\b|let\b)(?P<name>\w+)(?:<[^>]*>)?' + _INHERITS),
+        'structs': re.compile(_PREFIX + r'struct\s+(?P<name>\w+)(?:<[^>]*>)?' + _INHERITS),
+        'protocols': re.compile(_PREFIX + r'protocol\s+(?P<name>\w+)' + _INHERITS),
+        'extensions': re.compile(_PREFIX + r'extension\s+(?P<name>[\w.]+)(?:<[^>]*>)?' + _INHERITS),
+        'enums': re.compile(_PREFIX + r'enum\s+(?P<name>\w+)(?:<[^>]*>)?' + _INHERITS),
+        'functions': re.compile(
+            _PREFIX + r'(?:func\s+(?P<name>[^\s(<]+)|(?P<init>init[?!]?|subscript|deinit))'
+            r'(?P<signature>(?:<[^>]*>)?(?:\(.*?\))?(?:\s*(?:async|throws|rethrows))*(?:\s*->\s*[^{]+)?)'
+        ),
+    }
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Split modifiers into access level + others; attach enum cases."""
+        entry = super()._make_entry(category, match, line_no)
+
+        if category == 'functions' and 'init' in entry:
+            # init / subscript / deinit have no `func name`
+            entry.pop('content', None)
+            entry = {'line': line_no, 'name': entry.pop('init'), **entry}
+            line_end = self._find_block_end(line_no)
+            if line_end > line_no:
+                entry['line_end'] = line_end
+                entry['line_count'] = line_end - line_no + 1
+
+        if category == 'imports':
+            return entry
+
+        modifiers = entry.pop('modifiers', '').split()
+        access = [m for m in modifiers if m.split('(')[0] in self.ACCESS_LEVELS]
+        entry['visibility'] = ' '.join(access) or 'internal'
+        others = [m for m in modifiers if m not in access]
+        if others:
+            entry['modifiers'] = others
+
+        if 'signature' in entry:
+            entry['signature'] = entry['signature'].strip().rstrip('{').rstrip()
+            if category != 'functions':
+                entry['signature'] = f": {entry['signature'].lstrip(':').strip()}"
+
+        if category == 'enums':
+            cases = self._enum_cases(line_no, entry.get('line_end', line_no))
+            if cases:
+                entry['cases'] = cases
+
+        return entry
+
+    def _enum_cases(self, start: int, end: int) -> List[str]:
+        """Collect case names declared directly in an enum body."""
+        cases = []
+        depth = 0
+
+        for i in range(start - 1, end):
+            line = self._strip_strings(self.lines[i])
+            if depth == 1:
+                match = re.match(r'^\s*(?:indirect\s+)?case\s+(.+)', line)
+                if match:
+                    for case in self._split_cases(match.group(1)):
+                        cases.append(case)
+            depth += line.count('{') - line.count('}')
+
+        return cases
+
+    @staticmethod
+    def _split_cases(text: str) -> List[str]:
+        """Split `a, b(Int), c = 3` into case names."""
+        names = []
+        depth = 0
+        current = ''
+
+        for char in text:
+            if char in '([':
+                depth += 1
+            elif char in ')]':
+                depth -= 1
+            if char == ',' and depth == 0:
+                names.append(current)
+                current = ''
+            else:
+                current += char
+        names.append(current)
+
+        return [re.match(r'\s*(\w+)', n).group(1) for n in names if re.match(r'\s*(\w+)', n)]
@@ -0,0 +1,122 @@
+"""Terraform / HCL analyzer - tree-sitter based."""
+
+from typing import Dict, List, Any, Iterator, Tuple
+from ..base import register
+from ..treesitter import TreeSitterAnalyzer
+
+
+@register('.tf', '.hcl', name='Terraform', icon='')
+class TerraformAnalyzer(TreeSitterAnalyzer):
+    """Terraform / HCL analyzer.
+
+    Lists top-level blocks grouped by block type:
+    - resources and data sources (type.name)
+    - modules (with source), providers (with alias)
+    - variables (with type and default), outputs
+    - locals (each local value), terraform settings
+    """
+    language = 'hcl'
+
+    # Top-level block type -> category
+    BLOCK_CATEGORIES = {
+        'resource': 'resources',
+        'data': 'data_sources',
+        'module': 'modules',
+        'provider': 'providers',
+        'variable': 'variables',
+        'output': 'outputs',
+        'terraform': 'terraform',
+    }
+
+    # Attributes shown per block type
+    SHOWN_ATTRIBUTES = {
+        'modules': ('source', 'version'),
+        'providers': ('alias', 'region'),
+        'variables': ('type', 'default'),
+        'outputs': ('value',),
+        'terraform': ('required_version',),
+    }
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract top-level blocks by type, and each value defined in `locals { ... }`."""
+        structure = {category: [] for category in self.BLOCK_CATEGORIES.values()}
+        structure['locals'] = []
+
+        for block in self._top_level_blocks():
+            block_type, labels = self._block_header(block)
+
+            if block_type == 'locals':
+                for key, attribute in self._attributes(block):
+                    structure['locals'].append({'line': attribute.start_point[0] + 1, 'name': f"local.{key}"})
+                continue
+
+            category = self.BLOCK_CATEGORIES.get(block_type)
+            if category in ('resources', 'data_sources'):
+                name = '.'.join(labels[:2]) if len(labels) >= 2 else None
+            elif category == 'terraform':
+                name = 'terraform'
+            else:
+                name = labels[0] if category and labels else None
+            if not name:
+                continue
+
+            entry = {
+                'line': block.start_point[0] + 1,
+                'line_end': block.end_point[0] + 1,
+                'name': name,
+            }
+            if category in self.SHOWN_ATTRIBUTES:
+                # Single-line values only: nested maps, lists and heredocs are left out
+                attributes = {}
+                for key, value in self._attribute_values(block):
+                    text = self._get_node_text(value)
+                    if '\n' not in text:
+                        attributes[key] = text
+                shown = [f"{key}={attributes[key]}" for key in self.SHOWN_ATTRIBUTES[category] if key in attributes]
+                if shown:
+                    entry['signature'] = f" ({', '.join(shown)})"
+                entry['attributes'] = attributes
+            structure[category].append(entry)
+
+        return structure
+
+    def _top_level_blocks(self) -> List:
+        """Blocks in the file's top-level body."""
+        blocks = []
+        for child in self.tree.root_node.children:
+            if child.type == 'block':
+                blocks.append(child)
+            elif child.type == 'body':
+                blocks.extend(node for node in child.children if node.type == 'block')
+        return blocks
+
+    def _block_header(self, block) -> Tuple[str, List[str]]:
+        """A block's type and labels: resource "aws_instance" "web" -> ('resource', ['aws_instance', 'web'])."""
+        block_type = ''
+        labels = []
+        for child in block.children:
+            if child.type == 'identifier' and not block_type:
+                block_type = self._get_node_text(child)
+            elif child.type in ('string_lit', 'identifier'):
+                labels.append(self._get_node_text(child).strip('"'))
+            elif child.type in ('block_start', 'body', '{'):
+                break
+        return block_type, labels
+
+    def _attributes(self, block) -> Iterator[Tuple[str, Any]]:
+        """(key, attribute node) for attributes directly in a block's body."""
+        for body in block.children:
+            if body.type != 'body':
+                continue
+            for child in body.children:
+                if child.type == 'attribute':
+                    named = [node for node in child.children if node.is_named]
+                    if named and named[0].type == 'identifier':
+                        yield self._get_node_text(named[0]), child
+
+    def _attribute_values(self, block) -> Iterator[Tuple[str, Any]]:
+        """(key, value expression node) for attributes directly in a block's body."""
+        for key, attribute in self._attributes(block):
+            named = [node for node in attribute.children if node.is_named]
+            if len(named) > 1:
+                yield key, named[-1]
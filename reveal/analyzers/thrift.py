@@ -0,0 +1,220 @@
+"""Apache Thrift IDL (.thrift) analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_TYPE = r'[\w.]+(?:\s*<.*>)?'
+_FIELD = re.compile(
+    r'^\s*(?P<number>-?\d+)\s*:\s*(?:(?P<label>required|optional)\s+)?(?P<type>' + _TYPE + r')\s+(?P<name>\w+)'
+)
+_ENUM_VALUE = re.compile(r'^\s*(?P<name>[A-Za-z_]\w*)\s*(?:=\s*(?P<number>-?\w+))?\s*[,;]?\s*(?://.*|#.*)?$')
+
+
+@register('.thrift', name='Thrift', icon='')
+class ThriftAnalyzer(RegexAnalyzer):
+    """Apache Thrift IDL analyzer.
+
+    Extracts:
+    - include and namespace declarations
+    - Structs, unions and exceptions with their fields and field ids
+    - Enums with values
+    - Services (with extends) and their methods: argument list, return
+      type, oneway and throws
+    - typedefs and constants
+    """
+
+    # Fields shown inline before eliding
+    MAX_INLINE_FIELDS = 8
+
+    comment_prefixes = ('//', '#', '/*', '*')
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?P<content>(?:include|cpp_include)\s+"[^"]+")'),
+        'namespaces': re.compile(r'^\s*(?P<content>namespace\s+[\w.*]+\s+[\w.]+)'),
+        'structs': re.compile(r'^\s*(?P<kind>struct|union|exception)\s+(?P<name>\w+)'),
+        'enums': re.compile(r'^\s*(?:enum|senum)\s+(?P<name>\w+)'),
+        'services': re.compile(r'^\s*service\s+(?P<name>\w+)(?:\s+extends\s+(?P<extends>[\w.]+))?'),
+        'typedefs': re.compile(r'^\s*typedef\s+(?P<type>' + _TYPE + r')\s+(?P<name>\w+)'),
+        'constants': re.compile(r'^\s*const\s+(?P<type>' + _TYPE + r')\s+(?P<name>\w+)\s*=\s*(?P<value>.*?)[,;]?\s*$'),
+        'methods': re.compile(r'^\s*(?P<oneway>oneway\s+)?(?P<returns>' + _TYPE + r')\s+(?P<name>\w+)\s*\('),
+    }
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; methods are only taken from service bodies."""
+        self._depths = self._line_depths()
+        return super().get_structure(head=head, tail=tail, range=range, **kwargs)
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Attach fields, enum values and method signatures."""
+        if category == 'methods':
+            service = self._enclosing_service(line_no)
+            if service is None:
+                return None
+            return self._method_entry(match, line_no, service)
+
+        if category in ('typedefs', 'constants'):
+            entry = {'line': line_no, 'name': match.group('name'), 'type': ' '.join(match.group('type').split())}
+            entry['signature'] = f": {entry['type']}"
+            if category == 'constants':
+                entry['value'] = match.group('value')
+                entry['signature'] += f" = {entry['value']}"
+            return entry
+
+        entry = super()._make_entry(category, match, line_no)
+        line_end = entry.get('line_end', line_no)
+
+        if category == 'structs':
+            fields = self._body_items(line_no, line_end, _FIELD)
+            entry['fields'] = [{
+                'name': f['name'], 'id': int(f['number']), 'type': ' '.join(f['type'].split()),
+                **({'label': f['label']} if f.get('label') else {}),
+            } for f in fields]
+            signature = self._inline(f"{f['name']}={f['id']}" for f in entry['fields'])
+            if entry['kind'] != 'struct':
+                signature += f"  ({entry['kind']})"
+            entry['signature'] = signature
+        elif category == 'enums':
+            values = self._body_items(line_no, line_end, _ENUM_VALUE)
+            entry['values'] = [{'name': v['name'], **({'number': v['number']} if 'number' in v else {})}
+                               for v in values]
+            entry['signature'] = self._inline(
+                f"{v['name']}={v['number']}" if 'number' in v else v['name'] for v in entry['values']
+            )
+        elif category == 'services' and entry.get('extends'):
+            entry['signature'] = f" extends {entry['extends']}"
+
+        return entry
+
+    def _method_entry(self, match, line_no: int, service: str) -> Dict[str, Any]:
+        """`oneway? Ret name(1: T a, ...) throws (1: E e)`, possibly spanning lines."""
+        first = self.lines[line_no - 1]
+        text = ' '.join(line.strip() for line in self.lines[line_no - 1:line_no + 20])
+        start = text.index('(', match.end('name') - (len(first) - len(first.lstrip())))
+        args, end = self._parenthesized(text, start)
+        entry = {
+            'line': line_no,
+            'name': f"{service}.{match.group('name')}",
+            'service': service,
+            'returns': ' '.join(match.group('returns').split()),
+        }
+        params = [self._param(p) for p in self._split(args)]
+        signature = f"({', '.join(params)}) -> {entry['returns']}"
+
+        throws = re.match(r'\s*throws\s*\(', text[end:])
+        if throws:
+            thrown, _ = self._parenthesized(text, end + throws.end() - 1)
+            entry['throws'] = [self._param(t).split()[0] for t in self._split(thrown)]
+            signature += f" throws {', '.join(entry['throws'])}"
+        if match.group('oneway'):
+            entry['oneway'] = True
+            signature += '  (oneway)'
+        entry['signature'] = signature
+
+        line_end = self._method_end(line_no)
+        if line_end > line_no:
+            entry['line_end'] = line_end
+        return entry
+
+    def _method_end(self, line_no: int) -> int:
+        """Last line of a method: its parentheses balanced and no throws clause following."""
+        depth = 0
+        opened = False
+        for i in range(line_no - 1, min(len(self.lines), line_no + 20)):
+            code = self._strip_strings(self.lines[i])
+            depth += code.count('(') - code.count(')')
+            opened = opened or '(' in code
+            if opened and depth <= 0:
+                following = self.lines[i + 1].strip() if i + 1 < len(self.lines) else ''
+                if not following.startswith('throws'):
+                    return i + 1
+        return line_no
+
+    @staticmethod
+    def _parenthesized(text: str, start: int):
+        """(contents, index after the ')') of the parenthesis opening at start."""
+        depth = 0
+        for i in range(start, len(text)):
+            if text[i] == '(':
+                depth += 1
+            elif text[i] == ')':
+                depth -= 1
+                if depth == 0:
+                    return text[start + 1:i], i + 1
+        return text[start + 1:], len(text)
+
+    @staticmethod
+    def _split(args: str) -> List[str]:
+        """Split a field list on top-level commas/semicolons."""
+        parts, depth, current = [], 0, ''
+        for char in args:
+            if char in '<([{':
+                depth += 1
+            elif char in '>)]}':
+                depth -= 1
+            if char in ',;' and depth == 0:
+                parts.append(current.strip())
+                current = ''
+                continue
+            current += char
+        parts.append(current.strip())
+        return [p for p in parts if p]
+
+    @staticmethod
+    def _param(field: str) -> str:
+        """'1: required i64 id = 0' -> 'i64 id'."""
+        field = re.sub(r'^\s*-?\d+\s*:\s*', '', field)
+        field = re.sub(r'^(?:required|optional)\s+', '', field)
+        return ' '.join(field.split('=', 1)[0].split())
+
+    def _enclosing_service(self, line_no: int) -> Optional[str]:
+        """Name of the service whose body directly contains line_no."""
+        if self._depths[line_no - 1] != 1:
+            return None
+        for i in range(line_no - 2, -1, -1):
+            if self._depths[i] == 0:
+                opener = self.patterns['services'].match(self.lines[i])
+                return opener.group('name') if opener else None
+        return None
+
+    def _inline(self, items) -> str:
+        """Format ' { a=1, b=2, ... }' for display."""
+        items = list(items)
+        if not items:
+            return ' {}'
+        shown = ', '.join(items[:self.MAX_INLINE_FIELDS])
+        if len(items) > self.MAX_INLINE_FIELDS:
+            shown += f", ... +{len(items) - self.MAX_INLINE_FIELDS}"
+        return f" {{ {shown} }}"
+
+    def _body_items(self, start: int, end: int, pattern) -> List[Dict[str, str]]:
+        """Match pattern against the lines directly inside a block."""
+        items = []
+        for i in range(start, end - 1):
+            line = self.lines[i]
+            if self._depths[i] != self._depths[start - 1] + 1 or self._is_comment(line):
+                continue
+            for part in self._split(self._strip_comments(line)):
+                match = pattern.match(part)
+                if match:
+                    items.append({k: v for k, v in match.groupdict().items() if v is not None})
+        if end == start:
+            # One-line block: struct Point { 1: i32 x, 2: i32 y }
+            body = re.search(r'\{(.*)\}', self._strip_comments(self.lines[start - 1]))
+            for part in self._split(body.group(1)) if body else []:
+                match = pattern.match(part)
+                if match:
+                    items.append({k: v for k, v in match.groupdict().items() if v is not None})
+        return items
+
+    def _strip_comments(self, line: str) -> str:
+        line = re.sub(r'/\*.*?\*/', '', line)
+        return re.split(r'//|#', line, 1)[0]
+
+    def _strip_strings(self, line: str) -> str:
+        """Remove strings and comments so braces inside them don't count."""
+        line = re.sub(r'"(?:\\.|[^"\\])*"', '""', line)
+        return re.split(r'//|#', line, 1)[0]
@@ -1,7 +1,7 @@
 """TOML file analyzer."""
 
 import re
-from typing import Dict, List, Any, Optional
+from typing import Dict, List, Any, Optional, Tuple
 from ..base import FileAnalyzer, register
 
 
@@ -9,56 +9,159 @@ from ..base import FileAnalyzer, register
 class TomlAnalyzer(FileAnalyzer):
     """TOML file analyzer.
 
-    Extracts sections ([section]) and key-value pairs.
+    Extracts the section hierarchy ([table] and [[array]] headers,
+    indented by dotted depth) with each section's key names, and
+    top-level keys with their values. Values of keys that look like
+    secrets (password, token, api_key, ...) are always shown as ***;
+    redact=True (--redact) hides every value.
     """
 
-    def get_structure(self) -> Dict[str, List[Dict[str, Any]]]:
-        """Extract TOML sections and top-level keys."""
+    SENSITIVE_KEY = re.compile(
+        r'passw(?:or)?d|passwd|secret|token|api[_-]?key|private[_-]?key|access[_-]?key|credential|auth(?!or)',
+        re.IGNORECASE
+    )
+    REDACTED = '***'
+    # Values longer than this are cut short
+    MAX_VALUE_LENGTH = 60
+    # Section key names shown inline before eliding
+    MAX_INLINE_KEYS = 6
+
+    SECTION = re.compile(r'^\[(?P<array>\[)?\s*(?P<name>[^\[\]]+?)\s*\]\]?\s*(?:#.*)?$')
+    KEY = re.compile(
+        r'''^(?P<name>(?:[\w-]+|"[^"]*"|'[^']*')(?:\s*\.\s*(?:[\w-]+|"[^"]*"|'[^']*'))*)\s*=\s*(?P<value>.*)$'''
+    )
+
+    def get_structure(self, head: int = None, tail: int = None, range: tuple = None,
+                      redact: bool = False, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract the section hierarchy and top-level keys."""
+        sections, keys = self._parse()
+
+        for item in keys + [k for s in sections for k in s['keys']]:
+            item['value'] = self._display_value(item['name'], item['value'], redact)
+        for key in keys:
+            key['signature'] = f" = {key['value']}"
+        for section in sections:
+            names = [k['name'] for k in section['keys']]
+            signature = ''
+            if names:
+                shown = names[:self.MAX_INLINE_KEYS]
+                more = len(names) - len(shown)
+                signature = f" {{{', '.join(shown)}{f', ... +{more}' if more else ''}}}"
+            if section.get('kind') == 'array':
+                signature += '  (array)'
+            section['signature'] = signature
+
+        if head or tail or range:
+            sections = self._apply_semantic_slice(sections, head, tail, range)
+            keys = self._apply_semantic_slice(keys, head, tail, range)
+
+        result = {}
+        if sections:
+            result['sections'] = sections
+        if keys:
+            result['keys'] = keys
+
+        return result
+
+    def _parse(self) -> Tuple[List[Dict[str, Any]], List[Dict[str, Any]]]:
+        """Sections (with their keys) and top-level keys, raw values."""
         sections = []
         keys = []
-
-        current_section = None
+        closing = None      # delimiter ending a multi-line string value
+        depth = 0           # open brackets of a multi-line array/table value
+        entry = None
 
         for i, line in enumerate(self.lines, 1):
             stripped = line.strip()
 
+            if closing or depth > 0:
+                if closing and closing in stripped:
+                    closing = None
+                elif depth > 0:
+                    depth += self._bracket_balance(stripped)
+                if not closing and depth <= 0:
+                    entry['line_end'] = i
+                continue
+
             # Skip empty lines and comments
             if not stripped or stripped.startswith('#'):
                 continue
 
             # Section header: [section] or [[array]]
-            section_match = re.match(r'^\[+([^\]]+)\]+', stripped)
+            section_match = self.SECTION.match(stripped)
             if section_match:
-                section_name = section_match.group(1).strip()
-                sections.append({
-                    'line': i,
-                    'name': section_name,
-                })
-                current_section = section_name
+                name = section_match.group('name')
+                section = {'line': i, 'name': name, 'keys': []}
+                if section_match.group('array'):
+                    section['kind'] = 'array'
+                sections.append(section)
                 continue
 
-            # Key-value pair
-            key_match = re.match(r'^([a-zA-Z_][a-zA-Z0-9_-]*)\s*=', stripped)
+            key_match = self.KEY.match(stripped)
             if key_match:
-                key_name = key_match.group(1)
-
-                # Only include top-level keys (no section)
-                if current_section is None:
-                    keys.append({
-                        'line': i,
-                        'name': key_name,
-                    })
-
-        result = {}
-        if sections:
-            result['sections'] = sections
-        if keys:
-            result['keys'] = keys
-
-        return result
+                value = self._strip_comment(key_match.group('value'))
+                for delimiter in ('"""', "'''"):
+                    if value.startswith(delimiter) and value.count(delimiter) == 1:
+                        closing = delimiter
+                        value = f"{delimiter}...{delimiter}"
+                depth = self._bracket_balance(value) if not closing else 0
+                if depth > 0:
+                    value = f"{value[0]}...{']' if value[0] == '[' else '}'}"
+
+                entry = {'line': i, 'name': '.'.join(self._split_dotted(key_match.group('name'))), 'value': value}
+                (sections[-1]['keys'] if sections else keys).append(entry)
+
+        self._set_levels(sections)
+        self._set_section_ends(sections)
+        return sections, keys
+
+    def _set_levels(self, sections: List[Dict[str, Any]]):
+        """Nest sections under dotted prefixes that are sections too; [tool.x] without [tool] stays top level."""
+        names = {tuple(self._split_dotted(s['name'])) for s in sections}
+        for section in sections:
+            parts = tuple(self._split_dotted(section['name']))
+            section['level'] = 1 + sum(1 for n in range(1, len(parts)) if parts[:n] in names)
+
+    def _set_section_ends(self, sections: List[Dict[str, Any]]):
+        """A section runs until the next header, trailing blanks and comments excluded."""
+        for index, section in enumerate(sections):
+            end = sections[index + 1]['line'] - 1 if index + 1 < len(sections) else len(self.lines)
+            while end > section['line'] and (not self.lines[end - 1].strip()
+                                             or self.lines[end - 1].strip()[0] in '#;'):
+                end -= 1
+            if end > section['line']:
+                section['line_end'] = end
+
+    @staticmethod
+    def _split_dotted(name: str) -> List[str]:
+        """'tool."pytest.ini".x' -> ['tool', 'pytest.ini', 'x']."""
+        parts = re.findall(r'''"([^"]*)"|'([^']*)'|([^.\s"']+)''', name)
+        return [next(p for p in part if p) if any(part) else '' for part in parts]
+
+    @staticmethod
+    def _mask_strings(text: str) -> str:
+        return re.sub(r'''"(?:\\.|[^"\\])*"|'[^']*\'''', lambda m: '"' * len(m.group()), text)
+
+    def _strip_comment(self, value: str) -> str:
+        """Remove a trailing # comment outside strings."""
+        position = self._mask_strings(value).find('#')
+        return (value[:position] if position >= 0 else value).strip()
+
+    def _bracket_balance(self, text: str) -> int:
+        """Opened minus closed brackets, ignoring strings and comments."""
+        masked = self._mask_strings(text).split('#', 1)[0]
+        return masked.count('[') + masked.count('{') - masked.count(']') - masked.count('}')
+
+    def _display_value(self, name: str, value: str, redact: bool) -> str:
+        """Value as shown: secrets (or everything, when redacting) become ***; long values are cut."""
+        if redact or self.SENSITIVE_KEY.search(name):
+            return self.REDACTED
+        if len(value) > self.MAX_VALUE_LENGTH:
+            return value[:self.MAX_VALUE_LENGTH - 3] + '...'
+        return value
 
     def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
-        """Extract a TOML section or key.
+        """Extract a section (by name) or a key (by name or section.key).
 
         Args:
             element_type: 'section' or 'key'
@@ -67,29 +170,19 @@ class TomlAnalyzer(FileAnalyzer):
         Returns:
             Dict with section/key content
         """
-        # Try to find as section first
-        for i, line in enumerate(self.lines, 1):
-            stripped = line.strip()
-
-            # Match section header
-            section_match = re.match(r'^\[+([^\]]+)\]+', stripped)
-            if section_match and section_match.group(1).strip() == name:
-                start_line = i
-
-                # Find end of section (next section or EOF)
-                end_line = len(self.lines) + 1
-                for j in range(i, len(self.lines)):
-                    if re.match(r'^\[+[^\]]+\]+', self.lines[j].strip()):
-                        end_line = j + 1
-                        break
-
-                source = '\n'.join(self.lines[start_line-1:end_line-1])
-
+        sections, keys = self._parse()
+
+        candidates = [(s['name'], s) for s in sections]
+        candidates += [(k['name'], k) for k in keys]
+        candidates += [(f"{s['name']}.{k['name']}", k) for s in sections for k in s['keys']]
+        for candidate, item in candidates:
+            if candidate == name:
+                line_end = item.get('line_end', item['line'])
                 return {
                     'name': name,
-                    'line_start': start_line,
-                    'line_end': end_line - 1,
-                    'source': source,
+                    'line_start': item['line'],
+                    'line_end': line_end,
+                    'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
                 }
 
         # Fall back to grep-based search
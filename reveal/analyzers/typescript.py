@@ -1,24 +1,197 @@
 """TypeScript file analyzer - tree-sitter based."""
 
+from typing import Dict, List, Any, Optional
 from ..base import register
 from ..treesitter import TreeSitterAnalyzer
 
 
-@register('.ts', name='TypeScript', icon='')
-@register('.tsx', name='TypeScript React', icon='')
+@register('.ts', '.mts', '.cts', name='TypeScript', icon='')
 class TypeScriptAnalyzer(TreeSitterAnalyzer):
     """TypeScript file analyzer.
 
     Full TypeScript support via tree-sitter!
     Extracts:
-    - Import/export statements (ES6 modules)
-    - Function declarations
-    - Class definitions
-    - Interfaces
-    - Type definitions
-    - Arrow functions
-
-    Supports both .ts and .tsx (React) files.
+    - Import statements (ES6 modules)
+    - Function declarations and top-level arrow functions
+    - Class definitions (including abstract classes)
+    - Interfaces, type aliases and enums
+    - Exported declarations
+
     Works on all platforms (Windows, Linux, macOS).
     """
     language = 'typescript'
+
+    # Node types for top-level function-valued declarations
+    FUNCTION_VALUE_TYPES = ('arrow_function', 'function', 'function_expression')
+
+    def _extract_functions(self) -> List[Dict[str, Any]]:
+        """Extract function declarations plus `const name = () => ...` functions."""
+        functions = super()._extract_functions()
+
+        for declarator, value in self._function_declarators():
+            line_start = declarator.start_point[0] + 1
+            line_end = declarator.end_point[0] + 1
+            functions.append({
+                'line': line_start,
+                'line_end': line_end,
+                'name': self._get_node_name(declarator),
+                'signature': self._get_signature(value),
+                'line_count': line_end - line_start + 1,
+                'depth': self._get_nesting_depth(value),
+            })
+
+        return sorted(functions, key=lambda f: f['line'])
+
+    def _extract_classes(self) -> List[Dict[str, Any]]:
+        """Extract classes, including abstract classes."""
+        classes = super()._extract_classes()
+        classes.extend(self._extract_definitions(['abstract_class_declaration']))
+        return sorted(classes, key=lambda c: c['line'])
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract interfaces, type aliases, enums and exports."""
+        return {
+            'interfaces': self._extract_definitions(['interface_declaration']),
+            'types': self._extract_definitions(['type_alias_declaration']),
+            'enums': self._extract_definitions(['enum_declaration']),
+            'exports': self._extract_exports(),
+        }
+
+    def _function_declarators(self):
+        """Yield (declarator, value) pairs for module-level function constants.
+
+        Only declarations directly in the module (or behind `export`) count,
+        so callbacks and handlers nested inside functions aren't listed.
+        """
+        for declarator in self._find_nodes_by_type('variable_declarator'):
+            value = declarator.child_by_field_name('value')
+            if value is None or value.type not in self.FUNCTION_VALUE_TYPES:
+                continue
+
+            declaration = declarator.parent
+            scope = declaration.parent if declaration is not None else None
+            if scope is None or scope.type not in ('program', 'export_statement'):
+                continue
+
+            yield declarator, value
+
+    def _extract_exports(self) -> List[Dict[str, Any]]:
+        """Extract exported declarations (export function/class/const/...)."""
+        exports = []
+
+        for node in self._find_nodes_by_type('export_statement'):
+            declaration = node.child_by_field_name('declaration')
+            if declaration is None:
+                continue
+
+            is_default = any(child.type == 'default' for child in node.children)
+            for name in self._declared_names(declaration):
+                exports.append({
+                    'line': node.start_point[0] + 1,
+                    'name': name,
+                    'kind': self._declaration_kind(declaration),
+                    'default': is_default,
+                })
+
+        return exports
+
+    def _declared_names(self, declaration) -> List[str]:
+        """Names introduced by a declaration node."""
+        if declaration.type in ('lexical_declaration', 'variable_declaration'):
+            names = []
+            for child in declaration.children:
+                if child.type == 'variable_declarator':
+                    name = self._get_node_name(child)
+                    if name:
+                        names.append(name)
+            return names
+
+        name = self._get_node_name(declaration)
+        return [name] if name else []
+
+    @staticmethod
+    def _declaration_kind(declaration) -> str:
+        """Human-readable kind for an exported declaration."""
+        kinds = {
+            'function_declaration': 'function',
+            'generator_function_declaration': 'function',
+            'class_declaration': 'class',
+            'abstract_class_declaration': 'class',
+            'interface_declaration': 'interface',
+            'type_alias_declaration': 'type',
+            'enum_declaration': 'enum',
+            'lexical_declaration': 'const',
+            'variable_declaration': 'var',
+        }
+        return kinds.get(declaration.type, declaration.type)
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Make interfaces, type aliases and enums extractable by name."""
+        type_map = super()._get_element_type_map()
+        type_map['class'] = type_map['class'] + ['abstract_class_declaration']
+        type_map['interface'] = ['interface_declaration']
+        type_map['type'] = ['type_alias_declaration']
+        type_map['enum'] = ['enum_declaration']
+        return type_map
+
+
+@register('.tsx', name='TypeScript React', icon='')
+class TSXAnalyzer(TypeScriptAnalyzer):
+    """TypeScript React (.tsx) analyzer.
+
+    Uses the tsx grammar (the plain typescript grammar cannot parse JSX)
+    and additionally lists React components: capitalized functions or
+    arrow functions that render JSX, and classes extending Component.
+    """
+    language = 'tsx'
+
+    JSX_TYPES = ('jsx_element', 'jsx_self_closing_element', 'jsx_fragment')
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Add React components to the TypeScript categories."""
+        result = super()._extract_language_specific()
+        result['components'] = self._extract_components()
+        return result
+
+    def _extract_components(self) -> List[Dict[str, Any]]:
+        """Extract React function and class components."""
+        components = []
+
+        candidates = [(node, node) for node in self._find_nodes_by_type('function_declaration')]
+        candidates.extend(self._function_declarators())
+
+        for named, body in candidates:
+            name = self._get_node_name(named)
+            if name and name[0].isupper() and self._renders_jsx(body):
+                components.append({
+                    'line': named.start_point[0] + 1,
+                    'line_end': named.end_point[0] + 1,
+                    'name': name,
+                    'kind': 'function',
+                })
+
+        for node in self._find_nodes_by_type('class_declaration'):
+            name = self._get_node_name(node)
+            heritage = self._get_class_heritage(node)
+            if name and heritage and 'Component' in heritage:
+                components.append({
+                    'line': node.start_point[0] + 1,
+                    'line_end': node.end_point[0] + 1,
+                    'name': name,
+                    'kind': 'class',
+                })
+
+        return sorted(components, key=lambda c: c['line'])
+
+    def _renders_jsx(self, node) -> bool:
+        """Check whether a function body contains JSX."""
+        if node.type in self.JSX_TYPES:
+            return True
+        return any(self._renders_jsx(child) for child in node.children)
+
+    def _get_class_heritage(self, node) -> Optional[str]:
+        """Get the `extends ...` clause text of a class, if any."""
+        for child in node.children:
+            if child.type == 'class_heritage':
+                return self._get_node_text(child)
+        return None
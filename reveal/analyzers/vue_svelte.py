@@ -0,0 +1,351 @@
+"""Vue and Svelte single-file component analyzers."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+class ComponentAnalyzer(FileAnalyzer):
+    """Base for single-file components (.vue, .svelte).
+
+    Splits the file into its top-level <template>/<script>/<style>
+    sections, then extracts props, emitted events and functions from the
+    script blocks. Subclasses supply the framework-specific patterns.
+    """
+
+    SECTION_OPEN = re.compile(r'^<(?P<tag>template|script|style)\b(?P<attrs>[^>]*)>')
+    ATTRIBUTE = re.compile(r'([\w:-]+)(?:\s*=\s*"([^"]*)")?')
+
+    FUNCTION = re.compile(
+        r'^(?P<export>export\s+)?(?:async\s+)?function\s*\*?\s*(?P<name>\w+)\s*(?P<signature>\([^)]*\))'
+        r'|^(?P<export2>export\s+)?(?:const|let)\s+(?P<name2>\w+)\s*=\s*(?:async\s+)?'
+        r'(?P<signature2>\([^)]*\)|\w+)\s*(?::\s*[^=]+)?=>'
+    )
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract sections, props, emitted events and script functions."""
+        sections = self._extract_sections()
+        scripts = [s for s in sections if s['name'] == 'script']
+
+        structure = {
+            'sections': sections,
+            'props': self._extract_props(scripts),
+            'events': self._extract_events(scripts),
+            'functions': self._extract_functions(scripts),
+        }
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_sections(self) -> List[Dict[str, Any]]:
+        """Find top-level <template>, <script> and <style> blocks."""
+        sections = []
+        i = 0
+
+        while i < len(self.lines):
+            match = self.SECTION_OPEN.match(self.lines[i])
+            if not match:
+                i += 1
+                continue
+
+            tag = match.group('tag')
+            attrs = {k: v for k, v in self.ATTRIBUTE.findall(match.group('attrs'))}
+            end = i
+            closing = f'</{tag}>'
+            while end < len(self.lines) and not self._closes_section(self.lines[end], closing, tag):
+                end += 1
+            end = min(end, len(self.lines) - 1)
+
+            entry = {
+                'line': i + 1,
+                'line_end': end + 1,
+                'name': tag,
+                'line_count': end - i + 1,
+                'attributes': attrs,
+            }
+            if attrs:
+                shown = [f"{k}={v}" if v else k for k, v in attrs.items()]
+                entry['signature'] = f" ({', '.join(shown)})"
+            sections.append(entry)
+            i = end + 1
+
+        return sections
+
+    @staticmethod
+    def _closes_section(line: str, closing: str, tag: str) -> bool:
+        """Closing tag at column 0 (nested <template> tags are indented)."""
+        if tag == 'template':
+            return line.startswith(closing)
+        return closing in line
+
+    def _script_lines(self, scripts: List[Dict[str, Any]]):
+        """Yield (line_no, line, depth) for script block bodies; depth is brace nesting."""
+        for script in scripts:
+            depth = 0
+            start = script['line']
+            end = script['line_end']
+            for line_no in range(start, end + 1):
+                line = self.lines[line_no - 1]
+                if line_no == start:
+                    line = line.split('>', 1)[1] if '>' in line else ''
+                if line_no == end:
+                    line = line.split('</script>', 1)[0]
+                code = self._strip_strings(line)
+                yield line_no, line, depth
+                depth += code.count('{') + code.count('(') - code.count('}') - code.count(')')
+
+    @staticmethod
+    def _strip_strings(line: str) -> str:
+        """Remove string literals and // comments before counting braces."""
+        line = re.sub(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'|`(?:\\.|[^`\\])*`', '""', line)
+        return line.split('//', 1)[0]
+
+    def _extract_functions(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        """Top-level function declarations and arrow functions in script blocks."""
+        functions = []
+        exposed = self._exposed_names(scripts)
+
+        for line_no, line, depth in self._script_lines(scripts):
+            if depth != 0:
+                continue
+            match = self.FUNCTION.match(line.strip())
+            if not match:
+                continue
+
+            name = match.group('name') or match.group('name2')
+            signature = match.group('signature') or match.group('signature2')
+            if not signature.startswith('('):
+                signature = f"({signature})"
+            entry = {'line': line_no, 'name': name, 'signature': signature}
+            if match.group('export') or match.group('export2') or name in exposed:
+                entry['visibility'] = 'exported'
+            functions.append(entry)
+
+        return functions
+
+    def _exposed_names(self, scripts: List[Dict[str, Any]]) -> set:
+        """Names the component exposes to parents (beyond `export`)."""
+        return set()
+
+    def _extract_props(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        return []
+
+    def _extract_events(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        return []
+
+    def _script_text(self, scripts: List[Dict[str, Any]]) -> str:
+        """Script block bodies joined, with original line positions kept."""
+        text = [''] * len(self.lines)
+        for line_no, line, _ in self._script_lines(scripts):
+            text[line_no - 1] = line
+        return '\n'.join(text)
+
+    def _events_from_calls(self, text: str, pattern: str, seen: set) -> List[Dict[str, Any]]:
+        """Events named in emit/dispatch calls: emit('change', ...)."""
+        events = []
+        for match in re.finditer(pattern, text):
+            name = match.group('name')
+            if name not in seen:
+                seen.add(name)
+                events.append({'line': text.count('\n', 0, match.start()) + 1, 'name': name})
+        return events
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a section (template/script/style) or a script function."""
+        structure = self.get_structure()
+
+        for section in structure.get('sections', []):
+            if section['name'] == name:
+                return {
+                    'name': name,
+                    'line_start': section['line'],
+                    'line_end': section['line_end'],
+                    'source': '\n'.join(self.lines[section['line'] - 1:section['line_end']]),
+                }
+
+        for function in structure.get('functions', []):
+            if function['name'] == name:
+                line_end = self._function_end(function['line'])
+                return {
+                    'name': name,
+                    'line_start': function['line'],
+                    'line_end': line_end,
+                    'source': '\n'.join(self.lines[function['line'] - 1:line_end]),
+                }
+
+        return super().extract_element(element_type, name)
+
+    def _function_end(self, line_no: int) -> int:
+        """Line where the braces opened on a function's first line balance."""
+        depth = 0
+        opened = False
+
+        for i in range(line_no - 1, len(self.lines)):
+            code = self._strip_strings(self.lines[i])
+            depth += code.count('{') - code.count('}')
+            opened = opened or '{' in code
+            if opened and depth <= 0:
+                return i + 1
+            if not opened and i > line_no - 1:
+                return i
+        return line_no
+
+
+@register('.vue', name='Vue', icon='')
+class VueAnalyzer(ComponentAnalyzer):
+    """Vue single-file component analyzer.
+
+    Props and events come from <script setup> macros (defineProps,
+    defineEmits, including their TypeScript forms) or the Options API
+    (props:, emits:), plus $emit()/emit() calls. Functions passed to
+    defineExpose() are marked exported.
+    """
+
+    def _extract_props(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        text = self._script_text(scripts)
+        props = []
+
+        for match in re.finditer(r'defineProps\s*(?:<\s*\{|\(\s*[\[{])|\bprops\s*:\s*[\[{]', text):
+            body, start = self._balanced(text, match.end() - 1)
+            line = text.count('\n', 0, match.start()) + 1
+            for name, prop_type, offset in self._members(body):
+                entry = {'line': text.count('\n', 0, start + offset) + 1 if offset else line,
+                         'name': name}
+                if prop_type:
+                    entry['signature'] = f": {prop_type}"
+                props.append(entry)
+
+        return props
+
+    def _extract_events(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        text = self._script_text(scripts)
+        events = []
+        seen = set()
+
+        for match in re.finditer(r'defineEmits\s*(?:<\s*\{|\(\s*[\[{])|\bemits\s*:\s*[\[{]', text):
+            body, start = self._balanced(text, match.end() - 1)
+            if re.search(r'\(\s*e\s*:', body):
+                # TypeScript call signatures: (e: 'change', id: number): void
+                pattern = r"\(\s*e\s*:\s*['\"](?P<name>[\w:-]+)['\"]"
+            elif body.lstrip().startswith(('[', "'", '"')) or match.group(0).endswith('['):
+                pattern = r"['\"](?P<name>[\w:-]+)['\"]"
+            else:
+                # Object form: { change: (id) => true } or Vue 3.3 { change: [id: number] }
+                pattern = r"(?:^|[,{\n])\s*['\"]?(?P<name>[\w:-]+)['\"]?\s*:"
+            for event in re.finditer(pattern, body):
+                name = event.group('name')
+                if name not in seen:
+                    seen.add(name)
+                    events.append({'line': text.count('\n', 0, start + event.start('name')) + 1,
+                                   'name': name})
+
+        events += self._events_from_calls(text, r"\b\$?emit\(\s*['\"](?P<name>[\w:-]+)['\"]", seen)
+
+        # $emit() in the template
+        for section in self._extract_sections():
+            if section['name'] != 'template':
+                continue
+            template = '\n'.join([''] * (section['line'] - 1) +
+                                 self.lines[section['line'] - 1:section['line_end']])
+            events += self._events_from_calls(template, r"\$emit\(\s*['\"](?P<name>[\w:-]+)['\"]", seen)
+
+        return sorted(events, key=lambda e: e['line'])
+
+    def _exposed_names(self, scripts: List[Dict[str, Any]]) -> set:
+        text = self._script_text(scripts)
+        names = set()
+        for match in re.finditer(r'defineExpose\s*\(\s*\{', text):
+            body, _ = self._balanced(text, match.end() - 1)
+            names.update(re.findall(r'\b(\w+)\b(?=\s*[,}:]|\s*$)', body))
+        return names
+
+    @staticmethod
+    def _balanced(text: str, open_index: int):
+        """Body between a bracket at open_index and its match: (body, body start)."""
+        pairs = {'{': '}', '[': ']', '(': ')', '<': '>'}
+        opener = text[open_index]
+        if opener not in pairs:
+            return '', open_index
+        depth = 0
+        for i in range(open_index, len(text)):
+            if text[i] in '{[(<':
+                depth += 1
+            elif text[i] in '}])>':
+                depth -= 1
+                if depth == 0:
+                    return text[open_index + 1:i], open_index + 1
+        return text[open_index + 1:], open_index + 1
+
+    @staticmethod
+    def _members(body: str):
+        """Yield (name, type, offset) for direct members of a props object or list."""
+        if body.lstrip().startswith(("'", '"')) or '{' not in body and ':' not in body:
+            for match in re.finditer(r"['\"]([\w-]+)['\"]", body):
+                yield match.group(1), None, match.start()
+            return
+
+        depth = 0
+        i = 0
+        while i < len(body):
+            if depth == 0:
+                match = re.match(r"\s*['\"]?([\w-]+)['\"]?(\??)\s*:\s*([^,;\n{]*)", body[i:])
+                if match and (i == 0 or body[i - 1] in ',;{\n'):
+                    prop_type = match.group(3).strip().rstrip(',;') or None
+                    if prop_type is None and body[i + match.end():i + match.end() + 1] == '{':
+                        inner = re.search(r'\btype\s*:\s*([\w\[\], ]+?)\s*[,}\n]', body[i + match.end():])
+                        prop_type = inner.group(1) if inner else None
+                    yield match.group(1) + match.group(2), prop_type, i + match.start(1)
+                    i += match.end()
+                    continue
+            char = body[i]
+            if char in '{[(<':
+                depth += 1
+            elif char in '}])>':
+                depth -= 1
+            i += 1
+
+
+@register('.svelte', name='Svelte', icon='')
+class SvelteAnalyzer(ComponentAnalyzer):
+    """Svelte component analyzer.
+
+    Props are `export let` declarations (or Svelte 5 `$props()`
+    destructuring); events come from createEventDispatcher() dispatch
+    calls; `export function`/`export const` are the component's exported
+    functions.
+    """
+
+    def _extract_props(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        props = []
+
+        for line_no, line, depth in self._script_lines(scripts):
+            if depth != 0:
+                continue
+            match = re.match(r'^\s*export\s+let\s+(?P<name>\w+)\s*(?::\s*(?P<type>[^=;]+?))?\s*(?:=\s*(?P<default>[^;]+?))?;?\s*$', line)
+            if match:
+                entry = {'line': line_no, 'name': match.group('name')}
+                if match.group('type'):
+                    entry['signature'] = f": {match.group('type')}"
+                elif match.group('default'):
+                    entry['signature'] = f" = {match.group('default')}"
+                props.append(entry)
+                continue
+
+            runes = re.match(r'^\s*let\s*\{(?P<names>[^}]*)\}\s*(?::\s*\w+\s*)?=\s*\$props\(\)', line)
+            if runes:
+                for name in runes.group('names').split(','):
+                    name = name.split('=')[0].split(':')[0].strip()
+                    if name and not name.startswith('...'):
+                        props.append({'line': line_no, 'name': name})
+
+        return props
+
+    def _extract_events(self, scripts: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        text = self._script_text(scripts)
+        return self._events_from_calls(text, r"\bdispatch\(\s*['\"](?P<name>[\w:-]+)['\"]", set())
@@ -0,0 +1,121 @@
+"""XML document analyzer."""
+
+from xml.parsers import expat
+from typing import Dict, List, Any, Optional
+from ..base import FileAnalyzer, register
+
+
+@register('.xml', '.xsd', '.xsl', '.xslt', '.wsdl', '.plist', '.xaml', '.svg', '.csproj', '.vbproj',
+          '.fsproj', '.props', '.targets', '.nuspec', '.resx', name='XML', icon='')
+class XmlAnalyzer(FileAnalyzer):
+    """XML document analyzer.
+
+    Shows the element tree down to a configurable depth (default 3; the
+    CLI passes --depth). Each element lists its attribute count and an
+    identifying attribute (id, name, android:name, Include) when present;
+    leaf elements show short text content, and elements at the depth
+    limit show how many children were cut off. Works for pom.xml,
+    AndroidManifest.xml, MSBuild projects and other config-heavy XML.
+    """
+
+    DEFAULT_DEPTH = 3
+    # Attributes that identify an element, shown inline
+    IDENTIFYING_ATTRIBUTES = ('id', 'name', 'android:name', 'key', 'Include', 'Name', 'Id')
+    # Leaf text longer than this is not shown
+    MAX_TEXT_LENGTH = 40
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, depth: int = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract the element tree down to depth levels."""
+        depth = depth or self.DEFAULT_DEPTH
+        elements = [e for e in self._element_tree() if e['level'] <= depth]
+
+        for element in elements:
+            signature = ''
+            if element.get('key'):
+                signature += f" {element['key']}"
+            if element['attributes']:
+                signature += f" [{element['attributes']} attr{'s' if element['attributes'] != 1 else ''}]"
+            if element.get('text'):
+                signature += f" = {element['text']}"
+            if element['children'] and element['level'] == depth:
+                signature += f"  (+{element['children']} child{'ren' if element['children'] != 1 else ''})"
+            element['signature'] = signature
+
+        if head or tail or range:
+            elements = self._apply_semantic_slice(elements, head, tail, range)
+
+        return {'elements': elements} if elements else {}
+
+    def _element_tree(self) -> List[Dict[str, Any]]:
+        """Every element in document order with level, extent and child count.
+
+        Malformed documents yield the elements parsed before the error.
+        """
+        elements = []
+        stack = []
+        parser = expat.ParserCreate()
+
+        def start(tag, attributes):
+            if stack:
+                stack[-1]['children'] += 1
+            element = {
+                'line': parser.CurrentLineNumber,
+                'name': tag,
+                'level': len(stack) + 1,
+                'path': '/'.join([s['name'] for s in stack] + [tag]),
+                'attributes': len(attributes),
+                'children': 0,
+                '_text': [],
+            }
+            key = next((a for a in self.IDENTIFYING_ATTRIBUTES if a in attributes), None)
+            if key:
+                element['key'] = f'{key}="{attributes[key]}"'
+            elements.append(element)
+            stack.append(element)
+
+        def end(tag):
+            element = stack.pop()
+            if parser.CurrentLineNumber > element['line']:
+                element['line_end'] = parser.CurrentLineNumber
+            text = ' '.join(''.join(element['_text']).split())
+            if text and not element['children'] and len(text) <= self.MAX_TEXT_LENGTH:
+                element['text'] = text
+
+        def characters(data):
+            if stack:
+                stack[-1]['_text'].append(data)
+
+        parser.StartElementHandler = start
+        parser.EndElementHandler = end
+        parser.CharacterDataHandler = characters
+        try:
+            parser.Parse(self.content, True)
+        except expat.ExpatError:
+            pass
+
+        for element in elements:
+            del element['_text']
+        return elements
+
+    def get_directory_summary(self) -> Optional[str]:
+        """Root element for directory trees: '<project> 42 elements'."""
+        elements = self._element_tree()
+        if not elements:
+            return None
+        return f"<{elements[0]['name']}> {len(elements)} elements"
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract the first element matching a tag, a path (a/b/c) or an identifying attribute value."""
+        for element in self._element_tree():
+            key_value = element['key'].split('=', 1)[1].strip('"') if element.get('key') else None
+            if name in (element['name'], key_value) or '/' in name and ('/' + element['path']).endswith('/' + name):
+                line_end = element.get('line_end', element['line'])
+                return {
+                    'name': element['name'],
+                    'line_start': element['line'],
+                    'line_end': line_end,
+                    'source': '\n'.join(self.lines[element['line'] - 1:line_end]),
+                }
+
+        return super().extract_element(element_type, name)
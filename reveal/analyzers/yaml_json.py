@@ -1,5 +1,6 @@
 """YAML and JSON file analyzers."""
 
+import bisect
 import json
 import re
 from typing import Dict, List, Any, Optional
@@ -11,10 +12,44 @@ class YamlAnalyzer(FileAnalyzer):
     """YAML file analyzer.
 
     Extracts top-level keys.
+
+    Kubernetes manifests (documents with apiVersion and kind) are
+    recognized automatically: each document in a (multi-document) file
+    is listed as Kind/name with namespace, replicas, images and ports.
+
+    Ansible content is recognized too: playbooks (a list of plays with
+    hosts) list plays, roles, tasks, handlers and play variables; role
+    task/handler files list their tasks, and vars/defaults/group_vars
+    files list variables.
     """
 
+    # Pod spec locations by workload kind (default: spec.template.spec)
+    POD_SPEC_PATHS = {
+        'Pod': ('spec',),
+        'CronJob': ('spec', 'jobTemplate', 'spec', 'template', 'spec'),
+    }
+
+    # Task keywords that are not the module being called
+    ANSIBLE_TASK_KEYWORDS = {
+        'name', 'when', 'loop', 'loop_control', 'register', 'notify', 'listen', 'tags', 'vars', 'args',
+        'become', 'become_user', 'become_method', 'become_flags', 'ignore_errors', 'ignore_unreachable',
+        'changed_when', 'failed_when', 'delegate_to', 'delegate_facts', 'run_once', 'until', 'retries',
+        'delay', 'environment', 'no_log', 'check_mode', 'diff', 'any_errors_fatal', 'timeout', 'throttle',
+        'connection', 'collections', 'module_defaults', 'debugger', 'async', 'poll', 'remote_user',
+        'block', 'rescue', 'always',
+    }
+    ANSIBLE_VARS_DIRS = ('vars', 'defaults', 'group_vars', 'host_vars')
+
     def get_structure(self) -> Dict[str, List[Dict[str, Any]]]:
-        """Extract YAML top-level keys."""
+        """Extract YAML top-level keys (or Kubernetes resources, or Ansible content)."""
+        manifests = self._extract_manifests()
+        if manifests:
+            return {'manifests': manifests}
+
+        ansible = self._extract_ansible()
+        if ansible:
+            return ansible
+
         keys = []
 
         for i, line in enumerate(self.lines, 1):
@@ -29,16 +64,363 @@ class YamlAnalyzer(FileAnalyzer):
 
         return {'keys': keys}
 
+    def get_directory_summary(self) -> Optional[str]:
+        """List Kubernetes resources (Kind/name) or Ansible plays/tasks in directory trees."""
+        names = [m['name'] for m in self._extract_manifests()]
+        if not names:
+            ansible = self._extract_ansible()
+            if ansible.get('plays'):
+                names = [p['name'] for p in ansible['plays']]
+            elif ansible:
+                return ', '.join(f"{len(v)} {k}" for k, v in ansible.items())
+        if not names:
+            return None
+        return ', '.join(names[:4]) + (f", ... +{len(names) - 4}" if len(names) > 4 else '')
+
+    def _split_documents(self) -> List[Dict[str, Any]]:
+        """Split a multi-document YAML file on '---' separators.
+
+        Returns:
+            List of dicts with 'line' (first content line), 'line_end' and 'text'
+        """
+        documents = []
+        start = 0
+
+        for i, line in enumerate(self.lines + ['---']):
+            if re.match(r'^---(\s|$)', line) or re.match(r'^\.\.\.\s*$', line):
+                body = self.lines[start:i]
+                content = [n for n, l in enumerate(body, start + 1)
+                           if l.strip() and not l.lstrip().startswith('#')]
+                if content:
+                    documents.append({
+                        'line': content[0],
+                        'line_end': content[-1],
+                        'text': '\n'.join(body),
+                    })
+                start = i + 1
+
+        return documents
+
+    def _extract_manifests(self) -> List[Dict[str, Any]]:
+        """Extract Kubernetes resources, one per document.
+
+        Returns an empty list unless at least one document looks like a
+        Kubernetes object (a mapping with apiVersion and kind).
+        """
+        if not re.search(r'^apiVersion\s*:', self.content, re.MULTILINE):
+            return []
+
+        try:
+            import yaml
+        except ImportError:
+            return []
+
+        manifests = []
+        for document in self._split_documents():
+            try:
+                data = yaml.safe_load(document['text'])
+            except yaml.YAMLError:
+                # Helm templates and the like aren't plain YAML
+                continue
+            if not isinstance(data, dict) or 'apiVersion' not in data or 'kind' not in data:
+                continue
+
+            manifests.append(self._manifest_entry(data, document))
+
+        return manifests
+
+    def _manifest_entry(self, data: Dict[str, Any], document: Dict[str, Any]) -> Dict[str, Any]:
+        """Summarize one Kubernetes object."""
+        kind = str(data['kind'])
+        metadata = data.get('metadata') or {}
+        spec = data.get('spec') or {}
+        name = metadata.get('name') or metadata.get('generateName') or '<unnamed>'
+
+        entry = {
+            'line': document['line'],
+            'line_end': document['line_end'],
+            'name': f"{kind}/{name}",
+            'kind': kind,
+            'api_version': str(data['apiVersion']),
+        }
+        details = []
+
+        if metadata.get('namespace'):
+            entry['namespace'] = metadata['namespace']
+            details.append(f"ns={metadata['namespace']}")
+
+        if isinstance(spec, dict):
+            if 'replicas' in spec:
+                entry['replicas'] = spec['replicas']
+                details.append(f"replicas={spec['replicas']}")
+
+            containers = self._pod_containers(kind, spec)
+            images = [c.get('image') for c in containers if c.get('image')]
+            ports = [str(p.get('containerPort')) for c in containers
+                     for p in (c.get('ports') or []) if isinstance(p, dict) and p.get('containerPort')]
+
+            if kind == 'Service':
+                if spec.get('type'):
+                    details.append(f"type={spec['type']}")
+                ports = [self._service_port(p) for p in (spec.get('ports') or []) if isinstance(p, dict)]
+
+            if kind == 'Ingress':
+                hosts = [r.get('host') for r in (spec.get('rules') or [])
+                         if isinstance(r, dict) and r.get('host')]
+                if hosts:
+                    entry['hosts'] = hosts
+                    details.append(f"hosts={','.join(hosts)}")
+
+            if images:
+                entry['images'] = images
+                details.append(f"image={','.join(images)}")
+            if ports:
+                entry['ports'] = ports
+                details.append(f"ports={','.join(ports)}")
+
+        if details:
+            entry['signature'] = f" ({', '.join(details)})"
+        return entry
+
+    def _pod_containers(self, kind: str, spec: Dict[str, Any]) -> List[Dict[str, Any]]:
+        """Containers of a workload's pod template."""
+        path = self.POD_SPEC_PATHS.get(kind, ('spec', 'template', 'spec'))
+        pod_spec = {'spec': spec}
+
+        for key in path:
+            if not isinstance(pod_spec, dict):
+                return []
+            pod_spec = pod_spec.get(key) or {}
+
+        if not isinstance(pod_spec, dict):
+            return []
+        containers = (pod_spec.get('initContainers') or []) + (pod_spec.get('containers') or [])
+        return [c for c in containers if isinstance(c, dict)]
+
+    @staticmethod
+    def _service_port(port: Dict[str, Any]) -> str:
+        """Format a Service port: '80->8080/TCP'."""
+        text = str(port.get('port', '?'))
+        if port.get('targetPort') and port.get('targetPort') != port.get('port'):
+            text += f"->{port['targetPort']}"
+        if port.get('protocol') and port['protocol'] != 'TCP':
+            text += f"/{port['protocol']}"
+        return text
+
+    def _extract_ansible(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract Ansible plays, roles, tasks, handlers and variables.
+
+        Returns an empty dict unless the file is a playbook (a list of
+        mappings with hosts or import_playbook), a task/handler list
+        under tasks/ or handlers/, or a variables file under vars/,
+        defaults/, group_vars/ or host_vars/.
+        """
+        try:
+            import yaml
+        except ImportError:
+            return {}
+
+        try:
+            root = yaml.compose(self.content, Loader=yaml.SafeLoader)
+        except yaml.YAMLError:
+            return {}
+
+        directories = [part.lower() for part in self.path.parts[:-1]]
+        parent = directories[-1] if directories else ''
+
+        if isinstance(root, yaml.SequenceNode):
+            items = [n for n in root.value if isinstance(n, yaml.MappingNode)]
+            if items and all({'hosts', 'import_playbook', 'ansible.builtin.import_playbook'} & set(self._node_keys(n))
+                             for n in items):
+                return self._ansible_playbook(items)
+            if items and parent in ('tasks', 'handlers'):
+                category = 'handlers' if parent == 'handlers' else 'tasks'
+                structure = {category: []}
+                for item in items:
+                    self._ansible_tasks(item, structure[category])
+                return {k: v for k, v in structure.items() if v}
+
+        if isinstance(root, yaml.MappingNode) and (
+                parent in self.ANSIBLE_VARS_DIRS or any(d in ('group_vars', 'host_vars') for d in directories)):
+            variables = self._ansible_variables(root)
+            return {'variables': variables} if variables else {}
+
+        return {}
+
+    def _ansible_playbook(self, plays) -> Dict[str, List[Dict[str, Any]]]:
+        """Plays of a playbook and everything they contain."""
+        import yaml
+
+        structure = {'imports': [], 'plays': [], 'roles': [], 'variables': [], 'tasks': [], 'handlers': []}
+
+        for play in plays:
+            fields = self._node_map(play)
+            imported = fields.get('import_playbook') or fields.get('ansible.builtin.import_playbook')
+            if imported is not None:
+                structure['imports'].append({
+                    'line': play.start_mark.line + 1,
+                    'content': f"import_playbook: {self._scalar(imported)}",
+                })
+                continue
+
+            name = self._scalar(fields.get('name')) or f"hosts: {self._scalar(fields.get('hosts'))}"
+            entry = {
+                'line': play.start_mark.line + 1,
+                'line_end': self._node_end(play),
+                'name': name,
+                'hosts': self._scalar(fields.get('hosts')),
+            }
+            details = [f"hosts={entry['hosts']}"]
+            if self._scalar(fields.get('become')) in ('true', 'yes', 'True', 'Yes'):
+                entry['become'] = True
+                details.append('become')
+            entry['signature'] = f" ({', '.join(details)})"
+            structure['plays'].append(entry)
+
+            if isinstance(fields.get('vars'), yaml.MappingNode):
+                for variable in self._ansible_variables(fields['vars']):
+                    variable['play'] = name
+                    structure['variables'].append(variable)
+
+            for role in self._sequence(fields.get('roles')):
+                role_fields = self._node_map(role)
+                role_name = self._scalar(role) or self._scalar(role_fields.get('role') or role_fields.get('name'))
+                if role_name:
+                    structure['roles'].append({'line': role.start_mark.line + 1, 'name': role_name, 'play': name})
+
+            for section in ('pre_tasks', 'tasks', 'post_tasks', 'handlers'):
+                target = structure['handlers' if section == 'handlers' else 'tasks']
+                for task in self._sequence(fields.get(section)):
+                    for entry in self._ansible_tasks(task, target):
+                        entry['play'] = name
+                        if section in ('pre_tasks', 'post_tasks'):
+                            entry['section'] = section
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _ansible_tasks(self, node, entries: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+        """Append a task (or a block's tasks) to entries; return what was added."""
+        import yaml
+
+        if not isinstance(node, yaml.MappingNode):
+            return []
+        fields = self._node_map(node)
+
+        if 'block' in fields:
+            added = []
+            for section in ('block', 'rescue', 'always'):
+                for task in self._sequence(fields.get(section)):
+                    added.extend(self._ansible_tasks(task, entries))
+            return added
+
+        module = next((k for k in fields if k not in self.ANSIBLE_TASK_KEYWORDS), None)
+        if module in ('action', 'local_action'):
+            module = (self._scalar(fields[module]) or module).split()[0]
+        name = self._scalar(fields.get('name')) or module or '<unnamed>'
+
+        entry = {'line': node.start_mark.line + 1, 'name': name}
+        line_end = self._node_end(node)
+        if line_end > entry['line']:
+            entry['line_end'] = line_end
+        details = []
+        if module:
+            entry['module'] = module
+            if name != module:
+                details.append(module)
+        notify = [self._scalar(n) for n in self._sequence(fields.get('notify'))] or \
+            ([self._scalar(fields['notify'])] if 'notify' in fields and self._scalar(fields['notify']) else [])
+        if notify:
+            entry['notify'] = notify
+            details.append(f"notify: {', '.join(notify)}")
+        if details:
+            entry['signature'] = f" ({', '.join(details)})"
+        entries.append(entry)
+        return [entry]
+
+    def _ansible_variables(self, node) -> List[Dict[str, Any]]:
+        """Variables (keys) of a mapping node, with scalar values shown."""
+        variables = []
+        for key, value in node.value:
+            entry = {'line': key.start_mark.line + 1, 'name': str(key.value)}
+            scalar = self._scalar(value)
+            if scalar is not None:
+                entry['signature'] = f" = {scalar}" if len(scalar) <= 60 else f" = {scalar[:57]}..."
+            variables.append(entry)
+        return variables
+
+    @staticmethod
+    def _node_keys(node) -> List[str]:
+        return [str(key.value) for key, _ in node.value]
+
+    @staticmethod
+    def _node_map(node) -> Dict[str, Any]:
+        """Key -> value node of a mapping node (empty for other nodes)."""
+        import yaml
+
+        if not isinstance(node, yaml.MappingNode):
+            return {}
+        return {str(key.value): value for key, value in node.value if isinstance(key, yaml.ScalarNode)}
+
+    @staticmethod
+    def _sequence(node) -> List[Any]:
+        """Items of a sequence node (empty for anything else)."""
+        import yaml
+
+        return node.value if isinstance(node, yaml.SequenceNode) else []
+
+    @staticmethod
+    def _scalar(node) -> Optional[str]:
+        """Value of a scalar node (None for anything else)."""
+        import yaml
+
+        return node.value if isinstance(node, yaml.ScalarNode) else None
+
+    def _node_end(self, node) -> int:
+        """Last line of a node (its last nested value; trailing comments excluded)."""
+        last = node
+        while isinstance(last.value, list) and last.value:
+            last = last.value[-1][1] if isinstance(last.value[-1], tuple) else last.value[-1]
+        end = last.end_mark.line + (1 if last.end_mark.column else 0)
+        while end > node.start_mark.line + 1 and (
+                not self.lines[end - 1].strip() or self.lines[end - 1].lstrip().startswith('#')):
+            end -= 1
+        return end
+
     def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
         """Extract a YAML key and its value.
 
+        For Kubernetes manifests, extracts a whole document by
+        'Kind/name' or just 'name'. For Ansible, extracts a play or
+        task by name.
+
         Args:
-            element_type: 'key'
-            name: Key name to find
+            element_type: 'key' or 'manifest'
+            name: Key name (or resource name) to find
 
         Returns:
             Dict with key content
         """
+        for manifest in self._extract_manifests():
+            if name in (manifest['name'], manifest['name'].split('/', 1)[1]):
+                return {
+                    'name': manifest['name'],
+                    'line_start': manifest['line'],
+                    'line_end': manifest['line_end'],
+                    'source': '\n'.join(self.lines[manifest['line'] - 1:manifest['line_end']]),
+                }
+
+        ansible = self._extract_ansible()
+        for category in ('plays', 'tasks', 'handlers'):
+            for item in ansible.get(category, []):
+                if item['name'] == name:
+                    line_end = item.get('line_end', item['line'])
+                    return {
+                        'name': item['name'],
+                        'line_start': item['line'],
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                    }
+
         # Find the key
         start_line = None
 
@@ -74,37 +456,156 @@ class YamlAnalyzer(FileAnalyzer):
 class JsonAnalyzer(FileAnalyzer):
     """JSON file analyzer.
 
-    Extracts top-level keys.
+    Summarizes the document's shape instead of its contents: each
+    top-level key shows its value type, array lengths and the keys of
+    nested objects (two levels deep), e.g.
+    `users: [1200 × {id: number, name: string, email?: string|null}]`.
+    Arrays are sampled, so multi-megabyte data files stay fast; keys
+    missing from some sampled objects are marked optional with `?`.
+    Documents whose root is an array show a single `$` entry.
     """
 
-    def get_structure(self) -> Dict[str, List[Dict[str, Any]]]:
-        """Extract JSON top-level keys."""
+    # Nesting levels expanded in a shape
+    SHAPE_DEPTH = 2
+    # Object keys shown per shape before eliding
+    MAX_SHAPE_KEYS = 6
+    # Array items examined when inferring element shapes
+    SAMPLE_SIZE = 100
+
+    TOKEN = re.compile(r'"(?:[^"\\]|\\.)*"(?P<key>\s*:)?|[{}\[\]]')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract top-level keys with their value shapes."""
         try:
             data = json.loads(self.content)
+        except json.JSONDecodeError:
+            return {}
 
-            if not isinstance(data, dict):
-                return {}
-
+        if isinstance(data, dict):
+            spans = self._top_level_spans()
             keys = []
-            for key in data.keys():
-                # Find line number by searching
-                line_num = self._find_key_line(key)
-                keys.append({
-                    'line': line_num,
-                    'name': key,
-                })
+            for key, value in data.items():
+                line, line_end = spans.get(key, (1, 1))
+                entry = {'line': line, 'name': key, 'type': self._kind(value)}
+                if line_end > line:
+                    entry['line_end'] = line_end
+                if isinstance(value, (list, dict)):
+                    entry['length'] = len(value)
+                entry['signature'] = f": {self._shape([value], self.SHAPE_DEPTH)}"
+                keys.append(entry)
+        else:
+            keys = [{
+                'line': 1,
+                'line_end': len(self.lines),
+                'name': '$',
+                'type': self._kind(data),
+                'signature': f": {self._shape([data], self.SHAPE_DEPTH)}",
+            }]
+            if isinstance(data, list):
+                keys[0]['length'] = len(data)
 
-            return {'keys': keys}
+        if head or tail or range:
+            keys = self._apply_semantic_slice(keys, head, tail, range)
 
-        except json.JSONDecodeError:
-            return {}
+        return {'keys': keys} if keys else {}
 
-    def _find_key_line(self, key: str) -> int:
-        """Find line number where key is defined."""
-        pattern = rf'"{re.escape(key)}"\s*:'
+    @staticmethod
+    def _kind(value: Any) -> str:
+        if isinstance(value, dict):
+            return 'object'
+        if isinstance(value, list):
+            return 'array'
+        if isinstance(value, bool):
+            return 'bool'
+        if isinstance(value, (int, float)):
+            return 'number'
+        if isinstance(value, str):
+            return 'string'
+        return 'null'
 
-        for i, line in enumerate(self.lines, 1):
-            if re.search(pattern, line):
-                return i
+    def _shape(self, values: List[Any], depth: int) -> str:
+        """Shape shared by values: 'string', '{id: number}', '[3 × string]', 'string|null'."""
+        shapes = []
+        for kind in dict.fromkeys(self._kind(v) for v in values):
+            if kind == 'object':
+                shapes.append(self._object_shape([v for v in values if isinstance(v, dict)], depth))
+            elif kind == 'array':
+                shapes.append(self._array_shape([v for v in values if isinstance(v, list)], depth))
+            else:
+                shapes.append(kind)
+        return '|'.join(shapes)
+
+    def _object_shape(self, objects: List[Dict[str, Any]], depth: int) -> str:
+        """'{a: string, b?: number, ... +3}' - keys missing from some objects get '?'."""
+        keys = list(dict.fromkeys(k for o in objects for k in o))
+        if not keys:
+            return '{}'
+        if depth <= 0:
+            return f"{{{len(keys)} key{'s' if len(keys) != 1 else ''}}}"
+        fields = []
+        for key in keys[:self.MAX_SHAPE_KEYS]:
+            present = [o[key] for o in objects if key in o]
+            optional = '?' if len(present) < len(objects) else ''
+            fields.append(f"{key}{optional}: {self._shape(present, depth - 1)}")
+        if len(keys) > self.MAX_SHAPE_KEYS:
+            fields.append(f"... +{len(keys) - self.MAX_SHAPE_KEYS}")
+        return f"{{{', '.join(fields)}}}"
+
+    def _array_shape(self, arrays: List[List[Any]], depth: int) -> str:
+        """'[1200 × {...}]'; merged arrays of differing lengths show a range '[0-5 × string]'.
+
+        Past the depth limit only arrays of scalars keep their element type.
+        """
+        lengths = sorted({len(a) for a in arrays})
+        if lengths == [0]:
+            return '[]'
+        length = str(lengths[0]) if len(lengths) == 1 else f"{lengths[0]}-{lengths[-1]}"
+        sample = []
+        for array in arrays:
+            sample.extend(array[:self.SAMPLE_SIZE - len(sample)])
+            if len(sample) >= self.SAMPLE_SIZE:
+                break
+        if depth <= 0 and any(isinstance(v, (dict, list)) for v in sample):
+            return f"[{length}]"
+        return f"[{length} × {self._shape(sample, depth - 1)}]"
+
+    def _top_level_spans(self) -> Dict[str, tuple]:
+        """Map each top-level key to the (line, line_end) of its member."""
+        newlines = [m.start() for m in re.finditer('\n', self.content)]
+        starts = []
+        depth = 0
+        for match in self.TOKEN.finditer(self.content):
+            token = match.group()
+            if token in ('{', '['):
+                depth += 1
+            elif token in ('}', ']'):
+                depth -= 1
+                if depth == 0:
+                    starts.append((None, match.start()))
+                    break
+            elif match.group('key') and depth == 1:
+                starts.append((json.loads(token[:match.start('key') - match.start()]), match.start()))
+
+        spans = {}
+        for (key, start), (_, following) in zip(starts, starts[1:]):
+            end = following
+            while end > start and self.content[end - 1] in ' \t\r\n,':
+                end -= 1
+            spans.setdefault(key, (bisect.bisect_left(newlines, start) + 1,
+                                   bisect.bisect_left(newlines, end - 1) + 1))
+        return spans
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract a top-level key's member."""
+        for item in self.get_structure().get('keys', []):
+            if item['name'] == name:
+                line_end = item.get('line_end', item['line'])
+                return {
+                    'name': name,
+                    'line_start': item['line'],
+                    'line_end': line_end,
+                    'source': '\n'.join(self.lines[item['line'] - 1:line_end]),
+                }
 
-        return 1  # Default to line 1
+        return super().extract_element(element_type, name)
@@ -0,0 +1,121 @@
+"""Zig file analyzer - regex based."""
+
+import re
+from typing import Dict, List, Any, Optional
+from ..base import register
+from ..regex_analyzer import RegexAnalyzer
+
+
+_CONTAINER = r'^\s*(?P<pub>pub\s+)?(?:const|var)\s+(?P<name>\w+)\s*(?::\s*type\s*)?=\s*(?:extern\s+|packed\s+)?'
+
+
+@register('.zig', '.zon', name='Zig', icon='')
+class ZigAnalyzer(RegexAnalyzer):
+    """Zig file analyzer.
+
+    Extracts:
+    - @import statements
+    - Structs, unions (including tagged unions), enums and error sets
+    - Functions (pub/export/inline); functions inside a container are
+      named Container.function
+    - comptime blocks and test declarations
+    """
+
+    patterns = {
+        'imports': re.compile(r'^\s*(?:pub\s+)?const\s+(?P<name>\w+)\s*=\s*@import\(\s*"(?P<path>[^"]+)"\s*\)'),
+        'structs': re.compile(_CONTAINER + r'struct\b(?P<signature>\s*\([^)]*\))?'),
+        'unions': re.compile(_CONTAINER + r'union\b(?P<signature>\s*\([^)]*\))?'),
+        'enums': re.compile(_CONTAINER + r'enum\b(?P<signature>\s*\([^)]*\))?'),
+        'errors': re.compile(_CONTAINER + r'error\s*\{'),
+        'functions': re.compile(
+            r'^\s*(?P<pub>pub\s+)?(?P<modifiers>(?:(?:export|extern(?:\s+"\w+")?|inline|noinline)\s+)*)'
+            r'fn\s+(?P<name>\w+)\s*(?P<params>\(.*?\))\s*(?P<returns>[^{;]*)'
+        ),
+        'comptime': re.compile(r'^\s*(?P<content>comptime)\s*\{'),
+        'tests': re.compile(r'^\s*test\s+(?:"(?P<name>[^"]*)"|(?P<ident>\w+))?\s*\{'),
+    }
+
+    CONTAINER_CATEGORIES = ('structs', 'unions', 'enums')
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Mark pub visibility and format fn signatures."""
+        entry = super()._make_entry(category, match, line_no)
+
+        if category == 'imports':
+            entry['signature'] = f' = @import("{entry.pop("path")}")'
+            entry.pop('line_end', None)
+            return entry
+
+        if category == 'tests':
+            if 'ident' in entry:
+                entry['name'] = entry.pop('ident')
+            if 'name' not in entry:
+                entry = {'line': line_no, 'name': '(anonymous)', **{k: v for k, v in entry.items()
+                                                                     if k not in ('line', 'content')}}
+                line_end = self._find_block_end(line_no)
+                if line_end > line_no:
+                    entry['line_end'] = line_end
+            return entry
+
+        if category == 'comptime':
+            line_end = self._find_block_end(line_no)
+            if line_end > line_no:
+                entry['line_end'] = line_end
+                entry['content'] = f"comptime {{ ... }} ({line_end - line_no + 1} lines)"
+            return entry
+
+        entry['visibility'] = 'pub' if entry.pop('pub', None) else 'private'
+
+        if category == 'functions':
+            modifiers = entry.pop('modifiers', '').split()
+            if modifiers:
+                entry['modifiers'] = modifiers
+                if 'export' in modifiers:
+                    entry['visibility'] = 'export'
+            returns = entry.pop('returns', '').strip()
+            entry['signature'] = entry.pop('params') + (f" -> {returns}" if returns else '')
+        elif category == 'errors':
+            body = ' '.join(self.lines[line_no - 1:entry.get('line_end', line_no)])
+            members = re.search(r'error\s*\{([^}]*)\}', body)
+            if members:
+                entry['members'] = [m.strip() for m in members.group(1).split(',') if m.strip()]
+                entry['signature'] = f" {{ {', '.join(entry['members'])} }}"
+        elif 'signature' in entry:
+            entry['signature'] = f" {entry['signature'].strip()}"
+
+        return entry
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure; qualify functions and nested containers."""
+        structure = super().get_structure(**kwargs)
+
+        containers = sorted(
+            (c for category in self.CONTAINER_CATEGORIES for c in structure.get(category, [])),
+            key=lambda c: c['line'],
+        )
+        # Qualify outermost first so nested names build on qualified parents
+        for container in containers:
+            parent = self._enclosing(container, containers)
+            if parent:
+                container['name'] = f"{parent['name']}.{container['name']}"
+        for function in structure.get('functions', []):
+            parent = self._enclosing(function, containers)
+            if parent:
+                function['name'] = f"{parent['name']}.{function['name']}"
+                function['container'] = parent['name']
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return structure
+
+    @staticmethod
+    def _enclosing(item: Dict[str, Any], containers: List[Dict[str, Any]]) -> Optional[Dict[str, Any]]:
+        """Innermost container whose range contains the item."""
+        enclosing = [c for c in containers if c is not item
+                     and c['line'] < item['line'] <= c.get('line_end', c['line'])]
+        return enclosing[-1] if enclosing else None
@@ -0,0 +1,161 @@
+"""Public API surface: exported definitions as declarations with their docs, bodies left out.
+
+What counts as public follows each language's own rules: Go capitalized
+names, Python __all__ (or no leading underscore), JavaScript/TypeScript
+export and Rust pub on top-level items, the visibility analyzers record,
+and a leading underscore as the private convention elsewhere. Members
+are public when their type is.
+"""
+
+import re
+from typing import Any, Dict, List, Optional, Set
+
+from .base import DOC_LINE, category_kind
+from .hierarchy import HIERARCHY_CATEGORIES
+from .metrics import FUNCTION_CATEGORIES
+from .unused import exported_names, reference_name
+
+
+API_CATEGORIES = FUNCTION_CATEGORIES + HIERARCHY_CATEGORIES + ('properties', 'fields')
+
+PRIVATE_VISIBILITY = {'private', 'protected', 'package', 'internal', 'fileprivate', 'local'}
+PUBLIC_VISIBILITY = {'public', 'exported', 'export', 'external', 'open'}
+# Languages where top-level definitions are private unless marked
+_EXPORT_MARKED = {'.js': r'export', '.mjs': r'export', '.jsx': r'export', '.ts': r'export', '.tsx': r'export',
+                  '.rs': r'pub(?:\([^)]*\))?'}
+_PUBLIC_SPECIAL = {'__init__', '__call__', '__new__'}
+_BODY_OPENER = re.compile(r'\s*\{|\s=(?![=>])|\s+do(?::|\s*$)')
+
+
+def _suffix(file_name: str) -> str:
+    return file_name[file_name.rfind('.'):] if '.' in file_name else ''
+
+
+def is_public(file_name: str, lines: List[str], item: Dict[str, Any], owner: Optional[Dict[str, Any]],
+              listed: Set[str]) -> bool:
+    """Whether a definition belongs to the file's public API; owner is the type it is a member of."""
+    name = reference_name(str(item['name'])) or ''
+    if not name:
+        return False
+    if file_name.endswith('.go'):
+        return name[:1].isupper()
+    visibility = item.get('visibility')
+    if visibility in PRIVATE_VISIBILITY or name.startswith('#'):
+        return False
+    if name.startswith('_') and name not in _PUBLIC_SPECIAL:
+        return False
+    if owner is not None:
+        return True
+    if file_name.endswith('.py') and listed:
+        return name in listed
+    marker = _EXPORT_MARKED.get(_suffix(file_name))
+    if marker:
+        line = item.get('line', 0)
+        declaration = lines[line - 1] if 0 < line <= len(lines) else ''
+        return visibility in PUBLIC_VISIBILITY or bool(re.match(r'^\s*' + marker + r'\b', declaration))
+    return True
+
+
+def leading_docs(lines: List[str], line: int, floor: int = 0) -> List[str]:
+    """Comment, attribute and decorator lines directly above a definition, below line floor."""
+    start = line
+    while start > floor + 1 and DOC_LINE.match(lines[start - 2]) and not lines[start - 2].lstrip().startswith('#!'):
+        start -= 1
+    return lines[start - 1:line - 1]
+
+
+def declaration(lines: List[str], line: int) -> List[str]:
+    """A definition's header lines without its body: up to the closing parenthesis, cut before '{' or '='."""
+    header: List[str] = []
+    depth = 0
+    for text in lines[line - 1:line + 11]:
+        code = text.split('//', 1)[0] if '//' in text and 'http' not in text else text
+        depth += code.count('(') + code.count('[') - code.count(')') - code.count(']')
+        header.append(text.rstrip())
+        if depth <= 0:
+            break
+    # Body openers after the parameters: '{' (C family, Go), ' =' (Nim, Scala, Haskell), ' do' (Elixir)
+    last = header[-1]
+    params_end = last.rfind(')') + 1
+    cut = _BODY_OPENER.search(last, params_end)
+    if cut and cut.start() > 0:
+        header[-1] = last[:cut.start()].rstrip()
+    return header
+
+
+def python_docstring(lines: List[str], header_end: int) -> List[str]:
+    """The docstring lines right after a Python def/class header (header_end is its last line)."""
+    if header_end >= len(lines):
+        return []
+    first = lines[header_end].strip()
+    quote = first[:3] if first[:3] in ('"""', "'''") else None
+    if quote is None:
+        return []
+    if first.count(quote) >= 2 or len(first) > 3 and first.endswith(quote):
+        return [lines[header_end]]
+    for end in range(header_end + 1, len(lines)):
+        if quote in lines[end]:
+            return lines[header_end:end + 1]
+    return []
+
+
+def api_entries(file_name: str, lines: List[str], structure: Dict[str, List[Dict[str, Any]]]) -> List[Dict[str, Any]]:
+    """Public definitions in line order: {'name', 'kind', 'line', 'signature', 'doc', 'docstring'}, 'owner' for members.
+
+    doc holds the comments and decorators above the definition, docstring a
+    Python docstring below it.
+    """
+    listed = exported_names(file_name, lines)
+    items = sorted(((category, item) for category in API_CATEGORIES for item in structure.get(category, [])
+                    if item.get('name') and isinstance(item.get('line'), int)),
+                   key=lambda pair: (pair[1]['line'], -pair[1].get('line_end', pair[1]['line'])))
+    types = [item for category, item in items if category in HIERARCHY_CATEGORIES]
+
+    entries = []
+    hidden: List[Dict[str, Any]] = []
+    previous = 0
+    for category, item in items:
+        line = item['line']
+        # Docs stop at the previous definition (an @interface line is not a decorator of its first method)
+        floor, previous = (previous if previous < line else 0), line
+        owners = [t for t in types if t is not item and t['line'] < line <= t.get('line_end', t['line'])]
+        owner = owners[-1] if owners else None
+        # Members of private types, and anything nested in a function, stay private
+        if any(o['line'] < line <= o.get('line_end', o['line']) for o in hidden) or not is_public(
+                file_name, lines, item, owner, listed):
+            hidden.append(item)
+            continue
+        header = declaration(lines, line)
+        docstring = python_docstring(lines, line - 1 + len(header)) if file_name.endswith('.py') else []
+        entry = {'name': item['name'], 'kind': item.get('kind') or category_kind(category), 'line': line,
+                 'signature': '\n'.join(header), 'doc': '\n'.join(leading_docs(lines, line, floor)),
+                 'docstring': '\n'.join(docstring)}
+        if owner is not None:
+            entry['owner'] = owner['name']
+        entries.append(entry)
+        if category in FUNCTION_CATEGORIES:
+            hidden.append(item)
+    return entries
+
+
+def render_api_text(entries: List[Dict[str, Any]]) -> List[str]:
+    """Stub-like listing: comments/decorators, declaration and docstring of each entry as written in the source."""
+    output = []
+    for entry in entries:
+        if not entry.get('owner') and output:
+            output.append('')
+        for part in ('doc', 'signature', 'docstring'):
+            if entry[part]:
+                output.extend(entry[part].split('\n'))
+    return output
+
+
+# Decorators and attributes sit among doc lines but document nothing (Elixir's @doc does)
+_NOT_DOCUMENTATION = re.compile(r'^\s*(?:@(?!doc\b|moduledoc\b)|#\[|\[)')
+
+
+def is_documented(entry: Dict[str, Any]) -> bool:
+    """Whether an api_entries entry has a docstring or a comment above it."""
+    if entry['docstring'].strip():
+        return True
+    return any(line.strip() and not _NOT_DOCUMENTATION.match(line) for line in entry['doc'].split('\n'))
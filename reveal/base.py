@@ -1,11 +1,15 @@
 """Base analyzer class for reveal - clean, simple design."""
 
 import os
+import re
+import sys
 import logging
 from pathlib import Path
-from typing import Optional, Dict, Any, List
+from typing import Optional, Dict, Any, List, Tuple
 import hashlib
 
+from .metrics import line_counts
+
 logger = logging.getLogger(__name__)
 
 # Import type system (lazy to avoid circular imports)
@@ -13,6 +17,15 @@ _TYPE_REGISTRY = None
 _RELATIONSHIP_REGISTRY = None
 
 
+# Contents standing in for files on disk (a file as of a git revision): absolute path -> (text, revision)
+_SOURCES: Dict[str, Tuple[str, str]] = {}
+
+
+def use_source(path: str, text: str, revision: str) -> None:
+    """Analyze path from text instead of the file on disk (reveal file@rev)."""
+    _SOURCES[os.path.abspath(path)] = (text, revision)
+
+
 def _get_type_system():
     """Lazy import of type system to avoid circular dependencies."""
     global _TYPE_REGISTRY, _RELATIONSHIP_REGISTRY
@@ -54,6 +67,8 @@ class FileAnalyzer:
 
     def __init__(self, path: str):
         self.path = Path(path)
+        # Git revision the content comes from, None for the working tree
+        self.revision = _SOURCES.get(os.path.abspath(path), (None, None))[1]
         self.lines = self._read_file()
         self.content = '\n'.join(self.lines)
 
@@ -62,8 +77,22 @@ class FileAnalyzer:
         self._relationship_registry = None
         self._init_type_system()
 
+    def __reduce__(self):
+        """Pickled (back from a worker process) as its class and path: the copy re-reads the file.
+
+        Parse trees and registries don't travel; they are rebuilt on demand.
+        Classes made on the fly (tree-sitter fallbacks) are looked up again.
+        """
+        cls = type(self)
+        importable = getattr(sys.modules.get(cls.__module__), cls.__qualname__, None) is cls
+        return _reopen, (cls if importable else None, str(self.path))
+
     def _read_file(self) -> List[str]:
         """Read file with automatic encoding detection."""
+        source = _SOURCES.get(os.path.abspath(self.path))
+        if source is not None:
+            return source[0].splitlines()
+
         encodings = ['utf-8', 'utf-8-sig', 'latin-1', 'cp1252']
 
         for encoding in encodings:
@@ -86,15 +115,18 @@ class FileAnalyzer:
 
         Automatic - works for all file types.
         """
-        stat = os.stat(self.path)
+        source = _SOURCES.get(os.path.abspath(self.path))
+        size = len(source[0].encode('utf-8')) if source else os.stat(self.path).st_size
 
         return {
             'path': str(self.path),
             'name': self.path.name,
-            'size': stat.st_size,
-            'size_human': self._format_size(stat.st_size),
+            'size': size,
+            'size_human': self._format_size(size),
             'lines': len(self.lines),
+            **line_counts(self.lines, self.path.name),
             'encoding': self._detect_encoding(),
+            **({'revision': self.revision} if self.revision else {}),
         }
 
     def get_structure(self, head: int = None, tail: int = None,
@@ -146,6 +178,14 @@ class FileAnalyzer:
         else:
             return items
 
+    def cache_inputs(self) -> List[Path]:
+        """Other files the structure is read from (sibling headers, link targets), for cache keys.
+
+        Override in analyzers that read beyond their own file.
+        Default: none.
+        """
+        return []
+
     def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
         """Extract a specific element from the file.
 
@@ -278,6 +318,14 @@ class FileAnalyzer:
 
         return errors
 
+    def get_directory_summary(self) -> Optional[str]:
+        """Return a one-line summary shown next to the file in directory trees.
+
+        Optional - override to surface what matters at a glance
+        (e.g., the tables a SQL migration creates). Default: None.
+        """
+        return None
+
     def get_directory_entry(self) -> Dict[str, Any]:
         """Return info for directory listing.
 
@@ -337,15 +385,20 @@ def get_analyzer(path: str, allow_fallback: bool = True) -> Optional[type]:
     """
     file_path = Path(path)
     ext = file_path.suffix.lower()
+    filename = file_path.name.lower()
+
+    # Special filenames first (Dockerfile, Makefile, build.gradle.kts over .kts)
+    if filename in _ANALYZER_REGISTRY:
+        return _ANALYZER_REGISTRY.get(filename)
 
     # If we have an extension, use it
     if ext and ext in _ANALYZER_REGISTRY:
         return _ANALYZER_REGISTRY.get(ext)
 
-    # No extension or not found - check special filenames (Dockerfile, Makefile)
-    filename = file_path.name.lower()
-    if filename in _ANALYZER_REGISTRY:
-        return _ANALYZER_REGISTRY.get(filename)
+    # Variants of special filenames: Dockerfile.prod, Makefile.linux
+    base_name = filename.split('.', 1)[0]
+    if base_name != filename and base_name in _ANALYZER_REGISTRY:
+        return _ANALYZER_REGISTRY.get(base_name)
 
     # Path-based detection for nginx configs (handles /etc/nginx/sites-available/*, etc.)
     path_str = str(file_path.resolve())
@@ -376,9 +429,13 @@ def _detect_shebang(path: str) -> Optional[str]:
     Returns:
         Extension to use (e.g., '.py', '.sh') or None
     """
+    source = _SOURCES.get(os.path.abspath(path))
     try:
-        with open(path, 'rb') as f:
-            first_line = f.readline()
+        if source is not None:
+            first_line = source[0].split('\n', 1)[0].encode('utf-8')
+        else:
+            with open(path, 'rb') as f:
+                first_line = f.readline()
 
         # Decode with error handling
         try:
@@ -455,6 +512,11 @@ def _guess_treesitter_language(ext: str) -> Optional[str]:
     return EXTENSION_MAP.get(ext.lower())
 
 
+def _reopen(analyzer_class: Optional[type], path: str) -> FileAnalyzer:
+    """An analyzer of analyzer_class for path, or of the class get_analyzer picks (fallbacks included)."""
+    return (analyzer_class or get_analyzer(path, allow_fallback=True))(path)
+
+
 def _try_treesitter_fallback(ext: str) -> Optional[type]:
     """Try to create a dynamic TreeSitter analyzer for unknown extension.
 
@@ -501,6 +563,87 @@ def _try_treesitter_fallback(ext: str) -> Optional[type]:
         return None
 
 
+def category_kind(category: str) -> str:
+    """Singular kind for a structure category: 'classes' -> 'class', 'class_methods' -> 'class_method'."""
+    if category.endswith('ies'):
+        return category[:-3] + 'y'
+    if category.endswith(('sses', 'ches', 'shes', 'xes', 'ases')):
+        return category[:-2]
+    return category[:-1] if category.endswith('s') else category
+
+
+# Comment, attribute and decorator lines that document the definition below them
+DOC_LINE = re.compile(r'^\s*(?:#|//|///|/\*|\*|--|;|@|\[|%|\(\*)')
+
+# Separators in qualified symbol names: Owner.member, Owner::member, Owner#member
+SYMBOL_QUALIFIER = re.compile(r'::|#|\.')
+
+
+def find_symbols(items: List[Dict[str, Any]], lines: List[str], name: str) -> List[Dict[str, Any]]:
+    """Structure items a possibly qualified name refers to, best rule first.
+
+    Tries, in order: items whose own qualified name ends with the query
+    (Circle.area finds Shapes::Circle#area), members named like the last
+    part inside an owner's line range (a Python method in its class), and
+    members whose definition line names the owner before them (a Go
+    receiver, def Owner.member). Returns the matches of the first rule
+    that has any.
+    """
+    parts = [part for part in SYMBOL_QUALIFIER.split(name) if part]
+    if not parts:
+        return []
+    owner_parts, short = parts[:-1], parts[-1]
+    items = [item for item in items if item.get('name') and isinstance(item.get('line'), int)]
+
+    def qualified(item):
+        return [part for part in SYMBOL_QUALIFIER.split(item['name']) if part]
+
+    matches = [item for item in items if qualified(item)[-len(parts):] == parts]
+    if matches or not owner_parts:
+        return matches
+    owners = [item for item in items if qualified(item)[-len(owner_parts):] == owner_parts]
+    members = [item for item in items if qualified(item)[-1] == short and item not in owners]
+    matches = [member for member in members
+               if any(owner['line'] < member['line'] <= owner.get('line_end', owner['line']) for owner in owners)]
+    if matches:
+        return matches
+    owner_pattern = re.compile(r'\b' + re.escape(owner_parts[-1]) + r'\b.*\b' + re.escape(short) + r'\b')
+    return [member for member in members
+            if 0 < member['line'] <= len(lines) and owner_pattern.search(lines[member['line'] - 1])]
+
+
+def split_symbol_target(target: str):
+    """Split 'path::Symbol' at the first '::' whose left side is a file.
+
+    Returns (path, symbol), or (target, None) when no prefix is a file.
+    Symbols may themselves contain '::' (lib.rs::net::Server::start).
+    """
+    index = target.find('::')
+    while index > 0:
+        if Path(target[:index]).is_file():
+            return target[:index], target[index + 2:] or None
+        index = target.find('::', index + 2)
+    return target, None
+
+
+def enclosing_symbols(structure: Dict[str, List[Dict[str, Any]]], start: int, end: int) -> List[Dict[str, Any]]:
+    """Named definitions whose line range contains lines start-end, outermost first."""
+    from .tags import SKIPPED_CATEGORIES
+
+    found = {}
+    for category, items in structure.items():
+        if category in SKIPPED_CATEGORIES:
+            continue
+        for item in items:
+            line = item.get('line')
+            line_end = item.get('line_end', line)
+            if item.get('name') and isinstance(line, int) and line <= start and end <= line_end and line_end > line:
+                found.setdefault((line, line_end, item['name']),
+                                 {'name': item['name'], 'kind': item.get('kind') or category_kind(category),
+                                  'line': line, 'line_end': line_end})
+    return [found[key] for key in sorted(found, key=lambda key: (key[0], -key[1]))]
+
+
 def get_all_analyzers() -> Dict[str, Dict[str, Any]]:
     """Get all registered analyzers with metadata.
 
@@ -0,0 +1,117 @@
+"""--budget: render at the most detail that fits an approximate token budget.
+
+Output is rendered at successive levels of reduction until its estimated
+size (about four characters per token) fits. A file's structure first
+loses private symbols, then long signatures are shortened, then
+imports and other non-definitions go, then each category keeps only its
+first items. A directory tree is collapsed one level of depth at a
+time, then loses its line counts, then shows fewer entries. If even the
+smallest rendering is too large, text output is cut at the budget.
+"""
+
+from typing import Any, Callable, Dict, List
+
+from .api import is_public
+from .tags import SKIPPED_CATEGORIES
+from .unused import exported_names
+
+# Characters per token, roughly, for code and English alike
+CHARS_PER_TOKEN = 4
+# Signatures longer than this are shortened from level 2 on
+SIGNATURE_LENGTH = 40
+
+# What each level of a file's structure drops, cumulatively
+STRUCTURE_LEVELS = ('', 'private symbols hidden', 'signatures shortened', 'imports and non-definitions hidden',
+                    'at most 20 items per category', 'at most 5 items per category')
+_ITEM_LIMITS = {4: 20, 5: 5}
+
+
+def structure_reductions(level: int) -> str:
+    """What a file's structure at level leaves out, e.g. 'private symbols hidden, signatures shortened'."""
+    reductions = list(STRUCTURE_LEVELS[1:min(level, 3) + 1])
+    if level > 3:
+        reductions.append(STRUCTURE_LEVELS[level])
+    return ', '.join(reductions)
+
+
+def estimate_tokens(text: str) -> int:
+    """Approximate number of tokens in text."""
+    return -(-len(text) // CHARS_PER_TOKEN)
+
+
+def _shorten(signature: str) -> str:
+    if len(signature) <= SIGNATURE_LENGTH:
+        return signature
+    closing = ')' if signature.startswith('(') else ''
+    return signature[:SIGNATURE_LENGTH - 3 - len(closing)].rstrip(', ') + '...' + closing
+
+
+def reduce_structure(structure: Dict[str, List[Dict[str, Any]]], file_name: str, lines: List[str],
+                     level: int) -> Dict[str, List[Dict[str, Any]]]:
+    """A copy of a file's structure with the reductions of STRUCTURE_LEVELS up to level applied."""
+    listed = exported_names(file_name, lines) if level >= 1 else set()
+    reduced = {}
+    for category, items in structure.items():
+        if not isinstance(items, list):
+            reduced[category] = items
+            continue
+        if level >= 3 and category in SKIPPED_CATEGORIES:
+            continue
+        if level >= 1:
+            items = [item for item in items if not item.get('name') or is_public(file_name, lines, item, None, listed)]
+        if level >= 2:
+            items = [dict(item, signature=_shorten(item['signature'])) if isinstance(item.get('signature'), str)
+                     else item for item in items]
+        limit = _ITEM_LIMITS.get(level)
+        if limit is not None:
+            items = items[:limit]
+        if items:
+            reduced[category] = items
+    return reduced
+
+
+def tree_levels(depth: int, max_entries: int, fast: bool) -> List[Dict[str, Any]]:
+    """show_directory_tree options from the requested ones down to the smallest tree.
+
+    Depth goes first, one level at a time, then line counts (fast), then
+    entries are halved down to a handful.
+    """
+    levels = [{'depth': depth, 'fast': fast, 'max_entries': max_entries}]
+    for shallower in range(depth - 1, 0, -1):
+        levels.append({'depth': shallower, 'fast': fast, 'max_entries': max_entries})
+    if not fast:
+        levels.append({'depth': 1, 'fast': True, 'max_entries': max_entries})
+    entries = max_entries if 0 < max_entries <= 200 else 200
+    while entries > 5:
+        entries //= 2
+        levels.append({'depth': 1, 'fast': True, 'max_entries': entries})
+    return levels
+
+
+def cut_to_budget(text: str, budget: int) -> str:
+    """The leading lines of text that fit the budget, with a last line saying how many were cut."""
+    lines = text.split('\n')
+    kept: List[str] = []
+    used = 0
+    # Room for the closing note
+    room = budget * CHARS_PER_TOKEN - 60
+    for line in lines:
+        if used + len(line) + 1 > room:
+            break
+        kept.append(line)
+        used += len(line) + 1
+    return '\n'.join(kept + [f"... {len(lines) - len(kept)} more lines cut to fit --budget {budget}"])
+
+
+def fit_to_budget(render: Callable[[int], str], levels: int, budget: int, cut: bool = True) -> str:
+    """The first of render(0), render(1), ... render(levels - 1) that fits the budget.
+
+    When none does, the last one, cut at the budget if cut is set (for
+    text; structured formats are never cut).
+    """
+    text = ''
+    for level in range(levels):
+        text = render(level)
+        if estimate_tokens(text) <= budget:
+            return text
+    return cut_to_budget(text, budget) if cut else text
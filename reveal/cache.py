@@ -0,0 +1,86 @@
+"""On-disk cache of file structures, so repeated runs skip re-parsing unchanged files.
+
+Entries live under ~/.cache/reveal ($XDG_CACHE_HOME/reveal, or
+$REVEAL_CACHE_DIR when set), one file per entry, keyed by the analyzer,
+the file's absolute path, a hash of its content, the structure options
+and the reveal version, plus the modification time and size of any
+other file the analyzer reads (cache_inputs: C# partial siblings,
+Objective-C header/implementation pairs, Markdown link targets): an
+edited file or input, another analyzer or an upgrade is a miss, never
+a stale hit. The directory is safe to delete at any
+time. Content from a git revision (--rev, path@rev) is not cached.
+"""
+
+import hashlib
+import os
+import pickle
+import tempfile
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from .base import FileAnalyzer
+
+
+CACHE_FORMAT = 1
+
+
+def cache_dir() -> Path:
+    """Directory holding the cache entries."""
+    if os.environ.get('REVEAL_CACHE_DIR'):
+        return Path(os.environ['REVEAL_CACHE_DIR'])
+    base = os.environ.get('XDG_CACHE_HOME') or os.path.join(os.path.expanduser('~'), '.cache')
+    return Path(base) / 'reveal'
+
+
+def _entry(analyzer: FileAnalyzer, options: Dict[str, Any]) -> Path:
+    from . import __version__
+
+    digest = hashlib.sha256()
+    for part in (str(CACHE_FORMAT), __version__, f"{type(analyzer).__module__}.{type(analyzer).__qualname__}",
+                 os.path.abspath(analyzer.path), repr(sorted(options.items()))):
+        digest.update(part.encode('utf-8', 'surrogatepass') + b'\0')
+    digest.update(analyzer.content.encode('utf-8', 'surrogatepass') + b'\0')
+    for path in analyzer.cache_inputs():
+        try:
+            stat = path.stat()
+            state = f"{stat.st_mtime_ns}:{stat.st_size}"
+        except OSError:
+            state = 'missing'
+        digest.update(f"{os.path.abspath(path)}\0{state}\0".encode('utf-8', 'surrogatepass'))
+    key = digest.hexdigest()
+    return cache_dir() / key[:2] / key
+
+
+def cached_structure(analyzer: FileAnalyzer, options: Optional[Dict[str, Any]] = None,
+                     enabled: bool = True) -> Dict[str, List[Dict[str, Any]]]:
+    """analyzer.get_structure(**options), from the cache when the file is unchanged since it was stored.
+
+    A miss stores the result; an unreadable or unwritable cache just
+    means analyzing as usual.
+    """
+    options = options or {}
+    if not enabled or analyzer.revision:
+        return analyzer.get_structure(**options)
+
+    entry = _entry(analyzer, options)
+    try:
+        with open(entry, 'rb') as f:
+            return pickle.load(f)
+    except (OSError, EOFError, pickle.UnpicklingError, AttributeError, ImportError, IndexError, TypeError):
+        pass
+
+    structure = analyzer.get_structure(**options)
+    try:
+        entry.parent.mkdir(parents=True, exist_ok=True)
+        # Write then rename, so concurrent runs never read half an entry
+        fd, temporary = tempfile.mkstemp(dir=entry.parent, prefix='.tmp-')
+        try:
+            with os.fdopen(fd, 'wb') as f:
+                pickle.dump(structure, f, protocol=pickle.HIGHEST_PROTOCOL)
+            os.replace(temporary, entry)
+        except BaseException:
+            os.unlink(temporary)
+            raise
+    except (OSError, pickle.PicklingError, TypeError, AttributeError):
+        pass
+    return structure
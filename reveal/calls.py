@@ -0,0 +1,212 @@
+"""Call graphs: which functions call which, from structure line ranges and call syntax.
+
+A call is a name followed by '(' inside a function's body that names a
+function defined in the same file (or, for project graphs, anywhere in the
+project). Without a parser this misses calls written without parentheses
+and can't tell same-named functions apart beyond preferring the caller's
+own file and class.
+"""
+
+import re
+from typing import Any, Dict, Iterable, List, Tuple
+
+from .base import SYMBOL_QUALIFIER, find_symbols
+from .dependencies import _dot_id
+from .metrics import FUNCTION_CATEGORIES
+
+
+_CALL = re.compile(r'(?<![\w$])([A-Za-z_$][\w$]*[!?]?)\s*\(')
+_STRING = re.compile(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'|`[^`]*`')
+_LINE_COMMENT = re.compile(r'^\s*(?:#|//|--|;|\*|/\*)')
+
+
+def short_name(name: str) -> str:
+    """'Server#start' / 'Server.start' / 'net::start' -> 'start'."""
+    return SYMBOL_QUALIFIER.split(name)[-1] or name
+
+
+def owner_name(name: str) -> str:
+    """'Server#start' -> 'Server'; '' for unqualified names."""
+    parts = SYMBOL_QUALIFIER.split(name)
+    return '.'.join(parts[:-1])
+
+
+def functions_of(structure: Dict[str, List[Dict[str, Any]]]) -> List[Dict[str, Any]]:
+    """Functions and methods with line ranges, in line order."""
+    functions = [item for category in FUNCTION_CATEGORIES for item in structure.get(category, [])
+                 if item.get('name') and isinstance(item.get('line'), int)]
+    return sorted(functions, key=lambda item: (item['line'], -item.get('line_end', item['line'])))
+
+
+def call_sites(lines: List[str], functions: List[Dict[str, Any]]) -> List[Tuple[Dict[str, Any], str, int]]:
+    """(calling function, called name, line) for each call inside a function body.
+
+    Lines are attributed to the innermost function containing them; string
+    literals and comment lines are ignored.
+    """
+    owners: Dict[int, Dict[str, Any]] = {}
+    # Outer functions first, so nested ones overwrite their lines
+    for function in functions:
+        for number in range(function['line'], function.get('line_end', function['line']) + 1):
+            owners[number] = function
+
+    sites = []
+    for number in sorted(owners):
+        if number > len(lines):
+            break
+        text = lines[number - 1]
+        if _LINE_COMMENT.match(text):
+            continue
+        text = _STRING.sub('""', text).split('//', 1)[0]
+        caller = owners[number]
+        for match in _CALL.finditer(text):
+            name = match.group(1)
+            # The definition itself: def name(...) on its first line
+            if number == caller['line'] and name == short_name(caller['name']):
+                continue
+            sites.append((caller, name, number))
+    return sites
+
+
+def file_call_graph(lines: List[str], structure: Dict[str, List[Dict[str, Any]]]) -> Dict[str, List[Dict[str, Any]]]:
+    """Caller name -> [{'name': callee, 'line': first call line}] for functions calling each other in a file.
+
+    Every function appears as a key, in line order. A call to a name
+    defined more than once resolves to the caller's own class when it has
+    one of that name, else to all of them.
+    """
+    functions = functions_of(structure)
+    by_short: Dict[str, List[str]] = {}
+    for function in functions:
+        by_short.setdefault(short_name(function['name']), []).append(function['name'])
+
+    graph: Dict[str, List[Dict[str, Any]]] = {function['name']: [] for function in functions}
+    for caller, name, line in call_sites(lines, functions):
+        candidates = by_short.get(name, [])
+        own = [c for c in candidates if owner_name(c) == owner_name(caller['name'])]
+        for callee in own or candidates:
+            if all(call['name'] != callee for call in graph[caller['name']]):
+                graph[caller['name']].append({'name': callee, 'line': line})
+    return graph
+
+
+def call_tree(graph: Dict[str, List[Dict[str, Any]]]) -> List[Dict[str, Any]]:
+    """Nested {'name', 'line', 'children'} trees from the functions nothing else calls.
+
+    Functions only reachable through a cycle start their own tree; a callee
+    already expanded higher up is marked 'seen' (or 'recursive' when it is
+    on the current path) instead of being expanded again.
+    """
+    called = {call['name'] for calls in graph.values() for call in calls}
+    expanded = set()
+
+    def expand(name: str, line: Any, path: Tuple[str, ...]) -> Dict[str, Any]:
+        node = {'name': name, 'line': line, 'children': []}
+        if name in path:
+            node['recursive'] = True
+        elif name in expanded:
+            node['seen'] = bool(graph.get(name))
+        else:
+            expanded.add(name)
+            node['children'] = [expand(call['name'], call['line'], path + (name,)) for call in graph.get(name, [])]
+        return node
+
+    roots = [expand(name, None, ()) for name in graph if name not in called]
+    for name in graph:
+        if name not in expanded:
+            roots.append(expand(name, None, ()))
+    return roots
+
+
+def render_calls_dot(graph: Dict[str, List[Dict[str, Any]]], title: str) -> str:
+    """Graphviz DOT for a call graph."""
+    lines = [f'digraph {_dot_id(title)} {{',
+             '  rankdir=LR;',
+             '  node [shape=box, style="rounded,filled", fillcolor="#eef4ff", fontname="Helvetica", fontsize=10];',
+             '  edge [color="#57606a", arrowsize=0.7];']
+    for name in graph:
+        lines.append(f'  {_dot_id(name)};')
+    for caller, calls in graph.items():
+        for call in calls:
+            lines.append(f'  {_dot_id(caller)} -> {_dot_id(call["name"])};')
+    lines.append('}')
+    return '\n'.join(lines)
+
+
+class ProjectCallGraph:
+    """Calls between the functions of many files.
+
+    Nodes are 'file::name' ids; nodes[id] holds file, name, line and
+    line_end, and edges[id] the calls it makes ({'callee', 'line'}).
+    """
+
+    def __init__(self):
+        self.nodes: Dict[str, Dict[str, Any]] = {}
+        self.edges: Dict[str, List[Dict[str, Any]]] = {}
+        # file -> (lines, named structure items), for resolving qualified names
+        self.files: Dict[str, Tuple[List[str], List[Dict[str, Any]]]] = {}
+
+    def callers(self, node: str) -> List[Dict[str, Any]]:
+        """{'caller', 'line'} for each function calling node."""
+        return [{'caller': caller, 'line': call['line']}
+                for caller, calls in self.edges.items() for call in calls if call['callee'] == node]
+
+    def find(self, name: str) -> List[str]:
+        """Node ids a possibly qualified function name refers to, across files."""
+        found = []
+        for file_name, (lines, items) in self.files.items():
+            nodes = (f"{file_name}::{item['name']}" for item in find_symbols(items, lines, name))
+            found.extend(node for node in nodes if node in self.nodes and node not in found)
+        return found
+
+
+def project_call_graph(files: Iterable[Tuple[str, List[str], Dict[str, List[Dict[str, Any]]]]]) -> ProjectCallGraph:
+    """Call graph across (relative path, lines, structure) files.
+
+    A call resolves to a function of that name in the same file if there
+    is one, else in the caller's class, else to every function of that name
+    in the project.
+    """
+    graph = ProjectCallGraph()
+    functions_by_file = []
+    by_short: Dict[str, List[str]] = {}
+    for file_name, lines, structure in files:
+        functions = functions_of(structure)
+        functions_by_file.append((file_name, lines, functions))
+        graph.files[file_name] = (lines, [item for items in structure.values() for item in items])
+        for function in functions:
+            node = f"{file_name}::{function['name']}"
+            graph.nodes[node] = {'file': file_name, 'name': function['name'], 'line': function['line'],
+                                 'line_end': function.get('line_end', function['line'])}
+            graph.edges[node] = []
+            by_short.setdefault(short_name(function['name']), []).append(node)
+
+    for file_name, lines, functions in functions_by_file:
+        for caller, name, line in call_sites(lines, functions):
+            caller_node = f"{file_name}::{caller['name']}"
+            candidates = by_short.get(name, [])
+            local = [node for node in candidates if graph.nodes[node]['file'] == file_name]
+            owned = [node for node in candidates
+                     if owner_name(graph.nodes[node]['name']) == owner_name(caller['name']) != '']
+            calls = graph.edges[caller_node]
+            for callee in local or owned or candidates:
+                if all(call['callee'] != callee for call in calls):
+                    calls.append({'callee': callee, 'line': line})
+    return graph
+
+
+def render_call_graph_dot(matches: List[Dict[str, Any]], title: str) -> str:
+    """DOT for symbols (highlighted) with their callers and callees ('file'/'name' links)."""
+    def node_id(link: Dict[str, Any]) -> str:
+        return _dot_id(f"{link['file']}::{link['name']}")
+
+    lines = [f'digraph {_dot_id(title)} {{',
+             '  rankdir=LR;',
+             '  node [shape=box, style="rounded,filled", fillcolor="#eef4ff", fontname="Helvetica", fontsize=10];',
+             '  edge [color="#57606a", arrowsize=0.7];']
+    for match in matches:
+        lines.append(f'  {node_id(match)} [fillcolor="#ffe8a3"];')
+        lines.extend(f'  {node_id(caller)} -> {node_id(match)};' for caller in match['callers'])
+        lines.extend(f'  {node_id(match)} -> {node_id(callee)};' for callee in match['callees'])
+    lines.append('}')
+    return '\n'.join(lines)
@@ -0,0 +1,38 @@
+"""Symbols touched by a change: diff hunks intersected with symbol line ranges."""
+
+from typing import Any, Dict, List, Set, Tuple
+
+from .base import category_kind
+from .tags import SKIPPED_CATEGORIES
+
+
+def touched_symbols(structure: Dict[str, List[Dict[str, Any]]], lines: Set[int],
+                    deletions: Set[int], new_file: bool = False) -> Tuple[List[Dict[str, Any]], int]:
+    """Symbols whose range holds changed lines or removed ones, and the changed lines outside every symbol.
+
+    Each symbol is {'name', 'kind', 'line', 'line_end', 'status', 'changed'}:
+    status 'added' when all its lines are new (or the file is), else
+    'modified'; changed counts its changed lines.
+    """
+    touched = []
+    inside: Set[int] = set()
+    for category, items in structure.items():
+        if category in SKIPPED_CATEGORIES:
+            continue
+        for item in items:
+            start = item.get('line')
+            if not item.get('name') or not isinstance(start, int):
+                continue
+            end = item.get('line_end') or start
+            span = set(range(start, end + 1))
+            inside |= span
+            changed = span & lines
+            removed = any(start <= line < end for line in deletions)
+            if not (new_file or changed or removed):
+                continue
+            status = 'added' if new_file or (changed == span and not removed) else 'modified'
+            touched.append({'name': item['name'], 'kind': item.get('kind') or category_kind(category),
+                            'line': start, 'line_end': end, 'status': status,
+                            'changed': len(span) if new_file else len(changed)})
+    touched.sort(key=lambda symbol: (symbol['line'], -symbol['line_end']))
+    return touched, len(lines - inside)
@@ -0,0 +1,295 @@
+"""Command-line interfaces: the subcommands, flags and defaults a program defines.
+
+Python programs are read with the ast module: argparse (parsers,
+subparsers, argument groups) and click/typer decorators (@command,
+@group, @option, @argument). Go programs are matched line by line:
+cobra commands (Use/Short) and their Flags()/PersistentFlags(), and the
+standard flag package, FlagSets included.
+"""
+
+import ast
+import re
+from typing import Any, Dict, List, Optional, Tuple
+
+from .routes import split_arguments
+
+
+ProgramCommand = Dict[str, Any]
+
+
+def _command(commands: Dict[str, ProgramCommand], name: str, line: int = 0, help_text: str = '') -> ProgramCommand:
+    command = commands.setdefault(name, {'name': name, 'help': '', 'line': line, 'options': []})
+    if help_text and not command['help']:
+        command['help'] = help_text
+    if line and not command['line']:
+        command['line'] = line
+    return command
+
+
+def _first_line(text: Optional[str]) -> str:
+    return text.strip().splitlines()[0].strip() if text and text.strip() else ''
+
+
+# ---------------------------------------------------------------------------
+# Python: argparse and click/typer
+# ---------------------------------------------------------------------------
+
+_CLICK_COMMANDS = ('command', 'group')
+_CLICK_PARAMETERS = ('option', 'argument')
+
+
+class _PythonCli(ast.NodeVisitor):
+    def __init__(self, source: str):
+        self.source = source
+        self.commands: Dict[str, ProgramCommand] = {}
+        self.frameworks: List[str] = []
+        # Expression text of a parser, subparsers action or argument group -> command name
+        self.parsers: Dict[str, str] = {}
+        self.subparsers: Dict[str, str] = {}
+
+    def text(self, node: Optional[ast.AST]) -> str:
+        return (ast.get_source_segment(self.source, node) or '') if node is not None else ''
+
+    def string(self, node: Optional[ast.AST]) -> Optional[str]:
+        return node.value if isinstance(node, ast.Constant) and isinstance(node.value, str) else None
+
+    def keywords(self, call: ast.Call) -> Dict[str, ast.AST]:
+        return {keyword.arg: keyword.value for keyword in call.keywords if keyword.arg}
+
+    def framework(self, name: str) -> None:
+        if name not in self.frameworks:
+            self.frameworks.append(name)
+
+    def visit_Assign(self, node: ast.Assign) -> None:
+        if isinstance(node.value, ast.Call):
+            for target in node.targets:
+                self.bind(self.text(target), node.value)
+        self.generic_visit(node)
+
+    def bind(self, target: str, call: ast.Call) -> None:
+        """Remember what a name holds: a parser, a subparsers action or an argument group."""
+        function = call.func
+        method = function.attr if isinstance(function, ast.Attribute) else getattr(function, 'id', '')
+        owner = self.text(function.value) if isinstance(function, ast.Attribute) else ''
+        if method == 'ArgumentParser':
+            self.parsers[target] = ''
+        elif method == 'add_subparsers':
+            self.subparsers[target] = self.parsers.get(owner, '')
+        elif method in ('add_argument_group', 'add_mutually_exclusive_group'):
+            self.parsers[target] = self.parsers.get(owner, '')
+        elif method == 'add_parser' and owner in self.subparsers:
+            self.parsers[target] = self.subcommand(owner, call)
+
+    def subcommand(self, subparsers: str, call: ast.Call) -> str:
+        name = self.string(call.args[0]) if call.args else None
+        if name is None:
+            return ''
+        parent = self.subparsers.get(subparsers, '')
+        full_name = f"{parent} {name}".strip()
+        keywords = self.keywords(call)
+        help_text = self.string(keywords.get('help')) or self.string(keywords.get('description')) or ''
+        _command(self.commands, full_name, call.lineno, _first_line(help_text))
+        return full_name
+
+    def visit_Call(self, node: ast.Call) -> None:
+        function = node.func
+        method = function.attr if isinstance(function, ast.Attribute) else getattr(function, 'id', '')
+        owner = self.text(function.value) if isinstance(function, ast.Attribute) else ''
+        if method == 'add_argument' and owner:
+            self.framework('argparse')
+            self.argparse_option(self.parsers.get(owner, ''), node)
+        elif method == 'add_parser' and owner in self.subparsers:
+            self.subcommand(owner, node)
+        elif method == 'ArgumentParser':
+            self.framework('argparse')
+            description = self.string(self.keywords(node).get('description'))
+            _command(self.commands, '', node.lineno, _first_line(description))
+        self.generic_visit(node)
+
+    def argparse_option(self, command_name: str, call: ast.Call) -> None:
+        names = [name for name in map(self.string, call.args) if name is not None]
+        if not names:
+            return
+        keywords = self.keywords(call)
+        action = self.string(keywords.get('action')) or ''
+        option = {'names': sorted(names, key=lambda n: (not n.startswith('--'), n)), 'line': call.lineno,
+                  'positional': not names[0].startswith('-'), 'help': _first_line(self.string(keywords.get('help')))}
+        if action in ('store_true', 'store_false', 'count', 'help', 'version'):
+            option['flag'] = True
+        if 'default' in keywords:
+            option['default'] = self.text(keywords['default'])
+        elif action == 'store_true':
+            option['default'] = 'False'
+        if 'choices' in keywords:
+            option['choices'] = self.text(keywords['choices'])
+        if keywords.get('required') is not None and self.text(keywords['required']) == 'True':
+            option['required'] = True
+        _command(self.commands, command_name)['options'].append(option)
+
+    def visit_FunctionDef(self, node: ast.FunctionDef) -> None:
+        self.click_command(node)
+        self.generic_visit(node)
+
+    visit_AsyncFunctionDef = visit_FunctionDef
+
+    def click_command(self, node: ast.FunctionDef) -> None:
+        decorators = [d for d in node.decorator_list if isinstance(d, ast.Call)]
+
+        def method(call):
+            function = call.func
+            return function.attr if isinstance(function, ast.Attribute) else getattr(function, 'id', '')
+
+        command_call = next((d for d in decorators if method(d) in _CLICK_COMMANDS), None)
+        if command_call is None:
+            return
+        self.framework('click')
+        keywords = self.keywords(command_call)
+        name = (self.string(command_call.args[0]) if command_call.args else None) or self.string(keywords.get('name'))
+        name = name or node.name.replace('_', '-')
+        help_text = self.string(keywords.get('help')) or ast.get_docstring(node)
+        command = _command(self.commands, name, node.lineno, _first_line(help_text))
+        # Decorators apply bottom-up; list parameters in source order
+        for call in decorators:
+            if method(call) not in _CLICK_PARAMETERS:
+                continue
+            names = [n for n in map(self.string, call.args) if n is not None]
+            dashed = [n for n in names if n.startswith('-')]
+            parameter = self.keywords(call)
+            option = {'names': sorted(dashed or names[:1], key=lambda n: (not n.startswith('--'), n)),
+                      'line': call.lineno, 'positional': method(call) == 'argument',
+                      'help': _first_line(self.string(parameter.get('help')))}
+            if not option['names']:
+                continue
+            if self.text(parameter.get('is_flag')) == 'True' or any('/' in n for n in names):
+                option['flag'] = True
+            if 'default' in parameter:
+                option['default'] = self.text(parameter['default'])
+            if self.text(parameter.get('required')) == 'True':
+                option['required'] = True
+            command['options'].append(option)
+
+
+def _python_cli(source: str) -> Tuple[List[str], List[ProgramCommand]]:
+    try:
+        tree = ast.parse(source)
+    except SyntaxError:
+        return [], []
+    visitor = _PythonCli(source)
+    visitor.visit(tree)
+    return visitor.frameworks, list(visitor.commands.values())
+
+
+# ---------------------------------------------------------------------------
+# Go: cobra and the flag package
+# ---------------------------------------------------------------------------
+
+_COBRA_COMMAND = re.compile(r'(?P<var>\w+)\s*:?=\s*&cobra\.Command\s*\{')
+_COBRA_FIELD = re.compile(r'\b(?P<field>Use|Short)\s*:\s*"(?P<value>(?:\\.|[^"\\])*)"')
+_COBRA_FLAG = re.compile(r'(?P<var>[\w.]+?)\.(?:Persistent)?Flags\(\)\.(?P<function>\w+)\((?P<args>.*)')
+_FLAG_SET = re.compile(r'(?P<var>\w+)\s*:?=\s*flag\.NewFlagSet\(\s*"(?P<name>[^"]*)"')
+_GO_FLAG = re.compile(r'(?<![\w.])(?P<set>\w+)\.(?P<function>(?:String|Bool|Int|Int64|Uint|Uint64|Float64|Duration|'
+                      r'Text)?(?:Var|Func)?)\((?P<args>.*)')
+_GO_STRING = re.compile(r'^"((?:\\.|[^"\\])*)"$|^`([^`]*)`$')
+
+
+def _go_string(text: str) -> Optional[str]:
+    match = _GO_STRING.match(text.strip())
+    return next((group for group in match.groups() if group is not None), None) if match else None
+
+
+def _go_cli(lines: List[str]) -> Tuple[List[str], List[ProgramCommand]]:
+    commands: Dict[str, ProgramCommand] = {}
+    frameworks: List[str] = []
+    cobra_names: Dict[str, str] = {}
+    flag_sets: Dict[str, str] = {'flag': ''}
+
+    for number, text in enumerate(lines, 1):
+        match = _COBRA_COMMAND.search(text)
+        if match:
+            fields = {}
+            for offset, following in enumerate(lines[number - 1:number + 30]):
+                if offset and _COBRA_COMMAND.search(following):
+                    break
+                for field in _COBRA_FIELD.finditer(following[match.end():] if not offset else following):
+                    fields.setdefault(field.group('field'), field.group('value'))
+                if following.strip().startswith('}'):
+                    break
+            name = fields.get('Use', match.group('var')).split(' ')[0]
+            cobra_names[match.group('var')] = name
+            _command(commands, name, number, fields.get('Short', ''))
+            if 'cobra' not in frameworks:
+                frameworks.append('cobra')
+        match = _FLAG_SET.search(text)
+        if match:
+            flag_sets[match.group('var')] = match.group('name')
+            _command(commands, match.group('name'), number)
+
+    for number, text in enumerate(lines, 1):
+        match = _COBRA_FLAG.search(text)
+        if match:
+            function = match.group('function')
+            arguments = split_arguments(match.group('args'))
+            if function.endswith('P'):
+                function = function[:-1]
+                shorthand = True
+            else:
+                shorthand = False
+            if function.endswith('Var'):
+                function, arguments = function[:-3], arguments[1:]
+            if not arguments or _go_string(arguments[0]) is None:
+                continue
+            names = ['--' + _go_string(arguments[0])]
+            rest = arguments[1:]
+            if shorthand and rest:
+                short = _go_string(rest[0])
+                if short:
+                    names.append('-' + short)
+                rest = rest[1:]
+            option = {'names': names, 'line': number, 'positional': False, 'help': _go_string(rest[-1]) or ''}
+            if function not in ('', 'Count') and len(rest) >= 2:
+                option['default'] = rest[0]
+            if function == 'Bool':
+                option['flag'] = True
+            owner = match.group('var').rsplit('.', 1)[-1]
+            _command(commands, cobra_names.get(owner, owner))['options'].append(option)
+            continue
+        match = _GO_FLAG.search(text)
+        if not match or match.group('set') not in flag_sets or not match.group('function'):
+            continue
+        function = match.group('function')
+        arguments = split_arguments(match.group('args'))
+        if function.endswith('Var'):
+            arguments = arguments[1:]
+        name = _go_string(arguments[0]) if arguments else None
+        if name is None:
+            continue
+        if 'flag' not in frameworks:
+            frameworks.append('flag')
+        option = {'names': ['-' + name], 'line': number, 'positional': False,
+                  'help': _go_string(arguments[-1]) or '' if len(arguments) > 1 else ''}
+        if function not in ('Var', 'Func', 'BoolFunc', 'TextVar') and len(arguments) >= 3:
+            option['default'] = arguments[1]
+        if function.startswith('Bool'):
+            option['flag'] = True
+        _command(commands, flag_sets[match.group('set')])['options'].append(option)
+    return frameworks, [command for command in commands.values() if command['options'] or command['name']]
+
+
+def find_cli(file_name: str, lines: List[str]) -> Optional[Dict[str, Any]]:
+    """The command-line interface a file defines, or None.
+
+    {'frameworks': [...], 'commands': [{'name', 'help', 'line', 'options'}]}:
+    the root command is named '' and subcommands by their path ('remote add').
+    Options are {'names', 'line', 'positional', 'help'} plus 'default' (as
+    written in the source), 'flag', 'choices' and 'required' when known.
+    """
+    if file_name.endswith('.py'):
+        frameworks, commands = _python_cli('\n'.join(lines))
+    elif file_name.endswith('.go'):
+        frameworks, commands = _go_cli(lines)
+    else:
+        return None
+    commands = [command for command in commands if command['options'] or command['name'] or command['help']]
+    if not frameworks or not commands:
+        return None
+    return {'frameworks': frameworks, 'commands': sorted(commands, key=lambda c: (c['name'] != '', c['name']))}
@@ -0,0 +1,32 @@
+"""Subcommands: reveal <command> [args] (search, deps, ...)."""
+
+from .base import Command, register_command, get_command_class, list_commands, run_command
+from .search import SearchCommand
+from .deps import DepsCommand
+from .hierarchy import HierarchyCommand
+from .implements import ImplementsCommand
+from .unused import UnusedCommand
+from .todos import TodosCommand
+from .doc_coverage import DocCoverageCommand
+from .dupes import DupesCommand
+from .testmap import TestMapCommand
+from .refs import RefsCommand
+from .diff import DiffCommand
+from .changes import ChangesCommand
+from .history import HistoryCommand
+from .secrets import SecretsCommand
+from .hotspots import HotspotsCommand
+from .routes import RoutesCommand
+from .cli import CliCommand
+from .envvars import EnvCommand
+from .strings import StringsCommand
+from .queries import QueriesCommand
+from .index import IndexCommand
+
+__all__ = ['Command', 'register_command', 'get_command_class', 'list_commands', 'run_command', 'SearchCommand',
+           'DepsCommand', 'HierarchyCommand', 'ImplementsCommand', 'UnusedCommand',
+           'TodosCommand', 'DocCoverageCommand', 'DupesCommand',
+           'TestMapCommand', 'RefsCommand', 'DiffCommand',
+           'ChangesCommand', 'HistoryCommand', 'SecretsCommand',
+           'HotspotsCommand', 'RoutesCommand', 'CliCommand', 'EnvCommand',
+           'StringsCommand', 'QueriesCommand', 'IndexCommand']
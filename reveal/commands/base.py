@@ -0,0 +1,152 @@
+"""Base command interface for `reveal <command>` subcommands."""
+
+import argparse
+import sys
+from abc import ABC, abstractmethod
+from pathlib import Path
+from typing import Any, Dict, Iterator, List, Optional, Tuple
+
+from ..base import get_analyzer, FileAnalyzer
+from ..cache import cached_structure
+from ..index import ProjectIndex
+from ..sniff import DEFAULT_MAX_FILE_SIZE, oversized, parse_size, skip_reason
+from ..tree_view import iter_directory_files
+from ..workers import ordered_map
+
+
+class Command(ABC):
+    """Base class for subcommands (reveal search, ...).
+
+    Subclasses declare their arguments and do their work in run(),
+    returning the process exit code.
+    """
+
+    name: str = ''
+    description: str = ''
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        """Add command-specific arguments to the parser."""
+
+    @abstractmethod
+    def run(self, args: argparse.Namespace) -> int:
+        """Run the command; returns the exit code."""
+        pass
+
+
+# Registry for subcommands
+_COMMAND_REGISTRY: Dict[str, type] = {}
+
+
+def register_command(name: str):
+    """Decorator to register a subcommand.
+
+    Usage:
+        @register_command('search')
+        class SearchCommand(Command):
+            ...
+    """
+    def decorator(cls):
+        _COMMAND_REGISTRY[name] = cls
+        cls.name = name
+        return cls
+    return decorator
+
+
+def get_command_class(name: str) -> Optional[type]:
+    """Get the command class registered under name, or None."""
+    return _COMMAND_REGISTRY.get(name)
+
+
+def list_commands() -> List[str]:
+    """Names of all registered commands."""
+    return sorted(_COMMAND_REGISTRY.keys())
+
+
+def run_command(command_class: type, argv: List[str]) -> int:
+    """Parse argv for a command and run it."""
+    command = command_class()
+    parser = argparse.ArgumentParser(prog=f'reveal {command.name}', description=command.description)
+    command.add_arguments(parser)
+    return command.run(parser.parse_args(argv))
+
+
+def add_walk_options(parser: argparse.ArgumentParser, generated: bool = True) -> None:
+    """Options shared by commands that walk a directory of source files.
+
+    generated=False leaves out --include-generated, for commands that read
+    generated and minified files like any other.
+    """
+    parser.add_argument('--depth', type=int, default=0,
+                        help='Directory depth to search (default: 0 = unlimited)')
+    parser.add_argument('--no-fallback', action='store_true',
+                        help='Disable TreeSitter fallback for unknown file types')
+    parser.add_argument('--jobs', '-j', type=int, default=0, metavar='N',
+                        help='Files analyzed in parallel (default: 0 = one per CPU)')
+    parser.add_argument('--no-cache', action='store_true',
+                        help='Re-parse every file instead of reusing structures cached in ~/.cache/reveal')
+    parser.add_argument('--no-ignore', action='store_true',
+                        help='Walk everything, ignoring .gitignore/.ignore and the built-in skips (node_modules, ...)')
+    if generated:
+        parser.add_argument('--include-generated', action='store_true',
+                            help='Analyze binary, minified and generated files too instead of skipping them')
+    parser.add_argument('--max-file-size', type=parse_size, default=DEFAULT_MAX_FILE_SIZE, metavar='SIZE',
+                        help='Skip files larger than SIZE in directories (default: 5MB, 0 = no limit)')
+    parser.add_argument('--no-index', action='store_true',
+                        help="Analyze every file even where the project index (reveal index build) is current")
+
+
+def analyzed_files(path: Path, args: argparse.Namespace, suffixes: Optional[Tuple[str, ...]] = None,
+                   mentions: Optional[str] = None, structure_fields: Optional[Tuple[str, ...]] = None
+                   ) -> Iterator[Tuple[Path, Optional[FileAnalyzer], Dict[str, List[Dict[str, Any]]]]]:
+    """Yield (file path, analyzer, structure) for a file, or every analyzable file under a directory.
+
+    Files without an analyzer (or, given suffixes, with other extensions)
+    are skipped, and in directories so are files over --max-file-size and
+    binary, minified and generated files unless --include-generated;
+    analysis errors are reported on stderr and skipped.
+    Files are analyzed on --jobs workers and yielded in walk order;
+    structures of unchanged files come from the cache unless --no-cache.
+
+    Under a project index (reveal index build), files unchanged since it
+    was built are looked up instead, unless --no-index: given mentions,
+    those that don't contain that identifier are skipped unread; given
+    structure_fields, the item fields the caller reads, their structure
+    comes from the index and the analyzer is None, as long as the index
+    keeps all of those fields (else they are analyzed).
+    """
+    if path.is_file():
+        files = iter([path])
+        index = None
+        # A file named on the command line is analyzed whatever it is
+        skip_generated = False
+        max_size = 0
+    else:
+        files = iter_directory_files(path, depth=args.depth if args.depth > 0 else sys.getrecursionlimit(),
+                                     ignore=not args.no_ignore)
+        index = None if args.no_index else ProjectIndex.load(path)
+        skip_generated = not args.include_generated
+        max_size = args.max_file_size
+
+    def analyze(file_path: Path):
+        entry = index.entry(file_path) if index is not None else None
+        if entry is not None and mentions is not None and not index.mentions(entry, mentions):
+            return None
+        if entry is not None and structure_fields is not None and index.keeps(structure_fields):
+            return file_path, None, entry['structure']
+        analyzer_class = get_analyzer(str(file_path), allow_fallback=not args.no_fallback)
+        if not analyzer_class or oversized(file_path, max_size) or skip_generated and skip_reason(file_path):
+            return None
+        try:
+            analyzer = analyzer_class(str(file_path))
+            return file_path, analyzer, cached_structure(analyzer, enabled=not args.no_cache)
+        except Exception as e:
+            return file_path, None, e
+
+    files = (file_path for file_path in files if not suffixes or file_path.suffix in suffixes)
+    for result in ordered_map(analyze, files, args.jobs):
+        if result is None:
+            continue
+        if isinstance(result[2], Exception):
+            print(f"Warning: Failed to analyze {result[0]}: {result[2]}", file=sys.stderr)
+            continue
+        yield result
@@ -0,0 +1,101 @@
+"""reveal changes: which functions and classes a branch or the working tree touched."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..base import get_analyzer
+from ..changes import touched_symbols
+from ..git import GitError, changed_lines, merge_base
+from .base import Command, register_command
+
+
+@register_command('changes')
+class ChangesCommand(Command):
+    """Summarize a change as the symbols it touched, from git diff hunks.
+
+    Without --since: uncommitted changes (staged, unstaged and untracked
+    files) against HEAD. With --since REF: everything since the branch
+    forked from REF, committed or not ("what did this branch change?").
+    A symbol is added when all its lines are new, else modified; changed
+    lines outside any symbol are counted per file.
+    """
+
+    description = 'Functions/classes touched since a git ref or by uncommitted changes'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='Directory inside the repository (default: .)')
+        parser.add_argument('--since', metavar='REF', help='Compare with the merge base of REF and HEAD (e.g. main)')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        parser.add_argument('--no-fallback', action='store_true',
+                            help='Disable fallback to tree-sitter for files without a dedicated analyzer')
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.is_dir():
+            print(f"Error: {args.path} is not a directory", file=sys.stderr)
+            return 2
+        try:
+            base = merge_base(args.since, path) if args.since else 'HEAD'
+            changed = changed_lines(path, base)
+        except GitError as e:
+            print(f"Error: {e}", file=sys.stderr)
+            return 2
+
+        files = []
+        for file_name in sorted(changed):
+            change = changed[file_name]
+            entry = {'file': file_name, 'status': change['status'], 'lines': len(change['lines'])}
+            file_path = path / file_name
+            analyzer_class = get_analyzer(str(file_path), allow_fallback=not args.no_fallback) \
+                if change['status'] != 'deleted' and file_path.is_file() else None
+            if analyzer_class:
+                try:
+                    analyzer = analyzer_class(str(file_path))
+                    structure = analyzer.get_structure()
+                except Exception as e:
+                    print(f"Warning: Failed to analyze {file_path}: {e}", file=sys.stderr)
+                else:
+                    new_file = change['status'] == 'added'
+                    if new_file:
+                        entry['lines'] = len(analyzer.lines)
+                    entry['symbols'], entry['outside'] = touched_symbols(
+                        structure, change['lines'], change['deletions'], new_file=new_file)
+            files.append(entry)
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'since': args.since, 'base': base, 'files': files}, indent=2))
+        else:
+            _print_changes(files, args.since, base)
+        return 0
+
+
+_STATUS_MARK = {'added': '+', 'modified': '~'}
+
+
+def _print_changes(files: List[Dict[str, Any]], since: str, base: str) -> None:
+    scope = f"since {since} (merge base {base[:8]}), including uncommitted changes" if since \
+        else "uncommitted changes against HEAD"
+    if not files:
+        print(f"No changes: {scope}")
+        return
+    print(f"Changes {scope}:\n")
+    for entry in files:
+        print(f"{entry['file']}  ({entry['status']}, {entry['lines']} "
+              f"{'line' if entry['lines'] == 1 else 'lines'})")
+        symbols = entry.get('symbols', [])
+        if symbols:
+            width = max(len(f"{s['kind']} {s['name']}") for s in symbols)
+            for s in symbols:
+                label = f"{s['kind']} {s['name']}"
+                print(f"  {_STATUS_MARK[s['status']]} {label:<{width}}  lines {s['line']}-{s['line_end']}")
+        if entry.get('outside'):
+            print(f"  ({entry['outside']} changed {'line' if entry['outside'] == 1 else 'lines'} outside symbols)")
+    symbols = [s for entry in files for s in entry.get('symbols', [])]
+    added = sum(1 for s in symbols if s['status'] == 'added')
+    print(f"\n{len(files)} {'file' if len(files) == 1 else 'files'}, {len(symbols)} "
+          f"{'symbol' if len(symbols) == 1 else 'symbols'} touched "
+          f"({added} added, {len(symbols) - added} modified)")
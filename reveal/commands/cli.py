@@ -0,0 +1,87 @@
+"""reveal cli: the subcommands, flags and defaults of command-line programs."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..cli_options import find_cli
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('cli')
+class CliCommand(Command):
+    """Show what a command-line tool accepts without running it.
+
+    Reads argparse and click/typer definitions in Python and cobra and
+    flag definitions in Go; for each program, its root command and
+    subcommands with their options, positional arguments and defaults as
+    written in the source.
+    """
+
+    description = 'Subcommands, flags and defaults of CLI programs (argparse, click, cobra, Go flag)'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        programs = []
+        for file_path, analyzer, _structure in analyzed_files(path, args, suffixes=('.py', '.go')):
+            cli = find_cli(file_path.name, analyzer.lines)
+            if cli:
+                programs.append({'file': str(file_path), **cli})
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'programs': programs}, indent=2))
+        else:
+            _print_programs(programs)
+        return 0
+
+
+def _option_info(option: Dict[str, Any]) -> str:
+    info = []
+    if option.get('required'):
+        info.append('required')
+    if option['positional']:
+        info.append('positional')
+    elif option.get('flag'):
+        info.append('flag')
+    if 'default' in option and not (option.get('flag') and option['default'] in ('False', 'false')):
+        info.append(f"default {option['default']}")
+    if option.get('choices'):
+        info.append(f"choices {option['choices']}")
+    return ', '.join(info)
+
+
+def _print_programs(programs: List[Dict[str, Any]]) -> None:
+    if not programs:
+        print("No command-line definitions found")
+        return
+    for program in programs:
+        print(f"{program['file']}  ({', '.join(program['frameworks'])})")
+        for command in program['commands']:
+            name = command['name'] or '(root)'
+            print(f"  {name}" + (f"  {command['help']}" if command['help'] else ''))
+            options = command['options']
+            if not options:
+                continue
+            names = [', '.join(option['names']) for option in options]
+            infos = [_option_info(option) for option in options]
+            name_width = max(len(n) for n in names)
+            info_width = min(max(len(i) for i in infos), 30)
+            for option, option_names, info in zip(options, names, infos):
+                print(f"    {option_names:<{name_width}}  {info:<{info_width}}  {option['help']}".rstrip())
+        print()
+    commands = sum(len(program['commands']) for program in programs)
+    options = sum(len(command['options']) for program in programs for command in program['commands'])
+    print(f"{len(programs)} {'program' if len(programs) == 1 else 'programs'}, {commands} "
+          f"{'command' if commands == 1 else 'commands'}, {options} {'option' if options == 1 else 'options'}")
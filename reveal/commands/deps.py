@@ -0,0 +1,117 @@
+"""reveal deps: the import graph of a whole project."""
+
+import argparse
+import json
+import sys
+from collections import Counter
+from pathlib import Path
+
+from ..dependencies import ImportGraph, build_import_graph, find_cycles, package_graph, render_dot
+from ..mermaid import render_flowchart
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('deps')
+class DepsCommand(Command):
+    """Aggregate every file's imports into a file-level dependency graph.
+
+    Text output ranks modules by fan-in (how many project files import
+    them) and fan-out (how many they import), and lists the most used
+    external packages; --format dot/mermaid exports the graph itself.
+    --cycles lists circular imports instead (exit status 1 when there are
+    any), and --packages works on directories rather than files, which is
+    how Go package cycles show up.
+    """
+
+    description = 'Project dependency graph: fan-in/fan-out per module, DOT or Mermaid export'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='Project directory (default: .)')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'dot', 'mermaid'],
+                            help='Output format (default: text)')
+        parser.add_argument('--external', action='store_true',
+                            help='Include imports that do not resolve to project files (dot/mermaid/json)')
+        parser.add_argument('--top', type=int, default=0, metavar='N',
+                            help='Only the N modules with the highest fan-in (text; default: all)')
+        parser.add_argument('--cycles', action='store_true',
+                            help='Report import cycles with the chain of files involved (exit 1 if any)')
+        parser.add_argument('--packages', action='store_true',
+                            help='Group files by directory (package-level graph, e.g. Go packages)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.is_dir():
+            print(f"Error: {args.path} is not a directory", file=sys.stderr)
+            return 2
+
+        graph = project_import_graph(path, args)
+        if args.packages:
+            graph = package_graph(graph)
+        if args.cycles:
+            cycles = find_cycles(graph)
+            if args.format == 'json':
+                print(json.dumps({'cycles': cycles, 'total': len(cycles)}, indent=2))
+            else:
+                _print_cycles(cycles, unit='packages' if args.packages else 'files')
+            return 1 if cycles else 0
+
+        if args.format == 'dot':
+            print(render_dot(graph, path.resolve().name, include_external=args.external))
+        elif args.format == 'mermaid':
+            print(render_flowchart(graph, include_external=args.external))
+        elif args.format == 'json':
+            print(json.dumps(graph_summary(graph, include_external=args.external), indent=2))
+        else:
+            _print_summary(graph, top=args.top)
+        return 0
+
+
+def project_import_graph(path: Path, args: argparse.Namespace) -> ImportGraph:
+    """Import graph of every analyzable file under path (posix paths relative to it)."""
+    return build_import_graph((file_path.relative_to(path).as_posix(), structure)
+                              for file_path, _, structure in analyzed_files(path, args))
+
+
+def graph_summary(graph: ImportGraph, include_external: bool = False) -> dict:
+    """JSON-ready modules (with fan-in/fan-out and imports) and edges."""
+    fan_in, fan_out = graph.fan_in(), graph.fan_out()
+    modules = []
+    for node in graph.nodes:
+        module = {'file': node, 'fan_in': fan_in[node], 'fan_out': fan_out[node],
+                  'imports': sorted(graph.edges[node]),
+                  'imported_by': sorted(source for source in graph.nodes if node in graph.edges[source])}
+        if include_external:
+            module['external'] = sorted(graph.external[node])
+        modules.append(module)
+    return {'modules': modules, 'edges': [list(edge) for edge in graph.edge_list(include_external)]}
+
+
+def _print_summary(graph: ImportGraph, top: int = 0) -> None:
+    fan_in, fan_out = graph.fan_in(), graph.fan_out()
+    edges = sum(fan_out.values())
+    print(f"Dependency graph: {len(graph.nodes)} modules, {edges} internal imports\n")
+    if not graph.nodes:
+        return
+
+    ranked = sorted(graph.nodes, key=lambda node: (-fan_in[node], -fan_out[node], node))
+    if top > 0:
+        ranked = ranked[:top]
+    width = max(len('Module'), *(len(node) for node in ranked))
+    print(f"{'Module':<{width}}  Fan-in  Fan-out")
+    for node in ranked:
+        print(f"{node:<{width}}  {fan_in[node]:>6}  {fan_out[node]:>7}")
+
+    external = Counter(target for targets in graph.external.values() for target in targets)
+    if external:
+        packages = ', '.join(f"{name} ({count})" for name, count in external.most_common(10))
+        print(f"\nMost imported external: {packages}")
+
+
+def _print_cycles(cycles, unit: str = 'files') -> None:
+    if not cycles:
+        print("No import cycles found")
+        return
+    print(f"Found {len(cycles)} import {'cycle' if len(cycles) == 1 else 'cycles'}:\n")
+    for number, cycle in enumerate(cycles, 1):
+        print(f"{number}. {' → '.join(cycle)}  ({len(cycle) - 1} {unit})")
@@ -0,0 +1,129 @@
+"""reveal diff: structural differences between two versions of a file or a tree."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict
+
+from ..diff import (diff_summary, diff_symbols, diff_trees, file_symbols, render_diff_markdown, render_diff_text,
+                    tree_summary)
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('diff')
+class DiffCommand(Command):
+    """Compare two files, or two directory trees, by their symbols instead of their lines.
+
+    Reports functions, classes and other symbols added or removed, renamed
+    (same kind, near-identical body), and changed: a new signature, or a
+    body that differs beyond comments and formatting, with the size
+    change. For directories, files are paired by relative path: new and
+    removed files, then the changed public symbols of each file (--all
+    for private ones too); --format markdown reads as release notes. Exit
+    status follows diff(1): 0 when nothing changed, 1 when something did,
+    2 on errors.
+    """
+
+    description = 'Structural diff of two files or trees: symbols added/removed/renamed, signature and size changes'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('old', help='Old version of the file or directory')
+        parser.add_argument('new', help='New version of the file or directory')
+        parser.add_argument('--all', action='store_true', help='Directories: include private symbols')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'markdown'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        old, new = Path(args.old), Path(args.new)
+        for path in (old, new):
+            if not path.exists():
+                print(f"Error: {path} not found", file=sys.stderr)
+                return 2
+        if old.is_dir() != new.is_dir():
+            print("Error: compare a file with a file, or a directory with a directory", file=sys.stderr)
+            return 2
+        if old.is_dir():
+            return self._diff_trees(old, new, args)
+
+        symbols = []
+        for path in (old, new):
+            analyzed = next(analyzed_files(path, args), None)
+            if analyzed is None:
+                print(f"Error: No analyzer found for {path}", file=sys.stderr)
+                return 2
+            _, analyzer, structure = analyzed
+            symbols.append(file_symbols(path.name, analyzer.lines, structure))
+
+        diff = diff_symbols(*symbols)
+        changed = any(diff[key] for key in ('added', 'removed', 'renamed', 'changed'))
+        if args.format == 'json':
+            print(json.dumps({'old': str(old), 'new': str(new), **diff}, indent=2))
+        elif args.format == 'markdown':
+            print(f"## `{old}` → `{new}`\n")
+            print('\n'.join(render_diff_markdown(diff)) if changed else 'No structural changes.')
+        else:
+            print(f"{old} → {new}\n")
+            for line in render_diff_text(diff):
+                print(line)
+            print(f"\n{diff_summary(diff)}" if changed else f"No structural changes ({diff['unchanged']} symbols)")
+        return 1 if changed else 0
+
+    def _diff_trees(self, old: Path, new: Path, args: argparse.Namespace) -> int:
+        versions = [{file_path.relative_to(root).as_posix(): (analyzer.lines, structure)
+                     for file_path, analyzer, structure in analyzed_files(root, args)} for root in (old, new)]
+        tree = diff_trees(*versions, public_only=not args.all)
+        changed = bool(tree['added_files'] or tree['removed_files'] or tree['files'])
+        if args.format == 'json':
+            print(json.dumps({'old': str(old), 'new': str(new), **tree}, indent=2))
+        elif args.format == 'markdown':
+            _print_tree_markdown(old, new, tree, changed)
+        else:
+            _print_tree(old, new, tree, changed)
+        return 1 if changed else 0
+
+
+def _print_tree(old: Path, new: Path, tree: Dict[str, Any], changed: bool) -> None:
+    print(f"{old} → {new}\n")
+    if not changed:
+        print("No structural changes")
+        return
+    if tree['added_files']:
+        print(f"New files ({len(tree['added_files'])}):")
+        for added in tree['added_files']:
+            count = len(added['symbols'])
+            print(f"  + {added['file']}  ({count} {'symbol' if count == 1 else 'symbols'})")
+        print()
+    if tree['removed_files']:
+        print(f"Removed files ({len(tree['removed_files'])}):")
+        for removed in tree['removed_files']:
+            print(f"  - {removed}")
+        print()
+    if tree['files']:
+        print(f"Changed files ({len(tree['files'])}):")
+        for diff in tree['files']:
+            print(f"  {diff['file']}")
+            for line in render_diff_text(diff):
+                print(f"    {line}")
+        print()
+    print(tree_summary(tree))
+
+
+def _print_tree_markdown(old: Path, new: Path, tree: Dict[str, Any], changed: bool) -> None:
+    print(f"## Changes: `{old}` → `{new}`")
+    if not changed:
+        print("\nNo structural changes.")
+        return
+    if tree['added_files']:
+        print("\n### New files\n")
+        for added in tree['added_files']:
+            symbols = ', '.join(f"`{name}`" for name in added['symbols'])
+            print(f"- `{added['file']}`" + (f": {symbols}" if symbols else ''))
+    if tree['removed_files']:
+        print("\n### Removed files\n")
+        for removed in tree['removed_files']:
+            print(f"- `{removed}`")
+    for diff in tree['files']:
+        print(f"\n### `{diff['file']}`\n")
+        print('\n'.join(render_diff_markdown(diff)))
@@ -0,0 +1,94 @@
+"""reveal doc-coverage: how much of the public API has docstrings or doc comments."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..api import api_entries, is_documented
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+# Kinds counted: functions and types, not properties or fields
+_SKIPPED_KINDS = {'property', 'field'}
+
+
+@register_command('doc-coverage')
+class DocCoverageCommand(Command):
+    """Report the share of public functions and classes with documentation, per file and overall.
+
+    Public means what --api shows; a docstring or a comment directly above
+    the definition counts as documentation, decorators and attributes do
+    not. --min PERCENT makes the command fail (exit 1) below a threshold.
+    """
+
+    description = 'Percentage of public functions/classes with docstrings or doc comments, and what lacks them'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--min', type=float, metavar='PERCENT',
+                            help='Exit 1 if overall coverage is below PERCENT')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        base = path if path.is_dir() else path.parent
+        files = []
+        for file_path, analyzer, structure in analyzed_files(path, args):
+            file_name = file_path.relative_to(base).as_posix()
+            symbols = [entry for entry in api_entries(file_name, analyzer.lines, structure)
+                       if entry['kind'] not in _SKIPPED_KINDS]
+            if symbols:
+                undocumented = [{'line': e['line'], 'name': e['name'], 'kind': e['kind']}
+                                for e in symbols if not is_documented(e)]
+                files.append({'file': file_name, 'public': len(symbols),
+                              'documented': len(symbols) - len(undocumented),
+                              'coverage': _percent(len(symbols) - len(undocumented), len(symbols)),
+                              'undocumented': undocumented})
+
+        public = sum(f['public'] for f in files)
+        documented = sum(f['documented'] for f in files)
+        summary = {'path': str(path), 'public': public, 'documented': documented,
+                   'coverage': _percent(documented, public), 'files': files}
+        if args.format == 'json':
+            print(json.dumps(summary, indent=2))
+        else:
+            _print_report(summary)
+        if args.min is not None and public and summary['coverage'] < args.min:
+            if args.format == 'text':
+                print(f"\nCoverage {summary['coverage']:.1f}% is below --min {args.min:g}%")
+            return 1
+        return 0
+
+
+def _percent(part: int, whole: int) -> float:
+    return round(100.0 * part / whole, 1) if whole else 100.0
+
+
+def _print_report(summary: Dict[str, Any]) -> None:
+    files: List[Dict[str, Any]] = summary['files']
+    if not files:
+        print("No public functions or classes found")
+        return
+    width = max(len('File'), *(len(f['file']) for f in files))
+    print(f"{'File':<{width}}  Documented  Coverage")
+    for f in sorted(files, key=lambda f: (f['coverage'], f['file'])):
+        print(f"{f['file']:<{width}}  {f['documented']:>5}/{f['public']:<4}  {f['coverage']:>7.1f}%")
+    print(f"\nOverall: {summary['documented']}/{summary['public']} public symbols documented "
+          f"({summary['coverage']:.1f}%)")
+
+    missing = [(f['file'], symbol) for f in files for symbol in f['undocumented']]
+    if missing:
+        print("\nUndocumented:")
+        location_width = max(len(f"{name}:{symbol['line']}") for name, symbol in missing)
+        kind_width = max(len(symbol['kind']) for _, symbol in missing)
+        for name, symbol in missing:
+            location = f"{name}:{symbol['line']}"
+            print(f"  {location:<{location_width}}  {symbol['kind']:<{kind_width}}  {symbol['name']}")
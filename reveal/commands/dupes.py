@@ -0,0 +1,68 @@
+"""reveal dupes: functions whose bodies are copies, or near-copies, of each other."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..dupes import find_duplicates, project_functions
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('dupes')
+class DupesCommand(Command):
+    """Group functions with identical or near-identical bodies across the project.
+
+    Bodies are compared as token shingles with comments, formatting and
+    literal values ignored; --threshold sets how similar (percent) two
+    bodies must be, --min-lines how long, so one-line accessors do not
+    flood the report. Exits 1 when duplicates are found.
+    """
+
+    description = 'Duplicate and near-duplicate functions, by hashed normalized bodies'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='Project directory (default: .)')
+        parser.add_argument('--threshold', type=float, default=80, metavar='PERCENT',
+                            help='Minimum similarity to report (default: 80; 100 = identical only)')
+        parser.add_argument('--min-lines', type=int, default=5, metavar='N',
+                            help='Ignore functions with fewer than N body lines (default: 5)')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        base = path if path.is_dir() else path.parent
+        functions = project_functions(((file_path.relative_to(base).as_posix(), analyzer.lines, structure)
+                                       for file_path, analyzer, structure in analyzed_files(path, args)),
+                                      min_lines=args.min_lines)
+        groups = find_duplicates(functions, threshold=args.threshold / 100)
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'checked': len(functions), 'groups': groups,
+                              'duplicated_lines': sum(g['lines'] for g in groups)}, indent=2))
+        else:
+            _print_groups(groups, len(functions))
+        return 1 if groups else 0
+
+
+def _print_groups(groups: List[Dict[str, Any]], checked: int) -> None:
+    if not groups:
+        print(f"No duplicate functions ({checked} functions checked)")
+        return
+    for number, group in enumerate(groups, 1):
+        similar = 'identical' if group['similarity'] >= 1 else f"{group['similarity']:.0%} similar"
+        print(f"{number}. {similar}, {len(group['functions'])} functions")
+        width = max(len(f"{f['file']}:{f['line']}-{f['line_end']}") for f in group['functions'])
+        for function in group['functions']:
+            location = f"{function['file']}:{function['line']}-{function['line_end']}"
+            print(f"   {location:<{width}}  {function['name']}")
+        print()
+    functions = sum(len(g['functions']) for g in groups)
+    print(f"{len(groups)} duplicate {'group' if len(groups) == 1 else 'groups'}: {functions} of {checked} "
+          f"functions, {sum(g['lines'] for g in groups)} duplicated lines")
@@ -0,0 +1,90 @@
+"""reveal env: the environment variables a project reads, with where it reads them."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..base import enclosing_symbols
+from ..envvars import ENV_SUFFIXES, env_variables, find_env_reads
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('env')
+class EnvCommand(Command):
+    """List every environment variable read in a project, with each location that reads it.
+
+    Finds os.getenv/os.environ (Python), os.Getenv (Go), process.env
+    (JavaScript and TypeScript), ENV (Ruby), env::var (Rust),
+    System.getenv (Java) and their kin. A variable is required when some
+    read has no fallback; --required keeps only those. Only source files
+    of those languages are read, not docs or config that quote such code.
+    --format markdown prints a table ready for a configuration doc.
+    """
+
+    description = 'Environment variables read (os.getenv, process.env, ...): required or defaulted, and where'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--required', action='store_true', help='Only variables read somewhere without a default')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep', 'markdown'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        reads = []
+        for file_path, analyzer, structure in analyzed_files(path, args, suffixes=ENV_SUFFIXES):
+            for read in find_env_reads(file_path.name, analyzer.lines):
+                read['file'] = str(file_path)
+                enclosing = enclosing_symbols(structure, read['line'], read['line'])
+                if enclosing:
+                    read['symbol'] = enclosing[-1]['name']
+                reads.append(read)
+        variables = env_variables(reads)
+        if args.required:
+            variables = [variable for variable in variables if variable['required']]
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'variables': variables, 'total': len(variables)}, indent=2))
+        elif args.format == 'grep':
+            for variable in variables:
+                for use in variable['uses']:
+                    print(f"{use['file']}:{use['line']}:{variable['name']}")
+        elif args.format == 'markdown':
+            _print_markdown(variables)
+        else:
+            _print_variables(variables)
+        return 0
+
+
+def _print_variables(variables: List[Dict[str, Any]]) -> None:
+    if not variables:
+        print("No environment variables found")
+        return
+    width = max(len(variable['name']) for variable in variables)
+    for variable in variables:
+        status = 'required' if variable['required'] else 'default ' + ' | '.join(variable['defaults'])
+        print(f"{variable['name']:<{width}}  {status}")
+        for use in variable['uses']:
+            context = f"  [{use['symbol']}]" if use.get('symbol') else ''
+            default = f"  (default {use['default']})" if 'default' in use and variable['required'] else ''
+            print(f"    {use['file']}:{use['line']}{context}{default}")
+    required = sum(1 for variable in variables if variable['required'])
+    files = len({use['file'] for variable in variables for use in variable['uses']})
+    print(f"\n{len(variables)} {'variable' if len(variables) == 1 else 'variables'} ({required} required) "
+          f"in {files} {'file' if files == 1 else 'files'}")
+
+
+def _print_markdown(variables: List[Dict[str, Any]]) -> None:
+    print("| Variable | Required | Default | Used in |")
+    print("|---|---|---|---|")
+    for variable in variables:
+        default = ', '.join(f"`{value}`" for value in variable['defaults'])
+        used = ', '.join(sorted({f"`{use['file']}`" for use in variable['uses']}))
+        print(f"| `{variable['name']}` | {'yes' if variable['required'] else 'no'} | {default} | {used} |")
@@ -0,0 +1,84 @@
+"""reveal hierarchy: class inheritance trees across a project."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..hierarchy import TypeHierarchy, hierarchy_tree, project_hierarchy, render_hierarchy_mermaid
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('hierarchy')
+class HierarchyCommand(Command):
+    """Show which types extend, implement or embed which, as trees.
+
+    Each tree starts at a project type with subtypes but no bases of its
+    own, or at a base declared outside the project (Exception, io.Reader);
+    types with neither bases nor subtypes are left out. --type narrows the
+    output to the trees a type appears in.
+    """
+
+    description = 'Class inheritance trees: extends/implements (Python, Java, ...) and Go embedding'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--type', '-t', metavar='NAME', help='Only the trees containing this type')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'mermaid'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        base = path if path.is_dir() else path.parent
+        hierarchy = project_hierarchy((file_path.relative_to(base).as_posix(), analyzer.lines, structure)
+                                      for file_path, analyzer, structure in analyzed_files(path, args))
+        trees = [hierarchy_tree(hierarchy, root) for root in hierarchy.roots()]
+        if args.type:
+            wanted = {hierarchy.label(node) for node in hierarchy.find(args.type)}
+            trees = [tree for tree in trees if _contains(tree, wanted)]
+            if not trees:
+                print(f"No hierarchy found for '{args.type}'", file=sys.stderr)
+                return 1
+
+        if args.format == 'json':
+            print(json.dumps({'types': len(hierarchy.types), 'trees': trees}, indent=2))
+        elif args.format == 'mermaid':
+            print(render_hierarchy_mermaid(hierarchy))
+        else:
+            _print_trees(hierarchy, trees)
+        return 0
+
+
+def _contains(tree: Dict[str, Any], names) -> bool:
+    return tree['name'] in names or any(_contains(child, names) for child in tree['children'])
+
+
+def _print_trees(hierarchy: TypeHierarchy, trees: List[Dict[str, Any]]) -> None:
+    if not trees:
+        print(f"No inheritance found ({len(hierarchy.types)} types)")
+        return
+    for tree in trees:
+        print(_tree_label(tree))
+        _print_children(tree['children'], '')
+    related = sum(1 for node in hierarchy.types if hierarchy.bases[node] or hierarchy.children(node))
+    print(f"\n{related} of {len(hierarchy.types)} types in {len(trees)} {'tree' if len(trees) == 1 else 'trees'}")
+
+
+def _print_children(children: List[Dict[str, Any]], prefix: str) -> None:
+    for index, child in enumerate(children):
+        last = index == len(children) - 1
+        print(f"{prefix}{'└─ ' if last else '├─ '}{_tree_label(child)}")
+        _print_children(child['children'], prefix + ('   ' if last else '│  '))
+
+
+def _tree_label(node: Dict[str, Any]) -> str:
+    if node.get('external'):
+        return f"{node['name']}  (external)"
+    relation = f"  [{node['relation']}]" if node.get('relation', 'extends') != 'extends' else ''
+    return f"{node['name']}  {node['kind']}  {node['file']}:{node['line']}{relation}"
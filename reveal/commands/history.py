@@ -0,0 +1,87 @@
+"""reveal history: the commits that changed one function or class."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..base import category_kind, find_symbols, get_analyzer, split_symbol_target, use_source
+from ..git import GitError, file_at, line_history
+from .base import Command, register_command
+
+
+@register_command('history')
+class HistoryCommand(Command):
+    """List the commits that modified a symbol, newest first.
+
+    The symbol is located in the file as of --rev (default HEAD), so its
+    line range matches what git knows; git log -L then follows that range
+    back through the edits that moved it. Each commit shows its date,
+    author, subject and the lines it added and removed in the symbol.
+    """
+
+    description = "Commits that changed a function or class (git log -L on the symbol's line range)"
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('target', help='file::Symbol (server.go::Server.Start), or a file followed by the symbol')
+        parser.add_argument('symbol', nargs='?', help='Symbol name when not given as file::Symbol')
+        parser.add_argument('--rev', default='HEAD', metavar='REF', help='Start from this revision (default: HEAD)')
+        parser.add_argument('-n', '--limit', type=int, metavar='N', help='Show at most N commits')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        parser.add_argument('--no-fallback', action='store_true',
+                            help='Disable fallback to tree-sitter for files without a dedicated analyzer')
+
+    def run(self, args: argparse.Namespace) -> int:
+        file_name, symbol = args.target, args.symbol
+        if not symbol:
+            file_name, symbol = split_symbol_target(args.target)
+            if not symbol and '::' in args.target:
+                # Deleted from the working tree, still in history
+                file_name, _, symbol = args.target.partition('::')
+        if not symbol:
+            print("Error: no symbol given (use file::Symbol)", file=sys.stderr)
+            return 2
+        path = Path(file_name)
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {file_name}", file=sys.stderr)
+            return 2
+
+        try:
+            use_source(str(path), file_at(path, args.rev), args.rev)
+            analyzer = analyzer_class(str(path))
+            structure = analyzer.get_structure()
+            items = [dict(item, kind=item.get('kind') or category_kind(category))
+                     for category, items in structure.items() for item in items]
+            match = next(iter(find_symbols(items, analyzer.lines, symbol)), None)
+            if match is None:
+                print(f"Error: Symbol '{symbol}' not found in {file_name} at {args.rev}", file=sys.stderr)
+                return 2
+            start, end = match['line'], match.get('line_end') or match['line']
+            commits = line_history(path, start, end, args.rev, args.limit)
+        except GitError as e:
+            print(f"Error: {e}", file=sys.stderr)
+            return 2
+
+        result = {'file': file_name, 'symbol': symbol, 'kind': match['kind'], 'revision': args.rev,
+                  'line': start, 'line_end': end, 'commits': commits}
+        if args.format == 'json':
+            print(json.dumps(result, indent=2))
+        else:
+            _print_history(result)
+        return 0
+
+
+def _print_history(result: Dict[str, Any]) -> None:
+    commits: List[Dict[str, Any]] = result['commits']
+    print(f"{result['file']}::{result['symbol']}  ({result['kind']}, lines {result['line']}-{result['line_end']} "
+          f"at {result['revision']})\n")
+    if not commits:
+        print("No commits touch these lines")
+        return
+    width = max(len(c['author']) for c in commits)
+    for c in commits:
+        print(f"  {c['commit']}  {c['date']}  {c['author']:<{width}}  {c['subject']}  (+{c['added']} -{c['removed']})")
+    print(f"\n{len(commits)} {'commit' if len(commits) == 1 else 'commits'}")
@@ -0,0 +1,108 @@
+"""reveal hotspots: the longest, most complex functions of a project, ranked."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..metrics import function_metrics, hotspot_score
+from ..rules.base import Detection
+from ..rules.complexity.C901 import C901
+from ..rules.complexity.C902 import C902
+from ..rules.complexity.C905 import C905
+from ..sarif import sarif_log
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('hotspots')
+class HotspotsCommand(Command):
+    """Rank every function in a project by length, complexity and nesting together.
+
+    Each is scored against the limits (--max-lines, --max-complexity,
+    --max-nesting) and the top N are listed with the limits they exceed:
+    refactoring targets, from the same metrics as --metrics. With
+    --format sarif every function over a limit is reported, as C902
+    (lines), C901 (complexity) or C905 (nesting).
+    """
+
+    description = 'Project-wide ranking of functions by length and complexity (refactoring targets)'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--top', type=int, default=20, metavar='N', help='Show the N worst functions (default: 20)')
+        parser.add_argument('--max-lines', type=int, default=50, metavar='N',
+                            help='Length limit in lines (default: 50)')
+        parser.add_argument('--max-complexity', type=int, default=10, metavar='N',
+                            help='Cyclomatic complexity limit (default: 10)')
+        parser.add_argument('--max-nesting', type=int, default=4, metavar='N',
+                            help='Nesting depth limit (default: 4)')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'sarif'],
+                            help='Output format (default: text; sarif reports every function over a limit)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        functions = []
+        for file_path, analyzer, structure in analyzed_files(path, args):
+            for function in function_metrics(analyzer.lines, structure):
+                function['file'] = str(file_path)
+                function['score'] = round(hotspot_score(function, args.max_lines, args.max_complexity,
+                                                        args.max_nesting), 2)
+                function['exceeds'] = [limit for limit, value, maximum in (
+                    ('lines', function['lines'], args.max_lines),
+                    ('complexity', function['complexity'], args.max_complexity),
+                    ('nesting', function['nesting'], args.max_nesting)) if value > maximum]
+                functions.append(function)
+        functions.sort(key=lambda f: (-f['score'], f['file'], f['line']))
+        top = functions[:args.top] if args.top > 0 else functions
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'functions': len(functions),
+                              'limits': {'lines': args.max_lines, 'complexity': args.max_complexity,
+                                         'nesting': args.max_nesting},
+                              'over_limits': sum(1 for f in functions if f['exceeds']), 'hotspots': top}, indent=2))
+        elif args.format == 'sarif':
+            print(json.dumps(sarif_log(_limit_detections(functions, args)), indent=2))
+        else:
+            _print_hotspots(top, functions)
+        return 0
+
+
+def _limit_detections(functions: List[Dict[str, Any]], args: argparse.Namespace) -> List[Detection]:
+    """One detection per limit a function exceeds, against the limits given on the command line."""
+    detections = []
+    for function in functions:
+        if 'lines' in function['exceeds']:
+            detections.append(C902().function_detection(function['file'], function, args.max_lines))
+        if 'complexity' in function['exceeds']:
+            rule = C901()
+            detections.append(rule.create_detection(
+                file_path=function['file'], line=function['line'],
+                message=f"{rule.message}: {function['name']} (complexity: {function['complexity']}, "
+                        f"max: {args.max_complexity})",
+                suggestion="Break into smaller functions or reduce branching",
+                context=f"Function: {function['name']}"))
+        if 'nesting' in function['exceeds']:
+            detections.append(C905().function_detection(function['file'], function, args.max_nesting))
+    return detections
+
+
+def _print_hotspots(top: List[Dict[str, Any]], functions: List[Dict[str, Any]]) -> None:
+    if not top:
+        print("No functions found")
+        return
+    location_width = max(len(f"{f['file']}:{f['line']}") for f in top)
+    name_width = min(max(len(str(f['name'])) for f in top), 40)
+    print(f"  #  {'Location':<{location_width}}  {'Function':<{name_width}}  Score  Lines  Complexity  Nesting")
+    for rank, f in enumerate(top, 1):
+        location = f"{f['file']}:{f['line']}"
+        exceeds = f"  ⚠️ {', '.join(f['exceeds'])}" if f['exceeds'] else ''
+        print(f"{rank:>3}  {location:<{location_width}}  {str(f['name']):<{name_width}}  {f['score']:>5.1f}  "
+              f"{f['lines']:>5}  {f['complexity']:>10}  {f['nesting']:>7}{exceeds}")
+    over = sum(1 for f in functions if f['exceeds'])
+    print(f"\nTop {len(top)} of {len(functions)} functions; {over} over a limit")
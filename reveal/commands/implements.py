@@ -0,0 +1,84 @@
+"""reveal implements: Go interface implementations, and the interfaces a type satisfies."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..implements import GO_STD_INTERFACES, go_package_index, implementations, satisfied_interfaces
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('implements')
+class ImplementsCommand(Command):
+    """Answer "what implements io.Reader?" and "what does *File satisfy?" for Go code.
+
+    Given an interface (a project one, or a common standard library one
+    such as io.Reader, error, http.Handler or sort.Interface) it lists the
+    project types whose method sets satisfy it; given a concrete type it
+    lists the interfaces that type satisfies. Types that only satisfy an
+    interface through pointer-receiver methods are shown as *T.
+    """
+
+    description = 'Go: types implementing an interface, or interfaces a type satisfies (method sets)'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('name', help='Interface (io.Reader, store.Store) or type (File) name')
+        parser.add_argument('path', nargs='?', default='.', help='Go file or project directory (default: .)')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        base = path if path.is_dir() else path.parent
+        index = go_package_index((file_path.relative_to(base).as_posix(), analyzer.lines)
+                                 for file_path, analyzer, _ in analyzed_files(path, args, suffixes=('.go',)))
+        key = index.lookup(args.name)
+        if key and index.types[key]['kind'] != 'interface':
+            info = index.types[key]
+            result = {'type': key[1], 'file': info['file'], 'line': info['line'],
+                      'satisfies': satisfied_interfaces(index, key)}
+            found = result['satisfies']
+        elif key or args.name in GO_STD_INTERFACES:
+            result = {'interface': args.name, 'implementations': implementations(index, args.name)}
+            if key:
+                result.update(file=index.types[key]['file'], line=index.types[key]['line'])
+            found = result['implementations']
+        else:
+            print(f"Error: no Go interface or type named '{args.name}' in {args.path}", file=sys.stderr)
+            return 2
+
+        if args.format == 'json':
+            print(json.dumps(result, indent=2))
+        else:
+            _print_result(result)
+        return 0 if found else 1
+
+
+def _location(entry: Dict[str, Any]) -> str:
+    return f"{entry['file']}:{entry['line']}" if entry.get('file') else 'standard library'
+
+
+def _print_result(result: Dict[str, Any]) -> None:
+    if 'satisfies' in result:
+        rows: List[Dict[str, Any]] = result['satisfies']
+        print(f"{result['type']} ({_location(result)}) satisfies {len(rows)} "
+              f"{'interface' if len(rows) == 1 else 'interfaces'}")
+        names = [row['interface'] + (f" (as *{result['type']})" if row['pointer'] else '') for row in rows]
+    else:
+        rows = result['implementations']
+        print(f"{result['interface']} ({_location(result)}) is implemented by {len(rows)} "
+              f"{'type' if len(rows) == 1 else 'types'}")
+        names = [('*' if row['pointer'] else '') + row['type'] for row in rows]
+    if not rows:
+        return
+    print()
+    width = max(len(name) for name in names)
+    for name, row in zip(names, rows):
+        print(f"  {name:<{width}}  {_location(row) if row.get('file') else '(standard library)'}")
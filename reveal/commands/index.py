@@ -0,0 +1,88 @@
+"""reveal index: build a project symbol index, and look symbols up in it."""
+
+import argparse
+import json
+import os
+import re
+import sys
+import time
+from pathlib import Path
+
+from ..index import ProjectIndex, build_index, write_index
+from .base import Command, register_command, add_walk_options, analyzed_files
+from .search import _print_hits, kind_filter, name_matcher, search_structure
+
+
+@register_command('index')
+class IndexCommand(Command):
+    """Build the symbol index of a project (reveal index build) or query it (reveal index query NAME).
+
+    build analyzes every file under the directory and writes
+    .reveal/index.json there. query answers from the index alone, without
+    touching the files, so it is instant but as old as the last build;
+    search, refs and --call-graph use the index too, re-analyzing the
+    files changed since.
+    """
+
+    description = 'Build a symbol index (.reveal/index.json) or query it by name'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        actions = parser.add_subparsers(dest='action', metavar='{build,query}')
+        actions.required = True
+        build = actions.add_parser('build', help='Index every file under a directory')
+        build.add_argument('path', nargs='?', default='.', help='Directory to index (default: .)')
+        add_walk_options(build)
+        query = actions.add_parser('query', help='Look up definitions by name in the index')
+        query.add_argument('pattern', help="Name to look for: substring, glob ('parse_*') or --regex")
+        query.add_argument('path', nargs='?', default='.', help='Directory inside the indexed project (default: .)')
+        query.add_argument('--regex', '-E', action='store_true', help='Treat the pattern as a regular expression')
+        query.add_argument('--kind', '-k', metavar='KIND[,KIND]', help='Only these kinds: function, class, ...')
+        query.add_argument('--case-sensitive', '-s', action='store_true', help='Match case exactly')
+        query.add_argument('--format', default='text', choices=['text', 'json', 'grep'],
+                           help='Output format (default: text)')
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.is_dir():
+            print(f"Error: {args.path} is not a directory", file=sys.stderr)
+            return 2
+        return self._build(path, args) if args.action == 'build' else self._query(path, args)
+
+    def _build(self, path: Path, args: argparse.Namespace) -> int:
+        started = time.time()
+        index = build_index(path, analyzed_files(path, args))
+        target = write_index(path, index)
+        symbols = sum(len(items) for entry in index['files'].values() for items in entry['structure'].values())
+        print(f"Indexed {len(index['files'])} files ({symbols} symbols) in {time.time() - started:.1f}s "
+              f"→ {target} ({target.stat().st_size / 1024:.0f} KB)")
+        return 0
+
+    def _query(self, path: Path, args: argparse.Namespace) -> int:
+        index = ProjectIndex.load(path)
+        if index is None:
+            print(f"Error: No current index for {args.path}; run 'reveal index build' first", file=sys.stderr)
+            return 2
+        try:
+            matches = name_matcher(args.pattern, regex=args.regex, case_sensitive=args.case_sensitive)
+        except re.error as e:
+            print(f"Error: Invalid pattern '{args.pattern}': {e}", file=sys.stderr)
+            return 2
+
+        scope = index.relative(path)
+        hits = []
+        for key in sorted(index.files):
+            if scope != '.' and not key.startswith(scope + '/'):
+                continue
+            for hit in search_structure(index.files[key]['structure'], matches, kinds=kind_filter(args.kind)):
+                hit['file'] = os.path.relpath(index.root / key)
+                hits.append(hit)
+
+        if args.format == 'json':
+            print(json.dumps({'pattern': args.pattern, 'index': str(index.root), 'built': index.data['built'],
+                              'matches': hits, 'total': len(hits)}, indent=2))
+        elif args.format == 'grep':
+            for hit in hits:
+                print(f"{hit['file']}:{hit['line']}:{hit['name']}")
+        else:
+            _print_hits(hits)
+        return 0 if hits else 1
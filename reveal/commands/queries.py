@@ -0,0 +1,84 @@
+"""reveal queries: SQL embedded in source code, for query audits and migration planning."""
+
+import argparse
+import json
+import sys
+from collections import Counter
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..base import enclosing_symbols
+from ..queries import find_queries
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('queries')
+class QueriesCommand(Command):
+    """List SQL queries held in string literals, with location, calling function and tables.
+
+    A literal is a query when it has the shape of a SQL statement
+    (SELECT ... FROM, INSERT INTO, UPDATE ... SET, DELETE FROM, WITH,
+    CREATE/ALTER/DROP TABLE, ...). --table keeps the queries that touch
+    one table, e.g. to see what a schema migration affects.
+    """
+
+    description = 'SQL queries embedded in string literals: location, calling function, operation and tables'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--table', metavar='NAME', help='Only queries that touch this table')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        queries = []
+        for file_path, analyzer, structure in analyzed_files(path, args):
+            for query in find_queries('\n'.join(analyzer.lines)):
+                if args.table and not _touches(query, args.table):
+                    continue
+                query['file'] = str(file_path)
+                enclosing = enclosing_symbols(structure, query['line'], query['line'])
+                if enclosing:
+                    query['symbol'] = enclosing[-1]['name']
+                queries.append(query)
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'queries': queries, 'total': len(queries)}, indent=2))
+        elif args.format == 'grep':
+            for query in queries:
+                print(f"{query['file']}:{query['line']}:{query['sql']}")
+        else:
+            _print_queries(queries)
+        return 0
+
+
+def _touches(query: Dict[str, Any], table: str) -> bool:
+    """Whether a query names the table, with or without its schema (public.users matches users)."""
+    names = {name.lower() for name in query['tables']} | {name.lower().rsplit('.', 1)[-1] for name in query['tables']}
+    return table.lower() in names
+
+
+def _print_queries(queries: List[Dict[str, Any]]) -> None:
+    if not queries:
+        print("No SQL queries found")
+        return
+    for query in queries:
+        context = f"  [{query['symbol']}]" if query.get('symbol') else ''
+        tables = f"  {', '.join(query['tables'])}" if query['tables'] else ''
+        sql = query['sql'] if len(query['sql']) <= 100 else query['sql'][:97] + '...'
+        print(f"{query['file']}:{query['line']}{context}  {query['operation']}{tables}")
+        print(f"    {sql}")
+    operations = Counter(query['operation'] for query in queries)
+    tables = Counter(table for query in queries for table in query['tables'])
+    files = len({query['file'] for query in queries})
+    summary = ', '.join(f'{name} {count}' for name, count in operations.most_common())
+    print(f"\n{len(queries)} {'query' if len(queries) == 1 else 'queries'} in {files} "
+          f"{'file' if files == 1 else 'files'} ({summary})")
+    if tables:
+        print(f"Tables: {', '.join(f'{name} {count}' for name, count in tables.most_common())}")
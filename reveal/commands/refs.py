@@ -0,0 +1,82 @@
+"""reveal refs: every place a symbol is used across a project."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..refs import find_references, reference_pattern, reference_word
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('refs')
+class RefsCommand(Command):
+    """List the usage sites of a symbol with file:line and the enclosing function.
+
+    Whole-word matches in code only: string literals and comments are
+    skipped. Definitions and imports are shown apart from uses, and
+    --no-definitions leaves them out. The directory may also come first
+    (reveal refs src/ Name). Exits 1 when the name is not used anywhere.
+    """
+
+    description = 'Usage sites of a symbol across the project, with the enclosing function of each'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('name', help='Symbol name (Start, Server.Start, parse_config)')
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--no-definitions', action='store_true',
+                            help='Only uses: leave out definitions and imports')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        # reveal refs <dir> <name>
+        if args.path != '.' and Path(args.name).exists() and not Path(args.path).exists():
+            args.name, args.path = args.path, args.name
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        pattern = reference_pattern(args.name)
+        hits = []
+        for file_path, analyzer, structure in analyzed_files(path, args, mentions=reference_word(args.name)):
+            for hit in find_references(file_path.name, analyzer.lines, structure, pattern):
+                if args.no_definitions and hit['kind'] != 'reference':
+                    continue
+                hit['file'] = str(file_path)
+                hits.append(hit)
+
+        uses = [hit for hit in hits if hit['kind'] == 'reference']
+        if args.format == 'json':
+            print(json.dumps({'name': args.name, 'path': str(path), 'references': hits, 'total': len(uses)},
+                             indent=2))
+        elif args.format == 'grep':
+            for hit in hits:
+                print(f"{hit['file']}:{hit['line']}:{hit['column']}:{hit['text']}")
+        else:
+            _print_refs(args.name, hits)
+        return 0 if uses else 1
+
+
+def _print_refs(name: str, hits: List[Dict[str, Any]]) -> None:
+    uses = [hit for hit in hits if hit['kind'] == 'reference']
+    if not hits:
+        print(f"No references to {name}")
+        return
+    width = max(len(f"{hit['file']}:{hit['line']}") for hit in hits)
+    for title, kinds in (('Definitions', ('definition',)), ('Imports', ('import',)), ('References', ('reference',))):
+        section = [hit for hit in hits if hit['kind'] in kinds]
+        if not section:
+            continue
+        print(f"{title} ({len(section)})")
+        for hit in section:
+            location = f"{hit['file']}:{hit['line']}"
+            symbol = f"  [{hit['symbol']}]" if hit.get('symbol') else ''
+            print(f"  {location:<{width}}  {hit['text']}{symbol}")
+        print()
+    files = len({hit['file'] for hit in uses})
+    print(f"{len(uses)} {'reference' if len(uses) == 1 else 'references'} to {name} in {files} "
+          f"{'file' if files == 1 else 'files'}")
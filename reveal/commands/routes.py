@@ -0,0 +1,71 @@
+"""reveal routes: the HTTP API of a web service, from its route registrations."""
+
+import argparse
+import json
+import sys
+from collections import Counter
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..routes import ROUTE_SUFFIXES, find_routes, route_sort_key
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('routes')
+class RoutesCommand(Command):
+    """List METHOD, path and handler of every route a project registers.
+
+    Recognizes Flask, FastAPI and Django (Python), Express (JavaScript and
+    TypeScript), Gin/Echo, chi and net/http (Go). Routes are sorted by
+    path; --method keeps one method (ANY routes match every method).
+    """
+
+    description = 'HTTP routes (Flask/FastAPI/Django/Express/Gin/chi/net/http): METHOD, path and handler'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--method', metavar='METHOD', help='Only routes accepting this method (GET, POST, ...)')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        routes = []
+        for file_path, analyzer, _structure in analyzed_files(path, args, suffixes=ROUTE_SUFFIXES):
+            for route in find_routes(file_path.name, analyzer.lines):
+                route['file'] = str(file_path)
+                routes.append(route)
+        if args.method:
+            routes = [route for route in routes if route['method'] in (args.method.upper(), 'ANY')]
+        routes.sort(key=route_sort_key)
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'routes': routes, 'total': len(routes)}, indent=2))
+        elif args.format == 'grep':
+            for route in routes:
+                print(f"{route['file']}:{route['line']}:{route['method']} {route['path']} {route['handler']}")
+        else:
+            _print_routes(routes)
+        return 0
+
+
+def _print_routes(routes: List[Dict[str, Any]]) -> None:
+    if not routes:
+        print("No routes found")
+        return
+    method_width = max(len('Method'), max(len(r['method']) for r in routes))
+    path_width = min(max(len('Path'), max(len(r['path']) for r in routes)), 60)
+    handler_width = min(max(len('Handler'), max(len(r['handler']) for r in routes)), 40)
+    print(f"{'Method':<{method_width}}  {'Path':<{path_width}}  {'Handler':<{handler_width}}  Location")
+    for r in routes:
+        print(f"{r['method']:<{method_width}}  {r['path']:<{path_width}}  {r['handler']:<{handler_width}}  "
+              f"{r['file']}:{r['line']}")
+    frameworks = Counter(r['framework'] for r in routes)
+    files = len({r['file'] for r in routes})
+    print(f"\n{len(routes)} {'route' if len(routes) == 1 else 'routes'} in {files} {'file' if files == 1 else 'files'} "
+          f"({', '.join(f'{name} {count}' for name, count in frameworks.most_common())})")
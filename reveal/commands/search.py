@@ -0,0 +1,179 @@
+"""reveal search: find definitions by name across a project."""
+
+import argparse
+import fnmatch
+import json
+import re
+import sys
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Set, Tuple
+
+from ..base import category_kind
+from ..dependencies import item_modules
+from ..lsp import KIND_ALIASES
+from ..tags import SKIPPED_CATEGORIES
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('search')
+class SearchCommand(Command):
+    """Search symbol names (functions, classes, types, ...) with reveal's analyzers.
+
+    Only definitions match, never calls, comments or strings. The pattern is
+    a case-insensitive substring, a glob when it contains * ? or [, or a
+    regular expression (--regex, or when it uses ^ $ ( | + \\ or .*).
+    --kind limits hits to symbol kinds; --kind import searches imported
+    modules instead, answering "which files import X".
+    """
+
+    description = 'Find definitions whose names match a pattern, as file:line hits'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('pattern', help="Name to look for: substring, glob ('parse_*') or --regex")
+        parser.add_argument('path', nargs='?', default='.', help='File or directory to search (default: .)')
+        parser.add_argument('--regex', '-E', action='store_true', help='Treat the pattern as a regular expression')
+        parser.add_argument('--kind', '-k', metavar='KIND[,KIND]',
+                            help='Only these kinds: function, method, class, struct, ... or import')
+        parser.add_argument('--case-sensitive', '-s', action='store_true', help='Match case exactly')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'jsonl', 'grep'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+        try:
+            matches = name_matcher(args.pattern, regex=args.regex, case_sensitive=args.case_sensitive)
+        except re.error as e:
+            print(f"Error: Invalid pattern '{args.pattern}': {e}", file=sys.stderr)
+            return 2
+
+        hits = []
+        kinds = kind_filter(args.kind)
+        for file_path, _, structure in analyzed_files(path, args, structure_fields=search_fields(kinds)):
+            file_hits = search_structure(structure, matches, kinds=kinds)
+            for hit in file_hits:
+                hit['file'] = str(file_path)
+                if args.format == 'jsonl':
+                    print(json.dumps(hit), flush=True)
+            hits.extend(file_hits)
+
+        if args.format == 'json':
+            print(json.dumps({'pattern': args.pattern, 'path': str(path), 'matches': hits, 'total': len(hits)},
+                             indent=2))
+        elif args.format == 'grep':
+            for hit in hits:
+                print(f"{hit['file']}:{hit['line']}:{hit['name']}")
+        elif args.format == 'text':
+            _print_hits(hits)
+        return 0 if hits else 1
+
+
+_REGEX_HINT = re.compile(r'[\^$()|+\\]|\.[*+?]')
+
+
+def name_matcher(pattern: str, regex: bool = False, case_sensitive: bool = False):
+    """Predicate for symbol names: substring, glob (when the pattern has * ? [) or regex.
+
+    Patterns that look like regular expressions ('handle.*', '^get_') are
+    treated as such even without regex=True.
+    """
+    regex = regex or bool(_REGEX_HINT.search(pattern))
+    is_glob = not regex and any(c in pattern for c in '*?[')
+    if regex:
+        source = pattern
+    elif is_glob:
+        source = fnmatch.translate(pattern)
+    else:
+        source = re.escape(pattern)
+    compiled = re.compile(source, 0 if case_sensitive else re.IGNORECASE)
+    search = compiled.match if is_glob else compiled.search
+
+    def matches(name: str) -> bool:
+        # The qualified name or just its last part (Server.Start, Server#start)
+        return bool(search(name) or search(re.split(r'::|#|\.', name)[-1]))
+    return matches
+
+
+# Categories whose items are import statements (matched by module name)
+IMPORT_CATEGORIES = ('imports', 'includes', 'requires')
+# Item fields search reads from definitions, and in addition from import statements
+SEARCH_FIELDS = ('name', 'line', 'line_start', 'line_end', 'kind', 'signature')
+IMPORT_FIELDS = ('content', 'modules')
+
+
+def kind_filter(kinds: Optional[str]) -> Optional[Set[str]]:
+    """Kinds wanted by --kind 'function,method' (plural forms allowed), or None for all definitions."""
+    if not kinds:
+        return None
+    wanted = set()
+    for kind in kinds.lower().split(','):
+        kind = kind.strip()
+        if kind:
+            wanted.update((kind, category_kind(kind)))
+    return wanted
+
+
+def _item_kinds(category: str, item: Dict[str, Any]) -> Set[str]:
+    """An item's own kind, its category's, and what those are called elsewhere (proc -> function)."""
+    kinds = {category_kind(category)}
+    if isinstance(item.get('kind'), str):
+        kinds.add(item['kind'])
+    return kinds | {KIND_ALIASES[kind] for kind in kinds if kind in KIND_ALIASES}
+
+
+def search_fields(kinds: Optional[Set[str]] = None) -> Tuple[str, ...]:
+    """Item fields search_structure reads for these kinds: import statements' only when imports are wanted."""
+    if kinds is not None and any(kinds & _item_kinds(category, {}) for category in IMPORT_CATEGORIES):
+        return SEARCH_FIELDS + IMPORT_FIELDS
+    return SEARCH_FIELDS
+
+
+def search_structure(structure: Dict[str, List[Dict[str, Any]]], matches,
+                     kinds: Optional[Set[str]] = None) -> List[Dict[str, Any]]:
+    """Definitions in a file's structure whose names match, in line order.
+
+    Without kinds, imports and other references are skipped; with kinds,
+    only items of those kinds are considered, and import statements match
+    on the modules they name.
+    """
+    hits = []
+    for category, items in structure.items():
+        if kinds is None and category in SKIPPED_CATEGORIES:
+            continue
+        for item in items:
+            if kinds is not None and not kinds & _item_kinds(category, item):
+                continue
+            line = item.get('line') or item.get('line_start')
+            if category in IMPORT_CATEGORIES:
+                statement = str(item.get('content') or item.get('name') or '').strip()
+                names = item_modules(item) or [statement]
+                name = statement if any(matches(target) for target in names) else None
+            else:
+                name = item.get('name')
+                name = name if name and matches(str(name)) else None
+            if not name or not isinstance(line, int):
+                continue
+            hit = {'line': line, 'name': name, 'kind': item.get('kind') or category_kind(category),
+                   'category': category}
+            if item.get('line_end'):
+                hit['line_end'] = item['line_end']
+            if item.get('signature'):
+                hit['signature'] = item['signature']
+            hits.append(hit)
+    return sorted(hits, key=lambda hit: hit['line'])
+
+
+def _print_hits(hits: List[Dict[str, Any]]) -> None:
+    if not hits:
+        print("No matching definitions")
+        return
+    width = max(len(f"{hit['file']}:{hit['line']}") for hit in hits)
+    kind_width = max(len(hit['kind']) for hit in hits)
+    for hit in hits:
+        location = f"{hit['file']}:{hit['line']}"
+        print(f"{location:<{width}}  {hit['kind']:<{kind_width}}  {hit['name']}{hit.get('signature', '')}")
+    files = len({hit['file'] for hit in hits})
+    print(f"\n{len(hits)} {'match' if len(hits) == 1 else 'matches'} in {files} {'file' if files == 1 else 'files'}")
@@ -0,0 +1,132 @@
+"""reveal secrets: credentials committed to source, with their values redacted."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+from ..base import enclosing_symbols, get_analyzer
+from ..cache import cached_structure
+from ..index import ProjectIndex
+from ..rules.security.S301 import S301
+from ..sarif import sarif_log
+from ..secrets import find_secrets
+from ..sniff import SNIFF_BYTES, looks_binary, oversized
+from ..tree_view import iter_directory_files
+from ..workers import ordered_map
+from .base import Command, register_command, add_walk_options
+
+
+# Version control internals and reveal's own index (.reveal) are not the tree being scanned
+_SKIPPED_DIRS = ('.git', '.hg', '.svn', '.reveal')
+
+
+@register_command('secrets')
+class SecretsCommand(Command):
+    """Scan every text file, hidden ones included, for high-confidence secrets.
+
+    Reports file:line, the kind of secret and the enclosing function or
+    class when the file has structure; values are always redacted, also in
+    JSON. Binary files and version control directories are skipped, and in
+    directories so are files over --max-file-size; generated and minified
+    files are scanned like any other. Exits 1 when anything is found, so it
+    can gate CI.
+    """
+
+    description = 'High-confidence secrets (AWS keys, private keys, tokens, .env credentials), values redacted'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep', 'sarif'],
+                            help='Output format (default: text; sarif reports rule S301 for code scanning)')
+        add_walk_options(parser, generated=False)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        files = [path] if path.is_file() else iter_directory_files(
+            path, depth=args.depth if args.depth > 0 else sys.getrecursionlimit(), show_hidden=True,
+            skip=_SKIPPED_DIRS, ignore=not args.no_ignore)
+        # A file named on the command line is scanned whatever its size
+        max_size = 0 if path.is_file() else args.max_file_size
+        index = None if path.is_file() or args.no_index else ProjectIndex.load(path)
+
+        def scan(file_path: Path) -> Optional[List[Dict[str, Any]]]:
+            lines = None if oversized(file_path, max_size) else _text_lines(file_path)
+            if lines is None:
+                return None
+            found = find_secrets(file_path.name, lines)
+            if found:
+                structure = _structure(file_path, args, index)
+                for secret in found:
+                    enclosing = enclosing_symbols(structure, secret['line'], secret['line'])
+                    if enclosing:
+                        secret['symbol'] = enclosing[-1]['name']
+                    secret['file'] = str(file_path)
+            return found
+
+        secrets = []
+        scanned = 0
+        for found in ordered_map(scan, files, args.jobs):
+            if found is not None:
+                scanned += 1
+                secrets.extend(found)
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'secrets': secrets, 'total': len(secrets), 'files_scanned': scanned},
+                             indent=2))
+        elif args.format == 'sarif':
+            rule = S301()
+            print(json.dumps(sarif_log(rule.secret_detection(s['file'], s) for s in secrets), indent=2))
+        elif args.format == 'grep':
+            for secret in secrets:
+                print(f"{secret['file']}:{secret['line']}:{secret['column']}:{secret['kind']}: {secret['text']}")
+        else:
+            _print_secrets(secrets, scanned)
+        return 1 if secrets else 0
+
+
+def _text_lines(path: Path) -> Optional[List[str]]:
+    """A file's lines, or None for binary or unreadable files (only the first bytes of those are read)."""
+    try:
+        with open(path, 'rb') as f:
+            data = f.read(SNIFF_BYTES)
+            if looks_binary(data):
+                return None
+            data += f.read()
+    except OSError:
+        return None
+    return data.decode('utf-8', errors='replace').splitlines()
+
+
+def _structure(path: Path, args: argparse.Namespace,
+               index: Optional[ProjectIndex]) -> Dict[str, List[Dict[str, Any]]]:
+    """The file's structure: from the project index when it is current there, else (cached) analysis."""
+    entry = index.entry(path) if index is not None and index.keeps(('name', 'kind', 'line', 'line_end')) else None
+    if entry is not None:
+        return entry['structure']
+    analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+    if not analyzer_class:
+        return {}
+    try:
+        return cached_structure(analyzer_class(str(path)), enabled=not args.no_cache)
+    except Exception:
+        return {}
+
+
+def _print_secrets(secrets: List[Dict[str, Any]], scanned: int) -> None:
+    if not secrets:
+        print(f"No secrets found ({scanned} files scanned)")
+        return
+    width = max(len(f"{s['file']}:{s['line']}") for s in secrets)
+    for secret in secrets:
+        location = f"{secret['file']}:{secret['line']}"
+        context = f"  [{secret['symbol']}]" if secret.get('symbol') else ''
+        print(f"  {location:<{width}}  {secret['kind']}: {secret['text']}{context}")
+    files = len({s['file'] for s in secrets})
+    print(f"\n{len(secrets)} {'secret' if len(secrets) == 1 else 'secrets'} in {files} "
+          f"{'file' if files == 1 else 'files'} ({scanned} files scanned)")
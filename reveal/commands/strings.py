@@ -0,0 +1,79 @@
+"""reveal strings: hard-coded user-facing messages, for localization audits."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..base import enclosing_symbols
+from ..strings import find_strings
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('strings')
+class StringsCommand(Command):
+    """List the user-facing string literals of each file with the function they sit in.
+
+    By default only hard-coded literals are listed; literals already
+    passed to a translation function (_, gettext, t, i18n.t, ...) are
+    counted in the summary, and --all lists them too.
+    """
+
+    description = 'User-facing string literals per file (hard-coded messages, i18n audit)'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--all', action='store_true', help='Also list literals that are already translated')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        strings, translated = [], 0
+        for file_path, analyzer, structure in analyzed_files(path, args):
+            for string in find_strings(file_path.name, analyzer.lines):
+                translated += string['translated']
+                if string['translated'] and not args.all:
+                    continue
+                string['file'] = str(file_path)
+                enclosing = enclosing_symbols(structure, string['line'], string['line'])
+                if enclosing:
+                    string['symbol'] = enclosing[-1]['name']
+                strings.append(string)
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'strings': strings, 'total': len(strings),
+                              'translated': translated}, indent=2))
+        elif args.format == 'grep':
+            for string in strings:
+                print(f"{string['file']}:{string['line']}:{json.dumps(string['text'])}")
+        else:
+            _print_strings(strings, translated)
+        return 0
+
+
+def _print_strings(strings: List[Dict[str, Any]], translated: int) -> None:
+    hard_coded = sum(1 for string in strings if not string['translated'])
+    if not strings:
+        print(f"No hard-coded strings found ({translated} translated)" if translated
+              else "No user-facing strings found")
+        return
+    current = None
+    for string in strings:
+        if string['file'] != current:
+            if current is not None:
+                print()
+            current = string['file']
+            print(current)
+        context = f"  [{string['symbol']}]" if string.get('symbol') else ''
+        marker = '  (translated)' if string['translated'] else ''
+        print(f"  {string['line']:>5}{context}  {json.dumps(string['text'])}{marker}")
+    files = len({string['file'] for string in strings})
+    print(f"\n{hard_coded} hard-coded {'string' if hard_coded == 1 else 'strings'} in {files} "
+          f"{'file' if files == 1 else 'files'} ({translated} translated)")
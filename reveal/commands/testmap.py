@@ -0,0 +1,68 @@
+"""reveal test-map: test files paired with the source files they exercise, and what has no tests."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict
+
+from ..testmap import map_tests
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('test-map')
+class TestMapCommand(Command):
+    """Map test files to source files by naming convention and imports.
+
+    test_foo.py, foo_test.go, foo.spec.ts, FooTest.java and foo_spec.rb
+    are matched to foo by name (nearest directory first, tests/ mirroring
+    src/); any project file a test imports counts too. Source files with
+    functions or classes that no test reaches are listed as untested;
+    --untested prints only those.
+    """
+
+    description = 'Test files paired with the source files they exercise; source files without tests'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='Project directory (default: .)')
+        parser.add_argument('--untested', action='store_true', help='Only list source files without tests')
+        parser.add_argument('--format', default='text', choices=['text', 'json'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        base = path if path.is_dir() else path.parent
+        result = map_tests([(file_path.relative_to(base).as_posix(), analyzer.type_name, structure)
+                            for file_path, analyzer, structure in analyzed_files(path, args)])
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'tests': result['tests'], 'untested': result['untested'],
+                              'sources': len(result['sources'])}, indent=2))
+        elif args.untested:
+            for file_name in result['untested']:
+                print(file_name)
+        else:
+            _print_mapping(result)
+        return 0
+
+
+def _print_mapping(result: Dict[str, Any]) -> None:
+    tests, sources, untested = result['tests'], result['sources'], result['untested']
+    for test in tests:
+        print(test['file'])
+        for source in test['sources']:
+            print(f"  → {source['file']}  ({', '.join(source['via'])})")
+        if not test['sources']:
+            print("  (no matching source file)")
+    if untested:
+        print(f"\nUntested ({len(untested)} of {len(sources)} source files):")
+        for file_name in untested:
+            print(f"  {file_name}")
+    tested = len(sources) - len(untested)
+    percent = f" ({100 * tested / len(sources):.0f}%)" if sources else ''
+    print(f"\n{len(tests)} test {'file' if len(tests) == 1 else 'files'} cover {tested} of "
+          f"{len(sources)} source files{percent}")
@@ -0,0 +1,96 @@
+"""reveal todos: TODO/FIXME/HACK/XXX comments across a project, grouped by tag."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..git import GitError, blame
+from ..rules.maintainability.M501 import M501
+from ..sarif import sarif_log
+from ..todos import TODO_TAGS, file_todos, todo_pattern
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('todos')
+class TodosCommand(Command):
+    """List marker comments with file:line and the function or class they sit in.
+
+    Markers count only inside comments and in upper case, so a todo_list
+    variable or a "TODO" string is not reported. TODO(name): text records
+    name as the owner; --blame adds who last changed the line and when,
+    from git.
+    """
+
+    description = 'TODO/FIXME/HACK/XXX comments with location, enclosing symbol and optional git author'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='File or directory (default: .)')
+        parser.add_argument('--tags', metavar='TAG[,TAG]', default=','.join(TODO_TAGS),
+                            help=f"Markers to look for (default: {','.join(TODO_TAGS)})")
+        parser.add_argument('--blame', action='store_true', help='Add the git author and date of each line')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep', 'sarif'],
+                            help='Output format (default: text; sarif reports rule M501 for code scanning)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        tags = [tag.strip().upper() for tag in args.tags.split(',') if tag.strip()]
+        pattern = todo_pattern(tags)
+        todos = []
+        blame_failed = False
+        for file_path, analyzer, structure in analyzed_files(path, args):
+            found = file_todos(analyzer.lines, structure, pattern, str(file_path))
+            if found and args.blame and not blame_failed:
+                try:
+                    authors = blame(file_path)
+                except GitError as e:
+                    print(f"Warning: --blame unavailable: {e}", file=sys.stderr)
+                    blame_failed = True
+                else:
+                    for todo in found:
+                        todo.update(authors.get(todo['line'], {}))
+            for todo in found:
+                todo['file'] = str(file_path)
+            todos.extend(found)
+
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'todos': todos, 'total': len(todos),
+                              'by_tag': {tag: sum(1 for t in todos if t['tag'] == tag) for tag in tags}}, indent=2))
+        elif args.format == 'sarif':
+            rule = M501()
+            print(json.dumps(sarif_log(rule.todo_detection(todo['file'], todo) for todo in todos), indent=2))
+        elif args.format == 'grep':
+            for todo in todos:
+                print(f"{todo['file']}:{todo['line']}:{todo['tag']}: {todo['text']}")
+        else:
+            _print_todos(todos, tags)
+        return 0
+
+
+def _print_todos(todos: List[Dict[str, Any]], tags: List[str]) -> None:
+    if not todos:
+        print(f"No {'/'.join(tags)} comments found")
+        return
+    width = max(len(f"{todo['file']}:{todo['line']}") for todo in todos)
+    for tag in tags:
+        tagged = [todo for todo in todos if todo['tag'] == tag]
+        if not tagged:
+            continue
+        print(f"{tag} ({len(tagged)})")
+        for todo in tagged:
+            location = f"{todo['file']}:{todo['line']}"
+            symbol = f"  [{todo['symbol']}]" if todo.get('symbol') else ''
+            owner = f"({todo['owner']}) " if todo.get('owner') else ''
+            author = f"  -- {todo['author']}, {todo['date']}" if todo.get('author') else ''
+            print(f"  {location:<{width}}  {owner}{todo['text']}{symbol}{author}")
+        print()
+    counts = ', '.join(f"{sum(1 for t in todos if t['tag'] == tag)} {tag}" for tag in tags
+                       if any(t['tag'] == tag for t in todos))
+    files = len({todo['file'] for todo in todos})
+    print(f"{len(todos)} markers in {files} {'file' if files == 1 else 'files'}: {counts}")
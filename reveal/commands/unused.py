@@ -0,0 +1,69 @@
+"""reveal unused: functions and classes nothing in the project refers to."""
+
+import argparse
+import json
+import sys
+from pathlib import Path
+from typing import Any, Dict, List
+
+from ..unused import unused_symbols
+from .base import Command, register_command, add_walk_options, analyzed_files
+
+
+@register_command('unused')
+class UnusedCommand(Command):
+    """Report definitions whose names never appear elsewhere in the project.
+
+    Matching is by name, so a symbol is only reported when no file
+    mentions it at all. Entry points (main, init, handlers), tests,
+    special methods, decorated or annotated definitions and exported API
+    (Go capitalized names, __all__, export/pub/public) are skipped;
+    --include-exported checks exported API too, for applications whose
+    exports have no outside users. Exits 1 when anything is reported.
+    """
+
+    description = 'Functions and classes defined but never referenced anywhere in the project'
+
+    def add_arguments(self, parser: argparse.ArgumentParser) -> None:
+        parser.add_argument('path', nargs='?', default='.', help='Project directory (default: .)')
+        parser.add_argument('--include-exported', action='store_true',
+                            help='Also report exported/public API that nothing in the project uses')
+        parser.add_argument('--format', default='text', choices=['text', 'json', 'grep'],
+                            help='Output format (default: text)')
+        add_walk_options(parser)
+
+    def run(self, args: argparse.Namespace) -> int:
+        path = Path(args.path)
+        if not path.exists():
+            print(f"Error: {args.path} not found", file=sys.stderr)
+            return 2
+
+        base = path if path.is_dir() else path.parent
+        unused, checked = unused_symbols(((file_path.relative_to(base).as_posix(), analyzer.lines, structure)
+                                          for file_path, analyzer, structure in analyzed_files(path, args)),
+                                         include_exported=args.include_exported)
+        if args.format == 'json':
+            print(json.dumps({'path': str(path), 'checked': checked, 'unused': unused, 'total': len(unused)},
+                             indent=2))
+        elif args.format == 'grep':
+            for symbol in unused:
+                print(f"{symbol['file']}:{symbol['line']}:{symbol['name']}")
+        else:
+            _print_unused(unused, checked)
+        return 1 if unused else 0
+
+
+def _print_unused(unused: List[Dict[str, Any]], checked: int) -> None:
+    if not unused:
+        print(f"No unused symbols ({checked} definitions checked)")
+        return
+    by_file: Dict[str, List[Dict[str, Any]]] = {}
+    for symbol in unused:
+        by_file.setdefault(symbol['file'], []).append(symbol)
+    kind_width = max(len(symbol['kind']) for symbol in unused)
+    for file_name, symbols in by_file.items():
+        print(file_name)
+        for symbol in sorted(symbols, key=lambda s: s['line']):
+            print(f"  {symbol['line']:>5}  {symbol['kind']:<{kind_width}}  {symbol['name']}")
+    print(f"\n{len(unused)} unused of {checked} definitions checked, in {len(by_file)} "
+          f"{'file' if len(by_file) == 1 else 'files'}")
@@ -0,0 +1,345 @@
+"""Import graph: which files of a project import which.
+
+Analyzers report imports as statement text ('content'); this module pulls
+module names out of those statements, resolves them to files in the
+project, and renders the resulting graph.
+"""
+
+import posixpath
+import re
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Optional, Set, Tuple
+
+
+# Files that stand for their directory (package/module index files)
+INDEX_STEMS = {'__init__', 'index', 'mod', 'lib', 'main'}
+
+_QUOTED = re.compile(r'''["'`]([^"'`\s]+)["'`]''')
+_INCLUDE = re.compile(r'#\s*include\s*[<"]([^>"]+)[>"]')
+_FROM_IMPORT = re.compile(r'^\s*from\s+(\S+)\s+import\s+\(?([^)]*)\)?', re.DOTALL)
+_KEYWORD = re.compile(
+    r'^\s*(?:import|use|using|open|include|require(?:_relative)?|extern\s+crate|library|load|uses)\b'
+    r'(?:\s+(?:static|type|qualified))?\s*'
+)
+_NAME = re.compile(r'[\w.:/@$-]+')
+_PYTHON_SUFFIXES = ('.py', '.pyi', '.pyw')
+
+
+def import_targets(statement: str) -> List[str]:
+    """Module names/paths an import statement refers to.
+
+    'from .utils import a, b' -> ['.utils.a', '.utils.b']; 'import os, sys' -> ['os', 'sys'];
+    'import x from "./x"' -> ['./x']; 'use crate::a::{b, c}' -> ['crate::a'];
+    'import std/[os, strutils]' -> ['std/os', 'std/strutils'].
+    """
+    include = _INCLUDE.search(statement)
+    if include:
+        return [include.group(1)]
+
+    quoted = _QUOTED.findall(statement)
+    if quoted:
+        return quoted
+
+    from_import = _FROM_IMPORT.match(statement)
+    if from_import:
+        module, names = from_import.groups()
+        names = [name.split()[0] for name in names.replace('\n', ' ').split(',') if name.strip() not in ('', '*')]
+        if not names:
+            return [module] if module.strip('.') else []
+        # Each name may be a submodule ('from pkg import mod' -> 'pkg.mod'); resolving falls back to pkg
+        separator = '.' if module.strip('.') else ''
+        return [module + separator + name for name in names]
+
+    keyword = _KEYWORD.match(statement)
+    if not keyword:
+        return []
+    rest = statement[keyword.end():]
+    # a/[b, c] -> a/b, a/c
+    rest = re.sub(r'([\w./]*)\[([^\]]*)\]',
+                  lambda m: ', '.join(m.group(1) + part.strip() for part in m.group(2).split(',')), rest)
+    # Rust groups: a::{b, c} -> a
+    rest = re.sub(r'::\{[^}]*\}', '', rest)
+
+    targets = []
+    for part in rest.replace('\n', ' ').split(','):
+        name = _NAME.match(part.strip())
+        if name:
+            target = re.sub(r'(?:::|\.)\*$|[;:.]+$', '', name.group())
+            if target and target not in targets:
+                targets.append(target)
+    return targets
+
+
+def item_targets(item: Dict[str, Any]) -> List[str]:
+    """Module names/paths of an import entry: those the parser found, else read from its statement."""
+    modules = item.get('modules')
+    if modules:
+        return list(modules)
+    return import_targets(str(item.get('content') or item.get('name') or ''))
+
+
+def item_modules(item: Dict[str, Any]) -> List[str]:
+    """Modules an import entry names: its targets, except that 'from pkg import a, b' names just pkg."""
+    package = _from_package(item)
+    return [package] if package else item_targets(item)
+
+
+def _from_package(item: Dict[str, Any]) -> Optional[str]:
+    """pkg of a Python 'from pkg import ...' entry (None for 'from . import ...' and other statements)."""
+    from_import = _FROM_IMPORT.match(str(item.get('content') or ''))
+    return from_import.group(1) if from_import and from_import.group(1).strip('.') else None
+
+
+class ImportGraph:
+    """Files (posix paths relative to the root) and the files/modules each imports."""
+
+    def __init__(self):
+        self.nodes: List[str] = []
+        self.edges: Dict[str, Set[str]] = {}
+        # Imports that did not resolve to a project file, per file
+        self.external: Dict[str, Set[str]] = {}
+
+    def add_node(self, node: str):
+        if node not in self.edges:
+            self.nodes.append(node)
+            self.edges[node] = set()
+            self.external[node] = set()
+
+    def fan_in(self) -> Dict[str, int]:
+        """How many project files import each file."""
+        counts = {node: 0 for node in self.nodes}
+        for targets in self.edges.values():
+            for target in targets:
+                counts[target] += 1
+        return counts
+
+    def fan_out(self) -> Dict[str, int]:
+        """How many project files each file imports."""
+        return {node: len(self.edges[node]) for node in self.nodes}
+
+    def edge_list(self, include_external: bool = False) -> List[Tuple[str, str]]:
+        """Sorted (source, target) pairs."""
+        pairs = [(source, target) for source in self.nodes for target in sorted(self.edges[source])]
+        if include_external:
+            pairs += [(source, target) for source in self.nodes for target in sorted(self.external[source])]
+        return pairs
+
+
+def build_import_graph(files: Iterable[Tuple[str, Dict[str, List[Dict[str, Any]]]]]) -> ImportGraph:
+    """Graph from (relative posix path, structure) pairs.
+
+    Imports are matched to project files by path (relative paths from the
+    importing file, dotted or :: separated module names from the root or a
+    parent directory); unmatched ones are kept as external. An import
+    naming a directory (a Go package) resolves to its index file, else to
+    its first file.
+    """
+    files = list(files)
+    graph = ImportGraph()
+    index: Dict[str, str] = {}
+    for path, _ in files:
+        graph.add_node(path)
+        stem = posixpath.splitext(path)[0]
+        index.setdefault(stem, path)
+        directory, name = posixpath.split(stem)
+        if name in INDEX_STEMS and directory:
+            index.setdefault(directory, path)
+    for path, _ in files:
+        directory = posixpath.dirname(path)
+        if directory:
+            index.setdefault(directory, path)
+
+    for path, structure in files:
+        for item in structure.get('imports', []):
+            # An unresolved 'from pkg import a, b' is one external package, pkg
+            package = _from_package(item)
+            for target in item_targets(item):
+                resolved = _resolve(target, path, index)
+                if resolved and resolved != path:
+                    graph.edges[path].add(resolved)
+                elif not resolved:
+                    graph.external[path].add(package or target)
+
+    return graph
+
+
+def _resolve(target: str, importer: str, index: Dict[str, str]) -> Optional[str]:
+    """Project file a target refers to, or None."""
+    directory = posixpath.dirname(importer)
+
+    if target.startswith(('./', '../')) or target.startswith('/') and not target.startswith('//'):
+        return _lookup(posixpath.normpath(posixpath.join(directory, target)), index)
+
+    if target.startswith('.'):
+        # Python relative import: one dot per level up from the package
+        dots = len(target) - len(target.lstrip('.'))
+        base = directory
+        for _ in range(dots - 1):
+            base = posixpath.dirname(base)
+        # Longest first: .pkg.name is the submodule pkg/name, else a name defined in pkg
+        rest = [part for part in target[dots:].split('.') if part]
+        for length in range(len(rest), -1, -1):
+            found = _lookup(posixpath.join(base, *rest[:length]) if length else base, index)
+            if found:
+                return found
+        return None
+
+    parts = [p for p in re.split(r'::|[./\\]', target) if p]
+    if parts and parts[0] in ('crate', 'self', 'super', '@'):
+        parts = parts[1:]
+    if not parts:
+        return None
+
+    # Absolute Python imports start at the project root; C includes and Nim/Go siblings at the importer's directory
+    bases = ('',) if importer.endswith(_PYTHON_SUFFIXES) else (directory, '')
+    # Longest prefix first: a.b.C may name the class C inside a/b
+    for length in range(len(parts), 0, -1):
+        candidate = '/'.join(parts[:length])
+        for base in bases:
+            found = _lookup(posixpath.join(base, candidate) if base else candidate, index)
+            if found:
+                return found
+        # Source roots (src/pkg/mod.py imported as pkg.mod); single names are too ambiguous
+        if length > 1:
+            matches = sorted(key for key in index if key.endswith('/' + candidate))
+            if matches:
+                return index[min(matches, key=len)]
+
+    # Go import paths start with the module path (example.com/app/store): drop it a segment at a time
+    segments = target.split('/')
+    if len(segments) > 1 and '.' in segments[0]:
+        for start in range(1, len(segments)):
+            found = _lookup('/'.join(segments[start:]), index)
+            if found:
+                return found
+    return None
+
+
+def _lookup(stem: str, index: Dict[str, str]) -> Optional[str]:
+    """Index entry for a path with or without its extension."""
+    stem = stem.lstrip('/')
+    return index.get(stem) or index.get(posixpath.splitext(stem)[0])
+
+
+def package_graph(graph: ImportGraph) -> ImportGraph:
+    """The graph collapsed to directories (packages); '.' is the root directory."""
+    packages = ImportGraph()
+    for node in graph.nodes:
+        package = posixpath.dirname(node) or '.'
+        packages.add_node(package)
+        packages.external[package] |= graph.external[node]
+    for source in graph.nodes:
+        for target in graph.edges[source]:
+            source_package, target_package = posixpath.dirname(source) or '.', posixpath.dirname(target) or '.'
+            if source_package != target_package:
+                packages.edges[source_package].add(target_package)
+    return packages
+
+
+def find_cycles(graph: ImportGraph) -> List[List[str]]:
+    """One import cycle per strongly connected group of files, as [a, b, ..., a].
+
+    Each cycle is the shortest one through the group's first node (by
+    name), so the chain shows a concrete path of imports that loops back.
+    """
+    index: Dict[str, int] = {}
+    low: Dict[str, int] = {}
+    on_stack: Set[str] = set()
+    stack: List[str] = []
+    groups: List[List[str]] = []
+
+    # Tarjan's algorithm, iterative so deep import chains don't hit the recursion limit
+    for root in graph.nodes:
+        if root in index:
+            continue
+        work = [(root, iter(sorted(graph.edges[root])))]
+        index[root] = low[root] = len(index)
+        stack.append(root)
+        on_stack.add(root)
+        while work:
+            node, targets = work[-1]
+            target = next(targets, None)
+            if target is not None:
+                if target not in index:
+                    index[target] = low[target] = len(index)
+                    stack.append(target)
+                    on_stack.add(target)
+                    work.append((target, iter(sorted(graph.edges[target]))))
+                elif target in on_stack:
+                    low[node] = min(low[node], index[target])
+                continue
+            work.pop()
+            if work:
+                low[work[-1][0]] = min(low[work[-1][0]], low[node])
+            if low[node] == index[node]:
+                group = []
+                while True:
+                    member = stack.pop()
+                    on_stack.discard(member)
+                    group.append(member)
+                    if member == node:
+                        break
+                if len(group) > 1:
+                    groups.append(sorted(group))
+
+    cycles = []
+    for group in sorted(groups):
+        members = set(group)
+        start = group[0]
+        # Breadth-first search inside the group for the shortest way back to start
+        previous: Dict[str, str] = {}
+        queue = [start]
+        found = None
+        while queue and found is None:
+            node = queue.pop(0)
+            for target in sorted(graph.edges[node] & members):
+                if target == start:
+                    found = node
+                    break
+                if target not in previous:
+                    previous[target] = node
+                    queue.append(target)
+        chain = [start]
+        node = found
+        while node != start:
+            chain.insert(1, node)
+            node = previous[node]
+        cycles.append(chain + [start])
+    return cycles
+
+
+def render_dot(graph: ImportGraph, title: str, include_external: bool = False) -> str:
+    """Graphviz DOT for the graph; files are grouped into clusters by directory."""
+    lines = [f'digraph {_dot_id(title)} {{',
+             '  rankdir=LR;',
+             '  node [shape=box, style="rounded,filled", fillcolor="#eef4ff", fontname="Helvetica", fontsize=10];',
+             '  edge [color="#57606a", arrowsize=0.7];']
+
+    by_directory: Dict[str, List[str]] = {}
+    for node in graph.nodes:
+        by_directory.setdefault(posixpath.dirname(node), []).append(node)
+    for number, (directory, nodes) in enumerate(sorted(by_directory.items())):
+        indent = '  '
+        if directory:
+            lines.append(f'  subgraph cluster_{number} {{')
+            lines.append(f'    label={_dot_id(directory + "/")}; style=dashed; color="#8c959f"; fontname="Helvetica";')
+            indent = '    '
+        for node in nodes:
+            lines.append(f'{indent}{_dot_id(node)} [label={_dot_id(posixpath.basename(node))}];')
+        if directory:
+            lines.append('  }')
+
+    if include_external:
+        externals = sorted({target for targets in graph.external.values() for target in targets})
+        for target in externals:
+            lines.append(f'  {_dot_id(target)} [shape=ellipse, style=dashed, fillcolor=white, color="#8c959f"];')
+
+    for source, target in graph.edge_list(include_external):
+        lines.append(f'  {_dot_id(source)} -> {_dot_id(target)};')
+    lines.append('}')
+    return '\n'.join(lines)
+
+
+def _dot_id(value: str) -> str:
+    """Quoted DOT identifier."""
+    return '"' + value.replace('\\', '\\\\').replace('"', '\\"') + '"'
@@ -0,0 +1,210 @@
+"""Structural diff: what changed between two versions of a file, in symbols rather than lines.
+
+Symbols are matched by kind and name. Unmatched ones are added or removed,
+unless a removed and an added symbol of the same kind have near-identical
+bodies, which makes it a rename. Matched symbols changed when their
+declaration (signature) or their body tokens differ, literal values
+included; comments and formatting alone do not count. Trees are compared file by file, on
+relative paths, by default for public symbols only.
+"""
+
+from typing import Any, Dict, List, Optional, Set, Tuple
+
+from .api import API_CATEGORIES, api_entries, declaration, is_public
+from .base import category_kind
+from .dupes import body_tokens, shingles, similarity
+from .tags import SKIPPED_CATEGORIES
+from .unused import exported_names
+
+
+# How alike a removed and an added body must be to count as a rename
+RENAME_SIMILARITY = 0.8
+
+
+SymbolTable = Dict[Tuple[str, str, int], Dict[str, Any]]
+
+
+def file_symbols(file_name: str, lines: List[str], structure: Dict[str, List[Dict[str, Any]]]) -> SymbolTable:
+    """Symbols keyed by (kind, name, occurrence): {'kind', 'name', 'line', 'lines', 'signature', 'tokens'}.
+
+    occurrence tells apart overloads and other repeated names, in line order.
+    """
+    items = sorted(((category, item) for category, items in structure.items() if category not in SKIPPED_CATEGORIES
+                    for item in items if item.get('name') and isinstance(item.get('line'), int)),
+                   key=lambda pair: pair[1]['line'])
+    symbols: SymbolTable = {}
+    for category, item in items:
+        kind = item.get('kind') or category_kind(category)
+        name = str(item['name'])
+        line, line_end = item['line'], item.get('line_end') or item['line']
+        occurrence = sum(1 for key in symbols if key[:2] == (kind, name))
+        symbols[(kind, name, occurrence)] = {
+            'kind': kind, 'name': name, 'line': line, 'lines': line_end - line + 1,
+            'signature': ' '.join(' '.join(declaration(lines, line)).split()),
+            'tokens': body_tokens(lines, line, line_end, file_name, keep_literals=True),
+        }
+    return symbols
+
+
+def _public(symbol: Dict[str, Any]) -> Dict[str, Any]:
+    return {key: symbol[key] for key in ('kind', 'name', 'line', 'lines', 'signature')}
+
+
+def _rename_score(old: Dict[str, Any], new: Dict[str, Any]) -> float:
+    if old['tokens'] or new['tokens']:
+        return similarity(shingles(old['tokens']), shingles(new['tokens']))
+    # One-line symbols: the same declaration apart from the name
+    return 1.0 if old['signature'].replace(old['name'], '') == new['signature'].replace(new['name'], '') else 0.0
+
+
+def diff_symbols(old: SymbolTable, new: SymbolTable) -> Dict[str, Any]:
+    """{'added', 'removed', 'renamed', 'changed', 'unchanged'} between two file_symbols results.
+
+    renamed entries are {'kind', 'old', 'new', 'similarity', ...}; changed
+    entries carry 'old_signature'/'new_signature' when the declaration
+    changed, 'body_changed' and the old and new line counts.
+    """
+    removed = [old[key] for key in old if key not in new]
+    added = [new[key] for key in new if key not in old]
+
+    pairs = sorted(((_rename_score(a, b), i, j) for i, a in enumerate(removed) for j, b in enumerate(added)
+                    if a['kind'] == b['kind']), reverse=True)
+    renamed = []
+    used_old, used_new = set(), set()
+    for score, i, j in pairs:
+        if score < RENAME_SIMILARITY or i in used_old or j in used_new:
+            continue
+        used_old.add(i)
+        used_new.add(j)
+        a, b = removed[i], added[j]
+        renamed.append({'kind': a['kind'], 'old': a['name'], 'new': b['name'], 'similarity': round(score, 3),
+                        'line': b['line'], 'old_lines': a['lines'], 'new_lines': b['lines'],
+                        'old_signature': a['signature'], 'new_signature': b['signature']})
+
+    changed = []
+    unchanged = 0
+    for key in old:
+        if key not in new:
+            continue
+        a, b = old[key], new[key]
+        if a['signature'] == b['signature'] and a['tokens'] == b['tokens']:
+            unchanged += 1
+            continue
+        change = {'kind': a['kind'], 'name': a['name'], 'line': b['line'],
+                  'old_lines': a['lines'], 'new_lines': b['lines'], 'body_changed': a['tokens'] != b['tokens']}
+        if a['signature'] != b['signature']:
+            change.update(old_signature=a['signature'], new_signature=b['signature'])
+        changed.append(change)
+
+    return {
+        'added': [_public(s) for j, s in enumerate(added) if j not in used_new],
+        'removed': [_public(s) for i, s in enumerate(removed) if i not in used_old],
+        'renamed': sorted(renamed, key=lambda r: r['line']),
+        'changed': sorted(changed, key=lambda c: c['line']),
+        'unchanged': unchanged,
+    }
+
+
+def _delta(old: int, new: int) -> str:
+    return f"{old} → {new} lines, {new - old:+d}" if old != new else f"{new} lines"
+
+
+def render_diff_text(diff: Dict[str, Any]) -> List[str]:
+    """Added/Removed/Renamed/Changed sections, one symbol per line."""
+    output = []
+    if diff['added']:
+        output.append(f"Added ({len(diff['added'])}):")
+        output.extend(f"  + {s['kind']} {s['name']}  (line {s['line']}, {s['lines']} lines)" for s in diff['added'])
+    if diff['removed']:
+        output.append(f"Removed ({len(diff['removed'])}):")
+        output.extend(f"  - {s['kind']} {s['name']}  (was line {s['line']}, {s['lines']} lines)"
+                      for s in diff['removed'])
+    if diff['renamed']:
+        output.append(f"Renamed ({len(diff['renamed'])}):")
+        output.extend(f"  ~ {r['kind']} {r['old']} → {r['new']}  ({r['similarity']:.0%} similar, "
+                      f"{_delta(r['old_lines'], r['new_lines'])})" for r in diff['renamed'])
+    if diff['changed']:
+        output.append(f"Changed ({len(diff['changed'])}):")
+        for c in diff['changed']:
+            output.append(f"  ~ {c['kind']} {c['name']}  ({'body changed, ' if c['body_changed'] else ''}"
+                          f"{_delta(c['old_lines'], c['new_lines'])})")
+            if 'old_signature' in c:
+                output.append(f"      - {c['old_signature']}")
+                output.append(f"      + {c['new_signature']}")
+    return output
+
+
+def diff_summary(diff: Dict[str, Any]) -> str:
+    """One-line counts: '1 added, 0 removed, ...'."""
+    return (f"{len(diff['added'])} added, {len(diff['removed'])} removed, {len(diff['renamed'])} renamed, "
+            f"{len(diff['changed'])} changed, {diff['unchanged']} unchanged")
+
+
+def render_diff_markdown(diff: Dict[str, Any]) -> List[str]:
+    """Bullet per symbol change, for release notes and review summaries."""
+    output = [f"- Added {s['kind']} `{s['name']}`" for s in diff['added']]
+    output += [f"- Removed {s['kind']} `{s['name']}`" for s in diff['removed']]
+    output += [f"- Renamed {r['kind']} `{r['old']}` → `{r['new']}`" for r in diff['renamed']]
+    for c in diff['changed']:
+        if 'old_signature' in c:
+            output.append(f"- Changed {c['kind']} `{c['name']}`: `{c['old_signature']}` → `{c['new_signature']}`")
+        else:
+            output.append(f"- Changed {c['kind']} `{c['name']}` ({_delta(c['old_lines'], c['new_lines'])})")
+    return output
+
+
+FileVersion = Tuple[List[str], Dict[str, List[Dict[str, Any]]]]
+
+
+def _public_filter(diff: Dict[str, Any], old_public: Set[str], new_public: Set[str]) -> Dict[str, Any]:
+    return {
+        'added': [s for s in diff['added'] if s['name'] in new_public],
+        'removed': [s for s in diff['removed'] if s['name'] in old_public],
+        'renamed': [r for r in diff['renamed'] if r['old'] in old_public or r['new'] in new_public],
+        'changed': [c for c in diff['changed'] if c['name'] in old_public | new_public],
+        'unchanged': diff['unchanged'],
+    }
+
+
+def diff_trees(old: Dict[str, FileVersion], new: Dict[str, FileVersion], public_only: bool = True) -> Dict[str, Any]:
+    """Compare two trees given as {relative posix path: (lines, structure)}.
+
+    Returns {'added_files': [{'file', 'symbols'}], 'removed_files': [...],
+    'files': [{'file', **diff_symbols}]} with only files whose (public,
+    unless public_only is False) symbols changed; added files list their
+    public symbol names.
+    """
+    def public(path: str, version: Optional[FileVersion]) -> Set[str]:
+        if not version:
+            return set()
+        lines, structure = version
+        names = {str(e['name']) for e in api_entries(path, lines, structure)}
+        # Macros, constants, templates, ...: public by the same rules, as top-level definitions
+        listed = exported_names(path, lines)
+        names.update(str(item['name']) for category, items in structure.items()
+                     if category not in API_CATEGORIES and category not in SKIPPED_CATEGORIES
+                     for item in items if item.get('name') and is_public(path, lines, item, None, listed))
+        return names
+
+    added_files = []
+    for path in sorted(set(new) - set(old)):
+        names = (public(path, new[path]) if public_only
+                 else {symbol['name'] for symbol in file_symbols(path, *new[path]).values()})
+        added_files.append({'file': path, 'symbols': sorted(names)})
+    removed_files = [path for path in sorted(old) if path not in new]
+    files = []
+    for path in sorted(set(old) & set(new)):
+        diff = diff_symbols(file_symbols(path, *old[path]), file_symbols(path, *new[path]))
+        if public_only:
+            diff = _public_filter(diff, public(path, old[path]), public(path, new[path]))
+        if any(diff[key] for key in ('added', 'removed', 'renamed', 'changed')):
+            files.append({'file': path, **diff})
+    return {'added_files': added_files, 'removed_files': removed_files, 'files': files}
+
+
+def tree_summary(tree: Dict[str, Any]) -> str:
+    """One-line counts of file and symbol changes."""
+    totals = {key: sum(len(f[key]) for f in tree['files']) for key in ('added', 'removed', 'renamed', 'changed')}
+    return (f"{len(tree['added_files'])} new, {len(tree['removed_files'])} removed, {len(tree['files'])} changed "
+            f"files; symbols: {totals['added']} added, {totals['removed']} removed, {totals['renamed']} renamed, "
+            f"{totals['changed']} changed")
@@ -0,0 +1,138 @@
+"""Duplicate functions: bodies compared as token shingles, identical or near-identical.
+
+Bodies are tokenized with comments and whitespace dropped and literals
+replaced by placeholders, so reformatting or changing a message string
+does not hide a copy. Similarity is the Jaccard index of the sets of
+SHINGLE_SIZE-token runs; exact copies share a hash of the token sequence.
+"""
+
+import hashlib
+import re
+from itertools import combinations
+from typing import Any, Dict, Iterable, List, Set, Tuple
+
+from .metrics import FUNCTION_CATEGORIES, comment_syntax
+
+
+SHINGLE_SIZE = 5
+# Shingles shared by more bodies than this are boilerplate, not evidence of copying
+_COMMON_SHINGLE = 50
+
+_TOKEN = re.compile(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'|`[^`]*`|\d[\w.]*|\w+|[^\s\w]')
+
+
+def body_tokens(lines: List[str], start: int, end: int, file_name: str, keep_literals: bool = False) -> List[str]:
+    """Tokens of a function body (header line excluded), comments dropped, literals as STR/NUM unless kept."""
+    markers, blocks = comment_syntax(file_name)
+    tokens: List[str] = []
+    closer = None
+    for text in lines[start:end]:
+        stripped = text.strip()
+        if closer is not None:
+            if closer in stripped:
+                closer = None
+            continue
+        opened = next(((open_, close) for open_, close in blocks if stripped.startswith(open_)), None)
+        if opened is not None:
+            if opened[1] not in stripped[len(opened[0]):]:
+                closer = opened[1]
+            continue
+        if not stripped or markers and stripped.startswith(markers):
+            continue
+        for token in _TOKEN.findall(stripped):
+            if keep_literals:
+                tokens.append(token)
+            elif token[0] in '"\'`':
+                tokens.append('STR')
+            elif token[0].isdigit():
+                tokens.append('NUM')
+            else:
+                tokens.append(token)
+    return tokens
+
+
+def shingles(tokens: List[str], size: int = SHINGLE_SIZE) -> Set[int]:
+    """Hashes of every run of size consecutive tokens (the whole sequence if shorter)."""
+    runs = [tuple(tokens[i:i + size]) for i in range(max(len(tokens) - size + 1, 1))]
+    return {hash(run) for run in runs}
+
+
+def similarity(a: Set[int], b: Set[int]) -> float:
+    """Jaccard index of two shingle sets."""
+    return len(a & b) / len(a | b) if a or b else 1.0
+
+
+def project_functions(files: Iterable[Tuple[str, List[str], Dict[str, List[Dict[str, Any]]]]],
+                      min_lines: int) -> List[Dict[str, Any]]:
+    """Functions with at least min_lines non-empty body lines.
+
+    Each is {'file', 'name', 'line', 'line_end', 'lines', 'tokens'}.
+    """
+    functions = []
+    for file_name, lines, structure in files:
+        for category in FUNCTION_CATEGORIES:
+            for item in structure.get(category, []):
+                start, end = item.get('line'), item.get('line_end')
+                if not isinstance(start, int) or not isinstance(end, int):
+                    continue
+                body = sum(1 for text in lines[start:end] if text.strip())
+                if body < min_lines:
+                    continue
+                functions.append({'file': file_name, 'name': item.get('name', ''), 'line': start, 'line_end': end,
+                                  'lines': end - start + 1, 'tokens': body_tokens(lines, start, end, file_name)})
+    return functions
+
+
+def find_duplicates(functions: List[Dict[str, Any]], threshold: float = 0.8) -> List[Dict[str, Any]]:
+    """Groups of functions at least threshold similar, most similar and largest first.
+
+    Each group is {'similarity', 'lines', 'functions'}: similarity is the
+    lowest between linked members (1.0 for identical token sequences),
+    lines the duplicated lines beyond the first copy.
+    """
+    sets = [shingles(f['tokens']) for f in functions]
+    digests = [hashlib.sha1('\x00'.join(f['tokens']).encode()).hexdigest() for f in functions]
+
+    # Candidate pairs share an uncommon shingle
+    index: Dict[int, List[int]] = {}
+    for number, shingle_set in enumerate(sets):
+        for shingle in shingle_set:
+            index.setdefault(shingle, []).append(number)
+    candidates: Set[Tuple[int, int]] = set()
+    for members in index.values():
+        if 1 < len(members) <= _COMMON_SHINGLE:
+            candidates.update(combinations(members, 2))
+
+    parent = list(range(len(functions)))
+
+    def root(node: int) -> int:
+        while parent[node] != node:
+            parent[node] = parent[parent[node]]
+            node = parent[node]
+        return node
+
+    links: Dict[Tuple[int, int], float] = {}
+    for a, b in sorted(candidates):
+        # Jaccard can't exceed the size ratio; skip pairs that can't reach the threshold
+        if min(len(sets[a]), len(sets[b])) < threshold * max(len(sets[a]), len(sets[b])):
+            continue
+        score = 1.0 if digests[a] == digests[b] else similarity(sets[a], sets[b])
+        if score >= threshold:
+            links[(a, b)] = score
+            parent[root(a)] = root(b)
+
+    groups: Dict[int, List[int]] = {}
+    for number in range(len(functions)):
+        groups.setdefault(root(number), []).append(number)
+    result = []
+    for members in groups.values():
+        if len(members) < 2:
+            continue
+        member_set = set(members)
+        score = min(s for (a, b), s in links.items() if a in member_set)
+        entries = sorted(({k: functions[n][k] for k in ('file', 'name', 'line', 'line_end', 'lines')}
+                          for n in members), key=lambda f: (f['file'], f['line']))
+        result.append({'similarity': round(score, 3),
+                       'lines': sum(f['lines'] for f in entries) - max(f['lines'] for f in entries),
+                       'functions': entries})
+    return sorted(result, key=lambda g: (-g['similarity'], -g['lines'], g['functions'][0]['file']))
@@ -0,0 +1,104 @@
+"""Environment variables a program reads: os.Getenv, os.environ, process.env and their kin.
+
+Reads are matched by the idioms of each language with the variable name
+as a literal (a computed name can't be reported); writes such as
+os.environ['X'] = ... are not reads. A read carries its fallback when
+one is written next to it: the default argument of getenv-style calls,
+or `|| value` / `?? value` / `or value` after it.
+"""
+
+import re
+from typing import Any, Dict, List, Pattern, Tuple
+
+from .metrics import comment_syntax
+from .routes import split_arguments
+
+
+# Source files of the languages whose idioms are matched; docs and config quoting code are not reads
+ENV_SUFFIXES = ('.py', '.pyw', '.go', '.c', '.h', '.cc', '.cpp', '.cxx', '.hh', '.hpp', '.hxx', '.php',
+                '.js', '.mjs', '.cjs', '.jsx', '.ts', '.tsx', '.mts', '.cts', '.rb', '.rake', '.rs',
+                '.java', '.kt', '.kts', '.scala', '.cs', '.ex', '.exs')
+
+_NAME = r'(?P<name>[A-Za-z_]\w*)'
+_QUOTED = r'(?P<q>["\'`])' + _NAME + r'(?P=q)'
+
+# (pattern, whether the default is the call's second argument)
+ENV_PATTERNS: List[Tuple[Pattern, bool]] = [
+    # Python
+    (re.compile(r'\bos\.(?:getenv|environ\.get|environ\.setdefault)\(\s*' + _QUOTED), True),
+    (re.compile(r'(?<![\w.])(?:os\.)?environ\[\s*' + _QUOTED + r'\s*\]'), False),
+    # Go, C, PHP
+    (re.compile(r'\bos\.(?:Getenv|LookupEnv)\(\s*"' + _NAME + '"'), False),
+    (re.compile(r'(?<![\w.])(?:std::)?getenv\(\s*' + _QUOTED), False),
+    (re.compile(r'\$_ENV\[\s*' + _QUOTED + r'\s*\]'), False),
+    # JavaScript, TypeScript
+    (re.compile(r'\b(?:process\.env|import\.meta\.env)\.' + _NAME), False),
+    (re.compile(r'\b(?:process\.env|import\.meta\.env)\[\s*' + _QUOTED + r'\s*\]'), False),
+    # Ruby
+    (re.compile(r'(?<![\w.])ENV\[\s*' + _QUOTED + r'\s*\]'), False),
+    (re.compile(r'(?<![\w.])ENV\.fetch\(\s*' + _QUOTED), True),
+    # Rust
+    (re.compile(r'\b(?:std::)?env::var(?:_os)?\(\s*"' + _NAME + '"'), False),
+    (re.compile(r'\b(?:option_)?env!\(\s*"' + _NAME + '"'), False),
+    # Java, Kotlin, Scala, C#, Elixir
+    (re.compile(r'\bSystem\.getenv\(\s*"' + _NAME + '"'), False),
+    (re.compile(r'\bEnvironment\.GetEnvironmentVariable\(\s*"' + _NAME + '"'), False),
+    (re.compile(r'\bSystem\.(?:get_env|fetch_env!?)\(\s*"' + _NAME + '"'), True),
+]
+
+# const { PORT, HOST = 'localhost' } = process.env
+_DESTRUCTURING = re.compile(r'\{(?P<names>[^{}]*)\}\s*=\s*(?:process\.env|import\.meta\.env)\b')
+_FALLBACK = re.compile(r'^\s*\)?\s*(?:\|\||\?\?|\bor\b)\s*(?P<default>[^\s;,)][^;,)]*)')
+_ASSIGNMENT = re.compile(r'^\s*\]?\s*=(?!=)')
+
+
+def find_env_reads(file_name: str, lines: List[str]) -> List[Dict[str, Any]]:
+    """Environment variable reads in a file: {'name', 'line', 'column'} plus 'default' when there is one."""
+    markers, _blocks = comment_syntax(file_name)
+    reads = []
+    for number, text in enumerate(lines, 1):
+        if markers and text.lstrip().startswith(markers):
+            continue
+        for pattern, default_argument in ENV_PATTERNS:
+            for match in pattern.finditer(text):
+                rest = text[match.end():]
+                if _ASSIGNMENT.match(rest):
+                    continue
+                read = {'name': match.group('name'), 'line': number, 'column': match.start() + 1}
+                arguments = split_arguments(rest) if default_argument else []
+                fallback = _FALLBACK.match(rest) if not arguments else None
+                if len(arguments) > 1:
+                    read['default'] = arguments[1]
+                elif fallback:
+                    read['default'] = fallback.group('default').strip()
+                reads.append(read)
+        destructuring = _DESTRUCTURING.search(text)
+        if destructuring:
+            for entry in destructuring.group('names').split(','):
+                name, _, default = entry.partition('=')
+                name = name.split(':')[0].strip()
+                if re.match(r'^[A-Za-z_]\w*$', name):
+                    read = {'name': name, 'line': number, 'column': destructuring.start() + 1}
+                    if default.strip():
+                        read['default'] = default.strip()
+                    reads.append(read)
+    return sorted(reads, key=lambda read: (read['line'], read['column']))
+
+
+def env_variables(reads: List[Dict[str, Any]]) -> List[Dict[str, Any]]:
+    """Reads grouped by variable: {'name', 'required', 'defaults', 'uses'}, sorted by name.
+
+    required is True when some read has no fallback; defaults lists the
+    distinct fallbacks in the order they appear.
+    """
+    variables: Dict[str, Dict[str, Any]] = {}
+    for read in reads:
+        variable = variables.setdefault(read['name'], {'name': read['name'], 'required': False, 'defaults': [],
+                                                       'uses': []})
+        if 'default' in read:
+            if read['default'] not in variable['defaults']:
+                variable['defaults'].append(read['default'])
+        else:
+            variable['required'] = True
+        variable['uses'].append(read)
+    return [variables[name] for name in sorted(variables)]
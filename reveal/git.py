@@ -0,0 +1,162 @@
+"""Git access for history-aware commands: running git, reading blame, diffs and old file versions."""
+
+import re
+import subprocess
+import time
+from pathlib import Path
+from typing import Any, Dict, List, Optional, Union
+
+
+class GitError(Exception):
+    """git is missing, the path is not in a repository, or a git command failed."""
+
+
+def run_git(args: List[str], cwd: Union[str, Path]) -> str:
+    """Output of `git <args>` run in cwd; raises GitError with git's message on failure."""
+    try:
+        result = subprocess.run(['git', *args], cwd=str(cwd), capture_output=True, text=True,
+                                encoding='utf-8', errors='replace')
+    except FileNotFoundError:
+        raise GitError("git is not installed")
+    if result.returncode != 0:
+        message = result.stderr.strip().splitlines()
+        raise GitError(message[0] if message else f"git {args[0]} failed")
+    return result.stdout
+
+
+def blame(path: Union[str, Path], revision: Optional[str] = None) -> Dict[int, Dict[str, Any]]:
+    """Line number -> {'commit', 'author', 'date' (YYYY-MM-DD), 'time'} for a file's last changes.
+
+    Lines not committed yet have commit '00000000' and author 'Not Committed Yet'.
+    With a revision, the file is blamed as of that commit.
+    """
+    path = Path(path)
+    output = run_git(['blame', '--line-porcelain', *([revision] if revision else []), '--', path.name],
+                     cwd=path.parent or '.')
+    lines: Dict[int, Dict[str, Any]] = {}
+    current: Dict[str, Any] = {}
+    for text in output.splitlines():
+        if text.startswith('\t'):
+            lines[current['line']] = {'commit': current['commit'], 'author': current.get('author', ''),
+                                      'date': current.get('date', ''), 'time': current.get('time', 0)}
+            current = {}
+        elif not current:
+            # <sha> <original line> <final line> [<lines in group>]
+            parts = text.split()
+            current = {'commit': parts[0][:8], 'line': int(parts[2])}
+        elif text.startswith('author '):
+            current['author'] = text[len('author '):]
+        elif text.startswith('author-time '):
+            current['time'] = int(text.split()[1])
+            current['date'] = time.strftime('%Y-%m-%d', time.gmtime(current['time']))
+    return lines
+
+
+def last_change(lines: Dict[int, Dict[str, Any]], start: int, end: int) -> Optional[Dict[str, Any]]:
+    """The most recent blame entry among lines start..end (a symbol's owner and age), None if none."""
+    entries = [lines[number] for number in range(start, end + 1) if number in lines]
+    return max(entries, key=lambda entry: entry['time']) if entries else None
+
+
+def file_at(path: Union[str, Path], revision: str) -> str:
+    """Contents of a file as of a git revision, without checking it out.
+
+    The file need not exist in the working tree any more (nor its directory).
+    """
+    path = Path(path)
+    directory, relative = path.parent, Path(path.name)
+    while not directory.is_dir():
+        directory, relative = directory.parent, Path(directory.name) / relative
+    return run_git(['show', f'{revision}:./{relative.as_posix()}'], cwd=directory)
+
+
+def line_history(path: Union[str, Path], start: int, end: int, revision: str = 'HEAD',
+                 limit: Optional[int] = None) -> List[Dict[str, Any]]:
+    """Commits that changed lines start..end of a file as of revision, newest first (git log -L).
+
+    Each is {'commit', 'author', 'date', 'subject', 'added', 'removed'},
+    added/removed counting the lines the commit changed within the range.
+    git follows the range back through earlier edits that moved it.
+    """
+    path = Path(path)
+    output = run_git(['log', f'-L{start},{end}:./{path.name}', '--format=%x00%H%x09%an%x09%at%x09%s',
+                      '--no-color', '--no-ext-diff', *([f'-n{limit}'] if limit else []), revision, '--'],
+                     cwd=path.parent or '.')
+    commits: List[Dict[str, Any]] = []
+    in_header = False
+    for text in output.splitlines():
+        if text.startswith('\x00'):
+            commit, author, timestamp, subject = (text[1:].split('\t', 3) + [''] * 4)[:4]
+            commits.append({'commit': commit[:8], 'author': author,
+                            'date': time.strftime('%Y-%m-%d', time.gmtime(int(timestamp or 0))),
+                            'subject': subject, 'added': 0, 'removed': 0})
+        elif text.startswith('diff --git '):
+            in_header = True
+        elif text.startswith('@@'):
+            in_header = False
+        elif commits and not in_header and text[:1] in '+-' and text:
+            commits[-1]['added' if text[0] == '+' else 'removed'] += 1
+    return commits
+
+
+_HUNK = re.compile(r'^@@ -\d+(?:,\d+)? \+(?P<start>\d+)(?:,(?P<count>\d+))? @@')
+
+
+def merge_base(ref: str, cwd: Union[str, Path]) -> str:
+    """The commit where HEAD's branch forked from ref."""
+    return run_git(['merge-base', ref, 'HEAD'], cwd).strip()
+
+
+def _diff_path(text: str) -> Optional[str]:
+    """Path of a '--- a/path' or '+++ b/path' diff line, None for /dev/null.
+
+    git ends the line with a tab when the path contains a space.
+    """
+    path = text[4:].rstrip('\t')
+    if path == '/dev/null':
+        return None
+    return path[2:] if path.startswith(('a/', 'b/')) else path
+
+
+def changed_lines(cwd: Union[str, Path], base: str = 'HEAD') -> Dict[str, Dict[str, Any]]:
+    """Files under cwd changed in the working tree since base, untracked ones included.
+
+    Path (relative to cwd, posix) -> {'status': 'added'|'modified'|'deleted'|'renamed',
+    'lines': changed line numbers in the current file, 'deletions': lines
+    after which lines were removed}; untracked files are 'added' with no lines
+    listed (all of them are new).
+    """
+    output = run_git(['-c', 'core.quotepath=off', 'diff', '--unified=0', '--no-color', '--no-ext-diff',
+                      '--src-prefix=a/', '--dst-prefix=b/', '--relative', base, '--'], cwd)
+    files: Dict[str, Dict[str, Any]] = {}
+    current: Optional[Dict[str, Any]] = None
+    old_path = None
+    in_header = False
+    for text in output.splitlines():
+        if text.startswith('diff --git '):
+            current, old_path, in_header = None, None, True
+        elif in_header and text.startswith('rename to '):
+            current = files.setdefault(text[len('rename to '):], {'status': 'renamed', 'lines': set(),
+                                                                   'deletions': set()})
+        elif in_header and text.startswith('--- '):
+            old_path = _diff_path(text)
+        elif in_header and text.startswith('+++ '):
+            path = _diff_path(text)
+            if path is None:
+                status, path = 'deleted', old_path
+            else:
+                status = 'added' if old_path is None else 'renamed' if old_path != path else 'modified'
+            current = files.setdefault(path, {'status': status, 'lines': set(), 'deletions': set()})
+        elif current is not None and text.startswith('@@'):
+            in_header = False
+            match = _HUNK.match(text)
+            if match:
+                start, count = int(match.group('start')), int(match.group('count') or 1)
+                if count:
+                    current['lines'].update(range(start, start + count))
+                else:
+                    current['deletions'].add(start)
+    for path in run_git(['-c', 'core.quotepath=off', 'ls-files', '--others', '--exclude-standard'],
+                        cwd).splitlines():
+        files.setdefault(path, {'status': 'added', 'lines': set(), 'deletions': set()})
+    return files
@@ -0,0 +1,264 @@
+"""Type hierarchies: which classes extend, implement or embed which, across files.
+
+Analyzers that record a superclass (Crystal, PowerShell, ...) are used as
+is; otherwise the declaration header is read: Python 'class A(B, C):',
+Java/TypeScript/PHP 'extends'/'implements', Ruby/Crystal 'class A < B',
+and C#/C++/Kotlin/Swift 'class A : B, C'. Go types come from the source
+itself, with embedded structs and interfaces as their bases.
+"""
+
+import posixpath
+import re
+from typing import Any, Dict, Iterable, List, Optional, Tuple
+
+from .base import category_kind
+from .mermaid import TYPE_CATEGORIES, _bases
+
+
+# Extensions reopen a type rather than declare one
+HIERARCHY_CATEGORIES = tuple(category for category in TYPE_CATEGORIES if category != 'extensions')
+
+_PYTHON_CLASS = re.compile(r'^\s*class\s+\w+\s*(?:\[[^\]]*\])?\s*\((?P<bases>.*)\)\s*:\s*(?:#.*)?$')
+_EXTENDS = re.compile(r'\bextends\s+(?P<bases>.+?)(?=\bimplements\b|\bwith\b|\bwhere\b|\{|$)')
+_IMPLEMENTS = re.compile(r'\bimplements\s+(?P<bases>.+?)(?=\bextends\b|\bwhere\b|\{|$)')
+_RUBY_CLASS = re.compile(r'^\s*(?:\w+\s+)*(?:class|struct)\s+[\w:]+(?:\([^)]*\))?\s+<\s*(?P<bases>[\w:.]+)')
+_COLON_BASES = re.compile(
+    r'^\s*(?:[\w@]+\s+)*(?:class|struct|interface|record|object|protocol|enum)\s+\w+'
+    r'(?:\s*<[^>]*>)?(?:\s*\([^)]*\))?\s*:\s*(?P<bases>[^{]+?)\s*(?:\bwhere\b.*)?\{?\s*$'
+)
+_CONTINUATION = re.compile(r'^(?:extends|implements|with|where|:|,|<)')
+_BASE_PREFIX = re.compile(r'^(?:(?:public|private|protected|internal|virtual|open)\s+)+')
+_IGNORED_BASES = {'object', 'Object'}
+
+_GO_TYPE = re.compile(r'^\s*(?:type\s+)?(?P<name>[A-Za-z_]\w*)(?:\[[^\]]*\])?\s+(?P<kind>struct|interface)\s*\{')
+_GO_INTERFACE_METHOD = re.compile(r'^\s*(?P<name>[A-Za-z_]\w*)\s*(?P<signature>\(.*)$')
+_GO_EMBEDDED = re.compile(r'^\s*\*?(?P<name>(?:\w+\.)?[A-Za-z_]\w*)(?:\[[^\]]*\])?\s*(?:`[^`]*`)?\s*(?://.*)?$')
+
+
+def split_bases(text: str) -> List[str]:
+    """'Base[T], Mixin, metaclass=M' -> ['Base', 'Mixin']: top-level names, without type arguments or keywords."""
+    parts, depth, current = [], 0, ''
+    for char in text:
+        if char in '([<{':
+            depth += 1
+        elif char in ')]>}':
+            depth -= 1
+        if char == ',' and depth == 0:
+            parts.append(current)
+            current = ''
+        else:
+            current += char
+    parts.append(current)
+
+    names = []
+    for part in parts:
+        part = _BASE_PREFIX.sub('', part.strip())
+        if not part or '=' in part or part.startswith('*'):
+            continue
+        name = re.sub(r'[\[(<].*', '', part).strip()
+        if re.fullmatch(r'[\w.:$]+', name) and name not in _IGNORED_BASES:
+            names.append(name)
+    return names
+
+
+def declared_bases(lines: List[str], item: Dict[str, Any]) -> List[Tuple[str, str]]:
+    """(base name, 'extends' | 'implements') for a type declared at item['line']."""
+    recorded = _bases(item)
+    if recorded:
+        return [(base, 'extends') for base in recorded if base not in _IGNORED_BASES]
+
+    # The header continues on following lines while it is unfinished (Java's extends on its own line)
+    start = item.get('line', 0) - 1
+    if not 0 <= start < len(lines):
+        return []
+    header = lines[start].split('//', 1)[0].strip()
+    for text in lines[start + 1:start + 4]:
+        text = text.split('//', 1)[0].strip()
+        if '{' in header or not (header.endswith((',', '(', '<')) or _CONTINUATION.match(text)):
+            break
+        header += ' ' + text
+    header = header.split('{', 1)[0].strip()
+
+    python = _PYTHON_CLASS.match(header)
+    if python:
+        return [(base, 'extends') for base in split_bases(python.group('bases'))]
+    found = []
+    for pattern, relation in ((_EXTENDS, 'extends'), (_IMPLEMENTS, 'implements')):
+        for match in pattern.finditer(header):
+            found.extend((base, relation) for base in split_bases(match.group('bases')))
+    if found:
+        return found
+    ruby = _RUBY_CLASS.match(header)
+    if ruby:
+        return [(ruby.group('bases'), 'extends')]
+    colon = _COLON_BASES.match(header)
+    if colon:
+        return [(base, 'extends') for base in split_bases(colon.group('bases'))]
+    return []
+
+
+def go_types(lines: List[str]) -> List[Dict[str, Any]]:
+    """Go struct and interface types, each with the types it embeds as ('Name', 'embeds') bases.
+
+    Interfaces also list their own methods as (name, signature text) pairs.
+    """
+    types = []
+    current: Optional[Dict[str, Any]] = None
+    depth = 0
+    in_group = False
+    for number, text in enumerate(lines, 1):
+        code = text.split('//', 1)[0]
+        if current is None:
+            if re.match(r'^\s*type\s*\($', code):
+                in_group = True
+                continue
+            if in_group and code.strip() == ')':
+                in_group = False
+                continue
+            match = _GO_TYPE.match(code)
+            if match and (in_group or code.lstrip().startswith('type')):
+                current = {'name': match.group('name'), 'kind': match.group('kind'), 'line': number, 'bases': []}
+                if current['kind'] == 'interface':
+                    current['methods'] = []
+                depth = code.count('{') - code.count('}')
+                if depth <= 0:
+                    current['line_end'] = number
+                    types.append(current)
+                    current = None
+            continue
+
+        if depth == 1:
+            embedded = _GO_EMBEDDED.match(code)
+            method = _GO_INTERFACE_METHOD.match(code)
+            if embedded and code.strip():
+                current['bases'].append((embedded.group('name'), 'embeds'))
+            elif method and current['kind'] == 'interface':
+                current['methods'].append((method.group('name'), method.group('signature').strip()))
+        depth += code.count('{') - code.count('}')
+        if depth <= 0:
+            current['line_end'] = number
+            types.append(current)
+            current = None
+    return types
+
+
+def short_type_name(name: str) -> str:
+    """'models.Base' / 'Shapes::Shape' / 'io.Reader' -> the last part."""
+    return re.split(r'::|\.', name)[-1]
+
+
+class TypeHierarchy:
+    """Types of a project and their bases.
+
+    Project types are 'file::Name' ids with file, name, line and kind in
+    types[id]; bases[id] holds (target, relation) pairs, where target is a
+    project type id or, for types declared elsewhere, the name as written.
+    """
+
+    def __init__(self):
+        self.types: Dict[str, Dict[str, Any]] = {}
+        self.bases: Dict[str, List[Tuple[str, str]]] = {}
+
+    def children(self, node: str) -> List[Tuple[str, str]]:
+        """(subtype id, relation) pairs for types whose bases include node, in declaration order."""
+        return [(child, relation) for child, bases in self.bases.items() for target, relation in bases
+                if target == node]
+
+    def roots(self) -> List[str]:
+        """Project types with subtypes but no bases, then external bases, by name."""
+        targets = {target for bases in self.bases.values() for target, _ in bases}
+        internal = sorted((node for node in self.types if node in targets and not self.bases[node]),
+                          key=lambda node: (self.types[node]['name'], node))
+        external = sorted(target for target in targets if target not in self.types)
+        return internal + external
+
+    def label(self, node: str) -> str:
+        return self.types[node]['name'] if node in self.types else node
+
+    def find(self, name: str) -> List[str]:
+        """Project type ids and external base names matching a (possibly qualified) name."""
+        found = [node for node in self.types
+                 if node.endswith('::' + name) or self.types[node]['name'] == short_type_name(name)]
+        external = {target for bases in self.bases.values() for target, _ in bases if target not in self.types}
+        return found + sorted(target for target in external
+                              if target == name or short_type_name(target) == short_type_name(name))
+
+
+def project_hierarchy(files: Iterable[Tuple[str, List[str], Dict[str, List[Dict[str, Any]]]]]) -> TypeHierarchy:
+    """Hierarchy across (relative path, lines, structure) files.
+
+    A base resolves to the project type of that (last-part) name declared
+    in the same file, else the same directory, else anywhere; names that
+    match nothing stay external.
+    """
+    hierarchy = TypeHierarchy()
+    declared: List[Tuple[str, str, List[Tuple[str, str]]]] = []
+    by_name: Dict[str, List[str]] = {}
+    for file_name, lines, structure in files:
+        if file_name.endswith('.go'):
+            found = [(item, item['kind'], item['bases']) for item in go_types(lines)]
+        else:
+            found = [(item, item.get('kind') or category_kind(category), declared_bases(lines, item))
+                     for category in HIERARCHY_CATEGORIES for item in structure.get(category, [])
+                     if item.get('name')]
+        for item, kind, bases in found:
+            node = f"{file_name}::{item['name']}"
+            if node in hierarchy.types:
+                continue
+            hierarchy.types[node] = {'file': file_name, 'name': item['name'], 'line': item.get('line'),
+                                     'kind': kind}
+            declared.append((node, file_name, bases))
+            by_name.setdefault(short_type_name(item['name']), []).append(node)
+
+    for node, file_name, bases in declared:
+        resolved = []
+        for base, relation in bases:
+            candidates = [c for c in by_name.get(short_type_name(base), []) if c != node]
+            same_file = [c for c in candidates if hierarchy.types[c]['file'] == file_name]
+            same_dir = [c for c in candidates
+                        if posixpath.dirname(hierarchy.types[c]['file']) == posixpath.dirname(file_name)]
+            target = (same_file or same_dir or candidates or [base])[0]
+            if (target, relation) not in resolved:
+                resolved.append((target, relation))
+        hierarchy.bases[node] = resolved
+    return hierarchy
+
+
+def hierarchy_tree(hierarchy: TypeHierarchy, root: str, path: Tuple[str, ...] = ()) -> Dict[str, Any]:
+    """Nested {'name', 'file', 'line', 'kind', 'relation', 'children'} from a root; cycles are cut."""
+    node = {'name': hierarchy.label(root)}
+    if root in hierarchy.types:
+        info = hierarchy.types[root]
+        node.update(file=info['file'], line=info['line'], kind=info['kind'])
+    else:
+        node['external'] = True
+    children = []
+    if root not in path:
+        for child, relation in hierarchy.children(root):
+            subtree = hierarchy_tree(hierarchy, child, path + (root,))
+            subtree['relation'] = relation
+            children.append(subtree)
+    node['children'] = children
+    return node
+
+
+_RELATION_ARROWS = {'extends': '<|--', 'implements': '<|..', 'embeds': '*--'}
+
+
+def render_hierarchy_mermaid(hierarchy: TypeHierarchy) -> str:
+    """classDiagram of the inheritance edges (implements dotted, embeds as composition)."""
+    ids: Dict[str, str] = {}
+
+    def class_id(node: str) -> str:
+        if node not in ids:
+            ids[node] = f'T{len(ids)}'
+        return ids[node]
+
+    lines = ['classDiagram']
+    edges = []
+    for node, bases in hierarchy.bases.items():
+        for target, relation in bases:
+            edges.append(f'    {class_id(target)} {_RELATION_ARROWS[relation]} {class_id(node)}')
+    for node, identifier in ids.items():
+        lines.append(f'    class {identifier}["{hierarchy.label(node)}"]')
+    return '\n'.join(lines + edges)
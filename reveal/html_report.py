@@ -0,0 +1,148 @@
+"""Self-contained HTML report of a project's structure."""
+
+from datetime import datetime
+from html import escape
+from typing import Any, Dict, List
+from .tree_view import _format_size
+
+
+_STYLE = """
+body { font: 14px/1.45 -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+       margin: 0 auto; max-width: 1100px; padding: 1.5em; color: #1f2328; }
+header { position: sticky; top: 0; background: #fff; padding: .5em 0; border-bottom: 1px solid #d0d7de; }
+h1 { font-size: 1.4em; margin: 0 0 .3em; }
+.stats { color: #656d76; }
+#search { width: 100%; box-sizing: border-box; padding: .45em .6em; margin-top: .5em;
+          font-size: 1em; border: 1px solid #d0d7de; border-radius: 6px; }
+details { margin-left: 1.1em; }
+summary { cursor: pointer; padding: 1px 0; }
+summary.dir { font-weight: 600; }
+summary .meta, li .meta { color: #656d76; font-weight: normal; }
+.category { margin: .3em 0 0 1.1em; color: #656d76; font-size: .85em; text-transform: uppercase; letter-spacing: .03em; }
+ul { list-style: none; margin: 0; padding-left: 1.1em; }
+li { white-space: nowrap; }
+code, .loc { font: 12.5px/1.5 ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+.loc { display: inline-block; min-width: 6em; color: #656d76; }
+.hidden { display: none; }
+"""
+
+_SCRIPT = """
+(function () {
+  var input = document.getElementById('search');
+  var files = Array.prototype.slice.call(document.querySelectorAll('details.file'));
+  var dirs = Array.prototype.slice.call(document.querySelectorAll('details.dir')).reverse();
+  var initial = new Map();
+  document.querySelectorAll('details').forEach(function (d) { initial.set(d, d.open); });
+
+  input.addEventListener('input', function () {
+    var query = input.value.trim().toLowerCase();
+    files.forEach(function (file) {
+      var nameMatch = !query || file.dataset.name.indexOf(query) >= 0;
+      var hits = 0;
+      file.querySelectorAll('li').forEach(function (li) {
+        var match = nameMatch || li.textContent.toLowerCase().indexOf(query) >= 0;
+        li.classList.toggle('hidden', !match);
+        if (match) { hits++; }
+      });
+      file.querySelectorAll('.category').forEach(function (category) {
+        var list = category.nextElementSibling;
+        category.classList.toggle('hidden', !list.querySelector('li:not(.hidden)'));
+      });
+      file.classList.toggle('hidden', !(nameMatch || hits));
+      file.open = query ? hits > 0 && !nameMatch : initial.get(file);
+    });
+    dirs.forEach(function (dir) {
+      var visible = dir.querySelector('details.file:not(.hidden)');
+      dir.classList.toggle('hidden', !visible);
+      dir.open = query ? !!visible : initial.get(dir);
+    });
+  });
+})();
+"""
+
+
+def render_html_report(title: str, files: List[Dict[str, Any]]) -> str:
+    """Render files (JSON-output records, 'file' relative to the root) as one HTML page.
+
+    Directories and files are collapsible <details> elements; the search box
+    filters files and symbols by name, signature or path.
+    """
+    tree = {'dirs': {}, 'files': []}
+    for record in files:
+        node = tree
+        parts = record['file'].split('/')
+        for part in parts[:-1]:
+            node = node['dirs'].setdefault(part, {'dirs': {}, 'files': []})
+        node['files'].append((parts[-1], record))
+
+    symbols = sum(len(items) for f in files for items in f.get('structure', {}).values())
+    generated = datetime.now().strftime('%Y-%m-%d %H:%M')
+    body = _render_node(tree, open_levels=1)
+    return (
+        '<!DOCTYPE html>\n<html lang="en">\n<head>\n<meta charset="utf-8">\n'
+        f'<title>{escape(title)} - reveal</title>\n<style>{_STYLE}</style>\n</head>\n<body>\n'
+        f'<header>\n<h1>{escape(title)}</h1>\n'
+        f'<div class="stats">{len(files)} files, {symbols} symbols &middot; generated {generated} by reveal</div>\n'
+        '<input id="search" type="search" placeholder="Filter files and symbols..." autofocus>\n</header>\n'
+        f'<main>\n{body}</main>\n<script>{_SCRIPT}</script>\n</body>\n</html>\n'
+    )
+
+
+def _render_node(node: Dict[str, Any], open_levels: int) -> str:
+    """Directories first (open down to open_levels), then files."""
+    parts = []
+    for name in sorted(node['dirs']):
+        child = node['dirs'][name]
+        opened = ' open' if open_levels > 0 else ''
+        parts.append(f'<details class="dir"{opened}><summary class="dir">{escape(name)}/</summary>\n'
+                     f'{_render_node(child, open_levels - 1)}</details>\n')
+    for name, record in node['files']:
+        parts.append(_render_file(name, record))
+    return ''.join(parts)
+
+
+def _render_file(name: str, record: Dict[str, Any]) -> str:
+    """A file's summary line and its symbols grouped by category."""
+    structure = record.get('structure', {})
+    count = sum(len(items) for items in structure.values())
+    meta = []
+    if record.get('lines') is not None:
+        meta.append(f"{record['lines']} lines")
+    if record.get('type'):
+        meta.append(record['type'])
+    if record.get('size') is not None:
+        meta.append(_format_size(record['size']))
+    if record.get('error'):
+        meta.append(f"error: {record['error']}")
+    if count:
+        meta.append(f"{count} symbols")
+
+    lines = [f'<details class="file" data-name="{escape(record["file"].lower())}">'
+             f'<summary>{escape(name)} <span class="meta">{escape(", ".join(meta))}</span></summary>']
+    for category, items in structure.items():
+        if not items:
+            continue
+        lines.append(f'<div class="category">{escape(category.replace("_", " "))} ({len(items)})</div><ul>')
+        top_level = min(item.get('level', 1) for item in items)
+        for item in items:
+            start = item.get('line', item.get('line_start', '?'))
+            end = item.get('line_end')
+            location = f"{start}-{end}" if end and end != start else str(start)
+            indent = item.get('level', top_level) - top_level
+            style = f' style="padding-left: {indent * 1.2:g}em"' if indent else ''
+            visibility = f' <span class="meta">{escape(item["visibility"])}</span>' if item.get('visibility') else ''
+            lines.append(f'<li{style}><span class="loc">{escape(location)}</span> '
+                         f'<code>{escape(_item_text(item))}</code>{visibility}</li>')
+        lines.append('</ul>')
+    lines.append('</details>\n')
+    return '\n'.join(lines)
+
+
+def _item_text(item: Dict[str, Any]) -> str:
+    if item.get('name'):
+        return f"{item['name']}{item.get('signature', '')}"
+    if item.get('content'):
+        return str(item['content'])
+    if item.get('url'):
+        return f"[{item.get('text', '')}]({item['url']})"
+    return str(item.get('language', ''))
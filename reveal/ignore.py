@@ -0,0 +1,131 @@
+"""Ignore rules for directory walks: .gitignore, .ignore and built-in defaults.
+
+Dependency, virtualenv and build output directories (node_modules,
+.venv, target, ...) are skipped everywhere. On top of those, the
+.gitignore and .ignore files of each directory walked, and of its
+parents up to the repository root, apply with git's semantics: the
+last matching pattern wins, '!' re-includes, a trailing '/' matches
+directories only and a pattern containing '/' is anchored to the
+directory of its file.
+"""
+
+import re
+from pathlib import Path
+from typing import List, Optional, Pattern, Tuple
+
+# Directories of dependencies, environments, caches (reveal's own .reveal index among them) and build output
+DEFAULT_IGNORES = ('node_modules/', 'bower_components/', '.venv/', 'venv/', '__pycache__/', '.tox/', '.nox/',
+                   '.mypy_cache/', '.pytest_cache/', '.ruff_cache/', '.reveal/', '.eggs/', '*.egg-info/', 'target/',
+                   'build/', 'dist/', '.gradle/', '.next/', '.nuxt/', '.terraform/', '.git/', '.hg/', '.svn/')
+IGNORE_FILES = ('.gitignore', '.ignore')
+
+# (directory the pattern is relative to, compiled pattern, negated, directories only)
+Rule = Tuple[Path, Pattern[str], bool, bool]
+
+
+def _translate(pattern: str) -> str:
+    """Regex body for a gitignore glob: '*' and '?' stay within a path segment, '**' spans segments."""
+    out = []
+    i = 0
+    while i < len(pattern):
+        char = pattern[i]
+        if pattern.startswith('**/', i):
+            out.append('(?:.*/)?')
+            i += 3
+            continue
+        if pattern.startswith('**', i):
+            out.append('.*')
+            i += 2
+            continue
+        if char == '*':
+            out.append('[^/]*')
+        elif char == '?':
+            out.append('[^/]')
+        elif char == '[' and ']' in pattern[i + 2:]:
+            end = pattern.index(']', i + 2)
+            body = pattern[i + 1:end]
+            out.append('[' + ('^' + body[1:] if body.startswith('!') else body).replace('\\', '\\\\') + ']')
+            i = end
+        elif char == '\\' and i + 1 < len(pattern):
+            i += 1
+            out.append(re.escape(pattern[i]))
+        else:
+            out.append(re.escape(char))
+        i += 1
+    return ''.join(out)
+
+
+def parse_rule(line: str, base: Path) -> Optional[Rule]:
+    """The rule for one line of an ignore file in directory base, or None for blanks and comments."""
+    line = line.rstrip('\n\r')
+    if not line.endswith('\\ '):
+        line = line.rstrip()
+    if not line or line.startswith('#'):
+        return None
+    negated = line.startswith('!')
+    if negated or line.startswith('\\!') or line.startswith('\\#'):
+        line = line[1:]
+    directories_only = line.endswith('/')
+    line = line.rstrip('/')
+    if not line:
+        return None
+    # A slash anywhere but at the end anchors the pattern to base
+    anchored = '/' in line
+    body = _translate(line.lstrip('/'))
+    regex = re.compile(body if anchored else '(?:.*/)?' + body)
+    return base, regex, negated, directories_only
+
+
+def read_rules(directory: Path) -> List[Rule]:
+    """Rules of the ignore files in directory, in file order."""
+    rules = []
+    for name in IGNORE_FILES:
+        try:
+            text = (directory / name).read_text(encoding='utf-8', errors='replace')
+        except OSError:
+            continue
+        rules.extend(rule for rule in (parse_rule(line, directory) for line in text.splitlines()) if rule)
+    return rules
+
+
+class IgnoreRules:
+    """Rules in effect in one directory of a walk; descend() gives those of a subdirectory."""
+
+    def __init__(self, rules: List[Rule]):
+        self.rules = rules
+
+    @classmethod
+    def for_walk(cls, root: Path) -> 'IgnoreRules':
+        """Defaults plus the ignore files from the repository root (the nearest parent with .git) down to root."""
+        root = root.resolve()
+        rules = [rule for rule in (parse_rule(pattern, root) for pattern in DEFAULT_IGNORES) if rule]
+        chain = []
+        for directory in [root, *root.parents]:
+            chain.append(directory)
+            if (directory / '.git').exists():
+                break
+        else:
+            # Not in a repository: only the walked directory's own files
+            chain = [root]
+        for directory in reversed(chain):
+            rules.extend(read_rules(directory))
+        return cls(rules)
+
+    def descend(self, directory: Path) -> 'IgnoreRules':
+        """Rules inside a subdirectory: these plus its own ignore files."""
+        own = read_rules(directory.resolve())
+        return IgnoreRules(self.rules + own) if own else self
+
+    def ignored(self, path: Path, is_dir: bool) -> bool:
+        """Whether the file or directory is ignored (the last matching rule decides)."""
+        path = path.resolve()
+        for base, regex, negated, directories_only in reversed(self.rules):
+            if directories_only and not is_dir:
+                continue
+            try:
+                relative = path.relative_to(base).as_posix()
+            except ValueError:
+                continue
+            if regex.fullmatch(relative):
+                return not negated
+        return False
@@ -0,0 +1,273 @@
+"""Go interface satisfaction: which types implement an interface, and which interfaces a type satisfies.
+
+Go interfaces are implicit, so this compares method sets. A type's
+methods are its value-receiver methods, its pointer-receiver ones (which
+only *T has) and those promoted from embedded types; an interface's are
+its own plus those of embedded interfaces. Methods match by name and by
+parameter and result types, with package qualifiers ignored (store.ID
+and ID are the same type to a reader of one package). Common standard
+library interfaces are built in.
+"""
+
+import posixpath
+import re
+from typing import Any, Dict, Iterable, List, Optional, Tuple
+
+from .hierarchy import go_types, short_type_name
+
+
+# Method sets of frequently implemented standard library interfaces
+GO_STD_INTERFACES = {
+    'error': ['Error() string'],
+    'fmt.Stringer': ['String() string'],
+    'fmt.GoStringer': ['GoString() string'],
+    'io.Reader': ['Read(p []byte) (n int, err error)'],
+    'io.Writer': ['Write(p []byte) (n int, err error)'],
+    'io.Closer': ['Close() error'],
+    'io.Seeker': ['Seek(offset int64, whence int) (int64, error)'],
+    'io.ReaderAt': ['ReadAt(p []byte, off int64) (n int, err error)'],
+    'io.WriterAt': ['WriteAt(p []byte, off int64) (n int, err error)'],
+    'io.ReaderFrom': ['ReadFrom(r io.Reader) (n int64, err error)'],
+    'io.WriterTo': ['WriteTo(w io.Writer) (n int64, err error)'],
+    'io.ByteReader': ['ReadByte() (byte, error)'],
+    'io.StringWriter': ['WriteString(s string) (n int, err error)'],
+    'io.ReadWriter': ['Read(p []byte) (n int, err error)', 'Write(p []byte) (n int, err error)'],
+    'io.ReadCloser': ['Read(p []byte) (n int, err error)', 'Close() error'],
+    'io.WriteCloser': ['Write(p []byte) (n int, err error)', 'Close() error'],
+    'io.ReadWriteCloser': ['Read(p []byte) (n int, err error)', 'Write(p []byte) (n int, err error)',
+                           'Close() error'],
+    'sort.Interface': ['Len() int', 'Less(i, j int) bool', 'Swap(i, j int)'],
+    'heap.Interface': ['Len() int', 'Less(i, j int) bool', 'Swap(i, j int)', 'Push(x any)', 'Pop() any'],
+    'http.Handler': ['ServeHTTP(w http.ResponseWriter, r *http.Request)'],
+    'http.RoundTripper': ['RoundTrip(r *http.Request) (*http.Response, error)'],
+    'json.Marshaler': ['MarshalJSON() ([]byte, error)'],
+    'json.Unmarshaler': ['UnmarshalJSON(data []byte) error'],
+    'encoding.TextMarshaler': ['MarshalText() (text []byte, err error)'],
+    'encoding.TextUnmarshaler': ['UnmarshalText(text []byte) error'],
+    'encoding.BinaryMarshaler': ['MarshalBinary() (data []byte, err error)'],
+    'encoding.BinaryUnmarshaler': ['UnmarshalBinary(data []byte) error'],
+    'flag.Value': ['String() string', 'Set(value string) error'],
+    'driver.Valuer': ['Value() (driver.Value, error)'],
+    'sql.Scanner': ['Scan(src any) error'],
+    'context.Context': ['Deadline() (deadline time.Time, ok bool)', 'Done() <-chan struct{}', 'Err() error',
+                        'Value(key any) any'],
+}
+
+_GO_METHOD = re.compile(r'^func\s*\(\s*(?:\w+\s+)?(?P<pointer>\*?)\s*(?P<receiver>\w+)(?:\[[^\]]*\])?\s*\)\s*'
+                        r'(?P<name>[A-Za-z_]\w*)\s*(?:\[[^\]]*\])?\s*(?P<signature>\(.*)$')
+_GO_NAMED_TYPE = re.compile(r'^type\s+(?P<name>[A-Za-z_]\w*)(?:\[[^\]]*\])?\s+(?!struct\b|interface\b|=)\S')
+_EMPTY_BRACES = re.compile(r'\b(interface|struct)\s*\{\s*\}')
+_TYPE_KEYWORDS = ('chan', 'func', 'map', 'struct', 'interface')
+
+Signature = Tuple[Tuple[str, ...], Tuple[str, ...]]
+
+
+def _balanced(text: str) -> Tuple[str, str]:
+    """'(a, b(c)) rest' -> ('a, b(c)', ' rest')."""
+    depth = 0
+    for index, char in enumerate(text):
+        depth += char in '([{'
+        depth -= char in ')]}'
+        if depth == 0:
+            return text[1:index], text[index + 1:]
+    return text[1:], ''
+
+
+def _split_top(text: str) -> List[str]:
+    parts, depth, current = [], 0, ''
+    for char in text:
+        depth += char in '([{'
+        depth -= char in ')]}'
+        if char == ',' and depth == 0:
+            parts.append(current)
+            current = ''
+        else:
+            current += char
+    return [part.strip() for part in parts + [current] if part.strip()]
+
+
+def _type_list(text: str) -> Tuple[str, ...]:
+    """Types of a parameter or result list: 'a, b int, p []byte' -> ('int', 'int', '[]byte')."""
+    parts = _split_top(text)
+    named = [re.match(r'^([A-Za-z_]\w*)\s+(\S.*)$', part) for part in parts]
+    named = [match if match and match.group(1) not in _TYPE_KEYWORDS else None for match in named]
+    if not any(named):
+        return tuple(normalize_type(part) for part in parts)
+    types = []
+    pending = 0
+    for match in named:
+        if match:
+            types.extend([normalize_type(match.group(2))] * (pending + 1))
+            pending = 0
+        else:
+            # A bare name shares the type of the next named parameter
+            pending += 1
+    return tuple(types)
+
+
+def normalize_type(text: str) -> str:
+    """Comparable spelling of a type: no package qualifiers, interface{} as any, no spaces."""
+    text = _EMPTY_BRACES.sub(lambda m: 'any' if m.group(1) == 'interface' else 'struct{}', text)
+    text = re.sub(r'\b[A-Za-z_]\w*\.(?=[A-Za-z_])', '', text)
+    return re.sub(r'\s+', '', text)
+
+
+def go_signature(text: str) -> Signature:
+    """(parameter types, result types) from a signature: '(p []byte) (n int, err error) {' -> (('[]byte',), ('int', 'error'))."""
+    text = _EMPTY_BRACES.sub(lambda m: m.group(1) + '\0', text.strip())
+    params, rest = _balanced(text)
+    rest = rest.split('{', 1)[0].split('//', 1)[0].strip().replace('\0', '{}')
+    params = params.replace('\0', '{}')
+    if rest.startswith('('):
+        results = _type_list(_balanced(rest)[0])
+    else:
+        results = (normalize_type(rest),) if rest else ()
+    return _type_list(params), results
+
+
+def _parse_method(text: str) -> Tuple[str, Signature]:
+    name, signature = re.match(r'^\s*(\w+)\s*(\(.*)$', text).groups()
+    return name, go_signature(signature)
+
+
+def go_methods(lines: List[str]) -> List[Dict[str, Any]]:
+    """Methods with receivers: {'receiver', 'pointer', 'name', 'signature', 'line'}."""
+    methods = []
+    for number, text in enumerate(lines, 1):
+        if not text.startswith('func'):
+            continue
+        # Parameters may wrap onto following lines
+        header = text
+        for extra in lines[number:number + 8]:
+            if header.count('(') <= header.count(')'):
+                break
+            header += ' ' + extra.strip()
+        match = _GO_METHOD.match(header)
+        if match:
+            methods.append({'receiver': match.group('receiver'), 'pointer': bool(match.group('pointer')),
+                            'name': match.group('name'), 'signature': go_signature(match.group('signature')),
+                            'line': number})
+    return methods
+
+
+class GoPackageIndex:
+    """Go types, interfaces and methods of a project, keyed by (directory, type name).
+
+    types[key] holds file, line, kind and embedded bases; methods[key] maps
+    method names to (signature, pointer receiver).
+    """
+
+    def __init__(self):
+        self.types: Dict[Tuple[str, str], Dict[str, Any]] = {}
+        self.methods: Dict[Tuple[str, str], Dict[str, Tuple[Signature, bool]]] = {}
+
+    def add_file(self, file_name: str, lines: List[str]) -> None:
+        directory = posixpath.dirname(file_name)
+        for item in go_types(lines):
+            self.types.setdefault((directory, item['name']), {**item, 'file': file_name})
+        for number, text in enumerate(lines, 1):
+            named = _GO_NAMED_TYPE.match(text)
+            if named:
+                self.types.setdefault((directory, named.group('name')),
+                                      {'name': named.group('name'), 'kind': 'type', 'line': number, 'bases': [],
+                                       'file': file_name})
+        for method in go_methods(lines):
+            self.methods.setdefault((directory, method['receiver']), {})[method['name']] = (
+                method['signature'], method['pointer'])
+
+    def lookup(self, name: str, directory: str = '') -> Optional[Tuple[str, str]]:
+        """Key of the project type a possibly qualified name refers to: same directory first, then pkg.Name by directory name."""
+        short = short_type_name(name)
+        qualifier = name[:-len(short) - 1] if name != short else ''
+        if not qualifier and (directory, short) in self.types:
+            return directory, short
+        matches = sorted(key for key in self.types if key[1] == short
+                         and (not qualifier or posixpath.basename(key[0]) == short_type_name(qualifier)))
+        return matches[0] if matches else None
+
+    def interface_methods(self, name: str, directory: str = '', seen: Tuple[str, ...] = ()) -> Optional[Dict[str, Signature]]:
+        """Method set of an interface (project or built-in), or None when it is unknown."""
+        key = self.lookup(name, directory)
+        if key and self.types[key]['kind'] == 'interface':
+            info = self.types[key]
+            methods = {}
+            for embedded, _ in info['bases']:
+                if embedded not in seen:
+                    methods.update(self.interface_methods(embedded, key[0], seen + (embedded,)) or {})
+            methods.update(_parse_method(f'{n}{s}') for n, s in info['methods'])
+            return methods
+        if name in GO_STD_INTERFACES:
+            return dict(_parse_method(text) for text in GO_STD_INTERFACES[name])
+        return None
+
+    def method_set(self, key: Tuple[str, str], seen: Tuple[Tuple[str, str], ...] = ()) -> Dict[str, Tuple[Signature, bool]]:
+        """Methods of a type with whether they need a pointer receiver, promoted ones included."""
+        methods: Dict[str, Tuple[Signature, bool]] = {}
+        info = self.types.get(key, {})
+        if info.get('kind') == 'struct':
+            for embedded, _ in info.get('bases', []):
+                interface = self.interface_methods(embedded, key[0])
+                embedded_key = self.lookup(embedded, key[0])
+                if embedded_key and self.types[embedded_key]['kind'] != 'interface' and embedded_key not in seen:
+                    methods.update(self.method_set(embedded_key, seen + (key,)))
+                elif interface:
+                    methods.update((name, (signature, False)) for name, signature in interface.items())
+        methods.update(self.methods.get(key, {}))
+        return methods
+
+    def satisfies(self, key: Tuple[str, str], interface: Dict[str, Signature]) -> Optional[bool]:
+        """None if the type lacks an interface method, else whether only *T satisfies it."""
+        methods = self.method_set(key)
+        pointer = False
+        for name, signature in interface.items():
+            if name not in methods or methods[name][0] != signature:
+                return None
+            pointer = pointer or methods[name][1]
+        return pointer
+
+    def concrete_types(self) -> List[Tuple[str, str]]:
+        return sorted(key for key, info in self.types.items() if info['kind'] != 'interface')
+
+    def interfaces(self) -> List[Tuple[str, str]]:
+        return sorted(key for key, info in self.types.items() if info['kind'] == 'interface')
+
+
+def go_package_index(files: Iterable[Tuple[str, List[str]]]) -> GoPackageIndex:
+    """Index of (relative path, lines) Go files."""
+    index = GoPackageIndex()
+    for file_name, lines in files:
+        index.add_file(file_name, lines)
+    return index
+
+
+def implementations(index: GoPackageIndex, interface: str) -> List[Dict[str, Any]]:
+    """Project types satisfying an interface: {'type', 'file', 'line', 'pointer'}; [] for unknown interfaces."""
+    key = index.lookup(interface)
+    methods = index.interface_methods(key[1], key[0]) if key else index.interface_methods(interface)
+    if not methods:
+        return []
+    found = []
+    for concrete in index.concrete_types():
+        pointer = index.satisfies(concrete, methods)
+        if pointer is not None:
+            info = index.types[concrete]
+            found.append({'type': concrete[1], 'file': info['file'], 'line': info['line'], 'pointer': pointer})
+    return found
+
+
+def satisfied_interfaces(index: GoPackageIndex, key: Tuple[str, str]) -> List[Dict[str, Any]]:
+    """Interfaces (project, then built-in) a type satisfies: {'interface', 'file', 'line', 'pointer'}."""
+    found = []
+    for interface in index.interfaces():
+        methods = index.interface_methods(interface[1], interface[0])
+        if methods:
+            pointer = index.satisfies(key, methods)
+            if pointer is not None:
+                info = index.types[interface]
+                found.append({'interface': interface[1], 'file': info['file'], 'line': info['line'],
+                              'pointer': pointer})
+    for name, texts in GO_STD_INTERFACES.items():
+        pointer = index.satisfies(key, dict(_parse_method(text) for text in texts))
+        if pointer is not None:
+            found.append({'interface': name, 'std': True, 'pointer': pointer})
+    return found
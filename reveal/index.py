@@ -0,0 +1,150 @@
+"""Project symbol index: every file's structure and identifiers, saved in .reveal/index.json.
+
+`reveal index build` writes it; cross-file features (search, refs,
+--call-graph) then read files that are unchanged since the build from
+the index instead of analyzing them. Each file is keyed by its path
+relative to the indexed directory and checked against its modification
+time and size, so an edited, added or removed file is simply analyzed
+as usual: results never differ from a full walk, they only come faster.
+"""
+
+import json
+import os
+import re
+import time
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Optional, Set, Tuple
+
+from .base import FileAnalyzer
+from .refs import code_lines
+
+
+INDEX_VERSION = 3
+INDEX_FILE = Path('.reveal') / 'index.json'
+
+# Item fields kept in the index: names, kinds, line ranges, signatures, import statements and the modules
+# they name, enough for search, enclosing symbols and the call graph's definitions. The rest (docstrings,
+# decorators, parameters, complexity, ...) is dropped to keep the index small: a caller that needs another
+# field checks ProjectIndex.keeps and analyzes the file instead.
+_ITEM_FIELDS = ('name', 'line', 'line_start', 'line_end', 'kind', 'signature', 'content', 'modules')
+_WORD = re.compile(r'[A-Za-z_$][\w$]*')
+
+
+def identifier_words(lines: List[str]) -> Set[str]:
+    """Every identifier-like word in the lines."""
+    return {word for text in lines for word in _WORD.findall(text)}
+
+
+def _compact(structure: Dict[str, List[Dict[str, Any]]]) -> Dict[str, List[Dict[str, Any]]]:
+    return {category: [{key: item[key] for key in _ITEM_FIELDS if item.get(key) is not None} for item in items]
+            for category, items in structure.items() if isinstance(items, list)}
+
+
+def build_index(root: Path, files: Iterable[Tuple[Path, FileAnalyzer, Dict[str, List[Dict[str, Any]]]]]
+                ) -> Dict[str, Any]:
+    """Index of (file path, analyzer, structure) entries of files under root."""
+    from . import __version__
+
+    entries = {}
+    for file_path, analyzer, structure in files:
+        stat = file_path.stat()
+        entries[file_path.resolve().relative_to(root.resolve()).as_posix()] = {
+            'mtime': stat.st_mtime_ns,
+            'size': stat.st_size,
+            'type': analyzer.type_name,
+            'structure': _compact(structure),
+            # Words of the code only: string literals (secrets among them) and comments stay out of the index
+            'words': sorted(identifier_words(code_lines(file_path.name, analyzer.lines))),
+        }
+    return {'version': INDEX_VERSION, 'reveal': __version__, 'built': time.strftime('%Y-%m-%dT%H:%M:%S'),
+            'fields': list(_ITEM_FIELDS), 'files': entries}
+
+
+def write_index(root: Path, index: Dict[str, Any]) -> Path:
+    """Write the index under root/.reveal/ (atomically) and return its path.
+
+    The directory gets a .gitignore of its own, so the index is never committed.
+    """
+    target = root / INDEX_FILE
+    target.parent.mkdir(parents=True, exist_ok=True)
+    gitignore = target.parent / '.gitignore'
+    if not gitignore.exists():
+        gitignore.write_text('*\n', encoding='utf-8')
+    temporary = target.with_name(target.name + '.tmp')
+    with open(temporary, 'w', encoding='utf-8') as f:
+        json.dump(index, f, separators=(',', ':'))
+    os.replace(temporary, target)
+    return target
+
+
+class ProjectIndex:
+    """A loaded index and the directory it covers."""
+
+    def __init__(self, root: Path, data: Dict[str, Any]):
+        self.root = root.resolve()
+        self.data = data
+        self.files: Dict[str, Dict[str, Any]] = data['files']
+        self._words: Dict[int, Set[str]] = {}
+
+    @classmethod
+    def load(cls, path: Path) -> Optional['ProjectIndex']:
+        """The index covering path: in path/.reveal/ or the nearest parent directory that has one.
+
+        None when there is none, or it is unreadable, from another index
+        format or built by another version of reveal, whose analyzers may
+        find other structure: it is stale then.
+        """
+        from . import __version__
+
+        for directory in [path.resolve(), *path.resolve().parents]:
+            index_file = directory / INDEX_FILE
+            if not index_file.is_file():
+                continue
+            try:
+                with open(index_file, encoding='utf-8') as f:
+                    data = json.load(f)
+            except (OSError, ValueError):
+                return None
+            if not isinstance(data, dict) or data.get('version') != INDEX_VERSION or data.get('reveal') != __version__:
+                return None
+            return cls(directory, data)
+        return None
+
+    def keeps(self, fields: Iterable[str]) -> bool:
+        """Whether indexed structure items carry all these fields (when they have them at all)."""
+        return set(fields) <= set(self.data.get('fields', ()))
+
+    def relative(self, file_path: Path) -> Optional[str]:
+        """The file's key in the index, or None when it lies outside the indexed directory."""
+        try:
+            return file_path.resolve().relative_to(self.root).as_posix()
+        except ValueError:
+            return None
+
+    def entry(self, file_path: Path) -> Optional[Dict[str, Any]]:
+        """The file's entry when it is unchanged since the index was built, else None."""
+        key = self.relative(file_path)
+        entry = self.files.get(key) if key is not None else None
+        if entry is None:
+            return None
+        try:
+            stat = file_path.stat()
+        except OSError:
+            return None
+        return entry if (stat.st_mtime_ns, stat.st_size) == (entry['mtime'], entry['size']) else None
+
+    def mentions(self, entry: Dict[str, Any], word: str) -> bool:
+        """Whether the indexed file may use the word in code (always, for words the index doesn't track)."""
+        if not _WORD.fullmatch(word):
+            return True
+        key = id(entry)
+        if key not in self._words:
+            self._words[key] = set(entry['words'])
+        return word in self._words[key]
+
+    def defines(self, entry: Dict[str, Any], names: Set[str]) -> bool:
+        """Whether the indexed file defines any of the names (compared unqualified: Server.Start as Start)."""
+        from .calls import short_name
+
+        return any(short_name(str(item['name'])) in names
+                   for items in entry['structure'].values() for item in items if item.get('name'))
@@ -0,0 +1,102 @@
+"""Symbols in LSP DocumentSymbol form (textDocument/documentSymbol results)."""
+
+import re
+from typing import Any, Dict, List
+
+from .base import category_kind
+from .tags import SKIPPED_CATEGORIES
+
+
+# LSP SymbolKind values
+SYMBOL_KINDS = {
+    'file': 1, 'module': 2, 'namespace': 3, 'package': 4, 'class': 5, 'method': 6, 'property': 7,
+    'field': 8, 'constructor': 9, 'enum': 10, 'interface': 11, 'function': 12, 'variable': 13,
+    'constant': 14, 'string': 15, 'number': 16, 'boolean': 17, 'array': 18, 'object': 19, 'key': 20,
+    'null': 21, 'enum_member': 22, 'struct': 23, 'event': 24, 'operator': 25, 'type_parameter': 26,
+}
+# Reveal kinds/categories that go by another name in LSP
+KIND_ALIASES = {
+    'class_method': 'method', 'static_method': 'method', 'proc': 'function', 'func': 'function',
+    'converter': 'function', 'iterator': 'function', 'macro': 'function', 'template': 'function',
+    'subroutine': 'function', 'procedure': 'function', 'def': 'function', 'fn': 'function',
+    'trait': 'interface', 'protocol': 'interface', 'record': 'struct', 'union': 'struct',
+    'type': 'class', 'object': 'class', 'ref object': 'class', 'contract': 'class', 'mixin': 'class',
+    'heading': 'string', 'section': 'namespace', 'table': 'namespace', 'alias': 'type_parameter',
+    'const': 'constant', 'var': 'variable', 'variables': 'variable', 'global': 'variable',
+    'constants': 'constant', 'label': 'key', 'target': 'function', 'rule': 'function',
+    'service': 'interface', 'message': 'struct', 'lib': 'module',
+}
+
+
+def symbol_kind(category: str, item: Dict[str, Any]) -> int:
+    """SymbolKind for an item: its own kind if LSP knows it, else its category's."""
+    for kind in (item.get('kind'), category_kind(category)):
+        if not isinstance(kind, str):
+            continue
+        kind = KIND_ALIASES.get(kind, kind)
+        if kind in SYMBOL_KINDS:
+            return SYMBOL_KINDS[kind]
+    return SYMBOL_KINDS['variable']
+
+
+def document_symbols(lines: List[str], structure: Dict[str, List[Dict[str, Any]]]) -> List[Dict[str, Any]]:
+    """DocumentSymbol tree for a file; definitions nest by line range.
+
+    Lines and characters are zero-based as in LSP. A child named
+    Parent#name / Parent.name / Parent::name is shown as name.
+    """
+    flat = []
+    for category, items in structure.items():
+        if category in SKIPPED_CATEGORIES:
+            continue
+        for item in items:
+            line = item.get('line') or item.get('line_start')
+            if item.get('name') and isinstance(line, int):
+                flat.append((line, item.get('line_end') or line, category, item))
+    # Outer definitions first so they can adopt what follows
+    flat.sort(key=lambda entry: (entry[0], -entry[1]))
+
+    roots: List[Dict[str, Any]] = []
+    open_symbols: List[tuple] = []   # (line_end, full name, symbol)
+    for start, end, category, item in flat:
+        while open_symbols and open_symbols[-1][0] < start:
+            open_symbols.pop()
+        parent = next((entry for entry in reversed(open_symbols) if end <= entry[0]), None)
+
+        name = item['name']
+        if parent:
+            for separator in ('#', '::', '.'):
+                if name.startswith(parent[1] + separator):
+                    name = name[len(parent[1]) + len(separator):]
+                    break
+        symbol = {
+            'name': name,
+            'kind': symbol_kind(category, item),
+            'range': _range(lines, start, end),
+            'selectionRange': _selection_range(lines, start, name),
+            'children': [],
+        }
+        detail = re.sub(r'\s{2}\(.*\)$', '', item.get('signature', '')).strip()
+        if detail:
+            symbol['detail'] = detail
+        (parent[2]['children'] if parent else roots).append(symbol)
+        open_symbols.append((end, item['name'], symbol))
+
+    return roots
+
+
+def _range(lines: List[str], start: int, end: int) -> Dict[str, Any]:
+    end_text = lines[end - 1] if 0 < end <= len(lines) else ''
+    return {'start': {'line': start - 1, 'character': 0},
+            'end': {'line': end - 1, 'character': len(end_text.rstrip('\r\n'))}}
+
+
+def _selection_range(lines: List[str], line: int, name: str) -> Dict[str, Any]:
+    """The name on its definition line, or the whole line when it doesn't appear there."""
+    text = lines[line - 1] if 0 < line <= len(lines) else ''
+    short = re.split(r'#|::|\.', name)[-1] or name
+    column = text.find(short)
+    if column < 0:
+        column, short = len(text) - len(text.lstrip()), text.strip()
+    return {'start': {'line': line - 1, 'character': column},
+            'end': {'line': line - 1, 'character': column + len(short)}}
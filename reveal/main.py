@@ -3,11 +3,17 @@
 import sys
 import os
 import argparse
+import re
 from pathlib import Path
-from typing import Optional, Dict, List, Any
+from typing import Any, Callable, Dict, List, Optional, Set, Tuple
 from datetime import datetime, timedelta
-from .base import get_analyzer, get_all_analyzers, FileAnalyzer
-from .tree_view import show_directory_tree
+from .base import (get_analyzer, get_all_analyzers, category_kind, enclosing_symbols, find_symbols, SYMBOL_QUALIFIER,
+                   DOC_LINE, FileAnalyzer, split_symbol_target, use_source)
+from .git import GitError, blame, file_at, last_change
+from .tree_view import show_directory_tree, iter_directory_files, iter_directory_tree
+from .workers import ordered_map
+from .cache import cached_structure
+from .sniff import DEFAULT_MAX_FILE_SIZE, large_file_summary, oversized, parse_size, skip_reason
 from . import __version__
 
 
@@ -16,6 +22,14 @@ from . import __version__
 # Breadcrumb System - Agent-Friendly Navigation Hints
 # ============================================================================
 
+# Programming languages: breadcrumbs suggest <function>, --check and --outline
+CODE_FILE_TYPES = {
+    'python', 'javascript', 'typescript', 'rust', 'go', 'bash', 'gdscript',
+    'java', 'kotlin', 'c', 'cpp', 'csharp',
+    'ruby', 'php', 'swift', 'scala',
+}
+
+
 def get_element_placeholder(file_type):
     """Get appropriate element placeholder for file type.
 
@@ -25,14 +39,10 @@ def get_element_placeholder(file_type):
     Returns:
         String placeholder like '<function>', '<key>', etc.
     """
+    if file_type in CODE_FILE_TYPES:
+        return '<function>'
+
     mapping = {
-        'python': '<function>',
-        'javascript': '<function>',
-        'typescript': '<function>',
-        'rust': '<function>',
-        'go': '<function>',
-        'bash': '<function>',
-        'gdscript': '<function>',
         'yaml': '<key>',
         'json': '<key>',
         'jsonl': '<entry>',
@@ -59,8 +69,58 @@ def get_file_type_from_analyzer(analyzer):
         'PythonAnalyzer': 'python',
         'JavaScriptAnalyzer': 'javascript',
         'TypeScriptAnalyzer': 'typescript',
+        'TSXAnalyzer': 'typescript',
         'RustAnalyzer': 'rust',
         'GoAnalyzer': 'go',
+        'JavaAnalyzer': 'java',
+        'KotlinAnalyzer': 'kotlin',
+        'CAnalyzer': 'c',
+        'CppAnalyzer': 'cpp',
+        'CSharpAnalyzer': 'csharp',
+        'RubyAnalyzer': 'ruby',
+        'PHPAnalyzer': 'php',
+        'SwiftAnalyzer': 'swift',
+        'ScalaAnalyzer': 'scala',
+        'SQLAnalyzer': 'sql',
+        'ProtobufAnalyzer': 'protobuf',
+        'TerraformAnalyzer': 'terraform',
+        'MakefileAnalyzer': 'makefile',
+        'CMakeAnalyzer': 'cmake',
+        'HTMLAnalyzer': 'html',
+        'CSSAnalyzer': 'css',
+        'SCSSAnalyzer': 'scss',
+        'VueAnalyzer': 'vue',
+        'SvelteAnalyzer': 'svelte',
+        'ElixirAnalyzer': 'elixir',
+        'HaskellAnalyzer': 'haskell',
+        'LuaAnalyzer': 'lua',
+        'ZigAnalyzer': 'zig',
+        'DartAnalyzer': 'dart',
+        'ObjCAnalyzer': 'objc',
+        'LispAnalyzer': 'lisp',
+        'OCamlAnalyzer': 'ocaml',
+        'FSharpAnalyzer': 'fsharp',
+        'GradleAnalyzer': 'gradle',
+        'SolidityAnalyzer': 'solidity',
+        'ErlangAnalyzer': 'erlang',
+        'PerlAnalyzer': 'perl',
+        'RAnalyzer': 'r',
+        'JuliaAnalyzer': 'julia',
+        'PowerShellAnalyzer': 'powershell',
+        'FortranAnalyzer': 'fortran',
+        'VerilogAnalyzer': 'verilog',
+        'VHDLAnalyzer': 'vhdl',
+        'BazelAnalyzer': 'bazel',
+        'ThriftAnalyzer': 'thrift',
+        'AvroSchemaAnalyzer': 'avro',
+        'AvroIDLAnalyzer': 'avro',
+        'LaTeXAnalyzer': 'latex',
+        'AssemblyAnalyzer': 'assembly',
+        'XmlAnalyzer': 'xml',
+        'CsvAnalyzer': 'csv',
+        'IniAnalyzer': 'ini',
+        'NimAnalyzer': 'nim',
+        'CrystalAnalyzer': 'crystal',
         'BashAnalyzer': 'bash',
         'MarkdownAnalyzer': 'markdown',
         'YamlAnalyzer': 'yaml',
@@ -95,7 +155,7 @@ def print_breadcrumbs(context, path, file_type=None, **kwargs):
         element_placeholder = get_element_placeholder(file_type)
         print(f"Next: reveal {path} {element_placeholder}   # Extract specific element")
 
-        if file_type in ['python', 'javascript', 'typescript', 'rust', 'go', 'bash', 'gdscript']:
+        if file_type in CODE_FILE_TYPES:
             print(f"      reveal {path} --check      # Check code quality")
             print(f"      reveal {path} --outline    # Nested structure")
         elif file_type == 'markdown':
@@ -569,6 +629,7 @@ def _build_help_epilog() -> str:
 Examples:
   # Basic structure exploration
   reveal src/                    # Directory tree
+  reveal . --no-ignore           # Include .gitignore'd files, node_modules/, .venv/, ...
   reveal app.py                  # Show structure with metrics
   reveal app.py --meta           # File metadata
 
@@ -587,14 +648,58 @@ Examples:
   # Hierarchical outline (see structure as a tree!)
   reveal app.py --outline        # Classes with methods, nested structures
   reveal app.py --outline --check    # Outline with quality checks
+  reveal app.py --blame          # Last author and date of each symbol (git blame)
+  reveal app.py --calls          # Which functions call which (--format dot for Graphviz)
+  reveal ./... --call-graph Server.Start   # Callers and callees across the project
+  reveal src/ --api              # Public API: declarations and docs, no bodies
+  reveal src/ --metrics          # Complexity and nesting per function, hotspots flagged
+  reveal app.py --budget 500     # As much detail as fits about 500 tokens
+  reveal hotspots src/ --top 20  # Worst functions by length + complexity + nesting, ranked
+  reveal routes .                # HTTP routes: METHOD, path and handler function
+  reveal cli bin/                # Subcommands, flags and defaults of CLI programs
+  reveal env . --required        # Environment variables read without a default
+  reveal strings src/ --all      # User-facing string literals, translated or not
+  reveal queries --table users   # Embedded SQL queries touching a table
+  reveal deps src/               # Dependency graph with fan-in/fan-out (--format dot/mermaid/json)
+  reveal deps src/ --cycles      # Import cycles with the files involved (exit 1 if any)
+  reveal hierarchy src/          # Class inheritance trees across files (--type NAME)
+  reveal implements io.Reader .  # Go types implementing an interface (or a type's interfaces)
+  reveal unused src/             # Functions and classes nothing references (exit 1 if any)
+  reveal todos src/ --blame      # TODO/FIXME/HACK/XXX comments with symbol and author
+  reveal secrets .               # Credentials in source, values redacted (exit 1 if any)
+  reveal doc-coverage src/       # % of public API documented, undocumented symbols
+  reveal dupes src/              # Copy-pasted functions, identical or near-identical
+  reveal test-map . --untested   # Source files no test file names or imports
 
   # Element extraction
   reveal app.py load_config      # Extract specific function
   reveal app.py Database         # Extract class definition
+  reveal app.py::Database.connect    # Extract a method (with its docstring/comments)
+  reveal app.py:120-180          # Exact lines, with the enclosing function/class
+  reveal app.py@HEAD~3           # The file as of a git revision (or --rev REF)
+  reveal search 'parse_*' src/   # Find definitions by name (substring, glob or --regex)
+  reveal search --kind function 'handle.*'   # Only functions; --kind import finds importers
+  reveal refs Server.Start src/  # Every use of a symbol, with the enclosing function
+  reveal index build .           # Symbol index used by search, refs, --call-graph
+  reveal diff old.py new.py      # Symbols added/removed/renamed, signature and size changes
+  reveal diff v1/ v2/            # Per file: new/removed files, changed public symbols
+  reveal changes --since main    # Functions/classes touched by this branch (or uncommitted)
+  reveal history app.go::Serve   # Commits that changed one function, newest first
   reveal conversation.jsonl 42   # Extract record #42
 
   # Output formats
   reveal app.py --format=json    # JSON for scripting
+  reveal src/ --format=json      # Every file's symbols as one JSON document
+  reveal src/ --format=jsonl     # Same, streamed one record per line
+  reveal src/ --watch            # Re-render files as they change (jsonl: events)
+  reveal src/ --format=markdown  # Markdown report for PRs, wikis or prompts
+  reveal . --format=html -o report.html  # Browsable, searchable HTML snapshot
+  reveal src/ --format=dot | dot -Tsvg > deps.svg  # Import graph diagram
+  reveal app.py --format=mermaid # Class diagram (directories: import flowchart)
+  reveal --emit-tags tags        # ctags-compatible tags file for editors
+  reveal app.py --format=lsp     # LSP DocumentSymbol JSON for editor plugins
+  reveal src/ --format=csv -o metrics.csv   # Per-file metrics for spreadsheets
+  reveal src/ --check --format=sarif > reveal.sarif   # Code scanning upload
   reveal app.py --format=grep    # Pipeable format
 
   # Pipeline workflows (Unix composability!)
@@ -656,6 +761,14 @@ stdin: Reads file paths from stdin (one per line) - works with find, git, ls, et
 
 def _main_impl():
     """Main CLI entry point."""
+    # Subcommands (reveal search ...), unless a file or directory has that name
+    argv = sys.argv[1:]
+    if argv and not Path(argv[0]).exists():
+        from .commands import get_command_class, run_command
+        command_class = get_command_class(argv[0])
+        if command_class:
+            sys.exit(run_command(command_class, argv[1:]))
+
     parser = argparse.ArgumentParser(
         description='Reveal: Explore code semantically - The simplest way to understand code',
         formatter_class=argparse.RawDescriptionHelpFormatter,
@@ -676,17 +789,71 @@ def _main_impl():
     parser.add_argument('--stdin', action='store_true',
                         help='Read file paths from stdin (one per line) - enables Unix pipeline workflows')
     parser.add_argument('--meta', action='store_true', help='Show metadata only')
-    parser.add_argument('--format', choices=['text', 'json', 'typed', 'grep'], default='text',
-                        help='Output format (text, json, typed [typed JSON with types/relationships], grep)')
+    parser.add_argument('--blame', action='store_true',
+                        help='Annotate each symbol with the author and date of its last git change')
+    parser.add_argument('--rev', type=str, metavar='REF',
+                        help='Analyze the file as of a git revision (same as file@REF)')
+    parser.add_argument('--format', default='text',
+                        choices=['text', 'json', 'jsonl', 'markdown', 'html', 'dot', 'mermaid', 'lsp', 'csv', 'sarif',
+                                 'typed', 'grep'],
+                        help='Output format (text, json [files, symbols, lines, kinds, signatures; works on directories], '
+                             'jsonl [one record per file/symbol, streamed], markdown [report for PRs/wikis], '
+                             'html [collapsible, searchable report], dot [import graph for Graphviz], '
+                             'mermaid [class diagram for a file, import flowchart for a directory], '
+                             'lsp [LSP DocumentSymbol JSON], '
+                             'csv [per-file metrics: lines, symbols, complexity], '
+                             'sarif [--check results for code scanning], '
+                             'typed [typed JSON with types/relationships], grep)')
+    parser.add_argument('--output', '-o', type=str, metavar='FILE',
+                        help='Write the report to FILE (with --format html or csv)')
+    parser.add_argument('--emit-tags', type=str, metavar='FILE',
+                        help='Write a ctags-compatible tags file for the path (default: current directory)')
     parser.add_argument('--no-fallback', action='store_true',
                         help='Disable TreeSitter fallback for unknown file types')
-    parser.add_argument('--depth', type=int, default=3, help='Directory tree depth (default: 3)')
+    parser.add_argument('--depth', type=int, default=3, help='Directory tree depth, or XML element depth (default: 3)')
     parser.add_argument('--max-entries', type=int, default=200,
-                        help='Maximum entries to show in directory tree (default: 200, 0=unlimited)')
+                        help='Maximum directory tree entries; the most telling are kept (default: 200, 0=unlimited)')
+    parser.add_argument('--jobs', '-j', type=int, default=0, metavar='N',
+                        help='Files analyzed in parallel in directory views (default: 0 = one per CPU)')
+    parser.add_argument('--no-cache', action='store_true',
+                        help='Re-parse every file instead of reusing structures cached in ~/.cache/reveal')
+    parser.add_argument('--no-ignore', action='store_true',
+                        help='Walk everything, ignoring .gitignore/.ignore and the built-in skips (node_modules, ...)')
+    parser.add_argument('--include-generated', action='store_true',
+                        help='Analyze binary, minified and generated files too instead of skipping them in walks')
+    parser.add_argument('--max-file-size', type=parse_size, default=DEFAULT_MAX_FILE_SIZE, metavar='SIZE',
+                        help='Summarize instead of parsing files larger than SIZE (default: 5MB, 0 = no limit)')
+    parser.add_argument('--no-index', action='store_true',
+                        help="--call-graph: analyze every file even where the project index (reveal index build) "
+                             "is current")
+    parser.add_argument('--watch', action='store_true',
+                        help='Keep running: re-analyze and re-render files as they change (--format text or jsonl)')
+    parser.add_argument('--interval', type=float, default=1.0, metavar='SECONDS',
+                        help='--watch: seconds between checks for changes when polling (default: 1)')
+    parser.add_argument('--poll', action='store_true',
+                        help='--watch: poll for changes instead of using native notifications (network mounts)')
+    parser.add_argument('--budget', type=int, metavar='TOKENS',
+                        help='Reduce detail until the output fits about TOKENS tokens (file structure, directory tree)')
+    parser.add_argument('--quiet', '-q', action='store_true',
+                        help='No progress line on stderr while a large directory tree is analyzed')
     parser.add_argument('--fast', action='store_true',
                         help='Fast mode: skip line counting for better performance')
+    parser.add_argument('--redact', action='store_true',
+                        help='Hide all values in config files (TOML/INI); secrets are always hidden')
     parser.add_argument('--outline', action='store_true',
                         help='Show hierarchical outline (classes with methods, nested structures)')
+    parser.add_argument('--calls', action='store_true',
+                        help='Show which functions in the file call which (tree; --format dot/json)')
+    parser.add_argument('--call-graph', type=str, metavar='SYMBOL',
+                        help='Show callers and callees of SYMBOL across the project (e.g. Server.Start)')
+    parser.add_argument('--api', action='store_true',
+                        help='Public API only: exported declarations with signatures and docs, no bodies')
+    parser.add_argument('--metrics', action='store_true',
+                        help='Cyclomatic complexity and nesting depth per function, hotspots flagged')
+    parser.add_argument('--max-complexity', type=int, default=10, metavar='N',
+                        help='--metrics: flag functions with complexity above N (default: 10)')
+    parser.add_argument('--max-nesting', type=int, default=4, metavar='N',
+                        help='--metrics: flag functions nesting blocks deeper than N (default: 4)')
 
     # Pattern Detection (v0.13.0+) - Industry-aligned linting
     parser.add_argument('--check', '--lint', action='store_true',
@@ -748,6 +915,10 @@ def _main_impl():
             print("Expected format: START-END (e.g., 10-20, 1-indexed)", file=sys.stderr)
             sys.exit(1)
 
+    if args.output and args.format not in ('html', 'csv'):
+        print("Error: --output is only supported with --format html or csv", file=sys.stderr)
+        sys.exit(1)
+
     # Check for updates (once per day, non-blocking, opt-out available)
     check_for_updates()
 
@@ -845,6 +1016,7 @@ def _main_impl():
             sys.exit(1)
 
         # Read file paths from stdin (one per line)
+        sarif_paths = []
         for line in sys.stdin:
             file_path = line.strip()
             if not file_path:
@@ -862,12 +1034,20 @@ def _main_impl():
                 print(f"Warning: {file_path} is a directory, skipping (use reveal {file_path}/ directly)", file=sys.stderr)
                 continue
 
-            # Process the file
-            if path.is_file():
+            # Process the file (SARIF collects every file into one log)
+            if path.is_file() and args.format == 'sarif':
+                sarif_paths.append(path)
+            elif path.is_file():
                 handle_file(str(path), None, args.meta, args.format, args)
 
+        if args.format == 'sarif':
+            render_sarif(sarif_paths, args)
         sys.exit(0)
 
+    # --emit-tags defaults to the current directory
+    if args.emit_tags and not args.path:
+        args.path = '.'
+
     # Path is required if not using --list-supported or --stdin
     if not args.path:
         parser.print_help()
@@ -878,20 +1058,126 @@ def _main_impl():
         handle_uri(args.path, args.element, args)
         sys.exit(0)
 
+    # file@REV target (reveal server.go@HEAD~3, server.go@v1.2::Start, server.go@main:10-20)
+    revision = _REVISION_TARGET.match(args.path)
+    if revision and not args.rev and not Path(args.path).exists():
+        args.path = revision.group('path') + (revision.group('rest') or '')
+        args.rev = revision.group('rev')
+
+    # file::Symbol target (reveal server.go::Server.Start)
+    if not Path(args.path).exists() and '::' in args.path:
+        file_part, symbol = split_symbol_target(args.path)
+        if not symbol and args.rev:
+            # The file may not exist in the working tree
+            file_part, _, symbol = args.path.partition('::')
+        if symbol:
+            args.path, args.element = file_part, symbol
+            args.symbol_target = True
+
+    # file:START-END line range (reveal server.go:120-180)
+    line_range = _LINE_RANGE_TARGET.match(args.path)
+    if line_range and not Path(args.path).exists() and (args.rev or Path(line_range.group('path')).is_file()):
+        start = int(line_range.group('start'))
+        end = int(line_range.group('end') or start)
+        if end < start:
+            print(f"Error: Invalid line range {start}-{end} (end before start)", file=sys.stderr)
+            sys.exit(1)
+        args.path = line_range.group('path')
+        args.line_range = (start, end)
+
+    # Go-style ./... means the directory itself
+    if args.path.endswith('...') and not Path(args.path).exists():
+        args.path = args.path[:-3].rstrip('/\\') or '.'
+
     # Regular file/directory path
     path = Path(args.path)
-    if not path.exists():
+    if args.rev:
+        if path.is_dir():
+            print(f"Error: --rev reads single files; {args.path} is a directory", file=sys.stderr)
+            sys.exit(1)
+        try:
+            use_source(args.path, file_at(path, args.rev), args.rev)
+        except GitError as e:
+            print(f"Error: {e}", file=sys.stderr)
+            sys.exit(1)
+    elif not path.exists():
         print(f"Error: {args.path} not found", file=sys.stderr)
         sys.exit(1)
 
     # Route based on path type
-    if path.is_dir():
+    if getattr(args, 'line_range', None):
+        # file:START-END → exact lines with their enclosing symbols
+        handle_line_range(path, args)
+
+    elif args.watch:
+        # File or directory → render, then re-render what changes
+        render_watch(path, args)
+
+    elif args.emit_tags:
+        # File or directory → tags file
+        emit_tags(path, args)
+
+    elif args.calls:
+        # File → call graph of its functions
+        render_calls(path, args)
+
+    elif args.call_graph:
+        # Directory → callers/callees of one symbol across files
+        render_call_graph(path, args)
+
+    elif args.api:
+        # File or directory → public declarations and docs
+        render_api(path, args)
+
+    elif args.metrics:
+        # File or directory → per-function complexity and nesting
+        render_metrics(path, args)
+
+    elif args.format == 'html':
+        # File or directory → HTML report
+        render_html(path, args)
+
+    elif args.format == 'sarif':
+        # File or directory → --check results as SARIF
+        render_sarif([path], args)
+
+    elif args.format == 'csv':
+        # File or directory → metrics table
+        render_csv(path, args)
+
+    elif args.format == 'dot':
+        # File or directory → import graph
+        render_dot(path, args)
+
+    elif args.format == 'mermaid':
+        # File → class diagram, directory → import flowchart
+        render_mermaid(path, args)
+
+    elif args.format == 'lsp':
+        # File or directory → LSP DocumentSymbol JSON
+        render_lsp_symbols(path, args)
+
+    elif path.is_dir() and args.format in ('json', 'typed'):
+        # Directory → every file's structure as JSON
+        render_directory_json(path, args)
+
+    elif path.is_dir() and args.format == 'jsonl':
+        # Directory → stream records per file as analysis completes
+        render_directory_jsonl(path, args)
+
+    elif path.is_dir() and args.format == 'markdown':
+        # Directory → Markdown report
+        render_directory_markdown(path, args)
+
+    elif path.is_dir() and args.budget:
+        # Directory → tree reduced to fit the token budget
+        print(render_tree_within_budget(path, args))
+
+    elif path.is_dir():
         # Directory → show tree
-        output = show_directory_tree(str(path), depth=args.depth,
-                                     max_entries=args.max_entries, fast=args.fast)
-        print(output)
+        print_directory_tree(path, args)
 
-    elif path.is_file():
+    elif path.is_file() or args.rev:
         # File → show structure or extract element
         handle_file(str(path), args.element, args.meta, args.format, args)
 
@@ -976,6 +1262,44 @@ def list_supported_types():
     print(f"Help: reveal --help")
 
 
+def _check_detections(analyzer: FileAnalyzer, path: str, args) -> list:
+    """Run the applicable rules (narrowed by --select/--ignore) on an analyzed file."""
+    from .rules import RuleRegistry
+
+    select = args.select.split(',') if args.select else None
+    ignore = args.ignore.split(',') if args.ignore else None
+    return RuleRegistry.check_file(path, analyzer.get_structure(), analyzer.content, select=select, ignore=ignore)
+
+
+def render_sarif(paths: List[Path], args) -> None:
+    """Run --check over files and directories and print one SARIF log.
+
+    Files that cannot be analyzed are reported as tool execution
+    notifications rather than results.
+    """
+    import json
+    from .sarif import sarif_log
+
+    detections = []
+    errors = []
+    for path in paths:
+        if path.is_dir():
+            for file_path, analyzer, structure in _analyze_directory(path, args, max_entries=0):
+                if analyzer is not None:
+                    detections.extend(_check_detections(analyzer, str(file_path), args))
+                elif isinstance(structure, Exception):
+                    errors.append((str(file_path), str(structure)))
+            continue
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            continue
+        try:
+            detections.extend(_check_detections(analyzer_class(str(path)), str(path), args))
+        except Exception as e:
+            errors.append((str(path), str(e)))
+    print(json.dumps(sarif_log(detections, errors), indent=2))
+
+
 def run_pattern_detection(analyzer: FileAnalyzer, path: str, output_format: str, args):
     """Run pattern detection rules on a file.
 
@@ -985,21 +1309,15 @@ def run_pattern_detection(analyzer: FileAnalyzer, path: str, output_format: str,
         output_format: Output format ('text', 'json', 'grep')
         args: CLI arguments (for --select, --ignore)
     """
-    from .rules import RuleRegistry
-
-    # Parse select/ignore options
-    select = args.select.split(',') if args.select else None
-    ignore = args.ignore.split(',') if args.ignore else None
-
-    # Get structure and content
-    structure = analyzer.get_structure()
-    content = analyzer.content
-
-    # Run rules
-    detections = RuleRegistry.check_file(path, structure, content, select=select, ignore=ignore)
+    detections = _check_detections(analyzer, path, args)
 
     # Output results
-    if output_format == 'json':
+    if output_format == 'sarif':
+        import json
+        from .sarif import sarif_log
+        print(json.dumps(sarif_log(detections), indent=2))
+
+    elif output_format == 'json':
         import json
         result = {
             'file': path,
@@ -1008,6 +1326,11 @@ def run_pattern_detection(analyzer: FileAnalyzer, path: str, output_format: str,
         }
         print(json.dumps(result, indent=2))
 
+    elif output_format == 'jsonl':
+        import json
+        for d in detections:
+            print(json.dumps(d.to_dict()), flush=True)
+
     elif output_format == 'grep':
         # Grep format: file:line:column:code:message
         for d in detections:
@@ -1046,6 +1369,12 @@ def handle_file(path: str, element: Optional[str], show_meta: bool, output_forma
         print(f"Visit https://github.com/scottsen/reveal to request new file types", file=sys.stderr)
         sys.exit(1)
 
+    # Too large to parse: summarize from its first and last lines
+    max_size = getattr(args, 'max_file_size', DEFAULT_MAX_FILE_SIZE) if args else DEFAULT_MAX_FILE_SIZE
+    if not show_meta and oversized(Path(path), max_size):
+        show_large_file(Path(path), analyzer_class.type_name, max_size, output_format)
+        return
+
     analyzer = analyzer_class(path)
 
     # Show metadata only?
@@ -1060,13 +1389,111 @@ def handle_file(path: str, element: Optional[str], show_meta: bool, output_forma
 
     # Extract specific element?
     if element:
-        extract_element(analyzer, element, output_format)
+        extract_element(analyzer, element, output_format,
+                        include_docs=getattr(args, 'symbol_target', False))
+        return
+
+    # Token budget: the most detail that fits
+    if args and getattr(args, 'budget', None):
+        show_structure_within_budget(analyzer, output_format, args)
         return
 
     # Default: show structure
     show_structure(analyzer, output_format, args)
 
 
+def _captured(function: Callable[..., None], *args, **kwargs) -> str:
+    """What function prints to stdout, without its final newline."""
+    import contextlib
+    import io
+
+    output = io.StringIO()
+    with contextlib.redirect_stdout(output):
+        function(*args, **kwargs)
+    return output.getvalue().rstrip('\n')
+
+
+def show_structure_within_budget(analyzer: FileAnalyzer, output_format: str, args) -> None:
+    """--budget: show the file's structure with as little reduced (see budget.py) as fits the budget.
+
+    Text output ends with a note of what was left out.
+    """
+    from .budget import STRUCTURE_LEVELS, fit_to_budget, reduce_structure, structure_reductions
+
+    structure = analyzer.get_structure(**_build_analyzer_kwargs(analyzer, args))
+    text = output_format in ('text', 'grep')
+
+    def render(level: int) -> str:
+        reduced = reduce_structure(structure, str(analyzer.path), analyzer.lines, level)
+        output = _captured(show_structure, analyzer, output_format, args, structure=reduced)
+        if level and output_format == 'text':
+            output += f"\n\n(--budget {args.budget}: {structure_reductions(level)})"
+        return output
+
+    print(fit_to_budget(render, len(STRUCTURE_LEVELS), args.budget, cut=text))
+
+
+def print_directory_tree(path: Path, args) -> None:
+    """Print the directory tree as its directories are analyzed, with a progress line on stderr unless --quiet."""
+    from .progress import Progress
+
+    progress = Progress(enabled=not args.quiet)
+    for line in iter_directory_tree(str(path), depth=args.depth, max_entries=args.max_entries, fast=args.fast,
+                                    jobs=args.jobs, ignore=not args.no_ignore,
+                                    skip_generated=not args.include_generated, max_file_size=args.max_file_size,
+                                    progress=progress.update):
+        progress.clear()
+        print(line, flush=True)
+    progress.clear()
+
+
+def render_tree_within_budget(path: Path, args) -> str:
+    """--budget: the directory tree at the greatest depth and detail that fits the budget."""
+    from .budget import fit_to_budget, tree_levels
+
+    levels = tree_levels(args.depth, args.max_entries, args.fast)
+
+    def render(level: int) -> str:
+        options = levels[level]
+        output = show_directory_tree(str(path), jobs=args.jobs, ignore=not args.no_ignore,
+                                     skip_generated=not args.include_generated, max_file_size=args.max_file_size,
+                                     **options)
+        if level:
+            reduced = [f"depth {options['depth']}"]
+            if options['fast'] and not args.fast:
+                reduced.append('no line counts')
+            if options['max_entries'] != args.max_entries:
+                reduced.append(f"at most {options['max_entries']} entries")
+            output += f"\n\n(--budget {args.budget}: {', '.join(reduced)})"
+        return output
+
+    return fit_to_budget(render, len(levels), args.budget)
+
+
+def show_large_file(path: Path, language: str, max_size: int, output_format: str) -> None:
+    """Summary of a file over --max-file-size: size, language and its first and last lines."""
+    from .tree_view import _format_size
+
+    summary = large_file_summary(path, language)
+    summary['max_file_size'] = max_size
+    if output_format in ('json', 'jsonl'):
+        import json
+        print(json.dumps(summary, indent=2 if output_format == 'json' else None))
+        return
+
+    print(f"File: {path.name} ({_format_size(summary['size'])}, {language}) - not parsed: over --max-file-size "
+          f"{_format_size(max_size)}\n")
+    if summary['binary']:
+        print("  (binary content)")
+    for title, lines in (('First lines', summary['first_lines']), ('Last lines', summary['last_lines'])):
+        if lines:
+            print(f"{title}:")
+            for line in lines:
+                print(f"  {line[:200]}")
+            print()
+    print("Use --max-file-size 0 (or a larger SIZE) to parse it anyway, or --meta for line counts")
+
+
 def show_metadata(analyzer: FileAnalyzer, output_format: str):
     """Show file metadata."""
     meta = analyzer.get_metadata()
@@ -1074,11 +1501,16 @@ def show_metadata(analyzer: FileAnalyzer, output_format: str):
     if output_format == 'json':
         import json
         print(json.dumps(meta, indent=2))
+    elif output_format == 'jsonl':
+        import json
+        print(json.dumps(meta))
     else:
         print(f"File: {meta['name']}\n")
         print(f"Path:     {meta['path']}")
+        if meta.get('revision'):
+            print(f"Revision: {meta['revision']}")
         print(f"Size:     {meta['size_human']}")
-        print(f"Lines:    {meta['lines']}")
+        print(f"Lines:    {meta['lines']} ({meta['code']} code, {meta['comment']} comment, {meta['blank']} blank)")
         print(f"Encoding: {meta['encoding']}")
         print_breadcrumbs('metadata', meta['path'])
 
@@ -1135,6 +1567,25 @@ def build_hierarchy(structure: Dict[str, List[Dict[str, Any]]]) -> List[Dict[str
     return [item for item in all_items if not item.get('is_child', False)]
 
 
+def _format_metrics(item: Dict[str, Any]) -> str:
+    """Format the bracketed metrics suffix for an item.
+
+    Example: ' [public, 12 lines, depth:2]'
+    """
+    parts = []
+    if item.get('visibility'):
+        parts.append(item['visibility'])
+    if 'line_count' in item:
+        parts.append(f"{item['line_count']} lines")
+    if 'depth' in item:
+        parts.append(f"depth:{item['depth']}")
+    if item.get('parts'):
+        parts.append(f"partial, also in {', '.join(item['parts'])}")
+    if item.get('blame'):
+        parts.append(f"{item['blame']['author']} {item['blame']['date']}")
+    return f" [{', '.join(parts)}]" if parts else ''
+
+
 def render_outline(items: List[Dict[str, Any]], path: Path, indent: str = '', is_root: bool = True) -> None:
     """Render hierarchical outline with tree characters.
 
@@ -1156,15 +1607,7 @@ def render_outline(items: List[Dict[str, Any]], path: Path, indent: str = '', is
         signature = item.get('signature', '')
 
         # Build metrics display
-        metrics = ''
-        if 'line_count' in item or 'depth' in item:
-            parts = []
-            if 'line_count' in item:
-                parts.append(f"{item['line_count']} lines")
-            if 'depth' in item:
-                parts.append(f"depth:{item['depth']}")
-            if parts:
-                metrics = f" [{', '.join(parts)}]"
+        metrics = _format_metrics(item)
 
         # Format output
         if signature and name:
@@ -1276,6 +1719,16 @@ def _format_code_blocks(items: List[Dict[str, Any]], path: Path, output_format:
             print(f"    ... and {len(inline_items) - 10} more")
 
 
+def _format_headings(items: List[Dict[str, Any]], path: Path) -> None:
+    """Format leveled items (headings, XML elements, config sections) indented to show the hierarchy."""
+    top_level = min(item.get('level', 1) for item in items)
+
+    for item in items:
+        line = item.get('line', '?')
+        indent = '  ' * (item.get('level', top_level) - top_level)
+        print(f"  {path}:{line:<6} {indent}{item.get('name', '')}{item.get('signature', '')}")
+
+
 def _format_standard_items(items: List[Dict[str, Any]], path: Path, output_format: str) -> None:
     """Format and display standard items (functions, classes, etc.)."""
     for item in items:
@@ -1285,15 +1738,7 @@ def _format_standard_items(items: List[Dict[str, Any]], path: Path, output_forma
         content = item.get('content', '')
 
         # Build metrics display (if available)
-        metrics = ''
-        if 'line_count' in item or 'depth' in item:
-            parts = []
-            if 'line_count' in item:
-                parts.append(f"{item['line_count']} lines")
-            if 'depth' in item:
-                parts.append(f"depth:{item['depth']}")
-            if parts:
-                metrics = f" [{', '.join(parts)}]"
+        metrics = _format_metrics(item)
 
         # Format based on what's available
         if signature and name:
@@ -1345,21 +1790,33 @@ def _build_analyzer_kwargs(analyzer: FileAnalyzer, args) -> Dict[str, Any]:
             if args.inline:
                 kwargs['inline_code'] = args.inline
 
+    # XML element tree depth
+    if args and hasattr(analyzer, '_element_tree'):
+        kwargs['depth'] = args.depth
+
+    # Config file values (TOML/INI)
+    if args and hasattr(analyzer, '_display_value'):
+        kwargs['redact'] = args.redact
+
     return kwargs
 
 
-def _print_file_header(path: Path, is_fallback: bool = False, fallback_lang: str = None) -> None:
-    """Print file header with optional fallback indicator."""
+def _print_file_header(path: Path, is_fallback: bool = False, fallback_lang: str = None,
+                       revision: str = None) -> None:
+    """Print file header with optional fallback indicator and git revision."""
+    name = f"{path.name} @ {revision}" if revision else path.name
     if is_fallback and fallback_lang:
-        print(f"File: {path.name} (fallback: {fallback_lang})\n")
+        print(f"File: {name} (fallback: {fallback_lang})\n")
     else:
-        print(f"File: {path.name}\n")
+        print(f"File: {name}\n")
 
 
-def _render_json_output(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]) -> None:
-    """Render structure as JSON output (standard format)."""
-    import json
+def _json_result(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]) -> Dict[str, Any]:
+    """Structure of one file as a JSON-ready dict.
 
+    Every symbol carries its file and a kind (the analyzer's own kind, e.g.
+    'proc' or 'enum', else the singular of its category).
+    """
     is_fallback = getattr(analyzer, 'is_fallback', False)
     fallback_lang = getattr(analyzer, 'fallback_language', None)
     file_path = str(analyzer.path)
@@ -1369,8 +1826,9 @@ def _render_json_output(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[s
     for category, items in structure.items():
         enriched_items = []
         for item in items:
-            # Copy item and add file field
+            # Copy item and add file/kind fields
             enriched_item = item.copy()
+            enriched_item.setdefault('kind', category_kind(category))
             enriched_item['file'] = file_path
             enriched_items.append(enriched_item)
         enriched_structure[category] = enriched_items
@@ -1386,6 +1844,703 @@ def _render_json_output(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[s
         },
         'structure': enriched_structure
     }
+    if analyzer.revision:
+        result['revision'] = analyzer.revision
+    return result
+
+
+def _render_json_output(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]) -> None:
+    """Render structure as JSON output (standard format)."""
+    import json
+
+    print(json.dumps(_json_result(analyzer, structure), indent=2, default=str))
+
+
+def _analyze_directory(path: Path, args, depth: Optional[int] = None, max_entries: Optional[int] = None,
+                       select: Optional[Callable[[Path], bool]] = None):
+    """Yield (file path, analyzer, structure) for files under a directory, in tree order.
+
+    Files without an analyzer yield a None analyzer; analysis errors yield the
+    exception in place of the structure. Stops after --max-entries files and
+    yields the number skipped as a final (None, None, count). depth and
+    max_entries override --depth and --max-entries; select, when given,
+    picks the files to analyze. Files over --max-file-size, and binary,
+    minified and generated ones unless --include-generated, are yielded
+    unanalyzed. Files are analyzed on
+    --jobs workers; the order stays that of the tree. Structures of
+    unchanged files come from the cache unless --no-cache.
+    """
+    depth = args.depth if depth is None else depth
+    max_entries = args.max_entries if max_entries is None else max_entries
+    files = []
+    truncated = 0
+    for file_path in iter_directory_files(path, depth=depth, ignore=not args.no_ignore):
+        if select is not None and not select(file_path):
+            continue
+        if max_entries > 0 and len(files) >= max_entries:
+            truncated += 1
+        else:
+            files.append(file_path)
+
+    def analyze(file_path: Path):
+        analyzer_class = get_analyzer(str(file_path), allow_fallback=not args.no_fallback)
+        if (not analyzer_class or oversized(file_path, args.max_file_size)
+                or not args.include_generated and skip_reason(file_path)):
+            return file_path, None, None
+        try:
+            analyzer = analyzer_class(str(file_path))
+            return file_path, analyzer, cached_structure(analyzer, _build_analyzer_kwargs(analyzer, args),
+                                                         enabled=not args.no_cache)
+        except Exception as e:
+            return file_path, None, e
+
+    yield from ordered_map(analyze, files, args.jobs)
+
+    if truncated:
+        yield None, None, truncated
+
+
+def _unanalyzed_file(file_path: Path, error: Optional[Exception]) -> Dict[str, Any]:
+    """JSON record for a file without structure: its size, or the analysis error."""
+    if error is not None:
+        return {'file': str(file_path), 'type': None, 'error': str(error)}
+    return {'file': str(file_path), 'type': None, 'size': file_path.stat().st_size}
+
+
+def render_directory_json(path: Path, args) -> None:
+    """Render every file under a directory (down to --depth) with its structure as JSON.
+
+    Files without an analyzer are listed with their size only. --max-entries
+    caps the number of files; the rest are counted in 'truncated'. Analyzed
+    files carry code/comment/blank line counts, summed overall and per
+    language in 'summary'.
+    """
+    import json
+
+    from .metrics import line_counts
+
+    files = []
+    languages: Dict[str, Dict[str, int]] = {}
+    truncated = 0
+    for file_path, analyzer, structure in _analyze_directory(path, args):
+        if file_path is None:
+            truncated = structure
+        elif analyzer is None:
+            files.append(_unanalyzed_file(file_path, structure))
+        else:
+            result = _json_result(analyzer, structure)
+            counts = line_counts(analyzer.lines, file_path.name)
+            result.update({'lines': len(analyzer.lines), **counts})
+            files.append(result)
+            totals = languages.setdefault(analyzer.type_name, {'files': 0, 'code': 0, 'comment': 0, 'blank': 0})
+            totals['files'] += 1
+            for key, count in counts.items():
+                totals[key] += count
+
+    result = {
+        'directory': str(path),
+        'files': files,
+        'summary': {
+            'files': len(files),
+            'symbols': sum(len(items) for f in files for items in f.get('structure', {}).values()),
+            **{key: sum(totals[key] for totals in languages.values()) for key in ('code', 'comment', 'blank')},
+            'languages': languages,
+        },
+    }
+    if truncated:
+        result['truncated'] = truncated
+    print(json.dumps(result, indent=2, default=str))
+
+
+def _jsonl_records(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]):
+    """One 'file' record, then one 'symbol' record per structure item."""
+    from .metrics import line_counts
+
+    result = _json_result(analyzer, structure)
+    yield {
+        'record': 'file',
+        'file': result['file'],
+        'type': result['type'],
+        'analyzer': result['analyzer']['name'],
+        'lines': len(analyzer.lines),
+        **line_counts(analyzer.lines, analyzer.path.name),
+        'symbols': sum(len(items) for items in structure.values()),
+    }
+    for category, items in result['structure'].items():
+        for item in items:
+            yield {'record': 'symbol', 'category': category, **item}
+
+
+def _render_jsonl_output(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]) -> None:
+    """Render structure as JSON Lines, flushing each record."""
+    import json
+
+    for record in _jsonl_records(analyzer, structure):
+        print(json.dumps(record, default=str), flush=True)
+
+
+def render_directory_jsonl(path: Path, args) -> None:
+    """Stream JSON Lines for a directory: each file's records as soon as it is analyzed.
+
+    Ends with a 'summary' record (files, symbols, and truncated when
+    --max-entries cut the walk short).
+    """
+    import json
+
+    summary = {'record': 'summary', 'directory': str(path), 'files': 0, 'symbols': 0}
+    for file_path, analyzer, structure in _analyze_directory(path, args):
+        if file_path is None:
+            summary['truncated'] = structure
+            continue
+        summary['files'] += 1
+        if analyzer is None:
+            print(json.dumps({'record': 'file', **_unanalyzed_file(file_path, structure)}), flush=True)
+            continue
+        summary['symbols'] += sum(len(items) for items in structure.values())
+        _render_jsonl_output(analyzer, structure)
+    print(json.dumps(summary), flush=True)
+
+
+def render_watch(path: Path, args) -> None:
+    """--watch: render the file or directory, then re-analyze and re-render only the files that change.
+
+    Text prints a timestamped line per change followed by the file's
+    structure (its --check results with --check); jsonl emits an 'event'
+    record per change followed by the file's records. Changed files are
+    skipped like in the first render: over --max-file-size, or in a
+    directory binary, minified or generated unless --include-generated.
+    Changes are noticed through native notifications, or by polling every
+    --interval seconds (--poll), until interrupted.
+    """
+    import json
+    import time
+
+    from .watch import watch
+
+    if args.rev:
+        print("Error: --watch follows the working tree; it can't be combined with --rev", file=sys.stderr)
+        sys.exit(1)
+    if args.format not in ('text', 'jsonl'):
+        print(f"Error: --watch supports --format text or jsonl, not {args.format}", file=sys.stderr)
+        sys.exit(1)
+
+    if path.is_dir() and args.format == 'jsonl':
+        render_directory_jsonl(path, args)
+    elif path.is_dir():
+        print_directory_tree(path, args)
+    else:
+        handle_file(str(path), None, False, args.format, args)
+        sys.stdout.flush()
+
+    # As in the first render: a file named on the command line is analyzed whatever it is, but its size
+    skip_generated = path.is_dir() and not args.include_generated
+
+    def skipped(file_name: str) -> Optional[str]:
+        if oversized(Path(file_name), args.max_file_size):
+            return 'too large'
+        return skip_reason(Path(file_name)) if skip_generated else None
+
+    def on_change(events):
+        for event, file_name in events:
+            analyzer = None
+            reason = skipped(file_name) if event != 'removed' else None
+            if event != 'removed' and reason is None:
+                analyzer_class = get_analyzer(file_name, allow_fallback=not args.no_fallback)
+                try:
+                    analyzer = analyzer_class(file_name) if analyzer_class else None
+                except Exception as e:
+                    print(f"Warning: Failed to analyze {file_name}: {e}", file=sys.stderr)
+            if args.format == 'jsonl':
+                print(json.dumps({'record': 'event', 'event': event, 'file': file_name,
+                                  'time': time.strftime('%Y-%m-%dT%H:%M:%S')}), flush=True)
+                if analyzer is not None:
+                    _render_jsonl_output(analyzer, cached_structure(analyzer, _build_analyzer_kwargs(analyzer, args),
+                                                                    enabled=not args.no_cache))
+                elif reason is not None:
+                    print(json.dumps({'record': 'file', **_unanalyzed_file(Path(file_name), None)}), flush=True)
+                continue
+            print(f"\n[{time.strftime('%H:%M:%S')}] {event}: {file_name}" + (f" ({reason})" if reason else ''))
+            if analyzer is not None and args.check:
+                run_pattern_detection(analyzer, file_name, 'text', args)
+            elif analyzer is not None:
+                show_structure(analyzer, 'text', args)
+            sys.stdout.flush()
+
+    try:
+        watch(path, on_change, depth=args.depth, interval=args.interval, ignore=not args.no_ignore,
+              native=not args.poll)
+    except KeyboardInterrupt:
+        pass
+
+
+def _markdown_item(item: Dict[str, Any]) -> str:
+    """One item as a plain line: name and signature, an import's content, a link or a code block."""
+    if item.get('name'):
+        return f"{item['name']}{item.get('signature', '')}"
+    if item.get('content'):
+        return str(item['content'])
+    if item.get('url'):
+        return f"[{item.get('text', '')}]({item['url']})"
+    if item.get('language'):
+        return f"{item['language']} block ({item.get('line_count', 0)} lines)"
+    return ''
+
+
+def _markdown_file(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]],
+                   title: str, level: int = 1) -> List[str]:
+    """Markdown for one file: a heading, then a subheading and fenced listing per category.
+
+    Each listing line is 'LINE[-END]  name(signature)', indented by level for
+    headings/sections.
+    """
+    heading = '#' * min(level, 6)
+    subheading = '#' * min(level + 1, 6)
+    lines = [f"{heading} {title}", '']
+
+    if not structure:
+        lines += ['_No structure available for this file type._', '']
+        return lines
+
+    for category, items in structure.items():
+        if not items:
+            continue
+        lines += [f"{subheading} {category.replace('_', ' ').capitalize()} ({len(items)})", '', '```']
+        top_level = min(item.get('level', 1) for item in items)
+        for item in items:
+            start = item.get('line', item.get('line_start', '?'))
+            end = item.get('line_end')
+            location = f"{start}-{end}" if end and end != start else str(start)
+            indent = '  ' * (item.get('level', top_level) - top_level)
+            # The range already gives the length
+            metrics = _format_metrics({k: v for k, v in item.items() if k != 'line_count'})
+            lines.append(f"{location:<10} {indent}{_markdown_item(item)}{metrics}".rstrip())
+        lines += ['```', '']
+    return lines
+
+
+def _render_markdown_output(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]) -> None:
+    """Render structure as a Markdown document."""
+    print('\n'.join(_markdown_file(analyzer, structure, f"`{analyzer.path}`")))
+
+
+def render_directory_markdown(path: Path, args) -> None:
+    """Render a directory as Markdown: a heading per directory, then each file's structure below it.
+
+    Files without an analyzer are listed by name and size under their directory.
+    """
+    from .tree_view import _format_size
+
+    print(f"# `{path.name or path}/`\n", flush=True)
+    current_dir = None
+    for file_path, analyzer, structure in _analyze_directory(path, args):
+        if file_path is None:
+            print(f"_... {structure} more files (use --max-entries 0 to show all)_")
+            continue
+
+        directory = file_path.parent.relative_to(path).as_posix()
+        if directory != current_dir:
+            current_dir = directory
+            print(f"## `{path.name or path}/`\n" if directory == '.' else f"## `{directory}/`\n", flush=True)
+
+        if analyzer is None:
+            detail = f"error: {structure}" if structure is not None else _format_size(file_path.stat().st_size)
+            print(f"- `{file_path.name}` ({detail})\n", flush=True)
+            continue
+        title = f"`{file_path.name}` ({len(analyzer.lines)} lines, {getattr(analyzer, 'type_name', 'unknown')})"
+        print('\n'.join(_markdown_file(analyzer, structure, title, level=3)), flush=True)
+
+
+def render_html(path: Path, args) -> None:
+    """Write a self-contained HTML report of a file or directory to --output (or stdout)."""
+    from .html_report import render_html_report
+
+    files = []
+    if path.is_dir():
+        for file_path, analyzer, structure in _analyze_directory(path, args):
+            if file_path is None:
+                continue
+            if analyzer is None:
+                record = _unanalyzed_file(file_path, structure)
+            else:
+                record = _json_result(analyzer, structure)
+                record['lines'] = len(analyzer.lines)
+            record['file'] = file_path.relative_to(path).as_posix()
+            files.append(record)
+        title = f"{path.resolve().name}/"
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        record = _json_result(analyzer, analyzer.get_structure(**_build_analyzer_kwargs(analyzer, args)))
+        record.update({'file': path.name, 'lines': len(analyzer.lines)})
+        files.append(record)
+        title = path.name
+
+    report = render_html_report(title, files)
+    if args.output:
+        with open(args.output, 'w', encoding='utf-8') as f:
+            f.write(report)
+        print(f"Wrote {args.output} ({len(files)} files)", file=sys.stderr)
+    else:
+        sys.stdout.write(report)
+
+
+CSV_COLUMNS = ('path', 'language', 'lines', 'symbols', 'functions', 'complexity', 'max_complexity')
+
+
+def render_csv(path: Path, args) -> None:
+    """Write per-file metrics as CSV to --output (or stdout), one row per analyzed file.
+
+    complexity is the summed cyclomatic complexity of the file's functions
+    and methods; max_complexity the highest single score.
+    """
+    import csv
+    from .metrics import file_metrics
+
+    if path.is_dir():
+        files = [(file_path.relative_to(path).as_posix(), analyzer, structure)
+                 for file_path, analyzer, structure in _analyze_directory(path, args)
+                 if analyzer is not None]
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        files = [(path.name, analyzer, analyzer.get_structure(**_build_analyzer_kwargs(analyzer, args)))]
+
+    out = open(args.output, 'w', encoding='utf-8', newline='') if args.output else sys.stdout
+    try:
+        writer = csv.DictWriter(out, fieldnames=CSV_COLUMNS)
+        writer.writeheader()
+        for name, analyzer, structure in files:
+            language = (getattr(analyzer, 'fallback_language', None) if getattr(analyzer, 'is_fallback', False)
+                        else None) or analyzer.__class__.__name__.replace('Analyzer', '').lower()
+            writer.writerow({'path': name, 'language': language, **file_metrics(analyzer.lines, structure)})
+    finally:
+        if args.output:
+            out.close()
+            print(f"Wrote {args.output} ({len(files)} files)", file=sys.stderr)
+
+
+def _import_graph(path: Path, args):
+    """Import graph of a directory (project files only) or a single file (with its external imports)."""
+    from .dependencies import build_import_graph
+
+    if path.is_dir():
+        files = [(file_path.relative_to(path).as_posix(), structure)
+                 for file_path, analyzer, structure in _analyze_directory(path, args)
+                 if analyzer is not None]
+        return build_import_graph(files), False
+
+    analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+    if not analyzer_class:
+        print(f"Error: No analyzer found for {path}", file=sys.stderr)
+        sys.exit(1)
+    analyzer = analyzer_class(str(path))
+    return build_import_graph([(path.name, analyzer.get_structure())]), True
+
+
+def render_dot(path: Path, args) -> None:
+    """Print the import graph as Graphviz DOT (pipe into `dot -Tsvg`)."""
+    from .dependencies import render_dot as dot
+
+    graph, include_external = _import_graph(path, args)
+    print(dot(graph, path.resolve().name, include_external=include_external))
+
+
+def render_mermaid(path: Path, args) -> None:
+    """Print a Mermaid diagram: a file's class diagram, or a directory's import flowchart."""
+    from .mermaid import render_class_diagram, render_flowchart
+
+    if path.is_dir():
+        graph, include_external = _import_graph(path, args)
+        print(render_flowchart(graph, include_external=include_external))
+        return
+
+    analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+    if not analyzer_class:
+        print(f"Error: No analyzer found for {path}", file=sys.stderr)
+        sys.exit(1)
+    analyzer = analyzer_class(str(path))
+    print(render_class_diagram(analyzer.get_structure(**_build_analyzer_kwargs(analyzer, args)), path.name))
+
+
+def emit_tags(path: Path, args) -> None:
+    """Write a ctags-compatible tags file for a file or a whole directory tree.
+
+    Paths in the tags file are relative to the tags file's directory, as
+    editors expect.
+    """
+    from .tags import tag_entries, write_tags
+
+    tags_dir = Path(args.emit_tags).resolve().parent
+    if path.is_dir():
+        # Tags cover the whole tree, not just the --depth shown in the tree view
+        analyzed = ((file_path, analyzer, structure) for file_path, analyzer, structure
+                    in _analyze_directory(path, args, depth=sys.getrecursionlimit(), max_entries=0)
+                    if analyzer is not None)
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        analyzed = [(path, analyzer, analyzer.get_structure())]
+
+    entries = []
+    files = 0
+    for file_path, analyzer, structure in analyzed:
+        file_name = Path(os.path.relpath(file_path.resolve(), tags_dir)).as_posix()
+        entries.extend(tag_entries(file_name, analyzer.lines, structure))
+        files += 1
+
+    with open(args.emit_tags, 'w', encoding='utf-8') as f:
+        count = write_tags(entries, f)
+    print(f"Wrote {count} tags from {files} files to {args.emit_tags}", file=sys.stderr)
+
+
+def render_calls(path: Path, args) -> None:
+    """Print the file's call graph: a tree from the functions nothing calls, DOT, or JSON."""
+    import json
+    from .calls import call_tree, file_call_graph, render_calls_dot
+
+    if not path.is_file():
+        print("Error: --calls works on a single file", file=sys.stderr)
+        sys.exit(1)
+    analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+    if not analyzer_class:
+        print(f"Error: No analyzer found for {path}", file=sys.stderr)
+        sys.exit(1)
+    analyzer = analyzer_class(str(path))
+    graph = file_call_graph(analyzer.lines, analyzer.get_structure())
+
+    if args.format == 'dot':
+        print(render_calls_dot(graph, path.name))
+    elif args.format == 'json':
+        print(json.dumps({'file': str(path), 'calls': graph}, indent=2))
+    elif not graph:
+        print(f"{path}: no functions found")
+    else:
+        lines = {name: line for name, line in
+                 ((item['name'], item['line']) for category in analyzer.get_structure().values() for item in category
+                  if item.get('name') and isinstance(item.get('line'), int))}
+        for root in call_tree(graph):
+            print(f"{root['name']} ({path}:{lines.get(root['name'], '?')})")
+            _render_call_children(root['children'], '  ')
+
+
+def _render_call_children(nodes: List[Dict[str, Any]], indent: str) -> None:
+    """Callees as tree lines in render_outline's style, with the line of the call."""
+    for i, node in enumerate(nodes):
+        is_last = i == len(nodes) - 1
+        note = ' (recursive)' if node.get('recursive') else ' ...' if node.get('seen') else ''
+        print(f"{indent}{'└─ ' if is_last else '├─ '}{node['name']}{note} (line {node['line']})")
+        _render_call_children(node['children'], indent + ('   ' if is_last else '│  '))
+
+
+def render_api(path: Path, args) -> None:
+    """Print the public API of a file or every file under a directory: declarations and docs, no bodies."""
+    import json
+    from .api import api_entries, render_api_text
+
+    if path.is_dir():
+        files = [(file_path, analyzer, structure)
+                 for file_path, analyzer, structure in _analyze_directory(path, args, depth=sys.getrecursionlimit(),
+                                                                          max_entries=0)
+                 if analyzer is not None]
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        files = [(path, analyzer, analyzer.get_structure())]
+
+    results = []
+    for file_path, analyzer, structure in files:
+        entries = api_entries(file_path.as_posix(), analyzer.lines, structure)
+        if entries or not path.is_dir():
+            results.append((file_path, entries))
+
+    if args.format == 'json':
+        payload = [{'file': str(file_path), 'symbols': entries} for file_path, entries in results]
+        print(json.dumps(payload[0] if not path.is_dir() else {'path': str(path), 'files': payload}, indent=2))
+        return
+    for index, (file_path, entries) in enumerate(results):
+        if index:
+            print()
+        if args.format == 'markdown':
+            print(f"## {file_path}\n\n```{file_path.suffix.lstrip('.')}")
+            print('\n'.join(render_api_text(entries)))
+            print('```')
+        else:
+            print(f"{file_path} ({len(entries)} public)")
+            for line in render_api_text(entries):
+                print(f"  {line}" if line else '')
+    if not results:
+        print(f"{path}: no public API found")
+
+
+def render_metrics(path: Path, args) -> None:
+    """Print cyclomatic complexity and nesting depth of every function, most complex first, hotspots flagged."""
+    import json
+    from .metrics import function_metrics
+
+    if path.is_dir():
+        files = [(file_path, analyzer, structure)
+                 for file_path, analyzer, structure in _analyze_directory(path, args, depth=sys.getrecursionlimit(),
+                                                                          max_entries=0)
+                 if analyzer is not None]
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        files = [(path, analyzer, analyzer.get_structure())]
+
+    functions = []
+    for file_path, analyzer, structure in files:
+        for function in function_metrics(analyzer.lines, structure):
+            function['file'] = str(file_path)
+            function['hotspot'] = (function['complexity'] > args.max_complexity
+                                   or function['nesting'] > args.max_nesting)
+            functions.append(function)
+    functions.sort(key=lambda f: (-f['complexity'], -f['nesting'], f['file'], f['line']))
+    hotspots = sum(1 for f in functions if f['hotspot'])
+
+    if args.format == 'json':
+        print(json.dumps({'path': str(path), 'max_complexity': args.max_complexity,
+                          'max_nesting': args.max_nesting, 'hotspots': hotspots, 'functions': functions}, indent=2))
+        return
+    if not functions:
+        print(f"{path}: no functions found")
+        return
+    location_width = max(len(f"{f['file']}:{f['line']}") for f in functions)
+    name_width = min(max(len(str(f['name'])) for f in functions), 40)
+    print(f"{'Location':<{location_width}}  {'Function':<{name_width}}  Complexity  Nesting  Lines")
+    for f in functions:
+        location = f"{f['file']}:{f['line']}"
+        flag = '  ⚠️' if f['hotspot'] else ''
+        print(f"{location:<{location_width}}  {str(f['name']):<{name_width}}  {f['complexity']:>10}  "
+              f"{f['nesting']:>7}  {f['lines']:>5}{flag}")
+    average = sum(f['complexity'] for f in functions) / len(functions)
+    print(f"\n{len(functions)} functions, average complexity {average:.1f}; {hotspots} "
+          f"{'hotspot' if hotspots == 1 else 'hotspots'} (complexity > {args.max_complexity} "
+          f"or nesting > {args.max_nesting})")
+
+
+def _call_graph_files(path: Path, args) -> List[Tuple[str, List[str], Dict[str, List[Dict[str, Any]]]]]:
+    """(relative path, lines, structure) of the files under path that --call-graph needs, in tree order.
+
+    All of them; or, under a project index (reveal index build) unless
+    --no-index, those that mention the symbol (where it is defined and
+    called) and those defining a name its definitions use (its callees),
+    plus every file changed since the index was built.
+    """
+    from .calls import project_call_graph, short_name
+    from .index import ProjectIndex, identifier_words
+
+    def analyzed(select=None):
+        return [(file_path.relative_to(path).as_posix(), analyzer.lines, structure)
+                for file_path, analyzer, structure in _analyze_directory(path, args, depth=sys.getrecursionlimit(),
+                                                                         max_entries=0, select=select)
+                if analyzer is not None]
+
+    index = None if args.no_index else ProjectIndex.load(path)
+    if index is None:
+        return analyzed()
+
+    word = short_name(args.call_graph)
+
+    def mentioning(file_path: Path) -> bool:
+        entry = index.entry(file_path)
+        return entry is None or index.mentions(entry, word)
+
+    files = analyzed(mentioning)
+    graph = project_call_graph(files)
+    names: Set[str] = set()
+    for target in graph.find(args.call_graph):
+        node = graph.nodes[target]
+        names |= identifier_words(graph.files[node['file']][0][node['line'] - 1:node['line_end']])
+    seen = {name for name, _lines, _structure in files}
+
+    def defining(file_path: Path) -> bool:
+        entry = index.entry(file_path)
+        return entry is not None and file_path.relative_to(path).as_posix() not in seen and index.defines(entry, names)
+
+    order = {file_path.relative_to(path).as_posix(): number
+             for number, file_path in enumerate(iter_directory_files(path, depth=sys.getrecursionlimit(),
+                                                                   ignore=not args.no_ignore))}
+    return sorted(files + analyzed(defining), key=lambda file: order.get(file[0], len(order)))
+
+
+def render_call_graph(path: Path, args) -> None:
+    """Print the callers and callees of --call-graph SYMBOL across every file under path."""
+    import json
+    from .calls import project_call_graph, render_call_graph_dot
+
+    if path.is_dir():
+        files = _call_graph_files(path, args)
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        files = [(path.name, analyzer.lines, analyzer.get_structure())]
+
+    graph = project_call_graph(files)
+    targets = graph.find(args.call_graph)
+    if not targets:
+        print(f"Error: No function named '{args.call_graph}' found under {path}", file=sys.stderr)
+        sys.exit(1)
+
+    def link(node, line):
+        info = graph.nodes[node]
+        return {'file': info['file'], 'name': info['name'], 'line': info['line'], 'call_line': line}
+
+    matches = []
+    for target in targets:
+        match = dict(graph.nodes[target])
+        match['callers'] = [link(call['caller'], call['line']) for call in graph.callers(target)]
+        match['callees'] = [link(call['callee'], call['line']) for call in graph.edges[target]]
+        matches.append(match)
+
+    if args.format == 'json':
+        print(json.dumps({'symbol': args.call_graph, 'matches': matches}, indent=2))
+    elif args.format == 'dot':
+        print(render_call_graph_dot(matches, args.call_graph))
+    else:
+        for match in matches:
+            print(f"{match['name']} ({match['file']}:{match['line']})")
+            for title, arrow, links in (('Callers', '←', match['callers']), ('Callees', '→', match['callees'])):
+                print(f"  {title} ({len(links)}):")
+                for other in links:
+                    print(f"    {arrow} {other['name']} ({other['file']}:{other['line']}), "
+                          f"call at line {other['call_line']}")
+            print()
+
+
+def render_lsp_symbols(path: Path, args) -> None:
+    """Print LSP DocumentSymbol JSON: the symbol array for a file, or {uri, symbols} per file of a directory."""
+    import json
+    from .lsp import document_symbols
+
+    if path.is_dir():
+        result = [{'uri': file_path.resolve().as_uri(), 'symbols': document_symbols(analyzer.lines, structure)}
+                  for file_path, analyzer, structure in _analyze_directory(path, args)
+                  if analyzer is not None]
+    else:
+        analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+        if not analyzer_class:
+            print(f"Error: No analyzer found for {path}", file=sys.stderr)
+            sys.exit(1)
+        analyzer = analyzer_class(str(path))
+        result = document_symbols(analyzer.lines, analyzer.get_structure(**_build_analyzer_kwargs(analyzer, args)))
     print(json.dumps(result, indent=2))
 
 
@@ -1496,8 +2651,8 @@ def _render_text_categories(structure: Dict[str, List[Dict[str, Any]]],
         if not items:
             continue
 
-        # Format category name (e.g., 'functions' → 'Functions')
-        category_name = category.capitalize()
+        # Format category name (e.g., 'functions' → 'Functions', 'code_blocks' → 'Code blocks')
+        category_name = category.replace('_', ' ').capitalize()
         print(f"{category_name} ({len(items)}):")
 
         # Special handling for different categories
@@ -1505,21 +2660,44 @@ def _render_text_categories(structure: Dict[str, List[Dict[str, Any]]],
             _format_links(items, path, output_format)
         elif category == 'code_blocks':
             _format_code_blocks(items, path, output_format)
+        elif output_format != 'grep' and all('level' in item for item in items):
+            _format_headings(items, path)
         else:
             _format_standard_items(items, path, output_format)
 
         print()  # Blank line between categories
 
 
-def show_structure(analyzer: FileAnalyzer, output_format: str, args=None):
+def _annotate_blame(analyzer: FileAnalyzer, structure: Dict[str, List[Dict[str, Any]]]) -> None:
+    """Add 'blame' to each symbol: commit, author and date of the latest change in its line range."""
+    try:
+        lines = blame(analyzer.path, analyzer.revision)
+    except GitError as e:
+        print(f"Warning: --blame unavailable: {e}", file=sys.stderr)
+        return
+    for items in structure.values():
+        for item in items if isinstance(items, list) else []:
+            start = item.get('line') if isinstance(item, dict) else None
+            if not isinstance(start, int):
+                continue
+            change = last_change(lines, start, item.get('line_end') or start)
+            if change:
+                item['blame'] = {key: change[key] for key in ('commit', 'author', 'date')}
+
+
+def show_structure(analyzer: FileAnalyzer, output_format: str, args=None,
+                   structure: Optional[Dict[str, List[Dict[str, Any]]]] = None):
     """Show file structure.
 
-    Simplified using extracted helper functions.
+    Simplified using extracted helper functions. structure, when given,
+    is shown instead of the analyzer's own (--budget reduces it).
     """
     # Build kwargs and get structure
-    kwargs = _build_analyzer_kwargs(analyzer, args)
-    structure = analyzer.get_structure(**kwargs)
+    if structure is None:
+        structure = analyzer.get_structure(**_build_analyzer_kwargs(analyzer, args))
     path = analyzer.path
+    if args and getattr(args, 'blame', False):
+        _annotate_blame(analyzer, structure)
 
     # Get fallback info
     is_fallback = getattr(analyzer, 'is_fallback', False)
@@ -1527,7 +2705,7 @@ def show_structure(analyzer: FileAnalyzer, output_format: str, args=None):
 
     # Handle outline mode
     if args and getattr(args, 'outline', False):
-        _print_file_header(path, is_fallback, fallback_lang)
+        _print_file_header(path, is_fallback, fallback_lang, analyzer.revision)
         if not structure:
             print("No structure available for this file type")
             return
@@ -1540,6 +2718,16 @@ def show_structure(analyzer: FileAnalyzer, output_format: str, args=None):
         _render_json_output(analyzer, structure)
         return
 
+    # Handle JSON Lines output (one record per file/symbol)
+    if output_format == 'jsonl':
+        _render_jsonl_output(analyzer, structure)
+        return
+
+    # Handle Markdown report output
+    if output_format == 'markdown':
+        _render_markdown_output(analyzer, structure)
+        return
+
     # Handle typed JSON output (with types and relationships)
     if output_format == 'typed':
         _render_typed_json_output(analyzer, structure)
@@ -1547,12 +2735,12 @@ def show_structure(analyzer: FileAnalyzer, output_format: str, args=None):
 
     # Handle empty structure
     if not structure:
-        _print_file_header(path, is_fallback, fallback_lang)
+        _print_file_header(path, is_fallback, fallback_lang, analyzer.revision)
         print("No structure available for this file type")
         return
 
     # Text output: show header, categories, and navigation hints
-    _print_file_header(path, is_fallback, fallback_lang)
+    _print_file_header(path, is_fallback, fallback_lang, analyzer.revision)
     _render_text_categories(structure, path, output_format)
 
     # Navigation hints
@@ -1561,29 +2749,118 @@ def show_structure(analyzer: FileAnalyzer, output_format: str, args=None):
         print_breadcrumbs('structure', path, file_type=file_type)
 
 
-def extract_element(analyzer: FileAnalyzer, element: str, output_format: str):
+_REVISION_TARGET = re.compile(r'^(?P<path>(?:.*[/\\])?[^/\\@]+)@(?P<rev>[^:]+)(?P<rest>:.*)?$')
+_LINE_RANGE_TARGET = re.compile(r'^(?P<path>.+):(?P<start>\d+)(?:-(?P<end>\d+))?$')
+
+
+def handle_line_range(path: Path, args) -> None:
+    """Print lines START-END of a file with a header naming the function/class they belong to."""
+    analyzer_class = get_analyzer(str(path), allow_fallback=not args.no_fallback)
+    if analyzer_class:
+        analyzer = analyzer_class(str(path))
+        try:
+            context = enclosing_symbols(analyzer.get_structure(), *args.line_range)
+        except Exception:
+            context = []
+        lines = analyzer.lines
+    else:
+        # Any text file can be sliced; there's just no structure to name
+        if args.rev:
+            lines = file_at(path, args.rev).splitlines()
+        else:
+            with open(path, encoding='utf-8', errors='replace') as f:
+                lines = f.read().splitlines()
+        context = []
+
+    if args.rev:
+        path = f"{path}@{args.rev}"
+    start, end = args.line_range
+    if start < 1 or start > len(lines):
+        print(f"Error: Line {start} is out of range ({path} has {len(lines)} lines)", file=sys.stderr)
+        sys.exit(1)
+    end = min(end, len(lines))
+    source = lines[start - 1:end]
+
+    if args.format in ('json', 'jsonl'):
+        import json
+        result = {'file': str(path), 'line_start': start, 'line_end': end, 'context': context,
+                  'source': '\n'.join(source)}
+        print(json.dumps(result, indent=2 if args.format == 'json' else None))
+        return
+    if args.format == 'grep':
+        for number, text in enumerate(source, start):
+            print(f"{path}:{number}:{text}")
+        return
+
+    where = ' > '.join(f"{item['name']} ({item['kind']} {item['line']}-{item['line_end']})" for item in context)
+    print(f"{path}:{start}-{end}" + (f" | in {where}" if where else '') + "\n")
+    for number, text in enumerate(source, start):
+        print(f"   {number:4d}  {text}")
+
+
+def find_qualified_symbol(analyzer: FileAnalyzer, name: str) -> Optional[Dict[str, Any]]:
+    """Locate Owner.member (or Owner::member, Owner#member) in an analyzer's structure (see find_symbols)."""
+    items = [item for items in analyzer.get_structure().values() for item in items]
+    match = next(iter(find_symbols(items, analyzer.lines, name)), None)
+    if match is None:
+        return None
+
+    line_start = match['line']
+    line_end = match.get('line_end', line_start)
+    return {
+        'name': name,
+        'line_start': line_start,
+        'line_end': line_end,
+        'source': '\n'.join(analyzer.lines[line_start - 1:line_end]),
+    }
+
+
+def _with_leading_docs(analyzer: FileAnalyzer, result: Dict[str, Any]) -> Dict[str, Any]:
+    """Extend an extracted element upward over the comments, attributes and decorators directly above it."""
+    line_start = result.get('line_start', 1)
+    start = line_start
+    while start > 1 and DOC_LINE.match(analyzer.lines[start - 2]) \
+            and not analyzer.lines[start - 2].lstrip().startswith('#!'):
+        start -= 1
+    if start == line_start:
+        return result
+    result = dict(result, line_start=start)
+    result['source'] = '\n'.join(analyzer.lines[start - 1:line_start - 1] + [result.get('source', '')])
+    return result
+
+
+def extract_element(analyzer: FileAnalyzer, element: str, output_format: str, include_docs: bool = False):
     """Extract a specific element.
 
     Args:
         analyzer: File analyzer
-        element: Element name to extract
+        element: Element name to extract (Owner.member names are resolved through the structure)
         output_format: Output format
+        include_docs: Also include the doc comments/decorators directly above it
     """
-    # Try common element types
-    for element_type in ['function', 'class', 'struct', 'section', 'server', 'location', 'upstream']:
-        result = analyzer.extract_element(element_type, element)
-        if result:
-            break
-    else:
-        # Not found
-        print(f"Error: Element '{element}' not found in {analyzer.path}", file=sys.stderr)
-        sys.exit(1)
+    result = find_qualified_symbol(analyzer, element) if SYMBOL_QUALIFIER.search(element) else None
+    if result is None:
+        # Try common element types
+        for element_type in ['function', 'class', 'struct', 'section', 'server', 'location', 'upstream']:
+            result = analyzer.extract_element(element_type, element)
+            if result:
+                break
+        else:
+            # Not found
+            print(f"Error: Element '{element}' not found in {analyzer.path}", file=sys.stderr)
+            sys.exit(1)
+    if include_docs:
+        result = _with_leading_docs(analyzer, result)
 
     # Format output
     if output_format == 'json':
         import json
         print(json.dumps(result, indent=2))
         return
+    if output_format == 'jsonl':
+        import json
+        print(json.dumps(result))
+        return
 
     path = analyzer.path
     line_start = result.get('line_start', 1)
@@ -1591,6 +2868,9 @@ def extract_element(analyzer: FileAnalyzer, element: str, output_format: str):
     source = result.get('source', '')
     name = result.get('name', element)
 
+    if analyzer.revision:
+        path = f"{path}@{analyzer.revision}"
+
     # Header
     print(f"{path}:{line_start}-{line_end} | {name}\n")
 
@@ -0,0 +1,161 @@
+"""Mermaid diagrams: class diagrams for a file, import flowcharts for a project."""
+
+import posixpath
+import re
+from typing import Any, Dict, List
+
+from .dependencies import ImportGraph
+
+
+# Categories drawn as classes in a class diagram
+TYPE_CATEGORIES = ('classes', 'structs', 'interfaces', 'traits', 'protocols', 'records', 'enums',
+                   'types', 'objects', 'contracts', 'unions', 'modules', 'mixins', 'extensions')
+# Categories listed as class members when they fall inside a type's line range
+MEMBER_CATEGORIES = ('methods', 'functions', 'class_methods', 'constructors', 'properties',
+                     'fields', 'events', 'modifiers')
+
+_VISIBILITY = {'public': '+', 'private': '-', 'protected': '#', 'internal': '~', 'package': '~'}
+_STEREOTYPES = {'interfaces': 'interface', 'traits': 'trait', 'protocols': 'protocol', 'enums': 'enumeration',
+                'structs': 'struct', 'records': 'record', 'modules': 'module', 'mixins': 'mixin',
+                'contracts': 'contract', 'unions': 'union', 'objects': 'object', 'extensions': 'extension'}
+
+
+def render_class_diagram(structure: Dict[str, List[Dict[str, Any]]], module_name: str) -> str:
+    """classDiagram for one file.
+
+    Types get their methods (items inside the type's line range), fields or
+    enum values, a stereotype for non-classes and inheritance arrows from
+    superclass/parent/extends/bases/inherits. Top-level functions are
+    collected in a <<module>> class named after the file.
+    """
+    types = sorted(((category, item) for category in TYPE_CATEGORIES for item in structure.get(category, [])),
+                   key=lambda pair: pair[1].get('line', 0))
+    members = sorted(((category, item) for category in MEMBER_CATEGORIES for item in structure.get(category, [])),
+                     key=lambda pair: pair[1].get('line', 0))
+
+    lines = ['classDiagram']
+    ids = {}
+    owned = set()
+    relations = []
+    for category, item in types:
+        name = item['name']
+        class_id = _class_id(name)
+        ids[name] = class_id
+        body = []
+        stereotype = _STEREOTYPES.get(category)
+        if category == 'types' and item.get('kind') not in (None, 'object', 'ref object', 'class'):
+            stereotype = _STEREOTYPES.get(item['kind'] + 's', item['kind'])
+        if stereotype:
+            body.append(f'<<{stereotype}>>')
+        for value in item.get('fields') or item.get('members') or item.get('values') or []:
+            body.append(_clean(value if isinstance(value, str) else value.get('name', '')))
+        for index, (_, member) in enumerate(members):
+            if _contains(item, member) and not any(_contains(other, member) and _contains(item, other)
+                                                   for _, other in types if other is not item):
+                body.append(_member(members[index][0], member, name))
+                owned.add(index)
+
+        label = f'["{_clean(name)}"]' if class_id != name else ''
+        if body:
+            lines.append(f'    class {class_id}{label} {{')
+            lines.extend(f'        {line}' for line in body)
+            lines.append('    }')
+        else:
+            lines.append(f'    class {class_id}{label}')
+        for base in _bases(item):
+            relations.append((base, class_id))
+
+    module_functions = [(category, member) for index, (category, member) in enumerate(members)
+                        if index not in owned and category not in ('properties', 'fields', 'events')]
+    if module_functions:
+        lines.append(f'    class {_class_id(module_name)}["{_clean(module_name)}"] {{')
+        lines.append('        <<module>>')
+        lines.extend(f'        {_member(category, function, "")}' for category, function in module_functions)
+        lines.append('    }')
+
+    for base, class_id in relations:
+        # 'Shape' may be declared here as 'Shapes::Shape'
+        qualified = [name for name in ids if re.search(r'(?:::|\.)' + re.escape(base) + '$', name)]
+        base_id = ids.get(base) or (ids[qualified[0]] if len(qualified) == 1 else _class_id(base))
+        if base_id not in ids.values():
+            lines.append(f'    class {base_id}["{_clean(base)}"]' if base_id != base else f'    class {base_id}')
+            ids[base] = base_id
+        lines.append(f'    {base_id} <|-- {class_id}')
+
+    return '\n'.join(lines)
+
+
+def render_flowchart(graph: ImportGraph, include_external: bool = False) -> str:
+    """flowchart of file imports, with a subgraph per directory."""
+    node_ids = {node: f'n{number}' for number, node in enumerate(graph.nodes)}
+    lines = ['flowchart LR']
+
+    by_directory: Dict[str, List[str]] = {}
+    for node in graph.nodes:
+        by_directory.setdefault(posixpath.dirname(node), []).append(node)
+    for number, (directory, nodes) in enumerate(sorted(by_directory.items())):
+        indent = '    '
+        if directory:
+            lines.append(f'    subgraph d{number}["{_clean(directory)}/"]')
+            indent = '        '
+        for node in nodes:
+            lines.append(f'{indent}{node_ids[node]}["{_clean(posixpath.basename(node))}"]')
+        if directory:
+            lines.append('    end')
+
+    if include_external:
+        for target in sorted({t for targets in graph.external.values() for t in targets}):
+            node_ids[target] = f'x{len(node_ids)}'
+            lines.append(f'    {node_ids[target]}(["{_clean(target)}"])')
+
+    for source, target in graph.edge_list(include_external):
+        lines.append(f'    {node_ids[source]} --> {node_ids[target]}')
+    return '\n'.join(lines)
+
+
+def _contains(outer: Dict[str, Any], inner: Dict[str, Any]) -> bool:
+    start = outer.get('line', 0)
+    return outer is not inner and start < inner.get('line', 0) <= outer.get('line_end', start)
+
+
+def _bases(item: Dict[str, Any]) -> List[str]:
+    """Superclasses/interfaces, whichever key the analyzer used."""
+    bases = []
+    for key in ('superclass', 'parent', 'extends', 'bases', 'inherits'):
+        value = item.get(key)
+        if isinstance(value, str):
+            bases.append(value)
+        elif isinstance(value, list):
+            bases.extend(v for v in value if isinstance(v, str))
+    return [re.sub(r'[\[(<].*', '', base).strip() for base in bases if base.strip()]
+
+
+def _member(category: str, item: Dict[str, Any], owner: str) -> str:
+    """'+name(args) Return' in Mermaid member syntax; class methods are marked static ($)."""
+    name = item.get('name', '')
+    # Owner#method / Owner::method / Owner.method -> method
+    for separator in ('#', '::', '.'):
+        if owner and name.startswith(owner + separator):
+            name = name[len(owner) + len(separator):]
+            break
+    text = _VISIBILITY.get(item.get('visibility', ''), '') + name
+
+    signature = re.sub(r'\s{2}\(.*\)$', '', item.get('signature', ''))   # '  (kind)' annotations
+    call = re.match(r'\s*(?:\[[^\]]*\])?\s*(\((?:[^()]|\([^()]*\))*\))(.*)$', signature)
+    if call:
+        returns = re.sub(r'^\s*(?:->|:)\s*', '', call.group(2)).strip()
+        text += f"{call.group(1)} {returns}".rstrip()
+    elif category not in ('properties', 'fields', 'events'):
+        text += '()'
+    if category == 'class_methods':
+        text += '$'
+    return _clean(text)
+
+
+def _class_id(name: str) -> str:
+    return re.sub(r'\W', '_', name) or '_'
+
+
+def _clean(text: str) -> str:
+    """Characters Mermaid would misread: generics become ~T~, quotes and braces go."""
+    return re.sub(r'["{}]', '', str(text)).replace('<', '~').replace('>', '~').replace('~~', '~').strip()
@@ -0,0 +1,169 @@
+"""Code metrics: size, symbol count, cyclomatic complexity and nesting depth, per file and per function."""
+
+import re
+from typing import Any, Dict, List, Tuple
+
+
+# Categories whose items are callables with a body to score
+FUNCTION_CATEGORIES = ('functions', 'methods', 'class_methods', 'constructors')
+
+# Decision points, language-agnostic: branches, loops, handlers, cases and short-circuit operators
+_DECISION = re.compile(
+    r'\b(?:if|elif|elsif|elseif|else\s+if|unless|for|foreach|while|until|loop|case|when|catch|except|rescue)\b'
+    r'|&&|\|\||\band\b|\bor\b|\s\?\s'
+)
+_COMMENT = re.compile(r'^\s*(?:#|//|--|;|\*|/\*)')
+# Lines that open a nested block (a closing brace may come first: '} else if', '} catch')
+_BLOCK = re.compile(r'^\s*(?:\}\s*)?(?:if|unless|for|foreach|while|until|loop|do|case|switch|match|select|try|with)\b')
+
+# Comment syntax per file suffix (or lower-cased file name): line markers, then (open, close) block pairs.
+# Block openers are tried first, so Lua's --[[ is not read as a -- line comment.
+CommentSyntax = Tuple[Tuple[str, ...], Tuple[Tuple[str, str], ...]]
+_C_STYLE: CommentSyntax = (('//',), (('/*', '*/'),))
+_HASH: CommentSyntax = (('#',), ())
+_XML: CommentSyntax = ((), (('<!--', '-->'),))
+_COMMENT_FAMILIES: List[Tuple[CommentSyntax, str]] = [
+    (_C_STYLE, '.c .h .cc .cpp .cxx .c++ .hh .hpp .hxx .h++ .cs .java .kt .kts .scala .sc .groovy .gradle '
+               '.go .rs .swift .dart .js .mjs .cjs .jsx .ts .tsx .cts .mts .m .mm .sol .zig .zon .proto '
+               '.avdl .avsc .v .sv .svh .vh .scss .less .css'),
+    ((('//', '#'), (('/*', '*/'),)), '.php .phtml .thrift .tf .hcl'),
+    (_HASH, '.py .pyw .sh .bash .zsh .ksh .cgi .r .rprofile .cr .ex .exs .yaml .yml .toml .conf '
+            '.editorconfig .gitconfig .gitmodules .properties .coveragerc .flake8 .pylintrc pylintrc '
+            '.mk .make makefile gnumakefile dockerfile containerfile .dockerfile .bzl build build.bazel '
+            'buck workspace workspace.bazel module.bazel .gd .tcl'),
+    ((('#',), (('=begin', '=end'),)), '.rb .rake .gemspec'),
+    ((('#',), (('=pod', '=cut'), ('=head', '=cut'))), '.pl .pm .t'),
+    ((('#',), (('#=', '=#'),)), '.jl'),
+    ((('#',), (('#[', ']#'),)), '.nim .nims .nimble'),
+    ((('#',), (('<#', '#>'),)), '.ps1 .psm1'),
+    ((('#',), (('#[[', ']]'),)), '.cmake cmakelists.txt'),
+    (((';', '#'), ()), '.ini .cfg .asm .nasm .s .inc'),
+    ((('--',), (('--[[', ']]'),)), '.lua'),
+    ((('--',), (('{-', '-}'),)), '.hs .lhs'),
+    ((('--',), (('/*', '*/'),)), '.sql .ddl'),
+    ((('--',), ()), '.vhd .vhdl .elm'),
+    (((';',), (('#|', '|#'),)), '.clj .cljs .cljc .edn .rkt .scm .ss .sld'),
+    ((('%',), ()), '.erl .hrl .escript .tex .ltx .sty .cls'),
+    ((('!',), ()), '.f .for .ftn .f77 .f90 .f95 .f03 .f08'),
+    (((), (('(*', '*)'),)), '.ml .mli'),
+    ((('//',), (('(*', '*)'),)), '.fs .fsi .fsx'),
+    (_XML, '.xml .html .htm .xhtml .svg .xsd .xsl .xslt .wsdl .xaml .plist .resx .nuspec .props .targets '
+           '.csproj .fsproj .vbproj .md .markdown'),
+    ((('//',), (('/*', '*/'), ('<!--', '-->'))), '.vue .svelte'),
+    (((), ()), '.json .jsonl .ipynb .csv .tsv'),
+]
+COMMENT_SYNTAX: Dict[str, CommentSyntax] = {key: syntax for syntax, keys in _COMMENT_FAMILIES
+                                            for key in keys.split()}
+_STRING = re.compile(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'')
+# Unknown files (extensionless scripts, ...): the most common markers
+_DEFAULT_SYNTAX: CommentSyntax = (('#', '//'), (('/*', '*/'),))
+
+
+def comment_syntax(file_name: str) -> CommentSyntax:
+    """Line comment markers and block comment delimiters for a file name."""
+    name = file_name.replace('\\', '/').rsplit('/', 1)[-1].lower()
+    if name in COMMENT_SYNTAX:
+        return COMMENT_SYNTAX[name]
+    return COMMENT_SYNTAX.get(name[name.rfind('.'):] if '.' in name else '', _DEFAULT_SYNTAX)
+
+
+def line_counts(lines: List[str], file_name: str) -> Dict[str, int]:
+    """Split a file's lines into code, comment and blank (cloc-style: a line with any code is code)."""
+    markers, blocks = comment_syntax(file_name)
+    counts = {'code': 0, 'comment': 0, 'blank': 0}
+    closer = None
+    for text in lines:
+        stripped = text.strip()
+        if not stripped:
+            counts['blank'] += 1
+            continue
+        if closer is not None:
+            counts['comment'] += 1
+            if closer in stripped:
+                closer = None
+            continue
+        opened = next(((open_, close) for open_, close in blocks if stripped.startswith(open_)), None)
+        if opened is not None:
+            counts['comment'] += 1
+            if opened[1] not in stripped[len(opened[0]):]:
+                closer = opened[1]
+        elif markers and stripped.startswith(markers):
+            counts['comment'] += 1
+        else:
+            counts['code'] += 1
+            # A block comment opened after code on the same line and left open
+            code = _STRING.sub('""', stripped)
+            for open_, close in blocks:
+                start = code.rfind(open_)
+                if start > 0 and close not in code[start + len(open_):]:
+                    closer = close
+    return counts
+
+
+def cyclomatic_complexity(lines: List[str], start: int, end: int) -> int:
+    """1 + decision points between 1-based lines start and end (comment lines skipped)."""
+    complexity = 1
+    for text in lines[max(start, 1) - 1:end]:
+        if not _COMMENT.match(text):
+            complexity += len(_DECISION.findall(text))
+    return complexity
+
+
+def nesting_depth(lines: List[str], start: int, end: int) -> int:
+    """Deepest control block nesting in a body (1 = an if at the top of the body, 0 = straight-line code).
+
+    Measured by indentation, which works the same for brace, keyword and
+    offside-rule languages: a block line's level is its indent relative to
+    the first body line, in units of the smallest indent step seen.
+    """
+    body = [text.expandtabs(4) for text in lines[max(start, 1):end]
+            if text.strip() and not _COMMENT.match(text)]
+    if not body:
+        return 0
+    indents = [len(text) - len(text.lstrip()) for text in body]
+    base = indents[0]
+    unit = min((indent - base for indent in indents if indent > base), default=4)
+    return max(((indent - base) // unit + 1 for text, indent in zip(body, indents)
+                if indent >= base and _BLOCK.match(text)), default=0)
+
+
+def function_metrics(lines: List[str], structure: Dict[str, List[Dict[str, Any]]]) -> List[Dict[str, Any]]:
+    """Per function, in line order: {'name', 'line', 'line_end', 'lines', 'complexity', 'nesting'}."""
+    functions = []
+    for category in FUNCTION_CATEGORIES:
+        for item in structure.get(category, []):
+            start = item.get('line') or item.get('line_start')
+            if not isinstance(start, int):
+                continue
+            end = item.get('line_end') or start
+            functions.append({'name': item.get('name', ''), 'line': start, 'line_end': end,
+                              'lines': end - start + 1, 'complexity': cyclomatic_complexity(lines, start, end),
+                              'nesting': nesting_depth(lines, start, end)})
+    return sorted(functions, key=lambda f: f['line'])
+
+
+def file_metrics(lines: List[str], structure: Dict[str, List[Dict[str, Any]]]) -> Dict[str, int]:
+    """lines, symbols, functions, complexity (sum over functions) and max_complexity for a file."""
+    scores = []
+    for category in FUNCTION_CATEGORIES:
+        for item in structure.get(category, []):
+            start = item.get('line') or item.get('line_start')
+            if isinstance(start, int):
+                scores.append(cyclomatic_complexity(lines, start, item.get('line_end') or start))
+    return {
+        'lines': len(lines),
+        'symbols': sum(len(items) for items in structure.values()),
+        'functions': len(scores),
+        'complexity': sum(scores),
+        'max_complexity': max(scores, default=0),
+    }
+
+
+def hotspot_score(function: Dict[str, Any], max_lines: int, max_complexity: int, max_nesting: int) -> float:
+    """How far past its limits a function_metrics entry is: the sum of lines, complexity and nesting over their limits.
+
+    A function at every limit scores 3.0; twice as long with the same
+    complexity and nesting scores 4.0.
+    """
+    return (function['lines'] / max(max_lines, 1) + function['complexity'] / max(max_complexity, 1)
+            + function['nesting'] / max(max_nesting, 1))
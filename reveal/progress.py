@@ -0,0 +1,53 @@
+"""Progress line on stderr while a long directory walk runs: files done, percentage and time left.
+
+Shown only on a terminal, and only once a walk has taken long enough
+to be worth it, so short runs, pipes and logs stay clean.
+"""
+
+import sys
+import time
+from typing import Optional, TextIO
+
+# Seconds before the line first appears, and between redraws
+_DELAY = 0.5
+_REDRAW = 0.1
+
+
+class Progress:
+    """A self-erasing 'Analyzing 120/800 files (15%), about 12s left' line."""
+
+    def __init__(self, label: str = 'Analyzing', stream: Optional[TextIO] = None, enabled: bool = True):
+        self.label = label
+        self.stream = stream or sys.stderr
+        self.enabled = enabled and self.stream.isatty()
+        self.started = time.monotonic()
+        self.drawn_at = 0.0
+        self.shown = False
+
+    def update(self, done: int, total: int) -> None:
+        """Redraw the line for done of total files (throttled)."""
+        if not self.enabled or total <= 0:
+            return
+        now = time.monotonic()
+        elapsed = now - self.started
+        if elapsed < _DELAY or now - self.drawn_at < _REDRAW and done < total:
+            return
+        self.drawn_at = now
+        text = f"{self.label} {done}/{total} files ({100 * done // total}%)"
+        if 0 < done < total:
+            text += f", about {_duration(elapsed / done * (total - done))} left"
+        self.stream.write(f"\r\033[K{text}")
+        self.stream.flush()
+        self.shown = True
+
+    def clear(self) -> None:
+        """Erase the line, so output can be written in its place."""
+        if self.shown:
+            self.stream.write("\r\033[K")
+            self.stream.flush()
+            self.shown = False
+
+
+def _duration(seconds: float) -> str:
+    seconds = int(seconds + 0.5)
+    return f"{seconds // 60}m{seconds % 60:02}s" if seconds >= 60 else f"{seconds}s"
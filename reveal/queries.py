@@ -0,0 +1,93 @@
+"""SQL embedded in source code: string literals that hold queries, with the tables they touch.
+
+A literal is a query when it starts (after whitespace or an opening
+parenthesis) with the shape of a statement: SELECT ... FROM table,
+INSERT INTO, UPDATE table SET, DELETE FROM, WITH name AS (, CREATE/ALTER/
+DROP of a table, view or index, MERGE INTO, REPLACE INTO, TRUNCATE.
+Keywords are matched in any case, but the shape has to be there, and a
+SELECT ... FROM with mixed-case keywords or an article in its column
+list reads as prose ("Select an item from the list"). Triple-quoted
+and backtick literals may span lines; a query split across concatenated
+literals is reported from its first piece.
+"""
+
+import re
+from typing import Any, Dict, List
+
+
+_LITERAL = re.compile(r'(?<![\w])[fFrRbBuU]{0,2}(?P<literal>"""[\s\S]*?"""|\'\'\'[\s\S]*?\'\'\'|`(?:\\.|[^`\\])*`|'
+                      r'"(?:\\.|[^"\\\n])*"|\'(?:\\.|[^\'\\\n])*\')')
+
+_IDENTIFIER = r'[\w$."`\[\]]+'
+_CLAUSE_END = r'(?:\s*$|\s*[,;)]|\s+(?:WHERE|JOIN|INNER|LEFT|RIGHT|FULL|CROSS|NATURAL|ON|GROUP|ORDER|LIMIT|OFFSET|' \
+              r'HAVING|UNION|AS|USING|FOR|WINDOW|FETCH|RETURNING|\w+\s*(?:$|[,;)]|WHERE|JOIN|ON|GROUP|ORDER|LIMIT))\b)'
+_STATEMENT = re.compile(
+    r'^[\s(]*(?:'
+    r'(?P<select>SELECT)\b(?P<columns>[\s\S]*?)\b(?P<from>FROM)\s+' + _IDENTIFIER + _CLAUSE_END + '|'
+    r'(?P<insert>INSERT)\s+(?:OR\s+\w+\s+)?INTO\s+' + _IDENTIFIER + '|'
+    r'(?P<update>UPDATE)\s+' + _IDENTIFIER + r'\s+(?:\w+\s+)?SET\b|'
+    r'(?P<delete>DELETE)\s+FROM\s+' + _IDENTIFIER + '|'
+    r'(?P<with>WITH)\s+(?:RECURSIVE\s+)?\w+\s*(?:\([^)]*\)\s*)?AS\s*\(|'
+    r'(?P<ddl>CREATE|ALTER|DROP)\s+(?:OR\s+REPLACE\s+|UNIQUE\s+|TEMP(?:ORARY)?\s+)*'
+    r'(?:TABLE|VIEW|INDEX|MATERIALIZED\s+VIEW|SEQUENCE|TRIGGER|SCHEMA)\b|'
+    r'(?P<merge>MERGE|REPLACE)\s+INTO\s+' + _IDENTIFIER + '|'
+    r'(?P<truncate>TRUNCATE)\s+(?:TABLE\s+)?' + _IDENTIFIER +
+    r')', re.IGNORECASE)
+
+_TABLE = re.compile(r'\b(?:FROM|JOIN|INTO|UPDATE|TABLE(?:\s+IF\s+(?:NOT\s+)?EXISTS)?|TRUNCATE|'
+                    r'INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?[\w$.]+\s+ON)\s+'
+                    r'(?P<table>[A-Za-z_][\w$.]*|"[^"]+"|`[^`]+`|\[[^\]]+\])', re.IGNORECASE)
+_TABLE_KEYWORDS = {'select', 'table', 'if', 'lateral', 'only', 'unnest', 'values', 'set'}
+_KINDS = ('select', 'insert', 'update', 'delete', 'with', 'ddl', 'merge', 'truncate')
+# Words that put a SELECT ... FROM literal in prose rather than in SQL
+_ARTICLES = {'a', 'an', 'the', 'your', 'my', 'our', 'this', 'that', 'these', 'those', 'some', 'which', 'what'}
+
+
+def query_tables(sql: str) -> List[str]:
+    """Tables a query reads or writes, in order of first mention; names bound by WITH are left out."""
+    bound = {name.lower() for name in re.findall(r'(?:\bWITH(?:\s+RECURSIVE)?|,)\s*(\w+)\s*(?:\([^)]*\)\s*)?AS\s*\(',
+                                                  sql, re.IGNORECASE)}
+    tables: List[str] = []
+    for match in _TABLE.finditer(sql):
+        table = match.group('table').strip('"`[]')
+        if table.lower() in _TABLE_KEYWORDS or table.lower() in bound or table in tables:
+            continue
+        tables.append(table)
+    return tables
+
+
+def find_queries(content: str) -> List[Dict[str, Any]]:
+    """SQL queries in a file's string literals: {'operation', 'tables', 'sql', 'line', 'line_end', 'column'}.
+
+    operation is the statement keyword (SELECT, INSERT, ..., CREATE TABLE
+    for DDL); sql is the query with whitespace collapsed.
+    """
+    queries = []
+    for match in _LITERAL.finditer(content):
+        literal = match.group('literal')
+        quote = 3 if literal[:3] in ('"""', "'''") else 1
+        text = literal[quote:-quote]
+        statement = _STATEMENT.match(text)
+        if not statement or (statement.group('select') and _prose(statement)):
+            continue
+        kind = next(kind for kind in _KINDS if statement.group(kind))
+        operation = statement.group(kind).upper()
+        if kind == 'ddl':
+            operation = ' '.join(statement.group().split()).lstrip('( ').split(' (')[0].upper()
+            operation = ' '.join(word for word in operation.split()
+                                 if word not in ('OR', 'REPLACE', 'UNIQUE', 'TEMP', 'TEMPORARY'))
+        start = match.start('literal')
+        line = content.count('\n', 0, start) + 1
+        queries.append({'operation': operation, 'tables': query_tables(text), 'sql': ' '.join(text.split()),
+                        'line': line, 'line_end': line + literal.count('\n'),
+                        'column': start - content.rfind('\n', 0, start)})
+    return queries
+
+
+def _prose(statement) -> bool:
+    """Whether a SELECT ... FROM match is a sentence rather than a query."""
+    keywords = statement.group('select') + statement.group('from')
+    if not (keywords.isupper() or keywords.islower()):
+        return True
+    words = re.findall(r'[a-z]+(?=\s+[a-z])', statement.group('columns').lower())
+    return any(word in _ARTICLES for word in words)
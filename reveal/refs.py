@@ -0,0 +1,112 @@
+"""References to a symbol: every line that uses the name in code, with the function it sits in.
+
+Matching is textual on whole words, with string literals (triple-quoted
+ones followed across lines) and comments left out; a qualified name
+(Server.Start, Shapes::Circle) is looked up by its last segment. Lines
+that define the symbol or import it are told apart from plain uses.
+"""
+
+import re
+from typing import Any, Dict, List, Optional, Pattern, Tuple
+
+from .base import enclosing_symbols
+from .metrics import comment_syntax
+from .unused import reference_name
+
+
+_STRING = re.compile(r'"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'|`[^`]*`')
+# Rust: strings, and char literals ('x', '\n', '\u{1F600}') rather than lifetimes ('a)
+_RUST_STRING = re.compile(r'"(?:\\.|[^"\\])*"|\'(?:\\(?:u\{[0-9a-fA-F]*\}|x[0-9a-fA-F]{2}|.)|[^\'\\])\'')
+_TRIPLE_QUOTES = ('"""', "'''")
+
+
+def reference_word(name: str) -> str:
+    """The word code uses to refer to a (possibly qualified) name: Server.Start -> Start."""
+    return reference_name(re.split(r'\.|::|#', name)[-1]) or name
+
+
+def reference_pattern(name: str) -> Pattern:
+    """Whole-word pattern for the name as code refers to it."""
+    return re.compile(r'(?<![\w$])' + re.escape(reference_word(name)) + r'(?![\w$])')
+
+
+def _code(text: str, tokens: Pattern, markers, closers: Dict[str, str],
+          closer: Optional[str] = None) -> Tuple[str, Optional[str]]:
+    """A line with string literals and comments blanked out and any trailing line comment cut off (columns kept).
+
+    tokens finds string literals, line comment markers and the openers in
+    closers (triple quotes, block comments), which map to what ends them.
+    closer ends a string or comment left open on an earlier line; the one
+    still open at the end of this line is returned with the code.
+    """
+    code, position = '', 0
+    while position < len(text):
+        if closer is not None:
+            end = text.find(closer, position)
+            if end < 0:
+                return code + ' ' * (len(text) - position), closer
+            code += ' ' * (end + len(closer) - position)
+            position, closer = end + len(closer), None
+            continue
+        token = tokens.search(text, position)
+        if not token:
+            break
+        found = token.group()
+        if found.startswith('=') and found in closers and text[:token.start()].strip():
+            # =begin and =pod open a comment only at the start of a line
+            code += text[position:token.end()]
+            position = token.end()
+            continue
+        code += text[position:token.start()]
+        if found in markers:
+            return code, None
+        closer = closers.get(found)
+        code += ' ' * len(found)
+        position = token.end()
+    return code + text[position:], closer
+
+
+def code_lines(file_name: str, lines: List[str]) -> List[str]:
+    """The lines with string literals and comments blanked out (columns kept): the code a name can be used in."""
+    markers, blocks = comment_syntax(file_name)
+    # Rust's single quotes are char literals or lifetimes ('a), never strings
+    rust = file_name.lower().endswith('.rs')
+    strings, quotes = (_RUST_STRING, ('"""',)) if rust else (_STRING, _TRIPLE_QUOTES)
+    closers = {**{quote: quote for quote in quotes}, **dict(blocks)}
+    # Block openers before line markers, so Lua's --[[ is not read as a -- comment
+    tokens = re.compile('|'.join([re.escape(opener) for opener in closers] + [strings.pattern]
+                                 + [re.escape(marker) for marker in markers]))
+    result = []
+    closer = None
+    for text in lines:
+        code, closer = _code(text, tokens, markers, closers, closer)
+        result.append(code)
+    return result
+
+
+def find_references(file_name: str, lines: List[str], structure: Dict[str, List[Dict[str, Any]]],
+                    pattern: Pattern) -> List[Dict[str, Any]]:
+    """Uses of a name in one file: {'line', 'column', 'kind', 'text'} plus 'symbol', the enclosing function.
+
+    kind is 'definition' on a line where structure defines the name,
+    'import' on an import line and 'reference' elsewhere.
+    """
+    word = pattern.pattern
+    definitions = {item['line'] for category, items in structure.items() if category != 'imports'
+                   for item in items if isinstance(item.get('line'), int) and item.get('name')
+                   and re.search(word, str(item['name']))}
+    imports = {item['line'] for item in structure.get('imports', []) if isinstance(item.get('line'), int)}
+
+    references = []
+    for number, (text, code) in enumerate(zip(lines, code_lines(file_name, lines)), 1):
+        match = pattern.search(code)
+        if not match:
+            continue
+        stripped = text.strip()
+        kind = 'definition' if number in definitions else 'import' if number in imports else 'reference'
+        reference = {'line': number, 'column': match.start() + 1, 'kind': kind, 'text': stripped}
+        enclosing = [item for item in enclosing_symbols(structure, number, number) if item['line'] != number]
+        if enclosing:
+            reference['symbol'] = enclosing[-1]['name']
+        references.append(reference)
+    return references
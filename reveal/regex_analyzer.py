@@ -0,0 +1,254 @@
+"""Line-pattern based analyzer for languages without a tree-sitter grammar."""
+
+import re
+from typing import Dict, List, Any, Optional, Pattern, Tuple
+from .base import FileAnalyzer
+
+
+class RegexAnalyzer(FileAnalyzer):
+    """Base class for regex-based analyzers.
+
+    Only for languages tree-sitter-languages doesn't ship a grammar for
+    (Swift, Zig, Dart, Nim, Crystal, Solidity, PowerShell, F#, and the
+    Protobuf/Thrift/Avro schema formats). A language with a bundled
+    grammar subclasses TreeSitterAnalyzer instead, so it gets
+    error-tolerant parsing and the shared extraction. Subclasses declare
+    line patterns; the base class handles matching, block ends, slicing
+    and element extraction.
+
+    Subclass sets:
+        patterns (dict): category -> compiled regex, matched against each
+            line in order (first match wins). Named groups:
+            - name: element name (entries without it show `content`)
+            - signature: optional, appended to the name in output
+            - any other group: stored on the entry when it matched
+        block_style (str): how a definition's end line is found:
+            'braces' - matching { } (C-family)
+            'end'    - block_open / block_close keyword counting
+            'indent' - next line at the same or lower indentation
+            None     - single-line entries
+        comment_prefixes (tuple): lines starting with these are skipped
+
+    Usage:
+        @register('.swift', name='Swift', icon='')
+        class SwiftAnalyzer(RegexAnalyzer):
+            patterns = {
+                'functions': re.compile(r'^\\s*func\\s+(?P<name>\\w+)(?P<signature>\\(.*?\\))'),
+            }
+    """
+
+    patterns: Dict[str, Pattern] = {}
+    block_style: Optional[str] = 'braces'
+    comment_prefixes: Tuple[str, ...] = ('//',)
+
+    # For block_style = 'end': lines opening and closing a block
+    block_open: Optional[Pattern] = None
+    block_close: Optional[Pattern] = re.compile(r'^\s*end\b')
+
+    # Categories whose entries get a line_count metric
+    function_categories: Tuple[str, ...] = ('functions', 'methods')
+
+    def get_structure(self, head: int = None, tail: int = None,
+                      range: tuple = None, **kwargs) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract structure by matching patterns line by line.
+
+        Slicing applies to each category independently, as in
+        TreeSitterAnalyzer.
+        """
+        structure = {category: [] for category in self.patterns}
+
+        for i, line in enumerate(self.lines, 1):
+            if self._is_comment(line):
+                continue
+
+            for category, pattern in self.patterns.items():
+                match = pattern.match(line)
+                if not match:
+                    continue
+
+                entry = self._make_entry(category, match, i)
+                if entry is not None:
+                    structure[category].append(entry)
+                break
+
+        structure.update(self._extract_language_specific())
+
+        if head or tail or range:
+            for category in structure:
+                structure[category] = self._apply_semantic_slice(
+                    structure[category], head, tail, range
+                )
+
+        return {k: v for k, v in structure.items() if v}
+
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract categories that need more than a line pattern.
+
+        Override in subclasses (e.g., multi-line constructs).
+        """
+        return {}
+
+    def _make_entry(self, category: str, match, line_no: int) -> Optional[Dict[str, Any]]:
+        """Build an entry from a pattern match.
+
+        Override to post-process entries; return None to drop a match.
+        """
+        groups = {k: v for k, v in match.groupdict().items() if v is not None}
+        entry = {'line': line_no}
+
+        name = groups.pop('name', None)
+        if name:
+            entry['name'] = name.strip()
+        else:
+            entry['content'] = groups.pop('content', self.lines[line_no - 1]).strip()
+
+        signature = groups.pop('signature', None)
+        if signature:
+            entry['signature'] = signature.rstrip()
+
+        entry.update({k: v.strip() for k, v in groups.items()})
+
+        if name and self.block_style:
+            line_end = self._find_block_end(line_no)
+            if line_end > line_no:
+                entry['line_end'] = line_end
+                if category in self.function_categories:
+                    entry['line_count'] = line_end - line_no + 1
+
+        return entry
+
+    def _is_comment(self, line: str) -> bool:
+        """Check whether a line is a comment."""
+        stripped = line.lstrip()
+        return bool(self.comment_prefixes) and stripped.startswith(self.comment_prefixes)
+
+    def _find_block_end(self, line_no: int) -> int:
+        """Find the last line of the definition starting at line_no (1-indexed)."""
+        if self.block_style == 'braces':
+            return self._find_brace_end(line_no)
+        if self.block_style == 'end':
+            return self._find_keyword_end(line_no)
+        if self.block_style == 'indent':
+            return self._find_indent_end(line_no)
+        return line_no
+
+    def _find_brace_end(self, line_no: int) -> int:
+        """Match braces from the definition line.
+
+        The body must open on the definition line, on a continuation of
+        it (unclosed parentheses), or on a following line starting with
+        '{'. Otherwise - e.g. a protocol requirement or a prototype
+        ending in ';' - the definition is a single line.
+        """
+        depth = 0
+        parens = 0
+        opened = False
+
+        for i in range(line_no - 1, len(self.lines)):
+            for char in self._strip_strings(self.lines[i]):
+                if char == '{':
+                    depth += 1
+                    opened = True
+                elif char == '}':
+                    depth -= 1
+                    if opened and depth <= 0:
+                        return i + 1
+                elif char == ';' and not opened:
+                    return line_no
+                elif char in '([':
+                    parens += 1
+                elif char in ')]':
+                    parens -= 1
+
+            if not opened and parens <= 0:
+                following = self.lines[i + 1].lstrip() if i + 1 < len(self.lines) else ''
+                if not following.startswith('{'):
+                    return line_no
+
+        return len(self.lines) if opened else line_no
+
+    def _find_keyword_end(self, line_no: int) -> int:
+        """Count block_open / block_close keywords (Ruby-style `end`)."""
+        depth = 0
+
+        for i in range(line_no - 1, len(self.lines)):
+            line = self.lines[i]
+            if self._is_comment(line):
+                continue
+            if self.block_open is not None and self.block_open.match(line):
+                depth += 1
+            if self.block_close is not None and self.block_close.match(line):
+                depth -= 1
+                if depth <= 0:
+                    return i + 1
+
+        return line_no
+
+    def _find_indent_end(self, line_no: int) -> int:
+        """Last line before the next non-blank line at the same or lower indent."""
+        start = self.lines[line_no - 1]
+        indent = len(start) - len(start.lstrip())
+        last = line_no
+
+        for i in range(line_no, len(self.lines)):
+            line = self.lines[i]
+            if not line.strip():
+                continue
+            if len(line) - len(line.lstrip()) <= indent:
+                break
+            last = i + 1
+
+        return last
+
+    def _line_depths(self) -> List[int]:
+        """Brace depth at the start of each line."""
+        depths = []
+        depth = 0
+        in_block_comment = False
+
+        for line in self.lines:
+            depths.append(depth)
+            code = self._strip_strings(line)
+            if in_block_comment:
+                if '*/' not in code:
+                    continue
+                code = code.split('*/', 1)[1]
+                in_block_comment = False
+            if '/*' in code:
+                before, _, after = code.partition('/*')
+                code = before
+                in_block_comment = '*/' not in after
+            depth += code.count('{') - code.count('}')
+
+        return depths
+
+    @staticmethod
+    def _strip_strings(line: str) -> str:
+        """Remove string literals and // comments so braces inside them don't count."""
+        line = re.sub(r'"(?:\\.|[^"\\])*"', '""', line)
+        line = re.sub(r"'(?:\\.|[^'\\])*'", "''", line)
+        return line.split('//', 1)[0]
+
+    def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
+        """Extract an element by name using the structure's line ranges.
+
+        Prefers the category matching element_type ('function' ->
+        'functions'), then any other category.
+        """
+        structure = self.get_structure()
+        preferred = element_type + ('es' if element_type.endswith(('s', 'x')) else 's')
+        categories = sorted(structure, key=lambda c: c != preferred)
+
+        for category in categories:
+            for item in structure[category]:
+                if item.get('name') == name:
+                    line_start = item['line']
+                    line_end = item.get('line_end', line_start)
+                    return {
+                        'name': name,
+                        'line_start': line_start,
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[line_start - 1:line_end]),
+                    }
+
+        return super().extract_element(element_type, name)
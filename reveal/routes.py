@@ -0,0 +1,186 @@
+"""HTTP routes: route registrations of common web frameworks, as METHOD + path + handler.
+
+Detection is by the registration idioms of each framework, line by line:
+Flask and FastAPI decorators (@app.route, @router.get), Django urlpatterns
+(path, re_path, url), Express (app.get('/x', handler)), Gin/Echo
+(r.GET), chi (r.Get) and net/http (HandleFunc, including Go 1.22
+"GET /path" patterns and gorilla/mux .Methods). Client calls like
+axios.get('/api') are not routes.
+"""
+
+import re
+from typing import Any, Dict, List, Tuple
+
+
+PYTHON_SUFFIXES = ('.py',)
+JS_SUFFIXES = ('.js', '.mjs', '.cjs', '.jsx', '.ts', '.tsx')
+GO_SUFFIXES = ('.go',)
+ROUTE_SUFFIXES = PYTHON_SUFFIXES + JS_SUFFIXES + GO_SUFFIXES
+
+HTTP_METHODS = ('GET', 'POST', 'PUT', 'PATCH', 'DELETE', 'HEAD', 'OPTIONS')
+
+_PY_DECORATOR = re.compile(
+    r'^\s*@(?P<owner>[\w.]+)\.(?P<verb>route|api_route|get|post|put|patch|delete|head|options|websocket)'
+    r'\(\s*[rRuU]?(?P<q>["\'])(?P<path>.*?)(?P=q)(?P<rest>.*)')
+_PY_DEF = re.compile(r'^\s*(?:async\s+)?def\s+(?P<name>\w+)')
+_PY_METHODS = re.compile(r'methods\s*=\s*[\[(](?P<methods>[^\])]*)[\])]')
+_DJANGO = re.compile(r'^\s*(?:path|re_path|url)\(\s*[rRuU]?(?P<q>["\'])(?P<path>.*?)(?P=q)\s*,\s*(?P<rest>.*)')
+
+_JS_ROUTE = re.compile(r'(?<![\w$.])(?P<owner>[\w$]+)\.(?P<verb>get|post|put|patch|delete|head|options|all)'
+                       r'\(\s*(?P<q>["\'`])(?P<path>/.*?)(?P=q)\s*(?:,(?P<rest>.*))?')
+# HTTP clients and test agents use the same verbs for requests
+_JS_CLIENTS = {'axios', 'request', 'superagent', 'supertest', 'agent', 'client', 'http', 'https', 'api', 'fetch',
+               'ky', 'got', 'cy', '$', 'this', 'instance', 'service'}
+
+_GO_VERB = re.compile(r'(?<![\w.])(?P<owner>\w+)\.(?P<verb>GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS|Any|'
+                      r'Get|Post|Put|Patch|Delete|Head|Options)\(\s*"(?P<path>[^"]*)"\s*,\s*(?P<rest>.*)')
+_GO_HANDLE = re.compile(r'(?<![\w.])(?P<owner>\w+)\.(?:HandleFunc|Handle)\(\s*"(?P<first>[^"]*)"\s*,\s*'
+                        r'(?:"(?P<second>[^"]*)"\s*,\s*)?(?P<rest>.*)')
+_GO_METHODS = re.compile(r'\.Methods\((?P<methods>[^)]*)\)')
+
+_STRING = re.compile(r'["\']([^"\']*)["\']')
+_HANDLER_NAME = re.compile(r'^[\w$.]+$')
+
+
+def split_arguments(text: str) -> List[str]:
+    """Top-level comma-separated arguments of a call's remaining text, up to its closing parenthesis.
+
+    Commas and brackets inside string literals do not count.
+    """
+    arguments, depth, current, quote, escaped = [], 0, '', None, False
+    for char in text:
+        if quote:
+            current += char
+            if escaped:
+                escaped = False
+            elif char == '\\':
+                escaped = True
+            elif char == quote:
+                quote = None
+            continue
+        if char in '"\'`':
+            quote = char
+        elif char in '([{':
+            depth += 1
+        elif char in ')]}':
+            if depth == 0:
+                break
+            depth -= 1
+        elif char == ',' and depth == 0:
+            arguments.append(current.strip())
+            current = ''
+            continue
+        current += char
+    if current.strip():
+        arguments.append(current.strip())
+    return arguments
+
+
+def _handler(arguments: List[str]) -> str:
+    """The handler among route arguments: the last one, by name, or '(inline)' for a function literal."""
+    positional = [argument for argument in arguments if not re.match(r'^\w+\s*=', argument)]
+    if not positional:
+        return '(inline)'
+    handler = positional[-1]
+    wrapped = re.match(r'^(?:http\.HandlerFunc|[\w.]+\.as_view)\((?P<inner>[\w.]*)\)$', handler)
+    if wrapped:
+        handler = wrapped.group('inner') or handler
+    if handler.endswith('.as_view()'):
+        return handler[:-len('.as_view()')]
+    if handler.startswith('include('):
+        return handler
+    return handler if _HANDLER_NAME.match(handler) else '(inline)'
+
+
+def _methods(text: str) -> List[str]:
+    return [method.upper() for method in _STRING.findall(text)]
+
+
+def _python_routes(lines: List[str]) -> List[Dict[str, Any]]:
+    content = '\n'.join(lines)
+    framework = 'fastapi' if re.search(r'^\s*(?:from|import)\s+fastapi\b', content, re.MULTILINE) else 'flask'
+    django = bool(re.search(r'^\s*(?:from|import)\s+django\b', content, re.MULTILINE))
+    routes = []
+    for number, text in enumerate(lines, 1):
+        match = _PY_DECORATOR.match(text)
+        if match:
+            verb = match.group('verb')
+            if verb in ('route', 'api_route'):
+                listed = _PY_METHODS.search(match.group('rest'))
+                methods = _methods(listed.group('methods')) if listed else ['GET']
+            else:
+                methods = ['WS' if verb == 'websocket' else verb.upper()]
+            handler = next((found.group('name') for found in map(_PY_DEF.match, lines[number:number + 20]) if found),
+                           '(unknown)')
+            routes.extend({'method': method, 'path': match.group('path'), 'handler': handler, 'line': number,
+                           'framework': framework} for method in methods)
+            continue
+        match = _DJANGO.match(text) if django else None
+        if match:
+            routes.append({'method': 'ANY', 'path': match.group('path'),
+                           'handler': _handler(split_arguments(match.group('rest'))), 'line': number,
+                           'framework': 'django'})
+    return routes
+
+
+def _js_routes(lines: List[str]) -> List[Dict[str, Any]]:
+    routes = []
+    for number, text in enumerate(lines, 1):
+        for match in _JS_ROUTE.finditer(text):
+            if match.group('owner') in _JS_CLIENTS:
+                continue
+            verb = match.group('verb').upper()
+            routes.append({'method': 'ANY' if verb == 'ALL' else verb, 'path': match.group('path'),
+                           'handler': _handler(split_arguments(match.group('rest') or '')), 'line': number,
+                           'framework': 'express'})
+    return routes
+
+
+def _go_routes(lines: List[str]) -> List[Dict[str, Any]]:
+    routes = []
+    for number, text in enumerate(lines, 1):
+        match = _GO_VERB.search(text)
+        if match:
+            verb = match.group('verb')
+            routes.append({'method': 'ANY' if verb == 'Any' else verb.upper(), 'path': match.group('path'),
+                           'handler': _handler(split_arguments(match.group('rest'))), 'line': number,
+                           'framework': 'gin' if verb.isupper() or verb == 'Any' else 'chi'})
+            continue
+        match = _GO_HANDLE.search(text)
+        if not match:
+            continue
+        framework = 'net/http'
+        if match.group('second') is not None:
+            # gin's r.Handle("GET", "/path", handler)
+            methods, path, framework = [match.group('first').upper()], match.group('second'), 'gin'
+        else:
+            method, _, path = match.group('first').rpartition(' ')
+            listed = _GO_METHODS.search(match.group('rest'))
+            methods = [method.upper()] if method else _methods(listed.group('methods')) if listed else ['ANY']
+        handler = _handler(split_arguments(match.group('rest')))
+        routes.extend({'method': method, 'path': path, 'handler': handler, 'line': number,
+                       'framework': framework} for method in methods)
+    return routes
+
+
+def find_routes(file_name: str, lines: List[str]) -> List[Dict[str, Any]]:
+    """Route registrations in a file: {'method', 'path', 'handler', 'line', 'framework'}.
+
+    method is ANY when the route accepts every method (Django, app.all,
+    a net/http pattern without one); handler is '(inline)' for a function
+    literal.
+    """
+    suffix = '.' + file_name.rsplit('.', 1)[-1].lower() if '.' in file_name else ''
+    if suffix in PYTHON_SUFFIXES:
+        return _python_routes(lines)
+    if suffix in JS_SUFFIXES:
+        return _js_routes(lines)
+    if suffix in GO_SUFFIXES:
+        return _go_routes(lines)
+    return []
+
+
+def route_sort_key(route: Dict[str, Any]) -> Tuple[str, int, str]:
+    """Order routes by path, then method in conventional order."""
+    method = route['method']
+    return (route['path'], HTTP_METHODS.index(method) if method in HTTP_METHODS else len(HTTP_METHODS), method)
@@ -0,0 +1,58 @@
+"""C902: Function length detector.
+
+Detects functions longer than a line limit.
+Universal rule that works with reveal's structure output.
+"""
+
+import logging
+from typing import List, Dict, Any, Optional
+
+from ..base import BaseRule, Detection, RulePrefix, Severity
+from ...metrics import function_metrics
+
+logger = logging.getLogger(__name__)
+
+
+class C902(BaseRule):
+    """Detect overly long functions."""
+
+    code = "C902"
+    message = "Function is too long"
+    category = RulePrefix.C
+    severity = Severity.MEDIUM
+    file_patterns = ['*']  # Universal: works on any structured file
+    version = "1.0.0"
+
+    # Length limit in lines (the reveal hotspots default)
+    THRESHOLD = 50
+
+    def check(self,
+             file_path: str,
+             structure: Optional[Dict[str, Any]],
+             content: str) -> List[Detection]:
+        """
+        Check functions for excessive length.
+
+        Args:
+            file_path: Path to file
+            structure: Parsed structure from reveal analyzer
+            content: File content
+
+        Returns:
+            List of detections
+        """
+        if not structure:
+            return []
+        return [self.function_detection(file_path, function)
+                for function in function_metrics(content.splitlines(), structure)
+                if function['lines'] > self.THRESHOLD]
+
+    def function_detection(self, file_path: str, function: Dict[str, Any], limit: int = THRESHOLD) -> Detection:
+        """Detection for a function_metrics entry over limit lines."""
+        return self.create_detection(
+            file_path=file_path,
+            line=function['line'],
+            message=f"{self.message}: {function['name']} ({function['lines']} lines, max: {limit})",
+            suggestion="Split into smaller functions",
+            context=f"Function: {function['name']}",
+        )
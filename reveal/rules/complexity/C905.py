@@ -0,0 +1,58 @@
+"""C905: Nesting depth detector.
+
+Detects functions whose control blocks nest deeper than a limit.
+Universal rule that works with reveal's structure output.
+"""
+
+import logging
+from typing import List, Dict, Any, Optional
+
+from ..base import BaseRule, Detection, RulePrefix, Severity
+from ...metrics import function_metrics
+
+logger = logging.getLogger(__name__)
+
+
+class C905(BaseRule):
+    """Detect deeply nested functions."""
+
+    code = "C905"
+    message = "Nesting depth too high"
+    category = RulePrefix.C
+    severity = Severity.MEDIUM
+    file_patterns = ['*']  # Universal: works on any structured file
+    version = "1.0.0"
+
+    # Nesting limit (the reveal hotspots default)
+    THRESHOLD = 4
+
+    def check(self,
+             file_path: str,
+             structure: Optional[Dict[str, Any]],
+             content: str) -> List[Detection]:
+        """
+        Check functions for deep nesting.
+
+        Args:
+            file_path: Path to file
+            structure: Parsed structure from reveal analyzer
+            content: File content
+
+        Returns:
+            List of detections
+        """
+        if not structure:
+            return []
+        return [self.function_detection(file_path, function)
+                for function in function_metrics(content.splitlines(), structure)
+                if function['nesting'] > self.THRESHOLD]
+
+    def function_detection(self, file_path: str, function: Dict[str, Any], limit: int = THRESHOLD) -> Detection:
+        """Detection for a function_metrics entry nested deeper than limit."""
+        return self.create_detection(
+            file_path=file_path,
+            line=function['line'],
+            message=f"{self.message}: {function['name']} (depth: {function['nesting']}, max: {limit})",
+            suggestion="Return early or extract the inner blocks into functions",
+            context=f"Function: {function['name']}",
+        )
@@ -0,0 +1,81 @@
+"""E901: Syntax error detector.
+
+Reports the regions a tree-sitter grammar could not parse (ERROR and
+MISSING nodes). Applies to files whose analyzer is tree-sitter based.
+"""
+
+import logging
+from typing import List, Dict, Any, Optional
+
+from ..base import BaseRule, Detection, RulePrefix, Severity
+
+logger = logging.getLogger(__name__)
+
+
+class E901(BaseRule):
+    """Detect syntax errors reported by the tree-sitter parser."""
+
+    code = "E901"
+    message = "Syntax error"
+    category = RulePrefix.E
+    severity = Severity.HIGH
+    file_patterns = ['*']  # Universal: any file with a tree-sitter analyzer
+    version = "1.0.0"
+
+    # Errors reported per file; past the first few they are mostly follow-on errors
+    MAX_ERRORS = 10
+
+    def check(self,
+             file_path: str,
+             structure: Optional[Dict[str, Any]],
+             content: str) -> List[Detection]:
+        """
+        Check for regions the parser could not make sense of.
+
+        Args:
+            file_path: Path to file
+            structure: Parsed structure (not used)
+            content: File content
+
+        Returns:
+            List of detections
+        """
+        from ...base import get_analyzer
+        from ...treesitter import TreeSitterAnalyzer
+
+        analyzer_class = get_analyzer(file_path, allow_fallback=False)
+        if analyzer_class is None or not issubclass(analyzer_class, TreeSitterAnalyzer):
+            return []
+        try:
+            tree = analyzer_class(file_path).tree
+        except Exception as e:
+            logger.debug(f"E901: could not parse {file_path}: {e}")
+            return []
+        if tree is None or not tree.root_node.has_error:
+            return []
+
+        lines = content.splitlines()
+        detections = []
+        for node in self._error_nodes(tree.root_node)[:self.MAX_ERRORS]:
+            row, column = node.start_point
+            message = f"{self.message}: missing {node.type}" if node.is_missing else self.message
+            detections.append(self.create_detection(
+                file_path=file_path,
+                line=row + 1,
+                message=message,
+                column=column + 1,
+                context=lines[row].strip() if row < len(lines) else None,
+            ))
+
+        return detections
+
+    def _error_nodes(self, node) -> List:
+        """Outermost ERROR nodes and MISSING tokens, in file order."""
+        if node.type == 'ERROR' or node.is_missing:
+            return [node]
+        if not node.has_error:
+            return []
+        found = []
+        for child in node.children:
+            found.extend(self._error_nodes(child))
+        return found
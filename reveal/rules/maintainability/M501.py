@@ -0,0 +1,51 @@
+"""M501: TODO/FIXME comment detector.
+
+Reports TODO, FIXME, HACK and XXX markers in comments as tracked debt.
+Universal rule that works on any text file.
+"""
+
+import logging
+from typing import List, Dict, Any, Optional
+
+from ..base import BaseRule, Detection, RulePrefix, Severity
+from ...todos import find_todos, todo_pattern
+
+logger = logging.getLogger(__name__)
+
+
+class M501(BaseRule):
+    """Detect TODO/FIXME/HACK/XXX comments."""
+
+    code = "M501"
+    message = "TODO comment"
+    category = RulePrefix.M
+    severity = Severity.LOW
+    file_patterns = ['*']  # Universal: works on any file
+    version = "1.0.0"
+
+    def check(self,
+             file_path: str,
+             structure: Optional[Dict[str, Any]],
+             content: str) -> List[Detection]:
+        """
+        Check for marker comments.
+
+        Args:
+            file_path: Path to file
+            structure: Parsed structure (not used)
+            content: File content
+
+        Returns:
+            List of detections
+        """
+        return [self.todo_detection(file_path, todo) for todo in find_todos(content.splitlines(), todo_pattern(), file_path)]
+
+    def todo_detection(self, file_path: str, todo: Dict[str, Any]) -> Detection:
+        """Detection for a find_todos entry."""
+        owner = f"({todo['owner']})" if todo.get('owner') else ''
+        return self.create_detection(
+            file_path=file_path,
+            line=todo['line'],
+            message=f"{todo['tag']}{owner}: {todo['text']}",
+            column=todo.get('column', 1),
+        )
@@ -0,0 +1,55 @@
+"""S301: Hardcoded secret detector.
+
+Detects credentials committed to source (AWS keys, private keys, tokens,
+.env credentials) with the patterns of reveal secrets. Values are
+redacted in the reported context.
+"""
+
+import logging
+from pathlib import Path
+from typing import List, Dict, Any, Optional
+
+from ..base import BaseRule, Detection, RulePrefix, Severity
+from ...secrets import find_secrets
+
+logger = logging.getLogger(__name__)
+
+
+class S301(BaseRule):
+    """Detect hardcoded secrets."""
+
+    code = "S301"
+    message = "Hardcoded secret"
+    category = RulePrefix.S
+    severity = Severity.HIGH
+    file_patterns = ['*']  # Universal: works on any text file
+    version = "1.0.0"
+
+    def check(self,
+             file_path: str,
+             structure: Optional[Dict[str, Any]],
+             content: str) -> List[Detection]:
+        """
+        Check for high-confidence secrets.
+
+        Args:
+            file_path: Path to file
+            structure: Parsed structure (not used)
+            content: File content
+
+        Returns:
+            List of detections
+        """
+        return [self.secret_detection(file_path, secret)
+                for secret in find_secrets(Path(file_path).name, content.splitlines())]
+
+    def secret_detection(self, file_path: str, secret: Dict[str, Any]) -> Detection:
+        """Detection for a find_secrets entry; the context is its redacted line."""
+        return self.create_detection(
+            file_path=file_path,
+            line=secret['line'],
+            message=f"{self.message}: {secret['kind']}",
+            column=secret['column'],
+            suggestion="Move the value to the environment or a secret store, and rotate it",
+            context=secret['text'],
+        )
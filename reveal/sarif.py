@@ -0,0 +1,111 @@
+"""SARIF 2.1.0 logs from detections (GitHub code scanning and other SARIF consumers).
+
+Used for --check results and for the secrets, todos and hotspots
+commands, whose findings are reported under rules S301, M501 and
+C901/C902/C905.
+"""
+
+from pathlib import Path
+from typing import Any, Dict, Iterable, List, Tuple
+
+from . import __version__
+from .rules import RuleRegistry
+from .rules.base import Detection, Severity
+
+
+SARIF_SCHEMA = 'https://json.schemastore.org/sarif-2.1.0.json'
+SARIF_VERSION = '2.1.0'
+
+# Severity -> SARIF result level
+LEVELS = {
+    Severity.LOW: 'note',
+    Severity.MEDIUM: 'warning',
+    Severity.HIGH: 'error',
+    Severity.CRITICAL: 'error',
+}
+
+
+def sarif_log(detections: Iterable[Detection], errors: Iterable[Tuple[str, str]] = ()) -> Dict[str, Any]:
+    """One-run SARIF log for detections; errors are (file, message) pairs for files that could not be analyzed.
+
+    Rules that fired are described in tool.driver.rules; results point at
+    them by id and index.
+    """
+    detections = list(detections)
+    rules: List[Dict[str, Any]] = []
+    rule_index: Dict[str, int] = {}
+    for code in sorted({d.rule_code for d in detections}):
+        rule_index[code] = len(rules)
+        rules.append(_rule_descriptor(code))
+
+    results = [_result(d, rule_index[d.rule_code])
+               for d in sorted(detections, key=lambda d: (_uri(d.file_path), d.line, d.column, d.rule_code))]
+
+    invocation: Dict[str, Any] = {'executionSuccessful': True}
+    notifications = [{'level': 'error', 'message': {'text': message},
+                      'locations': [{'physicalLocation': {'artifactLocation': {'uri': _uri(file_path)}}}]}
+                     for file_path, message in errors]
+    if notifications:
+        invocation['toolExecutionNotifications'] = notifications
+
+    return {
+        '$schema': SARIF_SCHEMA,
+        'version': SARIF_VERSION,
+        'runs': [{
+            'tool': {'driver': {
+                'name': 'reveal',
+                'version': __version__,
+                'informationUri': 'https://github.com/scottsen/reveal',
+                'rules': rules,
+            }},
+            'invocations': [invocation],
+            'results': results,
+        }],
+    }
+
+
+def _rule_descriptor(code: str) -> Dict[str, Any]:
+    rule = RuleRegistry.get_rule(code)
+    if rule is None:
+        return {'id': code}
+    descriptor = {
+        'id': code,
+        'name': rule.__name__,
+        'shortDescription': {'text': rule.message or code},
+        'defaultConfiguration': {'level': LEVELS.get(rule.severity, 'warning')},
+        'properties': {'severity': rule.severity.value},
+    }
+    doc = (rule.__doc__ or '').strip()
+    if doc:
+        descriptor['fullDescription'] = {'text': doc.splitlines()[0]}
+    if rule.category:
+        descriptor['properties']['category'] = rule.category.value
+    return descriptor
+
+
+def _result(detection: Detection, index: int) -> Dict[str, Any]:
+    region: Dict[str, Any] = {'startLine': max(detection.line, 1), 'startColumn': max(detection.column, 1)}
+    if detection.context:
+        region['snippet'] = {'text': detection.context}
+    result = {
+        'ruleId': detection.rule_code,
+        'ruleIndex': index,
+        'level': LEVELS.get(detection.severity, 'warning'),
+        'message': {'text': detection.message},
+        'locations': [{'physicalLocation': {
+            'artifactLocation': {'uri': _uri(detection.file_path)},
+            'region': region,
+        }}],
+    }
+    if detection.suggestion:
+        result['properties'] = {'suggestion': detection.suggestion}
+    return result
+
+
+def _uri(file_path: str) -> str:
+    """Relative paths as posix URI references (resolved against the checkout); absolute ones as file:// URIs."""
+    path = Path(file_path)
+    if path.is_absolute():
+        return path.as_uri()
+    uri = path.as_posix()
+    return uri[2:] if uri.startswith('./') else uri
@@ -0,0 +1,95 @@
+"""Secrets in source: credentials matched by high-confidence patterns, reported with values redacted.
+
+Patterns are specific token formats (AWS key ids, GitHub and Slack
+tokens, private key blocks, passwords in URLs) rather than entropy
+guesses, plus sensitive-looking keys with literal values in .env files.
+Obvious placeholders (EXAMPLE, changeme, ${VAR}, <token>) are not reported.
+"""
+
+import re
+from typing import Any, Dict, List, Pattern, Tuple
+
+
+# (kind, pattern): the 'secret' group is what gets redacted
+SECRET_PATTERNS: List[Tuple[str, Pattern]] = [
+    ('private key', re.compile(r'-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----')),
+    ('AWS access key id', re.compile(r'\b(?P<secret>(?:AKIA|ASIA)[0-9A-Z]{16})\b')),
+    ('AWS secret access key', re.compile(
+        r'aws_?secret_?access_?key["\']?\s*[=:]\s*["\']?(?P<secret>[A-Za-z0-9/+=]{40})(?![A-Za-z0-9/+=])',
+        re.IGNORECASE)),
+    ('GitHub token', re.compile(r'\b(?P<secret>gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b')),
+    ('Slack token', re.compile(r'\b(?P<secret>xox[abposr]-[A-Za-z0-9-]{10,})\b')),
+    ('Stripe live key', re.compile(r'\b(?P<secret>[sr]k_live_[0-9A-Za-z]{20,})\b')),
+    ('Google API key', re.compile(r'\b(?P<secret>AIza[0-9A-Za-z_\-]{35})(?![0-9A-Za-z_\-])')),
+    ('password in URL', re.compile(r'\b[a-z][a-z0-9+.\-]*://[^/\s:@"\']+:(?P<secret>[^/\s:@"\']+)@')),
+]
+
+# .env, .env.local, prod.env
+_ENV_FILE = re.compile(r'^(?:\.env(?:\..+)?|.+\.env)$')
+_ENV_ASSIGNMENT = re.compile(r'^\s*(?:export\s+)?(?P<key>[A-Za-z_][\w.]*)\s*=\s*(?P<value>.*?)\s*$')
+_SENSITIVE_KEY = re.compile(
+    r'passw(?:or)?d|passwd|secret|token|api[_-]?key|private[_-]?key|access[_-]?key|credential|auth(?!or)',
+    re.IGNORECASE
+)
+_PLACEHOLDER = re.compile(r'example|sample|dummy|changeme|change_me|your[_-]|placeholder|x{4,}|\*{3,}|<[^>]*>|'
+                          r'\$\{|\$\(|\{\{|^\$\w+$|^(?:true|false|none|null)$', re.IGNORECASE)
+
+
+def is_env_file(name: str) -> bool:
+    """.env-style file: KEY=value lines whose sensitive values are secrets by themselves."""
+    return bool(_ENV_FILE.match(name))
+
+
+def redact(value: str) -> str:
+    """First characters of a secret and a fixed mask, so neither the value nor its length shows."""
+    return value[:4] + '*' * 8 if len(value) > 8 else '*' * 8
+
+
+def _env_value(value: str) -> str:
+    if len(value) >= 2 and value[0] == value[-1] and value[0] in '"\'':
+        return value[1:-1]
+    return re.sub(r'\s+#.*$', '', value)
+
+
+def find_secrets(file_name: str, lines: List[str]) -> List[Dict[str, Any]]:
+    """Secrets in a file: {'line', 'column', 'kind', 'text'}, text being the line with the value redacted.
+
+    .env files also report sensitive keys (PASSWORD, API_KEY, ...) assigned a
+    literal value, as kind 'env secret' with the key as 'key'.
+    """
+    env_file = is_env_file(file_name)
+    found = []
+    for number, text in enumerate(lines, 1):
+        hits = []
+        for kind, pattern in SECRET_PATTERNS:
+            for match in pattern.finditer(text):
+                secret = match.groupdict().get('secret')
+                if secret is not None and _PLACEHOLDER.search(secret):
+                    continue
+                span = match.span('secret') if secret is not None else match.span()
+                hits.append({'kind': kind, 'span': span, 'redact': secret is not None})
+        assignment = _ENV_ASSIGNMENT.match(text) if env_file and not hits else None
+        if assignment and _SENSITIVE_KEY.search(assignment.group('key')):
+            value = _env_value(assignment.group('value'))
+            if value and not _PLACEHOLDER.search(value):
+                start = text.index(value, assignment.start('value'))
+                hits.append({'kind': 'env secret', 'span': (start, start + len(value)), 'redact': True,
+                             'key': assignment.group('key')})
+        if not hits:
+            continue
+        # One report per stretch of text: a key inside a URL is not two secrets
+        kept = []
+        for hit in sorted(hits, key=lambda h: (h['span'][0], -h['span'][1])):
+            if not kept or hit['span'][0] >= kept[-1]['span'][1]:
+                kept.append(hit)
+        shown = text
+        for hit in reversed(kept):
+            start, end = hit['span']
+            if hit['redact']:
+                shown = shown[:start] + redact(text[start:end]) + shown[end:]
+        for hit in kept:
+            secret = {'line': number, 'column': hit['span'][0] + 1, 'kind': hit['kind'], 'text': shown.strip()}
+            if 'key' in hit:
+                secret['key'] = hit['key']
+            found.append(secret)
+    return found
@@ -0,0 +1,104 @@
+"""Tell files not worth analyzing: binary, minified, generated and oversized ones.
+
+Only the start of each file is read. A NUL byte means binary; a .js or
+.css file named *.min.* or made of very long lines is minified; a
+header line saying so (Go's "// Code generated ... DO NOT EDIT." or an
+"@generated" comment) or a code generator's file name
+(*_pb2.py, *.pb.go, *.g.dart, ...) means generated. Files over
+--max-file-size are summarized from their first and last lines instead
+of being parsed.
+"""
+
+import argparse
+import re
+from pathlib import Path
+from typing import Any, Dict, List, Optional
+
+# Bytes read from the start of a file
+SNIFF_BYTES = 8192
+# Files larger than this are summarized, not parsed (--max-file-size)
+DEFAULT_MAX_FILE_SIZE = 5 * 1024 * 1024
+# Lines shown from each end of an oversized file
+_SUMMARY_LINES = 5
+_SIZE_UNITS = {'': 1, 'B': 1, 'K': 1024, 'KB': 1024, 'M': 1024 ** 2, 'MB': 1024 ** 2, 'G': 1024 ** 3, 'GB': 1024 ** 3}
+_SIZE = re.compile(r'(\d+(?:\.\d+)?)\s*([KMG]?B?)', re.IGNORECASE)
+# Lines searched for a generated-file marker
+_HEADER_LINES = 10
+# Average line length above which JS/CSS counts as minified
+_MINIFIED_LINE_LENGTH = 200
+
+_MINIFIABLE = ('.js', '.mjs', '.cjs', '.css')
+_GENERATED_SUFFIXES = ('_pb2.py', '_pb2_grpc.py', '_pb2.pyi', '.pb.go', '.pb.cc', '.pb.h', '.pb.swift',
+                       '.g.dart', '.freezed.dart', '.designer.cs', '.generated.ts', '.generated.js')
+# Go's generated-file line (https://go.dev/s/generatedcode), or "@generated" in a comment line
+_GENERATED_MARKER = re.compile(r'^// Code generated .* DO NOT EDIT\.$|^\s*(?://+|#+|/\*+|\*|--|;+|<!--|%+).*@generated\b')
+
+
+def looks_binary(data: bytes) -> bool:
+    """Whether the leading bytes of a file are binary (contain a NUL)."""
+    return b'\0' in data[:SNIFF_BYTES]
+
+
+def _is_minified(name: str, lines: List[str]) -> bool:
+    if not name.endswith(_MINIFIABLE):
+        return False
+    if '.min.' in name:
+        return True
+    lines = [line for line in lines if line.strip()]
+    return bool(lines) and sum(len(line) for line in lines) / len(lines) > _MINIFIED_LINE_LENGTH
+
+
+def _is_generated(name: str, lines: List[str]) -> bool:
+    return name.endswith(_GENERATED_SUFFIXES) or any(_GENERATED_MARKER.match(line) for line in lines[:_HEADER_LINES])
+
+
+def skip_reason(path: Path) -> Optional[str]:
+    """'binary', 'minified' or 'generated' when the file is one, None for ordinary source (or an unreadable file)."""
+    try:
+        with open(path, 'rb') as f:
+            data = f.read(SNIFF_BYTES)
+    except OSError:
+        return None
+    if looks_binary(data):
+        return 'binary'
+    name = path.name.lower()
+    lines = data.decode('utf-8', errors='replace').splitlines()
+    # The sample may end mid-line
+    complete = lines[:-1] if len(data) == SNIFF_BYTES and len(lines) > 1 else lines
+    if _is_minified(name, complete):
+        return 'minified'
+    if _is_generated(name, lines):
+        return 'generated'
+    return None
+
+
+def parse_size(text: str) -> int:
+    """Bytes in a size such as 5MB, 512K, 1.5G or 2000 (argparse type for --max-file-size)."""
+    match = _SIZE.fullmatch(text.strip())
+    if not match:
+        raise argparse.ArgumentTypeError(f"invalid size '{text}' (e.g. 5MB, 512K, 2000; 0 = no limit)")
+    return int(float(match.group(1)) * _SIZE_UNITS[match.group(2).upper()])
+
+
+def oversized(path: Path, max_size: int) -> bool:
+    """Whether the file is larger than max_size bytes (never, when max_size is 0)."""
+    try:
+        return max_size > 0 and path.stat().st_size > max_size
+    except OSError:
+        return False
+
+
+def large_file_summary(path: Path, language: Optional[str] = None) -> Dict[str, Any]:
+    """Size, language and first/last lines of an oversized file, reading only its two ends."""
+    size = path.stat().st_size
+    with open(path, 'rb') as f:
+        head = f.read(SNIFF_BYTES)
+        f.seek(max(size - SNIFF_BYTES, len(head)))
+        tail = f.read()
+    summary = {'file': str(path), 'type': language, 'size': size, 'binary': looks_binary(head),
+               'first_lines': [], 'last_lines': []}
+    if not summary['binary']:
+        # The last line of the head and the first of the tail may be cut short
+        summary['first_lines'] = head.decode('utf-8', errors='replace').splitlines()[:-1][:_SUMMARY_LINES]
+        summary['last_lines'] = tail.decode('utf-8', errors='replace').splitlines()[1:][-_SUMMARY_LINES:]
+    return summary
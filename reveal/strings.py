@@ -0,0 +1,86 @@
+"""User-facing string literals: messages, labels and prompts a localization audit has to cover.
+
+A literal counts as user-facing when it reads like text for people: words
+separated by spaces, or a capitalized word ending in punctuation
+("Saved!"). Identifiers, keys, paths, URLs, SQL, regular expressions
+and CSS class lists are left out, as are comments, docstrings and import
+lines. Literals passed straight to a translation function (_, gettext,
+t, i18n.t, tr, NSLocalizedString, ...) are marked as translated.
+"""
+
+import re
+from typing import Any, Dict, List
+
+from .metrics import comment_syntax
+
+
+_LITERAL = re.compile(r'(?<![\w])[fFrRbBuU]{0,2}(?P<literal>"(?:\\.|[^"\\])*"|\'(?:\\.|[^\'\\])*\'|`[^`]*`)')
+_IMPORT = re.compile(r'^\s*(?:import|from|#\s*include|require|require_relative|use|using|package)\b')
+_TRIPLE = re.compile(r'"""|\'\'\'')
+_TRANSLATION = re.compile(r'(?:(?<![\w$])(?:_|_l|N_|gettext|ngettext|pgettext|ugettext|gettext_lazy|lazy_gettext|'
+                          r'ugettext_lazy|t|tr|qsTr|NSLocalizedString|\$t)|\bi18n\.t|\bintl\.formatMessage)'
+                          r'\(\s*$')
+_PLACEHOLDER = re.compile(r'%[-+ #0-9.]*[sdifrxXeEgGc%]|\{[^{}]*\}|\$\{[^{}]*\}')
+_NOT_TEXT = re.compile(r'://|^\s*/|^\s*(?:SELECT|INSERT|UPDATE|DELETE|CREATE|ALTER|DROP|WITH)\s|'
+                       r'\\[dswbDSWB]|\[\^|\(\?|^\s*<[^>]*>\s*$')
+_CSS_CLASS = re.compile(r'^[a-z0-9]+(?:[-_:][a-z0-9]+)*$')
+
+
+def is_user_facing(value: str) -> bool:
+    """Whether a literal's content reads like text meant for people."""
+    if _NOT_TEXT.search(value):
+        return False
+    text = _PLACEHOLDER.sub(' ', value).strip()
+    words = re.findall(r'[A-Za-z][A-Za-z\'-]*', text)
+    if not words:
+        return False
+    tokens = text.split()
+    if len(tokens) > 1:
+        css = all(_CSS_CLASS.match(token) for token in tokens) and re.search(r'[-_:]', text)
+        return len(words) > 1 and not css
+    return bool(re.match(r'^[A-Z][a-z]+[.!?:]$', text))
+
+
+def find_strings(file_name: str, lines: List[str]) -> List[Dict[str, Any]]:
+    """User-facing string literals in a file: {'text', 'line', 'column', 'translated'}.
+
+    text is the literal's content without quotes; translated is True when
+    it is the first argument of a translation call.
+    """
+    markers, blocks = comment_syntax(file_name)
+    strings = []
+    closer = None
+    for number, text in enumerate(lines, 1):
+        stripped = text.strip()
+        if closer is not None:
+            if closer in stripped:
+                closer = None
+            continue
+        opened = next(((open_, close) for open_, close in (*blocks, ('"""', '"""'), ("'''", "'''"))
+                       if stripped.startswith(open_)), None)
+        if opened is not None:
+            # Block comments and docstrings
+            if opened[1] not in stripped[len(opened[0]):]:
+                closer = opened[1]
+            continue
+        if (markers and stripped.startswith(markers)) or _IMPORT.match(text):
+            continue
+        if len(_TRIPLE.findall(text)) % 2:
+            closer = _TRIPLE.search(text).group()
+            continue
+        for match in _LITERAL.finditer(text):
+            before = text[:match.start()]
+            if any(marker in before for marker in markers) and _outside_strings(before, markers):
+                break
+            value = match.group('literal')[1:-1]
+            if not is_user_facing(value):
+                continue
+            strings.append({'text': value, 'line': number, 'column': match.start('literal') + 1,
+                            'translated': bool(_TRANSLATION.search(before))})
+    return strings
+
+
+def _outside_strings(before: str, markers) -> bool:
+    """Whether a comment marker occurs in code (not inside a literal) in the text before a literal."""
+    code = _LITERAL.sub(lambda m: ' ' * len(m.group()), before)
+    return any(marker in code for marker in markers)
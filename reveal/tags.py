@@ -0,0 +1,80 @@
+"""ctags-compatible tags files from reveal's structure."""
+
+import re
+from typing import Any, Dict, Iterable, List, TextIO, Tuple
+
+from . import __version__
+from .base import category_kind
+
+
+# Categories that list references or data rather than definitions
+SKIPPED_CATEGORIES = {'imports', 'exports', 'includes', 'requires', 'links', 'link_targets', 'code_blocks',
+                      'code_languages', 'columns', 'table', 'elements'}
+
+_OWNER = re.compile(r'^(?P<owner>.+?)(?:#|::|\.)(?P<name>[^#:.]+)$')
+_NAMESPACED = re.compile(r'^(?P<owner>.+)::(?P<name>[^:]+)$')
+_OWNED_CATEGORIES = ('methods', 'class_methods')
+
+
+def tag_entries(file_name: str, lines: List[str],
+                structure: Dict[str, List[Dict[str, Any]]]) -> List[Tuple[str, str, str, Dict[str, str]]]:
+    """(name, file, ex-command, fields) for each named definition in a file.
+
+    Methods reported as Owner#name, Owner.name or Owner::name are tagged as
+    name with a class: scope field; other Outer::Name definitions as Name
+    with a namespace: field.
+    """
+    entries = []
+    for category, items in structure.items():
+        if category in SKIPPED_CATEGORIES:
+            continue
+        kind = category_kind(category)
+        for item in items:
+            name = item.get('name')
+            line = item.get('line') or item.get('line_start')
+            if not name or not isinstance(line, int):
+                continue
+            fields = {'kind': item.get('kind') or kind, 'line': str(line)}
+            owned = _OWNER.match(name) if category in _OWNED_CATEGORIES else None
+            namespaced = _NAMESPACED.match(name) if not owned else None
+            if owned:
+                name = owned.group('name')
+                fields['class'] = owned.group('owner')
+            elif namespaced:
+                name = namespaced.group('name')
+                fields['namespace'] = namespaced.group('owner')
+            if item.get('signature', '').startswith('('):
+                fields['signature'] = item['signature'].split('  (')[0]
+            if item.get('visibility'):
+                fields['access'] = item['visibility']
+            source = lines[line - 1] if 0 < line <= len(lines) else ''
+            entries.append((name, file_name, _pattern(source), fields))
+    return entries
+
+
+def write_tags(entries: Iterable[Tuple[str, str, str, Dict[str, str]]], out: TextIO) -> int:
+    """Write a sorted, extended-format tags file; returns the number of tags."""
+    entries = sorted(entries, key=lambda e: (e[0].encode('utf-8'), e[1], int(e[3]['line'])))
+    out.write('!_TAG_FILE_FORMAT\t2\t/extended format; --format=1 will not append ;" to lines/\n')
+    out.write('!_TAG_FILE_SORTED\t1\t/0=unsorted, 1=sorted, 2=foldcase/\n')
+    out.write('!_TAG_PROGRAM_NAME\treveal\t//\n')
+    out.write('!_TAG_PROGRAM_URL\thttps://github.com/scottsen/reveal\t//\n')
+    out.write(f'!_TAG_PROGRAM_VERSION\t{__version__}\t//\n')
+    for name, file_name, address, fields in entries:
+        extras = '\t'.join(f'{key}:{_field(value)}' for key, value in fields.items())
+        out.write(f'{name}\t{file_name}\t{address};"\t{extras}\n')
+    return len(entries)
+
+
+def _pattern(source: str) -> str:
+    """/^line$/ search command matching the definition line exactly."""
+    escaped = source.rstrip('\r\n').replace('\\', '\\\\').replace('/', '\\/')
+    if escaped.endswith('$'):
+        escaped = escaped[:-1] + '\\$'
+    return f'/^{escaped}$/'
+
+
+def _field(value: str) -> str:
+    """Field values may not contain tabs or newlines."""
+    return str(value).replace('\\', '\\\\').replace('\t', '\\t').replace('\n', '\\n')
+
@@ -0,0 +1,96 @@
+"""Which test files exercise which source files: naming conventions plus imports.
+
+A test covers a source file when its name points at it (test_foo.py,
+foo_test.go, foo.spec.ts, FooTest.java, foo_spec.rb -> foo) or when it
+imports it. Source files are the non-test files that define functions or
+types; those no test reaches are untested.
+"""
+
+import posixpath
+import re
+from typing import Any, Dict, List, Optional, Tuple
+
+from .dependencies import build_import_graph
+from .hierarchy import HIERARCHY_CATEGORIES
+from .metrics import FUNCTION_CATEGORIES
+
+
+TEST_DIRS = {'test', 'tests', 'spec', 'specs', '__tests__', 'testing', 'testdata', 'test_data'}
+
+# File name -> the name of the file under test
+_TEST_NAMES = [
+    re.compile(r'^test_(?P<subject>.+)\.\w+$'),
+    re.compile(r'^(?P<subject>.+?)_(?:test|spec)\.\w+$'),
+    re.compile(r'^(?P<subject>.+?)\.(?:test|spec)\.\w+$'),
+    re.compile(r'^(?P<subject>.+?[a-z0-9])(?:Test|Tests|Spec)\.\w+$'),
+    re.compile(r'^Test(?P<subject>[A-Z].*)\.\w+$'),
+]
+
+
+def _key(name: str) -> str:
+    """Stem compared across naming styles: FooBar, foo_bar and foo-bar all give foobar."""
+    return re.sub(r'[_\-.]', '', name).lower()
+
+
+def _in_test_dir(path: str) -> bool:
+    return any(part in TEST_DIRS for part in posixpath.dirname(path).split('/'))
+
+
+def subject_of(path: str) -> Optional[str]:
+    """The name a test file is about (a test directory's other files: their own stem), None for non-tests."""
+    name = posixpath.basename(path)
+    for pattern in _TEST_NAMES:
+        match = pattern.match(name)
+        if match:
+            return match.group('subject')
+    if _in_test_dir(path):
+        return posixpath.splitext(name)[0]
+    return None
+
+
+def _is_source(structure: Dict[str, List[Dict[str, Any]]]) -> bool:
+    return any(structure.get(category) for category in FUNCTION_CATEGORIES + HIERARCHY_CATEGORIES)
+
+
+def _closeness(test: str, source: str) -> int:
+    """Directory names shared by a test and a source file, test directories ignored (tests/x/ mirrors src/x/)."""
+    test_dirs = [part for part in posixpath.dirname(test).split('/') if part and part not in TEST_DIRS]
+    source_dirs = [part for part in posixpath.dirname(source).split('/') if part]
+    shared = len(set(test_dirs) & set(source_dirs))
+    return shared * 2 + (posixpath.dirname(test) == posixpath.dirname(source))
+
+
+def map_tests(files: List[Tuple[str, str, Dict[str, List[Dict[str, Any]]]]]) -> Dict[str, Any]:
+    """Pair tests with sources from (relative posix path, language, structure) triples.
+
+    Returns {'tests': [{'file', 'sources': [{'file', 'via'}]}], 'sources': [...],
+    'untested': [...]}; via lists how the pair was found: 'name' and/or 'import'.
+    """
+    tests = {path: subject for path, _, _ in files for subject in [subject_of(path)] if subject is not None}
+    languages = {path: language for path, language, _ in files}
+    sources = [path for path, _, structure in files if path not in tests and _is_source(structure)]
+    by_key: Dict[str, List[str]] = {}
+    for path in sources:
+        by_key.setdefault(_key(posixpath.splitext(posixpath.basename(path))[0]), []).append(path)
+
+    graph = build_import_graph((path, structure) for path, _, structure in files)
+    source_set = set(sources)
+    mapping = []
+    covered = set()
+    for test in sorted(tests):
+        via: Dict[str, List[str]] = {}
+        candidates = by_key.get(_key(tests[test]), [])
+        same_language = [path for path in candidates if languages[path] == languages[test]]
+        candidates = same_language or candidates
+        if candidates:
+            best = max(_closeness(test, path) for path in candidates)
+            for path in candidates:
+                if _closeness(test, path) == best:
+                    via.setdefault(path, []).append('name')
+        for path in sorted(graph.edges.get(test, ())):
+            if path in source_set:
+                via.setdefault(path, []).append('import')
+        covered.update(via)
+        mapping.append({'file': test, 'sources': [{'file': path, 'via': via[path]} for path in sorted(via)]})
+
+    return {'tests': mapping, 'sources': sources, 'untested': [path for path in sources if path not in covered]}
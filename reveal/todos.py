@@ -0,0 +1,112 @@
+"""TODO/FIXME/HACK/XXX markers in comments."""
+
+import functools
+import re
+from typing import Any, Dict, Iterable, Iterator, List, Pattern, Tuple
+
+from .base import enclosing_symbols
+from .metrics import CommentSyntax, comment_syntax
+
+
+TODO_TAGS = ('TODO', 'FIXME', 'HACK', 'XXX')
+
+# Lines of unknown origin (no file name): any common comment marker counts
+_ANY_SYNTAX: CommentSyntax = (('#', '//', '--', ';', '%'), (('/*', '*/'), ('<!--', '-->')))
+# A ' * text' line continues a /* */ block comment even when its opening line is not in view
+_CONTINUATION = re.compile(r'^\s*\*(?!/)')
+_TRIPLE_QUOTES = ('"""', "'''")
+
+
+def todo_pattern(tags: Iterable[str] = TODO_TAGS) -> Pattern:
+    """'TODO(alice): text' -> tag, owner and text groups; tags are matched in upper case only.
+
+    The tag must be followed by '(owner)', ':', '!', ' - ' or whitespace and text, so prose such as
+    'TODO/FIXME comments' is not a marker.
+    """
+    alternatives = '|'.join(re.escape(tag) for tag in tags)
+    return re.compile(r'(?<![\w/])(?P<tag>' + alternatives + r')\b(?:\((?P<owner>[^)]*)\))?'
+                      r'(?:\s*[:!]|\s+-|(?<=\))|\s+(?=[^\s/]))\s*(?P<text>.*)')
+
+
+@functools.lru_cache(maxsize=None)
+def _token_pattern(syntax: CommentSyntax) -> Pattern:
+    """Block comment openers, line comment markers and (outside markup) string literals, in that order."""
+    markers, blocks = syntax
+    tokens = [re.escape(open_) for open_, _close in blocks] + [re.escape(marker) for marker in markers]
+    if markers:
+        # Markup has no line comments, and its quotes are prose rather than strings
+        tokens += ['"""', "'''", r'"(?:\\.|[^"\\])*"', r"'(?:\\.|[^'\\])*'"]
+    return re.compile('|'.join(tokens) if tokens else r'(?!)')
+
+
+def comment_spans(lines: List[str], syntax: CommentSyntax) -> Iterator[Tuple[int, int, str]]:
+    """(line, offset, text) of each comment's part on each line, skipping string literals.
+
+    Block comments and triple-quoted strings are followed across lines.
+    """
+    markers, blocks = syntax
+    closers = dict(blocks)
+    tokens = _token_pattern(syntax)
+    closer, in_comment = None, False
+    for number, text in enumerate(lines, 1):
+        position = 0
+        if closer is None and '/*' in closers and _CONTINUATION.match(text):
+            yield number, 0, text
+            continue
+        while position < len(text):
+            if closer is not None:
+                end = text.find(closer, position)
+                stop = len(text) if end < 0 else end
+                if in_comment:
+                    yield number, position, text[position:stop]
+                if end < 0:
+                    break
+                position, closer = end + len(closer), None
+                continue
+            token = tokens.search(text, position)
+            if not token:
+                break
+            found = token.group()
+            if found in closers:
+                closer, in_comment = closers[found], True
+            elif found in markers:
+                yield number, token.end(), text[token.end():]
+                break
+            elif found in _TRIPLE_QUOTES:
+                closer, in_comment = found, False
+            position = token.end()
+
+
+def find_todos(lines: List[str], pattern: Pattern, file_name: str = '') -> List[Dict[str, Any]]:
+    """Markers found in comments: {'line', 'column', 'tag', 'text'} plus 'owner' when written TODO(owner).
+
+    Comments are recognised with the comment syntax of file_name (any common syntax when it is not
+    given); every marker on a line is reported, each one's text ending where the next starts.
+    """
+    todos = []
+    syntax = comment_syntax(file_name) if file_name else _ANY_SYNTAX
+    for number, offset, comment in comment_spans(lines, syntax):
+        matches = []
+        match = pattern.search(comment)
+        while match:
+            matches.append(match)
+            match = pattern.search(comment, match.start('text'))
+        for match, following in zip(matches, matches[1:] + [None]):
+            text = match.group('text') if following is None else comment[match.start('text'):following.start()]
+            todo = {'line': number, 'column': offset + match.start() + 1, 'tag': match.group('tag'),
+                    'text': text.strip()}
+            if match.group('owner'):
+                todo['owner'] = match.group('owner').strip()
+            todos.append(todo)
+    return todos
+
+
+def file_todos(lines: List[str], structure: Dict[str, List[Dict[str, Any]]],
+               pattern: Pattern, file_name: str = '') -> List[Dict[str, Any]]:
+    """find_todos with the innermost enclosing function or class of each marker as 'symbol'."""
+    todos = find_todos(lines, pattern, file_name)
+    for todo in todos:
+        enclosing = enclosing_symbols(structure, todo['line'], todo['line'])
+        if enclosing:
+            todo['symbol'] = enclosing[-1]['name']
+    return todos
@@ -2,113 +2,223 @@
 
 import os
 from pathlib import Path
-from typing import List, Optional
+from typing import Any, Callable, Container, Dict, Iterator, List, Optional, Set, Tuple
 from .base import get_analyzer
+from .ignore import IgnoreRules
+from .sniff import DEFAULT_MAX_FILE_SIZE, oversized, skip_reason
+from .workers import ordered_map
 
 
-def show_directory_tree(path: str, depth: int = 3, show_hidden: bool = False,
-                        max_entries: int = 200, fast: bool = False) -> str:
-    """Show directory tree with file info.
+def show_directory_tree(path: str, **options: Any) -> str:
+    """Show directory tree with file info: the lines of iter_directory_tree (which takes the same options) joined."""
+    return '\n'.join(iter_directory_tree(path, **options))
+
+
+def iter_directory_tree(path: str, depth: int = 3, show_hidden: bool = False,
+                        max_entries: int = 200, fast: bool = False, jobs: int = 0, ignore: bool = True,
+                        skip_generated: bool = True, max_file_size: int = DEFAULT_MAX_FILE_SIZE,
+                        progress: Optional[Callable[[int, int], None]] = None) -> Iterator[str]:
+    """Yield the lines of a directory tree with file info as soon as each is complete.
 
     Args:
         path: Directory path
         depth: Maximum depth to traverse
         show_hidden: Whether to show hidden files/dirs
-        max_entries: Maximum entries to display (0=unlimited)
+        max_entries: Maximum entries to display (0=unlimited); when there
+            are more, the most telling ones are kept (see select_entries)
         fast: Skip expensive line counting for performance
-
-    Returns:
-        Formatted tree string
+        jobs: Files analyzed in parallel (0 = one per CPU)
+        ignore: Skip what .gitignore/.ignore and the built-in defaults exclude
+        skip_generated: List binary, minified and generated files with their
+            size and kind instead of analyzing them
+        max_file_size: List larger files with their size and language
+            instead of analyzing them (0 = no limit)
+        progress: Called with (files analyzed, files in all) after each file
+
+    Yields:
+        Formatted tree lines. Unless fast, files show code/comment line
+        counts, directories their totals, and a per-language table follows.
+        A directory's line comes once every file below it is analyzed, so
+        a large tree prints as it goes rather than all at the end.
     """
     path = Path(path)
 
     if not path.is_dir():
-        return f"Error: {path} is not a directory"
+        yield f"Error: {path} is not a directory"
+        return
+
+    rules = IgnoreRules.for_walk(path) if ignore else None
 
-    # Count total entries first for warnings
-    total_entries = _count_entries(path, depth, show_hidden)
+    # Scan the whole tree first, so the entries shown can be chosen from all of it
+    children = _scan_tree(path, depth, show_hidden, rules)
+    total_entries = sum(len(entries) for entries in children.values())
 
     lines = [f"{path.name or path}/\n"]
 
     # Warn if directory is large and user hasn't disabled limits
     if total_entries > 500 and max_entries > 0:
         lines.append(f"⚠️  Large directory detected ({total_entries} entries)")
-        lines.append(f"   Showing first {max_entries} entries (use --max-entries 0 for unlimited)")
+        lines.append(f"   Showing the {max_entries} most telling entries (use --max-entries 0 for unlimited)")
         if not fast:
             lines.append(f"   Consider using --fast to skip line counting for better performance\n")
 
-    # Track how many entries we've shown
-    context = {'count': 0, 'max_entries': max_entries, 'truncated': 0}
-    _walk_directory(path, lines, depth=depth, show_hidden=show_hidden,
-                   fast=fast, context=context)
+    context = {'children': children, 'shown': select_entries(path, children, max_entries), 'omitted': 0,
+               'files': [], 'languages': {}}
+    _walk_directory(path, lines, context)
+    emitted = 0
+    for complete in _fill_file_info(lines, context, fast, jobs, skip_generated, max_file_size, progress):
+        yield from lines[emitted:complete]
+        emitted = complete
+    lines = lines[emitted:]
 
     # Show truncation message if we hit the limit
-    if context['truncated'] > 0:
-        lines.append(f"\n... {context['truncated']} more entries (use --max-entries 0 to show all)")
+    if context['omitted'] > 0:
+        lines.append(f"\n... {context['omitted']} entries omitted in all (use --max-entries 0 to show all)")
+
+    if context['languages']:
+        lines.append('')
+        lines.extend(language_table(context['languages']))
 
     # Add navigation hint
     lines.append(f"\nUsage: reveal {path}/<file>")
 
-    return '\n'.join(lines)
+    yield from lines
 
 
-def _count_entries(path: Path, depth: int, show_hidden: bool) -> int:
-    """Count total entries in directory tree (fast, no analysis)."""
+def iter_directory_files(path: Path, depth: int = 3, show_hidden: bool = False,
+                         skip: Container[str] = (), ignore: bool = True) -> Iterator[Path]:
+    """Yield files in tree order (directories first, then by name) down to depth, skipping directories named in skip.
+
+    Unless ignore is False, what .gitignore/.ignore files and the built-in
+    defaults (node_modules, .venv, target, ...) exclude is skipped too.
+    """
+    yield from _iter_files(path, depth, show_hidden, skip, IgnoreRules.for_walk(path) if ignore else None)
+
+
+def iter_directories(path: Path, depth: int = 3, ignore: bool = True) -> Iterator[Path]:
+    """Yield path and every directory below it whose files iter_directory_files lists, empty ones included."""
+    rules = IgnoreRules.for_walk(path) if ignore else None
+    yield from _scan_tree(path, depth, False, rules)
+
+
+def _iter_files(path: Path, depth: int, show_hidden: bool, skip: Container[str],
+                rules: Optional[IgnoreRules]) -> Iterator[Path]:
     if depth <= 0:
-        return 0
+        return
 
     try:
-        entries = list(path.iterdir())
+        entries = sorted(path.iterdir(), key=lambda p: (not p.is_dir(), p.name))
     except PermissionError:
-        return 0
+        return
+
+    for entry in _visible(entries, show_hidden, rules):
+        if entry.is_file():
+            yield entry
+        elif entry.is_dir() and entry.name not in skip:
+            yield from _iter_files(entry, depth - 1, show_hidden, skip, rules and rules.descend(entry))
+
 
+def _visible(entries: List[Path], show_hidden: bool, rules: Optional[IgnoreRules]) -> List[Path]:
+    """Entries neither hidden (unless show_hidden) nor ignored by rules."""
     if not show_hidden:
         entries = [e for e in entries if not e.name.startswith('.')]
+    if rules is not None:
+        entries = [e for e in entries if not rules.ignored(e, e.is_dir())]
+    return entries
+
+
+def _scan_tree(path: Path, depth: int, show_hidden: bool, rules: Optional[IgnoreRules] = None,
+               children: Optional[Dict[Path, List[Path]]] = None) -> Dict[Path, List[Path]]:
+    """Visible entries of every directory down to depth, in tree order (directories first, then by name)."""
+    children = {} if children is None else children
+    if depth <= 0:
+        return children
+
+    try:
+        entries = sorted(path.iterdir(), key=lambda p: (not p.is_dir(), p.name))
+    except PermissionError:
+        return children
 
-    count = len(entries)
-    for entry in entries:
+    children[path] = _visible(entries, show_hidden, rules)
+    for entry in children[path]:
         if entry.is_dir():
-            count += _count_entries(entry, depth - 1, show_hidden)
+            _scan_tree(entry, depth - 1, show_hidden, rules and rules.descend(entry), children)
+    return children
 
-    return count
 
+# Files that tell what a directory is: entry points, package and build manifests
+_KEY_FILES = {'__init__.py', '__main__.py', 'setup.py', 'pyproject.toml', 'package.json', 'go.mod', 'cargo.toml',
+              'makefile', 'dockerfile', 'cmakelists.txt', 'pom.xml', 'build.gradle', 'build.gradle.kts', 'gemfile',
+              'mix.exs', 'lib.rs', 'mod.rs'}
+_KEY_STEMS = {'readme', 'main', 'index', 'app', 'server', 'cli'}
 
-def _walk_directory(path: Path, lines: List[str], prefix: str = '', depth: int = 3,
-                   show_hidden: bool = False, fast: bool = False, context: dict = None):
-    """Recursively walk directory and build tree.
+
+def _entry_rank(path: Path) -> int:
+    """How much an entry says about the tree, lowest first: directories, key files, source, the rest."""
+    if path.is_dir():
+        return 0
+    name = path.name.lower()
+    if name in _KEY_FILES or name.split('.', 1)[0] in _KEY_STEMS:
+        return 1
+    return 2 if get_analyzer(str(path), allow_fallback=False) else 3
+
+
+def select_entries(root: Path, children: Dict[Path, List[Path]], max_entries: int) -> Set[Path]:
+    """The entries to show when at most max_entries fit (0 = all), chosen level by level.
+
+    A level that fits is shown whole. Otherwise the directories of that
+    level take turns picking their most telling entry (directories, then
+    entry points and manifests, then source files) until the budget is
+    spent, so every directory keeps a few entries instead of the first
+    ones in walk order taking them all.
+    """
+    everything = {entry for entries in children.values() for entry in entries}
+    if max_entries <= 0 or len(everything) <= max_entries:
+        return everything
+
+    shown: Set[Path] = set()
+    level = [root]
+    while level and len(shown) < max_entries:
+        candidates = [children.get(directory, []) for directory in level]
+        budget = max_entries - len(shown)
+        if sum(len(entries) for entries in candidates) <= budget:
+            picked = {entry for entries in candidates for entry in entries}
+        else:
+            ranked = [sorted(entries, key=_entry_rank) for entries in candidates]
+            picked = set()
+            turn = 0
+            while len(picked) < budget:
+                for entries in ranked:
+                    if turn < len(entries) and len(picked) < budget:
+                        picked.add(entries[turn])
+                turn += 1
+        shown |= picked
+        level = [entry for entries in candidates for entry in entries if entry in picked and entry in children]
+    return shown
+
+
+def _walk_directory(path: Path, lines: List[str], context: dict, prefix: str = '', parents: Tuple[int, ...] = ()):
+    """Recursively render the shown entries of a scanned directory.
+
+    File lines are left as their tree prefix; each is recorded in
+    context['files'] for _fill_file_info to complete. Entries left out
+    are summed up in a last line per directory.
 
     Args:
         path: Directory to walk
         lines: Output lines list
+        context: Shared context dict with 'children' (from _scan_tree),
+            'shown' (from select_entries), 'omitted' (a running count) and
+            'files' ((line index, path, indices of the enclosing directory lines) per file)
         prefix: Tree prefix for indentation
-        depth: Remaining depth
-        show_hidden: Show hidden files
-        fast: Skip expensive operations
-        context: Shared context dict with 'count', 'max_entries', 'truncated'
+        parents: Line indices of the directories enclosing this one
     """
-    if depth <= 0:
-        return
-
-    if context is None:
-        context = {'count': 0, 'max_entries': 0, 'truncated': 0}
-
-    try:
-        entries = sorted(path.iterdir(), key=lambda p: (not p.is_dir(), p.name))
-    except PermissionError:
-        return
-
-    # Filter hidden files/dirs
-    if not show_hidden:
-        entries = [e for e in entries if not e.name.startswith('.')]
+    entries = context['children'].get(path, [])
+    shown = [entry for entry in entries if entry in context['shown']]
+    omitted = [entry for entry in entries if entry not in context['shown']]
 
-    for i, entry in enumerate(entries):
-        # Check if we've hit the entry limit
-        if context['max_entries'] > 0 and context['count'] >= context['max_entries']:
-            # Count remaining entries
-            context['truncated'] += len(entries) - i
-            return
-
-        is_last = (i == len(entries) - 1)
+    for i, entry in enumerate(shown):
+        is_last = (i == len(shown) - 1) and not omitted
 
         # Tree characters
         if is_last:
@@ -119,56 +229,148 @@ def _walk_directory(path: Path, lines: List[str], prefix: str = '', depth: int =
             extension = '│   '
 
         if entry.is_file():
-            # Show file with metadata
-            file_info = _get_file_info(entry, fast=fast)
-            lines.append(f"{prefix}{connector}{file_info}")
-            context['count'] += 1
+            # File info is filled in once every file is known, so they can be analyzed together
+            context['files'].append((len(lines), entry, parents))
+            lines.append(f"{prefix}{connector}")
 
         elif entry.is_dir():
-            # Show directory
             lines.append(f"{prefix}{connector}{entry.name}/")
-            context['count'] += 1
             # Recurse into subdirectory
-            _walk_directory(entry, lines, prefix + extension, depth - 1,
-                          show_hidden, fast, context)
+            _walk_directory(entry, lines, context, prefix + extension, parents + (len(lines) - 1,))
+
+    if omitted:
+        directories = sum(1 for entry in omitted if entry.is_dir())
+        files = len(omitted) - directories
+        parts = [f"{files} {'file' if files == 1 else 'files'}"] if files else []
+        parts += [f"{directories} {'directory' if directories == 1 else 'directories'}"] if directories else []
+        lines.append(f"{prefix}└── ... {len(omitted)} {'entry' if len(omitted) == 1 else 'entries'} omitted "
+                     f"({', '.join(parts)})")
+        context['omitted'] += len(omitted) + sum(_entries_below(entry, context['children']) for entry in omitted)
+
 
+def _entries_below(path: Path, children: Dict[Path, List[Path]]) -> int:
+    """Number of scanned entries under a directory."""
+    return sum(1 + _entries_below(entry, children) for entry in children.get(path, []))
 
-def _get_file_info(path: Path, fast: bool = False) -> str:
+
+def _fill_file_info(lines: List[str], context: dict, fast: bool, jobs: int, skip_generated: bool = True,
+                    max_file_size: int = 0, progress: Optional[Callable[[int, int], None]] = None
+                    ) -> Iterator[int]:
+    """Complete the file lines of a walked tree, analyzing files on up to jobs workers.
+
+    Adds each file's line counts to its language in context['languages']
+    and appends the totals of the files below each directory to its line.
+    Yields, as files are done, how many leading lines are complete: file
+    lines once analyzed, directory lines once every file below them is.
+    """
+    languages = context.setdefault('languages', {})
+    directories: Dict[int, Dict[str, int]] = {}
+    files = context['files']
+    infos = ordered_map(lambda entry: _get_file_info(entry[1], fast=fast, skip_generated=skip_generated,
+                                                     max_file_size=max_file_size), files, jobs)
+    # Line index of each file → its position; of each directory → the position of its last file
+    pending = {index: position for position, (index, _path, _parents) in enumerate(files)}
+    for position, (_index, _path, parents) in enumerate(files):
+        pending.update((parent, position) for parent in parents)
+
+    def add(totals: Dict[str, int], counts: Dict[str, int]) -> None:
+        totals['files'] += 1
+        for key, count in counts.items():
+            totals[key] += count
+
+    def complete_through(position: int, complete: int) -> int:
+        while complete < len(lines) and pending.get(complete, -1) <= position:
+            complete += 1
+        return complete
+
+    # The lines above the first file are complete from the start
+    complete = complete_through(-1, 0)
+    yield complete
+    for position, ((index, _path, parents), (info, file_type, counts)) in enumerate(zip(files, infos)):
+        lines[index] += info
+        if counts is not None:
+            add(languages.setdefault(file_type, {'files': 0, 'code': 0, 'comment': 0, 'blank': 0}), counts)
+            for parent in parents:
+                add(directories.setdefault(parent, {'files': 0, 'code': 0, 'comment': 0, 'blank': 0}), counts)
+        for parent in parents:
+            totals = directories.get(parent)
+            if pending[parent] == position and totals:
+                lines[parent] += (f" ({totals['files']} {'file' if totals['files'] == 1 else 'files'}, "
+                                  f"{totals['code']} code, {totals['comment']} comment)")
+        if progress is not None:
+            progress(position + 1, len(files))
+        complete = complete_through(position, complete)
+        yield complete
+    yield len(lines)
+
+
+def _get_file_info(path: Path, fast: bool = False, skip_generated: bool = False,
+                   max_file_size: int = 0) -> Tuple[str, Optional[str], Optional[Dict[str, int]]]:
     """Get formatted file info for tree display.
 
     Args:
         path: File path
         fast: If True, skip expensive line counting
+        skip_generated: If True, don't analyze binary, minified or generated files
+        max_file_size: Don't analyze files larger than this (0 = no limit)
 
     Returns:
-        Formatted string like "app.py (247 lines, Python)" or "app.py (12.5 KB)"
+        (info, file type, line counts): info like "app.py (247 lines: 180 code, 40 comment, Python)",
+        "app.py (12.5 KB)", "app.min.js (80.2 KB, minified)" or "dump.sql (1.2 GB, SQL, too large)";
+        type and code/comment/blank counts are None for files not analyzed
     """
     try:
         if fast:
             # Fast mode: just show file size, no analyzer
             stat = os.stat(path)
             size = _format_size(stat.st_size)
-            return f"{path.name} ({size})"
+            return f"{path.name} ({size})", None, None
 
         # Normal mode: Try to get analyzer for this file
         analyzer_class = get_analyzer(str(path))
+        if analyzer_class and oversized(path, max_file_size):
+            size = _format_size(os.stat(path).st_size)
+            return f"{path.name} ({size}, {analyzer_class.type_name}, too large)", None, None
+        reason = skip_reason(path) if analyzer_class and skip_generated else None
+        if reason:
+            return f"{path.name} ({_format_size(os.stat(path).st_size)}, {reason})", None, None
 
         if analyzer_class:
             # Use analyzer to get info
             analyzer = analyzer_class(str(path))
             meta = analyzer.get_metadata()
             file_type = analyzer.type_name
+            summary = analyzer.get_directory_summary()
+            counts = {key: meta[key] for key in ('code', 'comment', 'blank')}
 
-            return f"{path.name} ({meta['lines']} lines, {file_type})"
+            info = f"{path.name} ({meta['lines']} lines: {meta['code']} code, {meta['comment']} comment, {file_type})"
+            return (f"{info} - {summary}" if summary else info), file_type, counts
         else:
             # No analyzer - just show basic info
             stat = os.stat(path)
             size = _format_size(stat.st_size)
-            return f"{path.name} ({size})"
+            return f"{path.name} ({size})", None, None
 
     except Exception:
         # If anything fails, just show filename
-        return path.name
+        return path.name, None, None
+
+
+def _language_total(languages: Dict[str, Dict[str, int]]) -> Dict[str, int]:
+    """Sum per-language line counts: files, code, comment, blank."""
+    return {key: sum(totals[key] for totals in languages.values()) for key in ('files', 'code', 'comment', 'blank')}
+
+
+def language_table(languages: Dict[str, Dict[str, Any]]) -> List[str]:
+    """cloc-style table of files and code/comment/blank lines per language, most code first, with a total row."""
+    rows = sorted(languages.items(), key=lambda item: (-item[1]['code'], item[0]))
+    rows.append(('Total', _language_total(languages)))
+    width = max(len('Language'), *(len(name) for name, _ in rows))
+    table = [f"{'Language':<{width}}  {'Files':>5}  {'Code':>7}  {'Comment':>7}  {'Blank':>7}"]
+    for name, totals in rows:
+        table.append(f"{name:<{width}}  {totals['files']:>5}  {totals['code']:>7}  {totals['comment']:>7}  "
+                     f"{totals['blank']:>7}")
+    return table
 
 
 def _format_size(size: int) -> str:
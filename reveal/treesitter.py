@@ -34,10 +34,22 @@ class TreeSitterAnalyzer(FileAnalyzer):
 
     def __init__(self, path: str):
         super().__init__(path)
-        self.tree = None
-
-        if self.language:
-            self._parse_tree()
+        self._tree = None
+        self._parsed = False
+
+    @property
+    def tree(self):
+        """The parse tree, parsed on first use (a structure from the cache needs none)."""
+        if not self._parsed:
+            self._parsed = True
+            if self.language:
+                self._parse_tree()
+        return self._tree
+
+    @tree.setter
+    def tree(self, value):
+        self._tree = value
+        self._parsed = True
 
     def _parse_tree(self):
         """Parse file with tree-sitter."""
@@ -77,6 +89,9 @@ class TreeSitterAnalyzer(FileAnalyzer):
         structure['classes'] = self._extract_classes()
         structure['structs'] = self._extract_structs()
 
+        # Language-specific categories (enums, traits, interfaces, ...)
+        structure.update(self._extract_language_specific())
+
         # Apply semantic slicing to each category
         if head or tail or range:
             for category in structure:
@@ -103,13 +118,95 @@ class TreeSitterAnalyzer(FileAnalyzer):
         for import_type in import_types:
             nodes = self._find_nodes_by_type(import_type)
             for node in nodes:
-                imports.append({
+                entry = {
                     'line': node.start_point[0] + 1,
                     'content': self._get_node_text(node),
-                })
+                }
+                modules = self._import_modules(node)
+                if modules:
+                    entry['modules'] = modules
+                imports.append(entry)
 
         return imports
 
+    def _import_modules(self, node) -> List[str]:
+        """Module names/paths an import node refers to, read from the parse tree.
+
+        'from pkg import a, b' -> ['pkg.a', 'pkg.b'] and 'from . import a' ->
+        ['.a'] (Python), 'import x from "./x"'
+        -> ['./x'] (JavaScript), 'import ("fmt"; "os")' -> ['fmt', 'os'] (Go),
+        'use crate::a::{b, c};' -> ['crate::a'] (Rust). Empty for statements
+        with syntax errors or shapes not known here; the import graph then
+        falls back to reading the statement text.
+        """
+        if node.has_error:
+            return []
+
+        if node.type == 'import_from_statement':
+            module = node.child_by_field_name('module_name')
+            if module is None:
+                return []
+            prefix = self._get_node_text(module)
+            names = self._imported_names(node, after_keyword=True)
+            if not names:
+                # 'from pkg import *'
+                return [prefix] if prefix.strip('.') else []
+            # Each name may be a submodule ('from pkg import mod' -> 'pkg.mod'); resolving falls back to pkg
+            separator = '.' if prefix.strip('.') else ''
+            return [prefix + separator + name for name in names]
+
+        if node.type == 'import_statement':
+            source = node.child_by_field_name('source')
+            if source is not None:
+                return [self._get_node_text(source).strip('\'"`')]
+            return self._imported_names(node)
+
+        if node.type == 'import_declaration':
+            paths = [spec.child_by_field_name('path') for spec in self._find_descendants(node, 'import_spec')]
+            if paths:
+                return [self._get_node_text(path).strip('"`') for path in paths if path is not None]
+            # Java: 'import a.b.C;' / 'import static a.b.*;'
+            for child in node.children:
+                if child.type in ('scoped_identifier', 'identifier'):
+                    return [self._get_node_text(child)]
+            return []
+
+        if node.type == 'use_declaration':
+            argument = node.child_by_field_name('argument')
+            if argument is None or argument.type == 'use_list':
+                return []
+            if argument.type in ('scoped_use_list', 'use_as_clause'):
+                argument = argument.child_by_field_name('path')
+            elif argument.type == 'use_wildcard':
+                argument = argument.children[0] if argument.children else None
+            return [self._get_node_text(argument)] if argument is not None else []
+
+        return []
+
+    def _imported_names(self, node, after_keyword: bool = False) -> List[str]:
+        """Dotted names listed by a Python import ('import a.b as c, d' -> ['a.b', 'd'])."""
+        names = []
+        seen_keyword = not after_keyword
+        for child in node.children:
+            if child.type == 'import':
+                seen_keyword = True
+            elif seen_keyword and child.type == 'aliased_import':
+                name = child.child_by_field_name('name')
+                if name is not None:
+                    names.append(self._get_node_text(name))
+            elif seen_keyword and child.type == 'dotted_name':
+                names.append(self._get_node_text(child))
+        return names
+
+    def _find_descendants(self, node, node_type: str) -> List:
+        """Nodes of a given type below node."""
+        found = []
+        for child in node.children:
+            if child.type == node_type:
+                found.append(child)
+            found.extend(self._find_descendants(child, node_type))
+        return found
+
     def _extract_functions(self) -> List[Dict[str, Any]]:
         """Extract function definitions with complexity metrics."""
         functions = []
@@ -128,21 +225,24 @@ class TreeSitterAnalyzer(FileAnalyzer):
             for node in nodes:
                 name = self._get_function_name(node)
                 if name:
-                    line_start = node.start_point[0] + 1
-                    line_end = node.end_point[0] + 1
-                    line_count = line_end - line_start + 1
-
-                    functions.append({
-                        'line': line_start,
-                        'line_end': line_end,
-                        'name': name,
-                        'signature': self._get_signature(node),
-                        'line_count': line_count,
-                        'depth': self._get_nesting_depth(node),
-                    })
+                    functions.append(self._function_entry(node, name))
 
         return functions
 
+    def _function_entry(self, node, name: str) -> Dict[str, Any]:
+        """Build a function entry with signature and complexity metrics."""
+        line_start = node.start_point[0] + 1
+        line_end = node.end_point[0] + 1
+
+        return {
+            'line': line_start,
+            'line_end': line_end,
+            'name': name,
+            'signature': self._get_signature(node),
+            'line_count': line_end - line_start + 1,
+            'depth': self._get_nesting_depth(node),
+        }
+
     def _extract_classes(self) -> List[Dict[str, Any]]:
         """Extract class definitions."""
         classes = []
@@ -150,7 +250,6 @@ class TreeSitterAnalyzer(FileAnalyzer):
         class_types = [
             'class_definition',      # Python
             'class_declaration',     # Java, C#, JavaScript
-            'struct_item',           # Rust (treated as class)
         ]
 
         for class_type in class_types:
@@ -193,6 +292,52 @@ class TreeSitterAnalyzer(FileAnalyzer):
 
         return structs
 
+    def _extract_language_specific(self) -> Dict[str, List[Dict[str, Any]]]:
+        """Extract additional categories specific to a language.
+
+        Override in subclasses to add categories beyond the common
+        imports/functions/classes/structs (e.g., Rust enums and traits).
+
+        Returns:
+            Dict mapping category name to list of elements
+        """
+        return {}
+
+    def _extract_definitions(self, node_types: List[str]) -> List[Dict[str, Any]]:
+        """Extract named definitions of the given node types.
+
+        Args:
+            node_types: Tree-sitter node types to collect (e.g., ['enum_item'])
+
+        Returns:
+            List of dicts with line, line_end and name, sorted by line
+        """
+        definitions = []
+
+        for node_type in node_types:
+            for node in self._find_nodes_by_type(node_type):
+                name = self._get_node_name(node)
+                if name:
+                    definitions.append({
+                        'line': node.start_point[0] + 1,
+                        'line_end': node.end_point[0] + 1,
+                        'name': name,
+                    })
+
+        return sorted(definitions, key=lambda d: d['line'])
+
+    def _get_element_type_map(self) -> Dict[str, List[str]]:
+        """Map element types to the node types that define them.
+
+        Subclasses extend this to make language-specific definitions
+        (enums, traits, interfaces, ...) extractable by name.
+        """
+        return {
+            'function': ['function_definition', 'function_declaration', 'function_item', 'method_declaration'],
+            'class': ['class_definition', 'class_declaration'],
+            'struct': ['struct_item', 'struct_specifier', 'struct_declaration'],
+        }
+
     def extract_element(self, element_type: str, name: str) -> Optional[Dict[str, Any]]:
         """Extract a specific element using tree-sitter.
 
@@ -207,30 +352,49 @@ class TreeSitterAnalyzer(FileAnalyzer):
             return super().extract_element(element_type, name)
 
         # Map element type to node types
-        type_map = {
-            'function': ['function_definition', 'function_declaration', 'function_item', 'method_declaration'],
-            'class': ['class_definition', 'class_declaration'],
-            'struct': ['struct_item', 'struct_specifier', 'struct_declaration'],
-        }
-
+        type_map = self._get_element_type_map()
         node_types = type_map.get(element_type, [element_type])
 
-        # Find matching node
-        for node_type in node_types:
-            nodes = self._find_nodes_by_type(node_type)
-            for node in nodes:
-                node_name = self._get_node_name(node)
-                if node_name == name:
+        # Find matching node, then try every other known definition type
+        # before falling back to grep
+        node = self._find_named_node(node_types, name)
+        if node is None:
+            all_types = [t for types in type_map.values() for t in types]
+            node = self._find_named_node(all_types, name)
+
+        if node is not None:
+            return {
+                'name': name,
+                'line_start': node.start_point[0] + 1,
+                'line_end': node.end_point[0] + 1,
+                'source': self._get_node_text(node),
+            }
+
+        # Names built from several nodes (aws_instance.web, handle/2,
+        # Class#method) are found through the structure's line ranges
+        for items in self.get_structure().values():
+            for item in items if isinstance(items, list) else []:
+                if item.get('name') == name and isinstance(item.get('line'), int):
+                    line_start = item['line']
+                    line_end = item.get('line_end', line_start)
                     return {
                         'name': name,
-                        'line_start': node.start_point[0] + 1,
-                        'line_end': node.end_point[0] + 1,
-                        'source': self._get_node_text(node),
+                        'line_start': line_start,
+                        'line_end': line_end,
+                        'source': '\n'.join(self.lines[line_start - 1:line_end]),
                     }
 
         # Fall back to grep
         return super().extract_element(element_type, name)
 
+    def _find_named_node(self, node_types: List[str], name: str):
+        """Find the first node of the given types whose name matches."""
+        for node_type in node_types:
+            for node in self._find_nodes_by_type(node_type):
+                if self._get_node_name(node) == name:
+                    return node
+        return None
+
     def _find_nodes_by_type(self, node_type: str) -> List:
         """Find all nodes of a given type in the tree."""
         if not self.tree:
@@ -261,6 +425,12 @@ class TreeSitterAnalyzer(FileAnalyzer):
 
     def _get_node_name(self, node) -> Optional[str]:
         """Get the name of a node (function/class/struct name)."""
+        # Most grammars expose the name as a 'name' field
+        # (e.g., Rust struct_item -> type_identifier)
+        name_node = node.child_by_field_name('name')
+        if name_node is not None:
+            return self._get_node_text(name_node)
+
         # Look for 'name' or 'identifier' child
         for child in node.children:
             if child.type in ('identifier', 'name'):
@@ -292,6 +462,13 @@ class TreeSitterAnalyzer(FileAnalyzer):
             elif child.type in ('return_type', 'type'):
                 return_type = ' -> ' + self._get_node_text(child).strip(': ')
 
+        # Grammars like Rust expose the return type as a field with a
+        # language-specific node type (primitive_type, generic_type, ...)
+        if not return_type:
+            return_node = node.child_by_field_name('return_type')
+            if return_node is not None:
+                return_type = ' -> ' + self._get_node_text(return_node).strip(': ')
+
         if params_text:
             return params_text + return_type
 
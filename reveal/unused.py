@@ -0,0 +1,123 @@
+"""Unused symbols: functions and types defined in a project but never referenced.
+
+A definition counts as used when its name appears anywhere in the
+project's analyzed files other than its own definition line (calls,
+references, strings such as getattr('name') or route tables), so the
+report errs towards missing dead code rather than flagging live code.
+Entry points, tests, special methods, decorated or annotated
+definitions and exported API are left out.
+"""
+
+import re
+from collections import Counter
+from typing import Any, Dict, Iterable, List, Optional, Set, Tuple
+
+from .base import category_kind
+from .calls import _LINE_COMMENT
+from .hierarchy import HIERARCHY_CATEGORIES
+from .metrics import FUNCTION_CATEGORIES
+
+
+# Definitions considered (constructors are reached through their class)
+SYMBOL_CATEGORIES = tuple(c for c in FUNCTION_CATEGORIES if c != 'constructors') + HIERARCHY_CATEGORIES
+
+# Called by runtimes, frameworks or test runners rather than by project code
+ENTRY_POINTS = {'main', 'init', 'setup', 'setUp', 'tearDown', 'setUpClass', 'tearDownClass', 'setUpModule',
+                'tearDownModule', 'initialize', 'new', 'constructor', 'run', 'handler', 'lambda_handler',
+                'Main', 'DllMain', 'WinMain', 'onCreate', 'render', 'configure', 'receive', 'fallback'}
+# Interface definitions and schemas describe APIs used outside the project
+SKIPPED_SUFFIXES = ('.thrift', '.proto', '.avdl', '.avsc', '.graphql', '.gql', '.sql')
+_TEST_NAME = re.compile(r'^(?:test|Test|Benchmark|Example|Fuzz)|_test$')
+_SPECIAL = re.compile(r'^__\w+__$')
+_EXPORT_MARKER = re.compile(r'^\s*(?:export|pub(?:\([^)]*\))?|public|open)\b')
+_EXPORTED_VISIBILITY = {'public', 'exported', 'export', 'external'}
+_PYTHON_ALL = re.compile(r'__all__\s*[+]?=\s*[\[(]([^\])]*)[\])]', re.DOTALL)
+_TOKEN = re.compile(r'[A-Za-z_$][\w$]*[!?]?')
+_OBJC_SELECTOR = re.compile(r'^[-+]\[\S+\s+(\w+)')
+
+
+def reference_name(name: str) -> Optional[str]:
+    """Identifier that references to a definition use; None for operators and other unnamed items.
+
+    'Server#start' -> 'start', 'format/1' -> 'format', 'M:method' -> 'method',
+    '-[Widget initWithTitle:count:]' -> 'initWithTitle'.
+    """
+    selector = _OBJC_SELECTOR.match(name)
+    if selector:
+        return selector.group(1)
+    last = re.split(r'::|[#.:]|(?<=\w)\$', name)[-1].strip()
+    token = _TOKEN.match(last)
+    return token.group() if token else None
+
+
+def exported_names(file_name: str, lines: List[str]) -> Set[str]:
+    """Names a Python module lists in __all__."""
+    if not file_name.endswith('.py'):
+        return set()
+    names = set()
+    for match in _PYTHON_ALL.finditer('\n'.join(lines)):
+        names.update(re.findall(r'''["']([\w.]+)["']''', match.group(1)))
+    return names
+
+
+def is_exported(file_name: str, lines: List[str], item: Dict[str, Any], listed: Set[str]) -> bool:
+    """Whether a definition is part of its file's public API by its language's rules.
+
+    Go capitalized names, Python __all__ entries and names in an __init__.py,
+    export/pub/public declarations, and items the analyzer marks public.
+    """
+    name = reference_name(item['name']) or ''
+    if file_name.endswith('.go'):
+        return name[:1].isupper()
+    if file_name.endswith('.py'):
+        return name in listed or (file_name.endswith('__init__.py') and not name.startswith('_'))
+    if item.get('visibility') in _EXPORTED_VISIBILITY:
+        return True
+    line = item.get('line', 0)
+    return 0 < line <= len(lines) and bool(_EXPORT_MARKER.search(lines[line - 1]))
+
+
+def _is_excluded(name: str, lines: List[str], item: Dict[str, Any]) -> bool:
+    if name in ENTRY_POINTS or _SPECIAL.match(name) or _TEST_NAME.search(name):
+        return True
+    if item.get('decorators') or item.get('annotations'):
+        return True
+    # Registered by a decorator or annotation on the line above (@app.route, @Override, #[test])
+    line = item.get('line', 0)
+    previous = lines[line - 2].strip() if 1 < line <= len(lines) + 1 else ''
+    return previous.startswith(('@', '#[', '[')) and not previous.startswith('[]')
+
+
+def unused_symbols(files: Iterable[Tuple[str, List[str], Dict[str, List[Dict[str, Any]]]]],
+                   include_exported: bool = False) -> Tuple[List[Dict[str, Any]], int]:
+    """Unreferenced definitions ({'file', 'line', 'name', 'kind'}) and how many definitions were checked."""
+    references: Counter = Counter()
+    definitions: List[Tuple[str, str, str, Dict[str, Any], List[str], Set[str]]] = []
+    defined: Counter = Counter()
+    for file_name, lines, structure in files:
+        for text in lines:
+            if not _LINE_COMMENT.match(text):
+                references.update(_TOKEN.findall(text))
+        if file_name.endswith(SKIPPED_SUFFIXES):
+            continue
+        listed = exported_names(file_name, lines)
+        for category in SYMBOL_CATEGORIES:
+            for item in structure.get(category, []):
+                name = reference_name(str(item.get('name') or ''))
+                if name and isinstance(item.get('line'), int):
+                    definitions.append((name, file_name, category, item, lines, listed))
+                    defined[name] += 1
+
+    unused = []
+    checked = 0
+    for name, file_name, category, item, lines, listed in definitions:
+        if _is_excluded(name, lines, item):
+            continue
+        if not include_exported and is_exported(file_name, lines, item, listed):
+            continue
+        checked += 1
+        # Each definition line accounts for one mention of the name
+        if references[name] <= defined[name]:
+            unused.append({'file': file_name, 'line': item['line'], 'name': item['name'],
+                           'kind': item.get('kind') or category_kind(category)})
+    return unused, checked
@@ -0,0 +1,204 @@
+"""Watch a file or directory for changes: which files were added, modified or removed since the last look.
+
+Native change notifications say when to look: inotify on Linux, and
+watchdog's backends (FSEvents on macOS, kqueue, Windows) when watchdog
+is installed. What changed is always found by comparing snapshots of
+file modification times and sizes, so the events are the same as when
+polling, which is the fallback where notifications are unavailable
+(or run out, as inotify watches can) and the choice for network mounts.
+"""
+
+import ctypes
+import ctypes.util
+import os
+import select
+import struct
+import sys
+import threading
+import time
+from pathlib import Path
+from typing import Callable, Dict, List, Optional, Tuple
+
+from .tree_view import iter_directories, iter_directory_files
+
+
+Snapshot = Dict[str, Tuple[int, int]]
+
+# Quiet time that ends a burst of notifications (an editor's save is several)
+_SETTLE = 0.05
+
+# inotify(7) event masks
+_IN_MODIFY, _IN_ATTRIB, _IN_CLOSE_WRITE = 0x2, 0x4, 0x8
+_IN_MOVED_FROM, _IN_MOVED_TO, _IN_CREATE, _IN_DELETE = 0x40, 0x80, 0x100, 0x200
+_IN_DELETE_SELF, _IN_MOVE_SELF, _IN_ISDIR = 0x400, 0x800, 0x40000000
+_IN_MASK = (_IN_MODIFY | _IN_ATTRIB | _IN_CLOSE_WRITE | _IN_MOVED_FROM | _IN_MOVED_TO | _IN_CREATE | _IN_DELETE
+            | _IN_DELETE_SELF | _IN_MOVE_SELF)
+_IN_EVENT = struct.Struct('iIII')
+
+
+def snapshot(path: Path, depth: int = 3, ignore: bool = True) -> Snapshot:
+    """(modification time in ns, size) of the file, or of every file under the directory down to depth.
+
+    Unless ignore is False, files excluded by .gitignore/.ignore and the
+    built-in defaults are left out.
+    """
+    files = [path] if path.is_file() else iter_directory_files(path, depth=depth, ignore=ignore)
+    result = {}
+    for file_path in files:
+        try:
+            stat = file_path.stat()
+        except OSError:
+            # Removed while walking
+            continue
+        result[str(file_path)] = (stat.st_mtime_ns, stat.st_size)
+    return result
+
+
+def changed_files(before: Snapshot, after: Snapshot) -> List[Tuple[str, str]]:
+    """(event, file) for every file 'added', 'modified' or 'removed' between two snapshots, by file name."""
+    events = [('removed', name) for name in before if name not in after]
+    events += [('added' if name not in before else 'modified', name) for name, state in after.items()
+               if before.get(name) != state]
+    return sorted(events, key=lambda event: event[1])
+
+
+class Poller:
+    """No notifications: every wait lasts the whole interval and may have seen changes."""
+
+    def wait(self, timeout: float) -> bool:
+        time.sleep(timeout)
+        return True
+
+    def close(self) -> None:
+        pass
+
+
+class InotifyNotifier:
+    """Linux inotify watches on the walked directories (it isn't recursive), added as directories appear."""
+
+    def __init__(self, path: Path, depth: int, ignore: bool):
+        self._libc = ctypes.CDLL(ctypes.util.find_library('c'), use_errno=True)
+        self._fd = self._libc.inotify_init1(os.O_NONBLOCK | os.O_CLOEXEC)
+        if self._fd < 0:
+            raise OSError(ctypes.get_errno(), 'inotify_init1 failed')
+        # A file is watched through its directory: editors save by replacing the file
+        self._root, self._depth = (path.parent, 1) if path.is_file() else (path, depth)
+        self._ignore = ignore
+        self._polling = False
+        try:
+            self._add_watches()
+        except OSError:
+            self.close()
+            raise
+
+    def _add_watches(self) -> None:
+        for directory in iter_directories(self._root, self._depth, self._ignore):
+            if self._libc.inotify_add_watch(self._fd, os.fsencode(str(directory)), _IN_MASK) < 0:
+                error = ctypes.get_errno()
+                # Removed meanwhile; anything else (ENOSPC: out of watches) makes watching incomplete
+                if error not in (0, 2):
+                    raise OSError(error, f"inotify_add_watch failed for {directory}")
+
+    def _drain(self) -> bool:
+        """Read pending events; whether one was about a directory (whose new subdirectories need watches)."""
+        directories = False
+        while True:
+            try:
+                data = os.read(self._fd, 65536)
+            except BlockingIOError:
+                return directories
+            offset = 0
+            while offset + _IN_EVENT.size <= len(data):
+                _wd, mask, _cookie, length = _IN_EVENT.unpack_from(data, offset)
+                directories = directories or bool(mask & _IN_ISDIR)
+                offset += _IN_EVENT.size + length
+
+    def wait(self, timeout: float) -> bool:
+        """Wait up to timeout seconds for activity, then until it settles; whether there was any."""
+        if self._polling:
+            time.sleep(timeout)
+            return True
+        if not select.select([self._fd], [], [], timeout)[0]:
+            return False
+        directories = self._drain()
+        while select.select([self._fd], [], [], _SETTLE)[0]:
+            directories = self._drain() or directories
+        if directories:
+            try:
+                self._add_watches()
+            except OSError:
+                self._polling = True
+        return True
+
+    def close(self) -> None:
+        if self._fd >= 0:
+            os.close(self._fd)
+            self._fd = -1
+
+
+class WatchdogNotifier:
+    """watchdog's native observer (FSEvents, kqueue, ReadDirectoryChangesW) on the watched tree."""
+
+    def __init__(self, path: Path):
+        from watchdog.events import FileSystemEventHandler
+        from watchdog.observers import Observer
+
+        self._activity = threading.Event()
+        handler = FileSystemEventHandler()
+        handler.on_any_event = lambda _event: self._activity.set()
+        self._observer = Observer()
+        self._observer.schedule(handler, str(path.parent if path.is_file() else path), recursive=path.is_dir())
+        self._observer.start()
+
+    def wait(self, timeout: float) -> bool:
+        """Wait up to timeout seconds for activity, then until it settles; whether there was any."""
+        if not self._activity.wait(timeout):
+            return False
+        while True:
+            self._activity.clear()
+            if not self._activity.wait(_SETTLE):
+                return True
+
+    def close(self) -> None:
+        self._observer.stop()
+        self._observer.join()
+
+
+def notifier(path: Path, depth: int = 3, ignore: bool = True):
+    """Native change notifications for path (inotify on Linux, else watchdog's), or None where there are none."""
+    if sys.platform.startswith('linux'):
+        try:
+            return InotifyNotifier(path, depth, ignore)
+        except (OSError, AttributeError, TypeError):
+            # No inotify in this libc, or out of watches
+            pass
+    try:
+        return WatchdogNotifier(path)
+    except Exception:
+        # watchdog not installed, or no observer for this platform
+        return None
+
+
+def watch(path: Path, on_change: Callable[[List[Tuple[str, str]]], None], depth: int = 3,
+          interval: float = 1.0, polls: Optional[int] = None, ignore: bool = True, native: bool = True) -> None:
+    """Call on_change with the events of each look that finds changes.
+
+    With native notifications a look follows each burst of activity;
+    otherwise (or with native False) one happens every interval seconds.
+    Runs until interrupted, or for the given number of intervals.
+    """
+    waiter = (notifier(path, depth, ignore) if native else None) or Poller()
+    try:
+        current = snapshot(path, depth, ignore)
+        count = 0
+        while polls is None or count < polls:
+            count += 1
+            if not waiter.wait(interval):
+                continue
+            latest = snapshot(path, depth, ignore)
+            events = changed_files(current, latest)
+            current = latest
+            if events:
+                on_change(events)
+    finally:
+        waiter.close()
@@ -0,0 +1,70 @@
+"""Bounded worker pool: analyze many files at once and get the results back in walk order."""
+
+import multiprocessing
+import os
+from collections import deque
+from concurrent.futures import Executor, Future, ProcessPoolExecutor, ThreadPoolExecutor
+from itertools import chain, islice
+from typing import Any, Callable, Deque, Iterable, Iterator, Optional, Tuple, TypeVar
+
+
+T = TypeVar('T')
+R = TypeVar('R')
+
+# The function a worker process applies, set by its pool's initializer
+_FUNCTION: Optional[Callable[[Any], Any]] = None
+
+
+def default_jobs() -> int:
+    """Number of workers when --jobs is not given: one per CPU."""
+    return os.cpu_count() or 1
+
+
+def _set_function(function: Callable[[Any], Any]) -> None:
+    global _FUNCTION
+    _FUNCTION = function
+
+
+def _call(item: Any) -> Any:
+    return _FUNCTION(item)
+
+
+def _pool(function: Callable[[T], R], jobs: int) -> Tuple[Executor, Callable[[T], R]]:
+    """A pool of jobs worker processes and what to submit to it, or of threads where fork is unavailable.
+
+    Forked workers inherit function (a closure is fine) instead of having it pickled.
+    """
+    if 'fork' not in multiprocessing.get_all_start_methods():
+        return ThreadPoolExecutor(max_workers=jobs), function
+    return ProcessPoolExecutor(max_workers=jobs, mp_context=multiprocessing.get_context('fork'),
+                               initializer=_set_function, initargs=(function,)), _call
+
+
+def ordered_map(function: Callable[[T], R], items: Iterable[T], jobs: int = 0) -> Iterator[R]:
+    """Yield function(item) for every item, computed on up to jobs worker processes, in the order of items.
+
+    Analysis is CPU-bound Python, which threads can't run in parallel, so
+    workers are forked processes (threads where fork is unavailable) and
+    results must pickle. jobs 0 means one worker per CPU; 1, or a single
+    item, runs in the calling process. At most twice as many items as
+    workers are in flight, so results stream while the rest are computed.
+    function should handle its own errors: an exception it raises ends the
+    iteration.
+    """
+    jobs = jobs if jobs > 0 else default_jobs()
+    items = iter(items)
+    head = list(islice(items, 2))
+    if jobs == 1 or len(head) < 2:
+        for item in chain(head, items):
+            yield function(item)
+        return
+
+    pool, task = _pool(function, jobs)
+    with pool:
+        pending: Deque[Future] = deque()
+        for item in chain(head, items):
+            pending.append(pool.submit(task, item))
+            if len(pending) >= 2 * jobs:
+                yield pending.popleft().result()
+        while pending:
+            yield pending.popleft().result()
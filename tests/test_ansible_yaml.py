@@ -0,0 +1,107 @@
+"""Tests for Ansible detection in the YAML analyzer."""
+
+import os
+import shutil
+import tempfile
+import unittest
+from reveal.analyzers.yaml_json import YamlAnalyzer
+
+
+PLAYBOOK = '''---
+- import_playbook: common.yml
+
+- name: Configure web servers
+  hosts: webservers
+  become: true
+  vars:
+    http_port: 80
+  roles:
+    - common
+    - role: web
+  tasks:
+    - name: Install nginx
+      apt:
+        name: nginx
+      notify: restart nginx
+
+    - block:
+        - name: Start service
+          service: name=nginx state=started
+  handlers:
+    - name: restart nginx
+      service:
+        name: nginx
+        state: restarted
+
+- hosts: db
+  tasks:
+    - command: /bin/true
+'''
+
+ROLE_TASKS = '''- name: Copy config
+  template:
+    src: nginx.conf.j2
+    dest: /etc/nginx/nginx.conf
+- include_tasks: ssl.yml
+'''
+
+
+class TestAnsibleYaml(unittest.TestCase):
+    """Test Ansible mode of YamlAnalyzer."""
+
+    def _write(self, relative, content):
+        root = tempfile.mkdtemp()
+        path = os.path.join(root, relative)
+        os.makedirs(os.path.dirname(path), exist_ok=True)
+        with open(path, 'w') as f:
+            f.write(content)
+        self.addCleanup(shutil.rmtree, root)
+        return path
+
+    def test_playbook(self):
+        """Plays, roles, variables, tasks and handlers of a playbook."""
+        structure = YamlAnalyzer(self._write('site.yml', PLAYBOOK)).get_structure()
+
+        self.assertEqual(structure['imports'][0]['content'], 'import_playbook: common.yml')
+        web, db = structure['plays']
+        self.assertEqual((web['name'], web['line'], web['line_end']), ('Configure web servers', 4, 25))
+        self.assertEqual(web['signature'], ' (hosts=webservers, become)')
+        self.assertEqual(db['name'], 'hosts: db')
+        self.assertEqual([r['name'] for r in structure['roles']], ['common', 'web'])
+        self.assertEqual(structure['variables'][0]['signature'], ' = 80')
+
+        tasks = structure['tasks']
+        self.assertEqual([t['name'] for t in tasks], ['Install nginx', 'Start service', 'command'])
+        self.assertEqual((tasks[0]['line'], tasks[0]['line_end']), (13, 16))
+        self.assertEqual(tasks[0]['notify'], ['restart nginx'])
+        self.assertEqual(tasks[2]['play'], 'hosts: db')
+        self.assertEqual(structure['handlers'][0]['module'], 'service')
+
+    def test_extract_task(self):
+        """Tasks can be extracted by name."""
+        analyzer = YamlAnalyzer(self._write('site.yml', PLAYBOOK))
+        element = analyzer.extract_element('task', 'Install nginx')
+        self.assertEqual((element['line_start'], element['line_end']), (13, 16))
+
+    def test_role_tasks(self):
+        """A role's tasks/main.yml lists its tasks."""
+        structure = YamlAnalyzer(self._write('roles/web/tasks/main.yml', ROLE_TASKS)).get_structure()
+        self.assertEqual([t['name'] for t in structure['tasks']], ['Copy config', 'include_tasks'])
+        self.assertEqual(structure['tasks'][0]['signature'], ' (template)')
+
+    def test_group_vars(self):
+        """Variables files list their variables."""
+        path = self._write('group_vars/all.yml', 'ntp_server: pool.ntp.org\nusers:\n  - alice\n')
+        structure = YamlAnalyzer(path).get_structure()
+        self.assertEqual([v['name'] for v in structure['variables']], ['ntp_server', 'users'])
+
+    def test_generic_yaml_unchanged(self):
+        """Plain YAML lists and mappings are not mistaken for Ansible."""
+        path = self._write('config/items.yml', '- name: a\n  value: 1\n')
+        self.assertEqual(YamlAnalyzer(path).get_structure(), {'keys': []})
+        path = self._write('app.yml', 'server:\n  port: 80\n')
+        self.assertEqual(YamlAnalyzer(path).get_structure()['keys'][0]['name'], 'server')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,139 @@
+"""Tests for the public API surface (--api)."""
+
+import unittest
+from reveal.api import api_entries, declaration, is_documented, is_public, python_docstring, render_api_text
+
+
+PYTHON = '''"""Module."""
+__all__ = ['Parser', 'load']
+
+
+class Parser(Base):
+    """Parse things."""
+
+    def parse(self, text: str,
+              strict: bool = False) -> "Tree":
+        """Parse text.
+
+        Strictly, if asked.
+        """
+        def inner():
+            pass
+
+    def _helper(self):
+        pass
+
+
+@cache
+def load(path="a=1"):
+    return Parser().parse(path)
+
+
+def internal():
+    pass
+'''.splitlines()
+
+PYTHON_STRUCTURE = {
+    'classes': [{'name': 'Parser', 'line': 5, 'line_end': 18}],
+    'functions': [{'name': 'parse', 'line': 8, 'line_end': 15}, {'name': 'inner', 'line': 14, 'line_end': 15},
+                  {'name': '_helper', 'line': 17, 'line_end': 18}, {'name': 'load', 'line': 22, 'line_end': 23},
+                  {'name': 'internal', 'line': 26, 'line_end': 27}],
+}
+
+
+class TestDeclarations(unittest.TestCase):
+    """Headers without bodies."""
+
+    def test_body_openers(self):
+        cases = {
+            'func (s *Server) Start(ctx context.Context) error {': 'func (s *Server) Start(ctx context.Context) error',
+            'proc twice*(x: int): int =': 'proc twice*(x: int): int',
+            'def area(r: Double): Double = r * r': 'def area(r: Double): Double',
+            '  def handle(conn) do': '  def handle(conn)',
+            'export const f = (a: number): number => {': 'export const f = (a: number): number =>',
+            'def load(path="a=1"):': 'def load(path="a=1"):',
+            'class Circle < Shape': 'class Circle < Shape',
+        }
+        for text, expected in cases.items():
+            self.assertEqual(declaration([text], 1), [expected], text)
+
+    def test_multiline_parameters(self):
+        self.assertEqual(declaration(PYTHON, 8), PYTHON[7:9])
+
+    def test_python_docstring(self):
+        self.assertEqual(python_docstring(PYTHON, 5), ['    """Parse things."""'])
+        self.assertEqual(python_docstring(PYTHON, 9), PYTHON[9:13])
+        self.assertEqual(python_docstring(PYTHON, 22), [])
+
+
+class TestPublic(unittest.TestCase):
+    """Each language's notion of public."""
+
+    def check(self, file_name, text, name, owner=None, listed=(), **item):
+        return is_public(file_name, [text], {'name': name, 'line': 1, **item}, owner, set(listed))
+
+    def test_rules(self):
+        self.assertTrue(self.check('a.go', 'func Open() {', 'Open'))
+        self.assertFalse(self.check('a.go', 'func open() {', 'open'))
+        self.assertFalse(self.check('a.py', 'def _x():', '_x'))
+        self.assertTrue(self.check('a.py', 'def __init__(self):', '__init__', owner={'name': 'A'}))
+        self.assertFalse(self.check('a.py', 'def x():', 'x', listed=['y']))
+        self.assertTrue(self.check('a.ts', 'export function f() {', 'f'))
+        self.assertFalse(self.check('a.ts', 'function f() {', 'f'))
+        self.assertTrue(self.check('a.ts', '  run() {', 'run', owner={'name': 'A'}))
+        self.assertTrue(self.check('a.rs', 'pub(crate) fn f() {', 'f'))
+        self.assertFalse(self.check('a.rs', 'fn f() {', 'f'))
+        self.assertFalse(self.check('A.java', 'private void f() {', 'f', visibility='private'))
+        self.assertTrue(self.check('a.cr', 'def area', 'area'))
+
+
+class TestApiEntries(unittest.TestCase):
+    """Public definitions of a file, members under their types."""
+
+    def test_python_module(self):
+        entries = api_entries('pkg/parser.py', PYTHON, PYTHON_STRUCTURE)
+        self.assertEqual([(e['name'], e.get('owner')) for e in entries],
+                         [('Parser', None), ('parse', 'Parser'), ('load', None)])
+        self.assertEqual(entries[2]['doc'], '@cache')
+        self.assertEqual(render_api_text(entries), [
+            'class Parser(Base):',
+            '    """Parse things."""',
+            *PYTHON[7:13],
+            '',
+            '@cache',
+            'def load(path="a=1"):',
+        ])
+
+    def test_docs_stop_at_previous_definition(self):
+        lines = ['@interface Widget : NSObject', '@property int count;', '- (void)draw;']
+        structure = {'interfaces': [{'name': 'Widget', 'line': 1, 'line_end': 4}],
+                     'methods': [{'name': '-[Widget draw]', 'line': 3}],
+                     'properties': [{'name': 'Widget.count', 'line': 2}]}
+        self.assertEqual(render_api_text(api_entries('Widget.h', lines, structure)), lines)
+
+
+class TestIsDocumented(unittest.TestCase):
+    """Docstrings and comments count, decorators and attributes do not."""
+
+    def test_python_module(self):
+        entries = api_entries('pkg/parser.py', PYTHON, PYTHON_STRUCTURE)
+        self.assertEqual([(e['name'], is_documented(e)) for e in entries],
+                         [('Parser', True), ('parse', True), ('load', False)])
+
+    def test_doc_lines(self):
+        cases = {
+            '# Area of a circle.': True,
+            '/** Area of a circle. */': True,
+            '@doc "Starts the worker."': True,
+            '#[inline]': False,
+            '[Obsolete]': False,
+            '@Override\n@Deprecated': False,
+            '@Override\n// Draws the shape.': True,
+            '': False,
+        }
+        for doc, expected in cases.items():
+            self.assertEqual(is_documented({'doc': doc, 'docstring': ''}), expected, doc)
+
+
+if __name__ == '__main__':
+    unittest.main()
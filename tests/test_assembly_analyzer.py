@@ -0,0 +1,137 @@
+"""Tests for assembly analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.assembly import AssemblyAnalyzer
+
+
+GAS = '''/* Boot code
+ * _fake: not a label
+ */
+#include "defs.h"
+#define STACK_SIZE 4096
+
+    .section .text
+    .globl _start, main
+    .extern printf
+
+.macro PUSH_ALL reg1, reg2
+    push \\reg1
+    push \\reg2
+.endm
+
+    .type main, @function
+_start:
+    call main
+    ret
+
+main:
+    mov $1, %eax        ; counter
+.L1:
+    dec %eax
+    jnz .L1
+    ret
+
+    .data
+msg: .asciz "hi; there"
+    .equ LEN, 10
+'''
+
+NASM = '''; NASM example
+%include "io.inc"
+SYS_EXIT equ 60
+
+%macro exit 1
+    mov rax, SYS_EXIT
+    mov rdi, %1
+    syscall
+%endmacro
+
+section .data
+greeting: db "hello", 0
+
+section .text
+global _start
+extern puts
+
+_start:
+    call puts
+    exit 0
+'''
+
+MASM = '''.model flat
+.code
+PUBLIC AddNums
+AddNums PROC
+    mov eax, [esp+4]
+@@:
+    ret
+AddNums ENDP
+
+Sum MACRO a, b
+    mov eax, a
+ENDM
+END
+'''
+
+
+class TestAssemblyAnalyzer(unittest.TestCase):
+    """Test assembly analyzer."""
+
+    def _analyze(self, content, suffix='.s'):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return AssemblyAnalyzer(f.name)
+
+    def test_gas(self):
+        """Sections, symbols, macros and labels in GNU as syntax; local labels fold in."""
+        structure = self._analyze(GAS).get_structure()
+        self.assertEqual([s['name'] for s in structure['sections']], ['.text', '.data'])
+        self.assertEqual([g['name'] for g in structure['globals']], ['_start', 'main'])
+        self.assertEqual([e['name'] for e in structure['externs']], ['printf'])
+        self.assertEqual([i['content'] for i in structure['includes']], ['#include "defs.h"'])
+        self.assertEqual([(c['name'], c['value']) for c in structure['constants']],
+                         [('STACK_SIZE', '4096'), ('LEN', '10')])
+
+        macro = structure['macros'][0]
+        self.assertEqual((macro['name'], macro['params'], macro['line_end']), ('PUSH_ALL', ['reg1', 'reg2'], 14))
+
+        labels = [(l['name'], l['line'], l.get('line_end'), l.get('visibility'), l.get('kind'))
+                  for l in structure['labels']]
+        self.assertEqual(labels, [
+            ('_start', 17, 19, 'global', None),
+            ('main', 21, 26, 'global', 'function'),
+            ('msg', 29, 30, None, None),
+        ])
+
+    def test_nasm(self):
+        """NASM directives; labels stop at the next section."""
+        structure = self._analyze(NASM, '.asm').get_structure()
+        self.assertEqual([s['name'] for s in structure['sections']], ['.data', '.text'])
+        self.assertEqual([(c['name'], c['value']) for c in structure['constants']], [('SYS_EXIT', '60')])
+        self.assertEqual([(m['name'], m['line_end']) for m in structure['macros']], [('exit', 9)])
+        self.assertEqual([i['content'] for i in structure['includes']], ['%include "io.inc"'])
+        labels = [(l['name'], l.get('line_end'), l.get('visibility')) for l in structure['labels']]
+        self.assertEqual(labels, [('greeting', None, None), ('_start', 20, 'global')])
+
+    def test_masm(self):
+        """MASM procedures span PROC..ENDP; PUBLIC marks them global."""
+        structure = self._analyze(MASM, '.asm').get_structure()
+        self.assertEqual([s['name'] for s in structure['sections']], ['.code'])
+        proc = structure['labels'][0]
+        self.assertEqual((proc['name'], proc['kind'], proc['line_end'], proc['visibility']),
+                         ('AddNums', 'proc', 8, 'global'))
+        self.assertEqual(len(structure['labels']), 1)
+        self.assertEqual([(m['name'], m['params']) for m in structure['macros']], [('Sum', ['a', 'b'])])
+
+    def test_extract_label(self):
+        """A label is extracted with its body."""
+        element = self._analyze(GAS).extract_element('label', 'main')
+        self.assertEqual((element['line_start'], element['line_end']), (21, 26))
+        self.assertTrue(element['source'].endswith('ret'))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,119 @@
+"""Tests for Avro schema and IDL analyzers."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.avro import AvroSchemaAnalyzer, AvroIDLAnalyzer
+
+
+AVSC = '''{
+  "type": "record",
+  "name": "User",
+  "namespace": "com.acme",
+  "doc": "A user",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "email", "type": ["null", "string"], "default": null},
+    {"name": "status", "type": {
+      "type": "enum", "name": "Status", "symbols": ["ACTIVE", "DISABLED"]
+    }},
+    {"name": "addresses", "type": {"type": "array", "items": {
+      "type": "record",
+      "name": "Address",
+      "fields": [{"name": "city", "type": "string"}]
+    }}},
+    {"name": "hash", "type": {"type": "fixed", "name": "MD5", "size": 16}}
+  ]
+}
+'''
+
+AVDL = '''@namespace("com.acme.mail")
+protocol Mail {
+  import idl "common.avdl";
+
+  /** A message */
+  record Message {
+    string to;
+    union { null, string } subject = null;
+    array<string> cc;
+  }
+
+  error MailError {
+    string reason;
+  }
+
+  enum Priority {
+    LOW, NORMAL,
+    HIGH
+  }
+
+  fixed Digest(16);
+
+  string send(Message message, boolean urgent = false) throws MailError;
+  void ping() oneway;
+}
+'''
+
+
+def _write(suffix, content):
+    with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+        f.write(content)
+    return f.name
+
+
+class TestAvroSchemaAnalyzer(unittest.TestCase):
+    """Test Avro JSON schema analyzer."""
+
+    def setUp(self):
+        path = _write('.avsc', AVSC)
+        self.addCleanup(os.unlink, path)
+        self.analyzer = AvroSchemaAnalyzer(path)
+        self.structure = self.analyzer.get_structure()
+
+    def test_records(self):
+        """Top-level and nested records with field types and namespace."""
+        user, address = self.structure['records']
+        self.assertEqual((user['name'], user['line'], user['line_end']), ('User', 1, 19))
+        self.assertEqual(user['namespace'], 'com.acme')
+        self.assertEqual([f['type'] for f in user['fields']],
+                         ['long', 'null|string', 'Status', 'array<Address>', 'MD5'])
+        self.assertEqual((address['line'], address['namespace']), (12, 'com.acme'))
+
+    def test_enums_and_fixed(self):
+        """Enums nested in fields and fixed types."""
+        self.assertEqual(self.structure['enums'][0]['symbols'], ['ACTIVE', 'DISABLED'])
+        self.assertEqual(self.structure['fixed'][0]['signature'], ' (16 bytes)')
+
+    def test_extract_qualified(self):
+        """Named types can be extracted by qualified name."""
+        element = self.analyzer.extract_element('record', 'com.acme.Address')
+        self.assertEqual((element['line_start'], element['line_end']), (12, 16))
+
+
+class TestAvroIDLAnalyzer(unittest.TestCase):
+    """Test Avro IDL analyzer."""
+
+    def setUp(self):
+        path = _write('.avdl', AVDL)
+        self.addCleanup(os.unlink, path)
+        self.structure = AvroIDLAnalyzer(path).get_structure()
+
+    def test_records_and_enums(self):
+        """Records and errors with fields; enums spanning lines."""
+        message, error = self.structure['records']
+        self.assertEqual(message['signature'], ' { to: string, subject: null|string, cc: array<string> }')
+        self.assertEqual(error['kind'], 'error')
+        self.assertEqual(self.structure['enums'][0]['symbols'], ['LOW', 'NORMAL', 'HIGH'])
+        self.assertEqual(self.structure['fixed'][0]['size'], '16')
+
+    def test_messages(self):
+        """Protocol messages with throws and oneway."""
+        self.assertEqual(self.structure['protocols'][0]['name'], 'Mail')
+        send, ping = self.structure['messages']
+        self.assertEqual(send['name'], 'Mail.send')
+        self.assertEqual(send['signature'], '(Message message, boolean urgent) -> string throws MailError')
+        self.assertTrue(ping['oneway'])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,108 @@
+"""Tests for Bazel/Buck build file analyzer."""
+
+import os
+import shutil
+import tempfile
+import unittest
+from reveal.base import get_analyzer
+from reveal.analyzers.bazel import BazelAnalyzer
+
+
+BUILD = '''load("@rules_java//java:defs.bzl", "java_library", "java_test")
+load(
+    "//tools:defs.bzl",
+    my_macro = "macro",
+)
+
+package(default_visibility = ["//visibility:public"])
+
+java_library(
+    name = "core",
+    srcs = glob(["src/main/**/*.java"], exclude = ["**/Test*.java"]),
+    deps = [
+        "//common:util",
+        ":model",
+    ],
+)
+
+java_library(
+    name = "model",
+    srcs = ["Model.java", "Entity.java", "Id.java", "Key.java"],
+    visibility = ["//visibility:private"],
+)
+
+java_test(
+    name = "core_test",
+    srcs = ["CoreTest.java"],
+    deps = [":core"] + select({
+        "//conditions:default": [],
+    }),
+)
+'''
+
+DEFS_BZL = '''def my_macro(name, srcs = [], **kwargs):
+    native.genrule(name = name, srcs = srcs, outs = ["x"], cmd = "true")
+
+def _impl(ctx):
+    pass
+
+my_rule = rule(
+    implementation = _impl,
+    attrs = {},
+)
+'''
+
+
+class TestBazelAnalyzer(unittest.TestCase):
+    """Test Bazel analyzer."""
+
+    def _write(self, filename, content):
+        root = tempfile.mkdtemp()
+        self.addCleanup(shutil.rmtree, root)
+        path = os.path.join(root, filename)
+        with open(path, 'w') as f:
+            f.write(content)
+        return path
+
+    def test_build_filenames_registered(self):
+        """BUILD, BUILD.bazel, BUCK and .bzl files use the Bazel analyzer."""
+        for filename in ('BUILD', 'BUILD.bazel', 'BUCK', 'defs.bzl'):
+            self.assertIs(get_analyzer(filename), BazelAnalyzer)
+
+    def test_targets(self):
+        """Rule type, srcs (glob summarized) and deps of each target."""
+        structure = BazelAnalyzer(self._write('BUILD', BUILD)).get_structure()
+        core, model, test = structure['targets']
+
+        self.assertEqual((core['name'], core['rule'], core['line'], core['line_end']),
+                         ('core', 'java_library', 9, 16))
+        self.assertEqual(core['srcs'], ['glob(src/main/**/*.java, exclude: **/Test*.java)'])
+        self.assertEqual(core['deps'], ['//common:util', ':model'])
+        self.assertEqual(model['signature'], ' (java_library) srcs: Model.java, Entity.java, Id.java, +1 more')
+        self.assertEqual(model['visibility'], ['//visibility:private'])
+        self.assertEqual(test['deps'], [':core', 'select(...)'])
+
+    def test_loads(self):
+        """load() statements, including multi-line ones with aliases."""
+        loads = BazelAnalyzer(self._write('BUILD', BUILD)).get_structure()['loads']
+        self.assertEqual(loads[0]['content'], 'load("@rules_java//java:defs.bzl", "java_library", "java_test")')
+        self.assertEqual(loads[1]['content'], 'load("//tools:defs.bzl", my_macro = "macro")')
+        self.assertEqual(loads[1]['symbols'], ['my_macro'])
+
+    def test_bzl_macros_and_rules(self):
+        """Macros and rule definitions in .bzl files."""
+        structure = BazelAnalyzer(self._write('defs.bzl', DEFS_BZL)).get_structure()
+        self.assertEqual(structure['macros'][0]['signature'], '(name, srcs, **kwargs)')
+        rule = structure['rules'][0]
+        self.assertEqual((rule['name'], rule['implementation']), ('my_rule', '_impl'))
+
+    def test_extract_target(self):
+        """Targets can be extracted by name or :label."""
+        analyzer = BazelAnalyzer(self._write('BUILD', BUILD))
+        element = analyzer.extract_element('target', ':model')
+        self.assertEqual((element['line_start'], element['line_end']), (18, 22))
+        self.assertEqual(analyzer.get_directory_summary(), 'targets: core, model, core_test')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,88 @@
+"""Tests for --budget: reducing detail until output fits a token budget."""
+
+import contextlib
+import io
+import sys
+import tempfile
+import unittest
+from pathlib import Path
+from unittest import mock
+from reveal.budget import (cut_to_budget, estimate_tokens, fit_to_budget, reduce_structure, structure_reductions,
+                           tree_levels)
+from reveal.main import main
+
+
+class TestBudget(unittest.TestCase):
+    """Reduction levels for structures and trees, and picking the first that fits."""
+
+    structure = {
+        'imports': [{'line': 1, 'content': 'import os'}],
+        'functions': [{'name': f'pub{i}', 'line': i + 2, 'signature': '(alpha: int, beta: string, gamma: seq[float])'}
+                      for i in range(30)] + [{'name': '_hidden', 'line': 40, 'signature': '()'}],
+    }
+
+    def test_estimate_tokens(self):
+        self.assertEqual(estimate_tokens(''), 0)
+        self.assertEqual(estimate_tokens('abcd'), 1)
+        self.assertEqual(estimate_tokens('abcde'), 2)
+
+    def test_reduce_structure(self):
+        lines = ['import os']
+        self.assertEqual(reduce_structure(self.structure, 'app.py', lines, 0), self.structure)
+        level1 = reduce_structure(self.structure, 'app.py', lines, 1)
+        self.assertEqual(len(level1['functions']), 30)
+        self.assertEqual(level1['imports'], self.structure['imports'])
+        level2 = reduce_structure(self.structure, 'app.py', lines, 2)
+        self.assertEqual(level2['functions'][0]['signature'], '(alpha: int, beta: string, gamma: se...)')
+        # The original is left alone
+        self.assertEqual(self.structure['functions'][0]['signature'], '(alpha: int, beta: string, gamma: seq[float])')
+        level3 = reduce_structure(self.structure, 'app.py', lines, 3)
+        self.assertNotIn('imports', level3)
+        self.assertEqual(len(reduce_structure(self.structure, 'app.py', lines, 4)['functions']), 20)
+        self.assertEqual(len(reduce_structure(self.structure, 'app.py', lines, 5)['functions']), 5)
+        self.assertEqual(structure_reductions(5), 'private symbols hidden, signatures shortened, '
+                                                  'imports and non-definitions hidden, at most 5 items per category')
+
+    def test_tree_levels(self):
+        levels = tree_levels(3, 200, False)
+        self.assertEqual(levels[:4], [{'depth': 3, 'fast': False, 'max_entries': 200},
+                                      {'depth': 2, 'fast': False, 'max_entries': 200},
+                                      {'depth': 1, 'fast': False, 'max_entries': 200},
+                                      {'depth': 1, 'fast': True, 'max_entries': 200}])
+        self.assertEqual([level['max_entries'] for level in levels[4:]], [100, 50, 25, 12, 6, 3])
+        self.assertEqual(len(tree_levels(1, 0, True)), 7)
+
+    def test_fit_to_budget(self):
+        renders = ['x' * 400, 'x' * 100, 'x' * 10]
+        self.assertEqual(fit_to_budget(renders.__getitem__, 3, 30), 'x' * 100)
+        self.assertEqual(fit_to_budget(renders.__getitem__, 3, 1000), 'x' * 400)
+        # Nothing fits: the smallest is cut, unless cutting is off
+        self.assertEqual(fit_to_budget(renders.__getitem__, 2, 5, cut=False), 'x' * 100)
+        text = '\n'.join(f'line {i}' for i in range(100))
+        cut = cut_to_budget(text, 30)
+        self.assertLessEqual(estimate_tokens(cut), 30)
+        self.assertTrue(cut.startswith('line 0\nline 1\n'))
+        self.assertTrue(cut.endswith('more lines cut to fit --budget 30'))
+
+    def test_cli(self):
+        with tempfile.TemporaryDirectory() as tmpdir:
+            path = Path(tmpdir) / 'mid.nim'
+            path.write_text(''.join(f'proc pub{i}*(alpha: int, beta: string, gamma: seq[float]): bool =\n  true\n'
+                                    f'proc priv{i}(x: int) =\n  discard\n' for i in range(40)))
+            outputs = {}
+            for budget in ('2000', '1000', '60'):
+                output = io.StringIO()
+                with mock.patch.object(sys, 'argv', ['reveal', str(path), '--budget', budget]), \
+                        contextlib.redirect_stdout(output):
+                    main()
+                outputs[budget] = output.getvalue().rstrip('\n')
+                self.assertLessEqual(estimate_tokens(outputs[budget]), int(budget))
+        self.assertIn('priv0', outputs['2000'])
+        self.assertNotIn('--budget', outputs['2000'])
+        self.assertNotIn('priv0', outputs['1000'])
+        self.assertIn('(--budget 1000: private symbols hidden', outputs['1000'])
+        self.assertTrue(outputs['60'].endswith('more lines cut to fit --budget 60'))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,94 @@
+"""Tests for Makefile and CMake analyzers."""
+
+import os
+import shutil
+import tempfile
+import unittest
+from reveal.base import get_analyzer
+from reveal.analyzers.makefile import MakefileAnalyzer
+from reveal.analyzers.cmake import CMakeAnalyzer
+
+
+MAKEFILE = '''CC ?= gcc
+CFLAGS := -O2 \\
+  -Wall
+
+.PHONY: all clean
+
+all: build
+
+build: main.o util.o
+\t$(CC) -o app $^
+
+%.o: %.c
+\t$(CC) -c $<
+
+clean:
+\trm -f *.o
+'''
+
+CMAKELISTS = '''cmake_minimum_required(VERSION 3.16)
+project(Demo VERSION 1.2 LANGUAGES CXX)
+find_package(Boost 1.70 REQUIRED COMPONENTS system filesystem)
+add_subdirectory(src)
+add_library(core STATIC
+  core.cpp
+  util.cpp)
+add_executable(app main.cpp)
+target_link_libraries(app PRIVATE core)
+'''
+
+
+class TestBuildFileAnalyzers(unittest.TestCase):
+    """Test Makefile and CMake analyzers."""
+
+    def setUp(self):
+        self.temp_dir = tempfile.mkdtemp()
+
+    def tearDown(self):
+        shutil.rmtree(self.temp_dir)
+
+    def _write(self, name, content):
+        path = os.path.join(self.temp_dir, name)
+        with open(path, 'w') as f:
+            f.write(content)
+        return path
+
+    def test_makefile_targets_phony_and_variables(self):
+        path = self._write('Makefile', MAKEFILE)
+        self.assertIs(get_analyzer(path), MakefileAnalyzer)
+        structure = MakefileAnalyzer(path).get_structure()
+
+        targets = {t['name']: t for t in structure['targets']}
+        self.assertEqual(targets['build']['prerequisites'], ['main.o', 'util.o'])
+        self.assertEqual(targets['build']['line_end'], 10)
+        self.assertIn('%.o', targets)
+
+        phony = {t['name']: t for t in structure['phony_targets']}
+        self.assertEqual(set(phony), {'all', 'clean'})
+        self.assertTrue(phony['all']['default'])
+
+        variables = {v['name']: v['signature'] for v in structure['variables']}
+        self.assertEqual(variables['CC'], ' ?= gcc')
+        self.assertEqual(variables['CFLAGS'], ' := -O2 -Wall')
+
+    def test_cmake_targets_subdirectories_and_packages(self):
+        path = self._write('CMakeLists.txt', CMAKELISTS)
+        self.assertIs(get_analyzer(path), CMakeAnalyzer)
+        structure = CMakeAnalyzer(path).get_structure()
+
+        self.assertEqual(structure['project'][0]['name'], 'Demo')
+        targets = {t['name']: t for t in structure['targets']}
+        self.assertEqual(targets['core']['kind'], 'static library')
+        self.assertEqual(targets['core']['line_end'], 7)
+        self.assertEqual(targets['app']['links'], ['core'])
+
+        self.assertEqual(structure['subdirectories'][0]['name'], 'src')
+        boost = structure['packages'][0]
+        self.assertEqual(boost['name'], 'Boost')
+        self.assertTrue(boost['required'])
+        self.assertEqual(boost['components'], ['system', 'filesystem'])
+
+
+if __name__ == '__main__':
+    unittest.main()
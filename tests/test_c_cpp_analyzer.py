@@ -0,0 +1,109 @@
+"""Tests for C and C++ analyzers."""
+
+import os
+import tempfile
+import unittest
+from pathlib import Path
+from reveal.analyzers.c_cpp import CAnalyzer, CppAnalyzer
+
+
+SAMPLE = str(Path(__file__).parent / 'samples' / 'hello.c')
+
+
+class TestCAnalyzer(unittest.TestCase):
+    """Test C analyzer on sources and headers."""
+
+    def _analyze_temp(self, content, suffix, analyzer_class=CAnalyzer):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+            path = f.name
+        # Removed after the test, so assertions can still stat the file (get_metadata)
+        self.addCleanup(os.unlink, path)
+        analyzer = analyzer_class(path)
+        return analyzer, analyzer.get_structure()
+
+    def test_source_file(self):
+        """Should extract includes and function definitions from a .c file."""
+        analyzer = CAnalyzer(SAMPLE)
+        structure = analyzer.get_structure()
+
+        self.assertEqual(analyzer.type_name, 'C')
+        self.assertEqual(analyzer.get_metadata()['kind'], 'source')
+        self.assertEqual([i['content'] for i in structure['imports']],
+                         ['#include <stdio.h>', '#include <stdlib.h>'])
+
+        by_name = {f['name']: f for f in structure['functions']}
+        self.assertEqual(set(by_name), {'main', 'greet'})
+        self.assertEqual(by_name['main']['signature'], '(int argc, char *argv[]) -> int')
+
+    def test_header_file(self):
+        """Headers list prototypes, typedefs and only structs with bodies."""
+        content = '''#ifndef POINT_H
+#define POINT_H
+#define MAX(a, b) ((a) > (b) ? (a) : (b))
+
+struct point {
+    int x;
+    int y;
+};
+
+typedef struct point point_t;
+typedef int (*compare_fn)(const void *, const void *);
+
+enum color { RED, GREEN };
+
+struct point *point_new(int x, int y);
+void point_free(struct point *p);
+
+#endif
+'''
+        analyzer, structure = self._analyze_temp(content, '.h')
+
+        self.assertEqual(analyzer.type_name, 'C Header')
+        self.assertEqual(analyzer.get_metadata()['kind'], 'header')
+        self.assertEqual([s['name'] for s in structure['structs']], ['point'])
+        self.assertEqual([t['name'] for t in structure['typedefs']], ['point_t', 'compare_fn'])
+        self.assertEqual([e['name'] for e in structure['enums']], ['color'])
+        self.assertIn('MAX', [m['name'] for m in structure['macros']])
+
+        declarations = {d['name']: d for d in structure['declarations']}
+        self.assertEqual(set(declarations), {'point_new', 'point_free'})
+        self.assertEqual(declarations['point_new']['signature'], '(int x, int y) -> struct point*')
+
+
+class TestCppAnalyzer(unittest.TestCase):
+    """Test C++ analyzer."""
+
+    def test_classes_namespaces_and_methods(self):
+        """Should extract namespaces, classes, member declarations and qualified definitions."""
+        content = '''#include <string>
+
+namespace net {
+
+class Server {
+public:
+    void start();
+    int port() const;
+};
+
+void Server::start() {
+}
+
+}
+'''
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.cpp', delete=False) as f:
+            f.write(content)
+            path = f.name
+        try:
+            structure = CppAnalyzer(path).get_structure()
+        finally:
+            os.unlink(path)
+
+        self.assertEqual([n['name'] for n in structure['namespaces']], ['net'])
+        self.assertEqual([c['name'] for c in structure['classes']], ['Server'])
+        self.assertEqual([d['name'] for d in structure['declarations']], ['start', 'port'])
+        self.assertEqual([f['name'] for f in structure['functions']], ['Server::start'])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,91 @@
+"""Tests for the on-disk structure cache."""
+
+import os
+import tempfile
+import unittest
+from unittest import mock
+from reveal import base
+from reveal.base import get_analyzer, use_source
+from reveal.cache import cache_dir, cached_structure
+
+
+class TestCachedStructure(unittest.TestCase):
+    """Hits for unchanged files, misses after edits, opt-out."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.path = os.path.join(self.tmpdir.name, 'geo.nim')
+        self.write('proc area*(r: float): float =\n  r * r\n')
+        patcher = mock.patch.dict(os.environ, {'REVEAL_CACHE_DIR': os.path.join(self.tmpdir.name, 'cache')})
+        patcher.start()
+        self.addCleanup(patcher.stop)
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def write(self, text):
+        with open(self.path, 'w') as f:
+            f.write(text)
+
+    def analyzer(self):
+        return get_analyzer(self.path)(self.path)
+
+    def structure(self, **kwargs):
+        """Cached structure of the file, and whether get_structure ran."""
+        analyzer = self.analyzer()
+        with mock.patch.object(type(analyzer), 'get_structure', wraps=analyzer.get_structure) as get_structure:
+            structure = cached_structure(analyzer, **kwargs)
+        return structure, get_structure.called
+
+    def test_hit_and_miss(self):
+        expected = self.analyzer().get_structure()
+        self.assertEqual(self.structure(), (expected, True))
+        self.assertEqual(self.structure(), (expected, False))
+        # Different options are a different entry
+        self.assertTrue(self.structure(options={'head': 1})[1])
+
+        self.write('proc area*(r: float): float =\n  r * r\n\nproc volume*(r: float): float =\n  r * r * r\n')
+        structure, analyzed = self.structure()
+        self.assertTrue(analyzed)
+        self.assertEqual(structure, self.analyzer().get_structure())
+        self.assertNotEqual(structure, expected)
+
+    def test_inputs(self):
+        # A Markdown file's broken links depend on other files: creating the target is a miss
+        self.path = os.path.join(self.tmpdir.name, 'README.md')
+        self.write('# Docs\n\nSee [the guide](guide).\n')
+        options = {'options': {'extract_links': True}}
+        structure, analyzed = self.structure(**options)
+        self.assertTrue(analyzed)
+        self.assertTrue(structure['links'][0]['broken'])
+        self.assertFalse(self.structure(**options)[1])
+
+        with open(os.path.join(self.tmpdir.name, 'guide.md'), 'w') as f:
+            f.write('# Guide\n')
+        structure, analyzed = self.structure(**options)
+        self.assertTrue(analyzed)
+        self.assertFalse(structure['links'][0]['broken'])
+
+    def test_disabled(self):
+        self.structure()
+        self.assertTrue(self.structure(enabled=False)[1])
+
+    def test_revision_not_cached(self):
+        with mock.patch.dict(base._SOURCES):
+            use_source(self.path, 'proc old*() =\n  discard\n', 'HEAD~1')
+            self.assertTrue(self.structure()[1])
+            self.assertTrue(self.structure()[1])
+
+    def test_unwritable(self):
+        with open(os.path.join(self.tmpdir.name, 'file'), 'w'):
+            pass
+        with mock.patch.dict(os.environ, {'REVEAL_CACHE_DIR': os.path.join(self.tmpdir.name, 'file')}):
+            self.assertEqual(self.structure(), (self.analyzer().get_structure(), True))
+
+    def test_cache_dir(self):
+        with mock.patch.dict(os.environ, {'REVEAL_CACHE_DIR': '', 'XDG_CACHE_HOME': '/xdg'}):
+            self.assertEqual(str(cache_dir()), os.path.join('/xdg', 'reveal'))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,90 @@
+"""Tests for call graphs."""
+
+import unittest
+from reveal.calls import call_tree, file_call_graph, project_call_graph, render_calls_dot
+
+
+LINES = [
+    'class Shape:',                        # 1
+    '    def area(self):',                 # 2
+    '        return 0',                    # 3
+    '    def describe(self):',             # 4
+    '        return "%d" % self.area()',   # 5
+    'class Circle:',                       # 6
+    '    def area(self):',                 # 7
+    '        return helper(2)',            # 8
+    'def helper(n):',                      # 9
+    '    # helper(n - 1) in a comment',    # 10
+    '    return helper(n - 1) if n else "helper(0)"',  # 11
+    'def main():',                         # 12
+    '    print(Circle().area())',          # 13
+]
+STRUCTURE = {
+    'classes': [{'line': 1, 'line_end': 5, 'name': 'Shape'}, {'line': 6, 'line_end': 8, 'name': 'Circle'}],
+    'methods': [{'line': 2, 'line_end': 3, 'name': 'Shape.area'}, {'line': 4, 'line_end': 5, 'name': 'Shape.describe'},
+                {'line': 7, 'line_end': 8, 'name': 'Circle.area'}],
+    'functions': [{'line': 9, 'line_end': 11, 'name': 'helper'}, {'line': 12, 'line_end': 13, 'name': 'main'}],
+}
+
+
+class TestFileCallGraph(unittest.TestCase):
+    """Test call detection and resolution."""
+
+    def test_edges(self):
+        """Calls resolve to the caller's own class first; strings and comments don't count."""
+        graph = file_call_graph(LINES, STRUCTURE)
+        self.assertEqual(list(graph), ['Shape.area', 'Shape.describe', 'Circle.area', 'helper', 'main'])
+        self.assertEqual(graph['Shape.describe'], [{'name': 'Shape.area', 'line': 5}])
+        self.assertEqual(graph['Circle.area'], [{'name': 'helper', 'line': 8}])
+        self.assertEqual(graph['helper'], [{'name': 'helper', 'line': 11}])
+        # main's Circle().area() could be either area
+        self.assertEqual([call['name'] for call in graph['main']], ['Shape.area', 'Circle.area'])
+
+    def test_tree(self):
+        """Trees start at uncalled functions; recursion and repeats aren't expanded twice."""
+        roots = call_tree(file_call_graph(LINES, STRUCTURE))
+        self.assertEqual([root['name'] for root in roots], ['Shape.describe', 'main'])
+        circle = roots[1]['children'][1]
+        self.assertEqual(circle['name'], 'Circle.area')
+        helper = circle['children'][0]
+        self.assertEqual(helper['children'], [{'name': 'helper', 'line': 11, 'children': [], 'recursive': True}])
+        self.assertEqual(roots[1]['children'][0], {'name': 'Shape.area', 'line': 13, 'children': [], 'seen': False})
+
+    def test_dot(self):
+        dot = render_calls_dot({'main': [{'name': 'run', 'line': 2}], 'run': []}, 'app.py')
+        self.assertIn('"main" -> "run";', dot)
+        self.assertTrue(dot.startswith('digraph "app.py" {'))
+
+
+
+class TestProjectCallGraph(unittest.TestCase):
+    """Test calls across files."""
+
+    def setUp(self):
+        server = ['class Server:', '    def start(self):', '        self.listen()', '    def listen(self):',
+                  '        log("up")', 'def log(msg):', '    pass']
+        main = ['def log(msg):', '    pass', 'def main():', '    Server().start()', '    log("x")']
+        self.graph = project_call_graph([
+            ('net/server.py', server, {
+                'classes': [{'line': 1, 'line_end': 5, 'name': 'Server'}],
+                'functions': [{'line': 2, 'line_end': 3, 'name': 'start'}, {'line': 4, 'line_end': 5, 'name': 'listen'},
+                              {'line': 6, 'line_end': 7, 'name': 'log'}]}),
+            ('main.py', main, {
+                'functions': [{'line': 1, 'line_end': 2, 'name': 'log'}, {'line': 3, 'line_end': 5, 'name': 'main'}]}),
+        ])
+
+    def test_find_qualified(self):
+        """Owner.member finds a method inside its class's line range."""
+        self.assertEqual(self.graph.find('Server.start'), ['net/server.py::start'])
+        self.assertEqual(self.graph.find('log'), ['net/server.py::log', 'main.py::log'])
+
+    def test_resolution_prefers_own_file(self):
+        """Calls resolve across files, but a function in the caller's file wins."""
+        self.assertEqual(self.graph.callers('net/server.py::start'), [{'caller': 'main.py::main', 'line': 4}])
+        self.assertEqual(self.graph.edges['main.py::main'],
+                         [{'callee': 'net/server.py::start', 'line': 4}, {'callee': 'main.py::log', 'line': 5}])
+        self.assertEqual(self.graph.edges['net/server.py::listen'], [{'callee': 'net/server.py::log', 'line': 5}])
+
+
+if __name__ == '__main__':
+    unittest.main()
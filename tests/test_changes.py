@@ -0,0 +1,80 @@
+"""Tests for changed-symbol detection (reveal changes)."""
+
+import os
+import subprocess
+import tempfile
+import unittest
+from reveal.changes import touched_symbols
+from reveal.git import GitError, changed_lines
+
+
+STRUCTURE = {
+    'classes': [{'name': 'Shape', 'line': 1, 'line_end': 10}],
+    'functions': [{'name': 'area', 'line': 2, 'line_end': 4}, {'name': 'draw', 'line': 6, 'line_end': 9},
+                  {'name': 'helper', 'line': 12, 'line_end': 13}],
+}
+
+
+class TestTouchedSymbols(unittest.TestCase):
+    """Hunk line ranges intersected with symbol ranges."""
+
+    def test_modified(self):
+        symbols, outside = touched_symbols(STRUCTURE, {3, 15}, set())
+        self.assertEqual([(s['name'], s['status'], s['changed']) for s in symbols],
+                         [('Shape', 'modified', 1), ('area', 'modified', 1)])
+        self.assertEqual(outside, 1)
+
+    def test_added(self):
+        symbols, _ = touched_symbols(STRUCTURE, {12, 13}, set())
+        self.assertEqual([(s['name'], s['status']) for s in symbols], [('helper', 'added')])
+
+    def test_deletion_inside(self):
+        symbols, outside = touched_symbols(STRUCTURE, set(), {7})
+        self.assertEqual([s['name'] for s in symbols], ['Shape', 'draw'])
+        self.assertTrue(all(s['status'] == 'modified' for s in symbols))
+        self.assertEqual(outside, 0)
+
+    def test_new_file(self):
+        symbols, _ = touched_symbols(STRUCTURE, set(), set(), new_file=True)
+        self.assertEqual(len(symbols), 4)
+        self.assertTrue(all(s['status'] == 'added' for s in symbols))
+
+
+class TestChangedLines(unittest.TestCase):
+    """git diff hunks per file against a base."""
+
+    def test_changed_lines(self):
+        with tempfile.TemporaryDirectory() as directory:
+            env = {**os.environ, 'GIT_AUTHOR_NAME': 'Ada', 'GIT_AUTHOR_EMAIL': 'ada@example.com',
+                   'GIT_COMMITTER_NAME': 'Ada', 'GIT_COMMITTER_EMAIL': 'ada@example.com'}
+            for name, text in (('app.py', 'a = 1\nb = 2\nc = 3\n'), ('old.py', 'x = 1\n'), ('my notes.py', 'x = 1\n')):
+                with open(os.path.join(directory, name), 'w') as f:
+                    f.write(text)
+            try:
+                for command in (['init', '-q'], ['add', '.'], ['commit', '-qm', 'init']):
+                    subprocess.run(['git', *command], cwd=directory, env=env, check=True, capture_output=True)
+            except (OSError, subprocess.CalledProcessError):
+                self.skipTest('git not available')
+            with open(os.path.join(directory, 'app.py'), 'w') as f:
+                f.write('a = 1\nc = 3\nd = 4\n')
+            with open(os.path.join(directory, 'my notes.py'), 'w') as f:
+                f.write('x = 2\n')
+            os.remove(os.path.join(directory, 'old.py'))
+            with open(os.path.join(directory, 'new.py'), 'w') as f:
+                f.write('n = 1\n')
+
+            files = changed_lines(directory)
+            self.assertEqual(files['app.py'], {'status': 'modified', 'lines': {3}, 'deletions': {1}})
+            self.assertEqual(files['old.py']['status'], 'deleted')
+            self.assertEqual(files['new.py']['status'], 'added')
+            # git ends ---/+++ lines of paths with spaces with a tab
+            self.assertEqual(files['my notes.py'], {'status': 'modified', 'lines': {1}, 'deletions': set()})
+
+    def test_not_a_repository(self):
+        with tempfile.TemporaryDirectory() as directory:
+            with self.assertRaises(GitError):
+                changed_lines(directory)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,99 @@
+"""Tests for CLI definition extraction (reveal cli)."""
+
+import unittest
+from reveal.cli_options import find_cli
+
+
+def options(command):
+    return [(option['names'], option.get('default'), option.get('flag', False), option['positional'])
+            for option in command['options']]
+
+
+class TestPythonCli(unittest.TestCase):
+    """argparse and click definitions, read with ast."""
+
+    def test_argparse(self):
+        source = '''
+import argparse
+parser = argparse.ArgumentParser(description='Deploy things.')
+parser.add_argument('target', help='Where to deploy')
+parser.add_argument('-v', '--verbose', action='store_true')
+parser.add_argument('--retries', type=int,
+                    default=3, help='How often')
+sub = parser.add_subparsers(dest='cmd')
+build = sub.add_parser('build', help='Build artifacts')
+group = build.add_mutually_exclusive_group()
+group.add_argument('--out', '-o', default='dist', required=True)
+remote = sub.add_parser('remote')
+add = remote.add_subparsers().add_parser('add')
+'''
+        cli = find_cli('tool.py', source.splitlines())
+        self.assertEqual(cli['frameworks'], ['argparse'])
+        root, build, remote = cli['commands']
+        self.assertEqual((root['name'], root['help']), ('', 'Deploy things.'))
+        self.assertEqual(options(root), [(['target'], None, False, True), (['--verbose', '-v'], 'False', True, False),
+                                         (['--retries'], '3', False, False)])
+        self.assertEqual((build['name'], build['help']), ('build', 'Build artifacts'))
+        self.assertEqual(options(build), [(['--out', '-o'], "'dist'", False, False)])
+        self.assertTrue(build['options'][0]['required'])
+        self.assertEqual(remote['name'], 'remote')
+
+    def test_click(self):
+        source = '''
+import click
+
+@click.group()
+def cli():
+    """Top-level group."""
+
+@cli.command('greet')
+@click.option('--count', '-c', default=1, help='Number of greetings.')
+@click.option('--shout/--no-shout', default=False)
+@click.argument('name')
+def greet_cmd(count, shout, name):
+    """Greet someone."""
+'''
+        cli = find_cli('tool.py', source.splitlines())
+        group, greet = cli['commands']
+        self.assertEqual((group['name'], group['help']), ('cli', 'Top-level group.'))
+        self.assertEqual((greet['name'], greet['help']), ('greet', 'Greet someone.'))
+        self.assertEqual(options(greet), [(['--count', '-c'], '1', False, False),
+                                          (['--shout/--no-shout'], 'False', True, False), (['name'], None, False, True)])
+
+    def test_not_a_cli(self):
+        self.assertIsNone(find_cli('lib.py', ['def add(a, b):', '    return a + b']))
+        self.assertIsNone(find_cli('broken.py', ['def (']))
+
+
+class TestGoCli(unittest.TestCase):
+    """cobra and flag definitions."""
+
+    def test_cobra(self):
+        lines = ['var rootCmd = &cobra.Command{', '\tUse:   "app [command]",', '\tShort: "App does things",', '}',
+                 'var serveCmd = &cobra.Command{Use: "serve", Short: "Run the server"}',
+                 '\trootCmd.PersistentFlags().StringVarP(&cfg, "config", "c", "", "config file (default, ~/.app)")',
+                 '\tserveCmd.Flags().IntP("port", "p", 8080, "port to listen on")',
+                 '\tserveCmd.Flags().Bool("tls", false, "enable TLS")']
+        cli = find_cli('main.go', lines)
+        self.assertEqual(cli['frameworks'], ['cobra'])
+        app, serve = cli['commands']
+        self.assertEqual((app['name'], app['help']), ('app', 'App does things'))
+        self.assertEqual(options(app), [(['--config', '-c'], '""', False, False)])
+        self.assertEqual(app['options'][0]['help'], 'config file (default, ~/.app)')
+        self.assertEqual((serve['name'], serve['help']), ('serve', 'Run the server'))
+        self.assertEqual(options(serve), [(['--port', '-p'], '8080', False, False), (['--tls'], 'false', True, False)])
+
+    def test_flag(self):
+        lines = ['\taddr = flag.String("addr", ":8080", "listen address")',
+                 '\tflag.DurationVar(&timeout, "timeout", 5*time.Second, "request timeout")',
+                 '\tmigrate := flag.NewFlagSet("migrate", flag.ExitOnError)',
+                 '\tsteps := migrate.Int("steps", 1, "steps to apply")', '\ts := strings.String("x")']
+        cli = find_cli('main.go', lines)
+        root, migrate = cli['commands']
+        self.assertEqual(options(root), [(['-addr'], '":8080"', False, False),
+                                         (['-timeout'], '5*time.Second', False, False)])
+        self.assertEqual((migrate['name'], options(migrate)), ('migrate', [(['-steps'], '1', False, False)]))
+
+
+if __name__ == '__main__':
+    unittest.main()
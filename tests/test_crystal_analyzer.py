@@ -0,0 +1,87 @@
+"""Tests for Crystal analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.crystal import CrystalAnalyzer
+
+
+SOURCE = '''require "http/server"
+
+# def commented_out; end
+module Shapes
+  enum Color : UInt8
+    Red
+    Green = 2
+  end
+
+  abstract class Shape
+    abstract def area : Float64
+
+    def describe(io : IO) : Nil
+      io << "the end"
+      [1, 2].each do |x|
+        puts x
+      end
+    end
+  end
+
+  class Circle < Shape
+    def self.unit : Circle
+      new(1.0)
+    end
+
+    private def helper(a : Int32,
+                       b : Int32 = 2) : Int32
+      a + b
+    end
+  end
+end
+
+def main(args : Array(String))
+  value = if args.empty?
+    0
+  else
+    1
+  end
+end
+'''
+
+
+class TestCrystalAnalyzer(unittest.TestCase):
+    """Test Crystal analyzer."""
+
+    def _analyze(self, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.cr', delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return CrystalAnalyzer(f.name)
+
+    def test_types(self):
+        """Requires, modules, classes and enums, with nested types qualified."""
+        structure = self._analyze(SOURCE).get_structure()
+        self.assertEqual([i['content'] for i in structure['imports']], ['require "http/server"'])
+        self.assertEqual([(m['name'], m['line_end']) for m in structure['modules']], [('Shapes', 31)])
+        classes = {c['name']: c for c in structure['classes']}
+        self.assertEqual(classes['Shapes::Circle']['signature'], ' < Shape')
+        self.assertTrue(classes['Shapes::Shape']['abstract'])
+        self.assertEqual(structure['enums'][0]['members'], ['Red', 'Green'])
+
+    def test_methods(self):
+        """Methods are attached to their type; strings and do-blocks don't confuse `end`."""
+        structure = self._analyze(SOURCE).get_structure()
+        methods = {m['name']: m for m in structure['methods']}
+        self.assertEqual(sorted(methods), ['Shapes::Circle#helper', 'Shapes::Shape#area', 'Shapes::Shape#describe'])
+        self.assertEqual(methods['Shapes::Shape#describe']['line_end'], 18)
+        self.assertEqual(methods['Shapes::Circle#helper']['signature'], '(a : Int32, b : Int32 = 2) -> Int32')
+        self.assertEqual(methods['Shapes::Circle#helper']['visibility'], 'private')
+        self.assertEqual(structure['class_methods'][0]['name'], 'Shapes::Circle.unit')
+
+    def test_top_level_function(self):
+        """Top-level defs are functions; an assigned `if` is matched with its own `end`."""
+        functions = self._analyze(SOURCE).get_structure()['functions']
+        self.assertEqual([(f['name'], f['line'], f['line_end']) for f in functions], [('main', 33, 39)])
+
+
+if __name__ == '__main__':
+    unittest.main()
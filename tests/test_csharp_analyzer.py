@@ -0,0 +1,126 @@
+"""Tests for C# analyzer."""
+
+import tempfile
+import unittest
+from unittest import mock
+from pathlib import Path
+from reveal.analyzers.csharp import CSharpAnalyzer
+
+
+FORM = '''using System;
+using System.Windows.Forms;
+
+namespace Demo.UI
+{
+    public partial class Form1 : Form
+    {
+        public string Title { get; set; }
+
+        public Form1()
+        {
+            InitializeComponent();
+        }
+
+        private void OnClick(object sender, EventArgs e)
+        {
+        }
+    }
+
+    public interface IGreeter
+    {
+        string Greet(string name);
+    }
+
+    public record Person(string Name, int Age);
+}
+'''
+
+DESIGNER = '''namespace Demo.UI
+{
+    partial class Form1
+    {
+        private void InitializeComponent() { }
+    }
+}
+'''
+
+
+class TestCSharpAnalyzer(unittest.TestCase):
+    """Test C# analyzer on a partial WinForms-style class."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        root = Path(self.tmpdir.name)
+        (root / 'Form1.cs').write_text(FORM)
+        (root / 'Form1.Designer.cs').write_text(DESIGNER)
+        (root / 'Other.cs').write_text('class Other { }\n')
+        self.path = str(root / 'Form1.cs')
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def test_partial_parts_found_in_sibling_files(self):
+        """Sibling files declaring the same partial type are found."""
+        structure = CSharpAnalyzer(self.path).get_structure()
+        self.assertEqual(structure['classes'][0]['parts'], ['Form1.Designer.cs'])
+        other = CSharpAnalyzer(str(Path(self.tmpdir.name) / 'Other.cs')).get_structure()
+        self.assertNotIn('parts', other['classes'][0])
+
+    def test_sibling_parsed_once(self):
+        """Analyzing a directory parses each unchanged sibling once, not once per file."""
+        root = Path(self.tmpdir.name)
+        for number in range(3):
+            (root / f'Form1.Part{number}.cs').write_text(f'partial class Form1 {{ void M{number}() {{ }} }}\n')
+        with mock.patch('reveal.analyzers.csharp.CSharpAnalyzer._own_partial_types',
+                        autospec=True, side_effect=CSharpAnalyzer._own_partial_types) as parse:
+            for path in sorted(root.glob('*.cs')):
+                CSharpAnalyzer(str(path)).get_structure()
+        self.assertEqual(sorted(Path(call.args[0].path).name for call in parse.call_args_list),
+                         ['Form1.Designer.cs', 'Form1.Part0.cs', 'Form1.Part1.cs', 'Form1.Part2.cs', 'Form1.cs'])
+
+    def test_structure(self):
+        """Should extract usings, namespaces, types, properties and methods."""
+        structure = CSharpAnalyzer(self.path).get_structure()
+
+        self.assertEqual(len(structure['imports']), 2)
+        self.assertEqual([n['name'] for n in structure['namespaces']], ['Demo.UI'])
+        self.assertEqual([i['name'] for i in structure['interfaces']], ['IGreeter'])
+        self.assertEqual([r['name'] for r in structure['records']], ['Person'])
+
+        form = structure['classes'][0]
+        self.assertEqual(form['name'], 'Form1')
+        self.assertEqual(form['parts'], ['Form1.Designer.cs'])
+        # Members of every part are rolled up, each with the file declaring it
+        self.assertEqual([(m['name'], m['kind'], m['file']) for m in form['members']], [
+            ('Title', 'property', 'Form1.cs'),
+            ('Form1', 'constructor', 'Form1.cs'),
+            ('OnClick', 'method', 'Form1.cs'),
+            ('InitializeComponent', 'method', 'Form1.Designer.cs'),
+        ])
+
+        self.assertEqual(structure['properties'][0]['name'], 'Title')
+        self.assertEqual(structure['properties'][0]['accessors'], ['get', 'set'])
+
+        by_name = {f['name']: f for f in structure['functions']}
+        self.assertEqual(by_name['OnClick']['visibility'], 'private')
+        self.assertEqual(by_name['Greet']['visibility'], 'public')  # interface member
+        self.assertEqual(by_name['OnClick']['signature'], '(object sender, EventArgs e) -> void')
+
+    def test_default_visibility(self):
+        """Types without an access modifier are internal at namespace level, private when nested."""
+        root = Path(self.tmpdir.name)
+        (root / 'Defaults.cs').write_text(
+            'namespace Demo\n{\n    class Outer\n    {\n        class Inner { }\n        void Run() { }\n    }\n}\n'
+            'struct Loose { }\n')
+        structure = CSharpAnalyzer(str(root / 'Defaults.cs')).get_structure()
+
+        classes = {c['name']: c['visibility'] for c in structure['classes']}
+        self.assertEqual(classes, {'Outer': 'internal', 'Inner': 'private'})
+        self.assertEqual(structure['structs'][0]['visibility'], 'internal')
+        self.assertEqual(structure['functions'][0]['visibility'], 'private')
+        designer = CSharpAnalyzer(str(root / 'Form1.Designer.cs')).get_structure()
+        self.assertEqual(designer['classes'][0]['visibility'], 'internal')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,60 @@
+"""Tests for CSV/TSV analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.csv_tsv import CsvAnalyzer
+
+
+PEOPLE = '''id,name,score,active,joined,seen_at,note
+1,Ann,3.5,true,2024-01-02,2024-01-02T10:00:00Z,"hello, world"
+2,Bob,4,false,2024-02-03,2024-02-03 11:30,
+3,"Multi
+line",5.25,yes,2024-03-04,2024-03-04T09:15:00+02:00,x
+'''
+
+
+class TestCsvAnalyzer(unittest.TestCase):
+    """Test CSV/TSV analyzer."""
+
+    def _analyze(self, content, suffix='.csv'):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False, newline='') as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return CsvAnalyzer(f.name)
+
+    def test_columns_and_types(self):
+        """Header columns with types inferred from the rows; quoted newlines are one row."""
+        structure = self._analyze(PEOPLE).get_structure()
+        columns = [(c['name'], c['type']) for c in structure['columns']]
+        self.assertEqual(columns, [
+            ('id', 'integer'), ('name', 'string'), ('score', 'float'), ('active', 'bool'),
+            ('joined', 'date'), ('seen_at', 'datetime'), ('note', 'string?'),
+        ])
+        table = structure['table'][0]
+        self.assertEqual((table['rows'], table['delimiter'], table['header']), (3, ',', True))
+
+    def test_tsv(self):
+        """Tab delimiter is detected."""
+        table = self._analyze('a\tb\n1\t2\n3\t4\n', '.tsv').get_structure()['table'][0]
+        self.assertEqual(table['delimiter'], '\t')
+        self.assertEqual(table['content'], "2 rows × 2 columns, delimiter '\\t'")
+
+    def test_semicolon_without_header(self):
+        """A first row that looks like data is counted as a row."""
+        structure = self._analyze('1;2;x\n3;4;y\n').get_structure()
+        self.assertEqual([c['name'] for c in structure['columns']], ['column1', 'column2', 'column3'])
+        table = structure['table'][0]
+        self.assertEqual((table['rows'], table['delimiter'], table['header']), (2, ';', False))
+
+    def test_empty_file(self):
+        """Empty files have no structure."""
+        self.assertEqual(self._analyze('').get_structure(), {})
+
+    def test_directory_summary(self):
+        """Directory trees show the table shape."""
+        self.assertEqual(self._analyze(PEOPLE).get_directory_summary(), '7 columns, 3 rows')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,92 @@
+"""Tests for Dart/Flutter analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.dart import DartAnalyzer
+
+
+APP_DART = '''import 'package:flutter/material.dart';
+
+void main() {
+  runApp(const MyApp());
+}
+
+class MyApp extends StatelessWidget {
+  const MyApp({super.key});
+
+  @override
+  Widget build(BuildContext context) {
+    if (true) {
+      return Container();
+    }
+    return const Counter();
+  }
+}
+
+class Counter extends StatefulWidget {
+  const Counter({super.key});
+
+  @override
+  State<Counter> createState() => _CounterState();
+}
+
+class _CounterState extends State<Counter> {
+  int _count = 0;
+
+  int get count => _count;
+
+  void _increment() {
+    setState(() {
+      _count++;
+    });
+  }
+}
+
+abstract class Repository {
+  Future<List<String>> fetchAll();
+}
+'''
+
+
+class TestDartAnalyzer(unittest.TestCase):
+    """Test Dart analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.dart', delete=False) as f:
+            f.write(APP_DART)
+            self.path = f.name
+        self.structure = DartAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_imports_and_functions(self):
+        self.assertEqual(self.structure['imports'][0]['content'],
+                         "import 'package:flutter/material.dart'")
+        self.assertEqual([f['name'] for f in self.structure['functions']], ['main'])
+
+    def test_widget_detection(self):
+        widgets = {w['name']: w for w in self.structure['widgets']}
+
+        self.assertEqual(widgets['MyApp']['widget'], 'stateless')
+        self.assertEqual(widgets['Counter']['widget'], 'stateful')
+        self.assertEqual(widgets['_CounterState']['state_of'], 'Counter')
+        self.assertEqual(widgets['_CounterState']['visibility'], 'private')
+        self.assertEqual([c['name'] for c in self.structure['classes']], ['Repository'])
+
+    def test_members(self):
+        methods = {m['name']: m for m in self.structure['methods']}
+
+        self.assertEqual(methods['MyApp']['kind'], 'constructor')
+        self.assertEqual(methods['MyApp.build']['signature'], '(BuildContext context) -> Widget')
+        self.assertEqual(methods['MyApp.build']['line_end'], 16)
+        self.assertEqual(methods['_CounterState.count']['kind'], 'getter')
+        self.assertEqual(methods['_CounterState._increment']['visibility'], 'private')
+        self.assertIn('Repository.fetchAll', methods)
+        # Control flow inside bodies is not mistaken for members
+        self.assertNotIn('MyApp.if', methods)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,149 @@
+"""Tests for the import graph."""
+
+import unittest
+from reveal.commands.deps import graph_summary
+from reveal.dependencies import build_import_graph, find_cycles, import_targets, package_graph, render_dot
+
+
+def imports(*statements):
+    return {'imports': [{'line': i, 'content': s} for i, s in enumerate(statements, 1)]}
+
+
+class TestImportTargets(unittest.TestCase):
+    """Module names pulled out of import statements."""
+
+    def test_languages(self):
+        cases = {
+            'import os, sys as system': ['os', 'sys'],
+            'from reveal.base import FileAnalyzer': ['reveal.base.FileAnalyzer'],
+            'from . import utils, models': ['.utils', '.models'],
+            'from ..core import x': ['..core.x'],
+            'from pkg import (a,\n    b as c)': ['pkg.a', 'pkg.b'],
+            'from pkg import *': ['pkg'],
+            "import { a } from './lib/a'": ['./lib/a'],
+            'const fs = require("fs")': ['fs'],
+            '#include "parser.h"': ['parser.h'],
+            '#include <stdio.h>': ['stdio.h'],
+            'use crate::config::{Config, Mode};': ['crate::config'],
+            'import static org.junit.Assert.*;': ['org.junit.Assert'],
+            'import qualified Data.Map as M': ['Data.Map'],
+            'import std/[os, strutils]': ['std/os', 'std/strutils'],
+            'require "./helpers"': ['./helpers'],
+            'x = 1': [],
+        }
+        for statement, expected in cases.items():
+            with self.subTest(statement=statement):
+                self.assertEqual(import_targets(statement), expected)
+
+
+class TestImportGraph(unittest.TestCase):
+    """Resolution of imports to project files."""
+
+    def test_resolution(self):
+        graph = build_import_graph([
+            ('src/pkg/__init__.py', {}),
+            ('src/pkg/core.py', imports('from .util import helper', 'import os')),
+            ('src/pkg/util.py', {}),
+            ('tests/test_core.py', imports('from pkg.core import run', 'import pkg')),
+            ('web/app.js', imports("import x from './lib/x.js'", "import React from 'react'")),
+            ('web/lib/x.js', {}),
+        ])
+        self.assertEqual(graph.edge_list(), [
+            ('src/pkg/core.py', 'src/pkg/util.py'),
+            ('tests/test_core.py', 'src/pkg/core.py'),
+            ('web/app.js', 'web/lib/x.js'),
+        ])
+        self.assertEqual(graph.external['src/pkg/core.py'], {'os'})
+        # A single name is not matched against nested source roots
+        self.assertEqual(graph.external['tests/test_core.py'], {'pkg'})
+        self.assertEqual(graph.external['web/app.js'], {'react'})
+
+    def test_python_submodules_and_root(self):
+        graph = build_import_graph([
+            ('app/__init__.py', {}),
+            ('app/models.py', {}),
+            ('app/helpers.py', {}),
+            ('app/views.py', imports('from app import models, settings', 'from . import helpers', 'import models',
+                                     'from typing import Dict, List')),
+            ('models.py', {}),
+        ])
+        # A name that is no submodule (settings) falls back to the package; absolute imports start at the root
+        self.assertEqual(graph.edge_list(), [
+            ('app/views.py', 'app/__init__.py'),
+            ('app/views.py', 'app/helpers.py'),
+            ('app/views.py', 'app/models.py'),
+            ('app/views.py', 'models.py'),
+        ])
+        self.assertEqual(graph.external['app/views.py'], {'typing'})
+
+    def test_dot(self):
+        graph = build_import_graph([
+            ('main.cr', imports('require "./src/lib"')),
+            ('src/lib.cr', imports('require "json"')),
+        ])
+        dot = render_dot(graph, 'demo')
+        self.assertTrue(dot.startswith('digraph "demo" {'))
+        self.assertIn('label="src/";', dot)
+        self.assertIn('"src/lib.cr" [label="lib.cr"];', dot)
+        self.assertIn('"main.cr" -> "src/lib.cr";', dot)
+        self.assertNotIn('"json"', dot)
+        self.assertIn('"src/lib.cr" -> "json";', render_dot(graph, 'demo', include_external=True))
+
+
+class TestProjectDeps(unittest.TestCase):
+    """Fan-in/fan-out summary used by reveal deps."""
+
+    def setUp(self):
+        self.graph = build_import_graph([
+            ('app.py', imports('import models', 'import views', 'import requests')),
+            ('views.py', imports('import models')),
+            ('models.py', {}),
+        ])
+
+    def test_fan_counts(self):
+        self.assertEqual(self.graph.fan_in(), {'app.py': 0, 'views.py': 1, 'models.py': 2})
+        self.assertEqual(self.graph.fan_out(), {'app.py': 2, 'views.py': 1, 'models.py': 0})
+
+    def test_summary(self):
+        summary = graph_summary(self.graph, include_external=True)
+        self.assertEqual(summary['modules'][2], {'file': 'models.py', 'fan_in': 2, 'fan_out': 0, 'imports': [],
+                                                 'imported_by': ['app.py', 'views.py'], 'external': []})
+        self.assertEqual(summary['modules'][0]['external'], ['requests'])
+        self.assertIn(['app.py', 'requests'], summary['edges'])
+        self.assertNotIn(['app.py', 'requests'], graph_summary(self.graph)['edges'])
+
+
+
+class TestCycles(unittest.TestCase):
+    """Import cycles reported by reveal deps --cycles."""
+
+    def test_no_cycles(self):
+        graph = build_import_graph([('app.py', imports('import models')), ('models.py', {})])
+        self.assertEqual(find_cycles(graph), [])
+
+    def test_shortest_chain_per_cycle(self):
+        graph = build_import_graph([
+            ('a.py', imports('import b', 'import d')),
+            ('b.py', imports('import c')),
+            ('c.py', imports('import a')),
+            ('d.py', imports('import a')),
+            ('x.py', imports('import y')),
+            ('y.py', imports('import x')),
+            ('z.py', imports('import x')),
+        ])
+        self.assertEqual(find_cycles(graph), [['a.py', 'd.py', 'a.py'], ['x.py', 'y.py', 'x.py']])
+
+    def test_package_cycles(self):
+        # Go-style: importing a directory pulls in its package
+        graph = build_import_graph([
+            ('server/http.go', imports('import "example.com/app/store"')),
+            ('server/routes.go', {}),
+            ('store/db.go', imports('import "example.com/app/server"')),
+        ])
+        self.assertEqual(graph.edges['server/http.go'], {'store/db.go'})
+        self.assertEqual(find_cycles(graph), [['server/http.go', 'store/db.go', 'server/http.go']])
+        self.assertEqual(find_cycles(package_graph(graph)), [['server', 'store', 'server']])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,123 @@
+"""Tests for the structural diff (reveal diff)."""
+
+import unittest
+from reveal.diff import (diff_summary, diff_symbols, diff_trees, file_symbols, render_diff_markdown,
+                         render_diff_text, tree_summary)
+
+
+OLD = [
+    'def load(path)',
+    '  # read it',
+    '  File.read(path)',
+    'end',
+    '',
+    'def parse(text)',
+    '  text.split(",")',
+    'end',
+    '',
+    'def legacy',
+    '  puts "old"',
+    '  nil',
+    'end',
+]
+
+NEW = [
+    'def load(path, mode = "r")',
+    '  File.read(path)',
+    'end',
+    '',
+    'def parse_csv(text)',
+    '  text.split(",")',
+    'end',
+    '',
+    'def report(rows)',
+    '  rows.each { |row| puts row }',
+    '  rows.size',
+    'end',
+]
+
+
+def functions(*ranges):
+    return {'functions': [{'name': name, 'line': line, 'line_end': end} for name, line, end in ranges]}
+
+
+class TestDiffSymbols(unittest.TestCase):
+    """Added, removed, renamed and changed symbols."""
+
+    def setUp(self):
+        old = file_symbols('old.cr', OLD, functions(('load', 1, 4), ('parse', 6, 8), ('legacy', 10, 13)))
+        new = file_symbols('new.cr', NEW, functions(('load', 1, 3), ('parse_csv', 5, 7), ('report', 9, 12)))
+        self.diff = diff_symbols(old, new)
+
+    def test_added_removed_renamed(self):
+        self.assertEqual([s['name'] for s in self.diff['added']], ['report'])
+        self.assertEqual([s['name'] for s in self.diff['removed']], ['legacy'])
+        self.assertEqual([(r['old'], r['new'], r['similarity']) for r in self.diff['renamed']],
+                         [('parse', 'parse_csv', 1.0)])
+
+    def test_signature_change_ignores_comments(self):
+        self.assertEqual(self.diff['changed'], [{
+            'kind': 'function', 'name': 'load', 'line': 1, 'old_lines': 4, 'new_lines': 3, 'body_changed': False,
+            'old_signature': 'def load(path)', 'new_signature': 'def load(path, mode = "r")',
+        }])
+        self.assertEqual(diff_summary(self.diff), '1 added, 1 removed, 1 renamed, 1 changed, 0 unchanged')
+
+    def test_render(self):
+        self.assertEqual(render_diff_text(self.diff), [
+            'Added (1):',
+            '  + function report  (line 9, 4 lines)',
+            'Removed (1):',
+            '  - function legacy  (was line 10, 4 lines)',
+            'Renamed (1):',
+            '  ~ function parse → parse_csv  (100% similar, 3 lines)',
+            'Changed (1):',
+            '  ~ function load  (4 → 3 lines, -1)',
+            '      - def load(path)',
+            '      + def load(path, mode = "r")',
+        ])
+
+    def test_repeated_names(self):
+        lines = ['area(c) = 1', 'area(b) = 2']
+        old = file_symbols('a.jl', lines, functions(('area', 1, 1), ('area', 2, 2)))
+        new = file_symbols('a.jl', lines[:1], functions(('area', 1, 1)))
+        diff = diff_symbols(old, new)
+        self.assertEqual((len(diff['removed']), diff['unchanged']), (1, 1))
+
+
+GO_OLD = ['func Load(path string) error {', '\treturn read(path)', '}', 'func helper() {', '\tprintln(1)', '}']
+GO_NEW = ['func Load(path string, strict bool) error {', '\treturn read(path)', '}',
+          'func helper() {', '\tprintln(2)', '}']
+
+
+class TestDiffTrees(unittest.TestCase):
+    """Files paired by relative path, public symbols only by default."""
+
+    def setUp(self):
+        structure = functions(('Load', 1, 3), ('helper', 4, 6))
+        self.old = {'pkg/store.go': (GO_OLD, structure), 'pkg/legacy.go': (GO_OLD[:3], functions(('Load', 1, 3)))}
+        self.new = {'pkg/store.go': (GO_NEW, structure),
+                    'pkg/cache.go': (GO_OLD, functions(('Get', 1, 3), ('evict', 4, 6)))}
+
+    def test_public_changes(self):
+        tree = diff_trees(self.old, self.new)
+        self.assertEqual(tree['added_files'], [{'file': 'pkg/cache.go', 'symbols': ['Get']}])
+        self.assertEqual(tree['removed_files'], ['pkg/legacy.go'])
+        self.assertEqual([(f['file'], [c['name'] for c in f['changed']]) for f in tree['files']],
+                         [('pkg/store.go', ['Load'])])
+        self.assertEqual(tree_summary(tree),
+                         '1 new, 1 removed, 1 changed files; symbols: 0 added, 0 removed, 0 renamed, 1 changed')
+        self.assertEqual(render_diff_markdown(tree['files'][0]), [
+            '- Changed function `Load`: `func Load(path string) error` → `func Load(path string, strict bool) error`',
+        ])
+
+    def test_private_changes(self):
+        tree = diff_trees(self.old, self.new, public_only=False)
+        self.assertEqual(tree['added_files'][0]['symbols'], ['Get', 'evict'])
+        self.assertEqual([c['name'] for c in tree['files'][0]['changed']], ['Load', 'helper'])
+
+    def test_unchanged_tree(self):
+        self.assertEqual(diff_trees(self.old, self.old), {'added_files': [], 'removed_files': [], 'files': []})
+
+
+if __name__ == '__main__':
+    unittest.main()
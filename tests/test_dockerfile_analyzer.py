@@ -312,6 +312,52 @@ CMD ["nginx", "-g", "daemon off;"]
             os.unlink(path)
             os.rmdir(os.path.dirname(path))
 
+    def test_multi_stage_summary(self):
+        """Stages summarize base image, ports, COPY sources and ENTRYPOINT/CMD."""
+        content = """FROM node:18 AS builder
+WORKDIR /app
+COPY package.json package-lock.json ./
+RUN npm ci
+
+FROM nginx:alpine
+COPY --from=builder /app/dist /usr/share/nginx/html
+EXPOSE 80 443
+ENTRYPOINT ["/docker-entrypoint.sh"]
+CMD ["nginx", "-g", "daemon off;"]
+"""
+        path = self.create_temp_dockerfile(content)
+        try:
+            analyzer = DockerfileAnalyzer(path)
+            structure = analyzer.get_structure()
+
+            builder, final = structure['stages']
+            self.assertEqual(builder['name'], 'builder')
+            self.assertEqual(builder['base'], 'node:18')
+            self.assertEqual((builder['line'], builder['line_end']), (1, 5))
+            self.assertEqual(builder['copy_sources'], ['package.json', 'package-lock.json'])
+
+            self.assertEqual(final['name'], 'stage 1')
+            self.assertEqual(final['ports'], ['80', '443'])
+            self.assertEqual(final['copies_from'], ['builder'])
+            self.assertEqual(final['cmd'], '["nginx", "-g", "daemon off;"]')
+
+            copy = structure['copy'][1]
+            self.assertEqual(copy['from_stage'], 'builder')
+            self.assertEqual(copy['sources'], ['/app/dist'])
+            self.assertEqual(copy['dest'], '/usr/share/nginx/html')
+
+            self.assertEqual(analyzer.get_directory_summary(), 'node:18 -> nginx:alpine, expose 80 443')
+
+        finally:
+            os.unlink(path)
+            os.rmdir(os.path.dirname(path))
+
+    def test_dockerfile_name_variants(self):
+        """Dockerfile.prod, Containerfile and *.dockerfile use this analyzer."""
+        from reveal.base import get_analyzer
+        for name in ('Dockerfile.prod', 'Containerfile', 'api.dockerfile'):
+            self.assertIs(get_analyzer(name), DockerfileAnalyzer, name)
+
 
 if __name__ == '__main__':
     unittest.main()
@@ -0,0 +1,77 @@
+"""Tests for duplicate function detection (reveal dupes)."""
+
+import unittest
+from reveal.dupes import body_tokens, find_duplicates, project_functions, shingles, similarity
+
+
+LOAD = [
+    'proc load{0}(path: string): seq[string] =',
+    '  var items: seq[string] = @[]',
+    '  for line in lines(path):',
+    '    if line.len > {1}:',
+    '      items.add(line.strip(){2})',
+    '  if items.len == 0:',
+    '    echo "no {0}"',
+    '  result = items',
+]
+
+
+def load(name, minimum=0, extra=''):
+    return [line.format(name, minimum, extra) for line in LOAD]
+
+
+def project(*bodies):
+    """One file per body, each a single function spanning the whole file."""
+    return [(f'{number}.nim', lines, {'functions': [{'name': f'f{number}', 'line': 1, 'line_end': len(lines)}]})
+            for number, lines in enumerate(bodies)]
+
+
+class TestTokens(unittest.TestCase):
+    """Normalized body tokens."""
+
+    def test_comments_literals_and_formatting(self):
+        a = ['def f(x)', '  # first', '  puts "a", 1', 'end']
+        b = ['def g(y)', '  puts    "b",  2  # trailing', 'end']
+        self.assertEqual(body_tokens(a, 1, 4, 'a.cr'), ['puts', 'STR', ',', 'NUM', 'end'])
+        self.assertEqual(body_tokens(b, 1, 3, 'b.cr')[:4], ['puts', 'STR', ',', 'NUM'])
+
+    def test_block_comments(self):
+        lines = ['int f() {', '  /* old', '     code */', '  return 1;', '}']
+        self.assertEqual(body_tokens(lines, 1, 5, 'f.c'), ['return', 'NUM', ';', '}'])
+
+    def test_similarity(self):
+        a = shingles(list('abcdefgh'))
+        self.assertEqual(similarity(a, a), 1.0)
+        self.assertEqual(similarity(a, shingles(list('abcdefgX'))), 0.6)
+        self.assertEqual(similarity(a, shingles(list('zyxwvuts'))), 0.0)
+
+
+class TestFindDuplicates(unittest.TestCase):
+    """Groups of similar functions."""
+
+    def groups(self, files, threshold=0.8, min_lines=3):
+        return find_duplicates(project_functions(files, min_lines=min_lines), threshold=threshold)
+
+    def test_identical_bodies(self):
+        groups = self.groups(project(load('Users'), load('Groups')))
+        self.assertEqual(len(groups), 1)
+        self.assertEqual(groups[0]['similarity'], 1.0)
+        self.assertEqual([f['name'] for f in groups[0]['functions']], ['f0', 'f1'])
+        self.assertEqual(groups[0]['lines'], 8)
+
+    def test_near_duplicates(self):
+        files = project(load('Users'), load('Roles', 1, '.toLower()'))
+        self.assertEqual(self.groups(files, threshold=1.0), [])
+        groups = self.groups(files)
+        self.assertEqual(len(groups), 1)
+        self.assertTrue(0.8 <= groups[0]['similarity'] < 1.0)
+
+    def test_distinct_and_short_functions(self):
+        other = ['proc other(a, b: int): int =', '  var total = 0', '  for i in a..b:', '    total += i * 2',
+                 '  while total > 100:', '    total = total div 3', '  total']
+        self.assertEqual(self.groups(project(load('Users'), other)), [])
+        self.assertEqual(self.groups(project(load('Users'), load('Groups')), min_lines=10), [])
+
+
+if __name__ == '__main__':
+    unittest.main()
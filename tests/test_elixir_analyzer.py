@@ -0,0 +1,85 @@
+"""Tests for Elixir analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.elixir import ElixirAnalyzer
+
+
+WORKER_EX = '''defmodule MyApp.Worker do
+  use GenServer
+  alias MyApp.Repo
+
+  def start_link(opts \\\\ []) do
+    GenServer.start_link(__MODULE__, opts)
+  end
+
+  @impl true
+  def init(state), do: {:ok, state}
+
+  def handle_cast({:put, v}, _state) do
+    Enum.each([v], fn x -> x end)
+    {:noreply, v}
+  end
+
+  defp format(%{name: name}), do: name
+  defp format(other) do
+    inspect(other)
+  end
+
+  defmacro debug(msg) do
+    quote do
+      IO.inspect(unquote(msg))
+    end
+  end
+
+  defmodule State do
+    def new, do: %{}
+  end
+end
+'''
+
+
+class TestElixirAnalyzer(unittest.TestCase):
+    """Test Elixir analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.ex', delete=False) as f:
+            f.write(WORKER_EX)
+            self.path = f.name
+        self.structure = ElixirAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_modules_and_directives(self):
+        modules = [(m['name'], m['line'], m['line_end']) for m in self.structure['modules']]
+        self.assertEqual(modules, [('MyApp.Worker', 1, 31), ('MyApp.Worker.State', 28, 30)])
+        self.assertEqual([i['content'] for i in self.structure['imports']],
+                         ['use GenServer', 'alias MyApp.Repo'])
+
+    def test_functions_with_arity(self):
+        functions = {f['name']: f for f in self.structure['functions']}
+
+        self.assertEqual(set(functions), {'start_link/1', 'format/1', 'new/0'})
+        self.assertEqual(functions['start_link/1']['min_arity'], 0)
+        self.assertEqual(functions['start_link/1']['line_end'], 7)
+
+        # Clauses of format/1 merge into one private entry
+        self.assertEqual(functions['format/1']['visibility'], 'private')
+        self.assertEqual(functions['format/1']['clauses'], 2)
+        self.assertEqual(functions['format/1']['line_end'], 20)
+
+        self.assertEqual(functions['new/0']['module'], 'MyApp.Worker.State')
+
+    def test_genserver_callbacks(self):
+        callbacks = {c['name']: c['behaviour'] for c in self.structure['callbacks']}
+        self.assertEqual(callbacks, {'init/1': 'GenServer', 'handle_cast/2': 'GenServer'})
+
+    def test_macros(self):
+        macro = self.structure['macros'][0]
+        self.assertEqual((macro['name'], macro['line_end']), ('debug/1', 26))
+
+
+if __name__ == '__main__':
+    unittest.main()
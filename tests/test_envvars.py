@@ -0,0 +1,74 @@
+"""Tests for environment variable reads (reveal env)."""
+
+import contextlib
+import io
+import json
+import os
+import tempfile
+import unittest
+from reveal.commands import EnvCommand, run_command
+from reveal.envvars import env_variables, find_env_reads
+
+
+class TestFindEnvReads(unittest.TestCase):
+    """Reads per language, fallbacks and writes."""
+
+    def reads(self, file_name, lines):
+        return [(read['name'], read.get('default')) for read in find_env_reads(file_name, lines)]
+
+    def test_python(self):
+        lines = ['url = os.environ["DATABASE_URL"]',
+                 'port = int(os.getenv("PORT", "8080"))',
+                 'os.environ["WRITTEN"] = "1"',
+                 'debug = os.environ.get("DEBUG") or "0"',
+                 '# os.getenv("COMMENTED")',
+                 'if os.environ["MODE"] == "prod": pass']
+        self.assertEqual(self.reads('settings.py', lines),
+                         [('DATABASE_URL', None), ('PORT', '"8080"'), ('DEBUG', '"0"'), ('MODE', None)])
+
+    def test_javascript(self):
+        lines = ['const port = process.env.PORT || 3000;',
+                 "const { HOST = 'localhost', API_KEY: key } = process.env;",
+                 'const mode = process.env["NODE_ENV"] ?? "dev";']
+        self.assertEqual(self.reads('server.ts', lines),
+                         [('PORT', '3000'), ('HOST', "'localhost'"), ('API_KEY', None), ('NODE_ENV', '"dev"')])
+
+    def test_other_languages(self):
+        self.assertEqual(self.reads('main.go', ['\tdsn := os.Getenv("DSN")', '\tv, ok := os.LookupEnv("OPT")']),
+                         [('DSN', None), ('OPT', None)])
+        self.assertEqual(self.reads('app.rb', ['ENV.fetch("RAILS_ENV", "development")', 'ENV["SECRET"]']),
+                         [('RAILS_ENV', '"development"'), ('SECRET', None)])
+        self.assertEqual(self.reads('main.rs', ['let home = std::env::var("HOME")?;']), [('HOME', None)])
+        self.assertEqual(self.reads('App.java', ['String token = System.getenv("TOKEN");']), [('TOKEN', None)])
+
+    def test_env_variables(self):
+        reads = [{'name': 'PORT', 'line': 1, 'default': '80'}, {'name': 'DSN', 'line': 2},
+                 {'name': 'PORT', 'line': 3, 'default': '80'}, {'name': 'PORT', 'line': 4, 'default': '8080'}]
+        variables = env_variables(reads)
+        self.assertEqual([(v['name'], v['required'], v['defaults'], len(v['uses'])) for v in variables],
+                         [('DSN', True, [], 1), ('PORT', False, ['80', '8080'], 3)])
+
+
+class TestEnvCommand(unittest.TestCase):
+    """reveal env over a directory."""
+
+    def test_command(self):
+        with tempfile.TemporaryDirectory() as directory:
+            with open(os.path.join(directory, 'server.js'), 'w') as f:
+                f.write('const port = process.env.PORT || 3000;\nconst key = process.env.API_KEY;\n')
+            with open(os.path.join(directory, 'README.md'), 'w') as f:
+                f.write('Set the token: `process.env.GITHUB_TOKEN`\n')
+            output = io.StringIO()
+            with contextlib.redirect_stdout(output):
+                code = run_command(EnvCommand, [directory, '--required', '--format', 'json'])
+            self.assertEqual(code, 0)
+            result = json.loads(output.getvalue())
+            self.assertEqual([v['name'] for v in result['variables']], ['API_KEY'])
+            self.assertEqual(result['variables'][0]['uses'][0]['line'], 2)
+
+            with contextlib.redirect_stderr(io.StringIO()):
+                self.assertEqual(run_command(EnvCommand, [os.path.join(directory, 'missing')]), 2)
+
+
+if __name__ == '__main__':
+    unittest.main()
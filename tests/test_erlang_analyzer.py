@@ -0,0 +1,88 @@
+"""Tests for Erlang analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.erlang import ErlangAnalyzer
+
+
+COUNTER_ERL = '''%%% A counter server
+-module(counter).
+-behaviour(gen_server).
+
+-include_lib("kernel/include/logger.hrl").
+
+-export([start_link/0,
+         increment/1]).
+-export([init/1, handle_call/3]).
+
+-record(state, {count = 0 :: integer(), name}).
+-type count() :: non_neg_integer().
+
+-spec start_link() -> {ok, pid()}.
+start_link() ->
+    gen_server:start_link(?MODULE, [], []).
+
+increment(N) when is_integer(N) ->
+    gen_server:cast(?MODULE, {inc, N}).
+
+init([]) -> {ok, #state{}}.
+
+handle_call(value, _From, State = #state{count = C}) ->
+    {reply, C, State};
+handle_call(_Other, _From, State) ->
+    {reply, {error, "unknown. request"}, State}.
+
+helper(<<A:8, B/binary>>, X) -> {A, B, X}.
+'''
+
+
+class TestErlangAnalyzer(unittest.TestCase):
+    """Test Erlang analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.erl', delete=False) as f:
+            f.write(COUNTER_ERL)
+            self.path = f.name
+        self.analyzer = ErlangAnalyzer(self.path)
+        self.structure = self.analyzer.get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_module_attributes(self):
+        """Module, behaviour and exports across multi-line lists."""
+        self.assertEqual(self.structure['module'][0]['name'], 'counter')
+        self.assertEqual(self.structure['behaviours'][0]['name'], 'gen_server')
+        self.assertEqual([e['name'] for e in self.structure['exports']],
+                         ['start_link/0', 'increment/1', 'init/1', 'handle_call/3'])
+        self.assertEqual(self.structure['exports'][1]['line'], 8)
+        self.assertEqual(self.structure['records'][0]['fields'], ['count', 'name'])
+        self.assertEqual(self.structure['types'][0]['name'], 'count()')
+
+    def test_functions_with_arity(self):
+        """Functions are name/arity, with visibility and specs."""
+        functions = {f['name']: f for f in self.structure['functions']}
+        self.assertEqual(set(functions), {'start_link/0', 'increment/1', 'helper/2'})
+        self.assertEqual(functions['start_link/0']['spec'], '() -> {ok, pid()}')
+        self.assertEqual(functions['increment/1']['visibility'], 'public')
+        self.assertEqual(functions['helper/2']['visibility'], 'private')
+
+    def test_callbacks(self):
+        """gen_server callbacks are split out; clauses are merged."""
+        callbacks = {c['name']: c for c in self.structure['callbacks']}
+        self.assertEqual(set(callbacks), {'init/1', 'handle_call/3'})
+        handle_call = callbacks['handle_call/3']
+        self.assertEqual(handle_call['behaviour'], 'gen_server')
+        self.assertEqual(handle_call['clauses'], 2)
+        self.assertEqual((handle_call['line'], handle_call['line_end']), (23, 26))
+
+    def test_extract_by_bare_name(self):
+        """Elements can be extracted without the arity."""
+        element = self.analyzer.extract_element('function', 'handle_call')
+        self.assertEqual(element['name'], 'handle_call/3')
+        self.assertEqual(element['line_start'], 23)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,108 @@
+"""Tests for Fortran analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.fortran import FortranAnalyzer
+
+
+GEOMETRY_F90 = '''! Geometry module
+module geometry
+  use iso_fortran_env, only: real64, &
+                             int32
+  implicit none
+
+  type, extends(shape) :: circle
+     real(real64) :: r
+  end type circle
+
+  interface area
+     module procedure area_circle
+  end interface area
+
+contains
+
+  pure function area_circle(c) result(a)
+    type(circle), intent(in) :: c
+    real(real64) :: a
+    a = 3.14159_real64 * c%r**2
+  end function area_circle
+
+  subroutine scale(c, &
+                   factor)
+    type(circle), intent(inout) :: c
+    if (factor > 0) then
+       c%r = c%r * factor
+    end if
+  end subroutine scale
+
+end module geometry
+
+program main
+  use geometry
+  call scale(c, 2.0d0)
+end program main
+'''
+
+LEGACY_F = '''C     Legacy fixed-form code
+      PROGRAM LEGACY
+      CALL SOLVE(N,
+     &           2.0)
+      END
+*
+      SUBROUTINE SOLVE(N, X)
+      INTEGER N
+ 100  CONTINUE
+      END
+      REAL FUNCTION F(X)
+      F = X*X
+      END
+'''
+
+
+class TestFortranAnalyzer(unittest.TestCase):
+    """Test Fortran analyzer."""
+
+    def _structure(self, suffix, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+            path = f.name
+        self.addCleanup(os.unlink, path)
+        return FortranAnalyzer(path).get_structure()
+
+    def test_free_form_units(self):
+        """Modules, programs and procedures with their extent and parent."""
+        structure = self._structure('.f90', GEOMETRY_F90)
+        module = structure['modules'][0]
+        self.assertEqual((module['name'], module['line'], module['line_end']), ('geometry', 2, 31))
+        self.assertEqual(structure['programs'][0]['name'], 'main')
+        scale = structure['subroutines'][0]
+        self.assertEqual(scale['signature'], '(c, factor)')
+        self.assertEqual(scale['parent'], 'geometry')
+        self.assertEqual(scale['line_end'], 29)
+
+    def test_function_return_type(self):
+        """Result variable types are looked up in the declarations."""
+        function = self._structure('.f90', GEOMETRY_F90)['functions'][0]
+        self.assertEqual(function['name'], 'area_circle')
+        self.assertEqual(function['result'], 'a')
+        self.assertEqual(function['returns'], 'real(real64)')
+
+    def test_uses_types_interfaces(self):
+        """Continued use statements, extended types and generic interfaces."""
+        structure = self._structure('.f90', GEOMETRY_F90)
+        self.assertEqual(structure['uses'][0]['signature'], ', only: real64, int32')
+        self.assertEqual(structure['types'][0]['extends'], 'shape')
+        self.assertEqual(structure['interfaces'][0]['procedures'], ['area_circle'])
+
+    def test_fixed_form(self):
+        """Column-based comments, continuations and labels."""
+        structure = self._structure('.f', LEGACY_F)
+        self.assertEqual(structure['programs'][0]['name'], 'LEGACY')
+        self.assertEqual(structure['subroutines'][0]['signature'], '(N, X)')
+        self.assertEqual(structure['subroutines'][0]['line_end'], 10)
+        self.assertEqual(structure['functions'][0]['returns'], 'REAL')
+
+
+if __name__ == '__main__':
+    unittest.main()
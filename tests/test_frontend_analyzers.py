@@ -0,0 +1,92 @@
+"""Tests for HTML and CSS/SCSS analyzers."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.html import HTMLAnalyzer
+from reveal.analyzers.css import CSSAnalyzer, SCSSAnalyzer
+
+
+INDEX_HTML = '''<!DOCTYPE html>
+<html>
+<head>
+  <title>Demo</title>
+  <link rel="stylesheet" href="site.css">
+  <script src="app.js"></script>
+</head>
+<body>
+  <main id="app">
+    <h1>Welcome</h1>
+    <section id="features">
+      <h2>Features</h2>
+      <img src="a.png">
+    </section>
+  </main>
+</body>
+</html>
+'''
+
+SITE_CSS = ''':root { --gap: 4px; }
+/* .commented { } */
+.btn { color: red; }
+@media (max-width: 600px) {
+  .btn { display: block; }
+}
+'''
+
+SITE_SCSS = '''@use 'theme';
+$primary: #333;
+@mixin button($size) {
+  padding: $size;
+}
+.card {
+  &:hover { color: $primary; }
+  .title { font-weight: bold; }
+}
+'''
+
+
+class TestFrontendAnalyzers(unittest.TestCase):
+    """Test HTML, CSS and SCSS structure extraction."""
+
+    def _analyze(self, analyzer_class, suffix, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+            path = f.name
+        self.addCleanup(os.unlink, path)
+        return analyzer_class(path).get_structure()
+
+    def test_html_outline_includes_and_sections(self):
+        structure = self._analyze(HTMLAnalyzer, '.html', INDEX_HTML)
+
+        self.assertEqual(structure['title'][0]['content'], 'Demo')
+        self.assertEqual([(h['name'], h['level']) for h in structure['headings']],
+                         [('Welcome', 1), ('Features', 2)])
+        self.assertEqual(structure['scripts'][0]['name'], 'app.js')
+        self.assertEqual(structure['styles'][0]['name'], 'site.css')
+
+        sections = {s['name']: s for s in structure['sections']}
+        self.assertEqual((sections['#app']['line'], sections['#app']['line_end']), (9, 15))
+        self.assertEqual(sections['#features']['line_end'], 14)
+
+    def test_css_selectors_variables_and_media(self):
+        structure = self._analyze(CSSAnalyzer, '.css', SITE_CSS)
+
+        self.assertEqual(structure['variables'][0]['name'], '--gap')
+        selectors = [(s['name'], s.get('signature', '')) for s in structure['selectors']]
+        self.assertEqual(selectors, [(':root', ''), ('.btn', ''),
+                                     ('.btn', '  (@media (max-width: 600px))')])
+        self.assertEqual(structure['media_queries'][0]['line_end'], 6)
+
+    def test_scss_mixins_variables_and_nesting(self):
+        structure = self._analyze(SCSSAnalyzer, '.scss', SITE_SCSS)
+
+        self.assertEqual(structure['imports'][0]['content'], "@use 'theme'")
+        self.assertEqual(structure['variables'][0]['name'], '$primary')
+        self.assertEqual(structure['mixins'][0]['name'], 'button')
+        self.assertEqual([s['name'] for s in structure['selectors']],
+                         ['.card', '.card:hover', '.card .title'])
+
+
+if __name__ == '__main__':
+    unittest.main()
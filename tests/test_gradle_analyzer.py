@@ -0,0 +1,120 @@
+"""Tests for Gradle build script analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.base import get_analyzer
+from reveal.analyzers.gradle import GradleAnalyzer
+
+
+BUILD_GRADLE = '''buildscript {
+    dependencies {
+        classpath 'com.android.tools.build:gradle:8.1.0'
+    }
+}
+
+plugins {
+    id 'java'
+    id 'org.springframework.boot' version '3.1.0'
+}
+
+apply plugin: 'idea'
+
+dependencies {
+    implementation 'org.springframework.boot:spring-boot-starter-web'
+    implementation project(':core')
+    api group: 'com.google.guava', name: 'guava', version: '32.0-jre'
+    testImplementation('org.junit.jupiter:junit-jupiter') {
+        exclude group: 'org.hamcrest'
+    }
+}
+
+task copyDocs(type: Copy) {
+    from 'src/docs'
+}
+
+tasks.register('hello') {
+    doLast { println 'hi' }
+}
+'''
+
+BUILD_GRADLE_KTS = '''plugins {
+    `java-library`
+    kotlin("jvm") version "1.9.0"
+}
+dependencies {
+    implementation("com.squareup.okhttp3:okhttp:4.11.0")
+}
+tasks.register<Zip>("bundle") {
+    from("build")
+}
+'''
+
+SETTINGS_GRADLE = '''rootProject.name = 'demo'
+include ':app', ':core'
+'''
+
+
+class TestGradleAnalyzer(unittest.TestCase):
+    """Test Gradle analyzer."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.mkdtemp()
+
+    def tearDown(self):
+        for name in os.listdir(self.tmpdir):
+            os.unlink(os.path.join(self.tmpdir, name))
+        os.rmdir(self.tmpdir)
+
+    def _structure(self, filename, content):
+        path = os.path.join(self.tmpdir, filename)
+        with open(path, 'w') as f:
+            f.write(content)
+        return GradleAnalyzer(path).get_structure()
+
+    def test_plugins(self):
+        """plugins { } entries and apply plugin: with versions."""
+        structure = self._structure('build.gradle', BUILD_GRADLE)
+        self.assertEqual([p['name'] for p in structure['plugins']],
+                         ['java', 'org.springframework.boot', 'idea'])
+        self.assertEqual(structure['plugins'][1]['version'], '3.1.0')
+
+    def test_dependencies_by_configuration(self):
+        """Each configuration gets its own category."""
+        structure = self._structure('build.gradle', BUILD_GRADLE)
+        self.assertEqual([d['name'] for d in structure['implementation_dependencies']],
+                         ['org.springframework.boot:spring-boot-starter-web', 'project(:core)'])
+        self.assertEqual(structure['api_dependencies'][0]['name'], 'com.google.guava:guava:32.0-jre')
+        self.assertEqual(structure['test_implementation_dependencies'][0]['name'],
+                         'org.junit.jupiter:junit-jupiter')
+        self.assertEqual(structure['classpath_dependencies'][0]['scope'], 'buildscript')
+
+    def test_tasks(self):
+        """task and tasks.register definitions with their type and extent."""
+        structure = self._structure('build.gradle', BUILD_GRADLE)
+        self.assertEqual([t['name'] for t in structure['tasks']], ['copyDocs', 'hello'])
+        self.assertEqual(structure['tasks'][0]['type'], 'Copy')
+        self.assertEqual(structure['tasks'][0]['line_end'], 25)
+
+    def test_kotlin_dsl(self):
+        """Kotlin DSL plugins, dependencies and typed task registration."""
+        structure = self._structure('build.gradle.kts', BUILD_GRADLE_KTS)
+        self.assertEqual([p['name'] for p in structure['plugins']], ['java-library', 'kotlin(jvm)'])
+        self.assertEqual(structure['implementation_dependencies'][0]['name'],
+                         'com.squareup.okhttp3:okhttp:4.11.0')
+        self.assertEqual(structure['tasks'][0]['type'], 'Zip')
+
+    def test_settings_subprojects(self):
+        """settings.gradle lists the root project and included subprojects."""
+        structure = self._structure('settings.gradle', SETTINGS_GRADLE)
+        self.assertEqual(structure['project'][0]['name'], 'demo')
+        self.assertEqual([s['name'] for s in structure['subprojects']], [':app', ':core'])
+
+    def test_kts_build_files_use_gradle_analyzer(self):
+        """build.gradle.kts is a Gradle script; other .kts files stay Kotlin."""
+        self.assertIs(get_analyzer('build.gradle.kts'), GradleAnalyzer)
+        self.assertIsNot(get_analyzer('script.kts'), GradleAnalyzer)
+
+
+if __name__ == '__main__':
+    unittest.main()
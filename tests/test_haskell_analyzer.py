@@ -0,0 +1,69 @@
+"""Tests for Haskell analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.haskell import HaskellAnalyzer
+
+
+SHAPES_HS = '''module Data.Shapes
+  ( Shape(..)
+  , area
+  ) where
+
+import qualified Data.Map as M
+
+data Shape = Circle Double
+           | Rect Double Double
+  deriving (Show, Eq)
+
+newtype Name = Name String
+
+class Show a => Named a where
+  name :: a -> String
+
+instance Named Shape where
+  name _ = "shape"
+
+area :: Shape
+     -> Double
+area (Circle r) = pi * r * r
+area (Rect w h) = w * h
+'''
+
+
+class TestHaskellAnalyzer(unittest.TestCase):
+    """Test Haskell analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.hs', delete=False) as f:
+            f.write(SHAPES_HS)
+            self.path = f.name
+        self.structure = HaskellAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_module_and_exports(self):
+        self.assertEqual(self.structure['module'][0]['name'], 'Data.Shapes')
+        self.assertEqual([(e['name'], e['line']) for e in self.structure['exports']],
+                         [('Shape(..)', 2), ('area', 3)])
+
+    def test_types_classes_and_instances(self):
+        types = {t['name']: t for t in self.structure['types']}
+        self.assertEqual(types['Shape']['constructors'], ['Circle', 'Rect'])
+        self.assertEqual(types['Name']['kind'], 'newtype')
+
+        named = self.structure['classes'][0]
+        self.assertEqual((named['name'], named['methods']), ('Named', ['name']))
+        self.assertEqual(self.structure['instances'][0]['name'], 'Named Shape')
+
+    def test_function_signatures_span_equations(self):
+        area = self.structure['functions'][0]
+        self.assertEqual(area['name'], 'area')
+        self.assertEqual(area['signature'], ' :: Shape -> Double')
+        self.assertEqual((area['line'], area['line_end']), (20, 23))
+
+
+if __name__ == '__main__':
+    unittest.main()
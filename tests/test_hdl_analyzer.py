@@ -0,0 +1,198 @@
+"""Tests for Verilog and VHDL analyzers."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.hdl import VerilogAnalyzer, VHDLAnalyzer
+
+
+TOP_SV = '''`timescale 1ns/1ps
+`include "defs.vh"
+
+// Top level
+module top #(
+    parameter WIDTH = 8,
+    parameter int DEPTH = 16
+) (
+    input  wire             clk,
+    input  wire             rst_n,
+    input  wire [WIDTH-1:0] a, b,
+    output reg  [WIDTH-1:0] q
+);
+  import util_pkg::*;
+  localparam HALF = WIDTH / 2;
+  wire [WIDTH-1:0] sum;
+
+  /* adder; instance */
+  adder #(.W(WIDTH)) u_add (
+      .a(a),
+      .b(b),
+      .s(sum)
+  );
+
+  and g1 (x, a[0], b[0]);
+
+  genvar i;
+  generate
+    for (i = 0; i < 4; i = i + 1) begin : g_regs
+      dff u_dff (.clk(clk), .d(sum[i]), .q());
+    end
+  endgenerate
+
+  always @(posedge clk) begin
+    if (!rst_n) q <= 0;
+    else q <= sum;
+    $display("done; %d", q);
+  end
+endmodule
+
+module adder(a, b, s);
+  parameter W = 4;
+  input [W-1:0] a, b;
+  output [W-1:0] s;
+  assign s = a + b;
+endmodule
+'''
+
+COUNTER_VHD = '''library ieee;
+use ieee.std_logic_1164.all;
+use ieee.numeric_std.all;
+
+-- A counter; with comments
+entity counter is
+  generic (
+    WIDTH : integer := 8
+  );
+  port (
+    clk, rst : in  std_logic;
+    q        : out std_logic_vector(WIDTH-1 downto 0)
+  );
+end entity counter;
+
+architecture rtl of counter is
+  signal count : unsigned(WIDTH-1 downto 0);
+
+  component adder
+    port (a, b : in std_logic; s : out std_logic);
+  end component;
+
+  function inc(x : unsigned) return unsigned is
+  begin
+    return x + 1;
+  end;
+begin
+  process (clk) is
+  begin
+    if rising_edge(clk) then
+      if rst = '1' then
+        count <= (others => '0');
+      else
+        count <= inc(count);
+      end if;
+    end if;
+  end process;
+
+  u_add : adder port map (a => clk, b => rst, s => open);
+
+  gen : for i in 0 to 3 generate
+    u_reg : entity work.dff(behav)
+      port map (d => count(i), q => q(i));
+  end generate;
+
+  q <= std_logic_vector(count);
+end architecture rtl;
+
+package counter_pkg is
+  constant MAX : integer := 255;
+end package;
+'''
+
+
+def _analyzer(cls, suffix, content):
+    with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+        f.write(content)
+        path = f.name
+    return cls(path), path
+
+
+class TestVerilogAnalyzer(unittest.TestCase):
+    """Test Verilog analyzer."""
+
+    def setUp(self):
+        self.analyzer, path = _analyzer(VerilogAnalyzer, '.sv', TOP_SV)
+        self.addCleanup(os.unlink, path)
+        self.structure = self.analyzer.get_structure()
+
+    def test_modules(self):
+        """Modules span to endmodule and list their ports."""
+        top, adder = self.structure['modules']
+        self.assertEqual((top['name'], top['line'], top['line_end']), ('top', 5, 39))
+        self.assertEqual(top['signature'], '(clk, rst_n, a, b, q)')
+        self.assertEqual((adder['line'], adder['line_end']), (41, 46))
+
+    def test_parameters(self):
+        """Header, body and local parameters."""
+        params = {p['name']: p for p in self.structure['parameters']}
+        self.assertEqual(params['top.WIDTH']['value'], '8')
+        self.assertEqual(params['top.DEPTH']['type'], 'int')
+        self.assertTrue(params['top.HALF']['local'])
+        self.assertEqual(params['adder.W']['line'], 42)
+
+    def test_ports(self):
+        """ANSI ports inherit direction; old-style ports are declared in the body."""
+        ports = {p['name']: p for p in self.structure['ports']}
+        self.assertEqual(ports['top.b']['signature'], ': input wire [WIDTH-1:0]')
+        self.assertEqual(ports['top.q']['direction'], 'output')
+        self.assertEqual(ports['adder.s']['signature'], ': output [W-1:0]')
+
+    def test_instances(self):
+        """Instantiations (including generate blocks); gates and statements are skipped."""
+        instances = [(i['name'], i['type'], i['line']) for i in self.structure['instances']]
+        self.assertEqual(instances, [('top.u_add', 'adder', 19), ('top.u_dff', 'dff', 30)])
+        self.assertEqual(self.structure['instances'][0]['line_end'], 23)
+
+    def test_includes_and_extract(self):
+        """`include directives and module extraction."""
+        self.assertEqual(self.structure['imports'][0]['content'], '`include "defs.vh"')
+        element = self.analyzer.extract_element('module', 'adder')
+        self.assertTrue(element['source'].startswith('module adder'))
+        self.assertTrue(element['source'].endswith('endmodule'))
+
+
+class TestVHDLAnalyzer(unittest.TestCase):
+    """Test VHDL analyzer."""
+
+    def setUp(self):
+        self.analyzer, path = _analyzer(VHDLAnalyzer, '.vhd', COUNTER_VHD)
+        self.addCleanup(os.unlink, path)
+        self.structure = self.analyzer.get_structure()
+
+    def test_units(self):
+        """Entities, architectures, packages and components with their extent."""
+        entity = self.structure['entities'][0]
+        self.assertEqual((entity['name'], entity['line_end'], entity['signature']), ('counter', 14, '(clk, rst, q)'))
+        architecture = self.structure['architectures'][0]
+        self.assertEqual((architecture['name'], architecture['entity'], architecture['line_end']), ('rtl', 'counter', 47))
+        self.assertEqual(self.structure['packages'][0]['line_end'], 51)
+        self.assertEqual(self.structure['components'][0]['signature'], '(a, b, s)')
+
+    def test_generics_and_ports(self):
+        """Generic defaults and port modes."""
+        generic = self.structure['generics'][0]
+        self.assertEqual((generic['name'], generic['default']), ('counter.WIDTH', '8'))
+        ports = [(p['name'], p['mode'], p['line']) for p in self.structure['ports']]
+        self.assertEqual(ports, [('counter.clk', 'in', 11), ('counter.rst', 'in', 11), ('counter.q', 'out', 12)])
+
+    def test_instances(self):
+        """Component and direct entity instantiations."""
+        instances = [(i['name'], i['unit'], i['line']) for i in self.structure['instances']]
+        self.assertEqual(instances, [('counter.u_add', 'adder', 39), ('counter.u_reg', 'work.dff(behav)', 42)])
+
+    def test_imports_and_extract(self):
+        """library/use clauses; names are case-insensitive."""
+        self.assertEqual(len(self.structure['imports']), 3)
+        self.assertEqual(self.analyzer.extract_element('entity', 'COUNTER')['line_end'], 14)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,102 @@
+"""Tests for type hierarchies (reveal hierarchy)."""
+
+import unittest
+from reveal.hierarchy import declared_bases, go_types, hierarchy_tree, project_hierarchy, split_bases
+
+
+def bases_of(*lines, **item):
+    return declared_bases(list(lines), {'line': 1, **item})
+
+
+class TestDeclaredBases(unittest.TestCase):
+    """Bases read from declaration headers."""
+
+    def test_split_bases(self):
+        self.assertEqual(split_bases('Generic[T], models.Base, metaclass=ABCMeta, *mixins'),
+                         ['Generic', 'models.Base'])
+        self.assertEqual(split_bases('public Base, private virtual Mixin<int, char>'), ['Base', 'Mixin'])
+
+    def test_python(self):
+        self.assertEqual(bases_of('class Dog(Animal, Serializable):'), [('Animal', 'extends'), ('Serializable', 'extends')])
+        self.assertEqual(bases_of('class Thing(object):'), [])
+        self.assertEqual(bases_of('class Plain:'), [])
+
+    def test_java_extends_implements(self):
+        self.assertEqual(bases_of('public class Repo<T> extends BaseRepo<T> implements Closeable, Iterable<T> {'),
+                         [('BaseRepo', 'extends'), ('Closeable', 'implements'), ('Iterable', 'implements')])
+        self.assertEqual(bases_of('public interface Store', '    extends Reader, Writer {'),
+                         [('Reader', 'extends'), ('Writer', 'extends')])
+
+    def test_ruby_and_colon_syntax(self):
+        self.assertEqual(bases_of('class Admin < User'), [('User', 'extends')])
+        self.assertEqual(bases_of('public sealed class Repo : BaseRepo, IDisposable'),
+                         [('BaseRepo', 'extends'), ('IDisposable', 'extends')])
+        self.assertEqual(bases_of('class Button(label: String) : View(label), Clickable {'),
+                         [('View', 'extends'), ('Clickable', 'extends')])
+
+    def test_header_stops_at_next_declaration(self):
+        self.assertEqual(bases_of('abstract class Shape', 'end', 'class Circle < Shape'), [])
+
+    def test_recorded_superclass_wins(self):
+        self.assertEqual(bases_of('class Circle(Shape):', superclass='Figure'), [('Figure', 'extends')])
+
+
+class TestGoTypes(unittest.TestCase):
+    """Go structs and interfaces with embedded types."""
+
+    def test_embedding(self):
+        lines = [
+            'type Reader interface {',
+            '\tio.Reader',
+            '\tLen() int',
+            '}',
+            'type (',
+            '\tUser struct {',
+            '\t\tBase',
+            '\t\t*Audit // who changed it',
+            '\t\tName string `json:"name"`',
+            '\t\tMeta struct {',
+            '\t\t\tInner',
+            '\t\t}',
+            '\t}',
+            ')',
+            'type Empty struct{}',
+        ]
+        types = go_types(lines)
+        self.assertEqual([(t['name'], t['kind'], t['line'], t['line_end']) for t in types],
+                         [('Reader', 'interface', 1, 4), ('User', 'struct', 6, 13), ('Empty', 'struct', 15, 15)])
+        self.assertEqual(types[0]['bases'], [('io.Reader', 'embeds')])
+        self.assertEqual(types[1]['bases'], [('Base', 'embeds'), ('Audit', 'embeds')])
+
+
+class TestProjectHierarchy(unittest.TestCase):
+    """Bases resolved across files into trees."""
+
+    def setUp(self):
+        self.hierarchy = project_hierarchy([
+            ('models/base.py', ['class Model(Base):'], {'classes': [{'line': 1, 'name': 'Model'}]}),
+            ('models/user.py', ['class User(base.Model, Exception):', 'class Admin(User):'],
+             {'classes': [{'line': 1, 'name': 'User'}, {'line': 2, 'name': 'Admin'}]}),
+            ('lone.py', ['class Lone:'], {'classes': [{'line': 1, 'name': 'Lone'}]}),
+        ])
+
+    def test_resolution(self):
+        self.assertEqual(self.hierarchy.bases['models/user.py::User'],
+                         [('models/base.py::Model', 'extends'), ('Exception', 'extends')])
+        self.assertEqual(self.hierarchy.roots(), ['Base', 'Exception'])
+
+    def test_tree(self):
+        tree = hierarchy_tree(self.hierarchy, 'Base')
+        self.assertTrue(tree['external'])
+        model = tree['children'][0]
+        self.assertEqual((model['name'], model['file'], model['line'], model['relation']),
+                         ('Model', 'models/base.py', 1, 'extends'))
+        self.assertEqual([child['name'] for child in model['children'][0]['children']], ['Admin'])
+
+    def test_find(self):
+        self.assertEqual(self.hierarchy.find('Admin'), ['models/user.py::Admin'])
+        self.assertEqual(self.hierarchy.find('builtins.Exception'), ['Exception'])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,72 @@
+"""Tests for symbol history (reveal history)."""
+
+import contextlib
+import io
+import json
+import os
+import subprocess
+import tempfile
+import unittest
+from reveal.commands import HistoryCommand, run_command
+from reveal.git import line_history
+
+
+class TestLineHistory(unittest.TestCase):
+    """git log -L over a symbol's line range."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.directory = self.tmpdir.name
+        self.path = os.path.join(self.directory, 'geo.nim')
+        self.env = {**os.environ, 'GIT_AUTHOR_NAME': 'Ada', 'GIT_AUTHOR_EMAIL': 'ada@example.com',
+                    'GIT_COMMITTER_NAME': 'Ada', 'GIT_COMMITTER_EMAIL': 'ada@example.com'}
+        self.write('proc area*(r: float): float =\n  r * r\n\nproc perimeter*(r: float): float =\n  2 * r\n')
+        try:
+            self.git('init', '-q')
+            self.commit('add geometry')
+        except (OSError, subprocess.CalledProcessError):
+            self.skipTest('git not available')
+        # Lines above area move it down; only perimeter's body changes
+        self.write('import math\n\nproc area*(r: float): float =\n  r * r\n\nproc perimeter*(r: float): float =\n'
+                   '  2 * PI * r\n')
+        self.commit('fix perimeter')
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def write(self, text):
+        with open(self.path, 'w') as f:
+            f.write(text)
+
+    def git(self, *args):
+        subprocess.run(['git', *args], cwd=self.directory, env=self.env, check=True, capture_output=True)
+
+    def commit(self, message):
+        self.git('add', '.')
+        self.git('commit', '-qm', message)
+
+    def test_line_history(self):
+        commits = line_history(self.path, 6, 7)
+        self.assertEqual([(c['subject'], c['added'], c['removed']) for c in commits],
+                         [('fix perimeter', 1, 1), ('add geometry', 2, 0)])
+        self.assertEqual(commits[0]['author'], 'Ada')
+
+        # Moved but unchanged: only the commit that wrote it
+        self.assertEqual([c['subject'] for c in line_history(self.path, 3, 4)], ['add geometry'])
+        self.assertEqual(len(line_history(self.path, 6, 7, limit=1)), 1)
+
+    def test_command(self):
+        output = io.StringIO()
+        with contextlib.redirect_stdout(output):
+            code = run_command(HistoryCommand, [f'{self.path}::perimeter', '--format', 'json'])
+        self.assertEqual(code, 0)
+        result = json.loads(output.getvalue())
+        self.assertEqual((result['line'], result['line_end']), (6, 7))
+        self.assertEqual(len(result['commits']), 2)
+
+        with contextlib.redirect_stderr(io.StringIO()):
+            self.assertEqual(run_command(HistoryCommand, [self.path, 'volume']), 2)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,42 @@
+"""Tests for the HTML report."""
+
+import unittest
+from reveal.html_report import render_html_report
+
+
+FILES = [
+    {'file': 'src/app/main.nim', 'type': 'nim', 'lines': 12, 'structure': {
+        'functions': [{'line': 3, 'line_end': 5, 'name': 'area', 'signature': '(r: float) -> float',
+                       'visibility': 'public'}],
+        'imports': [{'line': 1, 'content': 'import std/<os>'}],
+    }},
+    {'file': 'notes.bin', 'type': None, 'size': 2048},
+]
+
+
+class TestHtmlReport(unittest.TestCase):
+    """Test HTML report rendering."""
+
+    def test_tree_and_symbols(self):
+        """Directories nest as <details>; symbols are listed with line ranges, escaped."""
+        html = render_html_report('demo/', FILES)
+        self.assertTrue(html.startswith('<!DOCTYPE html>'))
+        self.assertIn('<summary class="dir">src/</summary>', html)
+        self.assertIn('<summary class="dir">app/</summary>', html)
+        self.assertLess(html.index('app/'), html.index('main.nim'))
+        self.assertIn('<span class="loc">3-5</span> <code>area(r: float) -&gt; float</code>', html)
+        self.assertIn('<code>import std/&lt;os&gt;</code>', html)
+        self.assertIn('2 files, 2 symbols', html)
+        self.assertIn('notes.bin <span class="meta">2.0 KB</span>', html)
+
+    def test_self_contained(self):
+        """No external stylesheets or scripts; search box and filter script are inline."""
+        html = render_html_report('demo/', FILES)
+        self.assertNotIn('<link', html)
+        self.assertNotIn('src="', html)
+        self.assertIn('id="search"', html)
+        self.assertIn('<script>', html)
+
+
+if __name__ == '__main__':
+    unittest.main()
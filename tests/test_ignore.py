@@ -0,0 +1,88 @@
+"""Tests for .gitignore/.ignore handling in directory walks."""
+
+import tempfile
+import unittest
+from pathlib import Path
+from reveal.ignore import IgnoreRules, parse_rule
+from reveal.tree_view import iter_directory_files, show_directory_tree
+
+
+class TestIgnoreRules(unittest.TestCase):
+    """Pattern semantics, rule files up the repository, and the walks that use them."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.root = Path(self.tmpdir.name).resolve()
+        (self.root / '.git').mkdir()
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def write(self, name, text='x\n'):
+        path = self.root / name
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(text)
+
+    def walked(self, path=None, **options):
+        path = path or self.root
+        return [file_path.relative_to(path).as_posix() for file_path in iter_directory_files(path, depth=10, **options)]
+
+    def matches(self, pattern, relative, is_dir=False):
+        _base, regex, negated, directories_only = parse_rule(pattern, self.root)
+        return bool(regex.fullmatch(relative)) and not negated and (is_dir or not directories_only)
+
+    def test_patterns(self):
+        self.assertIsNone(parse_rule('# comment', self.root))
+        self.assertIsNone(parse_rule('   ', self.root))
+        self.assertTrue(self.matches('*.log', 'a/b/x.log'))
+        self.assertFalse(self.matches('*.log', 'a/x.log.txt'))
+        self.assertTrue(self.matches('/build', 'build'))
+        self.assertFalse(self.matches('/build', 'src/build'))
+        self.assertTrue(self.matches('docs/*.md', 'docs/a.md'))
+        self.assertFalse(self.matches('docs/*.md', 'docs/sub/a.md'))
+        self.assertTrue(self.matches('docs/**/*.md', 'docs/sub/deep/a.md'))
+        self.assertTrue(self.matches('**/gen', 'src/gen', is_dir=True))
+        self.assertTrue(self.matches('out/', 'out', is_dir=True))
+        self.assertFalse(self.matches('out/', 'out'))
+        self.assertTrue(self.matches('file[0-9].txt', 'file7.txt'))
+        self.assertTrue(self.matches('\\#notes', '#notes'))
+        self.assertTrue(parse_rule('!keep.log', self.root)[2])
+
+    def test_walk(self):
+        self.write('.gitignore', 'logs/\n*.log\n!keep.log\n/docs/*.md\n')
+        self.write('src/.ignore', 'generated/\n')
+        for name in ('node_modules/pkg/index.js', '.venv/lib/site.py', 'target/debug/main.rs', 'src/app.py',
+                     'src/generated/api.py', 'logs/today.txt', 'debug.log', 'keep.log', 'docs/a.md', 'docs/b.txt',
+                     'src/notes.md'):
+            self.write(name)
+        self.assertEqual(self.walked(), ['docs/b.txt', 'src/app.py', 'src/notes.md', 'keep.log'])
+        self.assertEqual(len(self.walked(ignore=False)), 10)  # .venv is hidden
+        # A subdirectory of the repository still honours the ignore files above it
+        self.assertEqual(self.walked(self.root / 'src'), ['app.py', 'notes.md'])
+
+        tree = show_directory_tree(str(self.root), fast=True)
+        self.assertIn('app.py', tree)
+        self.assertNotIn('node_modules', tree)
+        self.assertIn('node_modules', show_directory_tree(str(self.root), fast=True, ignore=False))
+
+    def test_gitignore_reincludes_default(self):
+        self.write('.gitignore', '!build/\n')
+        self.write('build/make.py')
+        self.write('dist/app.js')
+        self.assertEqual(self.walked(), ['build/make.py'])
+
+    def test_outside_repository(self):
+        (self.root / '.git').rmdir()
+        self.write('.gitignore', '*.tmp\n')
+        self.write('project/.gitignore', '*.bak\n')
+        self.write('project/a.tmp')
+        self.write('project/a.bak')
+        self.write('project/a.py')
+        # Without a repository, only the walked directory's own ignore files apply
+        self.assertEqual(self.walked(self.root / 'project'), ['a.py', 'a.tmp'])
+        rules = IgnoreRules.for_walk(self.root)
+        self.assertTrue(rules.ignored(self.root / 'x.tmp', False))
+
+
+if __name__ == '__main__':
+    unittest.main()
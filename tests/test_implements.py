@@ -0,0 +1,101 @@
+"""Tests for Go interface satisfaction (reveal implements)."""
+
+import unittest
+from reveal.implements import go_methods, go_package_index, go_signature, implementations, satisfied_interfaces
+
+
+STORE = '''package store
+
+import "io"
+
+type Store interface {
+	Get(id ID) (*User, error)
+	io.Closer
+}
+
+type ID int
+
+type Memory struct {
+	users map[ID]*User
+}
+
+func (m *Memory) Get(id ID) (*User, error) { return m.users[id], nil }
+
+func (m Memory) Close() error { return nil }
+
+type File struct {
+	Memory
+	path string
+}
+
+func (f *File) Read(p []byte) (n int, err error) {
+	return 0, nil
+}
+'''.splitlines()
+
+WEB = '''package web
+
+type HandlerFunc func(http.ResponseWriter, *http.Request)
+
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter,
+	r *http.Request) {
+	f(w, r)
+}
+
+type Cache struct {
+	store.Store
+}
+
+func (c Cache) Get(key string) (*store.User, error) { return nil, nil }
+'''.splitlines()
+
+
+class TestSignatures(unittest.TestCase):
+    """Signatures reduced to parameter and result types."""
+
+    def test_go_signature(self):
+        self.assertEqual(go_signature('(p []byte) (n int, err error) {'), (('[]byte',), ('int', 'error')))
+        self.assertEqual(go_signature('(i, j int) bool'), (('int', 'int'), ('bool',)))
+        self.assertEqual(go_signature('(w http.ResponseWriter, r *http.Request)'),
+                         (('ResponseWriter', '*Request'), ()))
+        self.assertEqual(go_signature('(ctx context.Context, v interface{}) <-chan struct{} {'),
+                         (('Context', 'any'), ('<-chanstruct{}',)))
+        self.assertEqual(go_signature('(func(int) error, chan int)'), (('func(int)error', 'chanint'), ()))
+
+    def test_go_methods(self):
+        methods = go_methods(WEB)
+        self.assertEqual([(m['receiver'], m['name'], m['pointer'], m['line']) for m in methods],
+                         [('HandlerFunc', 'ServeHTTP', False, 5), ('Cache', 'Get', False, 14)])
+        self.assertEqual(methods[0]['signature'], (('ResponseWriter', '*Request'), ()))
+
+
+class TestImplementations(unittest.TestCase):
+    """Method sets compared across a project."""
+
+    def setUp(self):
+        self.index = go_package_index([('store/store.go', STORE), ('web/web.go', WEB)])
+
+    def test_project_interface(self):
+        found = implementations(self.index, 'Store')
+        # Cache's own Get(string) shadows the one promoted from the embedded Store
+        self.assertEqual([(f['type'], f['pointer']) for f in found], [('File', True), ('Memory', True)])
+        self.assertEqual([s['interface'] for s in satisfied_interfaces(self.index, ('web', 'Cache'))], ['io.Closer'])
+
+    def test_standard_interfaces(self):
+        self.assertEqual([(f['type'], f['file'], f['line']) for f in implementations(self.index, 'http.Handler')],
+                         [('HandlerFunc', 'web/web.go', 3)])
+        # Close is promoted from the embedded Memory, Read needs *File
+        self.assertEqual([(f['type'], f['pointer']) for f in implementations(self.index, 'io.ReadCloser')],
+                         [('File', True)])
+
+    def test_satisfied_interfaces(self):
+        satisfied = satisfied_interfaces(self.index, ('store', 'Memory'))
+        self.assertEqual([(s['interface'], s['pointer'], s.get('std', False)) for s in satisfied],
+                         [('Store', True, False), ('io.Closer', False, True)])
+
+    def test_unknown_interface(self):
+        self.assertEqual(implementations(self.index, 'bufio.Flusher'), [])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,132 @@
+"""Tests for the project symbol index (reveal index)."""
+
+import contextlib
+import io
+import json
+import os
+import tempfile
+import unittest
+from pathlib import Path
+from unittest import mock
+from reveal import base
+from reveal.commands import IndexCommand, SearchCommand, RefsCommand, run_command
+from reveal.index import INDEX_FILE, ProjectIndex
+
+
+class TestProjectIndex(unittest.TestCase):
+    """Building, querying, and lookups that stay correct as files change."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.root = Path(self.tmpdir.name)
+        self.write('geo.nim', 'proc area*(r: float): float =\n  r * r\n')
+        self.write('lib/shapes.nim', 'proc perimeter*(r: float): float =\n  2 * area(r)\n')
+        self.write('lib/util.nim', 'proc clamp*(x: int): int =\n  x\n')
+        self.cache = mock.patch.dict(os.environ, {'REVEAL_CACHE_DIR': str(self.root / '.cache')})
+        self.cache.start()
+
+    def tearDown(self):
+        self.cache.stop()
+        self.tmpdir.cleanup()
+
+    def write(self, name, text):
+        path = self.root / name
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(text)
+
+    def run_json(self, command, argv):
+        output = io.StringIO()
+        with contextlib.redirect_stdout(output):
+            code = run_command(command, argv + ['--format', 'json'])
+        return code, json.loads(output.getvalue())
+
+    def build(self):
+        with contextlib.redirect_stdout(io.StringIO()):
+            self.assertEqual(run_command(IndexCommand, ['build', str(self.root)]), 0)
+
+    def test_build_and_query(self):
+        self.build()
+        self.assertTrue((self.root / INDEX_FILE).is_file())
+        index = ProjectIndex.load(self.root / 'lib')
+        self.assertEqual(index.root, self.root.resolve())
+        self.assertEqual(sorted(index.files), ['geo.nim', 'lib/shapes.nim', 'lib/util.nim'])
+
+        code, result = self.run_json(IndexCommand, ['query', 'area', str(self.root)])
+        self.assertEqual(code, 0)
+        self.assertEqual([(os.path.basename(hit['file']), hit['line']) for hit in result['matches']], [('geo.nim', 1)])
+        # Scoped to a subdirectory
+        code, result = self.run_json(IndexCommand, ['query', 'area', str(self.root / 'lib')])
+        self.assertEqual((code, result['matches']), (1, []))
+
+    def test_index_keeps_out_strings_and_itself(self):
+        self.write('config.nim', 'let token = "sk_live_secret" # rotate yearly\n')
+        self.build()
+        self.assertEqual((self.root / '.reveal' / '.gitignore').read_text(), '*\n')
+        self.build()
+        index = ProjectIndex.load(self.root)
+        self.assertEqual(sorted(index.files), ['config.nim', 'geo.nim', 'lib/shapes.nim', 'lib/util.nim'])
+        self.assertEqual(index.files['config.nim']['words'], ['let', 'token'])
+
+    def test_no_index(self):
+        with contextlib.redirect_stderr(io.StringIO()):
+            self.assertEqual(run_command(IndexCommand, ['query', 'area', str(self.root)]), 2)
+
+    def test_entry_freshness(self):
+        self.build()
+        index = ProjectIndex.load(self.root)
+        entry = index.entry(self.root / 'geo.nim')
+        self.assertIsNotNone(entry)
+        self.assertTrue(index.mentions(entry, 'area'))
+        self.assertFalse(index.mentions(entry, 'perimeter'))
+        self.assertTrue(index.defines(entry, {'area'}))
+
+        self.write('geo.nim', 'proc area*(r: float): float =\n  3.14 * r * r\n')
+        self.assertIsNone(index.entry(self.root / 'geo.nim'))
+        self.assertIsNone(index.entry(self.root / 'missing.nim'))
+
+    def test_other_reveal_version_is_stale(self):
+        self.build()
+        with mock.patch('reveal.__version__', '0.0.0'):
+            self.assertIsNone(ProjectIndex.load(self.root))
+        self.assertIsNotNone(ProjectIndex.load(self.root))
+
+    def test_search_falls_back_for_dropped_fields(self):
+        self.build()
+        index = ProjectIndex.load(self.root)
+        self.assertTrue(index.keeps(('name', 'line', 'signature', 'modules')))
+        self.assertFalse(index.keeps(('name', 'decorators')))
+
+        def analyzed(pattern, *options):
+            with mock.patch('reveal.commands.base.get_analyzer', wraps=base.get_analyzer) as get:
+                self.run_json(SearchCommand, [pattern, str(self.root), *options])
+            return sorted(os.path.basename(call.args[0]) for call in get.call_args_list)
+
+        self.assertEqual(analyzed('os', '--kind', 'import'), [])
+        # An index without the modules of imports: import searches analyze the files
+        data = json.loads((self.root / INDEX_FILE).read_text())
+        data['fields'].remove('modules')
+        (self.root / INDEX_FILE).write_text(json.dumps(data))
+        self.assertEqual(analyzed('area'), [])
+        self.assertEqual(analyzed('os', '--kind', 'import'), ['geo.nim', 'shapes.nim', 'util.nim'])
+
+    def test_search_and_refs_follow_edits(self):
+        self.build()
+        self.write('lib/util.nim', 'proc clamp*(x: int): int =\n  x\n\nproc areaOf*(r: float): float =\n  area(r)\n')
+        self.write('new.nim', 'proc area*(): int =\n  1\n')
+        for command in (SearchCommand, RefsCommand):
+            indexed = self.run_json(command, ['area', str(self.root)])
+            walked = self.run_json(command, ['area', str(self.root), '--no-index'])
+            self.assertEqual(indexed, walked)
+        _code, result = self.run_json(SearchCommand, ['area', str(self.root)])
+        self.assertEqual(sorted(os.path.basename(hit['file']) for hit in result['matches']),
+                         ['geo.nim', 'new.nim', 'util.nim'])
+
+    def test_refs_skip_files_without_the_word(self):
+        self.build()
+        with mock.patch('reveal.commands.base.get_analyzer', wraps=base.get_analyzer) as get:
+            self.run_json(RefsCommand, ['perimeter', str(self.root)])
+        self.assertEqual([os.path.basename(call.args[0]) for call in get.call_args_list], ['shapes.nim'])
+
+
+if __name__ == '__main__':
+    unittest.main()
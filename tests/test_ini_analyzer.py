@@ -0,0 +1,74 @@
+"""Tests for INI-style config analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.ini import IniAnalyzer
+
+
+SETUP_CFG = '''; setup config
+[metadata]
+name = demo
+version: 1.0
+password = hunter2
+
+[options]
+install_requires =
+    requests>=2
+    click
+zip_safe = False
+
+[options.extras_require]
+dev = pytest
+
+[mysqld]
+skip-external-locking
+'''
+
+
+class TestIniAnalyzer(unittest.TestCase):
+    """Test INI analyzer."""
+
+    def _analyze(self, content, suffix='.cfg'):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return IniAnalyzer(f.name)
+
+    def test_sections(self):
+        """Section hierarchy with key names; keys without values are kept."""
+        sections = self._analyze(SETUP_CFG).get_structure()['sections']
+        self.assertEqual([(s['name'], s['level'], s['line'], s.get('line_end')) for s in sections], [
+            ('metadata', 1, 2, 5),
+            ('options', 1, 7, 11),
+            ('options.extras_require', 2, 13, 14),
+            ('mysqld', 1, 16, 17),
+        ])
+        self.assertEqual(sections[0]['signature'], ' {name, version, password}')
+        self.assertEqual([k['name'] for k in sections[3]['keys']], ['skip-external-locking'])
+
+    def test_values(self):
+        """Continuation lines are joined; secrets are hidden; redact hides everything."""
+        analyzer = self._analyze(SETUP_CFG)
+        sections = analyzer.get_structure()['sections']
+        values = {k['name']: k['value'] for s in sections for k in s['keys']}
+        self.assertEqual(values['install_requires'], 'requests>=2, click')
+        self.assertEqual(values['version'], '1.0')
+        self.assertEqual(values['password'], '***')
+
+        redacted = analyzer.get_structure(redact=True)['sections']
+        self.assertEqual({k['value'] for s in redacted for k in s['keys']}, {'***'})
+
+    def test_global_keys(self):
+        """Keys before the first section are top-level keys (.editorconfig, .properties)."""
+        keys = self._analyze('root = true\n\n[*.py]\nindent_size = 4\n', '.ini').get_structure()['keys']
+        self.assertEqual([(k['name'], k['signature']) for k in keys], [('root', ' = true')])
+
+    def test_extract_key(self):
+        """A key is extracted with its continuation lines."""
+        element = self._analyze(SETUP_CFG).extract_element('key', 'options.install_requires')
+        self.assertEqual((element['line_start'], element['line_end']), (8, 10))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,56 @@
+"""Tests for Java analyzer."""
+
+import unittest
+from pathlib import Path
+from reveal.analyzers.java import JavaAnalyzer
+
+
+SAMPLE = str(Path(__file__).parent / 'samples' / 'HelloWorld.java')
+
+
+class TestJavaAnalyzer(unittest.TestCase):
+    """Test Java file analyzer against tests/samples/HelloWorld.java."""
+
+    def setUp(self):
+        self.structure = JavaAnalyzer(SAMPLE).get_structure()
+
+    def test_package_and_imports(self):
+        """Should extract package declaration and imports."""
+        self.assertEqual(self.structure['package'][0]['content'], 'package com.example.demo')
+        self.assertEqual(len(self.structure['imports']), 3)
+
+    def test_type_declarations(self):
+        """Should extract classes, interfaces and enums separately."""
+        self.assertEqual([c['name'] for c in self.structure['classes']], ['HelloWorld'])
+        self.assertEqual([i['name'] for i in self.structure['interfaces']], ['GreetingStrategy'])
+        self.assertEqual([e['name'] for e in self.structure['enums']], ['GreetingType'])
+        self.assertEqual(self.structure['classes'][0]['visibility'], 'public')
+
+    def test_methods_grouped_by_visibility(self):
+        """Public methods come first; enum constructor is package-private."""
+        functions = self.structure['functions']
+        visibilities = [f['visibility'] for f in functions]
+        self.assertEqual(visibilities, sorted(visibilities, key=JavaAnalyzer.VISIBILITY_ORDER.index))
+
+        by_name = {f['name']: f for f in functions}
+        self.assertEqual(by_name['greet']['visibility'], 'public')  # interface member
+        self.assertEqual(by_name['GreetingType']['visibility'], 'package')
+
+    def test_method_signatures(self):
+        """Signatures should include parameters, return type and throws."""
+        by_name = {f['name']: f for f in self.structure['functions']}
+        self.assertEqual(by_name['greetMultiple']['signature'],
+                         '(List<String> names) -> List<String>')
+        self.assertEqual(by_name['main']['signature'],
+                         '(String[] args) -> void throws IOException')
+        self.assertEqual(by_name['main']['modifiers'], ['static'])
+
+    def test_extract_prefers_type_over_constructor(self):
+        """Extracting a name shared by a type and its constructor yields the type."""
+        result = JavaAnalyzer(SAMPLE).extract_element('function', 'GreetingType')
+        self.assertIsNotNone(result)
+        self.assertIn('enum GreetingType', result['source'])
+
+
+if __name__ == '__main__':
+    unittest.main()
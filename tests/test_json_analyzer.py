@@ -0,0 +1,77 @@
+"""Tests for JSON structure summaries."""
+
+import json
+import os
+import tempfile
+import unittest
+from reveal.analyzers.yaml_json import JsonAnalyzer
+
+
+DOCUMENT = '''{
+  "name": "demo",
+  "version": 3,
+  "nested": {"name": "inner", "deep": {"a": {"b": 1}}},
+  "users": [
+    {"id": 1, "email": "x@y", "tags": ["t"]},
+    {"id": 2, "email": null, "tags": []},
+    {"id": 3, "tags": ["a", "b", "c"]}
+  ],
+  "empty": [],
+  "matrix": [[1, 2], [3, 4]]
+}
+'''
+
+
+class TestJsonAnalyzer(unittest.TestCase):
+    """Test JSON shape summaries."""
+
+    def _analyze(self, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.json', delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return JsonAnalyzer(f.name)
+
+    def test_top_level_keys(self):
+        """Top-level keys with types, lines and member extents."""
+        keys = self._analyze(DOCUMENT).get_structure()['keys']
+        self.assertEqual([(k['name'], k['type'], k['line'], k.get('line_end')) for k in keys], [
+            ('name', 'string', 2, None),
+            ('version', 'number', 3, None),
+            ('nested', 'object', 4, None),
+            ('users', 'array', 5, 9),
+            ('empty', 'array', 10, None),
+            ('matrix', 'array', 11, None),
+        ])
+        self.assertEqual(keys[3]['length'], 3)
+
+    def test_shapes(self):
+        """Array lengths, nested object keys, optional keys and unions."""
+        signatures = {k['name']: k['signature'] for k in self._analyze(DOCUMENT).get_structure()['keys']}
+        self.assertEqual(signatures['nested'], ': {name: string, deep: {a: {1 key}}}')
+        self.assertEqual(signatures['users'], ': [3 × {id: number, email?: string|null, tags: [0-3 × string]}]')
+        self.assertEqual(signatures['empty'], ': []')
+        self.assertEqual(signatures['matrix'], ': [2 × [2 × number]]')
+
+    def test_nested_key_does_not_shadow_top_level(self):
+        """A top-level key is located at depth 1, not where a nested key of the same name appears."""
+        keys = self._analyze('{\n  "a": {"b": 1},\n  "b": 2\n}\n').get_structure()['keys']
+        self.assertEqual([(k['name'], k['line']) for k in keys], [('a', 2), ('b', 3)])
+
+    def test_root_array(self):
+        """Array documents are summarized as a single $ entry."""
+        keys = self._analyze(json.dumps([{'a': 1}, {'a': 2, 'b': 'x'}])).get_structure()['keys']
+        self.assertEqual(keys[0]['name'], '$')
+        self.assertEqual(keys[0]['signature'], ': [2 × {a: number, b?: string}]')
+
+    def test_invalid_json(self):
+        """Invalid documents have no structure."""
+        self.assertEqual(self._analyze('{"a": ').get_structure(), {})
+
+    def test_extract_key(self):
+        """A top-level member is extracted by key."""
+        element = self._analyze(DOCUMENT).extract_element('key', 'users')
+        self.assertEqual((element['line_start'], element['line_end']), (5, 9))
+
+
+if __name__ == '__main__':
+    unittest.main()
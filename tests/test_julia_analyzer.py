@@ -0,0 +1,109 @@
+"""Tests for Julia analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.julia import JuliaAnalyzer
+
+
+SHAPES_JL = '''module Shapes
+
+using LinearAlgebra, Statistics
+export Shape, Circle, area
+
+abstract type Shape end
+
+"""
+    Circle(r)
+
+A circle; the function docs end here.
+"""
+struct Circle <: Shape
+    r::Float64
+end
+
+mutable struct Box{T<:Real} <: Shape
+    w::T
+    h::T
+end
+
+area(c::Circle) = pi * c.r^2
+area(b::Box) = b.w * b.h
+
+function area(shapes::Vector{<:Shape})::Float64
+    total = sum(area(s) for s in shapes)
+    last = shapes[end]
+    helper(x) = x + 1
+    for s in shapes
+        total += 1
+    end
+    return total
+end
+
+function scale!(b::Box{T}, k) where {T}
+    b.w *= k
+end
+
+macro check(ex)
+    quote
+        $(esc(ex)) || error("failed")
+    end
+end
+
+module Inner
+inner_f(x) = x
+end
+
+end # module
+'''
+
+
+class TestJuliaAnalyzer(unittest.TestCase):
+    """Test Julia analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.jl', delete=False) as f:
+            f.write(SHAPES_JL)
+            self.path = f.name
+        self.structure = JuliaAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_modules(self):
+        """Nested modules are qualified and span to their `end`."""
+        self.assertEqual([m['name'] for m in self.structure['modules']], ['Shapes', 'Shapes.Inner'])
+        self.assertEqual(self.structure['modules'][0]['line_end'], 49)
+
+    def test_types(self):
+        """Structs with supertype, mutability and fields; abstract types."""
+        circle, box = self.structure['structs']
+        self.assertEqual(circle['signature'], ' <: Shape')
+        self.assertEqual(circle['fields'], ['r'])
+        self.assertEqual(box['signature'], '{T<:Real} <: Shape  (mutable)')
+        self.assertEqual(self.structure['abstract_types'][0]['name'], 'Shape')
+        self.assertNotIn('line_end', self.structure['abstract_types'][0])
+
+    def test_multiple_dispatch(self):
+        """Each method is listed with its signature; local closures are not."""
+        functions = self.structure['functions']
+        self.assertEqual([(f['name'], f['signature']) for f in functions], [
+            ('area', '(c::Circle)'),
+            ('area', '(b::Box)'),
+            ('area', '(shapes::Vector{<:Shape}) -> Float64'),
+            ('scale!', '(b::Box{T}, k) where {T}'),
+            ('inner_f', '(x)'),
+        ])
+        self.assertEqual(functions[0]['dispatch'], 3)
+        self.assertEqual(functions[2]['line_end'], 33)
+
+    def test_macros_and_imports(self):
+        """Macros, using statements and exports."""
+        self.assertEqual(self.structure['macros'][0]['name'], 'check')
+        self.assertEqual(self.structure['macros'][0]['line_end'], 43)
+        self.assertEqual(self.structure['imports'][0]['content'], 'using LinearAlgebra, Statistics')
+        self.assertEqual(self.structure['exports'][0]['content'], 'Shape, Circle, area')
+
+
+if __name__ == '__main__':
+    unittest.main()
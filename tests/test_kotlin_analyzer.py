@@ -0,0 +1,86 @@
+"""Tests for Kotlin analyzer."""
+
+import unittest
+import tempfile
+import os
+from reveal.analyzers.kotlin import KotlinAnalyzer
+
+
+SAMPLE = '''package com.example.app
+
+import android.os.Bundle
+import kotlinx.coroutines.launch
+
+data class User(val id: Int, val name: String)
+
+interface Repository {
+    fun find(id: Int): User?
+}
+
+class UserService(private val repo: Repository) {
+    companion object {
+        const val TAG = "UserService"
+    }
+
+    fun greet(id: Int): String {
+        return "Hello ${repo.find(id)?.name}"
+    }
+
+    private fun log(message: String) {
+        println(message)
+    }
+}
+
+object Registry {
+    val users = mutableListOf<User>()
+}
+
+fun String.shout(): String = uppercase()
+
+fun main() {
+    println("hi".shout())
+}
+'''
+
+
+class TestKotlinAnalyzer(unittest.TestCase):
+    """Test Kotlin file analyzer."""
+
+    def setUp(self):
+        fd, self.path = tempfile.mkstemp(suffix='.kt')
+        with os.fdopen(fd, 'w', encoding='utf-8') as f:
+            f.write(SAMPLE)
+        self.structure = KotlinAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_package_and_imports(self):
+        """Should extract package header and imports."""
+        self.assertEqual(len(self.structure['package']), 1)
+        self.assertEqual(len(self.structure['imports']), 2)
+
+    def test_class_kinds(self):
+        """Data classes and interfaces are listed separately from classes."""
+        self.assertEqual([c['name'] for c in self.structure['classes']], ['UserService'])
+        self.assertEqual([c['name'] for c in self.structure['data_classes']], ['User'])
+        self.assertEqual([c['name'] for c in self.structure['interfaces']], ['Repository'])
+
+    def test_objects_and_companions(self):
+        """Should extract objects and companion objects."""
+        self.assertEqual([o['name'] for o in self.structure['objects']], ['Registry'])
+        self.assertEqual([c['name'] for c in self.structure['companion_objects']],
+                         ['UserService.Companion'])
+
+    def test_function_kinds(self):
+        """Top-level, member and extension functions are separated."""
+        self.assertEqual([f['name'] for f in self.structure['functions']], ['main'])
+        self.assertEqual([f['name'] for f in self.structure['extensions']], ['String.shout'])
+
+        methods = {m['name']: m for m in self.structure['methods']}
+        self.assertEqual(methods['greet']['signature'], '(id: Int) -> String')
+        self.assertEqual(methods['log']['visibility'], 'private')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,72 @@
+"""Tests for Kubernetes manifest detection in the YAML analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.yaml_json import YamlAnalyzer
+
+
+MANIFESTS = '''# App manifests
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: prod
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.25
+          ports:
+            - containerPort: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: ClusterIP
+  ports:
+    - port: 80
+      targetPort: 8080
+'''
+
+
+class TestKubernetesManifests(unittest.TestCase):
+    """Test Kubernetes mode of YamlAnalyzer."""
+
+    def _write(self, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.yaml', delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return f.name
+
+    def test_multi_document_manifests(self):
+        """Each document is listed as Kind/name with key spec fields."""
+        structure = YamlAnalyzer(self._write(MANIFESTS)).get_structure()
+
+        deployment, service = structure['manifests']
+        self.assertEqual(deployment['name'], 'Deployment/web')
+        self.assertEqual((deployment['line'], deployment['line_end']), (2, 15))
+        self.assertEqual(deployment['signature'],
+                         ' (ns=prod, replicas=3, image=nginx:1.25, ports=80)')
+
+        self.assertEqual(service['name'], 'Service/web')
+        self.assertEqual(service['line'], 17)
+        self.assertEqual(service['ports'], ['80->8080'])
+
+    def test_extract_document(self):
+        """A resource can be extracted by Kind/name."""
+        result = YamlAnalyzer(self._write(MANIFESTS)).extract_element('key', 'Service/web')
+        self.assertEqual((result['line_start'], result['line_end']), (17, 25))
+
+    def test_plain_yaml_unchanged(self):
+        """Non-Kubernetes YAML still lists top-level keys."""
+        structure = YamlAnalyzer(self._write('name: demo\nversion: 1\n')).get_structure()
+        self.assertEqual([k['name'] for k in structure['keys']], ['name', 'version'])
+
+
+if __name__ == '__main__':
+    unittest.main()
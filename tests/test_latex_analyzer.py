@@ -0,0 +1,91 @@
+"""Tests for LaTeX analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.latex import LaTeXAnalyzer
+
+
+PAPER = '''\\documentclass[11pt,a4paper]{article}
+\\usepackage[utf8]{inputenc}
+\\usepackage{amsmath,graphicx}
+\\addbibresource{refs.bib}
+
+% \\section{Commented out}
+\\newcommand{\\R}{\\mathbb{R}}
+\\newcommand{\\norm}[1]{\\left\\lVert#1\\right\\rVert}
+\\DeclareMathOperator{\\argmax}{arg\\,max}
+\\def\\eps{\\varepsilon}
+\\newenvironment{note}[1]
+  {\\begin{quote}\\textbf{#1}}
+  {\\end{quote}}
+
+\\begin{document}
+\\section{Introduction}
+Prior work~\\cite{smith2020,doe2019} and \\citep[p.~3]{smith2020}.
+\\input{sections/background}
+
+\\subsection*{Motivation}
+Text with 50\\% of things.
+
+\\section[Short]{Methods and \\emph{Results}}
+\\begin{verbatim}
+\\section{Not a section}
+\\end{verbatim}
+\\subsection{Setup}
+\\include{appendix}
+\\end{document}
+'''
+
+
+class TestLaTeXAnalyzer(unittest.TestCase):
+    """Test LaTeX analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.tex', delete=False) as f:
+            f.write(PAPER)
+        self.addCleanup(os.unlink, f.name)
+        self.analyzer = LaTeXAnalyzer(f.name)
+        self.structure = self.analyzer.get_structure()
+
+    def test_headings(self):
+        """Section hierarchy; comments and verbatim blocks are ignored."""
+        headings = [(h['name'], h['level'], h['line'], h['line_end']) for h in self.structure['headings']]
+        self.assertEqual(headings, [
+            ('Introduction', 3, 16, 22),
+            ('Motivation', 4, 20, 22),
+            ('Methods and \\emph{Results}', 3, 23, 28),
+            ('Setup', 4, 27, 28),
+        ])
+        self.assertFalse(self.structure['headings'][1]['numbered'])
+
+    def test_packages_and_includes(self):
+        """Document class, packages with options, included and bibliography files."""
+        packages = [(p['name'], p.get('options')) for p in self.structure['packages']]
+        self.assertEqual(packages, [('article', '11pt,a4paper'), ('inputenc', 'utf8'),
+                                    ('amsmath', None), ('graphicx', None)])
+        includes = [(i['target'], i.get('kind')) for i in self.structure['includes']]
+        self.assertEqual(includes, [('refs.bib', 'bibliography'), ('sections/background', None), ('appendix', None)])
+
+    def test_commands(self):
+        """Command, operator, \\def and environment definitions with argument counts."""
+        commands = [(c['name'], c['kind'], c.get('params', 0)) for c in self.structure['commands']]
+        self.assertEqual(commands, [
+            ('\\R', 'command', 0), ('\\norm', 'command', 1), ('\\argmax', 'operator', 0),
+            ('\\eps', 'def', 0), ('note', 'environment', 1),
+        ])
+        self.assertEqual(self.structure['commands'][4]['line_end'], 13)
+
+    def test_citations(self):
+        """Cited keys are counted across cite variants."""
+        citations = {c['name']: c['count'] for c in self.structure['citations']}
+        self.assertEqual(citations, {'smith2020': 2, 'doe2019': 1})
+
+    def test_extract_section(self):
+        """A section is extracted with its subsections."""
+        element = self.analyzer.extract_element('section', 'Introduction')
+        self.assertEqual((element['line_start'], element['line_end']), (16, 22))
+
+
+if __name__ == '__main__':
+    unittest.main()
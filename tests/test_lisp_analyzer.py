@@ -0,0 +1,95 @@
+"""Tests for the Lisp-family (Clojure/Scheme/Racket) analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.lisp import LispAnalyzer, read_forms
+
+
+CORE_CLJ = '''(ns myapp.core
+  "Docs (with an unbalanced paren"
+  (:require [clojure.string :as str]))
+
+;; (defn commented-out [])
+(def ^:private secret "x)")
+
+(defn greet
+  "Say hi"
+  [name]
+  (str "Hello, " name))
+
+(defn- helper [x] #_(ignored) (inc x))
+
+(defn multi
+  ([] (multi 1))
+  ([x] (* x 2)))
+
+(defmacro unless [test & body]
+  `(if ~test nil (do ~@body)))
+
+(defrecord Circle [r])
+'''
+
+UTIL_RKT = '''#lang racket
+(require (only-in racket/string string-join))
+(provide greet)
+
+(define (greet name)
+  (string-append "hi " name))
+
+(define pi 3.14)
+(define square (lambda (x) (* x x)))
+
+#|
+(define (commented) 1)
+|#
+'''
+
+
+class TestLispAnalyzer(unittest.TestCase):
+    """Test s-expression based extraction."""
+
+    def _analyze(self, suffix, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+            path = f.name
+        self.addCleanup(os.unlink, path)
+        return LispAnalyzer(path).get_structure()
+
+    def test_reader_line_ranges(self):
+        forms = read_forms('(a\n  (b "c)")\n  [d])\n; (e)\n(f)')
+        self.assertEqual(len(forms), 2)
+        self.assertEqual((forms[0].line, forms[0].line_end), (1, 3))
+        self.assertEqual(forms[1].line, 5)
+
+    def test_clojure(self):
+        structure = self._analyze('.clj', CORE_CLJ)
+
+        self.assertEqual(structure['namespaces'][0]['name'], 'myapp.core')
+        self.assertEqual(structure['imports'][0]['name'], 'clojure.string')
+
+        functions = {f['name']: f for f in structure['functions']}
+        self.assertEqual(set(functions), {'greet', 'helper', 'multi'})
+        self.assertEqual(functions['greet']['signature'], ' [name]')
+        self.assertEqual((functions['greet']['line'], functions['greet']['line_end']), (8, 11))
+        self.assertEqual(functions['helper']['visibility'], 'private')
+        self.assertEqual(functions['multi']['arities'], 2)
+
+        self.assertEqual(structure['macros'][0]['name'], 'unless')
+        self.assertEqual(structure['types'][0]['name'], 'Circle')
+        self.assertEqual(structure['definitions'][0]['visibility'], 'private')
+
+    def test_racket(self):
+        structure = self._analyze('.rkt', UTIL_RKT)
+
+        self.assertEqual(structure['namespaces'][0]['content'], '#lang racket')
+        self.assertEqual(structure['imports'][0]['name'], 'racket/string')
+        self.assertEqual(structure['exports'][0]['name'], 'greet')
+
+        functions = {f['name']: f.get('signature') for f in structure['functions']}
+        self.assertEqual(functions, {'greet': '(name)', 'square': '(x)'})
+        self.assertEqual([d['name'] for d in structure['definitions']], ['pi'])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,51 @@
+"""Tests for LSP DocumentSymbol output."""
+
+import unittest
+from reveal.lsp import SYMBOL_KINDS, document_symbols
+
+
+LINES = [
+    'require "json"',
+    'module Shapes',
+    '  class Circle',
+    '    def area : Float64',
+    '      3.14',
+    '    end',
+    '  end',
+    'end',
+    'def main',
+    'end',
+]
+STRUCTURE = {
+    'imports': [{'line': 1, 'content': 'require "json"'}],
+    'modules': [{'line': 2, 'line_end': 8, 'name': 'Shapes'}],
+    'classes': [{'line': 3, 'line_end': 7, 'name': 'Shapes::Circle'}],
+    'methods': [{'line': 4, 'line_end': 6, 'name': 'Shapes::Circle#area', 'signature': '() -> Float64'}],
+    'functions': [{'line': 9, 'line_end': 10, 'name': 'main', 'kind': 'proc', 'signature': '()  (proc)'}],
+}
+
+
+class TestDocumentSymbols(unittest.TestCase):
+    """Test DocumentSymbol trees."""
+
+    def test_nesting_and_kinds(self):
+        """Symbols nest by line range; names lose their parent's prefix; kinds map to SymbolKind."""
+        symbols = document_symbols(LINES, STRUCTURE)
+        self.assertEqual([(s['name'], s['kind']) for s in symbols],
+                         [('Shapes', SYMBOL_KINDS['module']), ('main', SYMBOL_KINDS['function'])])
+        circle = symbols[0]['children'][0]
+        self.assertEqual((circle['name'], circle['kind']), ('Circle', SYMBOL_KINDS['class']))
+        area = circle['children'][0]
+        self.assertEqual((area['name'], area['kind'], area['detail']), ('area', SYMBOL_KINDS['method'], '() -> Float64'))
+        self.assertEqual(symbols[1]['detail'], '()')
+
+    def test_ranges_are_zero_based(self):
+        """range spans the definition; selectionRange is the name on its first line."""
+        area = document_symbols(LINES, STRUCTURE)[0]['children'][0]['children'][0]
+        self.assertEqual(area['range'], {'start': {'line': 3, 'character': 0}, 'end': {'line': 5, 'character': 7}})
+        self.assertEqual(area['selectionRange'],
+                         {'start': {'line': 3, 'character': 8}, 'end': {'line': 3, 'character': 12}})
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,73 @@
+"""Tests for Lua analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.lua import LuaAnalyzer
+
+
+MODULE_LUA = '''local json = require("dkjson")
+local M = {}
+
+local function helper(x)
+  if x then
+    for i = 1, 3 do print(i) end
+  end
+  return x
+end
+
+function M.setup(opts)
+  local inner = function() return 1 end
+  return helper(opts)
+end
+
+function M:method(a, b)
+  return a + b
+end
+
+function global_fn()
+  return "end"
+end
+
+return M
+'''
+
+
+class TestLuaAnalyzer(unittest.TestCase):
+    """Test Lua analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.lua', delete=False) as f:
+            f.write(MODULE_LUA)
+            self.path = f.name
+        self.structure = LuaAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_requires(self):
+        self.assertEqual(self.structure['imports'][0]['name'], 'dkjson')
+        self.assertEqual(self.structure['imports'][0]['signature'], ' as json')
+
+    def test_local_and_global_functions(self):
+        functions = {f['name']: f for f in self.structure['functions']}
+
+        # Nested function expressions are not listed
+        self.assertEqual(set(functions), {'helper', 'global_fn'})
+        self.assertEqual(functions['helper']['visibility'], 'local')
+        self.assertEqual(functions['helper']['line_end'], 9)
+        self.assertEqual(functions['global_fn']['visibility'], 'global')
+
+    def test_module_tables(self):
+        module = self.structure['modules'][0]
+        self.assertEqual((module['name'], module['line']), ('M', 2))
+        self.assertEqual(module['functions'], ['setup', 'method'])
+        self.assertTrue(module['exported'])
+
+        methods = {m['name']: m for m in self.structure['methods']}
+        self.assertEqual(methods['M.setup']['line_end'], 14)
+        self.assertEqual(methods['M:method']['kind'], 'method')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,72 @@
+"""Tests for Markdown analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.markdown import MarkdownAnalyzer
+
+
+DOC_MD = '''# Title
+
+See [guide](missing.md), [usage](#usage) and [site](https://example.com).
+
+## Usage
+
+```bash
+# not a heading
+reveal file.py
+```
+
+### Options
+
+```python
+print("hi")
+```
+
+## Links
+
+[site again](https://example.com)
+'''
+
+
+class TestMarkdownAnalyzer(unittest.TestCase):
+    """Test Markdown outline extraction."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.md', delete=False) as f:
+            f.write(DOC_MD)
+            self.path = f.name
+        self.analyzer = MarkdownAnalyzer(self.path)
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_heading_hierarchy(self):
+        headings = self.analyzer.get_structure()['headings']
+
+        self.assertEqual([(h['name'], h['level']) for h in headings],
+                         [('Title', 1), ('Usage', 2), ('Options', 3), ('Links', 2)])
+        usage = headings[1]
+        self.assertEqual((usage['line'], usage['line_end']), (5, 17))
+
+    def test_code_languages_and_link_targets(self):
+        structure = self.analyzer.get_structure()
+
+        languages = {c['name']: c['blocks'] for c in structure['code_languages']}
+        self.assertEqual(languages, {'bash': 1, 'python': 1})
+
+        targets = {t['name']: t for t in structure['link_targets']}
+        self.assertEqual(targets['https://example.com']['count'], 2)
+        self.assertTrue(targets['missing.md']['broken'])
+        self.assertNotIn('broken', targets['#usage'])
+
+    def test_explicit_links_replace_summaries(self):
+        structure = self.analyzer.get_structure(extract_links=True)
+
+        self.assertEqual(len(structure['links']), 4)
+        self.assertNotIn('link_targets', structure)
+        self.assertNotIn('code_languages', structure)
+
+
+if __name__ == '__main__':
+    unittest.main()
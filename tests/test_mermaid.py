@@ -0,0 +1,64 @@
+"""Tests for Mermaid diagram output."""
+
+import unittest
+from reveal.dependencies import build_import_graph
+from reveal.mermaid import render_class_diagram, render_flowchart
+
+
+STRUCTURE = {
+    'classes': [
+        {'line': 1, 'line_end': 10, 'name': 'Shapes::Shape', 'signature': ''},
+        {'line': 12, 'line_end': 20, 'name': 'Shapes::Circle', 'superclass': 'Shape', 'signature': ' < Shape'},
+    ],
+    'enums': [{'line': 22, 'line_end': 25, 'name': 'Color', 'members': ['Red', 'Green']}],
+    'methods': [
+        {'line': 2, 'line_end': 4, 'name': 'Shapes::Shape#area', 'signature': '() -> Float64'},
+        {'line': 13, 'line_end': 15, 'name': 'Shapes::Circle#scale', 'signature': '(f : Array(Int32)) -> Nil',
+         'visibility': 'private'},
+    ],
+    'class_methods': [{'line': 16, 'line_end': 18, 'name': 'Shapes::Circle.unit', 'signature': '() -> Circle'}],
+    'functions': [{'line': 30, 'line_end': 32, 'name': 'main', 'signature': '(args : Hash<String, Int>)'}],
+}
+
+
+class TestClassDiagram(unittest.TestCase):
+    """Class diagram for a file."""
+
+    def test_classes_members_and_inheritance(self):
+        diagram = render_class_diagram(STRUCTURE, 'demo.cr').splitlines()
+        self.assertEqual(diagram[0], 'classDiagram')
+        circle = diagram.index('    class Shapes__Circle["Shapes::Circle"] {')
+        self.assertEqual(diagram[circle + 1:circle + 4], [
+            '        -scale(f : Array(Int32)) Nil',
+            '        unit() Circle$',
+            '    }',
+        ])
+        self.assertIn('        <<enumeration>>', diagram)
+        self.assertIn('        Red', diagram)
+        # Unqualified superclass resolves to the qualified class in the file
+        self.assertIn('    Shapes__Shape <|-- Shapes__Circle', diagram)
+        # Top-level functions go to a module class; generics use ~T~
+        self.assertIn('    class demo_cr["demo.cr"] {', diagram)
+        self.assertIn('        main(args : Hash~String, Int~)', diagram)
+
+
+class TestFlowchart(unittest.TestCase):
+    """Import flowchart for a project."""
+
+    def test_subgraphs_and_edges(self):
+        graph = build_import_graph([
+            ('main.cr', {'imports': [{'line': 1, 'content': 'require "./src/lib"'}]}),
+            ('src/lib.cr', {}),
+        ])
+        self.assertEqual(render_flowchart(graph).splitlines(), [
+            'flowchart LR',
+            '    n0["main.cr"]',
+            '    subgraph d1["src/"]',
+            '        n1["lib.cr"]',
+            '    end',
+            '    n0 --> n1',
+        ])
+
+
+if __name__ == '__main__':
+    unittest.main()
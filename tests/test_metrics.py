@@ -0,0 +1,92 @@
+"""Tests for per-file and per-function metrics."""
+
+import unittest
+from reveal.metrics import (comment_syntax, cyclomatic_complexity, file_metrics, function_metrics, hotspot_score,
+                            line_counts, nesting_depth)
+
+
+LINES = [
+    'def check(x):',
+    '    # if this is a comment, it does not count',
+    '    if x > 0 and x < 10:',
+    '        return 1',
+    '    elif x < 0 || x == 0:',
+    '        return -1',
+    '    for i in range(3):',
+    '        x = a ? b : c',
+    '    return 0',
+    'def simple():',
+    '    return 1',
+]
+
+
+class TestMetrics(unittest.TestCase):
+    """Test complexity and file metrics."""
+
+    def test_cyclomatic_complexity(self):
+        """One plus each branch, loop and short-circuit operator; comments ignored."""
+        self.assertEqual(cyclomatic_complexity(LINES, 1, 9), 7)
+        self.assertEqual(cyclomatic_complexity(LINES, 10, 11), 1)
+
+    def test_file_metrics(self):
+        """Complexity is summed over functions; every item counts as a symbol."""
+        structure = {
+            'imports': [{'line': 1, 'content': 'import os'}],
+            'functions': [{'line': 1, 'line_end': 9, 'name': 'check'},
+                          {'line': 10, 'line_end': 11, 'name': 'simple'}],
+        }
+        self.assertEqual(file_metrics(LINES, structure),
+                         {'lines': 11, 'symbols': 3, 'functions': 2, 'complexity': 8, 'max_complexity': 7})
+
+    def test_nesting_depth(self):
+        """Levels of nested blocks by indentation, whatever the block syntax."""
+        self.assertEqual(nesting_depth(LINES, 1, 9), 1)
+        self.assertEqual(nesting_depth(LINES, 10, 11), 0)
+        go = ['func walk(n *Node) {', '\tfor n != nil {', '\t\tif n.ok {', '\t\t\tswitch n.kind {',
+              '\t\t\t}', '\t\t} else if n.bad {', '\t\t}', '\t}', '}']
+        self.assertEqual(nesting_depth(go, 1, 9), 3)
+        nim = ['proc f(x: int) =', '  result = x', '  while x > 0:', '    if x == 1:', '      return']
+        self.assertEqual(nesting_depth(nim, 1, 5), 2)
+
+    def test_function_metrics(self):
+        """One entry per function, in line order."""
+        structure = {'methods': [{'line': 10, 'line_end': 11, 'name': 'simple'}],
+                     'functions': [{'line': 1, 'line_end': 9, 'name': 'check'}]}
+        self.assertEqual(function_metrics(LINES, structure), [
+            {'name': 'check', 'line': 1, 'line_end': 9, 'lines': 9, 'complexity': 7, 'nesting': 1},
+            {'name': 'simple', 'line': 10, 'line_end': 11, 'lines': 2, 'complexity': 1, 'nesting': 0},
+        ])
+
+    def test_hotspot_score(self):
+        """Lines, complexity and nesting over their limits, added up."""
+        at_limits = {'lines': 50, 'complexity': 10, 'nesting': 4}
+        self.assertEqual(hotspot_score(at_limits, 50, 10, 4), 3.0)
+        self.assertEqual(hotspot_score(dict(at_limits, lines=100), 50, 10, 4), 4.0)
+        self.assertEqual(hotspot_score({'lines': 5, 'complexity': 1, 'nesting': 0}, 50, 10, 0), 0.2)
+
+
+class TestLineCounts(unittest.TestCase):
+    """Code, comment and blank lines per file."""
+
+    def test_c_style(self):
+        """Block comments span lines; a line with any code is code; comment openers in strings don't count."""
+        lines = ['/* License', ' * text */', '', '#include <stdio.h>', 'int x; /* trailing', 'still */',
+                 '// note', 'puts("/*");', 'int y;']
+        self.assertEqual(line_counts(lines, 'src/main.c'), {'code': 4, 'comment': 4, 'blank': 1})
+
+    def test_language_specific(self):
+        self.assertEqual(line_counts(['--[[ block', ']]', '-- line', 'x = 1'], 'mod.lua'),
+                         {'code': 1, 'comment': 3, 'blank': 0})
+        self.assertEqual(line_counts(['# line', '=begin', 'doc', '=end', 'puts 1'], 'app.rb'),
+                         {'code': 1, 'comment': 4, 'blank': 0})
+        self.assertEqual(line_counts(['{"#": 1}'], 'data.json'), {'code': 1, 'comment': 0, 'blank': 0})
+
+    def test_comment_syntax_lookup(self):
+        """By suffix, by whole file name, else common defaults."""
+        self.assertEqual(comment_syntax('lib/Parser.HS'), (('--',), (('{-', '-}'),)))
+        self.assertEqual(comment_syntax('Makefile'), (('#',), ()))
+        self.assertEqual(comment_syntax('bin/deploy')[0], ('#', '//'))
+
+
+if __name__ == '__main__':
+    unittest.main()
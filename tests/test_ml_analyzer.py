@@ -0,0 +1,130 @@
+"""Tests for OCaml/F# analyzers."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.ml import OCamlAnalyzer, FSharpAnalyzer
+
+
+OCAML = '''open Printf
+
+type point = { x : float; mutable y : float }
+type shape =
+  | Circle of float
+  | Rect of float * float
+
+module type SHAPE = sig
+  val area : shape -> float
+end
+
+module Geometry = struct
+  let pi = 3.14159
+
+  let rec area s =
+    let sq x = x *. x in
+    match s with
+    | Circle r -> pi *. sq r
+    | Rect (w, h) -> w *. h
+
+  module Inner = struct
+    let helper () = ()
+  end
+end
+
+module Make (O : Set.OrderedType) = struct
+  let empty = []
+end
+
+let main () =
+  let s = Circle 1.0 in
+  printf "%f" (Geometry.area s)
+'''
+
+FSHARP = '''namespace MyApp
+
+open System
+
+type Shape =
+    | Circle of float
+    | Rect of float * float
+
+type Counter(start: int) =
+    let mutable count = start
+    member this.Increment() = count <- count + 1
+
+module Geometry =
+    let area shape =
+        let sq x = x * x
+        sq shape
+'''
+
+
+class TestOCamlAnalyzer(unittest.TestCase):
+    """Test OCaml analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.ml', delete=False) as f:
+            f.write(OCAML)
+            self.path = f.name
+        self.structure = OCamlAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_modules_nested_and_functors(self):
+        """Nested modules are qualified; functors are listed separately."""
+        self.assertEqual([m['name'] for m in self.structure['modules']], ['Geometry', 'Geometry.Inner'])
+        self.assertEqual(self.structure['modules'][0]['line_end'], 24)
+        self.assertEqual(self.structure['functors'][0]['name'], 'Make')
+        self.assertEqual(self.structure['functors'][0]['signature'], ' (O : Set.OrderedType)')
+        self.assertEqual(self.structure['module_types'][0]['name'], 'SHAPE')
+
+    def test_types(self):
+        """Records and variants are summarized."""
+        point, shape = self.structure['types']
+        self.assertEqual(point['kind'], 'record')
+        self.assertIn('{ x; y }', point['signature'])
+        self.assertEqual(shape['kind'], 'variant')
+        self.assertIn('Circle | Rect', shape['signature'])
+
+    def test_let_bindings_module_level_only(self):
+        """Local `let ... in` bindings are not listed."""
+        names = [f['name'] for f in self.structure['functions']]
+        self.assertEqual(names, ['pi', 'area', 'helper', 'empty', 'main'])
+        area = self.structure['functions'][1]
+        self.assertEqual(area['kind'], 'rec')
+        self.assertEqual(area['module'], 'Geometry')
+        self.assertEqual(area['line_end'], 19)
+
+    def test_val_signatures(self):
+        """`val` declarations carry their type."""
+        self.assertEqual(self.structure['values'][0]['signature'], ': shape -> float')
+
+
+class TestFSharpAnalyzer(unittest.TestCase):
+    """Test F# analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.fs', delete=False) as f:
+            f.write(FSHARP)
+            self.path = f.name
+        self.structure = FSharpAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_types_and_members(self):
+        """Unions, classes and their members."""
+        kinds = {t['name']: t['kind'] for t in self.structure['types']}
+        self.assertEqual(kinds, {'Shape': 'union', 'Counter': 'class'})
+        self.assertEqual([m['name'] for m in self.structure['methods']], ['Counter.Increment'])
+
+    def test_modules_and_functions(self):
+        """Indentation-based modules; local lets are skipped."""
+        self.assertEqual([m['name'] for m in self.structure['modules']], ['Geometry'])
+        self.assertEqual([f['name'] for f in self.structure['functions']], ['area'])
+        self.assertEqual(self.structure['namespaces'][0]['content'], 'namespace MyApp')
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -13,7 +13,7 @@ import tempfile
 import os
 from pathlib import Path
 from reveal.analyzers.javascript import JavaScriptAnalyzer
-from reveal.analyzers.typescript import TypeScriptAnalyzer
+from reveal.analyzers.typescript import TypeScriptAnalyzer, TSXAnalyzer
 from reveal.analyzers.bash import BashAnalyzer
 
 
@@ -272,10 +272,11 @@ type Status = "active" | "inactive";
             analyzer = TypeScriptAnalyzer(temp_path)
             structure = analyzer.get_structure()
 
-            # Interfaces might be extracted as a separate category
-            # or as classes, depending on tree-sitter behavior
-            # Just verify the file can be parsed
-            self.assertIsNotNone(structure)
+            interface_names = [i['name'] for i in structure['interfaces']]
+            self.assertEqual(interface_names, ['User', 'Product'])
+
+            type_names = [t['name'] for t in structure['types']]
+            self.assertEqual(type_names, ['Status'])
 
         finally:
             os.unlink(temp_path)
@@ -320,6 +321,44 @@ function App() {
         finally:
             os.unlink(temp_path)
 
+    def test_tsx_components_and_exports(self):
+        """TSX analyzer should list React components and exports."""
+        code = '''import React from 'react';
+
+export const Greeting = ({ name }: { name: string }) => {
+    return <div>Hello, {name}!</div>;
+};
+
+export function App() {
+    return <Greeting name="World" />;
+}
+
+function formatName(name: string): string {
+    return name.trim();
+}
+'''
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.tsx', delete=False, encoding='utf-8') as f:
+            f.write(code)
+            f.flush()
+            temp_path = f.name
+
+        try:
+            analyzer = TSXAnalyzer(temp_path)
+            structure = analyzer.get_structure()
+
+            component_names = [c['name'] for c in structure['components']]
+            self.assertEqual(component_names, ['Greeting', 'App'])
+
+            export_names = [e['name'] for e in structure['exports']]
+            self.assertEqual(export_names, ['Greeting', 'App'])
+
+            func_names = [f['name'] for f in structure['functions']]
+            self.assertIn('formatName', func_names)
+            self.assertIn('Greeting', func_names)
+
+        finally:
+            os.unlink(temp_path)
+
 
 class TestBashAnalyzer(unittest.TestCase):
     """Test Bash analyzer."""
@@ -455,6 +494,45 @@ log_info "Build complete!"
         finally:
             os.unlink(temp_path)
 
+    def test_bash_sources_exports_and_sections(self):
+        """Should list sourced files, exports and commands grouped by heading."""
+        code = '''#!/bin/bash
+set -e
+source ./lib/common.sh
+. "$HOME/.env"
+
+export APP_ENV=production
+export PATH
+
+# ==== Build ====
+make clean
+make all | tee build.log
+
+# Deploy
+# (requires credentials)
+if [ -n "$CI" ]; then
+    rsync -a dist/ server:/srv/app
+fi
+'''
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.sh', delete=False, encoding='utf-8') as f:
+            f.write(code)
+            temp_path = f.name
+
+        try:
+            structure = BashAnalyzer(temp_path).get_structure()
+
+            self.assertEqual(len(structure['imports']), 2)
+            self.assertEqual([e['name'] for e in structure['exports']], ['APP_ENV', 'PATH'])
+            self.assertEqual(structure['exports'][0]['signature'], '=production')
+
+            sections = structure['sections']
+            self.assertEqual([s['name'] for s in sections], ['(script start)', 'Build', 'Deploy'])
+            self.assertEqual(sections[1]['signature'], ' (2 commands: make)')
+            self.assertEqual(sections[2]['signature'], ' (1 command: if)')
+
+        finally:
+            os.unlink(temp_path)
+
 
 class TestCrossPlatformCompatibility(unittest.TestCase):
     """Test that all new analyzers work on all platforms."""
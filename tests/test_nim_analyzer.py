@@ -0,0 +1,81 @@
+"""Tests for Nim analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.nim import NimAnalyzer
+
+
+SOURCE = '''import std/[os, strutils]
+export strutils
+
+#[
+proc hidden() = discard
+]#
+
+type
+  Shape* = ref object of RootObj
+    name*: string
+    x, y: float
+  Color = enum
+    red, green = 2, blue
+
+proc area*(s: Shape): float =
+  result = 0.0
+
+func twice[T](x: T,
+              y: int = 2): T {.inline.} =
+  x
+
+method draw*(s: Shape) {.base.} =
+  echo s.name
+
+template check(cond: untyped) =
+  assert cond
+
+proc outer*() =
+  proc inner() = discard
+  inner()
+'''
+
+
+class TestNimAnalyzer(unittest.TestCase):
+    """Test Nim analyzer."""
+
+    def _analyze(self, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.nim', delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return NimAnalyzer(f.name)
+
+    def test_imports_and_types(self):
+        """Imports, exports and type sections with parents, fields and enum values."""
+        structure = self._analyze(SOURCE).get_structure()
+        self.assertEqual([i['content'] for i in structure['imports']], ['import std/[os, strutils]'])
+        self.assertEqual([e['content'] for e in structure['exports']], ['export strutils'])
+
+        types = {t['name']: t for t in structure['types']}
+        self.assertEqual(types['Shape']['parent'], 'RootObj')
+        self.assertEqual(types['Shape']['fields'], ['name', 'x', 'y'])
+        self.assertEqual(types['Shape']['visibility'], 'public')
+        self.assertEqual((types['Color']['kind'], types['Color']['values']), ('enum', ['red', 'green', 'blue']))
+
+    def test_routines(self):
+        """Routines by kind with signatures; commented-out and nested procs are skipped."""
+        structure = self._analyze(SOURCE).get_structure()
+        functions = {f['name']: f for f in structure['functions']}
+        self.assertEqual(sorted(functions), ['area', 'outer', 'twice'])
+        self.assertEqual(functions['area']['signature'], '(s: Shape) -> float')
+        self.assertEqual(functions['twice']['signature'], '[T](x: T, y: int = 2) -> T  (func)')
+        self.assertEqual(functions['twice']['visibility'], 'private')
+        self.assertEqual(structure['methods'][0]['name'], 'draw')
+        self.assertEqual(structure['macros'][0]['kind'], 'template')
+
+    def test_extract_proc(self):
+        """A proc is extracted with its indented body."""
+        result = self._analyze(SOURCE).extract_element('function', 'area')
+        self.assertEqual((result['line_start'], result['line_end']), (15, 16))
+
+
+if __name__ == '__main__':
+    unittest.main()
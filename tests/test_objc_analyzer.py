@@ -0,0 +1,85 @@
+"""Tests for Objective-C analyzer."""
+
+import os
+import shutil
+import tempfile
+import unittest
+from reveal.base import get_analyzer
+from reveal.analyzers.objc import ObjCAnalyzer
+
+
+WIDGET_H = '''#import <Foundation/Foundation.h>
+
+@interface Widget : NSObject <NSCopying>
+@property (nonatomic, strong) NSString *title;
+- (instancetype)initWithTitle:(NSString *)title
+                        count:(int)count;
++ (Widget *)sharedWidget;
+@end
+'''
+
+WIDGET_M = '''#import "Widget.h"
+
+@implementation Widget
+
+- (instancetype)initWithTitle:(NSString *)title
+                        count:(int)count {
+    self = [super init];
+    return self;
+}
+
++ (Widget *)sharedWidget {
+    return nil;
+}
+
+@end
+'''
+
+
+class TestObjCAnalyzer(unittest.TestCase):
+    """Test Objective-C analyzer."""
+
+    def setUp(self):
+        self.temp_dir = tempfile.mkdtemp()
+        self.header = os.path.join(self.temp_dir, 'Widget.h')
+        self.source = os.path.join(self.temp_dir, 'Widget.m')
+        with open(self.header, 'w') as f:
+            f.write(WIDGET_H)
+        with open(self.source, 'w') as f:
+            f.write(WIDGET_M)
+
+    def tearDown(self):
+        shutil.rmtree(self.temp_dir)
+
+    def test_header_interface_properties_and_methods(self):
+        # .h files with @interface are analyzed as Objective-C
+        analyzer = get_analyzer(self.header)(self.header)
+        self.assertEqual(analyzer.type_name, 'Objective-C Header')
+        structure = analyzer.get_structure()
+
+        interface = structure['interfaces'][0]
+        self.assertEqual((interface['name'], interface['line_end']), ('Widget', 8))
+        self.assertEqual(interface['implementation'], 'Widget.m:3')
+
+        prop = structure['properties'][0]
+        self.assertEqual(prop['name'], 'Widget.title')
+        self.assertEqual(prop['attributes'], ['nonatomic', 'strong'])
+
+        names = [m['name'] for m in structure['methods']]
+        self.assertEqual(names, ['-[Widget initWithTitle:count:]', '+[Widget sharedWidget]'])
+        self.assertTrue(structure['methods'][0]['declaration'])
+
+    def test_implementation_pairs_with_header(self):
+        structure = ObjCAnalyzer(self.source).get_structure()
+
+        implementation = structure['implementations'][0]
+        self.assertEqual(implementation['interface'], 'Widget.h:3')
+        self.assertEqual(implementation['line_end'], 15)
+
+        init = structure['methods'][0]
+        self.assertEqual((init['line'], init['line_end']), (5, 9))
+        self.assertEqual(init['signature'], ' -> instancetype')
+
+
+if __name__ == '__main__':
+    unittest.main()
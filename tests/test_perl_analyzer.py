@@ -0,0 +1,84 @@
+"""Tests for Perl analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.perl import PerlAnalyzer
+
+
+SHAPE_PM = '''package My::Shape;
+use strict;
+use parent -norequire, 'My::Base';
+use List::Util qw(sum max);
+
+=head1 NAME
+
+sub not_a_sub { }
+
+=cut
+
+sub new {
+    my ($class, %args) = @_;
+    my $self = { %args };   # a { brace in a comment
+    return bless $self, $class;
+}
+
+sub _helper { 1 }
+
+package My::Circle;
+use Moose;
+extends 'My::Shape';
+has 'radius' => (is => 'ro', isa => 'Num');
+
+sub area {
+    my $self = shift;
+    return 3.14 * $self->radius ** 2;
+}
+
+1;
+__END__
+sub after_end { }
+'''
+
+
+class TestPerlAnalyzer(unittest.TestCase):
+    """Test Perl analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.pm', delete=False) as f:
+            f.write(SHAPE_PM)
+            self.path = f.name
+        self.structure = PerlAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_packages_and_parents(self):
+        """Statement-form packages span until the next one."""
+        shape, circle = self.structure['packages']
+        self.assertEqual(shape['parents'], ['My::Base'])
+        self.assertEqual(shape['line_end'], 18)
+        self.assertEqual(circle['parents'], ['My::Shape'])
+        self.assertEqual(circle['line_end'], 30)
+
+    def test_subs(self):
+        """Subs get unpacked arguments, visibility and their package; POD is skipped."""
+        subs = self.structure['subs']
+        self.assertEqual([s['name'] for s in subs], ['new', '_helper', 'area'])
+        self.assertEqual(subs[0]['signature'], '($class, %args)')
+        self.assertEqual(subs[0]['line_end'], 16)
+        self.assertEqual(subs[1]['visibility'], 'private')
+        self.assertEqual(subs[2]['signature'], '($self)')
+        self.assertEqual(subs[2]['package'], 'My::Circle')
+
+    def test_imports_and_pragmas(self):
+        """Lowercase pragmas are separate from module imports."""
+        self.assertEqual([p['content'] for p in self.structure['pragmas']],
+                         ['use strict', "use parent -norequire, 'My::Base'"])
+        self.assertEqual([i['content'] for i in self.structure['imports']],
+                         ['use List::Util qw(sum max)', 'use Moose'])
+        self.assertEqual(self.structure['attributes'][0]['signature'], ' (is => ro, isa => Num)')
+
+
+if __name__ == '__main__':
+    unittest.main()
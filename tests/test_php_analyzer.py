@@ -0,0 +1,112 @@
+"""Tests for PHP analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.base import get_analyzer
+from reveal.analyzers.php import PHPAnalyzer
+
+
+SOURCE = '''<?php
+
+namespace App\\Models;
+
+use Illuminate\\Support\\Str;
+use App\\Contracts\\Shape as ShapeContract;
+
+interface Shape
+{
+    public function area(): float;
+}
+
+trait Named
+{
+    protected function label(string $prefix): string
+    {
+        return $prefix . static::class;
+    }
+}
+
+abstract class Circle implements Shape
+{
+    use Named;
+
+    public function area(): float
+    {
+        return 3.14 * $this->radius ** 2;
+    }
+
+    private static function unit(): self
+    {
+        return new static();
+    }
+
+    function radius()
+    {
+        return 1;
+    }
+}
+
+function helper($value)
+{
+    return Str::upper($value);
+}
+'''
+
+
+class TestPHPAnalyzer(unittest.TestCase):
+    """Test PHP analyzer."""
+
+    def setUp(self):
+        self.temp_dir = tempfile.TemporaryDirectory()
+        self.path = os.path.join(self.temp_dir.name, 'Circle.php')
+        with open(self.path, 'w') as f:
+            f.write(SOURCE)
+        self.structure = PHPAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        self.temp_dir.cleanup()
+
+    def test_registration(self):
+        self.assertIs(get_analyzer(self.path), PHPAnalyzer)
+
+    def test_namespace(self):
+        self.assertEqual([(item['line'], item['content']) for item in self.structure['namespaces']],
+                         [(3, 'namespace App\\Models')])
+
+    def test_use_imports(self):
+        # Top-level imports only: the trait use inside Circle is not one
+        self.assertEqual([(item['line'], item['content']) for item in self.structure['imports']],
+                         [(5, 'use Illuminate\\Support\\Str'), (6, 'use App\\Contracts\\Shape as ShapeContract')])
+
+    def test_classes_interfaces_and_traits(self):
+        circle = self.structure['classes'][0]
+        self.assertEqual((circle['name'], circle['line'], circle['line_end']), ('Circle', 21, 39))
+        self.assertEqual(circle['modifiers'], ['abstract'])
+        self.assertEqual([item['name'] for item in self.structure['interfaces']], ['Shape'])
+        self.assertEqual([item['name'] for item in self.structure['traits']], ['Named'])
+        self.assertEqual([item['name'] for item in self.structure['trait_uses']], ['Circle uses Named'])
+
+    def test_methods_named_by_class(self):
+        names = [method['name'] for method in self.structure['methods']]
+        self.assertEqual(names, ['Shape::area', 'Named::label', 'Circle::area', 'Circle::unit', 'Circle::radius'])
+        # Standalone functions are not methods
+        self.assertEqual([function['name'] for function in self.structure['functions']], ['helper'])
+
+    def test_method_visibility(self):
+        methods = {method['name']: method for method in self.structure['methods']}
+        self.assertEqual(methods['Named::label']['visibility'], 'protected')
+        self.assertEqual(methods['Circle::area']['visibility'], 'public')
+        self.assertEqual(methods['Circle::unit']['visibility'], 'private')
+        self.assertEqual(methods['Circle::unit']['modifiers'], ['static'])
+        # No modifier means public
+        self.assertEqual(methods['Circle::radius']['visibility'], 'public')
+        self.assertNotIn('modifiers', methods['Circle::radius'])
+
+    def test_signature(self):
+        methods = {method['name']: method for method in self.structure['methods']}
+        self.assertEqual(methods['Named::label']['signature'], '(string $prefix) -> string')
+
+
+if __name__ == '__main__':
+    unittest.main()
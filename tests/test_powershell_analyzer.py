@@ -0,0 +1,92 @@
+"""Tests for PowerShell analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.powershell import PowerShellAnalyzer
+
+
+DEPLOY_PS1 = '''#Requires -Modules Az.Accounts
+<#
+.SYNOPSIS
+  function Fake-Thing { }
+#>
+[CmdletBinding()]
+param(
+    [Parameter(Mandatory)]
+    [string]$Environment,
+    [int]$Retries = 3
+)
+
+Import-Module Az.Resources -Force
+
+function Get-Config {
+    [CmdletBinding(SupportsShouldProcess)]
+    [OutputType([hashtable])]
+    param(
+        [Parameter(Mandatory = $true, Position = 0)]
+        [ValidateNotNullOrEmpty()]
+        [string]$Path,
+
+        [switch]$Force
+    )
+    $cfg = @{ a = 1 }   # { brace in a comment
+    return $cfg
+}
+
+function helper($a, $b = 2) {
+    "$a and $b"
+}
+
+class Deployer : BaseDeployer {
+    [void] Run() {
+        Write-Host "run"
+    }
+}
+'''
+
+
+class TestPowerShellAnalyzer(unittest.TestCase):
+    """Test PowerShell analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.ps1', delete=False) as f:
+            f.write(DEPLOY_PS1)
+            self.path = f.name
+        self.structure = PowerShellAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_imports(self):
+        """#Requires and Import-Module are imports; block comments are skipped."""
+        self.assertEqual([i['content'] for i in self.structure['imports']],
+                         ['#Requires -Modules Az.Accounts', 'Import-Module Az.Resources -Force'])
+
+    def test_script_parameters(self):
+        """The script's param() block is listed with types and defaults."""
+        environment, retries = self.structure['parameters']
+        self.assertEqual((environment['name'], environment['line']), ('$Environment', 9))
+        self.assertTrue(environment['mandatory'])
+        self.assertEqual(retries['signature'], ': int = 3')
+
+    def test_advanced_function(self):
+        """param() block, CmdletBinding and OutputType."""
+        config = self.structure['functions'][0]
+        self.assertEqual(config['name'], 'Get-Config')
+        self.assertEqual(config['parameters'], ['Path', 'Force'])
+        self.assertEqual(config['mandatory'], ['Path'])
+        self.assertTrue(config['cmdlet_binding'])
+        self.assertEqual(config['output_type'], 'hashtable')
+        self.assertEqual(config['line_end'], 27)
+
+    def test_inline_parameters_and_classes(self):
+        """function f($a) parameters; class methods aren't functions."""
+        helper = self.structure['functions'][1]
+        self.assertEqual(helper['signature'], '($a, $b = 2)')
+        self.assertEqual(len(self.structure['functions']), 2)
+        self.assertEqual(self.structure['classes'][0]['bases'], ['BaseDeployer'])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,49 @@
+"""Tests for the stderr progress line of long directory walks."""
+
+import io
+import unittest
+from unittest import mock
+from reveal.progress import Progress
+
+
+class Terminal(io.StringIO):
+    def isatty(self):
+        return True
+
+
+class TestProgress(unittest.TestCase):
+    """When the line is drawn, what it says, and erasing it."""
+
+    def progress(self, stream, times, **options):
+        clock = mock.patch('reveal.progress.time.monotonic', side_effect=times)
+        clock.start()
+        self.addCleanup(clock.stop)
+        return Progress(stream=stream, **options)
+
+    def test_draws_after_delay(self):
+        stream = Terminal()
+        progress = self.progress(stream, [0.0, 0.2, 1.0, 1.05, 2.0])
+        progress.update(1, 10)
+        self.assertEqual(stream.getvalue(), '')
+        progress.update(2, 10)
+        self.assertEqual(stream.getvalue(), '\r\033[KAnalyzing 2/10 files (20%), about 4s left')
+        # Throttled between redraws
+        progress.update(3, 10)
+        self.assertEqual(stream.getvalue().count('\r'), 1)
+        progress.update(10, 10)
+        self.assertTrue(stream.getvalue().endswith('\r\033[KAnalyzing 10/10 files (100%)'))
+        progress.clear()
+        self.assertTrue(stream.getvalue().endswith('(100%)\r\033[K'))
+        progress.clear()
+        self.assertEqual(stream.getvalue().count('\r'), 3)
+
+    def test_quiet_or_not_a_terminal(self):
+        for stream, options in ((Terminal(), {'enabled': False}), (io.StringIO(), {})):
+            progress = self.progress(stream, [0.0, 5.0], **options)
+            progress.update(1, 2)
+            progress.clear()
+            self.assertEqual(stream.getvalue(), '')
+
+
+if __name__ == '__main__':
+    unittest.main()
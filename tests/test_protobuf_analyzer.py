@@ -0,0 +1,85 @@
+"""Tests for Protocol Buffers analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.protobuf import ProtobufAnalyzer
+
+
+PROTO = '''syntax = "proto3";
+package acme.users.v1;
+
+import "google/protobuf/timestamp.proto";
+
+// A user account
+message User {
+  string id = 1;
+  repeated string roles = 2;
+  map<string, string> labels = 3;
+  oneof contact {
+    string phone = 4;
+  }
+  message Address {
+    string city = 1;
+  }
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+    ACTIVE = 1;
+  }
+}
+
+service UserService {
+  rpc GetUser(GetUserRequest) returns (User);
+  rpc WatchUsers(stream WatchRequest) returns (stream User) {
+    option (google.api.http) = { get: "/v1/users" };
+  }
+}
+'''
+
+
+class TestProtobufAnalyzer(unittest.TestCase):
+    """Test .proto analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.proto', delete=False) as f:
+            f.write(PROTO)
+            self.path = f.name
+        self.structure = ProtobufAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_package_and_imports(self):
+        """Should extract syntax, package and imports without trailing ';'."""
+        self.assertEqual([p['content'] for p in self.structure['package']],
+                         ['syntax = "proto3"', 'package acme.users.v1'])
+        self.assertEqual(len(self.structure['imports']), 1)
+
+    def test_messages_with_field_numbers(self):
+        """Fields include oneof members but not nested message fields."""
+        user, address = self.structure['messages']
+        self.assertEqual(user['name'], 'User')
+        self.assertEqual([(f['name'], f['number']) for f in user['fields']],
+                         [('id', 1), ('roles', 2), ('labels', 3), ('phone', 4)])
+        self.assertEqual(user['fields'][1]['label'], 'repeated')
+        self.assertEqual(user['fields'][2]['type'], 'map<string, string>')
+        self.assertEqual(address['name'], 'User.Address')
+
+    def test_enums(self):
+        """Nested enums are qualified and list their values."""
+        status = self.structure['enums'][0]
+        self.assertEqual(status['name'], 'User.Status')
+        self.assertEqual(status['signature'], ' { STATUS_UNSPECIFIED=0, ACTIVE=1 }')
+
+    def test_services_and_rpcs(self):
+        """RPC methods carry request/response types and streaming."""
+        self.assertEqual([s['name'] for s in self.structure['services']], ['UserService'])
+        methods = {m['name']: m['signature'] for m in self.structure['methods']}
+        self.assertEqual(methods, {
+            'UserService.GetUser': '(GetUserRequest) -> User',
+            'UserService.WatchUsers': '(stream WatchRequest) -> stream User',
+        })
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,66 @@
+"""Tests for embedded SQL extraction (reveal queries)."""
+
+import contextlib
+import io
+import json
+import os
+import tempfile
+import unittest
+from reveal.commands import QueriesCommand, run_command
+from reveal.queries import find_queries, query_tables
+
+
+class TestFindQueries(unittest.TestCase):
+    """Statement shapes, multi-line literals and prose."""
+
+    def test_statements(self):
+        content = '\n'.join([
+            'def get_user(uid):',
+            '    return db.execute("SELECT id, name FROM users WHERE id = ?", (uid,))',
+            "cur.execute('INSERT INTO audit_log (event) VALUES (%s)', (e,))",
+            'cur.execute(f"UPDATE accounts SET balance = {b} WHERE id = 1")',
+            'q := `DELETE FROM sessions WHERE expires < NOW()`',
+            'm = "CREATE TABLE IF NOT EXISTS widgets (id INTEGER)"',
+            'n = "create unique index idx_a on widgets (a)"'])
+        self.assertEqual([(q['line'], q['operation'], q['tables']) for q in find_queries(content)],
+                         [(2, 'SELECT', ['users']), (3, 'INSERT', ['audit_log']), (4, 'UPDATE', ['accounts']),
+                          (5, 'DELETE', ['sessions']), (6, 'CREATE TABLE', ['widgets']),
+                          (7, 'CREATE INDEX', ['widgets'])])
+
+    def test_multiline(self):
+        content = 'sql = """\n    SELECT u.name\n    FROM users u\n    JOIN orders o ON o.user_id = u.id\n"""\n'
+        query, = find_queries(content)
+        self.assertEqual((query['line'], query['line_end'], query['column']), (1, 5, 7))
+        self.assertEqual(query['sql'], 'SELECT u.name FROM users u JOIN orders o ON o.user_id = u.id')
+        self.assertEqual(query['tables'], ['users', 'orders'])
+
+    def test_prose(self):
+        content = '\n'.join(['print("Select an item from the list")', 'x = "select the file from disk"',
+                             'y = "Please update your settings"', "# don't select from here"])
+        self.assertEqual(find_queries(content), [])
+
+    def test_query_tables(self):
+        sql = 'WITH recent AS (SELECT * FROM orders) SELECT * FROM recent r JOIN public.users u ON u.id = r.uid'
+        self.assertEqual(query_tables(sql), ['orders', 'public.users'])
+
+
+class TestQueriesCommand(unittest.TestCase):
+    """reveal queries over a directory."""
+
+    def test_command(self):
+        with tempfile.TemporaryDirectory() as directory:
+            with open(os.path.join(directory, 'repo.js'), 'w') as f:
+                f.write('db.query("SELECT * FROM public.users");\ndb.query("DELETE FROM carts WHERE id = $1");\n')
+            output = io.StringIO()
+            with contextlib.redirect_stdout(output):
+                code = run_command(QueriesCommand, [directory, '--table', 'users', '--format', 'json'])
+            self.assertEqual(code, 0)
+            result = json.loads(output.getvalue())
+            self.assertEqual([(q['line'], q['operation']) for q in result['queries']], [(1, 'SELECT')])
+
+            with contextlib.redirect_stderr(io.StringIO()):
+                self.assertEqual(run_command(QueriesCommand, [os.path.join(directory, 'missing')]), 2)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,86 @@
+"""Tests for R analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.r import RAnalyzer
+
+
+MODEL_R = '''library(dplyr)
+require(stats)
+source("utils.R")
+
+fit_model <- function(data, weights = c(1, 2), ...) {
+  helper <- function(z) z + 1
+  lm(y ~ x, data = data)
+}
+
+setClass("Person",
+  representation(name = "character", age = "numeric"),
+  contains = "Base"
+)
+
+setGeneric("greet", function(obj, ...) standardGeneric("greet"))
+
+setMethod("greet", "Person", function(obj, ...) {
+  cat("Hello", obj@name)
+})
+
+Account <- R6::R6Class("Account",
+  inherit = Base,
+  public = list(
+    deposit = function(x) {
+      private$log(x)
+    }
+  ),
+  private = list(
+    log = function(x) message(x)
+  )
+)
+'''
+
+
+class TestRAnalyzer(unittest.TestCase):
+    """Test R analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.R', delete=False) as f:
+            f.write(MODEL_R)
+            self.path = f.name
+        self.structure = RAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_imports(self):
+        """library, require and source calls."""
+        self.assertEqual([i['content'] for i in self.structure['imports']],
+                         ['dplyr', 'require(stats)', 'source(utils.R)'])
+
+    def test_functions(self):
+        """Top-level function assignments only, with balanced parameters."""
+        functions = self.structure['functions']
+        self.assertEqual([f['name'] for f in functions], ['fit_model'])
+        self.assertEqual(functions[0]['signature'], '(data, weights = c(1, 2), ...)')
+        self.assertEqual(functions[0]['line_end'], 8)
+
+    def test_s4(self):
+        """S4 classes with slots and parent; generics and methods."""
+        person = self.structure['classes'][0]
+        self.assertEqual((person['name'], person['kind'], person['parent']), ('Person', 'S4', 'Base'))
+        self.assertEqual(person['slots'], ['name', 'age'])
+        self.assertEqual(person['line_end'], 13)
+        self.assertEqual(self.structure['generics'][0]['name'], 'greet')
+        method = self.structure['methods'][0]
+        self.assertEqual((method['name'], method['class']), ('greet', 'Person'))
+
+    def test_r6_members(self):
+        """R6 member functions are methods with their list's visibility."""
+        account = self.structure['classes'][1]
+        self.assertEqual((account['kind'], account['parent']), ('R6', 'Base'))
+        members = {m['name']: m['visibility'] for m in self.structure['methods'] if 'visibility' in m}
+        self.assertEqual(members, {'Account$deposit': 'public', 'Account$log': 'private'})
+
+
+if __name__ == '__main__':
+    unittest.main()
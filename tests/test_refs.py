@@ -0,0 +1,82 @@
+"""Tests for symbol references (reveal refs)."""
+
+import unittest
+from reveal.refs import find_references, reference_pattern
+
+
+GO = [
+    'package server',
+    '',
+    'import "example.com/app/start"',
+    '',
+    '// Start begins serving.',
+    'func (s *Server) Start() error {',
+    '\treturn nil',
+    '}',
+    '',
+    'func Run(s *Server) {',
+    '\tlog.Print("Start called")',
+    '\ts.Start() // Start again',
+    '\ts.Started = true',
+    '\t/* s.Start()',
+    '\t   s.Start() */',
+    '}',
+]
+
+GO_STRUCTURE = {
+    'imports': [{'line': 3, 'content': 'import "example.com/app/start"'}],
+    'methods': [{'name': 'Server.Start', 'line': 6, 'line_end': 8}],
+    'functions': [{'name': 'Run', 'line': 10, 'line_end': 16}],
+}
+
+
+class TestReferencePattern(unittest.TestCase):
+    """Whole words, by the last segment of qualified names."""
+
+    def test_qualified_names(self):
+        for name in ('Start', 'Server.Start', 'app::Start', 'Server#Start'):
+            pattern = reference_pattern(name)
+            self.assertTrue(pattern.search('s.Start()'), name)
+            self.assertFalse(pattern.search('s.Started'), name)
+            self.assertFalse(pattern.search('$Start'), name)
+
+
+class TestFindReferences(unittest.TestCase):
+    """Uses in code, not in strings or comments."""
+
+    def test_go_file(self):
+        refs = find_references('server.go', GO, GO_STRUCTURE, reference_pattern('Server.Start'))
+        self.assertEqual([(r['line'], r['kind'], r.get('symbol')) for r in refs],
+                         [(6, 'definition', None), (12, 'reference', 'Run')])
+        self.assertEqual(refs[1]['column'], 4)
+        self.assertEqual(refs[1]['text'], 's.Start() // Start again')
+
+    def test_imports(self):
+        refs = find_references('main.py', ['from app import start', 'start()'],
+                               {'imports': [{'line': 1, 'content': 'from app import start'}]},
+                               reference_pattern('start'))
+        self.assertEqual([(r['line'], r['kind']) for r in refs], [(1, 'import'), (2, 'reference')])
+
+    def test_multiline_strings(self):
+        lines = ['def load():', '    """Read settings.', '', '    Calls parse_config internally.', '    """',
+                 "    text = '''parse_config", "    ''' + parse_config(path)",
+                 '    return parse_config(text)  # parse_config']
+        refs = find_references('app.py', lines, {'functions': [{'name': 'load', 'line': 1, 'line_end': 8}]},
+                               reference_pattern('parse_config'))
+        self.assertEqual([(r['line'], r['column']) for r in refs], [(7, 11), (8, 12)])
+
+    def test_block_comments_within_lines(self):
+        # Code after a comment closed on the same line counts; a comment opened mid-line hides the rest
+        lines = ['/* setup */ init(config);', 'int x = 1; /* config', '   still config */ use(config);']
+        refs = find_references('main.c', lines, {}, reference_pattern('config'))
+        self.assertEqual([(r['line'], r['column']) for r in refs], [(1, 18), (3, 24)])
+
+    def test_rust_lifetimes_and_chars(self):
+        lines = ["fn parse<'a>(input: &'a str) -> &'a str {", "    let quote = '\"'; check(input, 'x')", '}']
+        refs = find_references('lib.rs', lines, {}, reference_pattern('input'))
+        self.assertEqual([(r['line'], r['column']) for r in refs], [(1, 14), (2, 28)])
+        self.assertEqual(find_references('lib.rs', lines, {}, reference_pattern('x')), [])
+
+
+if __name__ == '__main__':
+    unittest.main()
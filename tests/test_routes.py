@@ -0,0 +1,62 @@
+"""Tests for HTTP route extraction (reveal routes)."""
+
+import unittest
+from reveal.routes import find_routes, route_sort_key
+
+
+def summary(routes):
+    return [(r['method'], r['path'], r['handler'], r['framework']) for r in routes]
+
+
+class TestFindRoutes(unittest.TestCase):
+    """Route registrations per framework."""
+
+    def test_flask(self):
+        lines = ['from flask import Flask', "@app.route('/users', methods=['GET', 'POST'])", 'def users():',
+                 '    pass', "@bp.get('/users/<int:id>')", '@login_required', 'def get_user(id):', '    pass']
+        self.assertEqual(summary(find_routes('app.py', lines)), [
+            ('GET', '/users', 'users', 'flask'), ('POST', '/users', 'users', 'flask'),
+            ('GET', '/users/<int:id>', 'get_user', 'flask')])
+
+    def test_fastapi(self):
+        lines = ['from fastapi import APIRouter', '@router.delete("/items/{item_id}")',
+                 'async def delete_item(item_id: int):', '    ...']
+        routes = find_routes('api.py', lines)
+        self.assertEqual(summary(routes), [('DELETE', '/items/{item_id}', 'delete_item', 'fastapi')])
+        self.assertEqual(routes[0]['line'], 2)
+
+    def test_django(self):
+        lines = ['from django.urls import path, include', 'urlpatterns = [',
+                 "    path('articles/<int:year>/', views.year_archive, name='year'),",
+                 "    path('about/', views.AboutView.as_view()),", "    path('api/', include('api.urls')),", ']']
+        self.assertEqual(summary(find_routes('urls.py', lines)), [
+            ('ANY', 'articles/<int:year>/', 'views.year_archive', 'django'),
+            ('ANY', 'about/', 'views.AboutView', 'django'), ('ANY', 'api/', "include('api.urls')", 'django')])
+        # path() calls mean nothing without Django
+        self.assertEqual(find_routes('geometry.py', ["    path('a', b)"]), [])
+
+    def test_express(self):
+        lines = ["app.get('/health', (req, res) => res.send('ok'));", "router.post('/login', rateLimit, auth.login);",
+                 "app.all('/proxy/*', proxy);", "axios.get('/api/users');", "const v = cache.get('key');"]
+        self.assertEqual(summary(find_routes('server.ts', lines)), [
+            ('GET', '/health', '(inline)', 'express'), ('POST', '/login', 'auth.login', 'express'),
+            ('ANY', '/proxy/*', 'proxy', 'express')])
+
+    def test_go(self):
+        lines = ['\tr.GET("/ping", handlers.Ping)', '\tr.POST("/orders", authMiddleware(), createOrder)',
+                 '\thttp.HandleFunc("/metrics", metricsHandler)', '\tmux.HandleFunc("GET /items/{id}", getItem)',
+                 '\tm.HandleFunc("/legacy", legacy).Methods("GET", "PUT")', '\tr.Handle("PATCH", "/x", patchX)',
+                 '\tc.Get("/chi", func(w http.ResponseWriter, r *http.Request) {})']
+        self.assertEqual(summary(find_routes('main.go', lines)), [
+            ('GET', '/ping', 'handlers.Ping', 'gin'), ('POST', '/orders', 'createOrder', 'gin'),
+            ('ANY', '/metrics', 'metricsHandler', 'net/http'), ('GET', '/items/{id}', 'getItem', 'net/http'),
+            ('GET', '/legacy', 'legacy', 'net/http'), ('PUT', '/legacy', 'legacy', 'net/http'),
+            ('PATCH', '/x', 'patchX', 'gin'), ('GET', '/chi', '(inline)', 'chi')])
+
+    def test_sort_key(self):
+        routes = [{'method': 'POST', 'path': '/a'}, {'method': 'ANY', 'path': '/a'}, {'method': 'GET', 'path': '/a'}]
+        self.assertEqual([r['method'] for r in sorted(routes, key=route_sort_key)], ['GET', 'POST', 'ANY'])
+
+
+if __name__ == '__main__':
+    unittest.main()
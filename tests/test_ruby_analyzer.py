@@ -0,0 +1,74 @@
+"""Tests for Ruby analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.ruby import RubyAnalyzer
+
+
+SOURCE = '''require 'json'
+require_relative 'concerns/auditable'
+
+module Billing
+  class Invoice < ApplicationRecord
+    attr_accessor :total, :currency
+    attr_reader :id
+    belongs_to :customer
+    has_many :line_items
+
+    def self.for_customer(customer)
+      where(customer: customer)
+    end
+
+    class << self
+      def overdue
+      end
+    end
+
+    def paid?
+      status == 'paid'
+    end
+  end
+end
+
+def helper(x)
+end
+'''
+
+
+class TestRubyAnalyzer(unittest.TestCase):
+    """Test Ruby analyzer on a Rails-style model."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.rb', delete=False) as f:
+            f.write(SOURCE)
+            self.path = f.name
+        self.structure = RubyAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_imports_modules_and_classes(self):
+        """Should extract requires, modules and classes."""
+        self.assertEqual(len(self.structure['imports']), 2)
+        self.assertEqual([m['name'] for m in self.structure['modules']], ['Billing'])
+        self.assertEqual([c['name'] for c in self.structure['classes']], ['Invoice'])
+
+    def test_instance_vs_class_methods(self):
+        """def self.x and class << self are class methods; top-level defs are functions."""
+        self.assertEqual([m['name'] for m in self.structure['methods']], ['Invoice#paid?'])
+        self.assertEqual([m['name'] for m in self.structure['class_methods']],
+                         ['Invoice.for_customer', 'Invoice.overdue'])
+        self.assertEqual([f['name'] for f in self.structure['functions']], ['helper'])
+
+    def test_attributes_and_associations(self):
+        """attr_* symbols and associations are listed by name."""
+        attrs = [(a['name'], a['kind']) for a in self.structure['attributes']]
+        self.assertEqual(attrs, [('total', 'attr_accessor'), ('currency', 'attr_accessor'),
+                                 ('id', 'attr_reader')])
+        self.assertEqual([a['name'] for a in self.structure['associations']],
+                         ['customer', 'line_items'])
+
+
+if __name__ == '__main__':
+    unittest.main()
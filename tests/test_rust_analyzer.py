@@ -0,0 +1,89 @@
+"""Tests for Rust analyzer."""
+
+import unittest
+import tempfile
+import os
+from reveal.analyzers.rust import RustAnalyzer
+
+
+SAMPLE = '''use std::fmt;
+use std::collections::HashMap;
+
+pub enum Shape {
+    Circle(f64),
+    Square(f64),
+}
+
+pub trait Area {
+    fn area(&self) -> f64;
+}
+
+pub struct Registry {
+    shapes: HashMap<String, Shape>,
+}
+
+impl Registry {
+    pub fn new() -> Self {
+        Registry { shapes: HashMap::new() }
+    }
+}
+
+impl fmt::Display for Shape {
+    fn fmt(&self, f: &mut fmt::Formatter) -> fmt::Result {
+        write!(f, "shape")
+    }
+}
+
+fn main() {
+    let _r = Registry::new();
+}
+'''
+
+
+class TestRustAnalyzer(unittest.TestCase):
+    """Test Rust file analyzer."""
+
+    def setUp(self):
+        fd, self.path = tempfile.mkstemp(suffix='.rs')
+        with os.fdopen(fd, 'w', encoding='utf-8') as f:
+            f.write(SAMPLE)
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_use_declarations(self):
+        """Should extract use statements as imports."""
+        structure = RustAnalyzer(self.path).get_structure()
+        self.assertEqual(len(structure['imports']), 2)
+        self.assertIn('std::fmt', structure['imports'][0]['content'])
+
+    def test_structs_enums_traits(self):
+        """Should extract structs, enums and traits by name."""
+        structure = RustAnalyzer(self.path).get_structure()
+        self.assertEqual([s['name'] for s in structure['structs']], ['Registry'])
+        self.assertEqual([e['name'] for e in structure['enums']], ['Shape'])
+        self.assertEqual([t['name'] for t in structure['traits']], ['Area'])
+
+    def test_impl_blocks(self):
+        """Should name trait impls as 'Trait for Type'."""
+        structure = RustAnalyzer(self.path).get_structure()
+        names = [i['name'] for i in structure['impls']]
+        self.assertEqual(names, ['Registry', 'fmt::Display for Shape'])
+
+    def test_functions_with_return_types(self):
+        """Should extract functions and methods with signatures."""
+        structure = RustAnalyzer(self.path).get_structure()
+        funcs = {f['name']: f for f in structure['functions']}
+        self.assertIn('main', funcs)
+        self.assertIn('new', funcs)
+        self.assertIn('-> Self', funcs['new']['signature'])
+
+    def test_extract_enum_by_name(self):
+        """Should extract an enum definition rather than grepping."""
+        result = RustAnalyzer(self.path).extract_element('function', 'Shape')
+        self.assertIsNotNone(result)
+        self.assertTrue(result['source'].startswith('pub enum Shape'))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,90 @@
+"""Tests for Scala analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.base import get_analyzer
+from reveal.analyzers.scala import ScalaAnalyzer
+
+
+SOURCE = '''package com.example.geo
+
+import scala.math.Pi
+import scala.collection.mutable.{Map, Set}
+
+trait Shape {
+  def area: Double
+}
+
+case class Circle(radius: Double) extends Shape {
+  def area: Double = Pi * radius * radius
+}
+
+class Canvas(width: Int, height: Int) {
+  val shapes: Set[Shape] = Set.empty
+  var title = "untitled"
+
+  def add(shape: Shape)(implicit log: String => Unit): Unit = {
+    val count = shapes.size
+    shapes += shape
+  }
+}
+
+object Canvas {
+  val Default = new Canvas(800, 600)
+}
+
+case object Empty extends Shape {
+  def area: Double = 0
+}
+'''
+
+
+class TestScalaAnalyzer(unittest.TestCase):
+    """Test Scala analyzer."""
+
+    def setUp(self):
+        self.temp_dir = tempfile.TemporaryDirectory()
+        self.path = os.path.join(self.temp_dir.name, 'Canvas.scala')
+        with open(self.path, 'w') as f:
+            f.write(SOURCE)
+        self.structure = ScalaAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        self.temp_dir.cleanup()
+
+    def test_registration(self):
+        self.assertIs(get_analyzer(self.path), ScalaAnalyzer)
+
+    def test_package_and_imports(self):
+        self.assertEqual([item['content'] for item in self.structure['package']], ['package com.example.geo'])
+        self.assertEqual([(item['line'], item['content']) for item in self.structure['imports']],
+                         [(3, 'import scala.math.Pi'), (4, 'import scala.collection.mutable.{Map, Set}')])
+
+    def test_classes_and_case_classes(self):
+        # Case classes are listed apart from plain classes
+        canvas = self.structure['classes']
+        self.assertEqual([(item['name'], item['line'], item['line_end']) for item in canvas], [('Canvas', 14, 22)])
+        self.assertEqual(canvas[0]['signature'], '(width: Int, height: Int)')
+        circle = self.structure['case_classes']
+        self.assertEqual([(item['name'], item['signature']) for item in circle], [('Circle', '(radius: Double)')])
+
+    def test_objects_and_traits(self):
+        self.assertEqual([(item['name'], item.get('kind')) for item in self.structure['objects']],
+                         [('Canvas', None), ('Empty', 'case object')])
+        self.assertEqual([(item['name'], item['line'], item['line_end']) for item in self.structure['traits']],
+                         [('Shape', 6, 8)])
+
+    def test_defs(self):
+        functions = {function['name']: function for function in self.structure['functions']}
+        self.assertEqual([function['line'] for function in self.structure['functions']], [7, 11, 18, 29])
+        self.assertEqual(functions['add']['signature'], '(shape: Shape)(implicit log: String => Unit) -> Unit')
+
+    def test_vals(self):
+        # Members and object fields, not locals inside def bodies
+        self.assertEqual([(item['name'], item['kind'], item.get('signature')) for item in self.structure['vals']],
+                         [('shapes', 'val', ': Set[Shape]'), ('title', 'var', None), ('Default', 'val', None)])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,73 @@
+"""Tests for reveal search."""
+
+import unittest
+from reveal.commands import get_command_class, SearchCommand
+from reveal.commands.search import kind_filter, name_matcher, search_structure
+
+
+STRUCTURE = {
+    'imports': [{'line': 1, 'content': 'import parser', 'name': 'parser'},
+                {'line': 2, 'content': 'from requests.adapters import HTTPAdapter'}],
+    'classes': [{'line': 3, 'line_end': 20, 'name': 'Parser'}],
+    'methods': [{'line': 5, 'line_end': 9, 'name': 'Parser#parse_file', 'signature': '(path)'}],
+    'functions': [{'line': 22, 'line_end': 24, 'name': 'parse_args', 'kind': 'proc'}],
+}
+
+
+class TestNameMatcher(unittest.TestCase):
+    """Test pattern forms."""
+
+    def test_substring_is_case_insensitive(self):
+        matches = name_matcher('parse')
+        self.assertTrue(matches('Parser'))
+        self.assertFalse(name_matcher('parse', case_sensitive=True)('Parser'))
+
+    def test_glob_matches_whole_name_or_last_part(self):
+        matches = name_matcher('parse_*')
+        self.assertTrue(matches('parse_args'))
+        self.assertTrue(matches('Parser#parse_file'))
+        self.assertFalse(matches('reparse_all'))
+
+    def test_regex(self):
+        matches = name_matcher(r'^parse_(args|file)$', regex=True)
+        self.assertTrue(matches('parse_args'))
+        self.assertFalse(matches('parse_all'))
+
+    def test_regex_detected_without_flag(self):
+        """'handle.*' reads as a regex, not a glob needing a literal dot."""
+        self.assertTrue(name_matcher('handle.*')('handle_request'))
+        self.assertTrue(name_matcher('^get_')('get_user'))
+        self.assertFalse(name_matcher('^get_')('forget_me'))
+
+
+class TestSearchStructure(unittest.TestCase):
+    """Test definition hits."""
+
+    def test_only_definitions_in_line_order(self):
+        """Imports never match; hits carry kind, category and signature."""
+        hits = search_structure(STRUCTURE, name_matcher('parse'))
+        self.assertEqual([hit['name'] for hit in hits], ['Parser', 'Parser#parse_file', 'parse_args'])
+        self.assertEqual(hits[1], {'line': 5, 'line_end': 9, 'name': 'Parser#parse_file', 'kind': 'method',
+                                   'category': 'methods', 'signature': '(path)'})
+        self.assertEqual(hits[2]['kind'], 'proc')
+
+    def test_kind_filter(self):
+        """--kind accepts plurals and lists; other names for a kind (proc) count."""
+        hits = search_structure(STRUCTURE, name_matcher('parse'), kinds=kind_filter('functions'))
+        self.assertEqual([hit['name'] for hit in hits], ['parse_args'])
+        hits = search_structure(STRUCTURE, name_matcher('parse'), kinds=kind_filter('class, method'))
+        self.assertEqual([hit['name'] for hit in hits], ['Parser', 'Parser#parse_file'])
+
+    def test_kind_import_matches_module_names(self):
+        """Imports match on the modules they name, and report the statement."""
+        hits = search_structure(STRUCTURE, name_matcher('requests'), kinds=kind_filter('import'))
+        self.assertEqual([(hit['line'], hit['name']) for hit in hits],
+                         [(2, 'from requests.adapters import HTTPAdapter')])
+        self.assertEqual(search_structure(STRUCTURE, name_matcher('HTTPAdapter'), kinds=kind_filter('import')), [])
+
+    def test_registered(self):
+        self.assertIs(get_command_class('search'), SearchCommand)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,128 @@
+"""Tests for binary, minified, generated and oversized file detection."""
+
+import argparse
+import contextlib
+import io
+import json
+import tempfile
+import unittest
+from pathlib import Path
+from reveal.commands import SearchCommand, SecretsCommand, run_command
+from reveal.main import show_large_file
+from reveal.sniff import large_file_summary, oversized, parse_size, skip_reason
+from reveal.tree_view import show_directory_tree
+
+
+class TestSkipReason(unittest.TestCase):
+    """What gets skipped in directory walks, and how it shows up."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.root = Path(self.tmpdir.name)
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def write(self, name, text):
+        path = self.root / name
+        path.write_bytes(text.encode() if isinstance(text, str) else text)
+        return path
+
+    def test_binary(self):
+        self.assertEqual(skip_reason(self.write('blob.py', b'x = 1\n\0\0\1')), 'binary')
+
+    def test_minified(self):
+        self.assertEqual(skip_reason(self.write('app.min.js', 'function a() {\n}\n')), 'minified')
+        self.assertEqual(skip_reason(self.write('bundle.js', 'var a=1;' * 2000)), 'minified')
+        self.assertIsNone(skip_reason(self.write('app.js', 'function a() {\n  return 1;\n}\n' * 50)))
+        # Long lines only count for JavaScript and CSS
+        self.assertIsNone(skip_reason(self.write('data.py', 'DATA = [' + '1, ' * 3000 + ']\n')))
+
+    def test_generated(self):
+        self.assertEqual(skip_reason(self.write('api.go', '// Code generated by protoc-gen-go. DO NOT EDIT.\n\n'
+                                                          'package api\n')), 'generated')
+        self.assertEqual(skip_reason(self.write('schema.py', '# -*- coding: utf-8 -*-\n# @generated\nx = 1\n')),
+                         'generated')
+        self.assertEqual(skip_reason(self.write('user_pb2.py', 'x = 1\n')), 'generated')
+        # Markers outside a header comment don't count, nor do loose mentions of generated code
+        self.assertIsNone(skip_reason(self.write('keys.py', '# Keys generated by the server\nx = 1\n')))
+        self.assertIsNone(skip_reason(self.write('0001_initial.py', '# Generated by Django 4.2 on 2024-01-01\n')))
+        self.assertIsNone(skip_reason(self.write('notes.go', '// Code generated by hand, do not edit lightly\n')))
+        self.assertIsNone(skip_reason(self.write('edit.py', 'WARNING = "do not edit"\n')))
+        self.assertIsNone(skip_reason(self.write('late.py', 'x = 1\n' * 20 + '# @generated\n')))
+
+    def test_walks(self):
+        self.write('real.nim', 'proc real*() = discard\n')
+        self.write('gen.nim', '# @generated by nimgen\nproc gen*() = discard\n')
+        tree = show_directory_tree(str(self.root))
+        self.assertIn('gen.nim (45.0 B, generated)', tree)
+        self.assertIn('real.nim (1 lines', tree)
+
+        def found(*options):
+            output = io.StringIO()
+            with contextlib.redirect_stdout(output):
+                run_command(SearchCommand, ['', *options, '--format', 'json'])
+            return sorted(hit['name'] for hit in json.loads(output.getvalue())['matches'])
+
+        self.assertEqual(found(str(self.root)), ['real'])
+        self.assertEqual(found(str(self.root), '--include-generated'), ['gen', 'real'])
+        # A file named on the command line is always analyzed
+        self.assertEqual(found(str(self.root / 'gen.nim')), ['gen'])
+
+
+class TestLargeFiles(unittest.TestCase):
+    """--max-file-size: parsing sizes, and summarizing files over the limit."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.root = Path(self.tmpdir.name)
+        self.big = self.root / 'big.nim'
+        self.big.write_text('# header\n' + ''.join(f'proc p{i}*() = discard\n' for i in range(3000)))
+        (self.root / 'small.nim').write_text('proc p1*() = discard\n')
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def test_parse_size(self):
+        self.assertEqual(parse_size('2000'), 2000)
+        self.assertEqual(parse_size('512K'), 512 * 1024)
+        self.assertEqual(parse_size('5MB'), 5 * 1024 ** 2)
+        self.assertEqual(parse_size('1.5g'), int(1.5 * 1024 ** 3))
+        self.assertEqual(parse_size('0'), 0)
+        with self.assertRaises(argparse.ArgumentTypeError):
+            parse_size('5XB')
+
+    def test_oversized(self):
+        self.assertTrue(oversized(self.big, 10 * 1024))
+        self.assertFalse(oversized(self.big, 0))
+        self.assertFalse(oversized(self.root / 'missing.nim', 1))
+
+    def test_summary(self):
+        summary = large_file_summary(self.big, 'Nim')
+        self.assertEqual(summary['size'], self.big.stat().st_size)
+        self.assertEqual(summary['first_lines'], ['# header', 'proc p0*() = discard', 'proc p1*() = discard',
+                                                  'proc p2*() = discard', 'proc p3*() = discard'])
+        self.assertEqual(summary['last_lines'][-1], 'proc p2999*() = discard')
+        self.assertEqual(len(summary['last_lines']), 5)
+
+        output = io.StringIO()
+        with contextlib.redirect_stdout(output):
+            show_large_file(self.big, 'Nim', 10 * 1024, 'text')
+        self.assertIn('big.nim (69.2 KB, Nim) - not parsed: over --max-file-size 10.0 KB', output.getvalue())
+        self.assertIn('proc p2999*() = discard', output.getvalue())
+
+    def test_walks(self):
+        output = io.StringIO()
+        with contextlib.redirect_stdout(output):
+            run_command(SearchCommand, ['p1', str(self.root), '--max-file-size', '10K', '--format', 'json'])
+        self.assertEqual([Path(hit['file']).name for hit in json.loads(output.getvalue())['matches']], ['small.nim'])
+
+        for arguments, scanned in ((['--max-file-size', '10K'], 1), ([], 2)):
+            output = io.StringIO()
+            with contextlib.redirect_stdout(output):
+                run_command(SecretsCommand, [str(self.root), '--format', 'json', *arguments])
+            self.assertEqual(json.loads(output.getvalue())['files_scanned'], scanned)
+
+
+if __name__ == '__main__':
+    unittest.main()
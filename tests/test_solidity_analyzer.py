@@ -0,0 +1,100 @@
+"""Tests for Solidity analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.solidity import SolidityAnalyzer
+
+
+TOKEN_SOL = '''// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.20;
+
+import "@openzeppelin/contracts/access/Ownable.sol";
+
+interface IVault {
+    function deposit(uint256 amount) external;
+}
+
+contract Token is IVault, Ownable(msg.sender) {
+    uint256 public constant MAX_SUPPLY = 1_000_000e18;
+    mapping(address => uint256) private balances;
+
+    event Transfer(address indexed from, address indexed to, uint256 value);
+    error InsufficientBalance(uint256 available, uint256 required);
+
+    modifier whenActive() {
+        _;
+    }
+
+    function transfer(address to, uint256 amount)
+        external
+        whenActive
+        returns (bool)
+    {
+        uint256 bal = balances[msg.sender];
+        return true;
+    }
+
+    function balanceOf(address who) public view returns (uint256) {
+        return balances[who];
+    }
+
+    receive() external payable {}
+}
+'''
+
+
+class TestSolidityAnalyzer(unittest.TestCase):
+    """Test Solidity analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.sol', delete=False) as f:
+            f.write(TOKEN_SOL)
+            self.path = f.name
+        self.analyzer = SolidityAnalyzer(self.path)
+        self.structure = self.analyzer.get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_contracts_and_inheritance(self):
+        """Contracts list their kind and base contracts."""
+        vault, token = self.structure['contracts']
+        self.assertEqual(vault['kind'], 'interface')
+        self.assertEqual(token['inherits'], ['IVault', 'Ownable'])
+        self.assertEqual(token['signature'], ' is IVault, Ownable')
+
+    def test_state_variables(self):
+        """Only contract-level declarations, with visibility and markers."""
+        variables = self.structure['state_variables']
+        self.assertEqual([v['name'] for v in variables], ['Token.MAX_SUPPLY', 'Token.balances'])
+        self.assertTrue(variables[0]['constant'])
+        self.assertEqual(variables[1]['visibility'], 'private')
+        self.assertEqual(variables[1]['type'], 'mapping(address => uint256)')
+
+    def test_functions(self):
+        """Multi-line headers: visibility, mutability, modifiers and returns."""
+        functions = {f['name']: f for f in self.structure['functions']}
+        transfer = functions['Token.transfer']
+        self.assertEqual(transfer['visibility'], 'external')
+        self.assertEqual(transfer['modifiers'], ['whenActive'])
+        self.assertEqual(transfer['signature'], '(address to, uint256 amount) -> (bool)  whenActive')
+        self.assertEqual(transfer['line_end'], 28)
+        self.assertEqual(functions['Token.balanceOf']['mutability'], 'view')
+        self.assertEqual(functions['Token.receive']['mutability'], 'payable')
+        self.assertEqual(functions['IVault.deposit']['visibility'], 'external')
+
+    def test_events_errors_modifiers(self):
+        """Events, custom errors and modifiers are listed per contract."""
+        self.assertEqual(self.structure['events'][0]['name'], 'Token.Transfer')
+        self.assertEqual(self.structure['errors'][0]['signature'], '(uint256 available, uint256 required)')
+        self.assertEqual(self.structure['modifiers'][0]['name'], 'Token.whenActive')
+
+    def test_extract_by_bare_name(self):
+        """Functions can be extracted without the contract prefix."""
+        element = self.analyzer.extract_element('function', 'balanceOf')
+        self.assertEqual((element['line_start'], element['line_end']), (30, 32))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,83 @@
+"""Tests for SQL analyzer."""
+
+import os
+import tempfile
+import unittest
+from pathlib import Path
+from reveal.analyzers.sql import SQLAnalyzer
+from reveal.tree_view import show_directory_tree
+
+
+MIGRATION = '''-- Initial schema; run once
+CREATE TABLE IF NOT EXISTS public.users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    "display name" text,
+    created_at timestamp with time zone DEFAULT now(),
+    CONSTRAINT email_chk CHECK (email <> '')
+);
+
+CREATE UNIQUE INDEX idx_users_email ON public.users (lower(email));
+CREATE MATERIALIZED VIEW user_stats AS SELECT count(*) FROM users;
+
+CREATE OR REPLACE FUNCTION touch(a integer) RETURNS trigger AS $$
+BEGIN
+  NEW.updated_at = now(); -- semicolons inside the body don't split
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE users ADD COLUMN last_login timestamp;
+INSERT INTO users (email) VALUES ('a;b');
+'''
+
+
+class TestSQLAnalyzer(unittest.TestCase):
+    """Test SQL analyzer on a Postgres-style migration."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.path = os.path.join(self.tmpdir.name, '001_init.sql')
+        Path(self.path).write_text(MIGRATION)
+        self.structure = SQLAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def test_table_columns(self):
+        """CREATE TABLE yields columns (with types) and constraints."""
+        table = self.structure['tables'][0]
+        self.assertEqual(table['name'], 'public.users')
+        self.assertEqual((table['line'], table['line_end']), (2, 8))
+        self.assertEqual([c['name'] for c in table['columns']],
+                         ['id', 'email', 'display name', 'created_at'])
+        self.assertEqual(table['columns'][3]['type'], 'timestamp with time zone')
+        self.assertEqual(len(table['constraints']), 1)
+
+    def test_other_statements(self):
+        """Indexes, views, functions and alters are extracted; DML is ignored."""
+        self.assertEqual(self.structure['indexes'][0]['signature'],
+                         ' ON public.users (lower(email))')
+        self.assertEqual(self.structure['views'][0]['kind'], 'materialized')
+
+        function = self.structure['functions'][0]
+        self.assertEqual(function['signature'], '(a integer) -> trigger')
+        self.assertEqual((function['line'], function['line_end']), (13, 18))
+
+        self.assertEqual(self.structure['alters'][0]['signature'], ' ADD COLUMN last_login timestamp')
+        self.assertEqual(len(self.structure['tables']), 1)
+
+    def test_extract_table(self):
+        """Tables can be extracted by unqualified name."""
+        result = SQLAnalyzer(self.path).extract_element('table', 'users')
+        self.assertEqual(result['line_start'], 2)
+        self.assertTrue(result['source'].endswith(');'))
+
+    def test_directory_summary(self):
+        """Directory trees show the tables each migration creates."""
+        tree = show_directory_tree(self.tmpdir.name)
+        self.assertIn('001_init.sql (21 lines: 17 code, 1 comment, SQL) - tables: public.users; views: user_stats', tree)
+
+
+if __name__ == '__main__':
+    unittest.main()
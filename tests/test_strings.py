@@ -0,0 +1,68 @@
+"""Tests for user-facing string extraction (reveal strings)."""
+
+import contextlib
+import io
+import json
+import os
+import tempfile
+import unittest
+from reveal.commands import StringsCommand, run_command
+from reveal.strings import find_strings, is_user_facing
+
+
+class TestFindStrings(unittest.TestCase):
+    """Which literals count as user-facing, and translation markers."""
+
+    def test_is_user_facing(self):
+        for value in ('File saved successfully.', 'Are you sure?', 'Saved!', 'Invalid value: {x}', 'hello world'):
+            self.assertTrue(is_user_facing(value), value)
+        for value in ('user_id', 'OK', '%s %s', 'btn btn-primary', 'https://example.com/a b', '/usr/local/bin',
+                      'SELECT id FROM users WHERE x = 1', r'^\d+ items$', '<br/>'):
+            self.assertFalse(is_user_facing(value), value)
+
+    def test_python(self):
+        lines = ['import os',
+                 'def save():',
+                 '    """Save the document to disk."""',
+                 '    print("File saved successfully.")',
+                 '    flash(_("Your changes were saved"))',
+                 '    d = {"user_id": 1}',
+                 '    raise ValueError(f"Invalid value: {x}")  # "not this one"',
+                 '    """',
+                 '    Not a message either',
+                 '    """',
+                 '    # "Commented out message"']
+        found = find_strings('app.py', lines)
+        self.assertEqual([(s['line'], s['text'], s['translated']) for s in found],
+                         [(4, 'File saved successfully.', False), (5, 'Your changes were saved', True),
+                          (7, 'Invalid value: {x}', False)])
+        self.assertEqual(found[0]['column'], 11)
+
+    def test_javascript(self):
+        lines = ['/* "Block comment text" */', 'alert(t("Welcome back, friend"));', 'el.className = "card card-body";',
+                 'msg = `Hello ${name}, welcome`;']
+        self.assertEqual([(s['text'], s['translated']) for s in find_strings('ui.js', lines)],
+                         [('Welcome back, friend', True), ('Hello ${name}, welcome', False)])
+
+
+class TestStringsCommand(unittest.TestCase):
+    """reveal strings over a directory."""
+
+    def test_command(self):
+        with tempfile.TemporaryDirectory() as directory:
+            with open(os.path.join(directory, 'ui.js'), 'w') as f:
+                f.write('alert("Are you sure?");\nalert(t("Welcome back, friend"));\n')
+            output = io.StringIO()
+            with contextlib.redirect_stdout(output):
+                code = run_command(StringsCommand, [directory, '--format', 'json'])
+            self.assertEqual(code, 0)
+            result = json.loads(output.getvalue())
+            self.assertEqual([s['text'] for s in result['strings']], ['Are you sure?'])
+            self.assertEqual(result['translated'], 1)
+
+            with contextlib.redirect_stderr(io.StringIO()):
+                self.assertEqual(run_command(StringsCommand, [os.path.join(directory, 'missing')]), 2)
+
+
+if __name__ == '__main__':
+    unittest.main()
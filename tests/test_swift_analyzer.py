@@ -0,0 +1,101 @@
+"""Tests for Swift analyzer (and the RegexAnalyzer base it builds on)."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.swift import SwiftAnalyzer
+
+
+SOURCE = '''import Foundation
+@testable import MyApp
+
+public protocol Greeter: AnyObject {
+    func greet(_ name: String) -> String
+}
+
+open class Base<T>: NSObject, Greeter {
+    public init(value: T) {
+        super.init()
+    }
+
+    public func greet(_ name: String) -> String {
+        return "Hello, {\\(name)"
+    }
+
+    class func make() -> Base { fatalError() }
+}
+
+struct Point {
+    mutating func move(by dx: Int,
+                       dy: Int) async throws {
+        x += dx
+    }
+}
+
+enum Direction: String {
+    case north, south
+    case east(Int), west
+}
+
+extension Point: CustomStringConvertible {
+    fileprivate var description: String { "p" }
+}
+'''
+
+
+class TestSwiftAnalyzer(unittest.TestCase):
+    """Test Swift analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.swift', delete=False) as f:
+            f.write(SOURCE)
+            self.path = f.name
+        self.analyzer = SwiftAnalyzer(self.path)
+        self.structure = self.analyzer.get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_types(self):
+        """Should extract types with access levels and conformances."""
+        base = self.structure['classes'][0]
+        self.assertEqual(base['name'], 'Base')
+        self.assertEqual(base['visibility'], 'open')
+        self.assertEqual(base['signature'], ': NSObject, Greeter')
+        self.assertEqual((base['line'], base['line_end']), (8, 18))
+
+        self.assertEqual([s['name'] for s in self.structure['structs']], ['Point'])
+        self.assertEqual([p['name'] for p in self.structure['protocols']], ['Greeter'])
+        self.assertEqual(self.structure['extensions'][0]['signature'], ': CustomStringConvertible')
+
+    def test_enum_cases(self):
+        """Enums list their cases, including associated-value cases."""
+        direction = self.structure['enums'][0]
+        self.assertEqual(direction['cases'], ['north', 'south', 'east', 'west'])
+
+    def test_functions(self):
+        """Functions carry signatures, access levels and modifiers."""
+        by_line = {f['line']: f for f in self.structure['functions']}
+
+        # Protocol requirement has no body; braces in strings are ignored
+        self.assertNotIn('line_end', by_line[5])
+        self.assertEqual(by_line[13]['line_end'], 15)
+
+        self.assertEqual(by_line[9]['name'], 'init')
+        self.assertEqual(by_line[9]['visibility'], 'public')
+        self.assertEqual(by_line[17]['modifiers'], ['class'])
+
+        # Multi-line parameter list
+        self.assertEqual(by_line[21]['modifiers'], ['mutating'])
+        self.assertEqual(by_line[21]['line_end'], 24)
+
+    def test_extract_element(self):
+        """Element extraction uses the block range."""
+        result = self.analyzer.extract_element('class', 'Base')
+        self.assertEqual(result['line_start'], 8)
+        self.assertEqual(result['line_end'], 18)
+        self.assertTrue(result['source'].rstrip().endswith('}'))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,56 @@
+"""Tests for ctags-compatible tags output."""
+
+import io
+import unittest
+from reveal.tags import tag_entries, write_tags
+
+
+LINES = [
+    'module Shapes',
+    '  class Circle < Shape',
+    '    def area : Float64',
+    '      x = "a/b"',
+    '    end',
+    '  end',
+    'end',
+    'def main$',
+]
+STRUCTURE = {
+    'imports': [{'line': 1, 'content': 'require "json"'}],
+    'modules': [{'line': 1, 'line_end': 7, 'name': 'Shapes'}],
+    'classes': [{'line': 2, 'line_end': 6, 'name': 'Shapes::Circle'}],
+    'methods': [{'line': 3, 'line_end': 5, 'name': 'Shapes::Circle#area', 'signature': '() -> Float64',
+                 'visibility': 'public'}],
+    'functions': [{'line': 8, 'name': 'main$', 'kind': 'def'}],
+}
+
+
+class TestTags(unittest.TestCase):
+    """Test tags entries and file format."""
+
+    def test_entries(self):
+        """Definitions are tagged with kind, line and scope; imports are skipped."""
+        entries = {e[0]: e for e in tag_entries('src/shapes.cr', LINES, STRUCTURE)}
+        self.assertEqual(sorted(entries), ['Circle', 'Shapes', 'area', 'main$'])
+        self.assertEqual(entries['Circle'][3], {'kind': 'class', 'line': '2', 'namespace': 'Shapes'})
+        self.assertEqual(entries['area'][2], '/^    def area : Float64$/')
+        self.assertEqual(entries['area'][3], {'kind': 'method', 'line': '3', 'class': 'Shapes::Circle',
+                                              'signature': '() -> Float64', 'access': 'public'})
+        self.assertEqual(entries['main$'][2], '/^def main\\$$/')
+        self.assertEqual(entries['main$'][3]['kind'], 'def')
+
+    def test_file_format(self):
+        """Header lines, then tags sorted by name (byte order) in extended format."""
+        out = io.StringIO()
+        count = write_tags(tag_entries('src/shapes.cr', LINES, STRUCTURE), out)
+        lines = out.getvalue().splitlines()
+        self.assertEqual(count, 4)
+        self.assertTrue(lines[0].startswith('!_TAG_FILE_FORMAT\t2\t'))
+        self.assertEqual(lines[1], '!_TAG_FILE_SORTED\t1\t/0=unsorted, 1=sorted, 2=foldcase/')
+        tags = [line for line in lines if not line.startswith('!')]
+        self.assertEqual([t.split('\t')[0] for t in tags], ['Circle', 'Shapes', 'area', 'main$'])
+        self.assertEqual(tags[0], 'Circle\tsrc/shapes.cr\t/^  class Circle < Shape$/;"\tkind:class\tline:2\tnamespace:Shapes')
+
+
+if __name__ == '__main__':
+    unittest.main()
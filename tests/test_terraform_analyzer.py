@@ -0,0 +1,91 @@
+"""Tests for Terraform/HCL analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.terraform import TerraformAnalyzer
+
+
+MAIN_TF = '''terraform {
+  required_version = ">= 1.5"
+}
+
+provider "aws" {
+  region = var.region
+}
+
+variable "region" {
+  type    = string
+  default = "us-east-1"
+}
+
+locals {
+  name = "app-${var.env}"
+  tags = {
+    Team = "core"
+  }
+}
+
+resource "aws_instance" "web" {
+  ami           = data.aws_ami.ubuntu.id
+  instance_type = "t3.micro"
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+
+output "web_ip" {
+  value = aws_instance.web.public_ip
+}
+'''
+
+
+class TestTerraformAnalyzer(unittest.TestCase):
+    """Test Terraform analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.tf', delete=False) as f:
+            f.write(MAIN_TF)
+            self.path = f.name
+        self.structure = TerraformAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_blocks_grouped_by_type(self):
+        """Each block type gets its own category."""
+        self.assertEqual([r['name'] for r in self.structure['resources']], ['aws_instance.web'])
+        self.assertEqual([d['name'] for d in self.structure['data_sources']], ['aws_ami.ubuntu'])
+        self.assertEqual([m['name'] for m in self.structure['modules']], ['vpc'])
+        self.assertEqual([p['name'] for p in self.structure['providers']], ['aws'])
+        self.assertEqual([o['name'] for o in self.structure['outputs']], ['web_ip'])
+        self.assertEqual(self.structure['resources'][0]['line_end'], 24)
+
+    def test_key_attributes(self):
+        """Modules show their source; variables their type and default."""
+        self.assertEqual(self.structure['modules'][0]['signature'],
+                         ' (source="terraform-aws-modules/vpc/aws", version="5.0.0")')
+        self.assertEqual(self.structure['variables'][0]['signature'],
+                         ' (type=string, default="us-east-1")')
+        self.assertEqual(self.structure['terraform'][0]['signature'],
+                         ' (required_version=">= 1.5")')
+
+    def test_locals(self):
+        """Each local value is listed, nested maps are not split."""
+        self.assertEqual([l['name'] for l in self.structure['locals']], ['local.name', 'local.tags'])
+
+    def test_extract_by_qualified_name(self):
+        """Blocks are extracted by their listed name (type.name)."""
+        element = TerraformAnalyzer(self.path).extract_element('resource', 'aws_instance.web')
+        self.assertEqual((element['line_start'], element['line_end']), (21, 24))
+        self.assertTrue(element['source'].startswith('resource "aws_instance" "web" {'))
+
+
+if __name__ == '__main__':
+    unittest.main()
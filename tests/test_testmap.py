@@ -0,0 +1,66 @@
+"""Tests for the test-to-source mapping (reveal test-map)."""
+
+import unittest
+from reveal.testmap import map_tests, subject_of
+
+
+FUNCTION = {'functions': [{'name': 'f', 'line': 1}]}
+
+
+class TestSubject(unittest.TestCase):
+    """Test file names and what they test."""
+
+    def test_naming_conventions(self):
+        cases = {
+            'tests/test_parser.py': 'parser',
+            'pkg/server_test.go': 'server',
+            'src/app.test.ts': 'app',
+            'src/app.spec.tsx': 'app',
+            'src/test/java/FooBarTest.java': 'FooBar',
+            'Tests/FooTests.swift': 'Foo',
+            'spec/user_spec.rb': 'user',
+            'src/TestWidget.java': 'Widget',
+            'tests/integration.rs': 'integration',
+        }
+        for path, subject in cases.items():
+            self.assertEqual(subject_of(path), subject, path)
+
+    def test_not_tests(self):
+        for path in ('src/parser.py', 'src/Latest.java', 'src/contest.go', 'attestation.rb'):
+            self.assertIsNone(subject_of(path), path)
+
+
+class TestMapTests(unittest.TestCase):
+    """Pairs by name and import, untested sources."""
+
+    def test_name_and_import(self):
+        files = [
+            ('src/shapes/circle.nim', 'Nim', FUNCTION),
+            ('lib/circle.nim', 'Nim', FUNCTION),
+            ('src/util.nim', 'Nim', FUNCTION),
+            ('src/helper.nim', 'Nim', FUNCTION),
+            ('src/config.nim', 'Nim', {}),
+            ('tests/shapes/test_circle.nim', 'Nim',
+             {'imports': [{'line': 1, 'content': 'import ../../src/util'}], **FUNCTION}),
+        ]
+        result = map_tests(files)
+        self.assertEqual(result['tests'], [{'file': 'tests/shapes/test_circle.nim', 'sources': [
+            {'file': 'src/shapes/circle.nim', 'via': ['name']},
+            {'file': 'src/util.nim', 'via': ['import']},
+        ]}])
+        self.assertEqual(result['untested'], ['lib/circle.nim', 'src/helper.nim'])
+        self.assertNotIn('src/config.nim', result['sources'])
+
+    def test_naming_styles_and_language(self):
+        files = [
+            ('src/main/java/FooBar.java', 'Java', FUNCTION),
+            ('web/foo_bar.js', 'JavaScript', FUNCTION),
+            ('src/test/java/FooBarTest.java', 'Java', FUNCTION),
+        ]
+        result = map_tests(files)
+        self.assertEqual(result['tests'][0]['sources'], [{'file': 'src/main/java/FooBar.java', 'via': ['name']}])
+        self.assertEqual(result['untested'], ['web/foo_bar.js'])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,89 @@
+"""Tests for Thrift analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.thrift import ThriftAnalyzer
+
+
+THRIFT = '''include "shared.thrift"
+namespace java com.acme.users
+namespace py acme.users
+
+typedef i64 UserId
+const i32 MAX_USERS = 1000
+
+/* A user
+ * account */
+struct User {
+  1: required UserId id,
+  2: optional string name = "anon",
+  3: list<string> roles;  // roles
+  4: map<string, list<i32>> scores,
+}
+
+union Contact { 1: string phone, 2: string email }
+
+exception NotFound {
+  1: string message
+}
+
+enum Status {
+  ACTIVE = 1,
+  DISABLED,
+}
+
+service UserService extends shared.Base {
+  User getUser(1: UserId id) throws (1: NotFound nf),
+  list<User> search(1: string query,
+                    2: i32 limit)
+    throws (1: NotFound nf),
+  oneway void ping()
+}
+'''
+
+
+class TestThriftAnalyzer(unittest.TestCase):
+    """Test Thrift analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.thrift', delete=False) as f:
+            f.write(THRIFT)
+        self.addCleanup(os.unlink, f.name)
+        self.analyzer = ThriftAnalyzer(f.name)
+        self.structure = self.analyzer.get_structure()
+
+    def test_structs(self):
+        """Structs, unions and exceptions with field ids, labels and generic types."""
+        user, contact, error = self.structure['structs']
+        self.assertEqual((user['name'], user['line'], user['line_end']), ('User', 10, 15))
+        self.assertEqual(user['fields'][0], {'name': 'id', 'id': 1, 'type': 'UserId', 'label': 'required'})
+        self.assertEqual(user['fields'][3]['type'], 'map<string, list<i32>>')
+        self.assertEqual(contact['signature'], ' { phone=1, email=2 }  (union)')
+        self.assertEqual(error['kind'], 'exception')
+
+    def test_enums_typedefs_constants(self):
+        """Enum values (explicit or implicit), typedefs and constants."""
+        self.assertEqual(self.structure['enums'][0]['signature'], ' { ACTIVE=1, DISABLED }')
+        self.assertEqual(self.structure['typedefs'][0]['signature'], ': i64')
+        self.assertEqual(self.structure['constants'][0]['value'], '1000')
+
+    def test_services(self):
+        """Service methods with arguments, return type, throws and oneway."""
+        self.assertEqual(self.structure['services'][0]['extends'], 'shared.Base')
+        get_user, search, ping = self.structure['methods']
+        self.assertEqual(get_user['signature'], '(UserId id) -> User throws NotFound')
+        self.assertEqual(search['signature'], '(string query, i32 limit) -> list<User> throws NotFound')
+        self.assertEqual((search['line'], search['line_end']), (30, 32))
+        self.assertTrue(ping['oneway'])
+
+    def test_headers_and_extract(self):
+        """Includes, namespaces and element extraction."""
+        self.assertEqual(self.structure['imports'][0]['content'], 'include "shared.thrift"')
+        self.assertEqual(len(self.structure['namespaces']), 2)
+        element = self.analyzer.extract_element('struct', 'NotFound')
+        self.assertEqual((element['line_start'], element['line_end']), (19, 21))
+
+
+if __name__ == '__main__':
+    unittest.main()
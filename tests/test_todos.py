@@ -0,0 +1,107 @@
+"""Tests for TODO/FIXME extraction (reveal todos)."""
+
+import os
+import subprocess
+import tempfile
+import unittest
+from reveal.git import GitError, blame, last_change
+from reveal.todos import file_todos, find_todos, todo_pattern
+
+
+class TestFindTodos(unittest.TestCase):
+    """Markers in comments, across comment styles."""
+
+    def test_comment_styles(self):
+        lines = [
+            '# TODO: python or shell',
+            'x = 1  // FIXME(bob): trailing',
+            '/* HACK - block comment */',
+            ' * XXX continuation line',
+            '-- TODO sql or lua',
+            '<!-- TODO: html -->',
+        ]
+        todos = find_todos(lines, todo_pattern())
+        self.assertEqual([(t['line'], t['tag'], t['text']) for t in todos], [
+            (1, 'TODO', 'python or shell'),
+            (2, 'FIXME', 'trailing'),
+            (3, 'HACK', 'block comment'),
+            (4, 'XXX', 'continuation line'),
+            (5, 'TODO', 'sql or lua'),
+            (6, 'TODO', 'html'),
+        ])
+        self.assertEqual(todos[1]['owner'], 'bob')
+
+    def test_not_comments(self):
+        lines = ['todo_list = []', 'message = "TODO: not a comment"', '# todo lower case', 'TODOS = 3  # note']
+        self.assertEqual(find_todos(lines, todo_pattern()), [])
+
+    def test_prose_is_not_a_marker(self):
+        lines = ['# M5xx: TODO/FIXME comments', '# TODO', 'HELP = """',
+                 '  reveal todos src/  # TODO/FIXME/HACK/XXX comments', '  # TODO: inside a string', '"""']
+        self.assertEqual(find_todos(lines, todo_pattern(), 'main.py'), [])
+
+    def test_comments_only(self):
+        lines = ['url = "http://x"; // TODO: after a string', 'print("// FIXME: in a string")', '/* start',
+                 '   HACK: inside the block */ call(); // XXX(kim) trailing']
+        todos = find_todos(lines, todo_pattern(), 'app.js')
+        self.assertEqual([(t['line'], t['column'], t['tag']) for t in todos], [(1, 22, 'TODO'), (4, 4, 'HACK'),
+                                                                              (4, 41, 'XXX')])
+        self.assertEqual(todos[2]['owner'], 'kim')
+
+    def test_several_markers_on_a_line(self):
+        todos = find_todos(['# TODO: cache this FIXME(bob): and lock it'], todo_pattern(), 'app.py')
+        self.assertEqual([(t['tag'], t['text']) for t in todos], [('TODO', 'cache this'), ('FIXME', 'and lock it')])
+
+    def test_custom_tags(self):
+        lines = ['# NOTE: keep in sync', '# TODO: later']
+        self.assertEqual([t['tag'] for t in find_todos(lines, todo_pattern(['NOTE']))], ['NOTE'])
+
+    def test_enclosing_symbol(self):
+        lines = ['def load():', '    # TODO: cache', '    pass', '# FIXME: module level']
+        structure = {'functions': [{'name': 'load', 'line': 1, 'line_end': 3}]}
+        todos = file_todos(lines, structure, todo_pattern())
+        self.assertEqual([t.get('symbol') for t in todos], ['load', None])
+
+
+class TestBlame(unittest.TestCase):
+    """git blame authors and dates per line."""
+
+    def test_blame(self):
+        with tempfile.TemporaryDirectory() as directory:
+            env = {**os.environ, 'GIT_AUTHOR_NAME': 'Ada', 'GIT_AUTHOR_EMAIL': 'ada@example.com',
+                   'GIT_COMMITTER_NAME': 'Ada', 'GIT_COMMITTER_EMAIL': 'ada@example.com',
+                   'GIT_AUTHOR_DATE': '2024-03-01T12:00:00Z', 'GIT_COMMITTER_DATE': '2024-03-01T12:00:00Z'}
+            path = os.path.join(directory, 'app.py')
+            with open(path, 'w') as f:
+                f.write('a = 1\n')
+            try:
+                for command in (['init', '-q'], ['add', '.'], ['commit', '-qm', 'init']):
+                    subprocess.run(['git', *command], cwd=directory, env=env, check=True, capture_output=True)
+            except (OSError, subprocess.CalledProcessError):
+                self.skipTest('git not available')
+            with open(path, 'a') as f:
+                f.write('b = 2\n')
+
+            lines = blame(path)
+            self.assertEqual((lines[1]['author'], lines[1]['date']), ('Ada', '2024-03-01'))
+            self.assertEqual(len(lines[1]['commit']), 8)
+            self.assertEqual(lines[2]['author'], 'Not Committed Yet')
+
+    def test_last_change(self):
+        lines = {1: {'commit': 'aaaaaaaa', 'author': 'Ada', 'date': '2024-03-01', 'time': 100},
+                 2: {'commit': 'bbbbbbbb', 'author': 'Lin', 'date': '2024-05-01', 'time': 200},
+                 3: {'commit': 'aaaaaaaa', 'author': 'Ada', 'date': '2024-03-01', 'time': 100}}
+        self.assertEqual(last_change(lines, 1, 3)['author'], 'Lin')
+        self.assertEqual(last_change(lines, 3, 3)['commit'], 'aaaaaaaa')
+        self.assertIsNone(last_change(lines, 4, 9))
+
+    def test_not_a_repository(self):
+        with tempfile.TemporaryDirectory() as directory:
+            path = os.path.join(directory, 'app.py')
+            open(path, 'w').close()
+            with self.assertRaises(GitError):
+                blame(path)
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -194,6 +194,73 @@ version = "1.0.0"
         finally:
             os.unlink(path)
 
+    def test_section_hierarchy_and_keys(self):
+        """Sections nest under existing dotted parents and list their key names."""
+        content = """
+[project]
+name = "test"
+version = "1.0.0"
+
+[project.urls]
+Homepage = "https://example.com"
+
+[tool.pytest.ini_options]
+testpaths = ["tests"]
+"""
+        path = self.create_temp_toml(content)
+        try:
+            sections = TomlAnalyzer(path).get_structure()['sections']
+            self.assertEqual([(s['name'], s['level']) for s in sections],
+                             [('project', 1), ('project.urls', 2), ('tool.pytest.ini_options', 1)])
+            self.assertEqual(sections[0]['signature'], ' {name, version}')
+            self.assertEqual(sections[0]['line_end'], 4)
+        finally:
+            os.unlink(path)
+
+    def test_values_and_redaction(self):
+        """Top-level values are shown; secrets are always hidden, everything with redact=True."""
+        content = """
+title = "demo"  # comment
+api_token = "abc123"
+deps = [
+    "a",  # ]
+    "b",
+]
+"""
+        path = self.create_temp_toml(content)
+        try:
+            analyzer = TomlAnalyzer(path)
+            keys = {k['name']: k for k in analyzer.get_structure()['keys']}
+            self.assertEqual(keys['title']['value'], '"demo"')
+            self.assertEqual(keys['api_token']['value'], '***')
+            self.assertEqual((keys['deps']['value'], keys['deps']['line_end']), ('[...]', 7))
+
+            redacted = analyzer.get_structure(redact=True)['keys']
+            self.assertEqual({k['value'] for k in redacted}, {'***'})
+        finally:
+            os.unlink(path)
+
+    def test_multiline_string_not_parsed(self):
+        """Lines inside multi-line strings are not sections or keys."""
+        content = '[project]\ndescription = """\n[not.a.section]\nfake = 1\n"""\nname = "real"\n'
+        path = self.create_temp_toml(content)
+        try:
+            sections = TomlAnalyzer(path).get_structure()['sections']
+            self.assertEqual([s['name'] for s in sections], ['project'])
+            self.assertEqual([k['name'] for k in sections[0]['keys']], ['description', 'name'])
+        finally:
+            os.unlink(path)
+
+    def test_extract_dotted_key(self):
+        """Keys are extracted as section.key, spanning multi-line values."""
+        content = '[build-system]\nrequires = [\n    "setuptools",\n]\n'
+        path = self.create_temp_toml(content)
+        try:
+            result = TomlAnalyzer(path).extract_element('key', 'build-system.requires')
+            self.assertEqual((result['line_start'], result['line_end']), (2, 4))
+        finally:
+            os.unlink(path)
+
 
 if __name__ == '__main__':
     unittest.main()
@@ -0,0 +1,99 @@
+"""Tests for the directory tree view and its --max-entries truncation."""
+
+import tempfile
+import unittest
+from pathlib import Path
+from unittest import mock
+from reveal import tree_view
+from reveal.tree_view import _scan_tree, iter_directory_tree, select_entries, show_directory_tree
+
+
+class TestTreeTruncation(unittest.TestCase):
+    """Which entries are kept when a tree has more than --max-entries, and how the rest is summed up."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.root = Path(self.tmpdir.name)
+        for i in range(1, 31):
+            self.write(f'docs/page{i:02}.txt')
+            self.write(f'tests/test_{i:02}.nim')
+        for i in range(1, 11):
+            self.write(f'src/pkg/mod{i:02}.nim')
+        self.write('src/main.nim')
+        self.write('src/zz_data.bin')
+        self.write('README.md')
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def write(self, name):
+        path = self.root / name
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text('x\n')
+
+    def names(self, shown):
+        return sorted(path.relative_to(self.root).as_posix() for path in shown)
+
+    def test_everything_fits(self):
+        children = _scan_tree(self.root, 3, False)
+        self.assertEqual(len(select_entries(self.root, children, 0)), 77)
+        self.assertEqual(len(select_entries(self.root, children, 77)), 77)
+        self.assertEqual(len(select_entries(self.root, children, 76)), 76)
+
+    def test_selection(self):
+        children = _scan_tree(self.root, 3, False)
+        shown = self.names(select_entries(self.root, children, 12))
+        # The top level fits whole; the next one is shared in turns, most telling entries first
+        self.assertEqual(shown, ['README.md', 'docs', 'docs/page01.txt', 'docs/page02.txt', 'docs/page03.txt',
+                                 'src', 'src/main.nim', 'src/pkg', 'src/zz_data.bin', 'tests', 'tests/test_01.nim',
+                                 'tests/test_02.nim'])
+        shown = self.names(select_entries(self.root, children, 8))
+        self.assertEqual(shown, ['README.md', 'docs', 'docs/page01.txt', 'docs/page02.txt', 'src', 'src/pkg', 'tests',
+                                 'tests/test_01.nim'])
+
+    def test_rendering(self):
+        tree = show_directory_tree(str(self.root), max_entries=8, fast=True)
+        self.assertIn('│   ├── page02.txt (2.0 B)\n│   └── ... 28 entries omitted (28 files)', tree)
+        self.assertIn('│   ├── pkg/\n│   │   └── ... 10 entries omitted (10 files)\n│   └── ... 2 entries omitted (2 files)',
+                      tree)
+        self.assertIn('... 69 entries omitted in all (use --max-entries 0 to show all)', tree)
+        self.assertIn('│   ├── main.nim (2.0 B)\n│   └── ... 1 entry omitted (1 file)',
+                      show_directory_tree(str(self.root), max_entries=9, fast=True))
+        self.assertNotIn('omitted', show_directory_tree(str(self.root), max_entries=0, fast=True))
+
+
+class TestTreeStreaming(unittest.TestCase):
+    """Lines come out as soon as the files they depend on are analyzed."""
+
+    def test_directory_lines_wait_for_their_files(self):
+        with tempfile.TemporaryDirectory() as tmpdir:
+            root = Path(tmpdir)
+            for name in ('a/one.nim', 'a/two.nim', 'b/three.nim', 'top.nim'):
+                (root / name).parent.mkdir(exist_ok=True)
+                (root / name).write_text('proc f*() = discard\n')
+
+            analyzed = []
+            get_file_info = tree_view._get_file_info
+
+            def recording(path, **options):
+                analyzed.append(path.name)
+                return get_file_info(path, **options)
+
+            seen = []
+            raw = []
+            with mock.patch.object(tree_view, '_get_file_info', recording):
+                for line in iter_directory_tree(str(root), jobs=1, progress=lambda done, total: seen.append(done)):
+                    seen.append((len(analyzed), line.strip('├└─│ ')))
+                    raw.append(line)
+            # Each line is yielded once the files it covers (its own, or every one below a directory) are done
+            lines = [item for item in seen if isinstance(item, tuple)]
+            self.assertEqual(lines[0], (0, root.name + '/\n'))
+            self.assertEqual(lines[1], (2, 'a/ (2 files, 2 code, 0 comment)'))
+            self.assertEqual(lines[4], (3, 'b/ (1 file, 1 code, 0 comment)'))
+            self.assertEqual(lines[6][0], 4)
+            self.assertEqual([item for item in seen if isinstance(item, int)], [1, 2, 3, 4])
+            self.assertEqual('\n'.join(raw), show_directory_tree(str(root)))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,110 @@
+"""Tests for reading imported modules from tree-sitter import nodes.
+
+The nodes here mimic the shapes of the real grammars (node types and
+field names), so the extraction logic is tested without a parser.
+"""
+
+import tempfile
+import unittest
+from pathlib import Path
+from types import SimpleNamespace
+from reveal.dependencies import build_import_graph
+from reveal.treesitter import TreeSitterAnalyzer
+
+
+class Node:
+    """Just enough of a tree-sitter node: type, text, children and fields."""
+
+    def __init__(self, type, text='', *children, line=0, has_error=False, **fields):
+        self.type = type
+        self.text = text
+        self.children = list(children) + [child for child in fields.values() if child not in children]
+        self.fields = fields
+        self.has_error = has_error
+        self.start_point = (line, 0)
+        self.end_point = (line, len(text))
+
+    def child_by_field_name(self, name):
+        return self.fields.get(name)
+
+
+class FakeAnalyzer(TreeSitterAnalyzer):
+    language = 'fake'
+
+    def _get_node_text(self, node):
+        return node.text
+
+
+def keyword(text):
+    return Node(text, text)
+
+
+class TestImportModules(unittest.TestCase):
+    """Module names come from the parse tree, not from slicing the statement."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        path = Path(self.tmpdir.name) / 'sample.fake'
+        path.write_text('')
+        self.analyzer = FakeAnalyzer(str(path))
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def modules(self, node):
+        return self.analyzer._import_modules(node)
+
+    def test_python(self):
+        aliased = Node('aliased_import', 'os.path as p', name=Node('dotted_name', 'os.path'))
+        self.assertEqual(self.modules(Node('import_statement', 'import os.path as p, sys', keyword('import'), aliased,
+                                           Node('dotted_name', 'sys'))), ['os.path', 'sys'])
+        self.assertEqual(self.modules(Node('import_from_statement', 'from ..core import x', keyword('from'),
+                                           keyword('import'), Node('dotted_name', 'x'),
+                                           module_name=Node('relative_import', '..core'))), ['..core.x'])
+        # 'from pkg import mod' may import the submodule pkg.mod; a wildcard only names pkg
+        submodule = Node('import_from_statement', 'from pkg import mod', keyword('from'), Node('dotted_name', 'pkg'),
+                         keyword('import'), Node('dotted_name', 'mod'))
+        submodule.fields['module_name'] = submodule.children[1]
+        self.assertEqual(self.modules(submodule), ['pkg.mod'])
+        wildcard = Node('import_from_statement', 'from pkg import *', keyword('from'), Node('dotted_name', 'pkg'),
+                        keyword('import'), Node('wildcard_import', '*'))
+        wildcard.fields['module_name'] = wildcard.children[1]
+        self.assertEqual(self.modules(wildcard), ['pkg'])
+        # 'from . import a, b' imports sibling modules; the module name itself is not one of them
+        sibling = Node('import_from_statement', 'from . import a, b as c', keyword('from'),
+                       Node('relative_import', '.'), keyword('import'), Node('dotted_name', 'a'),
+                       Node('aliased_import', 'b as c', name=Node('dotted_name', 'b')))
+        sibling.fields['module_name'] = sibling.children[1]
+        self.assertEqual(self.modules(sibling), ['.a', '.b'])
+
+    def test_other_languages(self):
+        self.assertEqual(self.modules(Node('import_statement', "import x from './x'",
+                                           source=Node('string', "'./x'"))), ['./x'])
+        specs = Node('import_spec_list', '', Node('import_spec', path=Node('interpreted_string_literal', '"fmt"')),
+                     Node('import_spec', path=Node('raw_string_literal', '`os`')))
+        self.assertEqual(self.modules(Node('import_declaration', '', keyword('import'), specs)), ['fmt', 'os'])
+        self.assertEqual(self.modules(Node('import_declaration', 'import static org.junit.Assert.*;', keyword('import'),
+                                           keyword('static'), Node('scoped_identifier', 'org.junit.Assert'),
+                                           Node('asterisk', '*'))), ['org.junit.Assert'])
+        grouped = Node('scoped_use_list', 'crate::config::{Config, Mode}',
+                       path=Node('scoped_identifier', 'crate::config'))
+        self.assertEqual(self.modules(Node('use_declaration', argument=grouped)), ['crate::config'])
+        wildcard = Node('use_wildcard', 'std::io::*', Node('scoped_identifier', 'std::io'))
+        self.assertEqual(self.modules(Node('use_declaration', argument=wildcard)), ['std::io'])
+        self.assertEqual(self.modules(Node('use_declaration', argument=Node('use_list', '{a, b}'))), [])
+
+    def test_syntax_errors_fall_back_to_the_statement(self):
+        broken = Node('import_statement', 'import os,', keyword('import'), Node('dotted_name', 'os'), has_error=True)
+        self.assertEqual(self.modules(broken), [])
+        fine = Node('import_statement', 'import app.db', keyword('import'), Node('dotted_name', 'app.db'), line=1)
+        self.analyzer.tree = SimpleNamespace(root_node=Node('module', '', broken, fine))
+        imports = self.analyzer.get_structure()['imports']
+        self.assertEqual(imports, [{'line': 1, 'content': 'import os,'},
+                                   {'line': 2, 'content': 'import app.db', 'modules': ['app.db']}])
+        graph = build_import_graph([('main.py', {'imports': imports}), ('app/db.py', {})])
+        self.assertEqual(graph.edge_list(), [('main.py', 'app/db.py')])
+        self.assertEqual(graph.external['main.py'], {'os'})
+
+
+if __name__ == '__main__':
+    unittest.main()
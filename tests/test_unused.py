@@ -0,0 +1,87 @@
+"""Tests for unused symbol detection (reveal unused)."""
+
+import unittest
+from reveal.unused import reference_name, unused_symbols
+
+
+def functions(*names_and_lines, category='functions', **extra):
+    return {category: [{'name': name, 'line': line, **extra} for name, line in names_and_lines]}
+
+
+class TestReferenceName(unittest.TestCase):
+    """Names as references spell them."""
+
+    def test_forms(self):
+        cases = {
+            'Server#start': 'start',
+            'Shapes::Circle::Inner': 'Inner',
+            'format/1': 'format',
+            'M:method': 'method',
+            'Account$log': 'log',
+            '$helper': '$helper',
+            'scale!': 'scale!',
+            '-[Widget initWithTitle:count:]': 'initWithTitle',
+            '+[Widget shared]': 'shared',
+            '(<+>)': None,
+        }
+        for name, expected in cases.items():
+            self.assertEqual(reference_name(name), expected, name)
+
+
+class TestUnusedSymbols(unittest.TestCase):
+    """Definitions no other line mentions."""
+
+    def names(self, files, **kwargs):
+        unused, _ = unused_symbols(files, **kwargs)
+        return [(symbol['file'], symbol['name']) for symbol in unused]
+
+    def test_unreferenced_functions(self):
+        files = [
+            ('app.cr', ['def main', '  run_job(load)', 'end', 'def run_job(x)', 'end', 'def load', 'end',
+                        'def old_helper', 'end', '# old_helper is kept for reference'],
+             functions(('main', 1), ('run_job', 4), ('load', 6), ('old_helper', 8))),
+        ]
+        unused, checked = unused_symbols(files)
+        self.assertEqual([(s['name'], s['line'], s['kind']) for s in unused], [('old_helper', 8, 'function')])
+        self.assertEqual(checked, 3)
+
+    def test_references_in_other_files_and_strings(self):
+        files = [
+            ('lib.cr', ['def parse', 'end', 'def dispatch', 'end'], functions(('parse', 1), ('dispatch', 3))),
+            ('main.cr', ['Lib.parse', 'handlers = {"dispatch" => 1}'], {}),
+        ]
+        self.assertEqual(self.names(files), [])
+
+    def test_same_name_defined_twice(self):
+        files = [('a.rb', ['def close', 'end'], functions(('close', 1))),
+                 ('b.rb', ['def close', 'end'], functions(('close', 1)))]
+        self.assertEqual(self.names(files), [('a.rb', 'close'), ('b.rb', 'close')])
+
+    def test_exclusions(self):
+        files = [
+            ('tests/test_app.py', ['def test_login():', '    pass'], functions(('test_login', 1))),
+            ('model.py', ['class A:', '    def __repr__(self):', '@app.route("/")', 'def index():'],
+             functions(('__repr__', 2), ('index', 4))),
+            ('proto/api.proto', ['service Api {', '  rpc Get(Req) returns (Resp);'],
+             functions(('Api.Get', 2), category='methods')),
+        ]
+        self.assertEqual(self.names(files), [])
+
+    def test_exported_api(self):
+        files = [
+            ('pkg/store.go', ['func Open() {}', 'func helper() {}'], functions(('Open', 1), ('helper', 2))),
+            ('pkg/__init__.py', ['def public():', 'def _private():'], functions(('public', 1), ('_private', 2))),
+            ('mod.py', ['__all__ = ["api"]', 'def api():', 'def internal():'], functions(('api', 2), ('internal', 3))),
+            ('lib.rs', ['pub fn open() {}', 'fn close() {}'], functions(('open', 1), ('close', 2))),
+            ('util.nim', ['proc shown*() =', 'proc hidden() ='],
+             {'functions': [{'name': 'shown', 'line': 1, 'visibility': 'public'},
+                            {'name': 'hidden', 'line': 2, 'visibility': 'private'}]}),
+        ]
+        self.assertEqual(self.names(files), [('pkg/store.go', 'helper'), ('pkg/__init__.py', '_private'),
+                                             ('mod.py', 'internal'), ('lib.rs', 'close'), ('util.nim', 'hidden')])
+        # api is still mentioned by __all__ itself
+        self.assertEqual(len(self.names(files, include_exported=True)), 9)
+
+
+if __name__ == '__main__':
+    unittest.main()
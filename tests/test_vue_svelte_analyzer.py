@@ -0,0 +1,103 @@
+"""Tests for Vue and Svelte single-file component analyzers."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.vue_svelte import VueAnalyzer, SvelteAnalyzer
+
+
+SETUP_VUE = '''<template>
+  <button @click="$emit('close')">{{ title }}</button>
+</template>
+
+<script setup lang="ts">
+const props = defineProps<{
+  title: string
+  count?: number
+}>()
+const emit = defineEmits<{
+  (e: 'change', id: number): void
+}>()
+
+function increment() {
+  emit('change', 1)
+}
+const reset = () => {}
+defineExpose({ reset })
+</script>
+
+<style scoped>
+button { color: red; }
+</style>
+'''
+
+OPTIONS_VUE = '''<script>
+export default {
+  props: {
+    title: { type: String, required: true },
+    size: Number,
+  },
+  emits: ['select'],
+}
+</script>
+'''
+
+BUTTON_SVELTE = '''<script>
+  import { createEventDispatcher } from 'svelte';
+  export let label;
+  export let size = 'md';
+  const dispatch = createEventDispatcher();
+  export function focus() {}
+  function click() {
+    dispatch('press');
+  }
+</script>
+
+<button on:click={click}>{label}</button>
+'''
+
+
+class TestComponentAnalyzers(unittest.TestCase):
+    """Test section splitting, props, events and functions."""
+
+    def _analyze(self, analyzer_class, suffix, content):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+            path = f.name
+        self.addCleanup(os.unlink, path)
+        return analyzer_class(path).get_structure()
+
+    def test_vue_script_setup(self):
+        structure = self._analyze(VueAnalyzer, '.vue', SETUP_VUE)
+
+        sections = [(s['name'], s['line'], s['line_end']) for s in structure['sections']]
+        self.assertEqual(sections, [('template', 1, 3), ('script', 5, 19), ('style', 21, 23)])
+        self.assertEqual(structure['sections'][1]['attributes'], {'setup': '', 'lang': 'ts'})
+
+        self.assertEqual([(p['name'], p['signature']) for p in structure['props']],
+                         [('title', ': string'), ('count?', ': number')])
+        self.assertEqual([e['name'] for e in structure['events']], ['close', 'change'])
+
+        functions = {f['name']: f for f in structure['functions']}
+        self.assertEqual(set(functions), {'increment', 'reset'})
+        self.assertEqual(functions['reset'].get('visibility'), 'exported')
+        self.assertNotIn('visibility', functions['increment'])
+
+    def test_vue_options_api(self):
+        structure = self._analyze(VueAnalyzer, '.vue', OPTIONS_VUE)
+
+        self.assertEqual([(p['name'], p['signature']) for p in structure['props']],
+                         [('title', ': String'), ('size', ': Number')])
+        self.assertEqual([e['name'] for e in structure['events']], ['select'])
+
+    def test_svelte_component(self):
+        structure = self._analyze(SvelteAnalyzer, '.svelte', BUTTON_SVELTE)
+
+        self.assertEqual([p['name'] for p in structure['props']], ['label', 'size'])
+        self.assertEqual([e['name'] for e in structure['events']], ['press'])
+        functions = {f['name']: f.get('visibility') for f in structure['functions']}
+        self.assertEqual(functions, {'focus': 'exported', 'click': None})
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,103 @@
+"""Tests for change detection behind --watch."""
+
+import contextlib
+import io
+import json
+import os
+import sys
+import tempfile
+import threading
+import unittest
+from pathlib import Path
+from unittest import mock
+from reveal.main import main
+from reveal.watch import changed_files, notifier, snapshot, watch
+
+
+class TestWatch(unittest.TestCase):
+    """Snapshots, events between them, and the watch loop, polling or notified."""
+
+    def setUp(self):
+        self.tmpdir = tempfile.TemporaryDirectory()
+        self.directory = Path(self.tmpdir.name)
+
+    def tearDown(self):
+        self.tmpdir.cleanup()
+
+    def write(self, name, text, mtime=None):
+        path = self.directory / name
+        path.parent.mkdir(parents=True, exist_ok=True)
+        path.write_text(text)
+        if mtime is not None:
+            os.utime(path, ns=(mtime, mtime))
+        return str(path)
+
+    def test_changed_files(self):
+        before = {'a.py': (1, 10), 'b.py': (1, 10), 'c.py': (1, 10)}
+        after = {'a.py': (1, 10), 'b.py': (2, 12), 'd.py': (1, 1)}
+        self.assertEqual(changed_files(before, after), [('modified', 'b.py'), ('removed', 'c.py'), ('added', 'd.py')])
+        self.assertEqual(changed_files(after, after), [])
+
+    def test_snapshot(self):
+        app = self.write('app.py', 'x = 1\n', mtime=10 ** 18)
+        self.write('.hidden/secret.py', 'x = 1\n')
+        self.assertEqual(snapshot(self.directory), {app: (10 ** 18, 6)})
+        self.assertEqual(snapshot(Path(app)), {app: (10 ** 18, 6)})
+
+    def test_watch(self):
+        app = self.write('app.py', 'x = 1\n')
+        lib = str(self.directory / 'lib.py')
+        # Each sleep stands for the time between polls: edit, nothing, add a file
+        edits = [lambda: self.write('app.py', 'x = 22\n'), lambda: None, lambda: self.write('lib.py', 'y = 1\n')]
+        calls = []
+        with mock.patch('reveal.watch.time.sleep', side_effect=lambda _seconds: edits.pop(0)()):
+            watch(self.directory, calls.append, interval=1, polls=3, native=False)
+        self.assertEqual(calls, [[('modified', app)], [('added', lib)]])
+
+    def test_native(self):
+        app = self.write('app.py', 'x = 1\n')
+        waiter = notifier(self.directory)
+        if waiter is None:
+            self.skipTest('no native change notifications here')
+        try:
+            self.assertFalse(waiter.wait(0.1))
+            (self.directory / 'pkg').mkdir()
+            self.assertTrue(waiter.wait(5))
+            # The new directory is watched too
+            self.write('pkg/new.py', 'y = 1\n')
+            self.assertTrue(waiter.wait(5))
+        finally:
+            waiter.close()
+
+        edit = threading.Timer(0.2, self.write, ('app.py', 'x = 22\n'))
+        edit.start()
+        calls = []
+        with mock.patch('reveal.watch.time.sleep', side_effect=AssertionError('polled')):
+            watch(self.directory, calls.append, interval=5, polls=1)
+        edit.join()
+        self.assertEqual(calls, [[('modified', app)]])
+
+    def test_render_skips(self):
+        # Changed files go through --max-file-size and the generated-file skip, like the first render
+        def changes(path, on_change, **options):
+            big = self.write('big.py', 'x = 1\n' * 400)
+            generated = self.write('api_pb2.py', 'x = 1\n')
+            small = self.write('small.py', 'def f():\n    pass\n')
+            on_change([('modified', big), ('modified', generated), ('added', small)])
+
+        output = io.StringIO()
+        argv = ['reveal', str(self.directory), '--watch', '--format', 'jsonl', '--max-file-size', '1K']
+        with mock.patch.object(sys, 'argv', argv), mock.patch('reveal.watch.watch', changes), \
+                contextlib.redirect_stdout(output):
+            main()
+        records = [json.loads(line) for line in output.getvalue().splitlines()]
+        after = records[[r.get('record') for r in records].index('event'):]
+        self.assertEqual([(r['record'], Path(r['file']).name, 'type' in r and r['type'] is None) for r in after
+                          if r['record'] in ('event', 'file')],
+                         [('event', 'big.py', False), ('file', 'big.py', True),
+                          ('event', 'api_pb2.py', False), ('file', 'api_pb2.py', True),
+                          ('event', 'small.py', False), ('file', 'small.py', False)])
+
+
+if __name__ == '__main__':
+    unittest.main()
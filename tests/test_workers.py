@@ -0,0 +1,65 @@
+"""Tests for the bounded worker pool behind --jobs."""
+
+import multiprocessing
+import os
+import pickle
+import tempfile
+import time
+import unittest
+from reveal.base import get_analyzer
+from reveal.workers import default_jobs, ordered_map
+
+
+class TestOrderedMap(unittest.TestCase):
+    """Results in input order, bounded concurrency."""
+
+    def test_order(self):
+        # Later items finish first; results still come back in input order
+        def slow(n):
+            time.sleep((10 - n) * 0.002)
+            return n * n
+
+        self.assertEqual(list(ordered_map(slow, range(10), jobs=4)), [n * n for n in range(10)])
+        self.assertEqual(list(ordered_map(slow, range(10), jobs=1)), [n * n for n in range(10)])
+        self.assertEqual(list(ordered_map(slow, [], jobs=4)), [])
+
+    def test_bounded(self):
+        pulled = []
+
+        def items():
+            for n in range(20):
+                pulled.append(n)
+                yield n
+
+        results = ordered_map(lambda n: (n, os.getpid()), items(), jobs=3)
+        self.assertEqual(next(results)[0], 0)
+        self.assertLessEqual(len(pulled), 6)
+        workers = {pid for _n, pid in results}
+        self.assertLessEqual(len(workers), 3)
+        self.assertEqual(set(pid for _n, pid in ordered_map(lambda n: (n, os.getpid()), range(3), jobs=1)),
+                         {os.getpid()})
+
+    def test_processes(self):
+        # CPU-bound work runs outside the calling process; closures are fine, results come back pickled
+        offset = 10
+        results = list(ordered_map(lambda n: (n + offset, os.getpid()), range(8), jobs=2))
+        self.assertEqual([n for n, _pid in results], list(range(10, 18)))
+        if 'fork' in multiprocessing.get_all_start_methods():
+            self.assertNotIn(os.getpid(), {pid for _n, pid in results})
+
+    def test_analyzers_pickle(self):
+        with tempfile.TemporaryDirectory() as directory:
+            path = os.path.join(directory, 'app.nim')
+            with open(path, 'w') as f:
+                f.write('proc main() = discard\n')
+            analyzer = get_analyzer(path)(path)
+            copy = pickle.loads(pickle.dumps(analyzer))
+        self.assertIs(type(copy), type(analyzer))
+        self.assertEqual(copy.lines, analyzer.lines)
+
+    def test_default_jobs(self):
+        self.assertGreaterEqual(default_jobs(), 1)
+
+
+if __name__ == '__main__':
+    unittest.main()
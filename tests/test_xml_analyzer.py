@@ -0,0 +1,91 @@
+"""Tests for XML analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.xml import XmlAnalyzer
+
+
+POM = '''<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <modelVersion>4.0.0</modelVersion>
+  <!-- <ignored/> -->
+  <dependencies>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+    </dependency>
+  </dependencies>
+</project>
+'''
+
+MANIFEST = '''<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.example">
+    <uses-permission android:name="android.permission.INTERNET" />
+    <application android:label="Demo">
+        <activity android:name=".MainActivity" android:exported="true" />
+    </application>
+</manifest>
+'''
+
+
+class TestXmlAnalyzer(unittest.TestCase):
+    """Test XML analyzer."""
+
+    def _analyze(self, content, suffix='.xml'):
+        with tempfile.NamedTemporaryFile(mode='w', suffix=suffix, delete=False) as f:
+            f.write(content)
+        self.addCleanup(os.unlink, f.name)
+        return XmlAnalyzer(f.name)
+
+    def test_default_depth(self):
+        """Three levels by default; cut-off elements count their children."""
+        elements = self._analyze(POM).get_structure()['elements']
+        tree = [(e['name'], e['level'], e['line'], e.get('line_end')) for e in elements]
+        self.assertEqual(tree, [
+            ('project', 1, 2, 16),
+            ('modelVersion', 2, 4, None),
+            ('dependencies', 2, 6, 15),
+            ('dependency', 3, 7, 10),
+            ('dependency', 3, 11, 14),
+        ])
+        self.assertEqual(elements[0]['attributes'], 2)
+        self.assertEqual(elements[1]['signature'], ' = 4.0.0')
+        self.assertEqual(elements[3]['signature'], '  (+2 children)')
+
+    def test_configurable_depth(self):
+        """Deeper levels show leaf text."""
+        elements = self._analyze(POM).get_structure(depth=4)['elements']
+        leaves = [(e['name'], e.get('text')) for e in elements if e['level'] == 4]
+        self.assertEqual(leaves, [('groupId', 'junit'), ('artifactId', 'junit'),
+                                  ('groupId', 'org.slf4j'), ('artifactId', 'slf4j-api')])
+        self.assertEqual(len(self._analyze(POM).get_structure(depth=1)['elements']), 1)
+
+    def test_identifying_attributes(self):
+        """android:name and friends are shown with the attribute count."""
+        elements = self._analyze(MANIFEST).get_structure()['elements']
+        activity = elements[3]
+        self.assertEqual(activity['name'], 'activity')
+        self.assertEqual(activity['signature'], ' android:name=".MainActivity" [2 attrs]')
+        self.assertEqual(elements[2]['signature'], ' [1 attr]')
+
+    def test_malformed(self):
+        """Elements before a parse error are kept."""
+        elements = self._analyze('<root>\n  <a>\n</root>\n').get_structure()['elements']
+        self.assertEqual([e['name'] for e in elements], ['root', 'a'])
+
+    def test_extract_element(self):
+        """Elements are extracted by tag, path or identifying attribute."""
+        analyzer = self._analyze(POM)
+        element = analyzer.extract_element('element', 'dependencies/dependency')
+        self.assertEqual((element['line_start'], element['line_end']), (7, 10))
+        manifest = self._analyze(MANIFEST)
+        self.assertEqual(manifest.extract_element('element', '.MainActivity')['line_start'], 4)
+
+
+if __name__ == '__main__':
+    unittest.main()
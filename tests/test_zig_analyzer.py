@@ -0,0 +1,72 @@
+"""Tests for Zig analyzer."""
+
+import os
+import tempfile
+import unittest
+from reveal.analyzers.zig import ZigAnalyzer
+
+
+MAIN_ZIG = '''const std = @import("std");
+
+pub const Point = struct {
+    x: f32,
+
+    pub fn init(x: f32) Point {
+        return .{ .x = x };
+    }
+};
+
+const Value = union(enum) {
+    int: i64,
+};
+
+const Errors = error{ OutOfMemory, Bad };
+
+comptime {
+    std.debug.assert(true);
+}
+
+export fn add(a: i32, b: i32) i32 {
+    return a + b;
+}
+
+test "point init" {
+    try std.testing.expect(true);
+}
+'''
+
+
+class TestZigAnalyzer(unittest.TestCase):
+    """Test Zig analyzer."""
+
+    def setUp(self):
+        with tempfile.NamedTemporaryFile(mode='w', suffix='.zig', delete=False) as f:
+            f.write(MAIN_ZIG)
+            self.path = f.name
+        self.structure = ZigAnalyzer(self.path).get_structure()
+
+    def tearDown(self):
+        os.unlink(self.path)
+
+    def test_imports_and_containers(self):
+        self.assertEqual(self.structure['imports'][0]['name'], 'std')
+
+        point = self.structure['structs'][0]
+        self.assertEqual((point['name'], point['visibility'], point['line_end']), ('Point', 'pub', 9))
+        self.assertEqual(self.structure['unions'][0]['signature'], ' (enum)')
+        self.assertEqual(self.structure['errors'][0]['members'], ['OutOfMemory', 'Bad'])
+
+    def test_functions(self):
+        functions = {f['name']: f for f in self.structure['functions']}
+
+        self.assertEqual(functions['Point.init']['signature'], '(x: f32) -> Point')
+        self.assertEqual(functions['Point.init']['visibility'], 'pub')
+        self.assertEqual(functions['add']['visibility'], 'export')
+
+    def test_comptime_and_tests(self):
+        self.assertEqual(self.structure['comptime'][0]['line_end'], 19)
+        self.assertEqual(self.structure['tests'][0]['name'], 'point init')
+
+
+if __name__ == '__main__':
+    unittest.main()